@@ -7,7 +7,9 @@ import (
 
 	"github.com/apenella/go-ansible/v2/pkg/execute"
 	"github.com/apenella/go-ansible/v2/pkg/execute/configuration"
+	"github.com/apenella/go-ansible/v2/pkg/execute/credentials"
 	"github.com/apenella/go-ansible/v2/pkg/playbook"
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
 )
 
 func main() {
@@ -17,7 +19,6 @@ func main() {
 		BecomeUser:   "root",
 		ExtraVars: map[string]interface{}{
 			"ansible_ssh_private_key_file": "/ssh/id_rsa",
-			"ansible_sudo_pass":            "12345",
 		},
 		Inventory:     "inventory.yml",
 		SSHCommonArgs: "-o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null",
@@ -25,6 +26,22 @@ func main() {
 		User:          "aleix",
 	}
 
+	// the become password is written to an ephemeral file, only readable by its owner, instead of being set as an extra variable
+	passwordFiles, err := credentials.NewCredentials(
+		credentials.WithBecomePasswordReader(text.NewReadPasswordFromText(text.WithText("12345"))),
+		credentials.WithBinary(playbook.DefaultAnsiblePlaybookBinary),
+	).Write(context.TODO())
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if passwordFiles.BecomePasswordFile != "" {
+		ansiblePlaybookOptions.BecomePasswordFile = passwordFiles.BecomePasswordFile
+	} else {
+		_ = ansiblePlaybookOptions.AddExtraVarsFile(passwordFiles.ExtraVarsFileArg())
+	}
+
 	cmd := playbook.NewAnsiblePlaybookCmd(
 		playbook.WithPlaybooks("site.yml"),
 		playbook.WithPlaybookOptions(ansiblePlaybookOptions),
@@ -40,7 +57,9 @@ func main() {
 
 	fmt.Printf("Executing command: '%s'", cmd.String())
 
-	err := exec.Execute(context.TODO())
+	err = exec.Execute(context.TODO())
+	// the password file is removed once the execution finishes
+	_ = passwordFiles.Close()
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
@@ -23,6 +23,12 @@ type AnsibleAdhocCmd struct {
 	Pattern string
 	// AdhocOptions are the ansible's playbook options
 	AdhocOptions *AnsibleAdhocOptions
+	// SkipValidation disables the adhoc options validation when the command is generated
+	SkipValidation bool
+	// FileValidation enables checking that the files referenced by the options exist when the command is generated
+	FileValidation bool
+	// FileValidationDir is the directory the relative files are resolved against when the files are validated
+	FileValidationDir string
 }
 
 // NewAnsibleAdhocCmd creates a new AnsibleAdhocCmd instance
@@ -57,6 +63,21 @@ func WithAdhocOptions(options *AnsibleAdhocOptions) AnsibleAdhocOptionsFunc {
 	}
 }
 
+// WithoutValidation disables the adhoc options validation
+func WithoutValidation() AnsibleAdhocOptionsFunc {
+	return func(a *AnsibleAdhocCmd) {
+		a.SkipValidation = true
+	}
+}
+
+// WithFileValidation enables checking that the files referenced by the ansible options exist. The relative files are resolved against dir, which is the directory where the command runs, or against the working directory when dir is empty. Do not enable it when the command runs on another host or container
+func WithFileValidation(dir string) AnsibleAdhocOptionsFunc {
+	return func(a *AnsibleAdhocCmd) {
+		a.FileValidation = true
+		a.FileValidationDir = dir
+	}
+}
+
 // Command generate the ansible command which will be executed
 func (a *AnsibleAdhocCmd) Command() ([]string, error) {
 	cmd := []string{}
@@ -74,6 +95,20 @@ func (a *AnsibleAdhocCmd) Command() ([]string, error) {
 
 	// Determine the options to be set
 	if a.AdhocOptions != nil {
+		if !a.SkipValidation {
+			err := a.AdhocOptions.Validate()
+			if err != nil {
+				return nil, errors.New("(adhoc::Command)", "Error validating options", err)
+			}
+		}
+
+		if a.FileValidation {
+			err := a.AdhocOptions.ValidateFiles(a.FileValidationDir)
+			if err != nil {
+				return nil, errors.New("(adhoc::Command)", "Error validating option files", err)
+			}
+		}
+
 		options, err := a.AdhocOptions.GenerateAnsibleAdhocOptions()
 		if err != nil {
 			return nil, errors.New("(adhoc::Command)", "Error creating options", err)
@@ -1,8 +1,13 @@
 package adhoc
 
 import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"syscall"
 	"testing"
 
+	errors "github.com/apenella/go-common-utils/error"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -92,6 +97,59 @@ func TestCommand(t *testing.T) {
 	assert.Equal(t, expected, res)
 }
 
+func TestCommandWithFileValidation(t *testing.T) {
+
+	tests := []struct {
+		desc     string
+		adhocCmd *AnsibleAdhocCmd
+		command  []string
+		err      error
+	}{
+		{
+			desc: "Testing generate ansible adhoc command without validating the option files",
+			adhocCmd: NewAnsibleAdhocCmd(
+				WithPattern("all"),
+				WithAdhocOptions(&AnsibleAdhocOptions{
+					PrivateKey: "missing",
+				}),
+			),
+			command: []string{
+				"ansible",
+				"all",
+				"--private-key=missing",
+			},
+		},
+		{
+			desc: "Testing error generating ansible adhoc command validating the option files",
+			adhocCmd: NewAnsibleAdhocCmd(
+				WithPattern("all"),
+				WithAdhocOptions(&AnsibleAdhocOptions{
+					PrivateKey: "missing",
+				}),
+				WithFileValidation("test"),
+			),
+			err: errors.New("(adhoc::Command)", "Error validating option files",
+				errors.New("(adhoc::ValidateFiles)", "Invalid ansible option files",
+					fmt.Errorf("'%s' file is not valid: %w", PrivateKeyFlag, &fs.PathError{Op: "stat", Path: filepath.Join("test", "missing"), Err: syscall.ENOENT}),
+				),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			command, err := test.adhocCmd.Command()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.command, command)
+			}
+		})
+	}
+}
+
 func TestString(t *testing.T) {
 
 	t.Log("Testing generate ansible adhoc command string")
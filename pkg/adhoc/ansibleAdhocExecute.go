@@ -4,11 +4,22 @@ import (
 	"context"
 
 	"github.com/apenella/go-ansible/v2/pkg/execute"
+	"github.com/apenella/go-ansible/v2/pkg/execute/credentials"
+	"github.com/apenella/go-ansible/v2/pkg/internal/inventoryprovider"
+	"github.com/apenella/go-ansible/v2/pkg/inventory"
+	"github.com/apenella/go-ansible/v2/pkg/inventory/dynamic"
+	"github.com/apenella/go-ansible/v2/pkg/vault/client"
+	errors "github.com/apenella/go-common-utils/error"
 )
 
 // AnsibleAdhocExecute is an executor for ansible command that runs the command using a DefaultExecute with default options
 type AnsibleAdhocExecute struct {
-	cmd *AnsibleAdhocCmd
+	cmd                *AnsibleAdhocCmd
+	credentialsOptions []credentials.OptionsFunc
+	inventory          *inventory.Inventory
+	inventoryFunc      dynamic.InventoryFunc
+	inventorySourceErr error
+	vaultClientOptions []client.OptionsFunc
 }
 
 // NewAnsibleAdhocExecute returns a new AnsibleAdhocExecute. It receives host pattern to be executed
@@ -39,17 +50,174 @@ func (e *AnsibleAdhocExecute) WithAdhocOptions(options *AnsibleAdhocOptions) *An
 	return e
 }
 
+// WithBecomePasswordReader returns an AnsibleAdhocExecute that reads the become password from the reader. The password is provided to ansible through an ephemeral file, only readable by the owner, that is removed once the command finishes
+func (e *AnsibleAdhocExecute) WithBecomePasswordReader(reader credentials.PasswordReader) *AnsibleAdhocExecute {
+	e.credentialsOptions = append(e.credentialsOptions, credentials.WithBecomePasswordReader(reader))
+
+	return e
+}
+
+// WithConnectionPasswordReader returns an AnsibleAdhocExecute that reads the connection password from the reader. The password is provided to ansible through an ephemeral file, only readable by the owner, that is removed once the command finishes
+func (e *AnsibleAdhocExecute) WithConnectionPasswordReader(reader credentials.PasswordReader) *AnsibleAdhocExecute {
+	e.credentialsOptions = append(e.credentialsOptions, credentials.WithConnectionPasswordReader(reader))
+
+	return e
+}
+
+// WithInventory returns an AnsibleAdhocExecute that runs against the in-memory inventory. The inventory is rendered to an ephemeral file, only readable by the owner, that is removed once the command finishes
+func (e *AnsibleAdhocExecute) WithInventory(inventory *inventory.Inventory) *AnsibleAdhocExecute {
+	e.inventory = inventory
+
+	return e
+}
+
+// WithInventoryFunc returns an AnsibleAdhocExecute that runs against the inventory computed by fn. The inventory is served to ansible through a dynamic inventory script, without writing it to disk, and fn is called every time ansible loads the inventory
+func (e *AnsibleAdhocExecute) WithInventoryFunc(fn dynamic.InventoryFunc) *AnsibleAdhocExecute {
+	e.inventoryFunc = fn
+	e.inventorySourceErr = nil
+
+	return e
+}
+
+// WithInventorySource returns an AnsibleAdhocExecute that runs against the inventory provided by the source, such as a Terraform state or an OpenSSH client configuration. The inventory is served to ansible through a dynamic inventory script, as WithInventoryFunc does. Execute returns an error when the source is nil
+func (e *AnsibleAdhocExecute) WithInventorySource(source inventory.InventorySource) *AnsibleAdhocExecute {
+	e.inventoryFunc = nil
+	e.inventorySourceErr = nil
+
+	if source == nil {
+		e.inventorySourceErr = errors.New("(adhoc::AnsibleAdhocExecute::WithInventorySource)", "Inventory source is nil")
+		return e
+	}
+	e.inventoryFunc = source.Inventory
+
+	return e
+}
+
+// WithoutValidation returns an AnsibleAdhocExecute that does not validate the adhoc options when the command is generated
+func (e *AnsibleAdhocExecute) WithoutValidation() *AnsibleAdhocExecute {
+	e.cmd.SkipValidation = true
+
+	return e
+}
+
+// WithVaultPasswordReader returns an AnsibleAdhocExecute that reads the password of the vault id label from the reader. The password is served to ansible through a vault client script, without writing it to disk
+func (e *AnsibleAdhocExecute) WithVaultPasswordReader(label string, reader client.PasswordReader) *AnsibleAdhocExecute {
+	e.vaultClientOptions = append(e.vaultClientOptions, client.WithPasswordReader(label, reader))
+
+	return e
+}
+
 // Execute method runs the ansible command using a DefaultExecute with default options
 func (e *AnsibleAdhocExecute) Execute(ctx context.Context) error {
+	errContext := "(adhoc::AnsibleAdhocExecute::Execute)"
+
+	cmd := e.cmd
 
-	exec := execute.NewDefaultExecute(
-		execute.WithCmd(e.cmd),
-	)
+	if e.inventorySourceErr != nil {
+		return e.inventorySourceErr
+	}
+
+	inventoryOption := ""
+	if cmd.AdhocOptions != nil {
+		inventoryOption = cmd.AdhocOptions.Inventory
+	}
 
-	err := exec.Execute(ctx)
+	inventoryProvider := &inventoryprovider.InventoryProvider{
+		Inventory:     e.inventory,
+		InventoryFunc: e.inventoryFunc,
+	}
+	inventorySource, closeInventory, err := inventoryProvider.Provide(ctx, inventoryOption)
+	if err != nil {
+		return err
+	}
+	defer closeInventory()
+
+	if inventorySource != "" {
+		cmd = cmd.withInventoryFile(inventorySource)
+	}
+
+	exec := execute.NewDefaultExecute()
+
+	if len(e.credentialsOptions) > 0 {
+		binary := e.cmd.Binary
+		if binary == "" {
+			binary = DefaultAnsibleAdhocBinary
+		}
+
+		// the binary version is detected as the executor runs the command
+		options := append([]credentials.OptionsFunc{
+			credentials.WithBinary(binary),
+			credentials.WithExecution(exec.Execution()),
+		}, e.credentialsOptions...)
+		files, err := credentials.NewCredentials(options...).Write(ctx)
+		if err != nil {
+			return errors.New(errContext, "Error providing the become and connection passwords", err)
+		}
+		defer files.Close()
+
+		cmd = cmd.withCredentialFiles(files)
+	}
+
+	exec.Cmd = cmd
+
+	vaultClientOptions := e.vaultPasswordClientOptions()
+	if len(vaultClientOptions) > 0 {
+		return client.NewAnsibleWithVaultPasswordClientExecute(exec, vaultClientOptions...).Execute(ctx)
+	}
+
+	err = exec.Execute(ctx)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// withCredentialFiles returns a copy of the command whose options pass the credential files to ansible
+func (c *AnsibleAdhocCmd) withCredentialFiles(files *credentials.CredentialFiles) *AnsibleAdhocCmd {
+	cmd := *c
+
+	options := &AnsibleAdhocOptions{}
+	if c.AdhocOptions != nil {
+		optionsCopy := *c.AdhocOptions
+		options = &optionsCopy
+	}
+	options.ExtraVarsFile = append([]string{}, options.ExtraVarsFile...)
+
+	if files.BecomePasswordFile != "" {
+		options.BecomePasswordFile = files.BecomePasswordFile
+	}
+
+	if files.ConnectionPasswordFile != "" {
+		options.ConnectionPasswordFile = files.ConnectionPasswordFile
+	}
+
+	if files.ExtraVarsFile != "" {
+		options.ExtraVarsFile = append(options.ExtraVarsFile, files.ExtraVarsFileArg())
+	}
+
+	cmd.AdhocOptions = options
+
+	return &cmd
+}
+
+// withInventoryFile returns a copy of the command whose options pass the inventory file to ansible
+func (c *AnsibleAdhocCmd) withInventoryFile(file string) *AnsibleAdhocCmd {
+	cmd := *c
+
+	options := &AnsibleAdhocOptions{}
+	if c.AdhocOptions != nil {
+		optionsCopy := *c.AdhocOptions
+		options = &optionsCopy
+	}
+	options.Inventory = file
+
+	cmd.AdhocOptions = options
+
+	return &cmd
+}
+
+// vaultPasswordClientOptions returns the options of the vault password client that serves the passwords of the options VaultPasswordReaders and of the executor vault password readers
+func (e *AnsibleAdhocExecute) vaultPasswordClientOptions() []client.OptionsFunc {
+	return append(e.cmd.AdhocOptions.VaultClientOptions(), e.vaultClientOptions...)
+}
@@ -0,0 +1,103 @@
+package adhoc
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/internal/flags/flagstest"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAddFlags tests
+func TestAddFlags(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *AnsibleAdhocOptions
+		prefix  string
+		args    []string
+		res     *AnsibleAdhocOptions
+		err     error
+	}{
+		{
+			desc:    "Testing add flags and parse short flags",
+			options: &AnsibleAdhocOptions{},
+			args:    []string{"-m", "ping", "-a", "data=pong", "-B", "30", "-P", "2", "-o", "-e", "foo=bar"},
+			res: &AnsibleAdhocOptions{
+				Args:       "data=pong",
+				Background: 30,
+				ExtraVars:  map[string]interface{}{"foo": "bar"},
+				ModuleName: "ping",
+				OneLine:    true,
+				Poll:       2,
+			},
+		},
+		{
+			desc:    "Testing add flags with prefix and parse long flags",
+			options: &AnsibleAdhocOptions{},
+			prefix:  "ansible-",
+			args:    []string{"--ansible-module-name=ping", "--ansible-inventory", "127.0.0.1,", "--ansible-verbose", "--ansible-verbose"},
+			res: &AnsibleAdhocOptions{
+				Inventory:  "127.0.0.1,",
+				ModuleName: "ping",
+				VerboseVV:  true,
+			},
+		},
+		{
+			desc:    "Testing add flags with prefix and parse flag aliases",
+			options: &AnsibleAdhocOptions{},
+			prefix:  "ansible-",
+			args:    []string{"--ansible-inventory-file", "127.0.0.1,", "--ansible-become-pass-file=password.txt"},
+			res: &AnsibleAdhocOptions{
+				BecomePasswordFile: "password.txt",
+				Inventory:          "127.0.0.1,",
+			},
+		},
+		{
+			desc:    "Testing add flags with prefix does not register shorthands",
+			options: &AnsibleAdhocOptions{},
+			prefix:  "ansible-",
+			args:    []string{"-m"},
+			err:     fmt.Errorf("unknown shorthand flag: 'm' in -m"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			test.options.AddFlags(fs, test.prefix)
+
+			err := fs.Parse(test.args)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, test.options)
+			}
+		})
+	}
+}
+
+// TestAddFlagsUsages tests that every flag constant is registered with its help text, because the flagUsages entries are maintained by hand
+func TestAddFlagsUsages(t *testing.T) {
+	longFlags, err := flagstest.LongFlags("ansibleAdhocOptions.go", "ansibleAdhocOptionsFlags.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	options := &AnsibleAdhocOptions{}
+	options.AddFlags(fs, "")
+
+	for _, longFlag := range longFlags {
+		flag := fs.Lookup(strings.TrimPrefix(longFlag, "--"))
+		if assert.NotNil(t, flag, "flag %s is not registered", longFlag) {
+			assert.NotEmpty(t, flag.Usage, "flag %s has no help text", longFlag)
+			assert.Equal(t, flagUsages[longFlag], flag.Usage)
+		}
+	}
+}
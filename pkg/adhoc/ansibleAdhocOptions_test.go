@@ -1,8 +1,16 @@
 package adhoc
 
 import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
 	"testing"
 
+	"github.com/apenella/go-ansible/v2/pkg/vault/client"
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
 	errors "github.com/apenella/go-common-utils/error"
 	"github.com/stretchr/testify/assert"
 )
@@ -12,17 +20,19 @@ func TestGenerateAnsibleAdhocOptions(t *testing.T) {
 	t.Log("Testing generate ansible adhoc options")
 
 	options := &AnsibleAdhocOptions{
-		Args:             "args",
-		AskBecomePass:    true,
-		AskPass:          true,
-		AskVaultPassword: true,
-		Background:       11,
-		Become:           true,
-		BecomeMethod:     "become-method",
-		BecomeUser:       "become-user",
-		Check:            true,
-		Connection:       "local",
-		Diff:             true,
+		Args:                   "args",
+		AskBecomePass:          true,
+		AskPass:                true,
+		AskVaultPassword:       true,
+		Background:             11,
+		Become:                 true,
+		BecomeMethod:           "become-method",
+		BecomePasswordFile:     "become-password-file",
+		BecomeUser:             "become-user",
+		Check:                  true,
+		Connection:             "local",
+		ConnectionPasswordFile: "connection-password-file",
+		Diff:                   true,
 		ExtraVars: map[string]interface{}{
 			"var1": "value1",
 			"var2": false,
@@ -79,6 +89,7 @@ func TestGenerateAnsibleAdhocOptions(t *testing.T) {
 		"--version",
 		"--ask-pass",
 		"--connection=local",
+		"--connection-password-file=connection-password-file",
 		"--private-key=pk",
 		"--scp-extra-args=scp-extra-args1 scp-extra-args2",
 		"--sftp-extra-args=sftp-extra-args1 sftp-extra-args2",
@@ -89,6 +100,7 @@ func TestGenerateAnsibleAdhocOptions(t *testing.T) {
 		"--ask-become-pass",
 		"--become",
 		"--become-method=become-method",
+		"--become-password-file=become-password-file",
 		"--become-user=become-user",
 	}
 
@@ -182,17 +194,19 @@ func TestAnsibleAdhocOptionsString(t *testing.T) {
 		{
 			desc: "Testing generate ansible adhoc options string",
 			options: &AnsibleAdhocOptions{
-				Args:             "args",
-				AskBecomePass:    true,
-				AskPass:          true,
-				AskVaultPassword: true,
-				Background:       11,
-				Become:           true,
-				BecomeMethod:     "become-method",
-				BecomeUser:       "become-user",
-				Check:            true,
-				Connection:       "local",
-				Diff:             true,
+				Args:                   "args",
+				AskBecomePass:          true,
+				AskPass:                true,
+				AskVaultPassword:       true,
+				Background:             11,
+				Become:                 true,
+				BecomeMethod:           "become-method",
+				BecomePasswordFile:     "become-password-file",
+				BecomeUser:             "become-user",
+				Check:                  true,
+				Connection:             "local",
+				ConnectionPasswordFile: "connection-password-file",
+				Diff:                   true,
 				ExtraVars: map[string]interface{}{
 					"var1": "value1",
 					"var2": false,
@@ -221,7 +235,7 @@ func TestAnsibleAdhocOptionsString(t *testing.T) {
 				Verbose:           true,
 				Version:           true,
 			},
-			res: " --args='args' --ask-vault-password --background=11 --check --diff --extra-vars='{\"var1\":\"value1\",\"var2\":false}' --extra-vars=@test/ansible/extra_vars.yml --forks=10 --inventory=127.0.0.1, --limit=myhost --list-hosts --module-name=module-name --module-path=/dev/null --one-line --playbook-dir=playbook-dir --poll=12 --syntax-check --tree=tree --vault-id=asdf --vault-password-file=/dev/null -vvvv --version --ask-pass --connection=local --private-key=pk --scp-extra-args='scp-extra-args' --sftp-extra-args='sftp-extra-args' --ssh-common-args='ssh-common-args' --ssh-extra-args='ssh-extra-args' --timeout=10 --user=user --ask-become-pass --become --become-method=become-method --become-user=become-user",
+			res: " --args='args' --ask-vault-password --background=11 --check --diff --extra-vars='{\"var1\":\"value1\",\"var2\":false}' --extra-vars=@test/ansible/extra_vars.yml --forks=10 --inventory=127.0.0.1, --limit=myhost --list-hosts --module-name=module-name --module-path=/dev/null --one-line --playbook-dir=playbook-dir --poll=12 --syntax-check --tree=tree --vault-id=asdf --vault-password-file=/dev/null -vvvv --version --ask-pass --connection=local --connection-password-file=connection-password-file --private-key=pk --scp-extra-args='scp-extra-args' --sftp-extra-args='sftp-extra-args' --ssh-common-args='ssh-common-args' --ssh-extra-args='ssh-extra-args' --timeout=10 --user=user --ask-become-pass --become --become-method=become-method --become-password-file=become-password-file --become-user=become-user",
 		},
 		{
 			desc: "Testing AnsibleAdhocOptions setting the VerboseV flag as true",
@@ -452,3 +466,208 @@ func TestGenerateVerbosityFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate(t *testing.T) {
+
+	tempDir := t.TempDir()
+	privateKey := filepath.Join(tempDir, "id_rsa")
+	err := os.WriteFile(privateKey, []byte("key"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(tempDir, "missing")
+
+	tests := []struct {
+		desc    string
+		options *AnsibleAdhocOptions
+		err     error
+	}{
+		{
+			desc:    "Testing error validating nil AnsibleAdhocOptions",
+			options: nil,
+			err:     errors.New("(adhoc::Validate)", "AnsibleAdhocOptions is nil"),
+		},
+		{
+			desc: "Testing validate AnsibleAdhocOptions",
+			options: &AnsibleAdhocOptions{
+				AskBecomePass: true,
+				Background:    10,
+				Become:        true,
+				BecomeMethod:  "ansible.builtin.su",
+				Connection:    "local",
+				ExtraVarsFile: []string{"@" + privateKey},
+				Poll:          5,
+				PrivateKey:    privateKey,
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing error validating AnsibleAdhocOptions with conflicting flags",
+			options: &AnsibleAdhocOptions{
+				AskPass:   true,
+				Check:     true,
+				ListHosts: true,
+				Poll:      5,
+				VerboseV:  true,
+				VerboseVV: true,
+			},
+			err: errors.New("(adhoc::Validate)", "Invalid ansible options",
+				fmt.Errorf("only one verbosity level can be set, but 2 were set"),
+				fmt.Errorf("'%s' is meaningless without '%s'", PollFlag, BackgroundFlag),
+				fmt.Errorf("'%s' is meaningless with '%s'", CheckFlag, ListHostsFlag),
+			),
+		},
+		{
+			desc: "Testing error validating AnsibleAdhocOptions with invalid values",
+			options: &AnsibleAdhocOptions{
+				BecomeMethod:      "sudo su",
+				Connection:        "ssh://",
+				ExtraVarsFile:     []string{missing},
+				Forks:             "0",
+				PrivateKey:        missing,
+				VaultPasswordFile: missing,
+			},
+			err: errors.New("(adhoc::Validate)", "Invalid ansible options",
+				fmt.Errorf("'%s' must be a positive integer, but '%s' was provided", ForksFlag, "0"),
+				fmt.Errorf("'%s' is not a valid become method. Use a plugin name, such as '%s', or a fully qualified collection name", "sudo su", strings.Join(BecomeMethods, "', '")),
+				fmt.Errorf("'%s' is not a valid connection. Use a plugin name, such as '%s', or a fully qualified collection name", "ssh://", strings.Join(Connections, "', '")),
+			),
+		},
+		{
+			desc: "Testing error validating AnsibleAdhocOptions with password files",
+			options: &AnsibleAdhocOptions{
+				AskBecomePass:          true,
+				AskPass:                true,
+				BecomePasswordFile:     missing,
+				ConnectionPasswordFile: privateKey,
+			},
+			err: errors.New("(adhoc::Validate)", "Invalid ansible options",
+				fmt.Errorf("'%s' and '%s' are mutually exclusive", AskPassFlag, ConnectionPasswordFileFlag),
+				fmt.Errorf("'%s' and '%s' are mutually exclusive", AskBecomePassFlag, BecomePasswordFileFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.Validate()
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+func TestValidateFiles(t *testing.T) {
+
+	tempDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(tempDir, "id_rsa"), []byte("key"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(tempDir, "missing")
+
+	tests := []struct {
+		desc    string
+		options *AnsibleAdhocOptions
+		dir     string
+		err     error
+	}{
+		{
+			desc:    "Testing error validating files of nil AnsibleAdhocOptions",
+			options: nil,
+			err:     errors.New("(adhoc::ValidateFiles)", "AnsibleAdhocOptions is nil"),
+		},
+		{
+			desc: "Testing validate files of AnsibleAdhocOptions resolving the relative files against the directory",
+			options: &AnsibleAdhocOptions{
+				BecomePasswordFile:     "id_rsa",
+				ConnectionPasswordFile: filepath.Join(tempDir, "id_rsa"),
+				ExtraVarsFile:          []string{"id_rsa"},
+				PrivateKey:             "id_rsa",
+				VaultPasswordFile:      "id_rsa",
+			},
+			dir: tempDir,
+			err: nil,
+		},
+		{
+			desc: "Testing error validating files of AnsibleAdhocOptions with invalid files",
+			options: &AnsibleAdhocOptions{
+				BecomePasswordFile:     missing,
+				ConnectionPasswordFile: "missing",
+				ExtraVarsFile:          []string{"" + missing},
+				PrivateKey:             missing,
+				VaultPasswordFile:      tempDir,
+			},
+			dir: tempDir,
+			err: errors.New("(adhoc::ValidateFiles)", "Invalid ansible option files",
+				fmt.Errorf("'%s' file is not valid: %w", PrivateKeyFlag, &fs.PathError{Op: "stat", Path: missing, Err: syscall.ENOENT}),
+				fmt.Errorf("'%s' file is not valid: %w", VaultPasswordFileFlag, fmt.Errorf("%s is a directory", tempDir)),
+				fmt.Errorf("'%s' file is not valid: %w", ConnectionPasswordFileFlag, &fs.PathError{Op: "stat", Path: missing, Err: syscall.ENOENT}),
+				fmt.Errorf("'%s' file is not valid: %w", BecomePasswordFileFlag, &fs.PathError{Op: "stat", Path: missing, Err: syscall.ENOENT}),
+				fmt.Errorf("'%s' file is not valid: %w", ExtraVarsFlag, &fs.PathError{Op: "stat", Path: missing, Err: syscall.ENOENT}),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.ValidateFiles(test.dir)
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+func TestVaultClientOptions(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *AnsibleAdhocOptions
+		labels  []string
+	}{
+		{
+			desc: "Testing vault client options sorted by vault id label",
+			options: &AnsibleAdhocOptions{
+				VaultPasswordReaders: map[string]client.PasswordReader{
+					"prod": text.NewReadPasswordFromText(text.WithText("prod-password")),
+					"dev":  text.NewReadPasswordFromText(text.WithText("dev-password")),
+				},
+			},
+			labels: []string{"dev", "prod"},
+		},
+		{
+			desc:    "Testing vault client options without vault password readers",
+			options: &AnsibleAdhocOptions{},
+		},
+		{
+			desc: "Testing vault client options of nil options",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			options := test.options.VaultClientOptions()
+			assert.Len(t, options, len(test.labels))
+			if len(options) == 0 {
+				return
+			}
+
+			vaultClient := client.NewVaultPasswordClient(options...)
+			err := vaultClient.Start()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer vaultClient.Close()
+
+			labels := []string{}
+			for _, vaultID := range vaultClient.VaultIDs() {
+				label, _, _ := strings.Cut(vaultID, "@")
+				labels = append(labels, label)
+			}
+			assert.Equal(t, test.labels, labels)
+		})
+	}
+}
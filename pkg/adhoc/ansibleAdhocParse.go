@@ -0,0 +1,49 @@
+package adhoc
+
+import (
+	"fmt"
+	"io"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/spf13/pflag"
+)
+
+// ParseAnsibleAdhocCmd creates an AnsibleAdhocCmd from an ansible command line. The first argument is the ansible binary, the flags are parsed into the adhoc options and the remaining argument is the host pattern. It is the inverse of the AnsibleAdhocCmd's Command method
+func ParseAnsibleAdhocCmd(args []string) (*AnsibleAdhocCmd, error) {
+
+	errContext := "(adhoc::ParseAnsibleAdhocCmd)"
+
+	if len(args) == 0 {
+		return nil, errors.New(errContext, "No ansible command line to parse")
+	}
+
+	options := &AnsibleAdhocOptions{}
+
+	fs := pflag.NewFlagSet(args[0], pflag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	options.AddFlags(fs, "")
+
+	err := fs.Parse(args[1:])
+	if err != nil {
+		return nil, errors.New(errContext, "Error parsing ansible command line", err)
+	}
+
+	if fs.NArg() == 0 {
+		return nil, errors.New(errContext, "No host pattern defined")
+	}
+
+	if fs.NArg() > 1 {
+		return nil, errors.New(errContext, fmt.Sprintf("Only one host pattern can be defined, but '%d' were found", fs.NArg()))
+	}
+
+	cmd := NewAnsibleAdhocCmd(
+		WithBinary(args[0]),
+		WithPattern(fs.Arg(0)),
+	)
+
+	if fs.NFlag() > 0 {
+		cmd.AdhocOptions = options
+	}
+
+	return cmd, nil
+}
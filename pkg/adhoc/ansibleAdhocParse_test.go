@@ -0,0 +1,188 @@
+package adhoc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseAnsibleAdhocCmd tests
+func TestParseAnsibleAdhocCmd(t *testing.T) {
+
+	errContext := "(adhoc::ParseAnsibleAdhocCmd)"
+
+	tests := []struct {
+		desc string
+		args []string
+		res  *AnsibleAdhocCmd
+		err  error
+	}{
+		{
+			desc: "Testing parse an ansible command line without arguments",
+			args: []string{},
+			err:  errors.New(errContext, "No ansible command line to parse"),
+		},
+		{
+			desc: "Testing parse an ansible command line without host pattern",
+			args: []string{"ansible", "-m", "ping"},
+			err:  errors.New(errContext, "No host pattern defined"),
+		},
+		{
+			desc: "Testing parse an ansible command line with several host patterns",
+			args: []string{"ansible", "all", "-m", "ping", "localhost"},
+			err:  errors.New(errContext, "Only one host pattern can be defined, but '2' were found"),
+		},
+		{
+			desc: "Testing parse an ansible command line with an unknown flag",
+			args: []string{"ansible", "all", "--unknown"},
+			err:  errors.New(errContext, "Error parsing ansible command line", fmt.Errorf("unknown flag: --unknown")),
+		},
+		{
+			desc: "Testing parse an ansible command line without flags",
+			args: []string{"ansible", "all"},
+			res: &AnsibleAdhocCmd{
+				Binary:  "ansible",
+				Pattern: "all",
+			},
+		},
+		{
+			desc: "Testing parse an ansible command line with short flags",
+			args: []string{"ansible", "all", "-i", "127.0.0.1,", "-m", "shell", "-a", "echo hello", "-B", "60", "-P", "5", "-o", "-t", "/tmp/tree", "-vvvv"},
+			res: &AnsibleAdhocCmd{
+				Binary:  "ansible",
+				Pattern: "all",
+				AdhocOptions: &AnsibleAdhocOptions{
+					Args:       "echo hello",
+					Background: 60,
+					Inventory:  "127.0.0.1,",
+					ModuleName: "shell",
+					OneLine:    true,
+					Poll:       5,
+					Tree:       "/tmp/tree",
+					Verbose:    true,
+				},
+			},
+		},
+		{
+			desc: "Testing parse an ansible command line with long flags, equal signs and repeated extra-vars",
+			args: []string{"ansible", "--module-name=ping", "--inventory", "inventory.ini", "-e", "var1=value1", "--extra-vars={\"var2\":\"value2\"}", "-e", "@vars.yml", "-v", "all"},
+			res: &AnsibleAdhocCmd{
+				Binary:  "ansible",
+				Pattern: "all",
+				AdhocOptions: &AnsibleAdhocOptions{
+					ExtraVars: map[string]interface{}{
+						"var1": "value1",
+						"var2": "value2",
+					},
+					ExtraVarsFile: []string{"@vars.yml"},
+					Inventory:     "inventory.ini",
+					ModuleName:    "ping",
+					VerboseV:      true,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := ParseAnsibleAdhocCmd(test.args)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+// TestParseAnsibleAdhocCmdRoundTrip tests that parsing the command generated by an AnsibleAdhocCmd returns the same AnsibleAdhocCmd
+func TestParseAnsibleAdhocCmdRoundTrip(t *testing.T) {
+	tests := []struct {
+		desc string
+		cmd  *AnsibleAdhocCmd
+	}{
+		{
+			desc: "Testing round trip of an ansible command without options",
+			cmd: NewAnsibleAdhocCmd(
+				WithBinary("ansible"),
+				WithPattern("all"),
+			),
+		},
+		{
+			desc: "Testing round trip of an ansible command with an empty pattern",
+			cmd: NewAnsibleAdhocCmd(
+				WithBinary("ansible"),
+				WithAdhocOptions(&AnsibleAdhocOptions{
+					Version: true,
+				}),
+			),
+		},
+		{
+			desc: "Testing round trip of an ansible command with all options",
+			cmd: NewAnsibleAdhocCmd(
+				WithBinary("custom-ansible"),
+				WithPattern("all"),
+				WithAdhocOptions(&AnsibleAdhocOptions{
+					Args:                   "args",
+					AskBecomePass:          true,
+					AskPass:                true,
+					AskVaultPassword:       true,
+					Background:             11,
+					Become:                 true,
+					BecomeMethod:           "sudo",
+					BecomePasswordFile:     "become-password-file",
+					BecomeUser:             "root",
+					Check:                  true,
+					Connection:             "local",
+					ConnectionPasswordFile: "connection-password-file",
+					Diff:                   true,
+					ExtraVars:              map[string]interface{}{"string": "value", "bool": true, "secret": vault.NewVaultVariableValue("encrypted")},
+					ExtraVarsFile:          []string{"@vars.yml"},
+					Forks:                  "12",
+					Inventory:              "127.0.0.1,",
+					Limit:                  "myhost",
+					ListHosts:              true,
+					ModuleName:             "module-name",
+					ModulePath:             "/dev/null",
+					OneLine:                true,
+					PlaybookDir:            "playbook-dir",
+					Poll:                   13,
+					PrivateKey:             "pk",
+					SCPExtraArgs:           "-o StrictHostKeyChecking=no",
+					SFTPExtraArgs:          "-o StrictHostKeyChecking=no",
+					SSHCommonArgs:          "-o StrictHostKeyChecking=no",
+					SSHExtraArgs:           "-o StrictHostKeyChecking=no",
+					SyntaxCheck:            true,
+					Timeout:                10,
+					Tree:                   "tree",
+					User:                   "apenella",
+					VaultID:                "dev@prompt",
+					VaultPasswordFile:      "/dev/null",
+					Verbose:                true,
+					Version:                true,
+				}),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			test.cmd.SkipValidation = true
+			command, err := test.cmd.Command()
+			assert.NoError(t, err)
+
+			res, err := ParseAnsibleAdhocCmd(command)
+			assert.NoError(t, err)
+
+			res.SkipValidation = true
+			assert.Equal(t, test.cmd, res)
+		})
+	}
+}
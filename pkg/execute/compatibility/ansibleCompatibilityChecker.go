@@ -0,0 +1,142 @@
+package compatibility
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// DefaultAnsibleCompatibilityChecker is the AnsibleCompatibilityChecker used by the DefaultExecute when no other is provided. It skips the check with a warning when the ansible-core version can not be detected, such as when the binary is a wrapper, and then the command runs as before
+var DefaultAnsibleCompatibilityChecker = NewAnsibleCompatibilityChecker(WithSkipUndetectedVersion())
+
+// AnsibleCompatibilityCheckerOptionsFunc is a function to set the AnsibleCompatibilityChecker options
+type AnsibleCompatibilityCheckerOptionsFunc func(*AnsibleCompatibilityChecker)
+
+// AnsibleCompatibilityChecker checks whether the flags and configuration settings of an execution are supported by the ansible-core version in use
+type AnsibleCompatibilityChecker struct {
+	// detector detects the ansible-core version
+	detector VersionDetector
+	// flagRules are the version ranges of the flags
+	flagRules []Rule
+	// settingRules are the version ranges of the configuration settings
+	settingRules []Rule
+	// skipUndetectedVersion reports a warning instead of an error when the version can not be detected
+	skipUndetectedVersion bool
+}
+
+// NewAnsibleCompatibilityChecker creates a new AnsibleCompatibilityChecker. By default, it uses the DefaultAnsibleVersionDetector, the DefaultFlagRules and the DefaultSettingRules
+func NewAnsibleCompatibilityChecker(options ...AnsibleCompatibilityCheckerOptionsFunc) *AnsibleCompatibilityChecker {
+	checker := &AnsibleCompatibilityChecker{
+		detector:     DefaultAnsibleVersionDetector,
+		flagRules:    DefaultFlagRules,
+		settingRules: DefaultSettingRules,
+	}
+
+	for _, option := range options {
+		option(checker)
+	}
+
+	return checker
+}
+
+// WithVersionDetector sets the version detector
+func WithVersionDetector(detector VersionDetector) AnsibleCompatibilityCheckerOptionsFunc {
+	return func(c *AnsibleCompatibilityChecker) {
+		c.detector = detector
+	}
+}
+
+// WithFlagRules sets the version ranges of the flags. They replace the DefaultFlagRules
+func WithFlagRules(rules ...Rule) AnsibleCompatibilityCheckerOptionsFunc {
+	return func(c *AnsibleCompatibilityChecker) {
+		c.flagRules = rules
+	}
+}
+
+// WithSettingRules sets the version ranges of the configuration settings. They replace the DefaultSettingRules
+func WithSettingRules(rules ...Rule) AnsibleCompatibilityCheckerOptionsFunc {
+	return func(c *AnsibleCompatibilityChecker) {
+		c.settingRules = rules
+	}
+}
+
+// WithSkipUndetectedVersion skips the check with a warning, instead of failing, when the ansible-core version can not be detected
+func WithSkipUndetectedVersion() AnsibleCompatibilityCheckerOptionsFunc {
+	return func(c *AnsibleCompatibilityChecker) {
+		c.skipUndetectedVersion = true
+	}
+}
+
+// Check detects the ansible-core version of the command binary, run as the execution describes, and checks the command flags and the configuration settings set as environment variables of the execution. It returns the warnings and an error that wraps all the unsupported flags and settings with error level
+func (c *AnsibleCompatibilityChecker) Check(ctx context.Context, command []string, execution Execution) ([]string, error) {
+	errContext := "(compatibility::AnsibleCompatibilityChecker::Check)"
+
+	if len(command) == 0 {
+		return nil, errors.New(errContext, "Compatibility check requires a command")
+	}
+
+	if c.detector == nil {
+		c.detector = DefaultAnsibleVersionDetector
+	}
+
+	version, err := c.detector.Detect(ctx, execution, command[0])
+	if err != nil {
+		if c.skipUndetectedVersion {
+			return []string{fmt.Sprintf("compatibility not checked, the ansible-core version of '%s' can not be detected", command[0])}, nil
+		}
+		return nil, errors.New(errContext, "Error detecting ansible version", err)
+	}
+
+	warnings := []string{}
+	errs := []error{}
+
+	report := func(rule Rule) {
+		if rule.Supports(version) {
+			return
+		}
+
+		if rule.Level == LevelWarning {
+			warnings = append(warnings, rule.Describe(version))
+			return
+		}
+
+		errs = append(errs, fmt.Errorf("%s", rule.Describe(version)))
+	}
+
+	flags := commandFlags(command[1:])
+	for _, rule := range c.flagRules {
+		if flags[rule.Name] {
+			report(rule)
+		}
+	}
+
+	for _, rule := range c.settingRules {
+		if _, isSet := execution.EnvVars[rule.Name]; isSet {
+			report(rule)
+		}
+	}
+
+	if len(errs) > 0 {
+		return warnings, errors.New(errContext, fmt.Sprintf("Options not supported by ansible-core %s", version.Version), errs...)
+	}
+
+	return warnings, nil
+}
+
+// commandFlags returns the long flags defined on the command arguments
+func commandFlags(args []string) map[string]bool {
+	flags := make(map[string]bool)
+
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "--") || arg == "--" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(arg, "=")
+		flags[name] = true
+	}
+
+	return flags
+}
@@ -0,0 +1,116 @@
+package compatibility
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCheck tests
+func TestCheck(t *testing.T) {
+	errContext := "(compatibility::AnsibleCompatibilityChecker::Check)"
+
+	tests := []struct {
+		desc     string
+		version  string
+		options  []AnsibleCompatibilityCheckerOptionsFunc
+		command  []string
+		envVars  map[string]string
+		warnings []string
+		err      error
+	}{
+		{
+			desc:     "Testing check a command supported by the ansible version",
+			version:  "ansible-playbook [core 2.15.5]\n",
+			command:  []string{"ansible-playbook", "--become-password-file", "become.txt", "site.yml"},
+			envVars:  map[string]string{"ANSIBLE_COW_ACCEPTLIST": "default"},
+			warnings: []string{},
+			err:      &errors.Error{},
+		},
+		{
+			desc:    "Testing check a command with a flag not supported by the ansible version",
+			version: "ansible-playbook [core 2.11.12]\n",
+			command: []string{"ansible-playbook", "--become-password-file=become.txt", "--connection-password-file", "connection.txt", "site.yml"},
+			err: errors.New(errContext, "Options not supported by ansible-core 2.11.12",
+				fmt.Errorf("'--become-password-file' requires ansible-core 2.12 or later, but 2.11.12 is in use"),
+				fmt.Errorf("'--connection-password-file' requires ansible-core 2.12 or later, but 2.11.12 is in use"),
+			),
+		},
+		{
+			desc:    "Testing check a command with configuration settings not supported by the ansible version",
+			version: "ansible-playbook 2.10.17\n",
+			command: []string{"ansible-playbook", "site.yml"},
+			envVars: map[string]string{
+				"ANSIBLE_COW_ACCEPTLIST": "default",
+				"ANSIBLE_FORKS":          "10",
+			},
+			warnings: []string{"'ANSIBLE_COW_ACCEPTLIST' requires ansible-core 2.11 or later, but 2.10.17 is in use"},
+			err:      &errors.Error{},
+		},
+		{
+			desc:    "Testing check a command using custom rules",
+			version: "ansible-galaxy [core 2.16.3]\n",
+			options: []AnsibleCompatibilityCheckerOptionsFunc{
+				WithFlagRules(Rule{Name: "--offline", MaxVersion: "2.15", Level: LevelWarning}),
+				WithSettingRules(Rule{Name: "ANSIBLE_FORKS", MinVersion: "2.17", Level: LevelError}),
+			},
+			command: []string{"ansible-galaxy", "collection", "install", "--offline", "community.general"},
+			envVars: map[string]string{"ANSIBLE_FORKS": "10"},
+			err: errors.New(errContext, "Options not supported by ansible-core 2.16.3",
+				fmt.Errorf("'ANSIBLE_FORKS' requires ansible-core 2.17 or later, but 2.16.3 is in use"),
+			),
+		},
+		{
+			desc:    "Testing error checking a command when the ansible version can not be detected",
+			version: "",
+			command: []string{"ansible-playbook", "site.yml"},
+			err: errors.New(errContext, "Error detecting ansible version",
+				errors.New("(compatibility::AnsibleVersionDetector::Detect)", "Error parsing 'ansible-playbook' version",
+					errors.New("(compatibility::ParseAnsibleVersion)", "Ansible version output is empty"),
+				),
+			),
+		},
+		{
+			desc:     "Testing skip checking a command when the ansible version can not be detected",
+			version:  "",
+			options:  []AnsibleCompatibilityCheckerOptionsFunc{WithSkipUndetectedVersion()},
+			command:  []string{"ansible-playbook", "--become-password-file", "become.txt", "site.yml"},
+			warnings: []string{"compatibility not checked, the ansible-core version of 'ansible-playbook' can not be detected"},
+			err:      &errors.Error{},
+		},
+		{
+			desc:    "Testing error checking an empty command",
+			command: []string{},
+			err:     errors.New(errContext, "Compatibility check requires a command"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			e := exec.NewMockExec()
+			cmd := exec.NewMockCmd()
+			if len(test.command) > 0 {
+				cmd.On("Output").Return([]byte(test.version), nil)
+				e.On("CommandContext", context.TODO(), test.command[0], []string{"--version"}).Return(cmd)
+			}
+
+			options := append([]AnsibleCompatibilityCheckerOptionsFunc{
+				WithVersionDetector(NewAnsibleVersionDetector(WithExecutable(e))),
+			}, test.options...)
+			checker := NewAnsibleCompatibilityChecker(options...)
+
+			warnings, err := checker.Check(context.TODO(), test.command, Execution{EnvVars: test.envVars})
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.warnings, warnings)
+			}
+		})
+	}
+}
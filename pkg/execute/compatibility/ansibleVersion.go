@@ -0,0 +1,134 @@
+package compatibility
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// configFileKey is the key of the configuration file on the --version output
+	configFileKey = "config file"
+	// pythonVersionKey is the key of the python version on the --version output
+	pythonVersionKey = "python version"
+	// noneValue is the value reported on the --version output when an attribute is not set
+	noneValue = "None"
+)
+
+// versionLineRegex matches the first line of the --version output. It supports the 'ansible-playbook [core 2.15.5]' format, used since ansible-core 2.11, and the 'ansible-playbook 2.9.27' format, used by older versions
+var versionLineRegex = regexp.MustCompile(`^\S+\s+(?:\[core\s+)?([0-9][^\s\]]*)\]?`)
+
+// AnsibleVersion holds the details reported by the --version flag of an Ansible binary
+type AnsibleVersion struct {
+	// ConfigFile is the configuration file used by Ansible. It is empty when there is no configuration file
+	ConfigFile string
+	// PythonVersion is the python version used by Ansible
+	PythonVersion string
+	// Version is the ansible-core version
+	Version string
+}
+
+// ParseAnsibleVersion returns the AnsibleVersion described by the --version output of an Ansible binary
+func ParseAnsibleVersion(output string) (*AnsibleVersion, error) {
+	errContext := "(compatibility::ParseAnsibleVersion)"
+
+	version := &AnsibleVersion{}
+	scanner := bufio.NewScanner(strings.NewReader(output))
+
+	if !scanner.Scan() {
+		return nil, errors.New(errContext, "Ansible version output is empty")
+	}
+
+	match := versionLineRegex.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+	if match == nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Ansible version could not be found on '%s'", scanner.Text()))
+	}
+	version.Version = match[1]
+
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), "=")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		switch strings.TrimSpace(key) {
+		case configFileKey:
+			if value != noneValue {
+				version.ConfigFile = value
+			}
+		case pythonVersionKey:
+			// python version line looks like '3.11.6 (main, Oct  2 2023, 13:45:54) [GCC 12.2.0] (/usr/bin/python3)'
+			fields := strings.Fields(value)
+			if len(fields) > 0 {
+				version.PythonVersion = fields[0]
+			}
+		}
+	}
+
+	return version, nil
+}
+
+// AtLeast returns whether the ansible-core version is equal or greater than the version received as argument. Only the components defined on the argument are compared, then version '2.15.5' is at least '2.15'
+func (v *AnsibleVersion) AtLeast(version string) bool {
+	return compareVersions(v.Version, version) >= 0
+}
+
+// AtMost returns whether the ansible-core version is equal or lower than the version received as argument. Only the components defined on the argument are compared, then version '2.15.5' is at most '2.15'
+func (v *AnsibleVersion) AtMost(version string) bool {
+	return compareVersions(v.Version, version) <= 0
+}
+
+// compareVersions compares the version with the reference using the number of components of the reference. It returns -1 when version is lower, 0 when they are equal and 1 when version is greater
+func compareVersions(version, reference string) int {
+	components := versionComponents(version)
+	referenceComponents := versionComponents(reference)
+
+	for i, referenceComponent := range referenceComponents {
+		versionComponent := 0
+		if i < len(components) {
+			versionComponent = components[i]
+		}
+
+		if versionComponent < referenceComponent {
+			return -1
+		}
+		if versionComponent > referenceComponent {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// versionComponents returns the numeric components of a version. Pre-release suffixes, such as 'rc1' on '2.16.0rc1', are ignored
+func versionComponents(version string) []int {
+	components := []int{}
+
+	for _, item := range strings.Split(version, ".") {
+		end := 0
+		for end < len(item) && item[end] >= '0' && item[end] <= '9' {
+			end++
+		}
+
+		if end == 0 {
+			break
+		}
+
+		value, err := strconv.Atoi(item[:end])
+		if err != nil {
+			break
+		}
+		components = append(components, value)
+
+		if end < len(item) {
+			break
+		}
+	}
+
+	return components
+}
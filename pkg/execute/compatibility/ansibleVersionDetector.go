@@ -0,0 +1,131 @@
+package compatibility
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// VersionFlag is the flag used to get the version details from an Ansible binary
+	VersionFlag = "--version"
+)
+
+// DefaultAnsibleVersionDetector is the AnsibleVersionDetector used when no other is provided. It is shared to keep the detected versions across executions
+var DefaultAnsibleVersionDetector = NewAnsibleVersionDetector()
+
+// AnsibleVersionDetectorOptionsFunc is a function to set the AnsibleVersionDetector options
+type AnsibleVersionDetectorOptionsFunc func(*AnsibleVersionDetector)
+
+// AnsibleVersionDetector detects the version of Ansible binaries. The version is detected once per binary, and then it is cached
+type AnsibleVersionDetector struct {
+	// exec is the executable used to run the binary when the execution does not define one
+	exec Executabler
+	// versions is the cache of the detections, by binary, working directory and environment variables
+	versions map[string]*versionDetection
+	// mutex protects the cache. It is not held while the binary runs
+	mutex sync.Mutex
+}
+
+// versionDetection is a detection of a binary version. The done channel is closed once the version or the error is set, so the concurrent detections of the same binary wait for the first one
+type versionDetection struct {
+	done    chan struct{}
+	version *AnsibleVersion
+	err     error
+}
+
+// NewAnsibleVersionDetector creates a new AnsibleVersionDetector
+func NewAnsibleVersionDetector(options ...AnsibleVersionDetectorOptionsFunc) *AnsibleVersionDetector {
+	detector := &AnsibleVersionDetector{
+		versions: make(map[string]*versionDetection),
+	}
+
+	for _, option := range options {
+		option(detector)
+	}
+
+	return detector
+}
+
+// WithExecutable sets the executable used to run the binary when the execution does not define one
+func WithExecutable(executable Executabler) AnsibleVersionDetectorOptionsFunc {
+	return func(d *AnsibleVersionDetector) {
+		d.exec = executable
+	}
+}
+
+// Detect returns the version details of the binary run as the execution describes. It runs the binary with the --version flag the first time, and returns the cached version afterwards. The versions are cached by binary, working directory and environment variables, and the concurrent detections of the same binary wait for the running one. A failed detection is not cached
+func (d *AnsibleVersionDetector) Detect(ctx context.Context, execution Execution, binary string) (*AnsibleVersion, error) {
+
+	envVars := make([]string, 0, len(execution.EnvVars))
+	for name, value := range execution.EnvVars {
+		envVars = append(envVars, fmt.Sprintf("%s=%s", name, value))
+	}
+	sort.Strings(envVars)
+
+	key := strings.Join(append([]string{binary, execution.Dir}, envVars...), "\x00")
+
+	d.mutex.Lock()
+	if d.versions == nil {
+		d.versions = make(map[string]*versionDetection)
+	}
+
+	detection, exists := d.versions[key]
+	if !exists {
+		detection = &versionDetection{done: make(chan struct{})}
+		d.versions[key] = detection
+	}
+	d.mutex.Unlock()
+
+	if exists {
+		select {
+		case <-detection.done:
+			return detection.version, detection.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	detection.version, detection.err = d.detect(ctx, execution, binary, envVars)
+	if detection.err != nil {
+		d.mutex.Lock()
+		delete(d.versions, key)
+		d.mutex.Unlock()
+	}
+	close(detection.done)
+
+	return detection.version, detection.err
+}
+
+// detect runs the binary with the --version flag and parses its output
+func (d *AnsibleVersionDetector) detect(ctx context.Context, execution Execution, binary string, envVars []string) (*AnsibleVersion, error) {
+	errContext := "(compatibility::AnsibleVersionDetector::Detect)"
+
+	executable := execution.Exec
+	if executable == nil {
+		executable = d.exec
+	}
+	if executable == nil {
+		executable = exec.NewOsExec()
+	}
+
+	cmd := executable.CommandContext(ctx, binary, VersionFlag)
+	exec.SetCmdRunDirAndEnv(cmd, execution.Dir, envVars)
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error running '%s %s'", binary, VersionFlag), err)
+	}
+
+	version, err := ParseAnsibleVersion(string(output))
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error parsing '%s' version", binary), err)
+	}
+
+	return version, nil
+}
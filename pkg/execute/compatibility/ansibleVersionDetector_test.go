@@ -0,0 +1,205 @@
+package compatibility
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDetect tests
+func TestDetect(t *testing.T) {
+	errContext := "(compatibility::AnsibleVersionDetector::Detect)"
+
+	tests := []struct {
+		desc              string
+		binary            string
+		version           *AnsibleVersion
+		err               error
+		prepareAssertFunc func(*exec.MockExec, *exec.MockCmd)
+	}{
+		{
+			desc:   "Testing detect the ansible version of a binary",
+			binary: "ansible-playbook",
+			version: &AnsibleVersion{
+				ConfigFile:    "/etc/ansible/ansible.cfg",
+				PythonVersion: "3.11.6",
+				Version:       "2.15.5",
+			},
+			err: &errors.Error{},
+			prepareAssertFunc: func(e *exec.MockExec, cmd *exec.MockCmd) {
+				cmd.On("Output").Return([]byte("ansible-playbook [core 2.15.5]\n  config file = /etc/ansible/ansible.cfg\n  python version = 3.11.6 (main) [GCC 12.2.0]\n"), nil).Once()
+				e.On("CommandContext", context.TODO(), "ansible-playbook", []string{"--version"}).Return(cmd).Once()
+			},
+		},
+		{
+			desc:   "Testing error detecting the ansible version when the binary fails",
+			binary: "ansible-playbook",
+			err:    errors.New(errContext, "Error running 'ansible-playbook --version'", fmt.Errorf("executable file not found")),
+			prepareAssertFunc: func(e *exec.MockExec, cmd *exec.MockCmd) {
+				cmd.On("Output").Return([]byte{}, fmt.Errorf("executable file not found"))
+				e.On("CommandContext", context.TODO(), "ansible-playbook", []string{"--version"}).Return(cmd)
+			},
+		},
+		{
+			desc:   "Testing error detecting the ansible version when the output is unexpected",
+			binary: "ansible-playbook",
+			err: errors.New(errContext, "Error parsing 'ansible-playbook' version",
+				errors.New("(compatibility::ParseAnsibleVersion)", "Ansible version output is empty"),
+			),
+			prepareAssertFunc: func(e *exec.MockExec, cmd *exec.MockCmd) {
+				cmd.On("Output").Return([]byte{}, nil)
+				e.On("CommandContext", context.TODO(), "ansible-playbook", []string{"--version"}).Return(cmd)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			e := exec.NewMockExec()
+			cmd := exec.NewMockCmd()
+			test.prepareAssertFunc(e, cmd)
+
+			detector := NewAnsibleVersionDetector(WithExecutable(e))
+
+			version, err := detector.Detect(context.TODO(), Execution{}, test.binary)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+				return
+			}
+			assert.Equal(t, test.version, version)
+
+			// the second detection is served from the cache, then the binary is run once
+			version, err = detector.Detect(context.TODO(), Execution{}, test.binary)
+			assert.NoError(t, err)
+			assert.Equal(t, test.version, version)
+
+			e.AssertExpectations(t)
+			cmd.AssertExpectations(t)
+		})
+	}
+}
+
+// TestDetectExecution tests that the binary is run as the execution describes and that the versions are cached by execution
+func TestDetectExecution(t *testing.T) {
+	detectorExec := exec.NewMockExec()
+	executionExec := exec.NewMockExec()
+
+	cmd := exec.NewMockCmd()
+	cmd.On("Output").Return([]byte("ansible-playbook [core 2.15.5]\n"), nil).Twice()
+	executionExec.On("CommandContext", context.TODO(), "./venv/bin/ansible-playbook", []string{"--version"}).Return(cmd).Twice()
+
+	detector := NewAnsibleVersionDetector(WithExecutable(detectorExec))
+
+	executions := []Execution{
+		{Exec: executionExec, Dir: "/project", EnvVars: map[string]string{"ANSIBLE_CONFIG": "ansible.cfg"}},
+		{Exec: executionExec, Dir: "/project", EnvVars: map[string]string{"ANSIBLE_CONFIG": "ansible.cfg"}},
+		{Exec: executionExec, Dir: "/other"},
+	}
+
+	for _, execution := range executions {
+		version, err := detector.Detect(context.TODO(), execution, "./venv/bin/ansible-playbook")
+		assert.NoError(t, err)
+		assert.Equal(t, "2.15.5", version.Version)
+	}
+
+	executionExec.AssertExpectations(t)
+	cmd.AssertExpectations(t)
+	detectorExec.AssertNotCalled(t, "CommandContext", context.TODO(), "./venv/bin/ansible-playbook", []string{"--version"})
+}
+
+// TestDetectConcurrently tests that the concurrent detections of a binary run it once, and that they do not block the detections of other binaries
+func TestDetectConcurrently(t *testing.T) {
+	e := exec.NewMockExec()
+	release := make(chan time.Time)
+
+	playbookCmd := exec.NewMockCmd()
+	playbookCmd.On("Output").Return([]byte("ansible-playbook [core 2.15.5]\n"), nil).WaitUntil(release).Once()
+	e.On("CommandContext", context.TODO(), "ansible-playbook", []string{"--version"}).Return(playbookCmd).Once()
+
+	galaxyCmd := exec.NewMockCmd()
+	galaxyCmd.On("Output").Return([]byte("ansible-galaxy [core 2.16.3]\n"), nil).Once()
+	e.On("CommandContext", context.TODO(), "ansible-galaxy", []string{"--version"}).Return(galaxyCmd).Once()
+
+	detector := NewAnsibleVersionDetector(WithExecutable(e))
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			version, err := detector.Detect(context.TODO(), Execution{}, "ansible-playbook")
+			assert.NoError(t, err)
+			assert.Equal(t, "2.15.5", version.Version)
+		}()
+	}
+
+	// the ansible-galaxy detection runs while the ansible-playbook detection is running
+	assert.Eventually(t, func() bool {
+		detector.mutex.Lock()
+		defer detector.mutex.Unlock()
+		return len(detector.versions) == 1
+	}, time.Second, time.Millisecond)
+
+	version, err := detector.Detect(context.TODO(), Execution{}, "ansible-galaxy")
+	assert.NoError(t, err)
+	assert.Equal(t, "2.16.3", version.Version)
+
+	close(release)
+	wg.Wait()
+
+	e.AssertExpectations(t)
+	playbookCmd.AssertExpectations(t)
+	galaxyCmd.AssertExpectations(t)
+}
+
+// TestDetectCanceled tests that a detection waiting for a running one returns when the context is canceled, and that the failed detections are not cached
+func TestDetectCanceled(t *testing.T) {
+	e := exec.NewMockExec()
+	release := make(chan time.Time)
+
+	failedCmd := exec.NewMockCmd()
+	failedCmd.On("Output").Return([]byte{}, fmt.Errorf("timeout")).WaitUntil(release).Once()
+	e.On("CommandContext", context.TODO(), "ansible-playbook", []string{"--version"}).Return(failedCmd).Once()
+
+	detector := NewAnsibleVersionDetector(WithExecutable(e))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := detector.Detect(context.TODO(), Execution{}, "ansible-playbook")
+		assert.Error(t, err)
+	}()
+
+	// wait until the first detection is running
+	assert.Eventually(t, func() bool {
+		detector.mutex.Lock()
+		defer detector.mutex.Unlock()
+		return len(detector.versions) == 1
+	}, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	_, err := detector.Detect(ctx, Execution{}, "ansible-playbook")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	close(release)
+	<-done
+
+	cmd := exec.NewMockCmd()
+	cmd.On("Output").Return([]byte("ansible-playbook [core 2.15.5]\n"), nil).Once()
+	e.On("CommandContext", context.TODO(), "ansible-playbook", []string{"--version"}).Return(cmd).Once()
+
+	version, err := detector.Detect(context.TODO(), Execution{}, "ansible-playbook")
+	assert.NoError(t, err)
+	assert.Equal(t, "2.15.5", version.Version)
+
+	e.AssertExpectations(t)
+}
@@ -0,0 +1,172 @@
+package compatibility
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseAnsibleVersion tests
+func TestParseAnsibleVersion(t *testing.T) {
+	errContext := "(compatibility::ParseAnsibleVersion)"
+
+	tests := []struct {
+		desc    string
+		output  string
+		version *AnsibleVersion
+		err     error
+	}{
+		{
+			desc: "Testing parse the version output of ansible-core",
+			output: `ansible-playbook [core 2.15.5]
+  config file = /etc/ansible/ansible.cfg
+  configured module search path = ['/root/.ansible/plugins/modules', '/usr/share/ansible/plugins/modules']
+  ansible python module location = /usr/lib/python3/dist-packages/ansible
+  ansible collection location = /root/.ansible/collections:/usr/share/ansible/collections
+  executable location = /usr/bin/ansible-playbook
+  python version = 3.11.6 (main, Oct  2 2023, 13:45:54) [GCC 12.2.0] (/usr/bin/python3)
+  jinja version = 3.1.2
+  libyaml = True
+`,
+			version: &AnsibleVersion{
+				ConfigFile:    "/etc/ansible/ansible.cfg",
+				PythonVersion: "3.11.6",
+				Version:       "2.15.5",
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc: "Testing parse the version output of ansible without a configuration file",
+			output: `ansible-playbook 2.9.27
+  config file = None
+  configured module search path = ['/root/.ansible/plugins/modules', '/usr/share/ansible/plugins/modules']
+  ansible python module location = /usr/lib/python3/dist-packages/ansible
+  executable location = /usr/bin/ansible-playbook
+  python version = 3.8.10 (default, Nov 22 2023, 10:22:35) [GCC 9.4.0]
+`,
+			version: &AnsibleVersion{
+				PythonVersion: "3.8.10",
+				Version:       "2.9.27",
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc:   "Testing parse the version output of a pre-release",
+			output: "ansible-galaxy [core 2.17.0rc1]\n",
+			version: &AnsibleVersion{
+				Version: "2.17.0rc1",
+			},
+			err: &errors.Error{},
+		},
+		{
+			desc:   "Testing error parsing an empty version output",
+			output: "",
+			err:    errors.New(errContext, "Ansible version output is empty"),
+		},
+		{
+			desc:   "Testing error parsing an unexpected version output",
+			output: "command not found\n",
+			err:    errors.New(errContext, "Ansible version could not be found on 'command not found'"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			version, err := ParseAnsibleVersion(test.output)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.version, version)
+			}
+		})
+	}
+}
+
+// TestAtLeast tests
+func TestAtLeast(t *testing.T) {
+	tests := []struct {
+		desc      string
+		version   *AnsibleVersion
+		reference string
+		res       bool
+	}{
+		{
+			desc:      "Testing a version greater than the reference",
+			version:   &AnsibleVersion{Version: "2.15.5"},
+			reference: "2.12",
+			res:       true,
+		},
+		{
+			desc:      "Testing a version that matches the reference components",
+			version:   &AnsibleVersion{Version: "2.12.0"},
+			reference: "2.12",
+			res:       true,
+		},
+		{
+			desc:      "Testing a version lower than the reference",
+			version:   &AnsibleVersion{Version: "2.11.12"},
+			reference: "2.12",
+			res:       false,
+		},
+		{
+			desc:      "Testing a pre-release version",
+			version:   &AnsibleVersion{Version: "2.12.0rc1"},
+			reference: "2.12",
+			res:       true,
+		},
+		{
+			desc:      "Testing a version with a minor component greater than 9",
+			version:   &AnsibleVersion{Version: "2.10.17"},
+			reference: "2.9",
+			res:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, test.version.AtLeast(test.reference))
+		})
+	}
+}
+
+// TestAtMost tests
+func TestAtMost(t *testing.T) {
+	tests := []struct {
+		desc      string
+		version   *AnsibleVersion
+		reference string
+		res       bool
+	}{
+		{
+			desc:      "Testing a version lower than the reference",
+			version:   &AnsibleVersion{Version: "2.9.27"},
+			reference: "2.14",
+			res:       true,
+		},
+		{
+			desc:      "Testing a version that matches the reference components",
+			version:   &AnsibleVersion{Version: "2.14.13"},
+			reference: "2.14",
+			res:       true,
+		},
+		{
+			desc:      "Testing a version greater than the reference",
+			version:   &AnsibleVersion{Version: "2.15.0"},
+			reference: "2.14",
+			res:       false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, test.version.AtMost(test.reference))
+		})
+	}
+}
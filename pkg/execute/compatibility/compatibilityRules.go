@@ -0,0 +1,85 @@
+package compatibility
+
+import (
+	"fmt"
+)
+
+const (
+	// LevelError is the level of the rules that fail the execution when they are not satisfied
+	LevelError Level = "error"
+	// LevelWarning is the level of the rules that only warn when they are not satisfied
+	LevelWarning Level = "warning"
+)
+
+// Level is the severity of a rule that is not satisfied
+type Level string
+
+// Rule defines the range of ansible-core versions that support a flag or a configuration setting
+type Rule struct {
+	// Level is the severity when the rule is not satisfied
+	Level Level
+	// MaxVersion is the last ansible-core version that supports the flag or setting. An empty value means that there is no upper limit
+	MaxVersion string
+	// MinVersion is the first ansible-core version that supports the flag or setting. An empty value means that there is no lower limit
+	MinVersion string
+	// Name is the flag or the configuration setting name
+	Name string
+}
+
+// Supports returns whether the version satisfies the rule
+func (r Rule) Supports(version *AnsibleVersion) bool {
+	if r.MinVersion != "" && !version.AtLeast(r.MinVersion) {
+		return false
+	}
+
+	if r.MaxVersion != "" && !version.AtMost(r.MaxVersion) {
+		return false
+	}
+
+	return true
+}
+
+// Describe returns a description of the rule for the version that does not satisfy it
+func (r Rule) Describe(version *AnsibleVersion) string {
+	switch {
+	case r.MinVersion != "" && r.MaxVersion != "":
+		return fmt.Sprintf("'%s' requires ansible-core from %s to %s, but %s is in use", r.Name, r.MinVersion, r.MaxVersion, version.Version)
+	case r.MinVersion != "":
+		return fmt.Sprintf("'%s' requires ansible-core %s or later, but %s is in use", r.Name, r.MinVersion, version.Version)
+	default:
+		return fmt.Sprintf("'%s' is not supported after ansible-core %s, but %s is in use", r.Name, r.MaxVersion, version.Version)
+	}
+}
+
+// DefaultFlagRules are the version ranges of the flags. Ansible refuses to run with an unknown flag, so they are errors
+var DefaultFlagRules = []Rule{
+	{Name: "--ask-vault-password", MinVersion: "2.10", Level: LevelError},
+	{Name: "--become-password-file", MinVersion: "2.12", Level: LevelError},
+	{Name: "--clear-response-cache", MinVersion: "2.12", Level: LevelError},
+	{Name: "--connection-password-file", MinVersion: "2.12", Level: LevelError},
+	{Name: "--disable-gpg-verify", MinVersion: "2.13", Level: LevelError},
+	{Name: "--ignore-signature-status-code", MinVersion: "2.13", Level: LevelError},
+	{Name: "--keyring", MinVersion: "2.13", Level: LevelError},
+	{Name: "--no-cache", MinVersion: "2.12", Level: LevelError},
+	{Name: "--offline", MinVersion: "2.14", Level: LevelError},
+	{Name: "--required-valid-signature-count", MinVersion: "2.13", Level: LevelError},
+	{Name: "--signature", MinVersion: "2.13", Level: LevelError},
+}
+
+// DefaultSettingRules are the version ranges of the configuration settings. Ansible ignores the settings it does not know, so they are warnings. The settings removed from Ansible define a MaxVersion
+var DefaultSettingRules = []Rule{
+	{Name: "ANSIBLE_BECOME_PASSWORD_FILE", MinVersion: "2.12", Level: LevelWarning},
+	{Name: "ANSIBLE_CALLBACKS_ENABLED", MinVersion: "2.11", Level: LevelWarning},
+	{Name: "ANSIBLE_CALLBACK_WHITELIST", MaxVersion: "2.14", Level: LevelWarning},
+	{Name: "ANSIBLE_COLLECTIONS_PATH", MinVersion: "2.10", Level: LevelWarning},
+	{Name: "ANSIBLE_COMMAND_WARNINGS", MaxVersion: "2.13", Level: LevelWarning},
+	{Name: "ANSIBLE_CONNECTION_PASSWORD_FILE", MinVersion: "2.12", Level: LevelWarning},
+	{Name: "ANSIBLE_COW_ACCEPTLIST", MinVersion: "2.11", Level: LevelWarning},
+	{Name: "ANSIBLE_COW_WHITELIST", MaxVersion: "2.14", Level: LevelWarning},
+	{Name: "ANSIBLE_GALAXY_COLLECTIONS_PATH_WARNING", MinVersion: "2.14", Level: LevelWarning},
+	{Name: "ANSIBLE_GALAXY_DISABLE_GPG_VERIFY", MinVersion: "2.13", Level: LevelWarning},
+	{Name: "ANSIBLE_GALAXY_GPG_KEYRING", MinVersion: "2.13", Level: LevelWarning},
+	{Name: "ANSIBLE_GALAXY_IGNORE_SIGNATURE_STATUS_CODES", MinVersion: "2.13", Level: LevelWarning},
+	{Name: "ANSIBLE_GALAXY_REQUIRED_VALID_SIGNATURE_COUNT", MinVersion: "2.13", Level: LevelWarning},
+	{Name: "ANSIBLE_TASK_TIMEOUT", MinVersion: "2.10", Level: LevelWarning},
+}
@@ -0,0 +1,91 @@
+package compatibility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSupports tests
+func TestSupports(t *testing.T) {
+	tests := []struct {
+		desc    string
+		rule    Rule
+		version *AnsibleVersion
+		res     bool
+	}{
+		{
+			desc:    "Testing a version that satisfies the minimum version",
+			rule:    Rule{Name: "ANSIBLE_COW_ACCEPTLIST", MinVersion: "2.11"},
+			version: &AnsibleVersion{Version: "2.11.0"},
+			res:     true,
+		},
+		{
+			desc:    "Testing a version lower than the minimum version",
+			rule:    Rule{Name: "ANSIBLE_COW_ACCEPTLIST", MinVersion: "2.11"},
+			version: &AnsibleVersion{Version: "2.10.17"},
+			res:     false,
+		},
+		{
+			desc:    "Testing a version that satisfies the maximum version",
+			rule:    Rule{Name: "ANSIBLE_COW_WHITELIST", MaxVersion: "2.14"},
+			version: &AnsibleVersion{Version: "2.14.13"},
+			res:     true,
+		},
+		{
+			desc:    "Testing a version greater than the maximum version",
+			rule:    Rule{Name: "ANSIBLE_COW_WHITELIST", MaxVersion: "2.14"},
+			version: &AnsibleVersion{Version: "2.15.0"},
+			res:     false,
+		},
+		{
+			desc:    "Testing a rule without limits",
+			rule:    Rule{Name: "ANSIBLE_FORKS"},
+			version: &AnsibleVersion{Version: "2.9.27"},
+			res:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, test.rule.Supports(test.version))
+		})
+	}
+}
+
+// TestDescribe tests
+func TestDescribe(t *testing.T) {
+	version := &AnsibleVersion{Version: "2.10.17"}
+
+	tests := []struct {
+		desc string
+		rule Rule
+		res  string
+	}{
+		{
+			desc: "Testing describe a rule with a minimum version",
+			rule: Rule{Name: "--become-password-file", MinVersion: "2.12"},
+			res:  "'--become-password-file' requires ansible-core 2.12 or later, but 2.10.17 is in use",
+		},
+		{
+			desc: "Testing describe a rule with a maximum version",
+			rule: Rule{Name: "ANSIBLE_COW_WHITELIST", MaxVersion: "2.9"},
+			res:  "'ANSIBLE_COW_WHITELIST' is not supported after ansible-core 2.9, but 2.10.17 is in use",
+		},
+		{
+			desc: "Testing describe a rule with a minimum and a maximum version",
+			rule: Rule{Name: "--custom", MinVersion: "2.11", MaxVersion: "2.14"},
+			res:  "'--custom' requires ansible-core from 2.11 to 2.14, but 2.10.17 is in use",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, test.rule.Describe(version))
+		})
+	}
+}
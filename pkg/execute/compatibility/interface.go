@@ -0,0 +1,27 @@
+package compatibility
+
+import (
+	"context"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
+)
+
+// Executabler is an interface to run commands
+type Executabler interface {
+	CommandContext(ctx context.Context, name string, arg ...string) exec.Cmder
+}
+
+// VersionDetector detects the version of an Ansible binary
+type VersionDetector interface {
+	Detect(ctx context.Context, execution Execution, binary string) (*AnsibleVersion, error)
+}
+
+// Execution describes how the Ansible binaries are run, which is the executable that runs them, the working directory and the environment variables. The version of a binary may depend on them, for instance when the binary path is relative or it is found through the PATH environment variable
+type Execution struct {
+	// Exec is the executable used to run the binary. The detector's executable is used when it is nil
+	Exec Executabler
+	// Dir is the working directory
+	Dir string
+	// EnvVars are the environment variables, added to the current process ones
+	EnvVars map[string]string
+}
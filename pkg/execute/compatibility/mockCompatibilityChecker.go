@@ -0,0 +1,23 @@
+package compatibility
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCompatibilityChecker is a mock of the CompatibilityChecker interface
+type MockCompatibilityChecker struct {
+	mock.Mock
+}
+
+// NewMockCompatibilityChecker returns a new instance of MockCompatibilityChecker
+func NewMockCompatibilityChecker() *MockCompatibilityChecker {
+	return &MockCompatibilityChecker{}
+}
+
+// Check is a mock
+func (c *MockCompatibilityChecker) Check(ctx context.Context, command []string, execution Execution) ([]string, error) {
+	args := c.Called(ctx, command, execution)
+	return args.Get(0).([]string), args.Error(1)
+}
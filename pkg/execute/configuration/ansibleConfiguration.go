@@ -64,6 +64,9 @@ const (
 	// AnsibleCollectionsOnAnsibleVersionMismatch () When a collection is loaded that does not support the running Ansible version (with the collection metadata key requires_ansible).
 	AnsibleCollectionsOnAnsibleVersionMismatch = "ANSIBLE_COLLECTIONS_ON_ANSIBLE_VERSION_MISMATCH"
 
+	// AnsibleCollectionsPath (pathspec) Colon separated paths in which Ansible will search for collections content. It replaces ANSIBLE_COLLECTIONS_PATHS, which is deprecated. [:Version Added: 2.10]
+	AnsibleCollectionsPath = "ANSIBLE_COLLECTIONS_PATH"
+
 	// AnsibleCollectionsPaths (pathspec) Colon separated paths in which Ansible will search for collections content. Collections must be in nested subdirectories, not directly in these directories. For example, if COLLECTIONS_PATHS includes '{{ ANSIBLE_HOME ~ "/collections" }}', and you want to add my.collection to that directory, it must be saved as '{{ ANSIBLE_HOME} ~ "/collections/ansible_collections/my/collection" }}'.
 	AnsibleCollectionsPaths = "ANSIBLE_COLLECTIONS_PATHS"
 
@@ -0,0 +1,210 @@
+package configuration
+
+// settings is the set of the ansible configuration settings that can be defined by environment variables
+var settings = map[string]struct{}{
+	AnsibleActionWarnings:                      {},
+	AnsibleAgnosticBecomePrompt:                {},
+	AnsibleConnectionPath:                      {},
+	AnsibleCowAcceptlist:                       {},
+	AnsibleCowPath:                             {},
+	AnsibleCowSelection:                        {},
+	AnsibleForceColor:                          {},
+	AnsibleHome:                                {},
+	NoColor:                                    {},
+	AnsibleNocows:                              {},
+	AnsiblePipelining:                          {},
+	AnsibleAnyErrorsFatal:                      {},
+	AnsibleBecomeAllowSameUser:                 {},
+	AnsibleBecomePasswordFile:                  {},
+	AnsibleBecomePlugins:                       {},
+	AnsibleCachePlugin:                         {},
+	AnsibleCachePluginConnection:               {},
+	AnsibleCachePluginPrefix:                   {},
+	AnsibleCachePluginTimeout:                  {},
+	AnsibleCallbacksEnabled:                    {},
+	AnsibleCollectionsOnAnsibleVersionMismatch: {},
+	AnsibleCollectionsPath:                     {},
+	AnsibleCollectionsPaths:                    {},
+	AnsibleCollectionsScanSysPath:              {},
+	AnsibleColorChanged:                        {},
+	AnsibleColorConsolePrompt:                  {},
+	AnsibleColorDebug:                          {},
+	AnsibleColorDeprecate:                      {},
+	AnsibleColorDiffAdd:                        {},
+	AnsibleColorDiffLines:                      {},
+	AnsibleColorDiffRemove:                     {},
+	AnsibleColorError:                          {},
+	AnsibleColorHighlight:                      {},
+	AnsibleColorOk:                             {},
+	AnsibleColorSkip:                           {},
+	AnsibleColorUnreachable:                    {},
+	AnsibleColorVerbose:                        {},
+	AnsibleColorWarn:                           {},
+	AnsibleConnectionPasswordFile:              {},
+	AnsibleCoverageRemoteOutput:                {},
+	AnsibleCoverageRemotePathFilter:            {},
+	AnsibleActionPlugins:                       {},
+	AnsibleAskPass:                             {},
+	AnsibleAskVaultPass:                        {},
+	AnsibleBecome:                              {},
+	AnsibleBecomeAskPass:                       {},
+	AnsibleBecomeExe:                           {},
+	AnsibleBecomeFlags:                         {},
+	AnsibleBecomeMethod:                        {},
+	AnsibleBecomeUser:                          {},
+	AnsibleCachePlugins:                        {},
+	AnsibleCallbackPlugins:                     {},
+	AnsibleCliconfPlugins:                      {},
+	AnsibleConnectionPlugins:                   {},
+	AnsibleDebug:                               {},
+	AnsibleExecutable:                          {},
+	AnsibleFactPath:                            {},
+	AnsibleFilterPlugins:                       {},
+	AnsibleForceHandlers:                       {},
+	AnsibleForks:                               {},
+	AnsibleGatherSubset:                        {},
+	AnsibleGatherTimeout:                       {},
+	AnsibleGathering:                           {},
+	AnsibleHashBehaviour:                       {},
+	AnsibleInventory:                           {},
+	AnsibleHttpapiPlugins:                      {},
+	AnsibleInventoryPlugins:                    {},
+	AnsibleJinja2Extensions:                    {},
+	AnsibleJinja2Native:                        {},
+	AnsibleKeepRemoteFiles:                     {},
+	AnsibleLibvirtLxcNoseclabel:                {},
+	AnsibleLoadCallbackPlugins:                 {},
+	AnsibleLocalTemp:                           {},
+	AnsibleLogFilter:                           {},
+	AnsibleLogPath:                             {},
+	AnsibleLookupPlugins:                       {},
+	AnsibleModuleArgs:                          {},
+	AnsibleLibrary:                             {},
+	AnsibleModuleUtils:                         {},
+	AnsibleNetconfPlugins:                      {},
+	AnsibleNoLog:                               {},
+	AnsibleNoTargetSyslog:                      {},
+	AnsibleNullRepresentation:                  {},
+	AnsiblePollInterval:                        {},
+	AnsiblePrivateKeyFile:                      {},
+	AnsiblePrivateRoleVars:                     {},
+	AnsibleRemotePort:                          {},
+	AnsibleRemoteUser:                          {},
+	AnsibleRolesPath:                           {},
+	AnsibleSelinuxSpecialFs:                    {},
+	AnsibleStdoutCallback:                      {},
+	AnsibleStrategy:                            {},
+	AnsibleStrategyPlugins:                     {},
+	AnsibleSu:                                  {},
+	AnsibleSyslogFacility:                      {},
+	AnsibleTerminalPlugins:                     {},
+	AnsibleTestPlugins:                         {},
+	AnsibleTimeout:                             {},
+	AnsibleTransport:                           {},
+	AnsibleErrorOnUndefinedVars:                {},
+	AnsibleVarsPlugins:                         {},
+	AnsibleVaultEncryptIdentity:                {},
+	AnsibleVaultIdMatch:                        {},
+	AnsibleVaultIdentity:                       {},
+	AnsibleVaultIdentityList:                   {},
+	AnsibleVaultPasswordFile:                   {},
+	AnsibleVerbosity:                           {},
+	AnsibleDeprecationWarnings:                 {},
+	AnsibleDevelWarning:                        {},
+	AnsibleDiffAlways:                          {},
+	AnsibleDiffContext:                         {},
+	AnsibleDisplayArgsToStdout:                 {},
+	AnsibleDisplaySkippedHosts:                 {},
+	AnsibleDocFragmentPlugins:                  {},
+	AnsibleDuplicateYamlDictKey:                {},
+	Editor:                                     {},
+	AnsibleEnableTaskDebugger:                  {},
+	AnsibleErrorOnMissingHandler:               {},
+	AnsibleFactsModules:                        {},
+	AnsibleGalaxyCacheDir:                      {},
+	AnsibleGalaxyCollectionSkeleton:            {},
+	AnsibleGalaxyCollectionSkeletonIgnore:      {},
+	AnsibleGalaxyCollectionsPathWarning:        {},
+	AnsibleGalaxyDisableGpgVerify:              {},
+	AnsibleGalaxyDisplayProgress:               {},
+	AnsibleGalaxyGpgKeyring:                    {},
+	AnsibleGalaxyIgnore:                        {},
+	AnsibleGalaxyIgnoreSignatureStatusCodes:    {},
+	AnsibleGalaxyRequiredValidSignatureCount:   {},
+	AnsibleGalaxyRoleSkeleton:                  {},
+	AnsibleGalaxyRoleSkeletonIgnore:            {},
+	AnsibleGalaxyServer:                        {},
+	AnsibleGalaxyServerList:                    {},
+	AnsibleGalaxyServerTimeout:                 {},
+	AnsibleGalaxyTokenPath:                     {},
+	AnsibleHostKeyChecking:                     {},
+	AnsibleHostPatternMismatch:                 {},
+	AnsibleInjectFactVars:                      {},
+	AnsiblePythonInterpreter:                   {},
+	AnsibleInvalidTaskAttributeFailed:          {},
+	AnsibleInventoryAnyUnparsedIsFailed:        {},
+	AnsibleInventoryCache:                      {},
+	AnsibleInventoryCachePlugin:                {},
+	AnsibleInventoryCacheConnection:            {},
+	AnsibleInventoryCachePluginPrefix:          {},
+	AnsibleInventoryCacheTimeout:               {},
+	AnsibleInventoryEnabled:                    {},
+	AnsibleInventoryExport:                     {},
+	AnsibleInventoryIgnore:                     {},
+	AnsibleInventoryIgnoreRegex:                {},
+	AnsibleInventoryUnparsedFailed:             {},
+	AnsibleInventoryUnparsedWarning:            {},
+	AnsibleJinja2NativeWarning:                 {},
+	AnsibleLocalhostWarning:                    {},
+	AnsibleMaxDiffSize:                         {},
+	AnsibleModuleIgnoreExts:                    {},
+	AnsibleModuleStrictUtf8Response:            {},
+	AnsibleNetconfSshConfig:                    {},
+	AnsibleNetworkGroupModules:                 {},
+	AnsibleOldPluginCacheClear:                 {},
+	Pager:                                      {},
+	AnsibleParamikoHostKeyAutoAdd:              {},
+	AnsibleParamikoLookForKeys:                 {},
+	AnsiblePersistentCommandTimeout:            {},
+	AnsiblePersistentConnectRetryTimeout:       {},
+	AnsiblePersistentConnectTimeout:            {},
+	AnsiblePersistentControlPathDir:            {},
+	AnsiblePlaybookDir:                         {},
+	AnsiblePlaybookVarsRoot:                    {},
+	AnsiblePythonModuleRlimitNofile:            {},
+	AnsibleRetryFilesEnabled:                   {},
+	AnsibleRetryFilesSavePath:                  {},
+	AnsibleRunVarsPlugins:                      {},
+	AnsibleShowCustomStats:                     {},
+	AnsibleStringConversionAction:              {},
+	AnsibleStringTypeFilters:                   {},
+	AnsibleSystemWarnings:                      {},
+	AnsibleRunTags:                             {},
+	AnsibleSkipTags:                            {},
+	AnsibleTaskDebuggerIgnoreErrors:            {},
+	AnsibleTaskTimeout:                         {},
+	AnsibleTransformInvalidGroupChars:          {},
+	AnsibleUsePersistentConnections:            {},
+	AnsibleValidateActionGroupMetadata:         {},
+	AnsibleVarsEnabled:                         {},
+	AnsiblePrecedence:                          {},
+	AnsibleVaultEncryptSalt:                    {},
+	AnsibleVerboseToStderr:                     {},
+	AnsibleWinAsyncStartupTimeout:              {},
+	AnsibleWorkerShutdownPollCount:             {},
+	AnsibleWorkerShutdownPollDelay:             {},
+	AnsibleYamlFilenameExt:                     {},
+}
+
+// IsConfigurationSetting returns whether name is a known ansible configuration setting
+func IsConfigurationSetting(name string) bool {
+	_, exists := settings[name]
+	return exists
+}
+
+// WithConfigurationSetting sets the value for the configuration setting name. It is useful when the configuration settings are only known at runtime, for example when they are loaded from a file
+func WithConfigurationSetting(name, value string) ConfigurationSettingsFunc {
+	return func(e *AnsibleWithConfigurationSettingsExecute) {
+		e.configurationSettings[name] = value
+	}
+}
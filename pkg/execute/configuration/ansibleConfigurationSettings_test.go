@@ -0,0 +1,45 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIsConfigurationSetting tests
+func TestIsConfigurationSetting(t *testing.T) {
+	tests := []struct {
+		desc string
+		name string
+		res  bool
+	}{
+		{
+			desc: "Testing a known configuration setting",
+			name: AnsibleForceColor,
+			res:  true,
+		},
+		{
+			desc: "Testing an unknown configuration setting",
+			name: "ANSIBLE_UNKNOWN_SETTING",
+			res:  false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, IsConfigurationSetting(test.name))
+		})
+	}
+}
+
+// TestWithConfigurationSetting tests the method that sets the value for a configuration setting
+func TestWithConfigurationSetting(t *testing.T) {
+	exec := NewAnsibleWithConfigurationSettingsExecute(nil,
+		WithConfigurationSetting(AnsibleCowAcceptlist, "default,tux"),
+	)
+	setting := exec.configurationSettings[AnsibleCowAcceptlist]
+	expected := "default,tux"
+	assert.Equal(t, setting, expected)
+}
@@ -255,6 +255,13 @@ func WithAnsibleCollectionsOnAnsibleVersionMismatch(value string) ConfigurationS
 	}
 }
 
+// WithAnsibleCollectionsPath sets the value for the configuraion ANSIBLE_COLLECTIONS_PATH (Colon separated paths in which Ansible will search for collections content. It replaces ANSIBLE_COLLECTIONS_PATHS, which is deprecated.)
+func WithAnsibleCollectionsPath(value string) ConfigurationSettingsFunc {
+	return func(e *AnsibleWithConfigurationSettingsExecute) {
+		e.configurationSettings[AnsibleCollectionsPath] = value
+	}
+}
+
 // WithAnsibleCollectionsPaths sets the value for the configuraion ANSIBLE_COLLECTIONS_PATHS (Colon separated paths in which Ansible will search for collections content. Collections must be in nested subdirectories, not directly in these directories. For example, if COLLECTIONS_PATHS includes '{{ ANSIBLE_HOME ~ "/collections" }}', and you want to add my.collection to that directory, it must be saved as '{{ ANSIBLE_HOME} ~ "/collections/ansible_collections/my/collection" }}'.)
 func WithAnsibleCollectionsPaths(value string) ConfigurationSettingsFunc {
 	return func(e *AnsibleWithConfigurationSettingsExecute) {
@@ -305,6 +305,16 @@ func TestWithAnsibleCollectionsOnAnsibleVersionMismatch(t *testing.T) {
 	assert.Equal(t, setting, value)
 }
 
+// TestWithAnsibleCollectionsPath tests the method that sets the value for ANSIBLE_COLLECTIONS_PATH
+func TestWithAnsibleCollectionsPath(t *testing.T) {
+	value := "testvalue"
+	exec := NewAnsibleWithConfigurationSettingsExecute(nil,
+		WithAnsibleCollectionsPath(value),
+	)
+	setting := exec.configurationSettings[AnsibleCollectionsPath]
+	assert.Equal(t, setting, value)
+}
+
 // TestWithAnsibleCollectionsPaths tests the method that sets the value for ANSIBLE_COLLECTIONS_PATHS
 func TestWithAnsibleCollectionsPaths(t *testing.T) {
 	value := "testvalue"
@@ -0,0 +1,45 @@
+package credentials
+
+import (
+	"os"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// CredentialFiles are the ephemeral files that provide the passwords to Ansible. Only the files that must be passed to Ansible are set
+type CredentialFiles struct {
+	// BecomePasswordFile is the file to pass through the --become-password-file flag
+	BecomePasswordFile string
+	// ConnectionPasswordFile is the file to pass through the --connection-password-file flag
+	ConnectionPasswordFile string
+	// ExtraVarsFile is the file to pass through the --extra-vars flag, prefixed by @, when the password files are not supported
+	ExtraVarsFile string
+
+	dir string
+}
+
+// ExtraVarsFileArg returns the ExtraVarsFile as an --extra-vars argument. It is empty when there is no extra vars file
+func (f *CredentialFiles) ExtraVarsFileArg() string {
+	if f == nil || f.ExtraVarsFile == "" {
+		return ""
+	}
+
+	return "@" + f.ExtraVarsFile
+}
+
+// Close removes the ephemeral files. It is safe to call it more than once
+func (f *CredentialFiles) Close() error {
+	errContext := "(credentials::CredentialFiles::Close)"
+
+	if f == nil || f.dir == "" {
+		return nil
+	}
+
+	err := os.RemoveAll(f.dir)
+	if err != nil {
+		return errors.New(errContext, "Error removing the credentials directory", err)
+	}
+	f.dir = ""
+
+	return nil
+}
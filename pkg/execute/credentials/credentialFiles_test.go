@@ -0,0 +1,61 @@
+package credentials
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredentialFilesClose(t *testing.T) {
+	t.Log("Testing close the credential files removes them")
+
+	files, err := NewCredentials(
+		WithBecomePasswordReader(text.NewReadPasswordFromText(text.WithText("secret"))),
+	).Write(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Dir(files.BecomePasswordFile)
+
+	assert.NoError(t, files.Close())
+	assert.NoError(t, files.Close())
+
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestCredentialFilesExtraVarsFileArg(t *testing.T) {
+	tests := []struct {
+		desc  string
+		files *CredentialFiles
+		res   string
+	}{
+		{
+			desc:  "Testing the extra vars argument of the extra vars file",
+			files: &CredentialFiles{ExtraVarsFile: "/tmp/credentials.json"},
+			res:   "@/tmp/credentials.json",
+		},
+		{
+			desc:  "Testing the extra vars argument without extra vars file",
+			files: &CredentialFiles{BecomePasswordFile: "/tmp/become-password"},
+			res:   "",
+		},
+		{
+			desc:  "Testing the extra vars argument of nil credential files",
+			files: nil,
+			res:   "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, test.files.ExtraVarsFileArg())
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/compatibility"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// PasswordFilesMinVersion is the first ansible-core version that supports the --become-password-file and --connection-password-file flags
+	PasswordFilesMinVersion = "2.12"
+
+	// BecomePasswordVar is the variable that holds the become password when the password files are not supported
+	BecomePasswordVar = "ansible_become_password"
+	// ConnectionPasswordVar is the variable that holds the connection password when the password files are not supported
+	ConnectionPasswordVar = "ansible_password"
+
+	// becomePasswordFileName is the name of the become password file
+	becomePasswordFileName = "become-password"
+	// connectionPasswordFileName is the name of the connection password file
+	connectionPasswordFileName = "connection-password"
+	// extraVarsFileName is the name of the extra vars file used when the password files are not supported
+	extraVarsFileName = "credentials.json"
+)
+
+// OptionsFunc is a function used to configure Credentials
+type OptionsFunc func(*Credentials)
+
+// Credentials provides the become and connection passwords read from PasswordReader to ansible and ansible-playbook without prompting. The passwords are written to ephemeral files, only readable by their owner, that are passed through the --become-password-file and --connection-password-file flags. When the ansible-core version does not support those flags, the passwords are passed as variables on an ephemeral extra vars file
+type Credentials struct {
+	becomeReader     PasswordReader
+	binary           string
+	connectionReader PasswordReader
+	detector         compatibility.VersionDetector
+	dir              string
+	execution        compatibility.Execution
+}
+
+// NewCredentials returns a Credentials
+func NewCredentials(options ...OptionsFunc) *Credentials {
+	credentials := &Credentials{}
+	credentials.Options(options...)
+
+	return credentials
+}
+
+// WithBecomePasswordReader sets the reader of the become password
+func WithBecomePasswordReader(reader PasswordReader) OptionsFunc {
+	return func(c *Credentials) {
+		c.becomeReader = reader
+	}
+}
+
+// WithConnectionPasswordReader sets the reader of the connection password
+func WithConnectionPasswordReader(reader PasswordReader) OptionsFunc {
+	return func(c *Credentials) {
+		c.connectionReader = reader
+	}
+}
+
+// WithBinary sets the Ansible binary whose version decides how the passwords are provided
+func WithBinary(binary string) OptionsFunc {
+	return func(c *Credentials) {
+		c.binary = binary
+	}
+}
+
+// WithVersionDetector sets the detector of the Ansible binary version. The compatibility.DefaultAnsibleVersionDetector is used by default
+func WithVersionDetector(detector compatibility.VersionDetector) OptionsFunc {
+	return func(c *Credentials) {
+		c.detector = detector
+	}
+}
+
+// WithExecution sets how the Ansible binary is run to detect its version, which must match how the executor runs the command
+func WithExecution(execution compatibility.Execution) OptionsFunc {
+	return func(c *Credentials) {
+		c.execution = execution
+	}
+}
+
+// WithDir sets the directory where the private directory of the ephemeral files is created. The default temporary directory is used by default
+func WithDir(dir string) OptionsFunc {
+	return func(c *Credentials) {
+		c.dir = dir
+	}
+}
+
+// Options configure the Credentials
+func (c *Credentials) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// Write reads the passwords and writes them to the ephemeral files. The returned CredentialFiles must be closed once the execution finishes to remove the files. When the binary version can not be detected, the password files are used
+func (c *Credentials) Write(ctx context.Context) (files *CredentialFiles, err error) {
+	errContext := "(credentials::Credentials::Write)"
+
+	if c == nil {
+		return nil, errors.New(errContext, "Credentials must be initialized before writing them")
+	}
+
+	if c.becomeReader == nil && c.connectionReader == nil {
+		return nil, errors.New(errContext, "Credentials requires a become or a connection password reader")
+	}
+
+	becomePassword, connectionPassword, err := c.readPasswords()
+	if err != nil {
+		return nil, errors.New(errContext, "Error reading the passwords", err)
+	}
+
+	// os.MkdirTemp creates the directory only accessible by its owner
+	dir, err := os.MkdirTemp(c.dir, "go-ansible-credentials-*")
+	if err != nil {
+		return nil, errors.New(errContext, "Error creating the credentials directory", err)
+	}
+
+	files = &CredentialFiles{
+		dir: dir,
+	}
+	defer func() {
+		if err != nil {
+			_ = files.Close()
+			files = nil
+		}
+	}()
+
+	if !c.supportsPasswordFiles(ctx) {
+		vars := map[string]string{}
+		if c.becomeReader != nil {
+			vars[BecomePasswordVar] = becomePassword
+		}
+		if c.connectionReader != nil {
+			vars[ConnectionPasswordVar] = connectionPassword
+		}
+
+		content, err := json.Marshal(vars)
+		if err != nil {
+			return nil, errors.New(errContext, "Error marshaling the credentials extra vars", err)
+		}
+
+		files.ExtraVarsFile, err = writeFile(dir, extraVarsFileName, string(content))
+		if err != nil {
+			return nil, errors.New(errContext, "Error writing the credentials extra vars file", err)
+		}
+
+		return files, nil
+	}
+
+	if c.becomeReader != nil {
+		files.BecomePasswordFile, err = writeFile(dir, becomePasswordFileName, becomePassword)
+		if err != nil {
+			return nil, errors.New(errContext, "Error writing the become password file", err)
+		}
+	}
+
+	if c.connectionReader != nil {
+		files.ConnectionPasswordFile, err = writeFile(dir, connectionPasswordFileName, connectionPassword)
+		if err != nil {
+			return nil, errors.New(errContext, "Error writing the connection password file", err)
+		}
+	}
+
+	return files, nil
+}
+
+// readPasswords returns the become and connection passwords
+func (c *Credentials) readPasswords() (string, string, error) {
+	var becomePassword, connectionPassword string
+	var err error
+
+	if c.becomeReader != nil {
+		becomePassword, err = c.becomeReader.Read()
+		if err != nil {
+			return "", "", fmt.Errorf("become password could not be read: %w", err)
+		}
+	}
+
+	if c.connectionReader != nil {
+		connectionPassword, err = c.connectionReader.Read()
+		if err != nil {
+			return "", "", fmt.Errorf("connection password could not be read: %w", err)
+		}
+	}
+
+	return becomePassword, connectionPassword, nil
+}
+
+// supportsPasswordFiles returns whether the binary supports the password files flags
+func (c *Credentials) supportsPasswordFiles(ctx context.Context) bool {
+	if c.binary == "" {
+		return true
+	}
+
+	detector := c.detector
+	if detector == nil {
+		detector = compatibility.DefaultAnsibleVersionDetector
+	}
+
+	version, err := detector.Detect(ctx, c.execution, c.binary)
+	if err != nil {
+		return true
+	}
+
+	return version.AtLeast(PasswordFilesMinVersion)
+}
+
+// writeFile writes the content to a new file, only readable by its owner, and returns its path
+func writeFile(dir, name, content string) (string, error) {
+	path := filepath.Join(dir, name)
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(content)
+	if err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
@@ -0,0 +1,166 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/compatibility"
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/mock"
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
+	"github.com/stretchr/testify/assert"
+)
+
+// versionDetector is a VersionDetector that returns a fixed version, when the binary runs on the expected directory
+type versionDetector struct {
+	version string
+	dir     string
+	err     error
+}
+
+func (d *versionDetector) Detect(ctx context.Context, execution compatibility.Execution, binary string) (*compatibility.AnsibleVersion, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	if execution.Dir != d.dir {
+		return nil, fmt.Errorf("unexpected directory '%s'", execution.Dir)
+	}
+
+	return &compatibility.AnsibleVersion{Version: d.version}, nil
+}
+
+func readFile(t *testing.T, file string) string {
+	if file == "" {
+		return ""
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(content)
+}
+
+func TestCredentialsWrite(t *testing.T) {
+	become := text.NewReadPasswordFromText(text.WithText("become-secret"))
+	connection := text.NewReadPasswordFromText(text.WithText("connection-secret"))
+
+	failingReader := mock.NewMockReadPassword()
+	failingReader.On("Read").Return("", fmt.Errorf("reader error"))
+
+	tests := []struct {
+		desc               string
+		credentials        *Credentials
+		becomePassword     string
+		connectionPassword string
+		extraVars          string
+		err                string
+	}{
+		{
+			desc: "Testing write the become and connection password files",
+			credentials: NewCredentials(
+				WithBecomePasswordReader(become),
+				WithConnectionPasswordReader(connection),
+				WithBinary("ansible-playbook"),
+				WithVersionDetector(&versionDetector{version: "2.15.5"}),
+			),
+			becomePassword:     "become-secret",
+			connectionPassword: "connection-secret",
+		},
+		{
+			desc: "Testing write the become password file when the version can not be detected",
+			credentials: NewCredentials(
+				WithBecomePasswordReader(become),
+				WithBinary("ansible-playbook"),
+				WithVersionDetector(&versionDetector{err: fmt.Errorf("detect error")}),
+			),
+			becomePassword: "become-secret",
+		},
+		{
+			desc: "Testing write the connection password file without binary",
+			credentials: NewCredentials(
+				WithConnectionPasswordReader(connection),
+			),
+			connectionPassword: "connection-secret",
+		},
+		{
+			desc: "Testing write the extra vars file for ansible-core versions without password files",
+			credentials: NewCredentials(
+				WithBecomePasswordReader(become),
+				WithConnectionPasswordReader(connection),
+				WithBinary("ansible-playbook"),
+				WithVersionDetector(&versionDetector{version: "2.11.12"}),
+			),
+			extraVars: `{"ansible_become_password":"become-secret","ansible_password":"connection-secret"}`,
+		},
+		{
+			desc: "Testing write the extra vars file detecting the version as the execution runs the binary",
+			credentials: NewCredentials(
+				WithBecomePasswordReader(become),
+				WithBinary("ansible-playbook"),
+				WithExecution(compatibility.Execution{Dir: "/opt/ansible"}),
+				WithVersionDetector(&versionDetector{version: "2.11.12", dir: "/opt/ansible"}),
+			),
+			extraVars: `{"ansible_become_password":"become-secret"}`,
+		},
+		{
+			desc:        "Testing error writing credentials without password readers",
+			credentials: NewCredentials(),
+			err:         "Credentials requires a become or a connection password reader",
+		},
+		{
+			desc: "Testing error writing credentials when a password can not be read",
+			credentials: NewCredentials(
+				WithBecomePasswordReader(become),
+				WithConnectionPasswordReader(failingReader),
+			),
+			err: "Error reading the passwords",
+		},
+		{
+			desc: "Testing error writing credentials on a directory that does not exist",
+			credentials: NewCredentials(
+				WithBecomePasswordReader(become),
+				WithDir(filepath.Join(t.TempDir(), "unknown")),
+			),
+			err: "Error creating the credentials directory",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			files, err := test.credentials.Write(context.TODO())
+			if err != nil {
+				assert.Contains(t, err.Error(), test.err)
+				assert.Nil(t, files)
+				return
+			}
+
+			assert.Empty(t, test.err)
+			defer files.Close()
+
+			assert.Equal(t, test.becomePassword, readFile(t, files.BecomePasswordFile))
+			assert.Equal(t, test.connectionPassword, readFile(t, files.ConnectionPasswordFile))
+			assert.Equal(t, test.extraVars, readFile(t, files.ExtraVarsFile))
+
+			for _, file := range []string{files.BecomePasswordFile, files.ConnectionPasswordFile, files.ExtraVarsFile} {
+				if file == "" {
+					continue
+				}
+
+				info, err := os.Stat(file)
+				assert.NoError(t, err)
+				assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+				info, err = os.Stat(filepath.Dir(file))
+				assert.NoError(t, err)
+				assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+			}
+		})
+	}
+}
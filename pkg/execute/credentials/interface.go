@@ -0,0 +1,6 @@
+package credentials
+
+// PasswordReader is the interface to read the become and connection passwords
+type PasswordReader interface {
+	Read() (string, error)
+}
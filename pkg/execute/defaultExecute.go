@@ -8,6 +8,7 @@ import (
 	osexec "os/exec"
 	"strings"
 
+	"github.com/apenella/go-ansible/v2/pkg/execute/compatibility"
 	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
 	"github.com/apenella/go-ansible/v2/pkg/execute/result"
 	defaultresults "github.com/apenella/go-ansible/v2/pkg/execute/result/default"
@@ -66,6 +67,8 @@ type DefaultExecute struct {
 	Cmd Commander
 	// CmdRunDir specifies the working directory of the command.
 	CmdRunDir string
+	// CompatibilityChecker checks the command against the Ansible version in use before running it. The command is not checked when it is nil
+	CompatibilityChecker CompatibilityChecker
 	// EnvVars specifies env vars of the command.
 	EnvVars EnvVars
 	// ErrContext is the error context
@@ -87,10 +90,11 @@ type DefaultExecute struct {
 // Ensure DefaultExecute implements the Executor interface
 var _ = Executor(&DefaultExecute{})
 
-// NewDefaultExecute return a new DefaultExecute instance with all options
+// NewDefaultExecute return a new DefaultExecute instance with all options. The command is checked against the Ansible version in use with the DefaultAnsibleCompatibilityChecker, unless another checker is set or the check is disabled
 func NewDefaultExecute(options ...ExecuteOptions) *DefaultExecute {
 	execute := &DefaultExecute{
-		EnvVars: make(map[string]string),
+		CompatibilityChecker: compatibility.DefaultAnsibleCompatibilityChecker,
+		EnvVars:              make(map[string]string),
 	}
 
 	for _, opt := range options {
@@ -114,6 +118,13 @@ func (e *DefaultExecute) AddEnvVar(key, value string) {
 	e.EnvVars[key] = value
 }
 
+// EnvVar returns the value of the provided environment variable and whether it is set on the executor
+func (e *DefaultExecute) EnvVar(key string) (string, bool) {
+	value, exists := e.EnvVars[key]
+
+	return value, exists
+}
+
 // AddEnvVarSafe add the provided environment variable. It returns an error when the variable already exists
 func (e *DefaultExecute) AddEnvVarSafe(key, value string) error {
 
@@ -132,6 +143,20 @@ func (e *DefaultExecute) AddEnvVarSafe(key, value string) error {
 	return nil
 }
 
+// Execution returns how the executor runs the commands, which is the executable, the working directory and the environment variables. It is used to detect the Ansible version of the binary as the command would run it
+func (e *DefaultExecute) Execution() compatibility.Execution {
+	executable := e.Exec
+	if executable == nil {
+		executable = exec.NewOsExec()
+	}
+
+	return compatibility.Execution{
+		Exec:    executable,
+		Dir:     e.CmdRunDir,
+		EnvVars: e.EnvVars,
+	}
+}
+
 // Quiet sets the executor in quiet mode
 func (e *DefaultExecute) Quiet() {
 	e.quiet = true
@@ -165,8 +190,6 @@ func (e *DefaultExecute) Execute(ctx context.Context) (err error) {
 	var cmdStderr, cmdStdout io.ReadCloser
 	errContext := "(execute::DefaultExecute::Execute)"
 
-	defer e.checkCompatibility()
-
 	// default stdout and stderr for the main process
 	if e.Write == nil {
 		e.Write = os.Stdout
@@ -196,19 +219,18 @@ func (e *DefaultExecute) Execute(ctx context.Context) (err error) {
 		}
 	}
 
+	err = e.checkCompatibility(ctx, command)
+	if err != nil {
+		return errors.New(errContext, "Error checking command compatibility", err)
+	}
+
 	cmd := e.Exec.CommandContext(ctx, command[0], command[1:]...)
 
+	exec.SetCmdRunDirAndEnv(cmd, e.CmdRunDir, e.EnvVars.Environ())
+
 	// Assert if cmd's type is the Golang's exec.Cmd as set the desired values for that case
 	_, isOsExecCmd := cmd.(*osexec.Cmd)
 	if isOsExecCmd {
-		if len(e.CmdRunDir) > 0 {
-			cmd.(*osexec.Cmd).Dir = e.CmdRunDir
-		}
-
-		if len(e.EnvVars) > 0 {
-			cmd.(*osexec.Cmd).Env = append(os.Environ(), e.EnvVars.Environ()...)
-		}
-
 		// connects the main process' stdin to ansible's stdin
 		cmd.(*osexec.Cmd).Stdin = os.Stdin
 	}
@@ -289,4 +311,17 @@ func (e *DefaultExecute) Execute(ctx context.Context) (err error) {
 	return nil
 }
 
-func (e *DefaultExecute) checkCompatibility() {}
+// checkCompatibility checks the command and the environment variables using the CompatibilityChecker, when it is defined. The warnings are written to the error writer
+func (e *DefaultExecute) checkCompatibility(ctx context.Context, command []string) error {
+
+	if e.CompatibilityChecker == nil {
+		return nil
+	}
+
+	warnings, err := e.CompatibilityChecker.Check(ctx, command, e.Execution())
+	for _, warning := range warnings {
+		_, _ = fmt.Fprintf(e.WriterError, "[WARNING]: %s\n", warning)
+	}
+
+	return err
+}
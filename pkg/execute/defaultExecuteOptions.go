@@ -17,6 +17,20 @@ func WithCmd(cmd Commander) ExecuteOptions {
 	}
 }
 
+// WithCompatibilityChecker sets the checker used to verify the command against the Ansible version in use. It replaces the DefaultAnsibleCompatibilityChecker
+func WithCompatibilityChecker(checker CompatibilityChecker) ExecuteOptions {
+	return func(e *DefaultExecute) {
+		e.CompatibilityChecker = checker
+	}
+}
+
+// WithoutCompatibilityCheck disables the check of the command against the Ansible version in use
+func WithoutCompatibilityCheck() ExecuteOptions {
+	return func(e *DefaultExecute) {
+		e.CompatibilityChecker = nil
+	}
+}
+
 // WithExecutable set the execuctable parameter
 func WithExecutable(executable Executabler) ExecuteOptions {
 	return func(e *DefaultExecute) {
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/apenella/go-ansible/v2/mocks"
+	"github.com/apenella/go-ansible/v2/pkg/execute/compatibility"
 	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
 	defaultresults "github.com/apenella/go-ansible/v2/pkg/execute/result/default"
 	"github.com/apenella/go-ansible/v2/pkg/execute/result/transformer"
@@ -22,6 +23,26 @@ func TestOptionsWithCmd(t *testing.T) {
 	assert.Equal(t, execute.Cmd, cmd)
 }
 
+// TestOptionsWithCompatibilityChecker tests the function WithCompatibilityChecker
+func TestOptionsWithCompatibilityChecker(t *testing.T) {
+	checker := compatibility.NewMockCompatibilityChecker()
+
+	execute := NewDefaultExecute(
+		WithCompatibilityChecker(checker),
+	)
+
+	assert.Equal(t, execute.CompatibilityChecker, checker)
+}
+
+// TestOptionsWithoutCompatibilityCheck tests the function WithoutCompatibilityCheck
+func TestOptionsWithoutCompatibilityCheck(t *testing.T) {
+	execute := NewDefaultExecute(
+		WithoutCompatibilityCheck(),
+	)
+
+	assert.Nil(t, execute.CompatibilityChecker)
+}
+
 // TestOptionsWithExecutable tests the function WithExecutable
 func TestOptionsWithExecutable(t *testing.T) {
 	e := exec.NewOsExec()
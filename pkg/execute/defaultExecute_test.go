@@ -3,16 +3,19 @@ package execute
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"os"
 	"testing"
 
 	"github.com/apenella/go-ansible/v2/mocks"
+	"github.com/apenella/go-ansible/v2/pkg/execute/compatibility"
 	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
 	defaultresults "github.com/apenella/go-ansible/v2/pkg/execute/result/default"
 	"github.com/apenella/go-ansible/v2/pkg/execute/result/transformer"
 	errors "github.com/apenella/go-common-utils/error"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestNewDefaultExecute(t *testing.T) {
@@ -37,6 +40,7 @@ func TestNewDefaultExecute(t *testing.T) {
 	assert.Equal(t, runDir, exe.CmdRunDir, "CmdRunDir does not match")
 	assert.Equal(t, wr, exe.Write, "Write does not match")
 	assert.Equal(t, wr, exe.WriterError, "WriteError does not match")
+	assert.Equal(t, compatibility.DefaultAnsibleCompatibilityChecker, exe.CompatibilityChecker, "CompatibilityChecker does not match")
 }
 
 func TestExecute(t *testing.T) {
@@ -44,6 +48,8 @@ func TestExecute(t *testing.T) {
 	var cmdRead bytes.Buffer
 
 	errContext := "(execute::DefaultExecute::Execute)"
+	compatibilityExec := exec.NewMockExec()
+	compatibilityChecker := compatibility.NewMockCompatibilityChecker()
 
 	tests := []struct {
 		desc              string
@@ -67,6 +73,7 @@ func TestExecute(t *testing.T) {
 			exec: exec.NewMockCmd(),
 			execute: NewDefaultExecute(
 				WithExecutable(exec.NewMockExec()),
+				WithoutCompatibilityCheck(),
 				WithWrite(io.Writer(&stdout)),
 				WithWriteError(io.Writer(&stderr)),
 				WithCmd(
@@ -127,6 +134,67 @@ func TestExecute(t *testing.T) {
 				e.AssertExpectations(t)
 			},
 		},
+		{
+			desc: "Testing error executing a command that is not compatible with the ansible version",
+			err: errors.New(errContext, "Error checking command compatibility",
+				errors.New("(compatibility::AnsibleCompatibilityChecker::Check)", "Options not supported by ansible-core 2.11.12",
+					fmt.Errorf("'--become-password-file' requires ansible-core 2.12 or later, but 2.11.12 is in use"),
+				),
+			),
+			exec: exec.NewMockCmd(),
+			execute: NewDefaultExecute(
+				WithExecutable(compatibilityExec),
+				WithWrite(io.Writer(&stdout)),
+				WithWriteError(io.Writer(&stderr)),
+				WithCmd(
+					mocks.NewMockAnsibleCmd([]string{"ansible-playbook", "--become-password-file", "become.txt", "../../test/test_site.yml"}, nil),
+				),
+				WithCompatibilityChecker(compatibility.NewAnsibleCompatibilityChecker(
+					// the detector runs the binary with the executor's executable
+					compatibility.WithVersionDetector(compatibility.NewAnsibleVersionDetector()),
+				)),
+			),
+			prepareAssertFunc: func(e *exec.MockExec, cmd *exec.MockCmd) {
+				cmd.On("Output").Return([]byte("ansible-playbook [core 2.11.12]\n  config file = None\n"), nil)
+
+				e.On("CommandContext", context.TODO(), "ansible-playbook", []string{"--version"}).Return(cmd)
+			},
+			assertFunc: func(e *exec.MockExec, cmd *exec.MockCmd) {
+				cmd.AssertExpectations(t)
+				e.AssertExpectations(t)
+				e.AssertNotCalled(t, "CommandContext", context.TODO(), "ansible-playbook", []string{"--become-password-file", "become.txt", "../../test/test_site.yml"})
+			},
+		},
+		{
+			desc: "Testing execute a command reporting the compatibility warnings",
+			err:  &errors.Error{},
+			exec: exec.NewMockCmd(),
+			execute: NewDefaultExecute(
+				WithExecutable(exec.NewMockExec()),
+				WithWrite(io.Writer(&stdout)),
+				WithWriteError(io.Writer(&stderr)),
+				WithCmd(
+					mocks.NewMockAnsibleCmd([]string{"ansible-playbook", "--connection", "local", "../../test/test_site.yml"}, nil),
+				),
+				WithCompatibilityChecker(compatibilityChecker),
+			),
+			prepareAssertFunc: func(e *exec.MockExec, cmd *exec.MockCmd) {
+				cmd.On("StdoutPipe").Return(io.NopCloser(io.Reader(&cmdRead)), nil)
+				cmd.On("StderrPipe").Return(io.NopCloser(io.Reader(&cmdRead)), nil)
+				cmd.On("Start").Return(nil)
+				cmd.On("Wait").Return(nil)
+
+				e.On("CommandContext", context.TODO(), "ansible-playbook", []string{"--connection", "local", "../../test/test_site.yml"}).Return(cmd)
+
+				compatibilityChecker.On("Check", context.TODO(), []string{"ansible-playbook", "--connection", "local", "../../test/test_site.yml"}, mock.Anything).Return([]string{"'ANSIBLE_COW_ACCEPTLIST' requires ansible-core 2.11 or later, but 2.10.17 is in use"}, nil)
+			},
+			assertFunc: func(e *exec.MockExec, cmd *exec.MockCmd) {
+				cmd.AssertExpectations(t)
+				e.AssertExpectations(t)
+				compatibilityChecker.AssertExpectations(t)
+				assert.Equal(t, "[WARNING]: 'ANSIBLE_COW_ACCEPTLIST' requires ansible-core 2.11 or later, but 2.10.17 is in use\n", stderr.String())
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -2,6 +2,7 @@ package exec
 
 import (
 	"context"
+	"os"
 	"os/exec"
 )
 
@@ -21,3 +22,19 @@ func (e *OsExec) Command(name string, arg ...string) Cmder {
 func (e *OsExec) CommandContext(ctx context.Context, name string, arg ...string) Cmder {
 	return exec.CommandContext(ctx, name, arg...)
 }
+
+// SetCmdRunDirAndEnv sets the working directory and the environment variables of the command, when it is a Golang's exec.Cmd. The environment variables are added to the current process ones
+func SetCmdRunDirAndEnv(cmd Cmder, dir string, envVars []string) {
+	osExecCmd, isOsExecCmd := cmd.(*exec.Cmd)
+	if !isOsExecCmd {
+		return
+	}
+
+	if len(dir) > 0 {
+		osExecCmd.Dir = dir
+	}
+
+	if len(envVars) > 0 {
+		osExecCmd.Env = append(os.Environ(), envVars...)
+	}
+}
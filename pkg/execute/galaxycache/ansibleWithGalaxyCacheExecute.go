@@ -0,0 +1,431 @@
+package galaxycache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+	"github.com/apenella/go-ansible/v2/pkg/execute/configuration"
+	galaxycollectioninstall "github.com/apenella/go-ansible/v2/pkg/galaxy/collection/install"
+	galaxyrequirements "github.com/apenella/go-ansible/v2/pkg/galaxy/requirements"
+	galaxyroleinstall "github.com/apenella/go-ansible/v2/pkg/galaxy/role/install"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// CollectionsDir is the cache entry subdirectory where the collections are installed
+	CollectionsDir = "collections"
+	// RolesDir is the cache entry subdirectory where the roles are installed
+	RolesDir = "roles"
+)
+
+// RequirementsFiles are the requirements files detected on the project directory, relative to it
+var RequirementsFiles = []string{
+	"requirements.yml",
+	"requirements.yaml",
+	filepath.Join(CollectionsDir, "requirements.yml"),
+	filepath.Join(CollectionsDir, "requirements.yaml"),
+	filepath.Join(RolesDir, "requirements.yml"),
+	filepath.Join(RolesDir, "requirements.yaml"),
+}
+
+// envVarGetter is implemented by the executors that expose their environment variables, such as the DefaultExecute
+type envVarGetter interface {
+	EnvVar(key string) (string, bool)
+}
+
+// GalaxyCacheOptionsFunc is a function to set the AnsibleWithGalaxyCacheExecute options
+type GalaxyCacheOptionsFunc func(*AnsibleWithGalaxyCacheExecute)
+
+// AnsibleWithGalaxyCacheExecute is an executor that installs the project collections and roles before running the executor it wraps. The installations are kept on a cache directory, indexed by the hash of the requirements files, the collection files they reference and the collections and roles paths, so they are only installed when the requirements change
+type AnsibleWithGalaxyCacheExecute struct {
+	binary           string
+	cacheDir         string
+	collectionsPath  string
+	exec             execute.Executabler
+	executor         configuration.ExecutorEnvVarSetter
+	projectDir       string
+	requirementFiles []string
+	rolesPath        string
+}
+
+// NewAnsibleWithGalaxyCacheExecute returns a new AnsibleWithGalaxyCacheExecute
+func NewAnsibleWithGalaxyCacheExecute(executor configuration.ExecutorEnvVarSetter, options ...GalaxyCacheOptionsFunc) *AnsibleWithGalaxyCacheExecute {
+	exec := &AnsibleWithGalaxyCacheExecute{
+		executor:   executor,
+		projectDir: ".",
+	}
+
+	for _, option := range options {
+		option(exec)
+	}
+
+	return exec
+}
+
+// WithBinary sets the ansible-galaxy binary file used to install the requirements
+func WithBinary(binary string) GalaxyCacheOptionsFunc {
+	return func(e *AnsibleWithGalaxyCacheExecute) {
+		e.binary = binary
+	}
+}
+
+// WithCacheDir sets the directory where the installations are cached. The default is the go-ansible/galaxy directory on the user cache directory
+func WithCacheDir(dir string) GalaxyCacheOptionsFunc {
+	return func(e *AnsibleWithGalaxyCacheExecute) {
+		e.cacheDir = dir
+	}
+}
+
+// WithCollectionsPath sets the collections path configured for the execution. The cached collections take precedence over it. The default is the ANSIBLE_COLLECTIONS_PATH environment variable value of the executor, or of the current process when the executor does not set it
+func WithCollectionsPath(path string) GalaxyCacheOptionsFunc {
+	return func(e *AnsibleWithGalaxyCacheExecute) {
+		e.collectionsPath = path
+	}
+}
+
+// WithExecutable sets the executable used to run ansible-galaxy
+func WithExecutable(executable execute.Executabler) GalaxyCacheOptionsFunc {
+	return func(e *AnsibleWithGalaxyCacheExecute) {
+		e.exec = executable
+	}
+}
+
+// WithProjectDir sets the directory where the requirements files are detected. The default is the current directory
+func WithProjectDir(dir string) GalaxyCacheOptionsFunc {
+	return func(e *AnsibleWithGalaxyCacheExecute) {
+		e.projectDir = dir
+	}
+}
+
+// WithRequirementsFiles sets the requirements files to install, instead of detecting them on the project directory. Relative files are resolved from the project directory
+func WithRequirementsFiles(files ...string) GalaxyCacheOptionsFunc {
+	return func(e *AnsibleWithGalaxyCacheExecute) {
+		e.requirementFiles = append([]string{}, files...)
+	}
+}
+
+// WithRolesPath sets the roles path configured for the execution. The cached roles take precedence over it. The default is the ANSIBLE_ROLES_PATH environment variable value of the executor, or of the current process when the executor does not set it
+func WithRolesPath(path string) GalaxyCacheOptionsFunc {
+	return func(e *AnsibleWithGalaxyCacheExecute) {
+		e.rolesPath = path
+	}
+}
+
+// WithExecutor sets the executor to run once the requirements are installed
+func (e *AnsibleWithGalaxyCacheExecute) WithExecutor(exec configuration.ExecutorEnvVarSetter) *AnsibleWithGalaxyCacheExecute {
+	e.executor = exec
+	return e
+}
+
+// Execute installs the requirements when they are not cached, points ANSIBLE_COLLECTIONS_PATH and ANSIBLE_ROLES_PATH to the cached installation and runs the executor. The executor runs without changes when the project has no requirements files
+func (e *AnsibleWithGalaxyCacheExecute) Execute(ctx context.Context) error {
+	errContext := "(galaxycache::AnsibleWithGalaxyCacheExecute::Execute)"
+
+	if e.executor == nil {
+		return errors.New(errContext, "AnsibleWithGalaxyCacheExecute executor requires an executor")
+	}
+
+	files, err := e.requirementsFiles()
+	if err != nil {
+		return errors.New(errContext, "Error detecting the requirements files", err)
+	}
+
+	if len(files) > 0 {
+		err = e.prepare(ctx, files)
+		if err != nil {
+			return errors.New(errContext, "Error preparing the cached requirements", err)
+		}
+	}
+
+	err = e.executor.Execute(ctx)
+	if err != nil {
+		return errors.New(errContext, "Error executing command", err)
+	}
+
+	return nil
+}
+
+// prepare installs the requirements defined on files when they are not cached, and sets the environment variables that point to the cached installation
+func (e *AnsibleWithGalaxyCacheExecute) prepare(ctx context.Context, files []string) error {
+	requirements := galaxyrequirements.NewRequirements()
+	contents := map[string][]byte{}
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		contents[file] = content
+
+		fileRequirements, err := galaxyrequirements.ParseFile(file)
+		if err != nil {
+			return err
+		}
+		requirements = requirements.Merge(fileRequirements)
+	}
+
+	sources, err := collectionFileDigests(requirements)
+	if err != nil {
+		return err
+	}
+
+	cacheDir, err := e.resolveCacheDir()
+	if err != nil {
+		return err
+	}
+
+	collectionsPath := e.resolveCollectionsPath()
+	rolesPath := e.resolveRolesPath()
+	entry := filepath.Join(cacheDir, cacheKey(e.projectDir, contents, sources, collectionsPath, rolesPath))
+
+	_, err = os.Stat(entry)
+	if os.IsNotExist(err) {
+		err = e.install(ctx, requirements, cacheDir, entry)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(requirements.Collections) > 0 {
+		e.executor.AddEnvVar(configuration.AnsibleCollectionsPath, joinPaths(filepath.Join(entry, CollectionsDir), collectionsPath))
+	}
+
+	if len(requirements.Roles) > 0 {
+		e.executor.AddEnvVar(configuration.AnsibleRolesPath, joinPaths(filepath.Join(entry, RolesDir), rolesPath))
+	}
+
+	return nil
+}
+
+// install installs the requirements on a temporary directory that becomes the cache entry once the installation succeeds, so a failed installation is never cached
+func (e *AnsibleWithGalaxyCacheExecute) install(ctx context.Context, requirements *galaxyrequirements.Requirements, cacheDir, entry string) error {
+	err := os.MkdirAll(cacheDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.MkdirTemp(cacheDir, filepath.Base(entry)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	if len(requirements.Collections) > 0 {
+		cmd, file, err := galaxyrequirements.NewAnsibleGalaxyCollectionInstallCmd(requirements, tmp,
+			galaxycollectioninstall.WithBinary(e.binary),
+			galaxycollectioninstall.WithGalaxyCollectionInstallOptions(&galaxycollectioninstall.AnsibleGalaxyCollectionInstallOptions{
+				CollectionsPath: filepath.Join(tmp, CollectionsDir),
+			}),
+		)
+		if err != nil {
+			return err
+		}
+
+		err = e.run(ctx, cmd)
+		os.Remove(file)
+		if err != nil {
+			return fmt.Errorf("error installing collections: %w", err)
+		}
+	}
+
+	if len(requirements.Roles) > 0 {
+		cmd, file, err := galaxyrequirements.NewAnsibleGalaxyRoleInstallCmd(requirements, tmp,
+			galaxyroleinstall.WithBinary(e.binary),
+			galaxyroleinstall.WithGalaxyRoleInstallOptions(&galaxyroleinstall.AnsibleGalaxyRoleInstallOptions{
+				RolesPath: filepath.Join(tmp, RolesDir),
+			}),
+		)
+		if err != nil {
+			return err
+		}
+
+		err = e.run(ctx, cmd)
+		os.Remove(file)
+		if err != nil {
+			return fmt.Errorf("error installing roles: %w", err)
+		}
+	}
+
+	err = os.Rename(tmp, entry)
+	if err != nil {
+		// another execution could have cached the same requirements in the meantime
+		_, statErr := os.Stat(entry)
+		if statErr == nil {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// run executes an ansible-galaxy command
+func (e *AnsibleWithGalaxyCacheExecute) run(ctx context.Context, cmd execute.Commander) error {
+	options := []execute.ExecuteOptions{
+		execute.WithCmd(cmd),
+	}
+
+	if e.exec != nil {
+		options = append(options, execute.WithExecutable(e.exec))
+	}
+
+	return execute.NewDefaultExecute(options...).Execute(ctx)
+}
+
+// requirementsFiles returns the requirements files to install. When they are not set, it returns the RequirementsFiles that exist on the project directory
+func (e *AnsibleWithGalaxyCacheExecute) requirementsFiles() ([]string, error) {
+	if len(e.requirementFiles) > 0 {
+		files := []string{}
+		for _, file := range e.requirementFiles {
+			if !filepath.IsAbs(file) {
+				file = filepath.Join(e.projectDir, file)
+			}
+			files = append(files, file)
+		}
+
+		return files, nil
+	}
+
+	files := []string{}
+	for _, candidate := range RequirementsFiles {
+		file := filepath.Join(e.projectDir, candidate)
+
+		info, err := os.Stat(file)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
+
+// resolveCacheDir returns the cache directory
+func (e *AnsibleWithGalaxyCacheExecute) resolveCacheDir() (string, error) {
+	if e.cacheDir != "" {
+		return e.cacheDir, nil
+	}
+
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(userCacheDir, "go-ansible", "galaxy"), nil
+}
+
+// resolveCollectionsPath returns the collections path configured for the execution
+func (e *AnsibleWithGalaxyCacheExecute) resolveCollectionsPath() string {
+	if e.collectionsPath != "" {
+		return e.collectionsPath
+	}
+
+	path := e.envVar(configuration.AnsibleCollectionsPath)
+	if path != "" {
+		return path
+	}
+
+	return e.envVar(configuration.AnsibleCollectionsPaths)
+}
+
+// resolveRolesPath returns the roles path configured for the execution
+func (e *AnsibleWithGalaxyCacheExecute) resolveRolesPath() string {
+	if e.rolesPath != "" {
+		return e.rolesPath
+	}
+
+	return e.envVar(configuration.AnsibleRolesPath)
+}
+
+// envVar returns the value of the environment variable set on the executor. When the executor does not expose its environment variables or it does not set the variable, the value of the current process is returned, which the executor inherits
+func (e *AnsibleWithGalaxyCacheExecute) envVar(key string) string {
+	getter, isEnvVarGetter := e.executor.(envVarGetter)
+	if isEnvVarGetter {
+		value, isSet := getter.EnvVar(key)
+		if isSet {
+			return value
+		}
+	}
+
+	return os.Getenv(key)
+}
+
+// collectionFileDigests returns the hash of the local tarballs referenced by the file collections, indexed by their path. The tarballs may change without changing the requirements files, so they are part of the cache key
+func collectionFileDigests(requirements *galaxyrequirements.Requirements) (map[string]string, error) {
+	digests := map[string]string{}
+
+	for _, collection := range requirements.Collections {
+		if collection.Type != galaxyrequirements.TypeFile {
+			continue
+		}
+
+		content, err := os.ReadFile(collection.Name)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the collection file '%s': %w", collection.Name, err)
+		}
+
+		digest := sha256.Sum256(content)
+		digests[collection.Name] = hex.EncodeToString(digest[:])
+	}
+
+	return digests, nil
+}
+
+// cacheKey returns the hash of the requirements files, the digests of the collection files they reference and the collections and roles paths. The requirements files are identified by their path relative to the project directory, so the key does not depend on where the project is
+func cacheKey(projectDir string, contents map[string][]byte, sources map[string]string, collectionsPath, rolesPath string) string {
+	hash := sha256.New()
+
+	files := make([]string, 0, len(contents))
+	for file := range contents {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		name, err := filepath.Rel(projectDir, file)
+		if err != nil {
+			name = file
+		}
+
+		fmt.Fprintf(hash, "%s\x00%s\x00", filepath.ToSlash(name), contents[file])
+	}
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(hash, "%s\x00%s\x00", name, sources[name])
+	}
+
+	fmt.Fprintf(hash, "%s\x00%s\x00%s\x00%s\x00", configuration.AnsibleCollectionsPath, collectionsPath, configuration.AnsibleRolesPath, rolesPath)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// joinPaths joins the non empty paths using the list separator
+func joinPaths(paths ...string) string {
+	nonEmpty := []string{}
+	for _, path := range paths {
+		if path != "" {
+			nonEmpty = append(nonEmpty, path)
+		}
+	}
+
+	return strings.Join(nonEmpty, string(os.PathListSeparator))
+}
@@ -0,0 +1,353 @@
+package galaxycache
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+	"github.com/apenella/go-ansible/v2/pkg/execute/configuration"
+	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const requirementsContent = `---
+collections:
+  - name: community.general
+    version: 8.0.0
+roles:
+  - name: geerlingguy.docker
+    version: 6.1.0
+`
+
+func newMockGalaxyCmd(err error) *exec.MockCmd {
+	cmd := exec.NewMockCmd()
+	cmd.On("StdoutPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+	cmd.On("StderrPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+	cmd.On("Start").Return(nil)
+	cmd.On("Wait").Return(err)
+
+	return cmd
+}
+
+// newMockGalaxyExec returns a MockExec that answers the ansible version detection of the compatibility check, which is not run when the version is already cached
+func newMockGalaxyExec(binary string) *exec.MockExec {
+	versionCmd := exec.NewMockCmd()
+	versionCmd.On("Output").Return([]byte("ansible-galaxy [core 2.16.3]\n"), nil).Maybe()
+
+	e := exec.NewMockExec()
+	e.On("CommandContext", context.TODO(), binary, []string{"--version"}).Return(versionCmd).Maybe()
+
+	return e
+}
+
+func writeRequirements(t *testing.T, dir, file, content string) {
+	err := os.MkdirAll(filepath.Dir(filepath.Join(dir, file)), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, file), []byte(content), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewAnsibleWithGalaxyCacheExecute(t *testing.T) {
+	executor := execute.NewMockExecute()
+
+	expect := &AnsibleWithGalaxyCacheExecute{
+		binary:           "custom-binary",
+		cacheDir:         "/tmp/cache",
+		collectionsPath:  "/collections",
+		executor:         executor,
+		projectDir:       "/project",
+		requirementFiles: []string{"requirements.yml"},
+		rolesPath:        "/roles",
+	}
+
+	res := NewAnsibleWithGalaxyCacheExecute(executor,
+		WithBinary("custom-binary"),
+		WithCacheDir("/tmp/cache"),
+		WithCollectionsPath("/collections"),
+		WithProjectDir("/project"),
+		WithRequirementsFiles("requirements.yml"),
+		WithRolesPath("/roles"),
+	)
+
+	assert.Equal(t, expect, res)
+}
+
+func TestAnsibleWithGalaxyCacheExecuteExecute(t *testing.T) {
+	t.Run("Testing install the requirements only once", func(t *testing.T) {
+		t.Log("Testing install the requirements only once")
+
+		projectDir := t.TempDir()
+		cacheDir := t.TempDir()
+		writeRequirements(t, projectDir, "requirements.yml", requirementsContent)
+
+		e := newMockGalaxyExec("ansible-galaxy")
+		collectionCmd := newMockGalaxyCmd(nil)
+		roleCmd := newMockGalaxyCmd(nil)
+		e.On("CommandContext", context.TODO(), "ansible-galaxy", mock.MatchedBy(func(args []string) bool { return args[0] == "collection" })).Return(collectionCmd).Once()
+		e.On("CommandContext", context.TODO(), "ansible-galaxy", mock.MatchedBy(func(args []string) bool { return args[0] == "role" })).Return(roleCmd).Once()
+
+		executor := execute.NewMockExecute()
+		executor.On("AddEnvVar", configuration.AnsibleCollectionsPath, mock.Anything).Return()
+		executor.On("AddEnvVar", configuration.AnsibleRolesPath, mock.Anything).Return()
+		executor.On("Execute", context.TODO()).Return(nil)
+
+		galaxyCache := NewAnsibleWithGalaxyCacheExecute(executor,
+			WithCacheDir(cacheDir),
+			WithCollectionsPath("/collections"),
+			WithExecutable(e),
+			WithProjectDir(projectDir),
+			WithRolesPath("/roles"),
+		)
+
+		err := galaxyCache.Execute(context.TODO())
+		assert.NoError(t, err)
+
+		err = galaxyCache.Execute(context.TODO())
+		assert.NoError(t, err)
+
+		entries, err := os.ReadDir(cacheDir)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+
+		entry := filepath.Join(cacheDir, entries[0].Name())
+		executor.AssertCalled(t, "AddEnvVar", configuration.AnsibleCollectionsPath, filepath.Join(entry, CollectionsDir)+string(os.PathListSeparator)+"/collections")
+		executor.AssertCalled(t, "AddEnvVar", configuration.AnsibleRolesPath, filepath.Join(entry, RolesDir)+string(os.PathListSeparator)+"/roles")
+		executor.AssertNumberOfCalls(t, "Execute", 2)
+		e.AssertExpectations(t)
+	})
+
+	t.Run("Testing execute without requirements files", func(t *testing.T) {
+		t.Log("Testing execute without requirements files")
+
+		e := newMockGalaxyExec("ansible-galaxy")
+		executor := execute.NewMockExecute()
+		executor.On("Execute", context.TODO()).Return(nil)
+
+		err := NewAnsibleWithGalaxyCacheExecute(executor,
+			WithCacheDir(t.TempDir()),
+			WithExecutable(e),
+			WithProjectDir(t.TempDir()),
+		).Execute(context.TODO())
+
+		assert.NoError(t, err)
+		executor.AssertExpectations(t)
+		executor.AssertNotCalled(t, "AddEnvVar", mock.Anything, mock.Anything)
+		e.AssertNotCalled(t, "CommandContext", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Testing install only the roles", func(t *testing.T) {
+		t.Log("Testing install only the roles")
+
+		projectDir := t.TempDir()
+		writeRequirements(t, projectDir, filepath.Join("roles", "requirements.yml"), "---\n- src: geerlingguy.docker\n")
+
+		e := newMockGalaxyExec("custom-binary")
+		roleCmd := newMockGalaxyCmd(nil)
+		e.On("CommandContext", context.TODO(), "custom-binary", mock.MatchedBy(func(args []string) bool { return args[0] == "role" })).Return(roleCmd).Once()
+
+		executor := execute.NewMockExecute()
+		executor.On("EnvVar", mock.Anything).Return("", false)
+		executor.On("AddEnvVar", configuration.AnsibleRolesPath, mock.Anything).Return()
+		executor.On("Execute", context.TODO()).Return(nil)
+
+		err := NewAnsibleWithGalaxyCacheExecute(executor,
+			WithBinary("custom-binary"),
+			WithCacheDir(t.TempDir()),
+			WithExecutable(e),
+			WithProjectDir(projectDir),
+		).Execute(context.TODO())
+
+		assert.NoError(t, err)
+		executor.AssertExpectations(t)
+		executor.AssertNotCalled(t, "AddEnvVar", configuration.AnsibleCollectionsPath, mock.Anything)
+		e.AssertExpectations(t)
+	})
+
+	t.Run("Testing a failed installation is not cached", func(t *testing.T) {
+		t.Log("Testing a failed installation is not cached")
+
+		projectDir := t.TempDir()
+		cacheDir := t.TempDir()
+		writeRequirements(t, projectDir, "requirements.yml", "---\ncollections:\n  - community.general\n")
+
+		e := newMockGalaxyExec("ansible-galaxy")
+		collectionCmd := newMockGalaxyCmd(fmt.Errorf("install failed"))
+		e.On("CommandContext", context.TODO(), "ansible-galaxy", mock.Anything).Return(collectionCmd)
+
+		executor := execute.NewMockExecute()
+		executor.On("EnvVar", mock.Anything).Return("", false)
+
+		err := NewAnsibleWithGalaxyCacheExecute(executor,
+			WithCacheDir(cacheDir),
+			WithExecutable(e),
+			WithProjectDir(projectDir),
+		).Execute(context.TODO())
+
+		assert.Error(t, err)
+		executor.AssertNotCalled(t, "Execute", mock.Anything)
+
+		entries, err := os.ReadDir(cacheDir)
+		assert.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("Testing prepend the cached installation to the paths set on the executor", func(t *testing.T) {
+		t.Log("Testing prepend the cached installation to the paths set on the executor")
+
+		t.Setenv(configuration.AnsibleCollectionsPath, "/process/collections")
+		t.Setenv(configuration.AnsibleRolesPath, "/process/roles")
+
+		projectDir := t.TempDir()
+		cacheDir := t.TempDir()
+		writeRequirements(t, projectDir, "requirements.yml", requirementsContent)
+
+		e := newMockGalaxyExec("ansible-galaxy")
+		e.On("CommandContext", context.TODO(), "ansible-galaxy", mock.Anything).Return(newMockGalaxyCmd(nil))
+
+		executor := execute.NewDefaultExecute(
+			execute.WithEnvVars(map[string]string{
+				configuration.AnsibleCollectionsPath: "/executor/collections",
+			}),
+			execute.WithExecutable(exec.NewMockExec()),
+		)
+
+		err := NewAnsibleWithGalaxyCacheExecute(executor,
+			WithCacheDir(cacheDir),
+			WithExecutable(e),
+			WithProjectDir(projectDir),
+		).prepare(context.TODO(), []string{filepath.Join(projectDir, "requirements.yml")})
+		assert.NoError(t, err)
+
+		entries, err := os.ReadDir(cacheDir)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+
+		entry := filepath.Join(cacheDir, entries[0].Name())
+		collectionsPath, _ := executor.EnvVar(configuration.AnsibleCollectionsPath)
+		rolesPath, _ := executor.EnvVar(configuration.AnsibleRolesPath)
+		assert.Equal(t, filepath.Join(entry, CollectionsDir)+string(os.PathListSeparator)+"/executor/collections", collectionsPath)
+		assert.Equal(t, filepath.Join(entry, RolesDir)+string(os.PathListSeparator)+"/process/roles", rolesPath)
+	})
+
+	t.Run("Testing install again when a collection file changes", func(t *testing.T) {
+		t.Log("Testing install again when a collection file changes")
+
+		projectDir := t.TempDir()
+		cacheDir := t.TempDir()
+		tarball := filepath.Join(projectDir, "my-collection.tar.gz")
+		writeRequirements(t, projectDir, "my-collection.tar.gz", "1.0.0")
+		writeRequirements(t, projectDir, "requirements.yml", fmt.Sprintf("---\ncollections:\n  - name: %s\n    type: file\n", tarball))
+
+		e := newMockGalaxyExec("ansible-galaxy")
+		e.On("CommandContext", context.TODO(), "ansible-galaxy", mock.Anything).Return(newMockGalaxyCmd(nil))
+
+		executor := execute.NewMockExecute()
+		executor.On("EnvVar", mock.Anything).Return("", false)
+		executor.On("AddEnvVar", configuration.AnsibleCollectionsPath, mock.Anything).Return()
+		executor.On("Execute", context.TODO()).Return(nil)
+
+		galaxyCache := NewAnsibleWithGalaxyCacheExecute(executor,
+			WithCacheDir(cacheDir),
+			WithExecutable(e),
+			WithProjectDir(projectDir),
+		)
+
+		err := galaxyCache.Execute(context.TODO())
+		assert.NoError(t, err)
+
+		writeRequirements(t, projectDir, "my-collection.tar.gz", "2.0.0")
+
+		err = galaxyCache.Execute(context.TODO())
+		assert.NoError(t, err)
+
+		entries, err := os.ReadDir(cacheDir)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 2)
+		e.AssertNumberOfCalls(t, "CommandContext", 2)
+	})
+
+	t.Run("Testing error when a collection file does not exist", func(t *testing.T) {
+		t.Log("Testing error when a collection file does not exist")
+
+		projectDir := t.TempDir()
+		writeRequirements(t, projectDir, "requirements.yml", fmt.Sprintf("---\ncollections:\n  - name: %s\n    type: file\n", filepath.Join(projectDir, "missing.tar.gz")))
+
+		executor := execute.NewMockExecute()
+
+		err := NewAnsibleWithGalaxyCacheExecute(executor,
+			WithCacheDir(t.TempDir()),
+			WithExecutable(exec.NewMockExec()),
+			WithProjectDir(projectDir),
+		).Execute(context.TODO())
+
+		assert.ErrorContains(t, err, "error reading the collection file")
+		executor.AssertNotCalled(t, "Execute", mock.Anything)
+	})
+
+	t.Run("Testing error when executor is not defined", func(t *testing.T) {
+		t.Log("Testing error when executor is not defined")
+
+		err := NewAnsibleWithGalaxyCacheExecute(nil).Execute(context.TODO())
+		assert.Equal(t, errors.New("(galaxycache::AnsibleWithGalaxyCacheExecute::Execute)", "AnsibleWithGalaxyCacheExecute executor requires an executor"), err)
+	})
+}
+
+func TestCacheKey(t *testing.T) {
+	tests := []struct {
+		desc  string
+		left  string
+		right string
+		equal bool
+	}{
+		{
+			desc:  "Testing cache key does not depend on the project directory",
+			left:  cacheKey("/a", map[string][]byte{"/a/requirements.yml": []byte("content")}, nil, "/collections", "/roles"),
+			right: cacheKey("/b", map[string][]byte{"/b/requirements.yml": []byte("content")}, nil, "/collections", "/roles"),
+			equal: true,
+		},
+		{
+			desc:  "Testing cache key depends on the requirements content",
+			left:  cacheKey("/a", map[string][]byte{"/a/requirements.yml": []byte("content")}, nil, "/collections", "/roles"),
+			right: cacheKey("/a", map[string][]byte{"/a/requirements.yml": []byte("changed")}, nil, "/collections", "/roles"),
+			equal: false,
+		},
+		{
+			desc:  "Testing cache key depends on the collection files content",
+			left:  cacheKey("/a", map[string][]byte{"/a/requirements.yml": []byte("content")}, map[string]string{"collection.tar.gz": "digest"}, "/collections", "/roles"),
+			right: cacheKey("/a", map[string][]byte{"/a/requirements.yml": []byte("content")}, map[string]string{"collection.tar.gz": "changed"}, "/collections", "/roles"),
+			equal: false,
+		},
+		{
+			desc:  "Testing cache key depends on the collections path",
+			left:  cacheKey("/a", map[string][]byte{"/a/requirements.yml": []byte("content")}, nil, "/collections", "/roles"),
+			right: cacheKey("/a", map[string][]byte{"/a/requirements.yml": []byte("content")}, nil, "/other", "/roles"),
+			equal: false,
+		},
+		{
+			desc:  "Testing cache key depends on the roles path",
+			left:  cacheKey("/a", map[string][]byte{"/a/requirements.yml": []byte("content")}, nil, "/collections", "/roles"),
+			right: cacheKey("/a", map[string][]byte{"/a/requirements.yml": []byte("content")}, nil, "/collections", "/other"),
+			equal: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.equal, test.left == test.right)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package execute
 import (
 	"context"
 
+	"github.com/apenella/go-ansible/v2/pkg/execute/compatibility"
 	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
 )
 
@@ -23,6 +24,11 @@ type Commander interface {
 	String() string
 }
 
+// CompatibilityChecker checks whether a command and the environment variables of its execution are supported by the Ansible version in use. It returns the warnings to report and an error when the command can not be executed
+type CompatibilityChecker interface {
+	Check(ctx context.Context, command []string, execution compatibility.Execution) ([]string, error)
+}
+
 // ErrorEnricher interface to enrich and customize errors
 type ErrorEnricher interface {
 	Enrich(err error) error
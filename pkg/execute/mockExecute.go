@@ -33,6 +33,12 @@ func (e *MockExecute) AddEnvVar(key, value string) {
 	e.Called(key, value)
 }
 
+// EnvVar is a mock
+func (e *MockExecute) EnvVar(key string) (string, bool) {
+	args := e.Called(key)
+	return args.String(0), args.Bool(1)
+}
+
 // WithOutput is a mock
 func (e *MockExecute) WithOutput(output result.ResultsOutputer) {
 	e.Called(output)
@@ -0,0 +1,144 @@
+package galaxycollectionbuild
+
+import (
+	"fmt"
+
+	galaxy "github.com/apenella/go-ansible/v2/pkg/galaxy"
+	galaxycollection "github.com/apenella/go-ansible/v2/pkg/galaxy/collection"
+)
+
+const (
+	// AnsibleGalaxyCollectionBuildSubCommand is the ansible-galaxy collection build subcommand
+	AnsibleGalaxyCollectionBuildSubCommand = "build"
+)
+
+// AnsibleGalaxyCollectionBuildOptionsFunc is a function to set executor options
+type AnsibleGalaxyCollectionBuildOptionsFunc func(*AnsibleGalaxyCollectionBuildCmd)
+
+// AnsibleGalaxyCollectionBuildCmd object is the main object which defines the `ansible-galaxy` command to build collections.
+type AnsibleGalaxyCollectionBuildCmd struct {
+	// Binary is the ansible-galaxy binary file
+	Binary string
+
+	// CollectionPaths are the paths to the collections to be built. The current working directory is built when it is empty
+	CollectionPaths []string
+
+	// GalaxyCollectionBuildOptions are the ansible-galaxy's collection build options
+	GalaxyCollectionBuildOptions *AnsibleGalaxyCollectionBuildOptions
+
+	// SkipValidation disables the collection build options validation when the command is generated
+	SkipValidation bool
+	// FileValidation enables checking that the paths referenced by the options are directories when the command is generated
+	FileValidation bool
+	// FileValidationDir is the directory the relative paths are resolved against when the files are validated
+	FileValidationDir string
+}
+
+// NewAnsibleGalaxyCollectionBuildCmd creates a new AnsibleGalaxyCollectionBuildCmd instance
+func NewAnsibleGalaxyCollectionBuildCmd(options ...AnsibleGalaxyCollectionBuildOptionsFunc) *AnsibleGalaxyCollectionBuildCmd {
+	cmd := &AnsibleGalaxyCollectionBuildCmd{}
+
+	for _, option := range options {
+		option(cmd)
+	}
+
+	return cmd
+}
+
+// WithBinary set the ansible-galaxy binary file
+func WithBinary(binary string) AnsibleGalaxyCollectionBuildOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionBuildCmd) {
+		p.Binary = binary
+	}
+}
+
+// WithCollectionPaths set the paths to the collections to be built
+func WithCollectionPaths(collectionPaths ...string) AnsibleGalaxyCollectionBuildOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionBuildCmd) {
+		p.CollectionPaths = append([]string{}, collectionPaths...)
+	}
+}
+
+// WithGalaxyCollectionBuildOptions set the ansible-galaxy collection build options
+func WithGalaxyCollectionBuildOptions(options *AnsibleGalaxyCollectionBuildOptions) AnsibleGalaxyCollectionBuildOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionBuildCmd) {
+		p.GalaxyCollectionBuildOptions = options
+	}
+}
+
+// WithoutValidation disables the ansible-galaxy collection build options validation
+func WithoutValidation() AnsibleGalaxyCollectionBuildOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionBuildCmd) {
+		p.SkipValidation = true
+	}
+}
+
+// WithFileValidation enables checking that the paths referenced by the ansible-galaxy collection build options are directories. The relative paths are resolved against dir, which is the directory where the command runs, or against the working directory when dir is empty. Do not enable it when the command runs on another host or container
+func WithFileValidation(dir string) AnsibleGalaxyCollectionBuildOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionBuildCmd) {
+		p.FileValidation = true
+		p.FileValidationDir = dir
+	}
+}
+
+// Command generate the ansible-galaxy collection build command which will be executed
+func (p *AnsibleGalaxyCollectionBuildCmd) Command() ([]string, error) {
+	cmd := []string{}
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = galaxy.DefaultAnsibleGalaxyBinary
+	}
+
+	cmd = append(cmd, p.Binary, galaxycollection.AnsibleGalaxyCollectionSubCommand, AnsibleGalaxyCollectionBuildSubCommand)
+
+	// Add the options
+	if p.GalaxyCollectionBuildOptions != nil {
+		if !p.SkipValidation {
+			err := p.GalaxyCollectionBuildOptions.Validate()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if p.FileValidation {
+			err := p.GalaxyCollectionBuildOptions.ValidateFiles(p.FileValidationDir)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		options, err := p.GalaxyCollectionBuildOptions.GenerateCommandOptions()
+		if err != nil {
+			return nil, err
+		}
+		cmd = append(cmd, options...)
+	}
+
+	// Add the collection paths
+	cmd = append(cmd, p.CollectionPaths...)
+
+	return cmd, nil
+}
+
+// String returns the ansible-galaxy collection build command as a string
+func (p *AnsibleGalaxyCollectionBuildCmd) String() string {
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = galaxy.DefaultAnsibleGalaxyBinary
+	}
+
+	str := fmt.Sprintf("%s %s %s", p.Binary, galaxycollection.AnsibleGalaxyCollectionSubCommand, AnsibleGalaxyCollectionBuildSubCommand)
+
+	if p.GalaxyCollectionBuildOptions != nil {
+		str = fmt.Sprintf("%s %s", str, p.GalaxyCollectionBuildOptions.String())
+	}
+
+	// Include the collection paths
+	for _, collectionPath := range p.CollectionPaths {
+		str = fmt.Sprintf("%s %s", str, collectionPath)
+	}
+
+	return str
+}
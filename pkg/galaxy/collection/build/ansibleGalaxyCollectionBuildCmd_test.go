@@ -0,0 +1,141 @@
+package galaxycollectionbuild
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/galaxy"
+	galaxycollection "github.com/apenella/go-ansible/v2/pkg/galaxy/collection"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleGalaxyCollectionBuildCmd(t *testing.T) {
+	cmd := NewAnsibleGalaxyCollectionBuildCmd(
+		WithBinary("ansible-galaxy-binary"),
+		WithCollectionPaths("collections/internal/tools"),
+		WithGalaxyCollectionBuildOptions(&AnsibleGalaxyCollectionBuildOptions{
+			Force: true,
+		}),
+	)
+
+	expect := &AnsibleGalaxyCollectionBuildCmd{
+		Binary:          "ansible-galaxy-binary",
+		CollectionPaths: []string{"collections/internal/tools"},
+		GalaxyCollectionBuildOptions: &AnsibleGalaxyCollectionBuildOptions{
+			Force: true,
+		},
+	}
+
+	assert.Equal(t, expect, cmd)
+}
+
+func TestAnsibleGalaxyCollectionBuildCmdCommand(t *testing.T) {
+
+	tests := []struct {
+		desc    string
+		cmd     *AnsibleGalaxyCollectionBuildCmd
+		command []string
+		err     error
+	}{
+		{
+			desc: "Testing generate a command for AnsibleGalaxyCollectionBuildCmd with all flags using default binary",
+			cmd: NewAnsibleGalaxyCollectionBuildCmd(
+				WithoutValidation(),
+				WithCollectionPaths("collections/internal/tools"),
+				WithGalaxyCollectionBuildOptions(&AnsibleGalaxyCollectionBuildOptions{
+					Force:      true,
+					OutputPath: "path",
+					Verbose:    true,
+				}),
+			),
+			err: &errors.Error{},
+			command: []string{
+				galaxy.DefaultAnsibleGalaxyBinary,
+				galaxycollection.AnsibleGalaxyCollectionSubCommand,
+				AnsibleGalaxyCollectionBuildSubCommand,
+				ForceFlag,
+				fmt.Sprintf("%s=%s", OutputPathFlag, "path"),
+				VerboseFlag,
+				"collections/internal/tools",
+			},
+		},
+		{
+			desc: "Testing generating a command for AnsibleGalaxyCollectionBuildCmd without validating the option files",
+			cmd: NewAnsibleGalaxyCollectionBuildCmd(
+				WithGalaxyCollectionBuildOptions(&AnsibleGalaxyCollectionBuildOptions{
+					OutputPath: "ansibleGalaxyCollectionBuildOptions.go",
+				}),
+			),
+			command: []string{
+				galaxy.DefaultAnsibleGalaxyBinary,
+				galaxycollection.AnsibleGalaxyCollectionSubCommand,
+				AnsibleGalaxyCollectionBuildSubCommand,
+				fmt.Sprintf("%s=%s", OutputPathFlag, "ansibleGalaxyCollectionBuildOptions.go"),
+			},
+		},
+		{
+			desc: "Testing error generating a command for AnsibleGalaxyCollectionBuildCmd validating the option files",
+			cmd: NewAnsibleGalaxyCollectionBuildCmd(
+				WithGalaxyCollectionBuildOptions(&AnsibleGalaxyCollectionBuildOptions{
+					OutputPath: "ansibleGalaxyCollectionBuildOptions.go",
+				}),
+				WithFileValidation(""),
+			),
+			err: errors.New("(galaxy::AnsibleGalaxyCollectionBuildOptions::ValidateFiles)", "Invalid ansible-galaxy collection build option files",
+				fmt.Errorf("'%s' must be a directory, but 'ansibleGalaxyCollectionBuildOptions.go' is a file", OutputPathFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			command, err := test.cmd.Command()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.command, command)
+			}
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionBuildCmdString(t *testing.T) {
+
+	tests := []struct {
+		desc string
+		cmd  *AnsibleGalaxyCollectionBuildCmd
+		res  string
+	}{
+		{
+			desc: "Testing AnsibleGalaxyCollectionBuildCmd to string with all flags",
+			cmd: NewAnsibleGalaxyCollectionBuildCmd(
+				WithCollectionPaths("collections/internal/tools"),
+				WithGalaxyCollectionBuildOptions(&AnsibleGalaxyCollectionBuildOptions{
+					Force:      true,
+					OutputPath: "path",
+					Verbose:    true,
+				}),
+			),
+			res: "ansible-galaxy collection build --force --output-path=path --verbose collections/internal/tools",
+		},
+		{
+			desc: "Testing AnsibleGalaxyCollectionBuildCmd to string using a custom binary",
+			cmd: NewAnsibleGalaxyCollectionBuildCmd(
+				WithBinary("custom-binary"),
+				WithCollectionPaths("collections/internal/tools"),
+			),
+			res: "custom-binary collection build collections/internal/tools",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, test.cmd.String())
+		})
+	}
+}
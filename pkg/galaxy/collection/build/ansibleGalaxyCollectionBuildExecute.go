@@ -0,0 +1,67 @@
+package galaxycollectionbuild
+
+import (
+	"context"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+)
+
+// AnsibleGalaxyCollectionBuildExecute is an executor for ansible-galaxy collection build command that runs the command using a DefaultExecute with default options
+type AnsibleGalaxyCollectionBuildExecute struct {
+	cmd  *AnsibleGalaxyCollectionBuildCmd
+	exec execute.Executabler
+}
+
+// NewAnsibleGalaxyCollectionBuildExecute returns a new AnsibleGalaxyCollectionBuildExecute. It receives the paths to the collections to build
+func NewAnsibleGalaxyCollectionBuildExecute(collectionPaths ...string) *AnsibleGalaxyCollectionBuildExecute {
+
+	exec := &AnsibleGalaxyCollectionBuildExecute{
+		cmd: &AnsibleGalaxyCollectionBuildCmd{
+			CollectionPaths: append([]string{}, collectionPaths...),
+		},
+	}
+
+	return exec
+}
+
+// WithBinary returns an AnsibleGalaxyCollectionBuildExecute with the binary file set
+func (e *AnsibleGalaxyCollectionBuildExecute) WithBinary(binary string) *AnsibleGalaxyCollectionBuildExecute {
+	e.cmd.Binary = binary
+
+	return e
+}
+
+// WithExecutable returns an AnsibleGalaxyCollectionBuildExecute with the executable used to run the command set
+func (e *AnsibleGalaxyCollectionBuildExecute) WithExecutable(executable execute.Executabler) *AnsibleGalaxyCollectionBuildExecute {
+	e.exec = executable
+
+	return e
+}
+
+// WithGalaxyCollectionBuildOptions returns an AnsibleGalaxyCollectionBuildExecute with the ansible-galaxy collection build options set
+func (e *AnsibleGalaxyCollectionBuildExecute) WithGalaxyCollectionBuildOptions(options *AnsibleGalaxyCollectionBuildOptions) *AnsibleGalaxyCollectionBuildExecute {
+	e.cmd.GalaxyCollectionBuildOptions = options
+
+	return e
+}
+
+// Execute method runs the ansible-galaxy collection build command using a DefaultExecute with default options
+func (e *AnsibleGalaxyCollectionBuildExecute) Execute(ctx context.Context) error {
+
+	options := []execute.ExecuteOptions{
+		execute.WithCmd(e.cmd),
+	}
+
+	if e.exec != nil {
+		options = append(options, execute.WithExecutable(e.exec))
+	}
+
+	exec := execute.NewDefaultExecute(options...)
+
+	err := exec.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
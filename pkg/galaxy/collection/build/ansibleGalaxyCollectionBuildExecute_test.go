@@ -0,0 +1,51 @@
+package galaxycollectionbuild
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleGalaxyCollectionBuildExecute(t *testing.T) {
+	expect := &AnsibleGalaxyCollectionBuildExecute{
+		cmd: &AnsibleGalaxyCollectionBuildCmd{
+			CollectionPaths: []string{"collections/internal/tools"},
+		},
+	}
+
+	res := NewAnsibleGalaxyCollectionBuildExecute("collections/internal/tools")
+
+	assert.Equal(t, expect, res)
+}
+
+func TestAnsibleGalaxyCollectionBuildExecuteExecute(t *testing.T) {
+
+	e := exec.NewMockExec()
+	cmd := exec.NewMockCmd()
+
+	cmd.On("StdoutPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+	cmd.On("StderrPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+	cmd.On("Start").Return(nil)
+	cmd.On("Wait").Return(nil)
+	// the compatibility check detects the ansible version, unless it is already cached
+	versionCmd := exec.NewMockCmd()
+	versionCmd.On("Output").Return([]byte("ansible-galaxy [core 2.16.3]\n"), nil).Maybe()
+	e.On("CommandContext", context.TODO(), "custom-binary", []string{"--version"}).Return(versionCmd).Maybe()
+	e.On("CommandContext", context.TODO(), "custom-binary", []string{"collection", "build", "--force", "collections/internal/tools"}).Return(cmd)
+
+	err := NewAnsibleGalaxyCollectionBuildExecute("collections/internal/tools").
+		WithBinary("custom-binary").
+		WithExecutable(e).
+		WithGalaxyCollectionBuildOptions(&AnsibleGalaxyCollectionBuildOptions{
+			Force: true,
+		}).
+		Execute(context.TODO())
+
+	assert.NoError(t, err)
+	e.AssertExpectations(t)
+	cmd.AssertExpectations(t)
+}
@@ -0,0 +1,112 @@
+package galaxycollectionbuild
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/apenella/go-ansible/v2/pkg/internal/validation"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+
+	// ForceFlag forces overwriting an existing role or collection.
+	ForceFlag = "--force"
+
+	// OutputPathFlag is the path in which the collection is built to. The default is the current working directory.
+	OutputPathFlag = "--output-path"
+
+	// VerboseFlag verbose mode enabled
+	VerboseFlag = "--verbose"
+)
+
+// AnsibleGalaxyCollectionBuildOptions are the ansible-galaxy collection build options
+type AnsibleGalaxyCollectionBuildOptions struct {
+
+	// Force forces overwriting an existing role or collection.
+	Force bool
+
+	// OutputPath is the path in which the collection is built to. The default is the current working directory.
+	OutputPath string
+
+	// Verbose verbose mode enabled
+	Verbose bool
+}
+
+// GenerateCommandOptions return a list of command options flags to be used on ansible-galaxy collection build execution
+func (o *AnsibleGalaxyCollectionBuildOptions) GenerateCommandOptions() ([]string, error) {
+	errContext := "(galaxy::AnsibleGalaxyCollectionBuildOptions::GenerateCommandOptions)"
+	options := []string{}
+
+	if o == nil {
+		return nil, errors.New(errContext, "AnsibleGalaxyCollectionBuildOptions is nil")
+	}
+
+	if o.Force {
+		options = append(options, ForceFlag)
+	}
+
+	if o.OutputPath != "" {
+		options = append(options, fmt.Sprintf("%s=%s", OutputPathFlag, o.OutputPath))
+	}
+
+	if o.Verbose {
+		options = append(options, VerboseFlag)
+	}
+
+	return options, nil
+}
+
+// Validate checks the options. The output path is checked by ValidateFiles
+func (o *AnsibleGalaxyCollectionBuildOptions) Validate() error {
+
+	if o == nil {
+		return errors.New("(galaxy::AnsibleGalaxyCollectionBuildOptions::Validate)", "AnsibleGalaxyCollectionBuildOptions is nil")
+	}
+
+	return nil
+}
+
+// ValidateFiles checks that the paths referenced by the options are directories. The relative paths are resolved against dir, which is the directory where the command runs, or against the working directory when dir is empty. It is not part of Validate because the command may run on another directory, host or container
+func (o *AnsibleGalaxyCollectionBuildOptions) ValidateFiles(dir string) error {
+	errContext := "(galaxy::AnsibleGalaxyCollectionBuildOptions::ValidateFiles)"
+	errs := []error{}
+
+	if o == nil {
+		return errors.New(errContext, "AnsibleGalaxyCollectionBuildOptions is nil")
+	}
+
+	// the output path is created when it does not exist, but it can not be a file
+	if o.OutputPath != "" {
+		info, err := os.Stat(validation.Path(dir, o.OutputPath))
+		if err == nil && !info.IsDir() {
+			errs = append(errs, fmt.Errorf("'%s' must be a directory, but '%s' is a file", OutputPathFlag, o.OutputPath))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid ansible-galaxy collection build option files", errs...)
+	}
+
+	return nil
+}
+
+// String return a string representation of the AnsibleGalaxyCollectionBuildOptions
+func (o *AnsibleGalaxyCollectionBuildOptions) String() string {
+	str := ""
+
+	if o.Force {
+		str = fmt.Sprintf("%s %s", str, ForceFlag)
+	}
+
+	if o.OutputPath != "" {
+		str = fmt.Sprintf("%s %s=%s", str, OutputPathFlag, o.OutputPath)
+	}
+
+	if o.Verbose {
+		str = fmt.Sprintf("%s %s", str, VerboseFlag)
+	}
+
+	return strings.TrimSpace(str)
+}
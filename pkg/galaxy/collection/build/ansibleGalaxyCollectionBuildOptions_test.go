@@ -0,0 +1,166 @@
+package galaxycollectionbuild
+
+import (
+	"fmt"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnsibleGalaxyCollectionBuildOptionsGenerateCommandOptions(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionBuildOptions::GenerateCommandOptions)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionBuildOptions
+		err     error
+		expect  []string
+	}{
+		{
+			desc:    "Testing nil AnsibleGalaxyCollectionBuildOptions definition",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyCollectionBuildOptions is nil"),
+		},
+		{
+			desc:    "Testing an empty AnsibleGalaxyCollectionBuildOptions definition",
+			options: &AnsibleGalaxyCollectionBuildOptions{},
+			expect:  []string{},
+		},
+		{
+			desc: "Testing AnsibleGalaxyCollectionBuildOptions with all flags",
+			options: &AnsibleGalaxyCollectionBuildOptions{
+				Force:      true,
+				OutputPath: "path",
+				Verbose:    true,
+			},
+			expect: []string{
+				ForceFlag,
+				fmt.Sprintf("%s=%s", OutputPathFlag, "path"),
+				VerboseFlag,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			options, err := test.options.GenerateCommandOptions()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.expect, options, "Unexpected options value")
+			}
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionBuildOptionsString(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionBuildOptions
+		expect  string
+	}{
+		{
+			desc:    "Testing generate string from an empty AnsibleGalaxyCollectionBuildOptions",
+			options: &AnsibleGalaxyCollectionBuildOptions{},
+			expect:  "",
+		},
+		{
+			desc: "Testing generate string from an AnsibleGalaxyCollectionBuildOptions with all flags",
+			options: &AnsibleGalaxyCollectionBuildOptions{
+				Force:      true,
+				OutputPath: "path",
+				Verbose:    true,
+			},
+			expect: "--force --output-path=path --verbose",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expect, test.options.String())
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionBuildOptionsValidate(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionBuildOptions::Validate)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionBuildOptions
+		err     error
+	}{
+		{
+			desc:    "Testing validate nil AnsibleGalaxyCollectionBuildOptions",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyCollectionBuildOptions is nil"),
+		},
+		{
+			desc: "Testing validate valid AnsibleGalaxyCollectionBuildOptions",
+			options: &AnsibleGalaxyCollectionBuildOptions{
+				Force:      true,
+				OutputPath: "nonexistent-path",
+			},
+			err: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.Validate()
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionBuildOptionsValidateFiles(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionBuildOptions::ValidateFiles)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionBuildOptions
+		err     error
+	}{
+		{
+			desc:    "Testing validate files of nil AnsibleGalaxyCollectionBuildOptions",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyCollectionBuildOptions is nil"),
+		},
+		{
+			desc: "Testing validate files of valid AnsibleGalaxyCollectionBuildOptions",
+			options: &AnsibleGalaxyCollectionBuildOptions{
+				Force:      true,
+				OutputPath: "nonexistent-path",
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing validate files of AnsibleGalaxyCollectionBuildOptions with an output path that is a file",
+			options: &AnsibleGalaxyCollectionBuildOptions{
+				OutputPath: "ansibleGalaxyCollectionBuildOptions.go",
+			},
+			err: errors.New(errContext, "Invalid ansible-galaxy collection build option files",
+				fmt.Errorf("'%s' must be a directory, but 'ansibleGalaxyCollectionBuildOptions.go' is a file", OutputPathFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.ValidateFiles("")
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
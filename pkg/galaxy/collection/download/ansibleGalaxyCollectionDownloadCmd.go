@@ -0,0 +1,144 @@
+package galaxycollectiondownload
+
+import (
+	"fmt"
+
+	galaxy "github.com/apenella/go-ansible/v2/pkg/galaxy"
+	galaxycollection "github.com/apenella/go-ansible/v2/pkg/galaxy/collection"
+)
+
+const (
+	// AnsibleGalaxyCollectionDownloadSubCommand is the ansible-galaxy collection download subcommand
+	AnsibleGalaxyCollectionDownloadSubCommand = "download"
+)
+
+// AnsibleGalaxyCollectionDownloadOptionsFunc is a function to set executor options
+type AnsibleGalaxyCollectionDownloadOptionsFunc func(*AnsibleGalaxyCollectionDownloadCmd)
+
+// AnsibleGalaxyCollectionDownloadCmd object is the main object which defines the `ansible-galaxy` command to download collections.
+type AnsibleGalaxyCollectionDownloadCmd struct {
+	// Binary is the ansible-galaxy binary file
+	Binary string
+
+	// CollectionNames is the ansible-galaxy's collection names to be downloaded
+	CollectionNames []string
+
+	// GalaxyCollectionDownloadOptions are the ansible-galaxy's collection download options
+	GalaxyCollectionDownloadOptions *AnsibleGalaxyCollectionDownloadOptions
+
+	// SkipValidation disables the collection download options validation when the command is generated
+	SkipValidation bool
+	// FileValidation enables checking that the files referenced by the options exist when the command is generated
+	FileValidation bool
+	// FileValidationDir is the directory the relative files are resolved against when the files are validated
+	FileValidationDir string
+}
+
+// NewAnsibleGalaxyCollectionDownloadCmd creates a new AnsibleGalaxyCollectionDownloadCmd instance
+func NewAnsibleGalaxyCollectionDownloadCmd(options ...AnsibleGalaxyCollectionDownloadOptionsFunc) *AnsibleGalaxyCollectionDownloadCmd {
+	cmd := &AnsibleGalaxyCollectionDownloadCmd{}
+
+	for _, option := range options {
+		option(cmd)
+	}
+
+	return cmd
+}
+
+// WithBinary set the ansible-galaxy binary file
+func WithBinary(binary string) AnsibleGalaxyCollectionDownloadOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionDownloadCmd) {
+		p.Binary = binary
+	}
+}
+
+// WithCollectionNames set the ansible-galaxy collection names to be downloaded
+func WithCollectionNames(collectionNames ...string) AnsibleGalaxyCollectionDownloadOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionDownloadCmd) {
+		p.CollectionNames = append([]string{}, collectionNames...)
+	}
+}
+
+// WithGalaxyCollectionDownloadOptions set the ansible-galaxy collection download options
+func WithGalaxyCollectionDownloadOptions(options *AnsibleGalaxyCollectionDownloadOptions) AnsibleGalaxyCollectionDownloadOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionDownloadCmd) {
+		p.GalaxyCollectionDownloadOptions = options
+	}
+}
+
+// WithoutValidation disables the ansible-galaxy collection download options validation
+func WithoutValidation() AnsibleGalaxyCollectionDownloadOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionDownloadCmd) {
+		p.SkipValidation = true
+	}
+}
+
+// WithFileValidation enables checking that the files referenced by the ansible-galaxy collection download options exist. The relative files are resolved against dir, which is the directory where the command runs, or against the working directory when dir is empty. Do not enable it when the command runs on another host or container
+func WithFileValidation(dir string) AnsibleGalaxyCollectionDownloadOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionDownloadCmd) {
+		p.FileValidation = true
+		p.FileValidationDir = dir
+	}
+}
+
+// Command generate the ansible-galaxy collection download command which will be executed
+func (p *AnsibleGalaxyCollectionDownloadCmd) Command() ([]string, error) {
+	cmd := []string{}
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = galaxy.DefaultAnsibleGalaxyBinary
+	}
+
+	cmd = append(cmd, p.Binary, galaxycollection.AnsibleGalaxyCollectionSubCommand, AnsibleGalaxyCollectionDownloadSubCommand)
+
+	// Add the options
+	if p.GalaxyCollectionDownloadOptions != nil {
+		if !p.SkipValidation {
+			err := p.GalaxyCollectionDownloadOptions.Validate()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if p.FileValidation {
+			err := p.GalaxyCollectionDownloadOptions.ValidateFiles(p.FileValidationDir)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		options, err := p.GalaxyCollectionDownloadOptions.GenerateCommandOptions()
+		if err != nil {
+			return nil, err
+		}
+		cmd = append(cmd, options...)
+	}
+
+	// Add the collection names
+	cmd = append(cmd, p.CollectionNames...)
+
+	return cmd, nil
+}
+
+// String returns the ansible-galaxy collection download command as a string
+func (p *AnsibleGalaxyCollectionDownloadCmd) String() string {
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = galaxy.DefaultAnsibleGalaxyBinary
+	}
+
+	str := fmt.Sprintf("%s %s %s", p.Binary, galaxycollection.AnsibleGalaxyCollectionSubCommand, AnsibleGalaxyCollectionDownloadSubCommand)
+
+	if p.GalaxyCollectionDownloadOptions != nil {
+		str = fmt.Sprintf("%s %s", str, p.GalaxyCollectionDownloadOptions.String())
+	}
+
+	// Include the collection names
+	for _, collectionName := range p.CollectionNames {
+		str = fmt.Sprintf("%s %s", str, collectionName)
+	}
+
+	return str
+}
@@ -0,0 +1,154 @@
+package galaxycollectiondownload
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/galaxy"
+	galaxycollection "github.com/apenella/go-ansible/v2/pkg/galaxy/collection"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleGalaxyCollectionDownloadCmd(t *testing.T) {
+	cmd := NewAnsibleGalaxyCollectionDownloadCmd(
+		WithBinary("ansible-galaxy-binary"),
+		WithCollectionNames("community.general", "ansible.posix"),
+		WithGalaxyCollectionDownloadOptions(&AnsibleGalaxyCollectionDownloadOptions{
+			APIKey: "apikey",
+		}),
+	)
+
+	expect := &AnsibleGalaxyCollectionDownloadCmd{
+		Binary:          "ansible-galaxy-binary",
+		CollectionNames: []string{"community.general", "ansible.posix"},
+		GalaxyCollectionDownloadOptions: &AnsibleGalaxyCollectionDownloadOptions{
+			APIKey: "apikey",
+		},
+	}
+
+	assert.Equal(t, expect, cmd)
+}
+
+func TestAnsibleGalaxyCollectionDownloadCmdCommand(t *testing.T) {
+
+	tests := []struct {
+		desc    string
+		cmd     *AnsibleGalaxyCollectionDownloadCmd
+		command []string
+		err     error
+	}{
+		{
+			desc: "Testing generate a command for AnsibleGalaxyCollectionDownloadCmd with all flags using default binary",
+			cmd: NewAnsibleGalaxyCollectionDownloadCmd(
+				WithoutValidation(),
+				WithCollectionNames("community.general", "ansible.posix"),
+				WithGalaxyCollectionDownloadOptions(&AnsibleGalaxyCollectionDownloadOptions{
+					APIKey:             "apikey",
+					ClearResponseCache: true,
+					DownloadPath:       "path",
+					IgnoreCerts:        true,
+					NoCache:            true,
+					NoDeps:             true,
+					Pre:                true,
+					RequirementsFile:   "requirements",
+					Server:             "server",
+					Timeout:            "10",
+					Token:              "token",
+					Verbose:            true,
+				}),
+			),
+			err: &errors.Error{},
+			command: []string{
+				galaxy.DefaultAnsibleGalaxyBinary,
+				galaxycollection.AnsibleGalaxyCollectionSubCommand,
+				AnsibleGalaxyCollectionDownloadSubCommand,
+				fmt.Sprintf("%s=%s", APIKeyFlag, "apikey"),
+				ClearResponseCacheFlag,
+				fmt.Sprintf("%s=%s", DownloadPathFlag, "path"),
+				IgnoreCertsFlag,
+				NoCacheFlag,
+				NoDepsFlag,
+				PreFlag,
+				fmt.Sprintf("%s=%s", RequirementsFileFlag, "requirements"),
+				fmt.Sprintf("%s=%s", ServerFlag, "server"),
+				fmt.Sprintf("%s=%s", TimeoutFlag, "10"),
+				fmt.Sprintf("%s=%s", TokenFlag, "token"),
+				VerboseFlag,
+				"community.general",
+				"ansible.posix",
+			},
+		},
+		{
+			desc: "Testing error generating a command for AnsibleGalaxyCollectionDownloadCmd with invalid options",
+			cmd: NewAnsibleGalaxyCollectionDownloadCmd(
+				WithGalaxyCollectionDownloadOptions(&AnsibleGalaxyCollectionDownloadOptions{
+					Timeout: "0",
+				}),
+			),
+			err: errors.New("(galaxy::AnsibleGalaxyCollectionDownloadOptions::Validate)", "Invalid ansible-galaxy collection download options",
+				fmt.Errorf("'%s' must be a positive integer, but '0' was provided", TimeoutFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			command, err := test.cmd.Command()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.command, command)
+			}
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionDownloadCmdString(t *testing.T) {
+
+	tests := []struct {
+		desc string
+		cmd  *AnsibleGalaxyCollectionDownloadCmd
+		res  string
+	}{
+		{
+			desc: "Testing AnsibleGalaxyCollectionDownloadCmd to string with all flags",
+			cmd: NewAnsibleGalaxyCollectionDownloadCmd(
+				WithCollectionNames("community.general", "ansible.posix"),
+				WithGalaxyCollectionDownloadOptions(&AnsibleGalaxyCollectionDownloadOptions{
+					APIKey:             "apikey",
+					ClearResponseCache: true,
+					DownloadPath:       "path",
+					IgnoreCerts:        true,
+					NoCache:            true,
+					NoDeps:             true,
+					Pre:                true,
+					RequirementsFile:   "requirements",
+					Server:             "server",
+					Timeout:            "10",
+					Token:              "token",
+					Verbose:            true,
+				}),
+			),
+			res: "ansible-galaxy collection download --api-key=apikey --clear-response-cache --download-path=path --ignore-certs --no-cache --no-deps --pre --requirements-file=requirements --server=server --timeout=10 --token=token --verbose community.general ansible.posix",
+		},
+		{
+			desc: "Testing AnsibleGalaxyCollectionDownloadCmd to string using a custom binary",
+			cmd: NewAnsibleGalaxyCollectionDownloadCmd(
+				WithBinary("custom-binary"),
+				WithCollectionNames("community.general", "ansible.posix"),
+			),
+			res: "custom-binary collection download community.general ansible.posix",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, test.cmd.String())
+		})
+	}
+}
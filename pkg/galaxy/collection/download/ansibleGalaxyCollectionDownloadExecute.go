@@ -0,0 +1,67 @@
+package galaxycollectiondownload
+
+import (
+	"context"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+)
+
+// AnsibleGalaxyCollectionDownloadExecute is an executor for ansible-galaxy collection download command that runs the command using a DefaultExecute with default options
+type AnsibleGalaxyCollectionDownloadExecute struct {
+	cmd  *AnsibleGalaxyCollectionDownloadCmd
+	exec execute.Executabler
+}
+
+// NewAnsibleGalaxyCollectionDownloadExecute returns a new AnsibleGalaxyCollectionDownloadExecute. It receives the collection names to download
+func NewAnsibleGalaxyCollectionDownloadExecute(collectionNames ...string) *AnsibleGalaxyCollectionDownloadExecute {
+
+	exec := &AnsibleGalaxyCollectionDownloadExecute{
+		cmd: &AnsibleGalaxyCollectionDownloadCmd{
+			CollectionNames: append([]string{}, collectionNames...),
+		},
+	}
+
+	return exec
+}
+
+// WithBinary returns an AnsibleGalaxyCollectionDownloadExecute with the binary file set
+func (e *AnsibleGalaxyCollectionDownloadExecute) WithBinary(binary string) *AnsibleGalaxyCollectionDownloadExecute {
+	e.cmd.Binary = binary
+
+	return e
+}
+
+// WithExecutable returns an AnsibleGalaxyCollectionDownloadExecute with the executable used to run the command set
+func (e *AnsibleGalaxyCollectionDownloadExecute) WithExecutable(executable execute.Executabler) *AnsibleGalaxyCollectionDownloadExecute {
+	e.exec = executable
+
+	return e
+}
+
+// WithGalaxyCollectionDownloadOptions returns an AnsibleGalaxyCollectionDownloadExecute with the ansible-galaxy collection download options set
+func (e *AnsibleGalaxyCollectionDownloadExecute) WithGalaxyCollectionDownloadOptions(options *AnsibleGalaxyCollectionDownloadOptions) *AnsibleGalaxyCollectionDownloadExecute {
+	e.cmd.GalaxyCollectionDownloadOptions = options
+
+	return e
+}
+
+// Execute method runs the ansible-galaxy collection download command using a DefaultExecute with default options
+func (e *AnsibleGalaxyCollectionDownloadExecute) Execute(ctx context.Context) error {
+
+	options := []execute.ExecuteOptions{
+		execute.WithCmd(e.cmd),
+	}
+
+	if e.exec != nil {
+		options = append(options, execute.WithExecutable(e.exec))
+	}
+
+	exec := execute.NewDefaultExecute(options...)
+
+	err := exec.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package galaxycollectiondownload
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleGalaxyCollectionDownloadExecute(t *testing.T) {
+	expect := &AnsibleGalaxyCollectionDownloadExecute{
+		cmd: &AnsibleGalaxyCollectionDownloadCmd{
+			CollectionNames: []string{"community.general", "ansible.posix"},
+		},
+	}
+
+	res := NewAnsibleGalaxyCollectionDownloadExecute("community.general", "ansible.posix")
+
+	assert.Equal(t, expect, res)
+}
+
+func TestAnsibleGalaxyCollectionDownloadExecuteExecute(t *testing.T) {
+
+	e := exec.NewMockExec()
+	cmd := exec.NewMockCmd()
+
+	cmd.On("StdoutPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+	cmd.On("StderrPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+	cmd.On("Start").Return(nil)
+	cmd.On("Wait").Return(nil)
+	// the compatibility check detects the ansible version, unless it is already cached
+	versionCmd := exec.NewMockCmd()
+	versionCmd.On("Output").Return([]byte("ansible-galaxy [core 2.16.3]\n"), nil).Maybe()
+	e.On("CommandContext", context.TODO(), "custom-binary", []string{"--version"}).Return(versionCmd).Maybe()
+	e.On("CommandContext", context.TODO(), "custom-binary", []string{"collection", "download", "--api-key=apikey", "community.general", "ansible.posix"}).Return(cmd)
+
+	err := NewAnsibleGalaxyCollectionDownloadExecute("community.general", "ansible.posix").
+		WithBinary("custom-binary").
+		WithExecutable(e).
+		WithGalaxyCollectionDownloadOptions(&AnsibleGalaxyCollectionDownloadOptions{
+			APIKey: "apikey",
+		}).
+		Execute(context.TODO())
+
+	assert.NoError(t, err)
+	e.AssertExpectations(t)
+	cmd.AssertExpectations(t)
+}
@@ -0,0 +1,161 @@
+package galaxycollectiondownload
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/galaxy"
+	"github.com/stretchr/testify/assert"
+)
+
+// galaxyStandIn is a minimal Galaxy v3 API server that serves a single collection version
+type galaxyStandIn struct {
+	artifact  []byte
+	namespace string
+	name      string
+	version   string
+	requested []string
+	mutex     sync.Mutex
+}
+
+func (g *galaxyStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mutex.Lock()
+	g.requested = append(g.requested, r.URL.Path)
+	g.mutex.Unlock()
+
+	collectionPath := "/api/v3/collections/" + g.namespace + "/" + g.name + "/"
+	versionPath := collectionPath + "versions/" + g.version + "/"
+	artifactPath := "/download/" + g.namespace + "-" + g.name + "-" + g.version + ".tar.gz"
+	sum := sha256.Sum256(g.artifact)
+
+	var body interface{}
+
+	switch r.URL.Path {
+	case "/api/":
+		body = map[string]interface{}{
+			"available_versions": map[string]string{"v3": "v3/"},
+		}
+	case collectionPath:
+		body = map[string]interface{}{
+			"namespace":       g.namespace,
+			"name":            g.name,
+			"highest_version": map[string]string{"version": g.version, "href": versionPath},
+		}
+	case collectionPath + "versions/":
+		body = map[string]interface{}{
+			"meta":  map[string]int{"count": 1},
+			"links": map[string]interface{}{"first": collectionPath + "versions/", "next": nil},
+			"data": []map[string]string{
+				{"version": g.version, "href": versionPath},
+			},
+		}
+	case versionPath:
+		body = map[string]interface{}{
+			"namespace":    map[string]string{"name": g.namespace},
+			"collection":   map[string]string{"name": g.name},
+			"version":      g.version,
+			"href":         versionPath,
+			"download_url": "http://" + r.Host + artifactPath,
+			"artifact": map[string]interface{}{
+				"filename": filepath.Base(artifactPath),
+				"sha256":   hex.EncodeToString(sum[:]),
+				"size":     len(g.artifact),
+			},
+			"metadata":   map[string]interface{}{"dependencies": map[string]string{}},
+			"signatures": []string{},
+		}
+	case artifactPath:
+		w.Header().Set("Content-Type", "application/gzip")
+		_, _ = w.Write(g.artifact)
+		return
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// collectionArtifact returns a collection tarball that only contains the MANIFEST.json file
+func collectionArtifact(t *testing.T, namespace, name, version string) []byte {
+	manifest, err := json.Marshal(map[string]interface{}{
+		"collection_info": map[string]interface{}{
+			"namespace":    namespace,
+			"name":         name,
+			"version":      version,
+			"authors":      []string{"go-ansible"},
+			"dependencies": map[string]string{},
+		},
+		"format": 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buff := &bytes.Buffer{}
+	gz := gzip.NewWriter(buff)
+	tw := tar.NewWriter(gz)
+
+	err = tw.WriteHeader(&tar.Header{Name: "MANIFEST.json", Mode: 0644, Size: int64(len(manifest))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = tw.Write(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = tw.Close()
+	_ = gz.Close()
+
+	return buff.Bytes()
+}
+
+func TestAnsibleGalaxyCollectionDownloadFunctional(t *testing.T) {
+
+	_, err := exec.LookPath(galaxy.DefaultAnsibleGalaxyBinary)
+	if err != nil {
+		t.Skipf("%s binary is not available", galaxy.DefaultAnsibleGalaxyBinary)
+	}
+
+	standIn := &galaxyStandIn{
+		artifact:  collectionArtifact(t, "internal", "tools", "1.0.0"),
+		namespace: "internal",
+		name:      "tools",
+		version:   "1.0.0",
+	}
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	downloadPath := t.TempDir()
+	t.Setenv("ANSIBLE_GALAXY_CACHE_DIR", t.TempDir())
+
+	err = NewAnsibleGalaxyCollectionDownloadExecute("internal.tools:1.0.0").
+		WithGalaxyCollectionDownloadOptions(&AnsibleGalaxyCollectionDownloadOptions{
+			DownloadPath: downloadPath,
+			NoCache:      true,
+			Server:       server.URL + "/api/",
+		}).
+		Execute(context.TODO())
+	if !assert.NoError(t, err) {
+		t.Log(standIn.requested)
+		return
+	}
+
+	_, err = os.Stat(filepath.Join(downloadPath, "internal-tools-1.0.0.tar.gz"))
+	assert.NoError(t, err)
+	assert.Contains(t, standIn.requested, "/download/internal-tools-1.0.0.tar.gz")
+}
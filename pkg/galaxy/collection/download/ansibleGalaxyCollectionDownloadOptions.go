@@ -0,0 +1,255 @@
+package galaxycollectiondownload
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/apenella/go-ansible/v2/pkg/internal/validation"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+
+	// APIKeyFlag the Ansible Galaxy API key. Same as --token
+	APIKeyFlag = "--api-key"
+
+	// ClearResponseCacheFlag clears the existing server response cache.
+	ClearResponseCacheFlag = "--clear-response-cache"
+
+	// DownloadPathFlag is the directory to download the collections to.
+	DownloadPathFlag = "--download-path"
+
+	// IgnoreCertsFlag ignores SSL certificate validation errors.
+	IgnoreCertsFlag = "--ignore-certs"
+
+	// NoCacheFlag does not use the server response cache.
+	NoCacheFlag = "--no-cache"
+
+	// NoDepsFlag doesn't download collections listed as dependencies.
+	NoDepsFlag = "--no-deps"
+
+	// PreFlag includes pre-release versions. Semantic versioning pre-releases are ignored by default.
+	PreFlag = "--pre"
+
+	// RequirementsFileFlag is a file containing a list of collections to be downloaded.
+	RequirementsFileFlag = "--requirements-file"
+
+	// ServerFlag is the Galaxy API server URL.
+	ServerFlag = "--server"
+
+	// TimeoutFlag is the time to wait for operations against the galaxy server, defaults to 60s.
+	TimeoutFlag = "--timeout"
+
+	// TokenFlag the Ansible Galaxy API key. Same as --api-key
+	TokenFlag = "--token"
+
+	// VerboseFlag verbose mode enabled
+	VerboseFlag = "--verbose"
+)
+
+// AnsibleGalaxyCollectionDownloadOptions are the ansible-galaxy collection download options
+type AnsibleGalaxyCollectionDownloadOptions struct {
+
+	// APIKey is the Ansible Galaxy API key.
+	APIKey string
+
+	// ClearResponseCache clears the existing server response cache.
+	ClearResponseCache bool
+
+	// DownloadPath is the directory to download the collections to.
+	DownloadPath string
+
+	// IgnoreCerts ignores SSL certificate validation errors.
+	IgnoreCerts bool
+
+	// NoCache does not use the server response cache.
+	NoCache bool
+
+	// NoDeps doesn't download collections listed as dependencies.
+	NoDeps bool
+
+	// Pre includes pre-release versions. Semantic versioning pre-releases are ignored by default.
+	Pre bool
+
+	// RequirementsFile is a file containing a list of collections to be downloaded.
+	RequirementsFile string
+
+	// Server is the Galaxy API server URL.
+	Server string
+
+	// Timeout is the time to wait for operations against the galaxy server, defaults to 60s.
+	Timeout string
+
+	// Token is the Ansible Galaxy API key.
+	Token string
+
+	// Verbose verbose mode enabled
+	Verbose bool
+}
+
+// GenerateCommandOptions return a list of command options flags to be used on ansible-galaxy collection download execution
+func (o *AnsibleGalaxyCollectionDownloadOptions) GenerateCommandOptions() ([]string, error) {
+	errContext := "(galaxy::AnsibleGalaxyCollectionDownloadOptions::GenerateCommandOptions)"
+	options := []string{}
+
+	if o == nil {
+		return nil, errors.New(errContext, "AnsibleGalaxyCollectionDownloadOptions is nil")
+	}
+
+	if o.APIKey != "" {
+		options = append(options, fmt.Sprintf("%s=%s", APIKeyFlag, o.APIKey))
+	}
+
+	if o.ClearResponseCache {
+		options = append(options, ClearResponseCacheFlag)
+	}
+
+	if o.DownloadPath != "" {
+		options = append(options, fmt.Sprintf("%s=%s", DownloadPathFlag, o.DownloadPath))
+	}
+
+	if o.IgnoreCerts {
+		options = append(options, IgnoreCertsFlag)
+	}
+
+	if o.NoCache {
+		options = append(options, NoCacheFlag)
+	}
+
+	if o.NoDeps {
+		options = append(options, NoDepsFlag)
+	}
+
+	if o.Pre {
+		options = append(options, PreFlag)
+	}
+
+	if o.RequirementsFile != "" {
+		options = append(options, fmt.Sprintf("%s=%s", RequirementsFileFlag, o.RequirementsFile))
+	}
+
+	if o.Server != "" {
+		options = append(options, fmt.Sprintf("%s=%s", ServerFlag, o.Server))
+	}
+
+	if o.Timeout != "" {
+		options = append(options, fmt.Sprintf("%s=%s", TimeoutFlag, o.Timeout))
+	}
+
+	if o.Token != "" {
+		options = append(options, fmt.Sprintf("%s=%s", TokenFlag, o.Token))
+	}
+
+	if o.Verbose {
+		options = append(options, VerboseFlag)
+	}
+
+	return options, nil
+}
+
+// Validate checks the options looking for mutually exclusive flags and unsupported values. It returns an error that wraps all the detected issues
+func (o *AnsibleGalaxyCollectionDownloadOptions) Validate() error {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionDownloadOptions::Validate)"
+	errs := []error{}
+
+	if o == nil {
+		return errors.New(errContext, "AnsibleGalaxyCollectionDownloadOptions is nil")
+	}
+
+	if o.APIKey != "" && o.Token != "" {
+		errs = append(errs, fmt.Errorf("'%s' and '%s' are mutually exclusive", APIKeyFlag, TokenFlag))
+	}
+
+	if o.Timeout != "" {
+		timeout, err := strconv.Atoi(o.Timeout)
+		if err != nil || timeout < 1 {
+			errs = append(errs, fmt.Errorf("'%s' must be a positive integer, but '%s' was provided", TimeoutFlag, o.Timeout))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid ansible-galaxy collection download options", errs...)
+	}
+
+	return nil
+}
+
+// ValidateFiles checks that the files referenced by the options exist and are not directories. The relative files are resolved against dir, which is the directory where the command runs, or against the working directory when dir is empty. It is not part of Validate because the command may run on another directory, host or container
+func (o *AnsibleGalaxyCollectionDownloadOptions) ValidateFiles(dir string) error {
+	errContext := "(galaxy::AnsibleGalaxyCollectionDownloadOptions::ValidateFiles)"
+	errs := []error{}
+
+	if o == nil {
+		return errors.New(errContext, "AnsibleGalaxyCollectionDownloadOptions is nil")
+	}
+
+	if o.RequirementsFile != "" {
+		err := validation.FileExists(dir, o.RequirementsFile)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("'%s' file is not valid: %w", RequirementsFileFlag, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid ansible-galaxy collection download option files", errs...)
+	}
+
+	return nil
+}
+
+// String return a string representation of the AnsibleGalaxyCollectionDownloadOptions
+func (o *AnsibleGalaxyCollectionDownloadOptions) String() string {
+	str := ""
+
+	if o.APIKey != "" {
+		str = fmt.Sprintf("%s %s=%s", str, APIKeyFlag, o.APIKey)
+	}
+
+	if o.ClearResponseCache {
+		str = fmt.Sprintf("%s %s", str, ClearResponseCacheFlag)
+	}
+
+	if o.DownloadPath != "" {
+		str = fmt.Sprintf("%s %s=%s", str, DownloadPathFlag, o.DownloadPath)
+	}
+
+	if o.IgnoreCerts {
+		str = fmt.Sprintf("%s %s", str, IgnoreCertsFlag)
+	}
+
+	if o.NoCache {
+		str = fmt.Sprintf("%s %s", str, NoCacheFlag)
+	}
+
+	if o.NoDeps {
+		str = fmt.Sprintf("%s %s", str, NoDepsFlag)
+	}
+
+	if o.Pre {
+		str = fmt.Sprintf("%s %s", str, PreFlag)
+	}
+
+	if o.RequirementsFile != "" {
+		str = fmt.Sprintf("%s %s=%s", str, RequirementsFileFlag, o.RequirementsFile)
+	}
+
+	if o.Server != "" {
+		str = fmt.Sprintf("%s %s=%s", str, ServerFlag, o.Server)
+	}
+
+	if o.Timeout != "" {
+		str = fmt.Sprintf("%s %s=%s", str, TimeoutFlag, o.Timeout)
+	}
+
+	if o.Token != "" {
+		str = fmt.Sprintf("%s %s=%s", str, TokenFlag, o.Token)
+	}
+
+	if o.Verbose {
+		str = fmt.Sprintf("%s %s", str, VerboseFlag)
+	}
+
+	return strings.TrimSpace(str)
+}
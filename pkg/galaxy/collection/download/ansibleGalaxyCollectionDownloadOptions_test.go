@@ -0,0 +1,218 @@
+package galaxycollectiondownload
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnsibleGalaxyCollectionDownloadOptionsGenerateCommandOptions(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionDownloadOptions::GenerateCommandOptions)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionDownloadOptions
+		err     error
+		expect  []string
+	}{
+		{
+			desc:    "Testing nil AnsibleGalaxyCollectionDownloadOptions definition",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyCollectionDownloadOptions is nil"),
+		},
+		{
+			desc:    "Testing an empty AnsibleGalaxyCollectionDownloadOptions definition",
+			options: &AnsibleGalaxyCollectionDownloadOptions{},
+			expect:  []string{},
+		},
+		{
+			desc: "Testing AnsibleGalaxyCollectionDownloadOptions with all flags",
+			options: &AnsibleGalaxyCollectionDownloadOptions{
+				APIKey:             "apikey",
+				ClearResponseCache: true,
+				DownloadPath:       "path",
+				IgnoreCerts:        true,
+				NoCache:            true,
+				NoDeps:             true,
+				Pre:                true,
+				RequirementsFile:   "requirements",
+				Server:             "server",
+				Timeout:            "10",
+				Token:              "token",
+				Verbose:            true,
+			},
+			expect: []string{
+				fmt.Sprintf("%s=%s", APIKeyFlag, "apikey"),
+				ClearResponseCacheFlag,
+				fmt.Sprintf("%s=%s", DownloadPathFlag, "path"),
+				IgnoreCertsFlag,
+				NoCacheFlag,
+				NoDepsFlag,
+				PreFlag,
+				fmt.Sprintf("%s=%s", RequirementsFileFlag, "requirements"),
+				fmt.Sprintf("%s=%s", ServerFlag, "server"),
+				fmt.Sprintf("%s=%s", TimeoutFlag, "10"),
+				fmt.Sprintf("%s=%s", TokenFlag, "token"),
+				VerboseFlag,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			options, err := test.options.GenerateCommandOptions()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.expect, options, "Unexpected options value")
+			}
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionDownloadOptionsString(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionDownloadOptions
+		expect  string
+	}{
+		{
+			desc:    "Testing generate string from an empty AnsibleGalaxyCollectionDownloadOptions",
+			options: &AnsibleGalaxyCollectionDownloadOptions{},
+			expect:  "",
+		},
+		{
+			desc: "Testing generate string from an AnsibleGalaxyCollectionDownloadOptions with all flags",
+			options: &AnsibleGalaxyCollectionDownloadOptions{
+				APIKey:             "apikey",
+				ClearResponseCache: true,
+				DownloadPath:       "path",
+				IgnoreCerts:        true,
+				NoCache:            true,
+				NoDeps:             true,
+				Pre:                true,
+				RequirementsFile:   "requirements",
+				Server:             "server",
+				Timeout:            "10",
+				Token:              "token",
+				Verbose:            true,
+			},
+			expect: "--api-key=apikey --clear-response-cache --download-path=path --ignore-certs --no-cache --no-deps --pre --requirements-file=requirements --server=server --timeout=10 --token=token --verbose",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expect, test.options.String())
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionDownloadOptionsValidate(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionDownloadOptions::Validate)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionDownloadOptions
+		err     error
+	}{
+		{
+			desc:    "Testing validate nil AnsibleGalaxyCollectionDownloadOptions",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyCollectionDownloadOptions is nil"),
+		},
+		{
+			desc: "Testing validate valid AnsibleGalaxyCollectionDownloadOptions",
+			options: &AnsibleGalaxyCollectionDownloadOptions{
+				DownloadPath: "path",
+				NoDeps:       true,
+				Timeout:      "10",
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing validate invalid AnsibleGalaxyCollectionDownloadOptions",
+			options: &AnsibleGalaxyCollectionDownloadOptions{
+				APIKey:           "apikey",
+				RequirementsFile: "nonexistent-requirements.yml",
+				Timeout:          "ten",
+				Token:            "token",
+			},
+			err: errors.New(errContext, "Invalid ansible-galaxy collection download options",
+				fmt.Errorf("'%s' and '%s' are mutually exclusive", APIKeyFlag, TokenFlag),
+				fmt.Errorf("'%s' must be a positive integer, but 'ten' was provided", TimeoutFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.Validate()
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionDownloadOptionsValidateFiles(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionDownloadOptions::ValidateFiles)"
+
+	tempDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(tempDir, "requirements.yml"), []byte("---\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionDownloadOptions
+		dir     string
+		err     error
+	}{
+		{
+			desc:    "Testing validate files of nil AnsibleGalaxyCollectionDownloadOptions",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyCollectionDownloadOptions is nil"),
+		},
+		{
+			desc: "Testing validate files of AnsibleGalaxyCollectionDownloadOptions resolving the requirements file against the directory",
+			options: &AnsibleGalaxyCollectionDownloadOptions{
+				RequirementsFile: "requirements.yml",
+			},
+			dir: tempDir,
+			err: nil,
+		},
+		{
+			desc: "Testing validate files of AnsibleGalaxyCollectionDownloadOptions with a nonexistent requirements file",
+			options: &AnsibleGalaxyCollectionDownloadOptions{
+				RequirementsFile: "nonexistent-requirements.yml",
+			},
+			err: errors.New(errContext, "Invalid ansible-galaxy collection download option files",
+				fmt.Errorf("'%s' file is not valid: %w", RequirementsFileFlag, &fs.PathError{Op: "stat", Path: "nonexistent-requirements.yml", Err: syscall.ENOENT}),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.ValidateFiles(test.dir)
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package galaxycollectioninit
+
+import (
+	"fmt"
+
+	galaxy "github.com/apenella/go-ansible/v2/pkg/galaxy"
+	galaxycollection "github.com/apenella/go-ansible/v2/pkg/galaxy/collection"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// AnsibleGalaxyCollectionInitSubCommand is the ansible-galaxy collection init subcommand
+	AnsibleGalaxyCollectionInitSubCommand = "init"
+)
+
+// AnsibleGalaxyCollectionInitOptionsFunc is a function to set executor options
+type AnsibleGalaxyCollectionInitOptionsFunc func(*AnsibleGalaxyCollectionInitCmd)
+
+// AnsibleGalaxyCollectionInitCmd object is the main object which defines the `ansible-galaxy` command to create the skeleton of a new collection.
+type AnsibleGalaxyCollectionInitCmd struct {
+	// Binary is the ansible-galaxy binary file
+	Binary string
+
+	// CollectionName is the name of the collection to be created, in the form namespace.collection
+	CollectionName string
+
+	// GalaxyCollectionInitOptions are the ansible-galaxy's collection init options
+	GalaxyCollectionInitOptions *AnsibleGalaxyCollectionInitOptions
+
+	// SkipValidation disables the collection init options validation when the command is generated
+	SkipValidation bool
+	// FileValidation enables checking that the paths referenced by the options are directories when the command is generated
+	FileValidation bool
+	// FileValidationDir is the directory the relative paths are resolved against when the files are validated
+	FileValidationDir string
+}
+
+// NewAnsibleGalaxyCollectionInitCmd creates a new AnsibleGalaxyCollectionInitCmd instance
+func NewAnsibleGalaxyCollectionInitCmd(options ...AnsibleGalaxyCollectionInitOptionsFunc) *AnsibleGalaxyCollectionInitCmd {
+	cmd := &AnsibleGalaxyCollectionInitCmd{}
+
+	for _, option := range options {
+		option(cmd)
+	}
+
+	return cmd
+}
+
+// WithBinary set the ansible-galaxy binary file
+func WithBinary(binary string) AnsibleGalaxyCollectionInitOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionInitCmd) {
+		p.Binary = binary
+	}
+}
+
+// WithCollectionName set the name of the collection to be created
+func WithCollectionName(collectionName string) AnsibleGalaxyCollectionInitOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionInitCmd) {
+		p.CollectionName = collectionName
+	}
+}
+
+// WithGalaxyCollectionInitOptions set the ansible-galaxy collection init options
+func WithGalaxyCollectionInitOptions(options *AnsibleGalaxyCollectionInitOptions) AnsibleGalaxyCollectionInitOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionInitCmd) {
+		p.GalaxyCollectionInitOptions = options
+	}
+}
+
+// WithoutValidation disables the ansible-galaxy collection init options validation
+func WithoutValidation() AnsibleGalaxyCollectionInitOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionInitCmd) {
+		p.SkipValidation = true
+	}
+}
+
+// WithFileValidation enables checking that the paths referenced by the ansible-galaxy collection init options are directories. The relative paths are resolved against dir, which is the directory where the command runs, or against the working directory when dir is empty. Do not enable it when the command runs on another host or container
+func WithFileValidation(dir string) AnsibleGalaxyCollectionInitOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionInitCmd) {
+		p.FileValidation = true
+		p.FileValidationDir = dir
+	}
+}
+
+// Command generate the ansible-galaxy collection init command which will be executed
+func (p *AnsibleGalaxyCollectionInitCmd) Command() ([]string, error) {
+	errContext := "(galaxy::AnsibleGalaxyCollectionInitCmd::Command)"
+	cmd := []string{}
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = galaxy.DefaultAnsibleGalaxyBinary
+	}
+
+	if p.CollectionName == "" {
+		return nil, errors.New(errContext, "No collection name defined")
+	}
+
+	cmd = append(cmd, p.Binary, galaxycollection.AnsibleGalaxyCollectionSubCommand, AnsibleGalaxyCollectionInitSubCommand)
+
+	// Add the options
+	if p.GalaxyCollectionInitOptions != nil {
+		if !p.SkipValidation {
+			err := p.GalaxyCollectionInitOptions.Validate()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if p.FileValidation {
+			err := p.GalaxyCollectionInitOptions.ValidateFiles(p.FileValidationDir)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		options, err := p.GalaxyCollectionInitOptions.GenerateCommandOptions()
+		if err != nil {
+			return nil, err
+		}
+		cmd = append(cmd, options...)
+	}
+
+	// Add the collection name
+	if p.CollectionName != "" {
+		cmd = append(cmd, p.CollectionName)
+	}
+
+	return cmd, nil
+}
+
+// String returns the ansible-galaxy collection init command as a string
+func (p *AnsibleGalaxyCollectionInitCmd) String() string {
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = galaxy.DefaultAnsibleGalaxyBinary
+	}
+
+	str := fmt.Sprintf("%s %s %s", p.Binary, galaxycollection.AnsibleGalaxyCollectionSubCommand, AnsibleGalaxyCollectionInitSubCommand)
+
+	if p.GalaxyCollectionInitOptions != nil {
+		str = fmt.Sprintf("%s %s", str, p.GalaxyCollectionInitOptions.String())
+	}
+
+	// Include the collection name
+	if p.CollectionName != "" {
+		str = fmt.Sprintf("%s %s", str, p.CollectionName)
+	}
+
+	return str
+}
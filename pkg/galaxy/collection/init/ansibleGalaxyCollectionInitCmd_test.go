@@ -0,0 +1,123 @@
+package galaxycollectioninit
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/galaxy"
+	galaxycollection "github.com/apenella/go-ansible/v2/pkg/galaxy/collection"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleGalaxyCollectionInitCmd(t *testing.T) {
+	cmd := NewAnsibleGalaxyCollectionInitCmd(
+		WithBinary("ansible-galaxy-binary"),
+		WithCollectionName("internal.tools"),
+		WithGalaxyCollectionInitOptions(&AnsibleGalaxyCollectionInitOptions{
+			Force: true,
+		}),
+	)
+
+	expect := &AnsibleGalaxyCollectionInitCmd{
+		Binary:         "ansible-galaxy-binary",
+		CollectionName: "internal.tools",
+		GalaxyCollectionInitOptions: &AnsibleGalaxyCollectionInitOptions{
+			Force: true,
+		},
+	}
+
+	assert.Equal(t, expect, cmd)
+}
+
+func TestAnsibleGalaxyCollectionInitCmdCommand(t *testing.T) {
+
+	tests := []struct {
+		desc    string
+		cmd     *AnsibleGalaxyCollectionInitCmd
+		command []string
+		err     error
+	}{
+		{
+			desc: "Testing generate a command for AnsibleGalaxyCollectionInitCmd with all flags using default binary",
+			cmd: NewAnsibleGalaxyCollectionInitCmd(
+				WithoutValidation(),
+				WithCollectionName("internal.tools"),
+				WithGalaxyCollectionInitOptions(&AnsibleGalaxyCollectionInitOptions{
+					CollectionSkeleton: "skeleton",
+					Force:              true,
+					InitPath:           "path",
+					Verbose:            true,
+				}),
+			),
+			err: &errors.Error{},
+			command: []string{
+				galaxy.DefaultAnsibleGalaxyBinary,
+				galaxycollection.AnsibleGalaxyCollectionSubCommand,
+				AnsibleGalaxyCollectionInitSubCommand,
+				fmt.Sprintf("%s=%s", CollectionSkeletonFlag, "skeleton"),
+				ForceFlag,
+				fmt.Sprintf("%s=%s", InitPathFlag, "path"),
+				VerboseFlag,
+				"internal.tools",
+			},
+		},
+		{
+			desc: "Testing error generating a command for AnsibleGalaxyCollectionInitCmd without collection name",
+			cmd:  NewAnsibleGalaxyCollectionInitCmd(),
+			err:  errors.New("(galaxy::AnsibleGalaxyCollectionInitCmd::Command)", "No collection name defined"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			command, err := test.cmd.Command()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.command, command)
+			}
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionInitCmdString(t *testing.T) {
+
+	tests := []struct {
+		desc string
+		cmd  *AnsibleGalaxyCollectionInitCmd
+		res  string
+	}{
+		{
+			desc: "Testing AnsibleGalaxyCollectionInitCmd to string with all flags",
+			cmd: NewAnsibleGalaxyCollectionInitCmd(
+				WithCollectionName("internal.tools"),
+				WithGalaxyCollectionInitOptions(&AnsibleGalaxyCollectionInitOptions{
+					CollectionSkeleton: "skeleton",
+					Force:              true,
+					InitPath:           "path",
+					Verbose:            true,
+				}),
+			),
+			res: "ansible-galaxy collection init --collection-skeleton=skeleton --force --init-path=path --verbose internal.tools",
+		},
+		{
+			desc: "Testing AnsibleGalaxyCollectionInitCmd to string using a custom binary",
+			cmd: NewAnsibleGalaxyCollectionInitCmd(
+				WithBinary("custom-binary"),
+				WithCollectionName("internal.tools"),
+			),
+			res: "custom-binary collection init internal.tools",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, test.cmd.String())
+		})
+	}
+}
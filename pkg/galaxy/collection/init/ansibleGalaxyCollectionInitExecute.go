@@ -0,0 +1,67 @@
+package galaxycollectioninit
+
+import (
+	"context"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+)
+
+// AnsibleGalaxyCollectionInitExecute is an executor for ansible-galaxy collection init command that runs the command using a DefaultExecute with default options
+type AnsibleGalaxyCollectionInitExecute struct {
+	cmd  *AnsibleGalaxyCollectionInitCmd
+	exec execute.Executabler
+}
+
+// NewAnsibleGalaxyCollectionInitExecute returns a new AnsibleGalaxyCollectionInitExecute. It receives the name of the collection to create
+func NewAnsibleGalaxyCollectionInitExecute(collectionName string) *AnsibleGalaxyCollectionInitExecute {
+
+	exec := &AnsibleGalaxyCollectionInitExecute{
+		cmd: &AnsibleGalaxyCollectionInitCmd{
+			CollectionName: collectionName,
+		},
+	}
+
+	return exec
+}
+
+// WithBinary returns an AnsibleGalaxyCollectionInitExecute with the binary file set
+func (e *AnsibleGalaxyCollectionInitExecute) WithBinary(binary string) *AnsibleGalaxyCollectionInitExecute {
+	e.cmd.Binary = binary
+
+	return e
+}
+
+// WithExecutable returns an AnsibleGalaxyCollectionInitExecute with the executable used to run the command set
+func (e *AnsibleGalaxyCollectionInitExecute) WithExecutable(executable execute.Executabler) *AnsibleGalaxyCollectionInitExecute {
+	e.exec = executable
+
+	return e
+}
+
+// WithGalaxyCollectionInitOptions returns an AnsibleGalaxyCollectionInitExecute with the ansible-galaxy collection init options set
+func (e *AnsibleGalaxyCollectionInitExecute) WithGalaxyCollectionInitOptions(options *AnsibleGalaxyCollectionInitOptions) *AnsibleGalaxyCollectionInitExecute {
+	e.cmd.GalaxyCollectionInitOptions = options
+
+	return e
+}
+
+// Execute method runs the ansible-galaxy collection init command using a DefaultExecute with default options
+func (e *AnsibleGalaxyCollectionInitExecute) Execute(ctx context.Context) error {
+
+	options := []execute.ExecuteOptions{
+		execute.WithCmd(e.cmd),
+	}
+
+	if e.exec != nil {
+		options = append(options, execute.WithExecutable(e.exec))
+	}
+
+	exec := execute.NewDefaultExecute(options...)
+
+	err := exec.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package galaxycollectioninit
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleGalaxyCollectionInitExecute(t *testing.T) {
+	expect := &AnsibleGalaxyCollectionInitExecute{
+		cmd: &AnsibleGalaxyCollectionInitCmd{
+			CollectionName: "internal.tools",
+		},
+	}
+
+	res := NewAnsibleGalaxyCollectionInitExecute("internal.tools")
+
+	assert.Equal(t, expect, res)
+}
+
+func TestAnsibleGalaxyCollectionInitExecuteExecute(t *testing.T) {
+
+	e := exec.NewMockExec()
+	cmd := exec.NewMockCmd()
+
+	cmd.On("StdoutPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+	cmd.On("StderrPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+	cmd.On("Start").Return(nil)
+	cmd.On("Wait").Return(nil)
+	// the compatibility check detects the ansible version, unless it is already cached
+	versionCmd := exec.NewMockCmd()
+	versionCmd.On("Output").Return([]byte("ansible-galaxy [core 2.16.3]\n"), nil).Maybe()
+	e.On("CommandContext", context.TODO(), "custom-binary", []string{"--version"}).Return(versionCmd).Maybe()
+	e.On("CommandContext", context.TODO(), "custom-binary", []string{"collection", "init", "--force", "internal.tools"}).Return(cmd)
+
+	err := NewAnsibleGalaxyCollectionInitExecute("internal.tools").
+		WithBinary("custom-binary").
+		WithExecutable(e).
+		WithGalaxyCollectionInitOptions(&AnsibleGalaxyCollectionInitOptions{
+			Force: true,
+		}).
+		Execute(context.TODO())
+
+	assert.NoError(t, err)
+	e.AssertExpectations(t)
+	cmd.AssertExpectations(t)
+}
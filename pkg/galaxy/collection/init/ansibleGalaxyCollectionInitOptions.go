@@ -0,0 +1,135 @@
+package galaxycollectioninit
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/apenella/go-ansible/v2/pkg/internal/validation"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+
+	// CollectionSkeletonFlag is the path to a collection skeleton that the new collection should be based upon.
+	CollectionSkeletonFlag = "--collection-skeleton"
+
+	// ForceFlag forces overwriting an existing role or collection.
+	ForceFlag = "--force"
+
+	// InitPathFlag is the path in which the skeleton collection will be created. The default is the current working directory.
+	InitPathFlag = "--init-path"
+
+	// VerboseFlag verbose mode enabled
+	VerboseFlag = "--verbose"
+)
+
+// AnsibleGalaxyCollectionInitOptions are the ansible-galaxy collection init options
+type AnsibleGalaxyCollectionInitOptions struct {
+
+	// CollectionSkeleton is the path to a collection skeleton that the new collection should be based upon.
+	CollectionSkeleton string
+
+	// Force forces overwriting an existing role or collection.
+	Force bool
+
+	// InitPath is the path in which the skeleton collection will be created. The default is the current working directory.
+	InitPath string
+
+	// Verbose verbose mode enabled
+	Verbose bool
+}
+
+// GenerateCommandOptions return a list of command options flags to be used on ansible-galaxy collection init execution
+func (o *AnsibleGalaxyCollectionInitOptions) GenerateCommandOptions() ([]string, error) {
+	errContext := "(galaxy::AnsibleGalaxyCollectionInitOptions::GenerateCommandOptions)"
+	options := []string{}
+
+	if o == nil {
+		return nil, errors.New(errContext, "AnsibleGalaxyCollectionInitOptions is nil")
+	}
+
+	if o.CollectionSkeleton != "" {
+		options = append(options, fmt.Sprintf("%s=%s", CollectionSkeletonFlag, o.CollectionSkeleton))
+	}
+
+	if o.Force {
+		options = append(options, ForceFlag)
+	}
+
+	if o.InitPath != "" {
+		options = append(options, fmt.Sprintf("%s=%s", InitPathFlag, o.InitPath))
+	}
+
+	if o.Verbose {
+		options = append(options, VerboseFlag)
+	}
+
+	return options, nil
+}
+
+// Validate checks the options. The skeleton and init paths are checked by ValidateFiles
+func (o *AnsibleGalaxyCollectionInitOptions) Validate() error {
+
+	if o == nil {
+		return errors.New("(galaxy::AnsibleGalaxyCollectionInitOptions::Validate)", "AnsibleGalaxyCollectionInitOptions is nil")
+	}
+
+	return nil
+}
+
+// ValidateFiles checks that the paths referenced by the options are directories. The relative paths are resolved against dir, which is the directory where the command runs, or against the working directory when dir is empty. It is not part of Validate because the command may run on another directory, host or container
+func (o *AnsibleGalaxyCollectionInitOptions) ValidateFiles(dir string) error {
+	errContext := "(galaxy::AnsibleGalaxyCollectionInitOptions::ValidateFiles)"
+	errs := []error{}
+
+	if o == nil {
+		return errors.New(errContext, "AnsibleGalaxyCollectionInitOptions is nil")
+	}
+
+	if o.CollectionSkeleton != "" {
+		info, err := os.Stat(validation.Path(dir, o.CollectionSkeleton))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("'%s' directory is not valid: %w", CollectionSkeletonFlag, err))
+		} else if !info.IsDir() {
+			errs = append(errs, fmt.Errorf("'%s' must be a directory, but '%s' is a file", CollectionSkeletonFlag, o.CollectionSkeleton))
+		}
+	}
+
+	// the init path is created when it does not exist, but it can not be a file
+	if o.InitPath != "" {
+		info, err := os.Stat(validation.Path(dir, o.InitPath))
+		if err == nil && !info.IsDir() {
+			errs = append(errs, fmt.Errorf("'%s' must be a directory, but '%s' is a file", InitPathFlag, o.InitPath))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid ansible-galaxy collection init option files", errs...)
+	}
+
+	return nil
+}
+
+// String return a string representation of the AnsibleGalaxyCollectionInitOptions
+func (o *AnsibleGalaxyCollectionInitOptions) String() string {
+	str := ""
+
+	if o.CollectionSkeleton != "" {
+		str = fmt.Sprintf("%s %s=%s", str, CollectionSkeletonFlag, o.CollectionSkeleton)
+	}
+
+	if o.Force {
+		str = fmt.Sprintf("%s %s", str, ForceFlag)
+	}
+
+	if o.InitPath != "" {
+		str = fmt.Sprintf("%s %s=%s", str, InitPathFlag, o.InitPath)
+	}
+
+	if o.Verbose {
+		str = fmt.Sprintf("%s %s", str, VerboseFlag)
+	}
+
+	return strings.TrimSpace(str)
+}
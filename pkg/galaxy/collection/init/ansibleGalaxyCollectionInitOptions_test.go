@@ -0,0 +1,182 @@
+package galaxycollectioninit
+
+import (
+	"fmt"
+	"io/fs"
+	"syscall"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnsibleGalaxyCollectionInitOptionsGenerateCommandOptions(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionInitOptions::GenerateCommandOptions)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionInitOptions
+		err     error
+		expect  []string
+	}{
+		{
+			desc:    "Testing nil AnsibleGalaxyCollectionInitOptions definition",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyCollectionInitOptions is nil"),
+		},
+		{
+			desc:    "Testing an empty AnsibleGalaxyCollectionInitOptions definition",
+			options: &AnsibleGalaxyCollectionInitOptions{},
+			expect:  []string{},
+		},
+		{
+			desc: "Testing AnsibleGalaxyCollectionInitOptions with all flags",
+			options: &AnsibleGalaxyCollectionInitOptions{
+				CollectionSkeleton: "skeleton",
+				Force:              true,
+				InitPath:           "path",
+				Verbose:            true,
+			},
+			expect: []string{
+				fmt.Sprintf("%s=%s", CollectionSkeletonFlag, "skeleton"),
+				ForceFlag,
+				fmt.Sprintf("%s=%s", InitPathFlag, "path"),
+				VerboseFlag,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			options, err := test.options.GenerateCommandOptions()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.expect, options, "Unexpected options value")
+			}
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionInitOptionsString(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionInitOptions
+		expect  string
+	}{
+		{
+			desc:    "Testing generate string from an empty AnsibleGalaxyCollectionInitOptions",
+			options: &AnsibleGalaxyCollectionInitOptions{},
+			expect:  "",
+		},
+		{
+			desc: "Testing generate string from an AnsibleGalaxyCollectionInitOptions with all flags",
+			options: &AnsibleGalaxyCollectionInitOptions{
+				CollectionSkeleton: "skeleton",
+				Force:              true,
+				InitPath:           "path",
+				Verbose:            true,
+			},
+			expect: "--collection-skeleton=skeleton --force --init-path=path --verbose",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expect, test.options.String())
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionInitOptionsValidate(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionInitOptions::Validate)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionInitOptions
+		err     error
+	}{
+		{
+			desc:    "Testing validate nil AnsibleGalaxyCollectionInitOptions",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyCollectionInitOptions is nil"),
+		},
+		{
+			desc: "Testing validate valid AnsibleGalaxyCollectionInitOptions",
+			options: &AnsibleGalaxyCollectionInitOptions{
+				CollectionSkeleton: ".",
+				InitPath:           "nonexistent-path",
+			},
+			err: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.Validate()
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionInitOptionsValidateFiles(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionInitOptions::ValidateFiles)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionInitOptions
+		err     error
+	}{
+		{
+			desc:    "Testing validate files of nil AnsibleGalaxyCollectionInitOptions",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyCollectionInitOptions is nil"),
+		},
+		{
+			desc: "Testing validate files of valid AnsibleGalaxyCollectionInitOptions",
+			options: &AnsibleGalaxyCollectionInitOptions{
+				CollectionSkeleton: ".",
+				InitPath:           "nonexistent-path",
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing validate files of invalid AnsibleGalaxyCollectionInitOptions",
+			options: &AnsibleGalaxyCollectionInitOptions{
+				CollectionSkeleton: "nonexistent-skeleton",
+				InitPath:           "ansibleGalaxyCollectionInitOptions.go",
+			},
+			err: errors.New(errContext, "Invalid ansible-galaxy collection init option files",
+				fmt.Errorf("'%s' directory is not valid: %w", CollectionSkeletonFlag, &fs.PathError{Op: "stat", Path: "nonexistent-skeleton", Err: syscall.ENOENT}),
+				fmt.Errorf("'%s' must be a directory, but 'ansibleGalaxyCollectionInitOptions.go' is a file", InitPathFlag),
+			),
+		},
+		{
+			desc: "Testing validate files of AnsibleGalaxyCollectionInitOptions with a skeleton that is a file",
+			options: &AnsibleGalaxyCollectionInitOptions{
+				CollectionSkeleton: "ansibleGalaxyCollectionInitOptions.go",
+			},
+			err: errors.New(errContext, "Invalid ansible-galaxy collection init option files",
+				fmt.Errorf("'%s' must be a directory, but 'ansibleGalaxyCollectionInitOptions.go' is a file", CollectionSkeletonFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.ValidateFiles("")
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
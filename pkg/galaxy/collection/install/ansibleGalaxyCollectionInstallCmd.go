@@ -25,6 +25,13 @@ type AnsibleGalaxyCollectionInstallCmd struct {
 
 	// GalaxyCollectionInstallOptions are the ansible-galaxy's collection install options
 	GalaxyCollectionInstallOptions *AnsibleGalaxyCollectionInstallOptions
+
+	// SkipValidation disables the collection install options validation when the command is generated
+	SkipValidation bool
+	// FileValidation enables checking that the files referenced by the options exist when the command is generated
+	FileValidation bool
+	// FileValidationDir is the directory the relative files are resolved against when the files are validated
+	FileValidationDir string
 }
 
 // NewAnsibleGalaxyCollectionInstallCmd creates a new AnsibleGalaxyCollectionInstallCmd instance
@@ -59,6 +66,21 @@ func WithCollectionNames(roleNames ...string) AnsibleGalaxyCollectionInstallOpti
 	}
 }
 
+// WithoutValidation disables the ansible-galaxy collection install options validation
+func WithoutValidation() AnsibleGalaxyCollectionInstallOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionInstallCmd) {
+		p.SkipValidation = true
+	}
+}
+
+// WithFileValidation enables checking that the files referenced by the ansible-galaxy collection install options exist. The relative files are resolved against dir, which is the directory where the command runs, or against the working directory when dir is empty. Do not enable it when the command runs on another host or container
+func WithFileValidation(dir string) AnsibleGalaxyCollectionInstallOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionInstallCmd) {
+		p.FileValidation = true
+		p.FileValidationDir = dir
+	}
+}
+
 // Command generate the ansible-galaxy role install command which will be executed
 func (p *AnsibleGalaxyCollectionInstallCmd) Command() ([]string, error) {
 	cmd := []string{}
@@ -72,6 +94,20 @@ func (p *AnsibleGalaxyCollectionInstallCmd) Command() ([]string, error) {
 
 	// Add the options
 	if p.GalaxyCollectionInstallOptions != nil {
+		if !p.SkipValidation {
+			err := p.GalaxyCollectionInstallOptions.Validate()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if p.FileValidation {
+			err := p.GalaxyCollectionInstallOptions.ValidateFiles(p.FileValidationDir)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		options, err := p.GalaxyCollectionInstallOptions.GenerateCommandOptions()
 		if err != nil {
 			return nil, err
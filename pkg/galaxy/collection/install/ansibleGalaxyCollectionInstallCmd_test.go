@@ -41,6 +41,7 @@ func TestAnsibleGalaxyCollectionInstallCmdCommand(t *testing.T) {
 		{
 			desc: "Testing generate a command for AnsibleGalaxyCollectionInstallCmd with all flags using default binary",
 			cmd: NewAnsibleGalaxyCollectionInstallCmd(
+				WithoutValidation(),
 				WithCollectionNames("collection-name"),
 				WithGalaxyCollectionInstallOptions(&AnsibleGalaxyCollectionInstallOptions{
 					ClearResponseCache:          true,
@@ -104,6 +105,7 @@ func TestAnsibleGalaxyCollectionInstallCmdCommand(t *testing.T) {
 		{
 			desc: "Testing generate a command for AnsibleGalaxyCollectionInstallCmd with all flags",
 			cmd: NewAnsibleGalaxyCollectionInstallCmd(
+				WithoutValidation(),
 				WithBinary("ansible-galaxy-binary"),
 				WithCollectionNames("collection-name"),
 				WithGalaxyCollectionInstallOptions(&AnsibleGalaxyCollectionInstallOptions{
@@ -192,6 +194,7 @@ func TestAnsibleGalaxyCollectionInstallCmdString(t *testing.T) {
 		{
 			desc: "Testing generate a command for AnsibleGalaxyCollectionInstallCmd with all flags using default binary",
 			cmd: NewAnsibleGalaxyCollectionInstallCmd(
+				WithoutValidation(),
 				WithCollectionNames("collection-name"),
 				WithGalaxyCollectionInstallOptions(&AnsibleGalaxyCollectionInstallOptions{
 					ClearResponseCache:          true,
@@ -225,6 +228,7 @@ func TestAnsibleGalaxyCollectionInstallCmdString(t *testing.T) {
 		{
 			desc: "Testing generate a command for AnsibleGalaxyCollectionInstallCmd with all flags",
 			cmd: NewAnsibleGalaxyCollectionInstallCmd(
+				WithoutValidation(),
 				WithBinary("ansible-galaxy-binary"),
 				WithCollectionNames("collection-name"),
 				WithGalaxyCollectionInstallOptions(&AnsibleGalaxyCollectionInstallOptions{
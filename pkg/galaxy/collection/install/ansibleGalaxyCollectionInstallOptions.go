@@ -2,8 +2,10 @@ package galaxycollectioninstall
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/apenella/go-ansible/v2/pkg/internal/validation"
 	errors "github.com/apenella/go-common-utils/error"
 )
 
@@ -264,6 +266,99 @@ func (o *AnsibleGalaxyCollectionInstallOptions) GenerateCommandOptions() ([]stri
 	return options, nil
 }
 
+// Validate checks the options looking for mutually exclusive or meaningless flag combinations and unsupported values. It returns an error that wraps all the detected issues
+func (o *AnsibleGalaxyCollectionInstallOptions) Validate() error {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionInstallOptions::Validate)"
+	errs := []error{}
+
+	if o == nil {
+		return errors.New(errContext, "AnsibleGalaxyCollectionInstallOptions is nil")
+	}
+
+	if o.APIKey != "" && o.Token != "" {
+		errs = append(errs, fmt.Errorf("'%s' and '%s' are mutually exclusive", APIKeyFlag, TokenFlag))
+	}
+
+	if o.Force && o.ForceWithDeps {
+		errs = append(errs, fmt.Errorf("'%s' is meaningless with '%s'", ForceFlag, ForceWithDepsFlag))
+	}
+
+	if o.NoDeps && o.ForceWithDeps {
+		errs = append(errs, fmt.Errorf("'%s' and '%s' are mutually exclusive", NoDepsFlag, ForceWithDepsFlag))
+	}
+
+	// offline installations never contact a distribution server
+	if o.Offline {
+		if o.Server != "" {
+			errs = append(errs, fmt.Errorf("'%s' is meaningless with '%s'", ServerFlag, OfflineFlag))
+		}
+		if o.Upgrade {
+			errs = append(errs, fmt.Errorf("'%s' and '%s' are mutually exclusive", UpgradeFlag, OfflineFlag))
+		}
+	}
+
+	// signature settings are ignored when the signature verification is disabled
+	if o.DisableGPGVerify {
+		if o.Keyring != "" {
+			errs = append(errs, fmt.Errorf("'%s' is meaningless with '%s'", KeyringFlag, DisableGPGVerifyFlag))
+		}
+		if o.Signature != "" {
+			errs = append(errs, fmt.Errorf("'%s' is meaningless with '%s'", SignatureFlag, DisableGPGVerifyFlag))
+		}
+		if o.RequiredValidSignatureCount > 0 {
+			errs = append(errs, fmt.Errorf("'%s' is meaningless with '%s'", RequiredValidSignatureCountFlag, DisableGPGVerifyFlag))
+		}
+	}
+
+	if o.RequiredValidSignatureCount < 0 {
+		errs = append(errs, fmt.Errorf("'%s' must not be negative, but '%d' was provided", RequiredValidSignatureCountFlag, o.RequiredValidSignatureCount))
+	}
+
+	if o.Timeout != "" {
+		timeout, err := strconv.Atoi(o.Timeout)
+		if err != nil || timeout < 1 {
+			errs = append(errs, fmt.Errorf("'%s' must be a positive integer, but '%s' was provided", TimeoutFlag, o.Timeout))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid ansible-galaxy collection install options", errs...)
+	}
+
+	return nil
+}
+
+// ValidateFiles checks that the files referenced by the options exist and are not directories. The relative files are resolved against dir, which is the directory where the command runs, or against the working directory when dir is empty. It is not part of Validate because the command may run on another directory, host or container
+func (o *AnsibleGalaxyCollectionInstallOptions) ValidateFiles(dir string) error {
+	errContext := "(galaxy::AnsibleGalaxyCollectionInstallOptions::ValidateFiles)"
+	errs := []error{}
+
+	if o == nil {
+		return errors.New(errContext, "AnsibleGalaxyCollectionInstallOptions is nil")
+	}
+
+	if o.Keyring != "" {
+		err := validation.FileExists(dir, o.Keyring)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("'%s' file is not valid: %w", KeyringFlag, err))
+		}
+	}
+
+	if o.RequirementsFile != "" {
+		err := validation.FileExists(dir, o.RequirementsFile)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("'%s' file is not valid: %w", RequirementsFileFlag, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid ansible-galaxy collection install option files", errs...)
+	}
+
+	return nil
+}
+
 // String return a string representation of the AnsibleGalaxyCollectionInstallOptions
 func (o *AnsibleGalaxyCollectionInstallOptions) String() string {
 	str := ""
@@ -0,0 +1,77 @@
+package galaxycollectioninstall
+
+import (
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// flagUsages relates the AnsibleGalaxyCollectionInstallOptions flags to their help text, copied from the ansible help. Every flag constant must have an entry
+var flagUsages = map[string]string{
+	APIKeyFlag:                      "the Ansible Galaxy API key. Same as --token",
+	ClearResponseCacheFlag:          "clears the existing server response cache",
+	CollectionsPathFlag:             "is the path to the directory containing your collections",
+	DisableGPGVerifyFlag:            "disables GPG signature verification when installing collections from a Galaxy server",
+	ForceFlag:                       "forces overwriting an existing role or collection",
+	ForceWithDepsFlag:               "forces overwriting an existing collection and its dependencies",
+	IgnoreCertsFlag:                 "ignores SSL certificate validation errors",
+	IgnoreErrorsFlag:                "ignores errors during installation and continue with the next specified collection",
+	IgnoreSignatureStatusCodeFlag:   "suppresses this argument. It may be specified multiple times",
+	IgnoreSignatureStatusCodesFlag:  "is a space separated list of status codes to ignore during signature verification",
+	KeyringFlag:                     "is the keyring used during signature verification",
+	NoCacheFlag:                     "does not use the server response cache",
+	NoDepsFlag:                      "doesn’t download collections listed as dependencies",
+	OfflineFlag:                     "installs collection artifacts (tarballs) without contacting any distribution servers",
+	PreFlag:                         "includes pre-release versions. Semantic versioning pre-releases are ignored by default",
+	RequiredValidSignatureCountFlag: "is the number of signatures that must successfully verify the collection",
+	RequirementsFileFlag:            "is a file containing a list of collections to be installed",
+	ServerFlag:                      "is the Galaxy API server URL",
+	SignatureFlag:                   "is an additional signature source to verify the authenticity of the MANIFEST.json",
+	TimeoutFlag:                     "is the time to wait for operations against the galaxy server, defaults to 60s",
+	TokenFlag:                       "the Ansible Galaxy API key. Same as --api-key",
+	UpgradeFlag:                     "upgrades installed collection artifacts. This will also update dependencies unless --no-deps is provided",
+	VerboseFlag:                     "verbose mode enabled",
+	VersionFlag:                     "show program's version number, config file location, configured module search path, module location, executable location and exit",
+}
+
+// AddFlags registers the AnsibleGalaxyCollectionInstallOptions attributes as flags on the flag set, using the ansible flag names and shorthands. The current attribute values are used as the flag defaults and parsing the flag set populates the options. When prefix is not empty, it is prepended to the flag names and the shorthands are not registered to avoid collisions
+func (o *AnsibleGalaxyCollectionInstallOptions) AddFlags(fs *pflag.FlagSet, prefix string) {
+	fs.StringVar(&o.APIKey, flagName(prefix, APIKeyFlag), o.APIKey, flagUsages[APIKeyFlag])
+	fs.BoolVar(&o.ClearResponseCache, flagName(prefix, ClearResponseCacheFlag), o.ClearResponseCache, flagUsages[ClearResponseCacheFlag])
+	fs.StringVarP(&o.CollectionsPath, flagName(prefix, CollectionsPathFlag), flagShorthand(prefix, "p"), o.CollectionsPath, flagUsages[CollectionsPathFlag])
+	fs.BoolVar(&o.DisableGPGVerify, flagName(prefix, DisableGPGVerifyFlag), o.DisableGPGVerify, flagUsages[DisableGPGVerifyFlag])
+	fs.BoolVarP(&o.Force, flagName(prefix, ForceFlag), flagShorthand(prefix, "f"), o.Force, flagUsages[ForceFlag])
+	fs.BoolVar(&o.ForceWithDeps, flagName(prefix, ForceWithDepsFlag), o.ForceWithDeps, flagUsages[ForceWithDepsFlag])
+	fs.BoolVarP(&o.IgnoreCerts, flagName(prefix, IgnoreCertsFlag), flagShorthand(prefix, "c"), o.IgnoreCerts, flagUsages[IgnoreCertsFlag])
+	fs.BoolVarP(&o.IgnoreErrors, flagName(prefix, IgnoreErrorsFlag), flagShorthand(prefix, "i"), o.IgnoreErrors, flagUsages[IgnoreErrorsFlag])
+	fs.BoolVar(&o.IgnoreSignatureStatusCode, flagName(prefix, IgnoreSignatureStatusCodeFlag), o.IgnoreSignatureStatusCode, flagUsages[IgnoreSignatureStatusCodeFlag])
+	fs.StringVar(&o.IgnoreSignatureStatusCodes, flagName(prefix, IgnoreSignatureStatusCodesFlag), o.IgnoreSignatureStatusCodes, flagUsages[IgnoreSignatureStatusCodesFlag])
+	fs.StringVar(&o.Keyring, flagName(prefix, KeyringFlag), o.Keyring, flagUsages[KeyringFlag])
+	fs.BoolVar(&o.NoCache, flagName(prefix, NoCacheFlag), o.NoCache, flagUsages[NoCacheFlag])
+	fs.BoolVarP(&o.NoDeps, flagName(prefix, NoDepsFlag), flagShorthand(prefix, "n"), o.NoDeps, flagUsages[NoDepsFlag])
+	fs.BoolVar(&o.Offline, flagName(prefix, OfflineFlag), o.Offline, flagUsages[OfflineFlag])
+	fs.BoolVar(&o.Pre, flagName(prefix, PreFlag), o.Pre, flagUsages[PreFlag])
+	fs.IntVar(&o.RequiredValidSignatureCount, flagName(prefix, RequiredValidSignatureCountFlag), o.RequiredValidSignatureCount, flagUsages[RequiredValidSignatureCountFlag])
+	fs.StringVarP(&o.RequirementsFile, flagName(prefix, RequirementsFileFlag), flagShorthand(prefix, "r"), o.RequirementsFile, flagUsages[RequirementsFileFlag])
+	fs.StringVarP(&o.Server, flagName(prefix, ServerFlag), flagShorthand(prefix, "s"), o.Server, flagUsages[ServerFlag])
+	fs.StringVar(&o.Signature, flagName(prefix, SignatureFlag), o.Signature, flagUsages[SignatureFlag])
+	fs.StringVar(&o.Timeout, flagName(prefix, TimeoutFlag), o.Timeout, flagUsages[TimeoutFlag])
+	fs.StringVar(&o.Token, flagName(prefix, TokenFlag), o.Token, flagUsages[TokenFlag])
+	fs.BoolVarP(&o.Upgrade, flagName(prefix, UpgradeFlag), flagShorthand(prefix, "U"), o.Upgrade, flagUsages[UpgradeFlag])
+	fs.BoolVarP(&o.Verbose, flagName(prefix, VerboseFlag), flagShorthand(prefix, "v"), o.Verbose, flagUsages[VerboseFlag])
+	fs.BoolVar(&o.Version, flagName(prefix, VersionFlag), o.Version, flagUsages[VersionFlag])
+}
+
+// flagName returns the flag name without the leading dashes and prepended by the prefix
+func flagName(prefix, flag string) string {
+	return prefix + strings.TrimLeft(flag, "-")
+}
+
+// flagShorthand returns the flag shorthand when there is no prefix, otherwise returns an empty shorthand
+func flagShorthand(prefix, shorthand string) string {
+	if prefix != "" {
+		return ""
+	}
+
+	return shorthand
+}
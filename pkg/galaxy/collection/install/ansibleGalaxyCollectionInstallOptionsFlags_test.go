@@ -0,0 +1,91 @@
+package galaxycollectioninstall
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/internal/flags/flagstest"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAddFlags tests
+func TestAddFlags(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionInstallOptions
+		prefix  string
+		args    []string
+		res     *AnsibleGalaxyCollectionInstallOptions
+		err     error
+	}{
+		{
+			desc:    "Testing add flags and parse short flags",
+			options: &AnsibleGalaxyCollectionInstallOptions{},
+			args:    []string{"-p", "collections", "-r", "requirements.yml", "-U", "-v"},
+			res: &AnsibleGalaxyCollectionInstallOptions{
+				CollectionsPath:  "collections",
+				RequirementsFile: "requirements.yml",
+				Upgrade:          true,
+				Verbose:          true,
+			},
+		},
+		{
+			desc:    "Testing add flags with prefix and parse long flags",
+			options: &AnsibleGalaxyCollectionInstallOptions{},
+			prefix:  "ansible-",
+			args:    []string{"--ansible-offline", "--ansible-collections-path=collections", "--ansible-required-valid-signature-count", "2"},
+			res: &AnsibleGalaxyCollectionInstallOptions{
+				CollectionsPath:             "collections",
+				Offline:                     true,
+				RequiredValidSignatureCount: 2,
+			},
+		},
+		{
+			desc:    "Testing add flags with prefix does not register shorthands",
+			options: &AnsibleGalaxyCollectionInstallOptions{},
+			prefix:  "ansible-",
+			args:    []string{"-p"},
+			err:     fmt.Errorf("unknown shorthand flag: 'p' in -p"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			test.options.AddFlags(fs, test.prefix)
+
+			err := fs.Parse(test.args)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, test.options)
+			}
+		})
+	}
+}
+
+// TestAddFlagsUsages tests that every flag constant is registered with its help text, because the flagUsages entries are maintained by hand
+func TestAddFlagsUsages(t *testing.T) {
+	longFlags, err := flagstest.LongFlags("ansibleGalaxyCollectionInstallOptions.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	options := &AnsibleGalaxyCollectionInstallOptions{}
+	options.AddFlags(fs, "")
+
+	for _, longFlag := range longFlags {
+		flag := fs.Lookup(strings.TrimPrefix(longFlag, "--"))
+		if assert.NotNil(t, flag, "flag %s is not registered", longFlag) {
+			assert.NotEmpty(t, flag.Usage, "flag %s has no help text", longFlag)
+			assert.Equal(t, flagUsages[longFlag], flag.Usage)
+		}
+	}
+}
@@ -2,6 +2,10 @@ package galaxycollectioninstall
 
 import (
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
 	"testing"
 
 	errors "github.com/apenella/go-common-utils/error"
@@ -159,3 +163,137 @@ func TestAnsibleGalaxyCollectionInstallOptionsString(t *testing.T) {
 		})
 	}
 }
+
+func TestAnsibleGalaxyCollectionInstallOptionsValidate(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionInstallOptions::Validate)"
+
+	tempDir := t.TempDir()
+	requirementsFile := filepath.Join(tempDir, "requirements.yml")
+	err := os.WriteFile(requirementsFile, []byte("---\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(tempDir, "missing.yml")
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionInstallOptions
+		err     error
+	}{
+		{
+			desc:    "Testing error validating nil AnsibleGalaxyCollectionInstallOptions",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyCollectionInstallOptions is nil"),
+		},
+		{
+			desc: "Testing validate AnsibleGalaxyCollectionInstallOptions",
+			options: &AnsibleGalaxyCollectionInstallOptions{
+				Offline:          true,
+				RequirementsFile: requirementsFile,
+				Timeout:          "60",
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing error validating AnsibleGalaxyCollectionInstallOptions",
+			options: &AnsibleGalaxyCollectionInstallOptions{
+				APIKey:                      "apikey",
+				DisableGPGVerify:            true,
+				Force:                       true,
+				ForceWithDeps:               true,
+				Keyring:                     missing,
+				NoDeps:                      true,
+				Offline:                     true,
+				RequiredValidSignatureCount: 1,
+				RequirementsFile:            tempDir,
+				Server:                      "https://galaxy.example.com",
+				Signature:                   "signature",
+				Timeout:                     "-1",
+				Token:                       "token",
+				Upgrade:                     true,
+			},
+			err: errors.New(errContext, "Invalid ansible-galaxy collection install options",
+				fmt.Errorf("'%s' and '%s' are mutually exclusive", APIKeyFlag, TokenFlag),
+				fmt.Errorf("'%s' is meaningless with '%s'", ForceFlag, ForceWithDepsFlag),
+				fmt.Errorf("'%s' and '%s' are mutually exclusive", NoDepsFlag, ForceWithDepsFlag),
+				fmt.Errorf("'%s' is meaningless with '%s'", ServerFlag, OfflineFlag),
+				fmt.Errorf("'%s' and '%s' are mutually exclusive", UpgradeFlag, OfflineFlag),
+				fmt.Errorf("'%s' is meaningless with '%s'", KeyringFlag, DisableGPGVerifyFlag),
+				fmt.Errorf("'%s' is meaningless with '%s'", SignatureFlag, DisableGPGVerifyFlag),
+				fmt.Errorf("'%s' is meaningless with '%s'", RequiredValidSignatureCountFlag, DisableGPGVerifyFlag),
+				fmt.Errorf("'%s' must be a positive integer, but '%s' was provided", TimeoutFlag, "-1"),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.Validate()
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionInstallOptionsValidateFiles(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionInstallOptions::ValidateFiles)"
+
+	var err error
+	tempDir := t.TempDir()
+
+	err = os.WriteFile(filepath.Join(tempDir, "keyring.kbx"), []byte("---\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(filepath.Join(tempDir, "requirements.yml"), []byte("---\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionInstallOptions
+		dir     string
+		err     error
+	}{
+		{
+			desc:    "Testing validate files of nil AnsibleGalaxyCollectionInstallOptions",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyCollectionInstallOptions is nil"),
+		},
+		{
+			desc: "Testing validate files of AnsibleGalaxyCollectionInstallOptions resolving the relative paths against the directory",
+			options: &AnsibleGalaxyCollectionInstallOptions{
+				Keyring:          "keyring.kbx",
+				RequirementsFile: "requirements.yml",
+			},
+			dir: tempDir,
+			err: nil,
+		},
+		{
+			desc: "Testing validate files of invalid AnsibleGalaxyCollectionInstallOptions",
+			options: &AnsibleGalaxyCollectionInstallOptions{
+				Keyring:          "nonexistent-keyring.kbx",
+				RequirementsFile: ".",
+			},
+			dir: tempDir,
+			err: errors.New(errContext, "Invalid ansible-galaxy collection install option files",
+				fmt.Errorf("'%s' file is not valid: %w", KeyringFlag, &fs.PathError{Op: "stat", Path: filepath.Join(tempDir, "nonexistent-keyring.kbx"), Err: syscall.ENOENT}),
+				fmt.Errorf("'%s' file is not valid: %w", RequirementsFileFlag, fmt.Errorf("%s is a directory", ".")),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.ValidateFiles(test.dir)
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package galaxycollectioninstall
+
+import (
+	"fmt"
+	"io"
+
+	galaxycollection "github.com/apenella/go-ansible/v2/pkg/galaxy/collection"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/spf13/pflag"
+)
+
+// ParseAnsibleGalaxyCollectionInstallCmd creates an AnsibleGalaxyCollectionInstallCmd from an ansible-galaxy collection install command line. The first argument is the ansible-galaxy binary followed by the collection install subcommand, the flags are parsed into the collection install options and the remaining arguments are the collection names. It is the inverse of the AnsibleGalaxyCollectionInstallCmd's Command method
+func ParseAnsibleGalaxyCollectionInstallCmd(args []string) (*AnsibleGalaxyCollectionInstallCmd, error) {
+
+	errContext := "(galaxy::ParseAnsibleGalaxyCollectionInstallCmd)"
+
+	if len(args) == 0 {
+		return nil, errors.New(errContext, "No ansible-galaxy command line to parse")
+	}
+
+	if len(args) < 3 || args[1] != galaxycollection.AnsibleGalaxyCollectionSubCommand || args[2] != AnsibleGalaxyCollectionInstallSubCommand {
+		return nil, errors.New(errContext, fmt.Sprintf("The command line is not an ansible-galaxy '%s %s' command", galaxycollection.AnsibleGalaxyCollectionSubCommand, AnsibleGalaxyCollectionInstallSubCommand))
+	}
+
+	options := &AnsibleGalaxyCollectionInstallOptions{}
+
+	fs := pflag.NewFlagSet(args[0], pflag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	options.AddFlags(fs, "")
+
+	err := fs.Parse(args[3:])
+	if err != nil {
+		return nil, errors.New(errContext, "Error parsing ansible-galaxy collection install command line", err)
+	}
+
+	cmd := NewAnsibleGalaxyCollectionInstallCmd(
+		WithBinary(args[0]),
+	)
+
+	if fs.NArg() > 0 {
+		cmd.CollectionNames = fs.Args()
+	}
+
+	if fs.NFlag() > 0 {
+		cmd.GalaxyCollectionInstallOptions = options
+	}
+
+	return cmd, nil
+}
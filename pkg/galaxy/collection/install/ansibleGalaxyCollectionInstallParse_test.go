@@ -0,0 +1,135 @@
+package galaxycollectioninstall
+
+import (
+	"fmt"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseAnsibleGalaxyCollectionInstallCmd tests
+func TestParseAnsibleGalaxyCollectionInstallCmd(t *testing.T) {
+
+	errContext := "(galaxy::ParseAnsibleGalaxyCollectionInstallCmd)"
+
+	tests := []struct {
+		desc string
+		args []string
+		res  *AnsibleGalaxyCollectionInstallCmd
+		err  error
+	}{
+		{
+			desc: "Testing parse an ansible-galaxy command line without arguments",
+			args: []string{},
+			err:  errors.New(errContext, "No ansible-galaxy command line to parse"),
+		},
+		{
+			desc: "Testing parse an ansible-galaxy command line which is not a collection install command",
+			args: []string{"ansible-galaxy", "role", "install", "geerlingguy.docker"},
+			err:  errors.New(errContext, "The command line is not an ansible-galaxy 'collection install' command"),
+		},
+		{
+			desc: "Testing parse an ansible-galaxy collection install command line with an unknown flag",
+			args: []string{"ansible-galaxy", "collection", "install", "--unknown", "community.general"},
+			err:  errors.New(errContext, "Error parsing ansible-galaxy collection install command line", fmt.Errorf("unknown flag: --unknown")),
+		},
+		{
+			desc: "Testing parse an ansible-galaxy collection install command line with short flags",
+			args: []string{"ansible-galaxy", "collection", "install", "-p", "collections", "-r", "requirements.yml", "-s", "https://galaxy.ansible.com", "-U", "-n", "-f", "-c", "-i", "-v", "community.general"},
+			res: &AnsibleGalaxyCollectionInstallCmd{
+				Binary:          "ansible-galaxy",
+				CollectionNames: []string{"community.general"},
+				GalaxyCollectionInstallOptions: &AnsibleGalaxyCollectionInstallOptions{
+					CollectionsPath:  "collections",
+					Force:            true,
+					IgnoreCerts:      true,
+					IgnoreErrors:     true,
+					NoDeps:           true,
+					RequirementsFile: "requirements.yml",
+					Server:           "https://galaxy.ansible.com",
+					Upgrade:          true,
+					Verbose:          true,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := ParseAnsibleGalaxyCollectionInstallCmd(test.args)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+// TestParseAnsibleGalaxyCollectionInstallCmdRoundTrip tests that parsing the command generated by an AnsibleGalaxyCollectionInstallCmd returns the same AnsibleGalaxyCollectionInstallCmd
+func TestParseAnsibleGalaxyCollectionInstallCmdRoundTrip(t *testing.T) {
+	tests := []struct {
+		desc string
+		cmd  *AnsibleGalaxyCollectionInstallCmd
+	}{
+		{
+			desc: "Testing round trip of an ansible-galaxy collection install command without options",
+			cmd: NewAnsibleGalaxyCollectionInstallCmd(
+				WithBinary("ansible-galaxy"),
+				WithCollectionNames("community.general", "ansible.posix"),
+			),
+		},
+		{
+			desc: "Testing round trip of an ansible-galaxy collection install command with all options",
+			cmd: NewAnsibleGalaxyCollectionInstallCmd(
+				WithBinary("custom-ansible-galaxy"),
+				WithCollectionNames("community.general"),
+				WithGalaxyCollectionInstallOptions(&AnsibleGalaxyCollectionInstallOptions{
+					APIKey:                      "apikey",
+					ClearResponseCache:          true,
+					CollectionsPath:             "collections",
+					DisableGPGVerify:            true,
+					Force:                       true,
+					ForceWithDeps:               true,
+					IgnoreCerts:                 true,
+					IgnoreErrors:                true,
+					IgnoreSignatureStatusCode:   true,
+					IgnoreSignatureStatusCodes:  "BADSIG EXPSIG",
+					Keyring:                     "keyring",
+					NoCache:                     true,
+					NoDeps:                      true,
+					Offline:                     true,
+					Pre:                         true,
+					RequiredValidSignatureCount: 2,
+					RequirementsFile:            "requirements.yml",
+					Server:                      "https://galaxy.ansible.com",
+					Signature:                   "signature",
+					Timeout:                     "60",
+					Token:                       "token",
+					Upgrade:                     true,
+					Verbose:                     true,
+					Version:                     true,
+				}),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			test.cmd.SkipValidation = true
+			command, err := test.cmd.Command()
+			assert.NoError(t, err)
+
+			res, err := ParseAnsibleGalaxyCollectionInstallCmd(command)
+			assert.NoError(t, err)
+
+			res.SkipValidation = true
+			assert.Equal(t, test.cmd, res)
+		})
+	}
+}
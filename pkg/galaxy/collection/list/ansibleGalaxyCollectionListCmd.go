@@ -0,0 +1,127 @@
+package galaxycollectionlist
+
+import (
+	"fmt"
+
+	galaxy "github.com/apenella/go-ansible/v2/pkg/galaxy"
+	galaxycollection "github.com/apenella/go-ansible/v2/pkg/galaxy/collection"
+)
+
+const (
+	// AnsibleGalaxyCollectionListSubCommand is the ansible-galaxy collection list subcommand
+	AnsibleGalaxyCollectionListSubCommand = "list"
+)
+
+// AnsibleGalaxyCollectionListOptionsFunc is a function to set executor options
+type AnsibleGalaxyCollectionListOptionsFunc func(*AnsibleGalaxyCollectionListCmd)
+
+// AnsibleGalaxyCollectionListCmd object is the main object which defines the `ansible-galaxy` command to list the installed collections.
+type AnsibleGalaxyCollectionListCmd struct {
+	// Binary is the ansible-galaxy binary file
+	Binary string
+
+	// CollectionName is the ansible-galaxy's collection name to be listed. When it is empty, all the installed collections are listed
+	CollectionName string
+
+	// GalaxyCollectionListOptions are the ansible-galaxy's collection list options
+	GalaxyCollectionListOptions *AnsibleGalaxyCollectionListOptions
+
+	// SkipValidation disables the collection list options validation when the command is generated
+	SkipValidation bool
+}
+
+// NewAnsibleGalaxyCollectionListCmd creates a new AnsibleGalaxyCollectionListCmd instance
+func NewAnsibleGalaxyCollectionListCmd(options ...AnsibleGalaxyCollectionListOptionsFunc) *AnsibleGalaxyCollectionListCmd {
+	cmd := &AnsibleGalaxyCollectionListCmd{}
+
+	for _, option := range options {
+		option(cmd)
+	}
+
+	return cmd
+}
+
+// WithBinary set the ansible-galaxy binary file
+func WithBinary(binary string) AnsibleGalaxyCollectionListOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionListCmd) {
+		p.Binary = binary
+	}
+}
+
+// WithCollectionName set the ansible-galaxy collection name to be listed
+func WithCollectionName(collectionName string) AnsibleGalaxyCollectionListOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionListCmd) {
+		p.CollectionName = collectionName
+	}
+}
+
+// WithGalaxyCollectionListOptions set the ansible-galaxy collection list options
+func WithGalaxyCollectionListOptions(options *AnsibleGalaxyCollectionListOptions) AnsibleGalaxyCollectionListOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionListCmd) {
+		p.GalaxyCollectionListOptions = options
+	}
+}
+
+// WithoutValidation disables the ansible-galaxy collection list options validation
+func WithoutValidation() AnsibleGalaxyCollectionListOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionListCmd) {
+		p.SkipValidation = true
+	}
+}
+
+// Command generate the ansible-galaxy collection list command which will be executed
+func (p *AnsibleGalaxyCollectionListCmd) Command() ([]string, error) {
+	cmd := []string{}
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = galaxy.DefaultAnsibleGalaxyBinary
+	}
+
+	cmd = append(cmd, p.Binary, galaxycollection.AnsibleGalaxyCollectionSubCommand, AnsibleGalaxyCollectionListSubCommand)
+
+	// Add the options
+	if p.GalaxyCollectionListOptions != nil {
+		if !p.SkipValidation {
+			err := p.GalaxyCollectionListOptions.Validate()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		options, err := p.GalaxyCollectionListOptions.GenerateCommandOptions()
+		if err != nil {
+			return nil, err
+		}
+		cmd = append(cmd, options...)
+	}
+
+	// Add the collection name
+	if p.CollectionName != "" {
+		cmd = append(cmd, p.CollectionName)
+	}
+
+	return cmd, nil
+}
+
+// String returns the ansible-galaxy collection list command as a string
+func (p *AnsibleGalaxyCollectionListCmd) String() string {
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = galaxy.DefaultAnsibleGalaxyBinary
+	}
+
+	str := fmt.Sprintf("%s %s %s", p.Binary, galaxycollection.AnsibleGalaxyCollectionSubCommand, AnsibleGalaxyCollectionListSubCommand)
+
+	if p.GalaxyCollectionListOptions != nil {
+		str = fmt.Sprintf("%s %s", str, p.GalaxyCollectionListOptions.String())
+	}
+
+	// Include the collection name
+	if p.CollectionName != "" {
+		str = fmt.Sprintf("%s %s", str, p.CollectionName)
+	}
+
+	return str
+}
@@ -0,0 +1,149 @@
+package galaxycollectionlist
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/galaxy"
+	galaxycollection "github.com/apenella/go-ansible/v2/pkg/galaxy/collection"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleGalaxyCollectionListCmd(t *testing.T) {
+	cmd := NewAnsibleGalaxyCollectionListCmd(
+		WithBinary("ansible-galaxy-binary"),
+		WithCollectionName("community.general"),
+		WithGalaxyCollectionListOptions(&AnsibleGalaxyCollectionListOptions{
+			Format: FormatJSON,
+		}),
+	)
+
+	expect := &AnsibleGalaxyCollectionListCmd{
+		Binary:         "ansible-galaxy-binary",
+		CollectionName: "community.general",
+		GalaxyCollectionListOptions: &AnsibleGalaxyCollectionListOptions{
+			Format: FormatJSON,
+		},
+	}
+
+	assert.Equal(t, expect, cmd)
+}
+
+func TestAnsibleGalaxyCollectionListCmdCommand(t *testing.T) {
+
+	tests := []struct {
+		desc    string
+		cmd     *AnsibleGalaxyCollectionListCmd
+		command []string
+		err     error
+	}{
+		{
+			desc: "Testing generate a command for AnsibleGalaxyCollectionListCmd with all flags using default binary",
+			cmd: NewAnsibleGalaxyCollectionListCmd(
+				WithoutValidation(),
+				WithCollectionName("community.general"),
+				WithGalaxyCollectionListOptions(&AnsibleGalaxyCollectionListOptions{
+					APIKey:          "apikey",
+					CollectionsPath: "path",
+					Format:          FormatJSON,
+					IgnoreCerts:     true,
+					Server:          "server",
+					Timeout:         "10",
+					Token:           "token",
+					Verbose:         true,
+				}),
+			),
+			err: &errors.Error{},
+			command: []string{
+				galaxy.DefaultAnsibleGalaxyBinary,
+				galaxycollection.AnsibleGalaxyCollectionSubCommand,
+				AnsibleGalaxyCollectionListSubCommand,
+				fmt.Sprintf("%s=%s", APIKeyFlag, "apikey"),
+				fmt.Sprintf("%s=%s", CollectionsPathFlag, "path"),
+				fmt.Sprintf("%s=%s", FormatFlag, FormatJSON),
+				IgnoreCertsFlag,
+				fmt.Sprintf("%s=%s", ServerFlag, "server"),
+				fmt.Sprintf("%s=%s", TimeoutFlag, "10"),
+				fmt.Sprintf("%s=%s", TokenFlag, "token"),
+				VerboseFlag,
+				"community.general",
+			},
+		},
+		{
+			desc: "Testing generate a command for AnsibleGalaxyCollectionListCmd without collection name using a custom binary",
+			cmd: NewAnsibleGalaxyCollectionListCmd(
+				WithBinary("custom-binary"),
+			),
+			err: &errors.Error{},
+			command: []string{
+				"custom-binary",
+				galaxycollection.AnsibleGalaxyCollectionSubCommand,
+				AnsibleGalaxyCollectionListSubCommand,
+			},
+		},
+		{
+			desc: "Testing error generating a command for AnsibleGalaxyCollectionListCmd with invalid options",
+			cmd: NewAnsibleGalaxyCollectionListCmd(
+				WithGalaxyCollectionListOptions(&AnsibleGalaxyCollectionListOptions{
+					Format: "table",
+				}),
+			),
+			err: errors.New("(galaxy::AnsibleGalaxyCollectionListOptions::Validate)", "Invalid ansible-galaxy collection list options",
+				fmt.Errorf("'--format' must be one of 'human', 'json' or 'yaml', but 'table' was provided"),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			command, err := test.cmd.Command()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.command, command)
+			}
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionListCmdString(t *testing.T) {
+
+	tests := []struct {
+		desc string
+		cmd  *AnsibleGalaxyCollectionListCmd
+		res  string
+	}{
+		{
+			desc: "Testing AnsibleGalaxyCollectionListCmd to string with all flags",
+			cmd: NewAnsibleGalaxyCollectionListCmd(
+				WithCollectionName("community.general"),
+				WithGalaxyCollectionListOptions(&AnsibleGalaxyCollectionListOptions{
+					APIKey:          "apikey",
+					CollectionsPath: "path",
+					Format:          FormatJSON,
+					IgnoreCerts:     true,
+					Server:          "server",
+					Timeout:         "10",
+					Verbose:         true,
+				}),
+			),
+			res: "ansible-galaxy collection list --api-key=apikey --collections-path=path --format=json --ignore-certs --server=server --timeout=10 --verbose community.general",
+		},
+		{
+			desc: "Testing AnsibleGalaxyCollectionListCmd to string without options",
+			cmd:  NewAnsibleGalaxyCollectionListCmd(),
+			res:  "ansible-galaxy collection list",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, test.cmd.String())
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package galaxycollectionlist
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// AnsibleGalaxyCollectionListExecute is an executor for ansible-galaxy collection list command that runs the command using a DefaultExecute with default options
+type AnsibleGalaxyCollectionListExecute struct {
+	cmd  *AnsibleGalaxyCollectionListCmd
+	exec execute.Executabler
+}
+
+// NewAnsibleGalaxyCollectionListExecute returns a new AnsibleGalaxyCollectionListExecute
+func NewAnsibleGalaxyCollectionListExecute() *AnsibleGalaxyCollectionListExecute {
+
+	exec := &AnsibleGalaxyCollectionListExecute{
+		cmd: &AnsibleGalaxyCollectionListCmd{},
+	}
+
+	return exec
+}
+
+// WithBinary returns an AnsibleGalaxyCollectionListExecute with the binary file set
+func (e *AnsibleGalaxyCollectionListExecute) WithBinary(binary string) *AnsibleGalaxyCollectionListExecute {
+	e.cmd.Binary = binary
+
+	return e
+}
+
+// WithCollectionName returns an AnsibleGalaxyCollectionListExecute with the collection name to list set
+func (e *AnsibleGalaxyCollectionListExecute) WithCollectionName(collectionName string) *AnsibleGalaxyCollectionListExecute {
+	e.cmd.CollectionName = collectionName
+
+	return e
+}
+
+// WithExecutable returns an AnsibleGalaxyCollectionListExecute with the executable used to run the command set
+func (e *AnsibleGalaxyCollectionListExecute) WithExecutable(executable execute.Executabler) *AnsibleGalaxyCollectionListExecute {
+	e.exec = executable
+
+	return e
+}
+
+// WithGalaxyCollectionListOptions returns an AnsibleGalaxyCollectionListExecute with the ansible-galaxy collection list options set
+func (e *AnsibleGalaxyCollectionListExecute) WithGalaxyCollectionListOptions(options *AnsibleGalaxyCollectionListOptions) *AnsibleGalaxyCollectionListExecute {
+	e.cmd.GalaxyCollectionListOptions = options
+
+	return e
+}
+
+// Execute method runs the ansible-galaxy collection list command using a DefaultExecute with default options
+func (e *AnsibleGalaxyCollectionListExecute) Execute(ctx context.Context) error {
+
+	options := []execute.ExecuteOptions{
+		execute.WithCmd(e.cmd),
+	}
+
+	if e.exec != nil {
+		options = append(options, execute.WithExecutable(e.exec))
+	}
+
+	exec := execute.NewDefaultExecute(options...)
+
+	err := exec.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// InstalledCollections runs the ansible-galaxy collection list command using the JSON format and returns the installed collections
+func (e *AnsibleGalaxyCollectionListExecute) InstalledCollections(ctx context.Context) ([]*InstalledCollection, error) {
+	errContext := "(galaxy::AnsibleGalaxyCollectionListExecute::InstalledCollections)"
+
+	// the command is copied to set the JSON format without modifying the user options
+	cmd := *e.cmd
+	listOptions := AnsibleGalaxyCollectionListOptions{}
+	if cmd.GalaxyCollectionListOptions != nil {
+		listOptions = *cmd.GalaxyCollectionListOptions
+	}
+	listOptions.Format = FormatJSON
+	cmd.GalaxyCollectionListOptions = &listOptions
+
+	stdout := &bytes.Buffer{}
+	options := []execute.ExecuteOptions{
+		execute.WithCmd(&cmd),
+		execute.WithWrite(stdout),
+	}
+
+	if e.exec != nil {
+		options = append(options, execute.WithExecutable(e.exec))
+	}
+
+	exec := execute.NewDefaultExecute(options...)
+
+	err := exec.Execute(ctx)
+	if err != nil {
+		return nil, errors.New(errContext, "Error listing the installed collections", err)
+	}
+
+	installedCollections, err := ParseAnsibleGalaxyCollectionListJSON(stdout)
+	if err != nil {
+		return nil, errors.New(errContext, "Error parsing the installed collections", err)
+	}
+
+	return installedCollections, nil
+}
@@ -0,0 +1,52 @@
+package galaxycollectionlist
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstalledCollections(t *testing.T) {
+
+	e := exec.NewMockExec()
+	cmd := exec.NewMockCmd()
+
+	cmd.On("StdoutPipe").Return(io.NopCloser(strings.NewReader(`{"/root/.ansible/collections/ansible_collections": {"community.general": {"version": "8.0.0"}}}`)), nil)
+	cmd.On("StderrPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+	cmd.On("Start").Return(nil)
+	cmd.On("Wait").Return(nil)
+	// the compatibility check detects the ansible version, unless it is already cached
+	versionCmd := exec.NewMockCmd()
+	versionCmd.On("Output").Return([]byte("ansible-galaxy [core 2.16.3]\n"), nil).Maybe()
+	e.On("CommandContext", context.TODO(), "ansible-galaxy", []string{"--version"}).Return(versionCmd).Maybe()
+	e.On("CommandContext", context.TODO(), "ansible-galaxy", []string{"collection", "list", "--collections-path=/root/.ansible/collections", "--format=json", "community.general"}).Return(cmd)
+
+	options := &AnsibleGalaxyCollectionListOptions{
+		CollectionsPath: "/root/.ansible/collections",
+	}
+
+	res, err := NewAnsibleGalaxyCollectionListExecute().
+		WithCollectionName("community.general").
+		WithExecutable(e).
+		WithGalaxyCollectionListOptions(options).
+		InstalledCollections(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*InstalledCollection{
+		{
+			Collection: "general",
+			Name:       "community.general",
+			Namespace:  "community",
+			Path:       "/root/.ansible/collections/ansible_collections",
+			Version:    "8.0.0",
+		},
+	}, res)
+	// the user options are not modified
+	assert.Equal(t, "", options.Format)
+	e.AssertExpectations(t)
+	cmd.AssertExpectations(t)
+}
@@ -0,0 +1,202 @@
+package galaxycollectionlist
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+
+	// APIKeyFlag the Ansible Galaxy API key. Same as --token
+	APIKeyFlag = "--api-key"
+
+	// CollectionsPathFlag is one or more directories to search for collections in addition to the default COLLECTIONS_PATHS. Separate multiple paths with ':'.
+	CollectionsPathFlag = "--collections-path"
+
+	// FormatFlag is the format to display the list of collections. It can be human, yaml or json.
+	FormatFlag = "--format"
+
+	// IgnoreCertsFlag ignores SSL certificate validation errors.
+	IgnoreCertsFlag = "--ignore-certs"
+
+	// ServerFlag is the Galaxy API server URL.
+	ServerFlag = "--server"
+
+	// TimeoutFlag is the time to wait for operations against the galaxy server, defaults to 60s.
+	TimeoutFlag = "--timeout"
+
+	// TokenFlag the Ansible Galaxy API key. Same as --api-key
+	TokenFlag = "--token"
+
+	// VerboseFlag verbose mode enabled
+	VerboseFlag = "--verbose"
+
+	// FormatHuman is the format that displays the list of collections as a table
+	FormatHuman = "human"
+
+	// FormatJSON is the format that displays the list of collections as JSON
+	FormatJSON = "json"
+
+	// FormatYAML is the format that displays the list of collections as YAML
+	FormatYAML = "yaml"
+)
+
+// formats are the formats supported by the format flag
+var formats = []string{FormatHuman, FormatJSON, FormatYAML}
+
+// AnsibleGalaxyCollectionListOptions are the ansible-galaxy collection list options
+type AnsibleGalaxyCollectionListOptions struct {
+
+	// APIKey is the Ansible Galaxy API key.
+	APIKey string
+
+	// CollectionsPath is one or more directories to search for collections in addition to the default COLLECTIONS_PATHS. Separate multiple paths with ':'.
+	CollectionsPath string
+
+	// Format is the format to display the list of collections. It can be human, yaml or json.
+	Format string
+
+	// IgnoreCerts ignores SSL certificate validation errors.
+	IgnoreCerts bool
+
+	// Server is the Galaxy API server URL.
+	Server string
+
+	// Timeout is the time to wait for operations against the galaxy server, defaults to 60s.
+	Timeout string
+
+	// Token is the Ansible Galaxy API key.
+	Token string
+
+	// Verbose verbose mode enabled
+	Verbose bool
+}
+
+// GenerateCommandOptions return a list of command options flags to be used on ansible-galaxy collection list execution
+func (o *AnsibleGalaxyCollectionListOptions) GenerateCommandOptions() ([]string, error) {
+	errContext := "(galaxy::AnsibleGalaxyCollectionListOptions::GenerateCommandOptions)"
+	options := []string{}
+
+	if o == nil {
+		return nil, errors.New(errContext, "AnsibleGalaxyCollectionListOptions is nil")
+	}
+
+	if o.APIKey != "" {
+		options = append(options, fmt.Sprintf("%s=%s", APIKeyFlag, o.APIKey))
+	}
+
+	if o.CollectionsPath != "" {
+		options = append(options, fmt.Sprintf("%s=%s", CollectionsPathFlag, o.CollectionsPath))
+	}
+
+	if o.Format != "" {
+		options = append(options, fmt.Sprintf("%s=%s", FormatFlag, o.Format))
+	}
+
+	if o.IgnoreCerts {
+		options = append(options, IgnoreCertsFlag)
+	}
+
+	if o.Server != "" {
+		options = append(options, fmt.Sprintf("%s=%s", ServerFlag, o.Server))
+	}
+
+	if o.Timeout != "" {
+		options = append(options, fmt.Sprintf("%s=%s", TimeoutFlag, o.Timeout))
+	}
+
+	if o.Token != "" {
+		options = append(options, fmt.Sprintf("%s=%s", TokenFlag, o.Token))
+	}
+
+	if o.Verbose {
+		options = append(options, VerboseFlag)
+	}
+
+	return options, nil
+}
+
+// Validate checks the options looking for mutually exclusive flags and unsupported values. It returns an error that wraps all the detected issues
+func (o *AnsibleGalaxyCollectionListOptions) Validate() error {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionListOptions::Validate)"
+	errs := []error{}
+
+	if o == nil {
+		return errors.New(errContext, "AnsibleGalaxyCollectionListOptions is nil")
+	}
+
+	if o.APIKey != "" && o.Token != "" {
+		errs = append(errs, fmt.Errorf("'%s' and '%s' are mutually exclusive", APIKeyFlag, TokenFlag))
+	}
+
+	if o.Format != "" && !isFormat(o.Format) {
+		errs = append(errs, fmt.Errorf("'%s' must be one of '%s', '%s' or '%s', but '%s' was provided", FormatFlag, FormatHuman, FormatJSON, FormatYAML, o.Format))
+	}
+
+	if o.Timeout != "" {
+		timeout, err := strconv.Atoi(o.Timeout)
+		if err != nil || timeout < 1 {
+			errs = append(errs, fmt.Errorf("'%s' must be a positive integer, but '%s' was provided", TimeoutFlag, o.Timeout))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid ansible-galaxy collection list options", errs...)
+	}
+
+	return nil
+}
+
+// isFormat returns whether format is one of the supported formats
+func isFormat(format string) bool {
+	for _, f := range formats {
+		if f == format {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String return a string representation of the AnsibleGalaxyCollectionListOptions
+func (o *AnsibleGalaxyCollectionListOptions) String() string {
+	str := ""
+
+	if o.APIKey != "" {
+		str = fmt.Sprintf("%s %s=%s", str, APIKeyFlag, o.APIKey)
+	}
+
+	if o.CollectionsPath != "" {
+		str = fmt.Sprintf("%s %s=%s", str, CollectionsPathFlag, o.CollectionsPath)
+	}
+
+	if o.Format != "" {
+		str = fmt.Sprintf("%s %s=%s", str, FormatFlag, o.Format)
+	}
+
+	if o.IgnoreCerts {
+		str = fmt.Sprintf("%s %s", str, IgnoreCertsFlag)
+	}
+
+	if o.Server != "" {
+		str = fmt.Sprintf("%s %s=%s", str, ServerFlag, o.Server)
+	}
+
+	if o.Timeout != "" {
+		str = fmt.Sprintf("%s %s=%s", str, TimeoutFlag, o.Timeout)
+	}
+
+	if o.Token != "" {
+		str = fmt.Sprintf("%s %s=%s", str, TokenFlag, o.Token)
+	}
+
+	if o.Verbose {
+		str = fmt.Sprintf("%s %s", str, VerboseFlag)
+	}
+
+	return strings.TrimSpace(str)
+}
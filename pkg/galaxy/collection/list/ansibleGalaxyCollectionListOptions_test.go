@@ -0,0 +1,153 @@
+package galaxycollectionlist
+
+import (
+	"fmt"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnsibleGalaxyCollectionListOptionsGenerateCommandOptions(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionListOptions::GenerateCommandOptions)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionListOptions
+		err     error
+		expect  []string
+	}{
+		{
+			desc:    "Testing nil AnsibleGalaxyCollectionListOptions definition",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyCollectionListOptions is nil"),
+		},
+		{
+			desc:    "Testing an empty AnsibleGalaxyCollectionListOptions definition",
+			options: &AnsibleGalaxyCollectionListOptions{},
+			expect:  []string{},
+		},
+		{
+			desc: "Testing AnsibleGalaxyCollectionListOptions with all flags",
+			options: &AnsibleGalaxyCollectionListOptions{
+				APIKey:          "apikey",
+				CollectionsPath: "path",
+				Format:          FormatYAML,
+				IgnoreCerts:     true,
+				Server:          "server",
+				Timeout:         "10",
+				Token:           "token",
+				Verbose:         true,
+			},
+			expect: []string{
+				fmt.Sprintf("%s=%s", APIKeyFlag, "apikey"),
+				fmt.Sprintf("%s=%s", CollectionsPathFlag, "path"),
+				fmt.Sprintf("%s=%s", FormatFlag, FormatYAML),
+				IgnoreCertsFlag,
+				fmt.Sprintf("%s=%s", ServerFlag, "server"),
+				fmt.Sprintf("%s=%s", TimeoutFlag, "10"),
+				fmt.Sprintf("%s=%s", TokenFlag, "token"),
+				VerboseFlag,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			options, err := test.options.GenerateCommandOptions()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.expect, options, "Unexpected options value")
+			}
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionListOptionsString(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionListOptions
+		expect  string
+	}{
+		{
+			desc:    "Testing generate string from an empty AnsibleGalaxyCollectionListOptions",
+			options: &AnsibleGalaxyCollectionListOptions{},
+			expect:  "",
+		},
+		{
+			desc: "Testing generate string from an AnsibleGalaxyCollectionListOptions with all flags",
+			options: &AnsibleGalaxyCollectionListOptions{
+				APIKey:          "apikey",
+				CollectionsPath: "path",
+				Format:          FormatHuman,
+				IgnoreCerts:     true,
+				Server:          "server",
+				Timeout:         "10",
+				Token:           "token",
+				Verbose:         true,
+			},
+			expect: "--api-key=apikey --collections-path=path --format=human --ignore-certs --server=server --timeout=10 --token=token --verbose",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expect, test.options.String())
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionListOptionsValidate(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionListOptions::Validate)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionListOptions
+		err     error
+	}{
+		{
+			desc:    "Testing validate nil AnsibleGalaxyCollectionListOptions",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyCollectionListOptions is nil"),
+		},
+		{
+			desc: "Testing validate valid AnsibleGalaxyCollectionListOptions",
+			options: &AnsibleGalaxyCollectionListOptions{
+				CollectionsPath: "path",
+				Format:          FormatJSON,
+				Timeout:         "10",
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing validate invalid AnsibleGalaxyCollectionListOptions",
+			options: &AnsibleGalaxyCollectionListOptions{
+				APIKey:  "apikey",
+				Format:  "table",
+				Timeout: "0",
+				Token:   "token",
+			},
+			err: errors.New(errContext, "Invalid ansible-galaxy collection list options",
+				fmt.Errorf("'%s' and '%s' are mutually exclusive", APIKeyFlag, TokenFlag),
+				fmt.Errorf("'--format' must be one of 'human', 'json' or 'yaml', but 'table' was provided"),
+				fmt.Errorf("'%s' must be a positive integer, but '0' was provided", TimeoutFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.Validate()
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package galaxycollectionlist
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// InstalledCollection is a collection reported by ansible-galaxy collection list
+type InstalledCollection struct {
+	// Collection is the collection name, without the namespace
+	Collection string
+	// Name is the fully qualified collection name, in the form namespace.collection
+	Name string
+	// Namespace is the collection namespace
+	Namespace string
+	// Path is the ansible_collections directory where the collection is installed
+	Path string
+	// Version is the installed collection version. It is '*' when the collection does not define a version
+	Version string
+}
+
+// installedCollectionDetails are the details of a collection on the ansible-galaxy collection list JSON output
+type installedCollectionDetails struct {
+	Version string `json:"version"`
+}
+
+// ParseAnsibleGalaxyCollectionListJSON returns the installed collections described by the ansible-galaxy collection list output when the JSON format is used. The output is a map of collections paths, where each path holds a map of collections and their details. The collections are sorted by path and name
+func ParseAnsibleGalaxyCollectionListJSON(reader io.Reader) ([]*InstalledCollection, error) {
+	errContext := "(galaxy::ParseAnsibleGalaxyCollectionListJSON)"
+
+	if reader == nil {
+		return nil, errors.New(errContext, "Reader is not defined")
+	}
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.New(errContext, "Error reading ansible-galaxy collection list output", err)
+	}
+
+	installedCollections := []*InstalledCollection{}
+	if strings.TrimSpace(string(content)) == "" {
+		return installedCollections, nil
+	}
+
+	paths := map[string]map[string]installedCollectionDetails{}
+	err = json.Unmarshal(content, &paths)
+	if err != nil {
+		return nil, errors.New(errContext, "Error unmarshaling ansible-galaxy collection list output", err)
+	}
+
+	for path, collections := range paths {
+		for name, details := range collections {
+			namespace, collection, _ := strings.Cut(name, ".")
+
+			installedCollections = append(installedCollections, &InstalledCollection{
+				Collection: collection,
+				Name:       name,
+				Namespace:  namespace,
+				Path:       path,
+				Version:    details.Version,
+			})
+		}
+	}
+
+	sort.Slice(installedCollections, func(i, j int) bool {
+		if installedCollections[i].Path != installedCollections[j].Path {
+			return installedCollections[i].Path < installedCollections[j].Path
+		}
+		return installedCollections[i].Name < installedCollections[j].Name
+	})
+
+	return installedCollections, nil
+}
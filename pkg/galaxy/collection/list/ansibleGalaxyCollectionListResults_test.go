@@ -0,0 +1,86 @@
+package galaxycollectionlist
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAnsibleGalaxyCollectionListJSON(t *testing.T) {
+
+	errContext := "(galaxy::ParseAnsibleGalaxyCollectionListJSON)"
+
+	tests := []struct {
+		desc   string
+		reader io.Reader
+		res    []*InstalledCollection
+		err    error
+	}{
+		{
+			desc: "Testing parse the collections installed on several collections paths",
+			reader: strings.NewReader(`{
+  "/usr/share/ansible/collections/ansible_collections": {
+    "community.general": {"version": "8.0.0"},
+    "ansible.posix": {"version": "1.5.4"}
+  },
+  "/root/.ansible/collections/ansible_collections": {
+    "internal.tools": {"version": "*"}
+  }
+}`),
+			res: []*InstalledCollection{
+				{
+					Collection: "tools",
+					Name:       "internal.tools",
+					Namespace:  "internal",
+					Path:       "/root/.ansible/collections/ansible_collections",
+					Version:    "*",
+				},
+				{
+					Collection: "posix",
+					Name:       "ansible.posix",
+					Namespace:  "ansible",
+					Path:       "/usr/share/ansible/collections/ansible_collections",
+					Version:    "1.5.4",
+				},
+				{
+					Collection: "general",
+					Name:       "community.general",
+					Namespace:  "community",
+					Path:       "/usr/share/ansible/collections/ansible_collections",
+					Version:    "8.0.0",
+				},
+			},
+		},
+		{
+			desc:   "Testing parse an empty output",
+			reader: strings.NewReader("\n"),
+			res:    []*InstalledCollection{},
+		},
+		{
+			desc:   "Testing error parsing a nil reader",
+			reader: nil,
+			err:    errors.New(errContext, "Reader is not defined"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := ParseAnsibleGalaxyCollectionListJSON(test.reader)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+
+	t.Run("Testing error parsing an output that is not JSON", func(t *testing.T) {
+		_, err := ParseAnsibleGalaxyCollectionListJSON(strings.NewReader("Collection        Version\n"))
+		assert.ErrorContains(t, err, "Error unmarshaling ansible-galaxy collection list output")
+	})
+}
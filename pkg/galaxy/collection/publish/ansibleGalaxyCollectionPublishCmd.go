@@ -0,0 +1,133 @@
+package galaxycollectionpublish
+
+import (
+	"fmt"
+
+	galaxy "github.com/apenella/go-ansible/v2/pkg/galaxy"
+	galaxycollection "github.com/apenella/go-ansible/v2/pkg/galaxy/collection"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// AnsibleGalaxyCollectionPublishSubCommand is the ansible-galaxy collection publish subcommand
+	AnsibleGalaxyCollectionPublishSubCommand = "publish"
+)
+
+// AnsibleGalaxyCollectionPublishOptionsFunc is a function to set executor options
+type AnsibleGalaxyCollectionPublishOptionsFunc func(*AnsibleGalaxyCollectionPublishCmd)
+
+// AnsibleGalaxyCollectionPublishCmd object is the main object which defines the `ansible-galaxy` command to publish a collection artifact to a Galaxy server.
+type AnsibleGalaxyCollectionPublishCmd struct {
+	// Binary is the ansible-galaxy binary file
+	Binary string
+
+	// ArtifactPath is the path to the collection artifact to be published, as created by ansible-galaxy collection build
+	ArtifactPath string
+
+	// GalaxyCollectionPublishOptions are the ansible-galaxy's collection publish options
+	GalaxyCollectionPublishOptions *AnsibleGalaxyCollectionPublishOptions
+
+	// SkipValidation disables the collection publish options validation when the command is generated
+	SkipValidation bool
+}
+
+// NewAnsibleGalaxyCollectionPublishCmd creates a new AnsibleGalaxyCollectionPublishCmd instance
+func NewAnsibleGalaxyCollectionPublishCmd(options ...AnsibleGalaxyCollectionPublishOptionsFunc) *AnsibleGalaxyCollectionPublishCmd {
+	cmd := &AnsibleGalaxyCollectionPublishCmd{}
+
+	for _, option := range options {
+		option(cmd)
+	}
+
+	return cmd
+}
+
+// WithBinary set the ansible-galaxy binary file
+func WithBinary(binary string) AnsibleGalaxyCollectionPublishOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionPublishCmd) {
+		p.Binary = binary
+	}
+}
+
+// WithArtifactPath set the path to the collection artifact to be published
+func WithArtifactPath(artifactPath string) AnsibleGalaxyCollectionPublishOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionPublishCmd) {
+		p.ArtifactPath = artifactPath
+	}
+}
+
+// WithGalaxyCollectionPublishOptions set the ansible-galaxy collection publish options
+func WithGalaxyCollectionPublishOptions(options *AnsibleGalaxyCollectionPublishOptions) AnsibleGalaxyCollectionPublishOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionPublishCmd) {
+		p.GalaxyCollectionPublishOptions = options
+	}
+}
+
+// WithoutValidation disables the ansible-galaxy collection publish options validation
+func WithoutValidation() AnsibleGalaxyCollectionPublishOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionPublishCmd) {
+		p.SkipValidation = true
+	}
+}
+
+// Command generate the ansible-galaxy collection publish command which will be executed
+func (p *AnsibleGalaxyCollectionPublishCmd) Command() ([]string, error) {
+	errContext := "(galaxy::AnsibleGalaxyCollectionPublishCmd::Command)"
+	cmd := []string{}
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = galaxy.DefaultAnsibleGalaxyBinary
+	}
+
+	if p.ArtifactPath == "" {
+		return nil, errors.New(errContext, "No collection artifact defined")
+	}
+
+	cmd = append(cmd, p.Binary, galaxycollection.AnsibleGalaxyCollectionSubCommand, AnsibleGalaxyCollectionPublishSubCommand)
+
+	// Add the options
+	if p.GalaxyCollectionPublishOptions != nil {
+		if !p.SkipValidation {
+			err := p.GalaxyCollectionPublishOptions.Validate()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		options, err := p.GalaxyCollectionPublishOptions.GenerateCommandOptions()
+		if err != nil {
+			return nil, err
+		}
+		cmd = append(cmd, options...)
+	}
+
+	// Add the collection artifact
+	if p.ArtifactPath != "" {
+		cmd = append(cmd, p.ArtifactPath)
+	}
+
+	return cmd, nil
+}
+
+// String returns the ansible-galaxy collection publish command as a string
+func (p *AnsibleGalaxyCollectionPublishCmd) String() string {
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = galaxy.DefaultAnsibleGalaxyBinary
+	}
+
+	str := fmt.Sprintf("%s %s %s", p.Binary, galaxycollection.AnsibleGalaxyCollectionSubCommand, AnsibleGalaxyCollectionPublishSubCommand)
+
+	if p.GalaxyCollectionPublishOptions != nil {
+		str = fmt.Sprintf("%s %s", str, p.GalaxyCollectionPublishOptions.String())
+	}
+
+	// Include the collection artifact
+	if p.ArtifactPath != "" {
+		str = fmt.Sprintf("%s %s", str, p.ArtifactPath)
+	}
+
+	return str
+}
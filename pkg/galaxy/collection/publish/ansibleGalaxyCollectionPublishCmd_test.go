@@ -0,0 +1,148 @@
+package galaxycollectionpublish
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/galaxy"
+	galaxycollection "github.com/apenella/go-ansible/v2/pkg/galaxy/collection"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleGalaxyCollectionPublishCmd(t *testing.T) {
+	cmd := NewAnsibleGalaxyCollectionPublishCmd(
+		WithBinary("ansible-galaxy-binary"),
+		WithArtifactPath("internal-tools-1.0.0.tar.gz"),
+		WithGalaxyCollectionPublishOptions(&AnsibleGalaxyCollectionPublishOptions{
+			APIKey: "apikey",
+		}),
+	)
+
+	expect := &AnsibleGalaxyCollectionPublishCmd{
+		Binary:       "ansible-galaxy-binary",
+		ArtifactPath: "internal-tools-1.0.0.tar.gz",
+		GalaxyCollectionPublishOptions: &AnsibleGalaxyCollectionPublishOptions{
+			APIKey: "apikey",
+		},
+	}
+
+	assert.Equal(t, expect, cmd)
+}
+
+func TestAnsibleGalaxyCollectionPublishCmdCommand(t *testing.T) {
+
+	tests := []struct {
+		desc    string
+		cmd     *AnsibleGalaxyCollectionPublishCmd
+		command []string
+		err     error
+	}{
+		{
+			desc: "Testing generate a command for AnsibleGalaxyCollectionPublishCmd with all flags using default binary",
+			cmd: NewAnsibleGalaxyCollectionPublishCmd(
+				WithoutValidation(),
+				WithArtifactPath("internal-tools-1.0.0.tar.gz"),
+				WithGalaxyCollectionPublishOptions(&AnsibleGalaxyCollectionPublishOptions{
+					APIKey:        "apikey",
+					IgnoreCerts:   true,
+					ImportTimeout: 1,
+					NoWait:        true,
+					Server:        "server",
+					Timeout:       "10",
+					Token:         "token",
+					Verbose:       true,
+				}),
+			),
+			err: &errors.Error{},
+			command: []string{
+				galaxy.DefaultAnsibleGalaxyBinary,
+				galaxycollection.AnsibleGalaxyCollectionSubCommand,
+				AnsibleGalaxyCollectionPublishSubCommand,
+				fmt.Sprintf("%s=%s", APIKeyFlag, "apikey"),
+				IgnoreCertsFlag,
+				fmt.Sprintf("%s=%s", ImportTimeoutFlag, "1"),
+				NoWaitFlag,
+				fmt.Sprintf("%s=%s", ServerFlag, "server"),
+				fmt.Sprintf("%s=%s", TimeoutFlag, "10"),
+				fmt.Sprintf("%s=%s", TokenFlag, "token"),
+				VerboseFlag,
+				"internal-tools-1.0.0.tar.gz",
+			},
+		},
+		{
+			desc: "Testing error generating a command for AnsibleGalaxyCollectionPublishCmd without collection artifact",
+			cmd:  NewAnsibleGalaxyCollectionPublishCmd(),
+			err:  errors.New("(galaxy::AnsibleGalaxyCollectionPublishCmd::Command)", "No collection artifact defined"),
+		},
+		{
+			desc: "Testing error generating a command for AnsibleGalaxyCollectionPublishCmd with invalid options",
+			cmd: NewAnsibleGalaxyCollectionPublishCmd(
+				WithArtifactPath("internal-tools-1.0.0.tar.gz"),
+				WithGalaxyCollectionPublishOptions(&AnsibleGalaxyCollectionPublishOptions{
+					NoWait:        true,
+					ImportTimeout: 10,
+				}),
+			),
+			err: errors.New("(galaxy::AnsibleGalaxyCollectionPublishOptions::Validate)", "Invalid ansible-galaxy collection publish options",
+				fmt.Errorf("'%s' is meaningless with '%s'", ImportTimeoutFlag, NoWaitFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			command, err := test.cmd.Command()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.command, command)
+			}
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionPublishCmdString(t *testing.T) {
+
+	tests := []struct {
+		desc string
+		cmd  *AnsibleGalaxyCollectionPublishCmd
+		res  string
+	}{
+		{
+			desc: "Testing AnsibleGalaxyCollectionPublishCmd to string with all flags",
+			cmd: NewAnsibleGalaxyCollectionPublishCmd(
+				WithArtifactPath("internal-tools-1.0.0.tar.gz"),
+				WithGalaxyCollectionPublishOptions(&AnsibleGalaxyCollectionPublishOptions{
+					APIKey:        "apikey",
+					IgnoreCerts:   true,
+					ImportTimeout: 1,
+					NoWait:        true,
+					Server:        "server",
+					Timeout:       "10",
+					Token:         "token",
+					Verbose:       true,
+				}),
+			),
+			res: "ansible-galaxy collection publish --api-key=apikey --ignore-certs --import-timeout=1 --no-wait --server=server --timeout=10 --token=token --verbose internal-tools-1.0.0.tar.gz",
+		},
+		{
+			desc: "Testing AnsibleGalaxyCollectionPublishCmd to string using a custom binary",
+			cmd: NewAnsibleGalaxyCollectionPublishCmd(
+				WithBinary("custom-binary"),
+				WithArtifactPath("internal-tools-1.0.0.tar.gz"),
+			),
+			res: "custom-binary collection publish internal-tools-1.0.0.tar.gz",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, test.cmd.String())
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package galaxycollectionpublish
+
+import (
+	"context"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+)
+
+// AnsibleGalaxyCollectionPublishExecute is an executor for ansible-galaxy collection publish command that runs the command using a DefaultExecute with default options
+type AnsibleGalaxyCollectionPublishExecute struct {
+	cmd  *AnsibleGalaxyCollectionPublishCmd
+	exec execute.Executabler
+}
+
+// NewAnsibleGalaxyCollectionPublishExecute returns a new AnsibleGalaxyCollectionPublishExecute. It receives the path to the collection artifact to publish
+func NewAnsibleGalaxyCollectionPublishExecute(artifactPath string) *AnsibleGalaxyCollectionPublishExecute {
+
+	exec := &AnsibleGalaxyCollectionPublishExecute{
+		cmd: &AnsibleGalaxyCollectionPublishCmd{
+			ArtifactPath: artifactPath,
+		},
+	}
+
+	return exec
+}
+
+// WithBinary returns an AnsibleGalaxyCollectionPublishExecute with the binary file set
+func (e *AnsibleGalaxyCollectionPublishExecute) WithBinary(binary string) *AnsibleGalaxyCollectionPublishExecute {
+	e.cmd.Binary = binary
+
+	return e
+}
+
+// WithExecutable returns an AnsibleGalaxyCollectionPublishExecute with the executable used to run the command set
+func (e *AnsibleGalaxyCollectionPublishExecute) WithExecutable(executable execute.Executabler) *AnsibleGalaxyCollectionPublishExecute {
+	e.exec = executable
+
+	return e
+}
+
+// WithGalaxyCollectionPublishOptions returns an AnsibleGalaxyCollectionPublishExecute with the ansible-galaxy collection publish options set
+func (e *AnsibleGalaxyCollectionPublishExecute) WithGalaxyCollectionPublishOptions(options *AnsibleGalaxyCollectionPublishOptions) *AnsibleGalaxyCollectionPublishExecute {
+	e.cmd.GalaxyCollectionPublishOptions = options
+
+	return e
+}
+
+// Execute method runs the ansible-galaxy collection publish command using a DefaultExecute with default options
+func (e *AnsibleGalaxyCollectionPublishExecute) Execute(ctx context.Context) error {
+
+	options := []execute.ExecuteOptions{
+		execute.WithCmd(e.cmd),
+	}
+
+	if e.exec != nil {
+		options = append(options, execute.WithExecutable(e.exec))
+	}
+
+	exec := execute.NewDefaultExecute(options...)
+
+	err := exec.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package galaxycollectionpublish
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleGalaxyCollectionPublishExecute(t *testing.T) {
+	expect := &AnsibleGalaxyCollectionPublishExecute{
+		cmd: &AnsibleGalaxyCollectionPublishCmd{
+			ArtifactPath: "internal-tools-1.0.0.tar.gz",
+		},
+	}
+
+	res := NewAnsibleGalaxyCollectionPublishExecute("internal-tools-1.0.0.tar.gz")
+
+	assert.Equal(t, expect, res)
+}
+
+func TestAnsibleGalaxyCollectionPublishExecuteExecute(t *testing.T) {
+
+	e := exec.NewMockExec()
+	cmd := exec.NewMockCmd()
+
+	cmd.On("StdoutPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+	cmd.On("StderrPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+	cmd.On("Start").Return(nil)
+	cmd.On("Wait").Return(nil)
+	// the compatibility check detects the ansible version, unless it is already cached
+	versionCmd := exec.NewMockCmd()
+	versionCmd.On("Output").Return([]byte("ansible-galaxy [core 2.16.3]\n"), nil).Maybe()
+	e.On("CommandContext", context.TODO(), "custom-binary", []string{"--version"}).Return(versionCmd).Maybe()
+	e.On("CommandContext", context.TODO(), "custom-binary", []string{"collection", "publish", "--api-key=apikey", "internal-tools-1.0.0.tar.gz"}).Return(cmd)
+
+	err := NewAnsibleGalaxyCollectionPublishExecute("internal-tools-1.0.0.tar.gz").
+		WithBinary("custom-binary").
+		WithExecutable(e).
+		WithGalaxyCollectionPublishOptions(&AnsibleGalaxyCollectionPublishOptions{
+			APIKey: "apikey",
+		}).
+		Execute(context.TODO())
+
+	assert.NoError(t, err)
+	e.AssertExpectations(t)
+	cmd.AssertExpectations(t)
+}
@@ -0,0 +1,184 @@
+package galaxycollectionpublish
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+
+	// APIKeyFlag the Ansible Galaxy API key. Same as --token
+	APIKeyFlag = "--api-key"
+
+	// IgnoreCertsFlag ignores SSL certificate validation errors.
+	IgnoreCertsFlag = "--ignore-certs"
+
+	// ImportTimeoutFlag is the time to wait for the collection import process to finish.
+	ImportTimeoutFlag = "--import-timeout"
+
+	// NoWaitFlag doesn't wait for import validation results.
+	NoWaitFlag = "--no-wait"
+
+	// ServerFlag is the Galaxy API server URL.
+	ServerFlag = "--server"
+
+	// TimeoutFlag is the time to wait for operations against the galaxy server, defaults to 60s.
+	TimeoutFlag = "--timeout"
+
+	// TokenFlag the Ansible Galaxy API key. Same as --api-key
+	TokenFlag = "--token"
+
+	// VerboseFlag verbose mode enabled
+	VerboseFlag = "--verbose"
+)
+
+// AnsibleGalaxyCollectionPublishOptions are the ansible-galaxy collection publish options
+type AnsibleGalaxyCollectionPublishOptions struct {
+
+	// APIKey is the Ansible Galaxy API key.
+	APIKey string
+
+	// IgnoreCerts ignores SSL certificate validation errors.
+	IgnoreCerts bool
+
+	// ImportTimeout is the time to wait for the collection import process to finish.
+	ImportTimeout int
+
+	// NoWait doesn't wait for import validation results.
+	NoWait bool
+
+	// Server is the Galaxy API server URL.
+	Server string
+
+	// Timeout is the time to wait for operations against the galaxy server, defaults to 60s.
+	Timeout string
+
+	// Token is the Ansible Galaxy API key.
+	Token string
+
+	// Verbose verbose mode enabled
+	Verbose bool
+}
+
+// GenerateCommandOptions return a list of command options flags to be used on ansible-galaxy collection publish execution
+func (o *AnsibleGalaxyCollectionPublishOptions) GenerateCommandOptions() ([]string, error) {
+	errContext := "(galaxy::AnsibleGalaxyCollectionPublishOptions::GenerateCommandOptions)"
+	options := []string{}
+
+	if o == nil {
+		return nil, errors.New(errContext, "AnsibleGalaxyCollectionPublishOptions is nil")
+	}
+
+	if o.APIKey != "" {
+		options = append(options, fmt.Sprintf("%s=%s", APIKeyFlag, o.APIKey))
+	}
+
+	if o.IgnoreCerts {
+		options = append(options, IgnoreCertsFlag)
+	}
+
+	if o.ImportTimeout > 0 {
+		options = append(options, fmt.Sprintf("%s=%d", ImportTimeoutFlag, o.ImportTimeout))
+	}
+
+	if o.NoWait {
+		options = append(options, NoWaitFlag)
+	}
+
+	if o.Server != "" {
+		options = append(options, fmt.Sprintf("%s=%s", ServerFlag, o.Server))
+	}
+
+	if o.Timeout != "" {
+		options = append(options, fmt.Sprintf("%s=%s", TimeoutFlag, o.Timeout))
+	}
+
+	if o.Token != "" {
+		options = append(options, fmt.Sprintf("%s=%s", TokenFlag, o.Token))
+	}
+
+	if o.Verbose {
+		options = append(options, VerboseFlag)
+	}
+
+	return options, nil
+}
+
+// Validate checks the options looking for mutually exclusive or meaningless flag combinations and unsupported values. It returns an error that wraps all the detected issues
+func (o *AnsibleGalaxyCollectionPublishOptions) Validate() error {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionPublishOptions::Validate)"
+	errs := []error{}
+
+	if o == nil {
+		return errors.New(errContext, "AnsibleGalaxyCollectionPublishOptions is nil")
+	}
+
+	if o.APIKey != "" && o.Token != "" {
+		errs = append(errs, fmt.Errorf("'%s' and '%s' are mutually exclusive", APIKeyFlag, TokenFlag))
+	}
+
+	if o.ImportTimeout < 0 {
+		errs = append(errs, fmt.Errorf("'%s' must not be negative, but '%d' was provided", ImportTimeoutFlag, o.ImportTimeout))
+	}
+
+	// the import timeout is only used when waiting for the import results
+	if o.NoWait && o.ImportTimeout > 0 {
+		errs = append(errs, fmt.Errorf("'%s' is meaningless with '%s'", ImportTimeoutFlag, NoWaitFlag))
+	}
+
+	if o.Timeout != "" {
+		timeout, err := strconv.Atoi(o.Timeout)
+		if err != nil || timeout < 1 {
+			errs = append(errs, fmt.Errorf("'%s' must be a positive integer, but '%s' was provided", TimeoutFlag, o.Timeout))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid ansible-galaxy collection publish options", errs...)
+	}
+
+	return nil
+}
+
+// String return a string representation of the AnsibleGalaxyCollectionPublishOptions
+func (o *AnsibleGalaxyCollectionPublishOptions) String() string {
+	str := ""
+
+	if o.APIKey != "" {
+		str = fmt.Sprintf("%s %s=%s", str, APIKeyFlag, o.APIKey)
+	}
+
+	if o.IgnoreCerts {
+		str = fmt.Sprintf("%s %s", str, IgnoreCertsFlag)
+	}
+
+	if o.ImportTimeout > 0 {
+		str = fmt.Sprintf("%s %s=%d", str, ImportTimeoutFlag, o.ImportTimeout)
+	}
+
+	if o.NoWait {
+		str = fmt.Sprintf("%s %s", str, NoWaitFlag)
+	}
+
+	if o.Server != "" {
+		str = fmt.Sprintf("%s %s=%s", str, ServerFlag, o.Server)
+	}
+
+	if o.Timeout != "" {
+		str = fmt.Sprintf("%s %s=%s", str, TimeoutFlag, o.Timeout)
+	}
+
+	if o.Token != "" {
+		str = fmt.Sprintf("%s %s=%s", str, TokenFlag, o.Token)
+	}
+
+	if o.Verbose {
+		str = fmt.Sprintf("%s %s", str, VerboseFlag)
+	}
+
+	return strings.TrimSpace(str)
+}
@@ -0,0 +1,163 @@
+package galaxycollectionpublish
+
+import (
+	"fmt"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnsibleGalaxyCollectionPublishOptionsGenerateCommandOptions(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionPublishOptions::GenerateCommandOptions)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionPublishOptions
+		err     error
+		expect  []string
+	}{
+		{
+			desc:    "Testing nil AnsibleGalaxyCollectionPublishOptions definition",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyCollectionPublishOptions is nil"),
+		},
+		{
+			desc:    "Testing an empty AnsibleGalaxyCollectionPublishOptions definition",
+			options: &AnsibleGalaxyCollectionPublishOptions{},
+			expect:  []string{},
+		},
+		{
+			desc: "Testing AnsibleGalaxyCollectionPublishOptions with all flags",
+			options: &AnsibleGalaxyCollectionPublishOptions{
+				APIKey:        "apikey",
+				IgnoreCerts:   true,
+				ImportTimeout: 1,
+				NoWait:        true,
+				Server:        "server",
+				Timeout:       "10",
+				Token:         "token",
+				Verbose:       true,
+			},
+			expect: []string{
+				fmt.Sprintf("%s=%s", APIKeyFlag, "apikey"),
+				IgnoreCertsFlag,
+				fmt.Sprintf("%s=%s", ImportTimeoutFlag, "1"),
+				NoWaitFlag,
+				fmt.Sprintf("%s=%s", ServerFlag, "server"),
+				fmt.Sprintf("%s=%s", TimeoutFlag, "10"),
+				fmt.Sprintf("%s=%s", TokenFlag, "token"),
+				VerboseFlag,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			options, err := test.options.GenerateCommandOptions()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.expect, options, "Unexpected options value")
+			}
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionPublishOptionsString(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionPublishOptions
+		expect  string
+	}{
+		{
+			desc:    "Testing generate string from an empty AnsibleGalaxyCollectionPublishOptions",
+			options: &AnsibleGalaxyCollectionPublishOptions{},
+			expect:  "",
+		},
+		{
+			desc: "Testing generate string from an AnsibleGalaxyCollectionPublishOptions with all flags",
+			options: &AnsibleGalaxyCollectionPublishOptions{
+				APIKey:        "apikey",
+				IgnoreCerts:   true,
+				ImportTimeout: 1,
+				NoWait:        true,
+				Server:        "server",
+				Timeout:       "10",
+				Token:         "token",
+				Verbose:       true,
+			},
+			expect: "--api-key=apikey --ignore-certs --import-timeout=1 --no-wait --server=server --timeout=10 --token=token --verbose",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expect, test.options.String())
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionPublishOptionsValidate(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionPublishOptions::Validate)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionPublishOptions
+		err     error
+	}{
+		{
+			desc:    "Testing validate nil AnsibleGalaxyCollectionPublishOptions",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyCollectionPublishOptions is nil"),
+		},
+		{
+			desc: "Testing validate valid AnsibleGalaxyCollectionPublishOptions",
+			options: &AnsibleGalaxyCollectionPublishOptions{
+				ImportTimeout: 300,
+				Server:        "https://galaxy.example.com",
+				Token:         "token",
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing validate invalid AnsibleGalaxyCollectionPublishOptions",
+			options: &AnsibleGalaxyCollectionPublishOptions{
+				APIKey:        "apikey",
+				ImportTimeout: 300,
+				NoWait:        true,
+				Timeout:       "-1",
+				Token:         "token",
+			},
+			err: errors.New(errContext, "Invalid ansible-galaxy collection publish options",
+				fmt.Errorf("'%s' and '%s' are mutually exclusive", APIKeyFlag, TokenFlag),
+				fmt.Errorf("'%s' is meaningless with '%s'", ImportTimeoutFlag, NoWaitFlag),
+				fmt.Errorf("'%s' must be a positive integer, but '-1' was provided", TimeoutFlag),
+			),
+		},
+		{
+			desc: "Testing validate AnsibleGalaxyCollectionPublishOptions with a negative import timeout",
+			options: &AnsibleGalaxyCollectionPublishOptions{
+				ImportTimeout: -1,
+			},
+			err: errors.New(errContext, "Invalid ansible-galaxy collection publish options",
+				fmt.Errorf("'%s' must not be negative, but '-1' was provided", ImportTimeoutFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.Validate()
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
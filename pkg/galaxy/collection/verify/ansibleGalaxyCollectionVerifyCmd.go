@@ -0,0 +1,144 @@
+package galaxycollectionverify
+
+import (
+	"fmt"
+
+	galaxy "github.com/apenella/go-ansible/v2/pkg/galaxy"
+	galaxycollection "github.com/apenella/go-ansible/v2/pkg/galaxy/collection"
+)
+
+const (
+	// AnsibleGalaxyCollectionVerifySubCommand is the ansible-galaxy collection verify subcommand
+	AnsibleGalaxyCollectionVerifySubCommand = "verify"
+)
+
+// AnsibleGalaxyCollectionVerifyOptionsFunc is a function to set executor options
+type AnsibleGalaxyCollectionVerifyOptionsFunc func(*AnsibleGalaxyCollectionVerifyCmd)
+
+// AnsibleGalaxyCollectionVerifyCmd object is the main object which defines the `ansible-galaxy` command to verify the installed collections.
+type AnsibleGalaxyCollectionVerifyCmd struct {
+	// Binary is the ansible-galaxy binary file
+	Binary string
+
+	// CollectionNames is the ansible-galaxy's collection names to be verified
+	CollectionNames []string
+
+	// GalaxyCollectionVerifyOptions are the ansible-galaxy's collection verify options
+	GalaxyCollectionVerifyOptions *AnsibleGalaxyCollectionVerifyOptions
+
+	// SkipValidation disables the collection verify options validation when the command is generated
+	SkipValidation bool
+	// FileValidation enables checking that the files referenced by the options exist when the command is generated
+	FileValidation bool
+	// FileValidationDir is the directory the relative files are resolved against when the files are validated
+	FileValidationDir string
+}
+
+// NewAnsibleGalaxyCollectionVerifyCmd creates a new AnsibleGalaxyCollectionVerifyCmd instance
+func NewAnsibleGalaxyCollectionVerifyCmd(options ...AnsibleGalaxyCollectionVerifyOptionsFunc) *AnsibleGalaxyCollectionVerifyCmd {
+	cmd := &AnsibleGalaxyCollectionVerifyCmd{}
+
+	for _, option := range options {
+		option(cmd)
+	}
+
+	return cmd
+}
+
+// WithBinary set the ansible-galaxy binary file
+func WithBinary(binary string) AnsibleGalaxyCollectionVerifyOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionVerifyCmd) {
+		p.Binary = binary
+	}
+}
+
+// WithCollectionNames set the ansible-galaxy collection names to be verified
+func WithCollectionNames(collectionNames ...string) AnsibleGalaxyCollectionVerifyOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionVerifyCmd) {
+		p.CollectionNames = append([]string{}, collectionNames...)
+	}
+}
+
+// WithGalaxyCollectionVerifyOptions set the ansible-galaxy collection verify options
+func WithGalaxyCollectionVerifyOptions(options *AnsibleGalaxyCollectionVerifyOptions) AnsibleGalaxyCollectionVerifyOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionVerifyCmd) {
+		p.GalaxyCollectionVerifyOptions = options
+	}
+}
+
+// WithoutValidation disables the ansible-galaxy collection verify options validation
+func WithoutValidation() AnsibleGalaxyCollectionVerifyOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionVerifyCmd) {
+		p.SkipValidation = true
+	}
+}
+
+// WithFileValidation enables checking that the files referenced by the ansible-galaxy collection verify options exist. The relative files are resolved against dir, which is the directory where the command runs, or against the working directory when dir is empty. Do not enable it when the command runs on another host or container
+func WithFileValidation(dir string) AnsibleGalaxyCollectionVerifyOptionsFunc {
+	return func(p *AnsibleGalaxyCollectionVerifyCmd) {
+		p.FileValidation = true
+		p.FileValidationDir = dir
+	}
+}
+
+// Command generate the ansible-galaxy collection verify command which will be executed
+func (p *AnsibleGalaxyCollectionVerifyCmd) Command() ([]string, error) {
+	cmd := []string{}
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = galaxy.DefaultAnsibleGalaxyBinary
+	}
+
+	cmd = append(cmd, p.Binary, galaxycollection.AnsibleGalaxyCollectionSubCommand, AnsibleGalaxyCollectionVerifySubCommand)
+
+	// Add the options
+	if p.GalaxyCollectionVerifyOptions != nil {
+		if !p.SkipValidation {
+			err := p.GalaxyCollectionVerifyOptions.Validate()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if p.FileValidation {
+			err := p.GalaxyCollectionVerifyOptions.ValidateFiles(p.FileValidationDir)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		options, err := p.GalaxyCollectionVerifyOptions.GenerateCommandOptions()
+		if err != nil {
+			return nil, err
+		}
+		cmd = append(cmd, options...)
+	}
+
+	// Add the collection names
+	cmd = append(cmd, p.CollectionNames...)
+
+	return cmd, nil
+}
+
+// String returns the ansible-galaxy collection verify command as a string
+func (p *AnsibleGalaxyCollectionVerifyCmd) String() string {
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = galaxy.DefaultAnsibleGalaxyBinary
+	}
+
+	str := fmt.Sprintf("%s %s %s", p.Binary, galaxycollection.AnsibleGalaxyCollectionSubCommand, AnsibleGalaxyCollectionVerifySubCommand)
+
+	if p.GalaxyCollectionVerifyOptions != nil {
+		str = fmt.Sprintf("%s %s", str, p.GalaxyCollectionVerifyOptions.String())
+	}
+
+	// Include the collection names
+	for _, collectionName := range p.CollectionNames {
+		str = fmt.Sprintf("%s %s", str, collectionName)
+	}
+
+	return str
+}
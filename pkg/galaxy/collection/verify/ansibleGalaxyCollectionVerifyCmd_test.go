@@ -0,0 +1,160 @@
+package galaxycollectionverify
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/galaxy"
+	galaxycollection "github.com/apenella/go-ansible/v2/pkg/galaxy/collection"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleGalaxyCollectionVerifyCmd(t *testing.T) {
+	cmd := NewAnsibleGalaxyCollectionVerifyCmd(
+		WithBinary("ansible-galaxy-binary"),
+		WithCollectionNames("community.general"),
+		WithGalaxyCollectionVerifyOptions(&AnsibleGalaxyCollectionVerifyOptions{
+			APIKey: "apikey",
+		}),
+	)
+
+	expect := &AnsibleGalaxyCollectionVerifyCmd{
+		Binary:          "ansible-galaxy-binary",
+		CollectionNames: []string{"community.general"},
+		GalaxyCollectionVerifyOptions: &AnsibleGalaxyCollectionVerifyOptions{
+			APIKey: "apikey",
+		},
+	}
+
+	assert.Equal(t, expect, cmd)
+}
+
+func TestAnsibleGalaxyCollectionVerifyCmdCommand(t *testing.T) {
+
+	tests := []struct {
+		desc    string
+		cmd     *AnsibleGalaxyCollectionVerifyCmd
+		command []string
+		err     error
+	}{
+		{
+			desc: "Testing generate a command for AnsibleGalaxyCollectionVerifyCmd with all flags using default binary",
+			cmd: NewAnsibleGalaxyCollectionVerifyCmd(
+				WithoutValidation(),
+				WithCollectionNames("community.general"),
+				WithGalaxyCollectionVerifyOptions(&AnsibleGalaxyCollectionVerifyOptions{
+					APIKey:                      "apikey",
+					CollectionsPath:             "path",
+					IgnoreCerts:                 true,
+					IgnoreErrors:                true,
+					IgnoreSignatureStatusCode:   "NO_PUBKEY",
+					Keyring:                     "keyring",
+					Offline:                     true,
+					RequiredValidSignatureCount: 1,
+					RequirementsFile:            "requirements",
+					Server:                      "server",
+					Signature:                   "signature",
+					Timeout:                     "10",
+					Token:                       "token",
+					Verbose:                     true,
+				}),
+			),
+			err: &errors.Error{},
+			command: []string{
+				galaxy.DefaultAnsibleGalaxyBinary,
+				galaxycollection.AnsibleGalaxyCollectionSubCommand,
+				AnsibleGalaxyCollectionVerifySubCommand,
+				fmt.Sprintf("%s=%s", APIKeyFlag, "apikey"),
+				fmt.Sprintf("%s=%s", CollectionsPathFlag, "path"),
+				IgnoreCertsFlag,
+				IgnoreErrorsFlag,
+				fmt.Sprintf("%s=%s", IgnoreSignatureStatusCodeFlag, "NO_PUBKEY"),
+				fmt.Sprintf("%s=%s", KeyringFlag, "keyring"),
+				OfflineFlag,
+				fmt.Sprintf("%s=%s", RequiredValidSignatureCountFlag, "1"),
+				fmt.Sprintf("%s=%s", RequirementsFileFlag, "requirements"),
+				fmt.Sprintf("%s=%s", ServerFlag, "server"),
+				fmt.Sprintf("%s=%s", SignatureFlag, "signature"),
+				fmt.Sprintf("%s=%s", TimeoutFlag, "10"),
+				fmt.Sprintf("%s=%s", TokenFlag, "token"),
+				VerboseFlag,
+				"community.general",
+			},
+		},
+		{
+			desc: "Testing error generating a command for AnsibleGalaxyCollectionVerifyCmd with invalid options",
+			cmd: NewAnsibleGalaxyCollectionVerifyCmd(
+				WithGalaxyCollectionVerifyOptions(&AnsibleGalaxyCollectionVerifyOptions{
+					Offline: true,
+					Server:  "server",
+				}),
+			),
+			err: errors.New("(galaxy::AnsibleGalaxyCollectionVerifyOptions::Validate)", "Invalid ansible-galaxy collection verify options",
+				fmt.Errorf("'%s' is meaningless with '%s'", ServerFlag, OfflineFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			command, err := test.cmd.Command()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.command, command)
+			}
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionVerifyCmdString(t *testing.T) {
+
+	tests := []struct {
+		desc string
+		cmd  *AnsibleGalaxyCollectionVerifyCmd
+		res  string
+	}{
+		{
+			desc: "Testing AnsibleGalaxyCollectionVerifyCmd to string with all flags",
+			cmd: NewAnsibleGalaxyCollectionVerifyCmd(
+				WithCollectionNames("community.general"),
+				WithGalaxyCollectionVerifyOptions(&AnsibleGalaxyCollectionVerifyOptions{
+					APIKey:                      "apikey",
+					CollectionsPath:             "path",
+					IgnoreCerts:                 true,
+					IgnoreErrors:                true,
+					IgnoreSignatureStatusCode:   "NO_PUBKEY",
+					Keyring:                     "keyring",
+					Offline:                     true,
+					RequiredValidSignatureCount: 1,
+					RequirementsFile:            "requirements",
+					Server:                      "server",
+					Signature:                   "signature",
+					Timeout:                     "10",
+					Token:                       "token",
+					Verbose:                     true,
+				}),
+			),
+			res: "ansible-galaxy collection verify --api-key=apikey --collections-path=path --ignore-certs --ignore-errors --ignore-signature-status-code=NO_PUBKEY --keyring=keyring --offline --required-valid-signature-count=1 --requirements-file=requirements --server=server --signature=signature --timeout=10 --token=token --verbose community.general",
+		},
+		{
+			desc: "Testing AnsibleGalaxyCollectionVerifyCmd to string using a custom binary",
+			cmd: NewAnsibleGalaxyCollectionVerifyCmd(
+				WithBinary("custom-binary"),
+				WithCollectionNames("community.general"),
+			),
+			res: "custom-binary collection verify community.general",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, test.cmd.String())
+		})
+	}
+}
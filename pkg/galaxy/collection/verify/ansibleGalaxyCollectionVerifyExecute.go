@@ -0,0 +1,67 @@
+package galaxycollectionverify
+
+import (
+	"context"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+)
+
+// AnsibleGalaxyCollectionVerifyExecute is an executor for ansible-galaxy collection verify command that runs the command using a DefaultExecute with default options
+type AnsibleGalaxyCollectionVerifyExecute struct {
+	cmd  *AnsibleGalaxyCollectionVerifyCmd
+	exec execute.Executabler
+}
+
+// NewAnsibleGalaxyCollectionVerifyExecute returns a new AnsibleGalaxyCollectionVerifyExecute. It receives the collection names to verify
+func NewAnsibleGalaxyCollectionVerifyExecute(collectionNames ...string) *AnsibleGalaxyCollectionVerifyExecute {
+
+	exec := &AnsibleGalaxyCollectionVerifyExecute{
+		cmd: &AnsibleGalaxyCollectionVerifyCmd{
+			CollectionNames: append([]string{}, collectionNames...),
+		},
+	}
+
+	return exec
+}
+
+// WithBinary returns an AnsibleGalaxyCollectionVerifyExecute with the binary file set
+func (e *AnsibleGalaxyCollectionVerifyExecute) WithBinary(binary string) *AnsibleGalaxyCollectionVerifyExecute {
+	e.cmd.Binary = binary
+
+	return e
+}
+
+// WithExecutable returns an AnsibleGalaxyCollectionVerifyExecute with the executable used to run the command set
+func (e *AnsibleGalaxyCollectionVerifyExecute) WithExecutable(executable execute.Executabler) *AnsibleGalaxyCollectionVerifyExecute {
+	e.exec = executable
+
+	return e
+}
+
+// WithGalaxyCollectionVerifyOptions returns an AnsibleGalaxyCollectionVerifyExecute with the ansible-galaxy collection verify options set
+func (e *AnsibleGalaxyCollectionVerifyExecute) WithGalaxyCollectionVerifyOptions(options *AnsibleGalaxyCollectionVerifyOptions) *AnsibleGalaxyCollectionVerifyExecute {
+	e.cmd.GalaxyCollectionVerifyOptions = options
+
+	return e
+}
+
+// Execute method runs the ansible-galaxy collection verify command using a DefaultExecute with default options
+func (e *AnsibleGalaxyCollectionVerifyExecute) Execute(ctx context.Context) error {
+
+	options := []execute.ExecuteOptions{
+		execute.WithCmd(e.cmd),
+	}
+
+	if e.exec != nil {
+		options = append(options, execute.WithExecutable(e.exec))
+	}
+
+	exec := execute.NewDefaultExecute(options...)
+
+	err := exec.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package galaxycollectionverify
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleGalaxyCollectionVerifyExecute(t *testing.T) {
+	expect := &AnsibleGalaxyCollectionVerifyExecute{
+		cmd: &AnsibleGalaxyCollectionVerifyCmd{
+			CollectionNames: []string{"community.general"},
+		},
+	}
+
+	res := NewAnsibleGalaxyCollectionVerifyExecute("community.general")
+
+	assert.Equal(t, expect, res)
+}
+
+func TestAnsibleGalaxyCollectionVerifyExecuteExecute(t *testing.T) {
+
+	e := exec.NewMockExec()
+	cmd := exec.NewMockCmd()
+
+	cmd.On("StdoutPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+	cmd.On("StderrPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+	cmd.On("Start").Return(nil)
+	cmd.On("Wait").Return(nil)
+	// the compatibility check detects the ansible version, unless it is already cached
+	versionCmd := exec.NewMockCmd()
+	versionCmd.On("Output").Return([]byte("ansible-galaxy [core 2.16.3]\n"), nil).Maybe()
+	e.On("CommandContext", context.TODO(), "custom-binary", []string{"--version"}).Return(versionCmd).Maybe()
+	e.On("CommandContext", context.TODO(), "custom-binary", []string{"collection", "verify", "--api-key=apikey", "community.general"}).Return(cmd)
+
+	err := NewAnsibleGalaxyCollectionVerifyExecute("community.general").
+		WithBinary("custom-binary").
+		WithExecutable(e).
+		WithGalaxyCollectionVerifyOptions(&AnsibleGalaxyCollectionVerifyOptions{
+			APIKey: "apikey",
+		}).
+		Execute(context.TODO())
+
+	assert.NoError(t, err)
+	e.AssertExpectations(t)
+	cmd.AssertExpectations(t)
+}
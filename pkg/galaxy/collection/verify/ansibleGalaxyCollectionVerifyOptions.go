@@ -0,0 +1,299 @@
+package galaxycollectionverify
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/apenella/go-ansible/v2/pkg/internal/validation"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+
+	// APIKeyFlag the Ansible Galaxy API key. Same as --token
+	APIKeyFlag = "--api-key"
+
+	// CollectionsPathFlag is one or more directories to search for collections in addition to the default COLLECTIONS_PATHS. Separate multiple paths with ':'.
+	CollectionsPathFlag = "--collections-path"
+
+	// IgnoreCertsFlag ignores SSL certificate validation errors.
+	IgnoreCertsFlag = "--ignore-certs"
+
+	// IgnoreErrorsFlag ignores errors during verification and continue with the next specified collection.
+	IgnoreErrorsFlag = "--ignore-errors"
+
+	// IgnoreSignatureStatusCodeFlag is a status code to ignore during signature verification, for example NO_PUBKEY. Provide this option multiple times to ignore a list of status codes.
+	IgnoreSignatureStatusCodeFlag = "--ignore-signature-status-code"
+
+	// KeyringFlag is the keyring used during signature verification.
+	KeyringFlag = "--keyring"
+
+	// OfflineFlag validates collection integrity locally without contacting server for canonical manifest hash.
+	OfflineFlag = "--offline"
+
+	// RequiredValidSignatureCountFlag is the number of signatures that must successfully verify the collection.
+	RequiredValidSignatureCountFlag = "--required-valid-signature-count"
+
+	// RequirementsFileFlag is a file containing a list of collections to be verified.
+	RequirementsFileFlag = "--requirements-file"
+
+	// ServerFlag is the Galaxy API server URL.
+	ServerFlag = "--server"
+
+	// SignatureFlag is an additional signature source to verify the authenticity of the MANIFEST.json before using it to verify the rest of the contents of a collection from a Galaxy server.
+	SignatureFlag = "--signature"
+
+	// TimeoutFlag is the time to wait for operations against the galaxy server, defaults to 60s.
+	TimeoutFlag = "--timeout"
+
+	// TokenFlag the Ansible Galaxy API key. Same as --api-key
+	TokenFlag = "--token"
+
+	// VerboseFlag verbose mode enabled
+	VerboseFlag = "--verbose"
+)
+
+// AnsibleGalaxyCollectionVerifyOptions are the ansible-galaxy collection verify options
+type AnsibleGalaxyCollectionVerifyOptions struct {
+
+	// APIKey is the Ansible Galaxy API key.
+	APIKey string
+
+	// CollectionsPath is one or more directories to search for collections in addition to the default COLLECTIONS_PATHS. Separate multiple paths with ':'.
+	CollectionsPath string
+
+	// IgnoreCerts ignores SSL certificate validation errors.
+	IgnoreCerts bool
+
+	// IgnoreErrors ignores errors during verification and continue with the next specified collection.
+	IgnoreErrors bool
+
+	// IgnoreSignatureStatusCode is a status code to ignore during signature verification, for example NO_PUBKEY. Provide this option multiple times to ignore a list of status codes.
+	IgnoreSignatureStatusCode string
+
+	// Keyring is the keyring used during signature verification.
+	Keyring string
+
+	// Offline validates collection integrity locally without contacting server for canonical manifest hash.
+	Offline bool
+
+	// RequiredValidSignatureCount is the number of signatures that must successfully verify the collection.
+	RequiredValidSignatureCount int
+
+	// RequirementsFile is a file containing a list of collections to be verified.
+	RequirementsFile string
+
+	// Server is the Galaxy API server URL.
+	Server string
+
+	// Signature is an additional signature source to verify the authenticity of the MANIFEST.json before using it to verify the rest of the contents of a collection from a Galaxy server.
+	Signature string
+
+	// Timeout is the time to wait for operations against the galaxy server, defaults to 60s.
+	Timeout string
+
+	// Token is the Ansible Galaxy API key.
+	Token string
+
+	// Verbose verbose mode enabled
+	Verbose bool
+}
+
+// GenerateCommandOptions return a list of command options flags to be used on ansible-galaxy collection verify execution
+func (o *AnsibleGalaxyCollectionVerifyOptions) GenerateCommandOptions() ([]string, error) {
+	errContext := "(galaxy::AnsibleGalaxyCollectionVerifyOptions::GenerateCommandOptions)"
+	options := []string{}
+
+	if o == nil {
+		return nil, errors.New(errContext, "AnsibleGalaxyCollectionVerifyOptions is nil")
+	}
+
+	if o.APIKey != "" {
+		options = append(options, fmt.Sprintf("%s=%s", APIKeyFlag, o.APIKey))
+	}
+
+	if o.CollectionsPath != "" {
+		options = append(options, fmt.Sprintf("%s=%s", CollectionsPathFlag, o.CollectionsPath))
+	}
+
+	if o.IgnoreCerts {
+		options = append(options, IgnoreCertsFlag)
+	}
+
+	if o.IgnoreErrors {
+		options = append(options, IgnoreErrorsFlag)
+	}
+
+	if o.IgnoreSignatureStatusCode != "" {
+		options = append(options, fmt.Sprintf("%s=%s", IgnoreSignatureStatusCodeFlag, o.IgnoreSignatureStatusCode))
+	}
+
+	if o.Keyring != "" {
+		options = append(options, fmt.Sprintf("%s=%s", KeyringFlag, o.Keyring))
+	}
+
+	if o.Offline {
+		options = append(options, OfflineFlag)
+	}
+
+	if o.RequiredValidSignatureCount > 0 {
+		options = append(options, fmt.Sprintf("%s=%d", RequiredValidSignatureCountFlag, o.RequiredValidSignatureCount))
+	}
+
+	if o.RequirementsFile != "" {
+		options = append(options, fmt.Sprintf("%s=%s", RequirementsFileFlag, o.RequirementsFile))
+	}
+
+	if o.Server != "" {
+		options = append(options, fmt.Sprintf("%s=%s", ServerFlag, o.Server))
+	}
+
+	if o.Signature != "" {
+		options = append(options, fmt.Sprintf("%s=%s", SignatureFlag, o.Signature))
+	}
+
+	if o.Timeout != "" {
+		options = append(options, fmt.Sprintf("%s=%s", TimeoutFlag, o.Timeout))
+	}
+
+	if o.Token != "" {
+		options = append(options, fmt.Sprintf("%s=%s", TokenFlag, o.Token))
+	}
+
+	if o.Verbose {
+		options = append(options, VerboseFlag)
+	}
+
+	return options, nil
+}
+
+// Validate checks the options looking for mutually exclusive or meaningless flag combinations and unsupported values. It returns an error that wraps all the detected issues
+func (o *AnsibleGalaxyCollectionVerifyOptions) Validate() error {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionVerifyOptions::Validate)"
+	errs := []error{}
+
+	if o == nil {
+		return errors.New(errContext, "AnsibleGalaxyCollectionVerifyOptions is nil")
+	}
+
+	if o.APIKey != "" && o.Token != "" {
+		errs = append(errs, fmt.Errorf("'%s' and '%s' are mutually exclusive", APIKeyFlag, TokenFlag))
+	}
+
+	// offline verifications never contact a distribution server
+	if o.Offline && o.Server != "" {
+		errs = append(errs, fmt.Errorf("'%s' is meaningless with '%s'", ServerFlag, OfflineFlag))
+	}
+
+	if o.RequiredValidSignatureCount < 0 {
+		errs = append(errs, fmt.Errorf("'%s' must not be negative, but '%d' was provided", RequiredValidSignatureCountFlag, o.RequiredValidSignatureCount))
+	}
+
+	if o.Timeout != "" {
+		timeout, err := strconv.Atoi(o.Timeout)
+		if err != nil || timeout < 1 {
+			errs = append(errs, fmt.Errorf("'%s' must be a positive integer, but '%s' was provided", TimeoutFlag, o.Timeout))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid ansible-galaxy collection verify options", errs...)
+	}
+
+	return nil
+}
+
+// ValidateFiles checks that the files referenced by the options exist and are not directories. The relative files are resolved against dir, which is the directory where the command runs, or against the working directory when dir is empty. It is not part of Validate because the command may run on another directory, host or container
+func (o *AnsibleGalaxyCollectionVerifyOptions) ValidateFiles(dir string) error {
+	errContext := "(galaxy::AnsibleGalaxyCollectionVerifyOptions::ValidateFiles)"
+	errs := []error{}
+
+	if o == nil {
+		return errors.New(errContext, "AnsibleGalaxyCollectionVerifyOptions is nil")
+	}
+
+	if o.Keyring != "" {
+		err := validation.FileExists(dir, o.Keyring)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("'%s' file is not valid: %w", KeyringFlag, err))
+		}
+	}
+
+	if o.RequirementsFile != "" {
+		err := validation.FileExists(dir, o.RequirementsFile)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("'%s' file is not valid: %w", RequirementsFileFlag, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid ansible-galaxy collection verify option files", errs...)
+	}
+
+	return nil
+}
+
+// String return a string representation of the AnsibleGalaxyCollectionVerifyOptions
+func (o *AnsibleGalaxyCollectionVerifyOptions) String() string {
+	str := ""
+
+	if o.APIKey != "" {
+		str = fmt.Sprintf("%s %s=%s", str, APIKeyFlag, o.APIKey)
+	}
+
+	if o.CollectionsPath != "" {
+		str = fmt.Sprintf("%s %s=%s", str, CollectionsPathFlag, o.CollectionsPath)
+	}
+
+	if o.IgnoreCerts {
+		str = fmt.Sprintf("%s %s", str, IgnoreCertsFlag)
+	}
+
+	if o.IgnoreErrors {
+		str = fmt.Sprintf("%s %s", str, IgnoreErrorsFlag)
+	}
+
+	if o.IgnoreSignatureStatusCode != "" {
+		str = fmt.Sprintf("%s %s=%s", str, IgnoreSignatureStatusCodeFlag, o.IgnoreSignatureStatusCode)
+	}
+
+	if o.Keyring != "" {
+		str = fmt.Sprintf("%s %s=%s", str, KeyringFlag, o.Keyring)
+	}
+
+	if o.Offline {
+		str = fmt.Sprintf("%s %s", str, OfflineFlag)
+	}
+
+	if o.RequiredValidSignatureCount > 0 {
+		str = fmt.Sprintf("%s %s=%d", str, RequiredValidSignatureCountFlag, o.RequiredValidSignatureCount)
+	}
+
+	if o.RequirementsFile != "" {
+		str = fmt.Sprintf("%s %s=%s", str, RequirementsFileFlag, o.RequirementsFile)
+	}
+
+	if o.Server != "" {
+		str = fmt.Sprintf("%s %s=%s", str, ServerFlag, o.Server)
+	}
+
+	if o.Signature != "" {
+		str = fmt.Sprintf("%s %s=%s", str, SignatureFlag, o.Signature)
+	}
+
+	if o.Timeout != "" {
+		str = fmt.Sprintf("%s %s=%s", str, TimeoutFlag, o.Timeout)
+	}
+
+	if o.Token != "" {
+		str = fmt.Sprintf("%s %s=%s", str, TokenFlag, o.Token)
+	}
+
+	if o.Verbose {
+		str = fmt.Sprintf("%s %s", str, VerboseFlag)
+	}
+
+	return strings.TrimSpace(str)
+}
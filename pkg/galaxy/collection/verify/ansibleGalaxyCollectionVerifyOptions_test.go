@@ -0,0 +1,240 @@
+package galaxycollectionverify
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnsibleGalaxyCollectionVerifyOptionsGenerateCommandOptions(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionVerifyOptions::GenerateCommandOptions)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionVerifyOptions
+		err     error
+		expect  []string
+	}{
+		{
+			desc:    "Testing nil AnsibleGalaxyCollectionVerifyOptions definition",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyCollectionVerifyOptions is nil"),
+		},
+		{
+			desc:    "Testing an empty AnsibleGalaxyCollectionVerifyOptions definition",
+			options: &AnsibleGalaxyCollectionVerifyOptions{},
+			expect:  []string{},
+		},
+		{
+			desc: "Testing AnsibleGalaxyCollectionVerifyOptions with all flags",
+			options: &AnsibleGalaxyCollectionVerifyOptions{
+				APIKey:                      "apikey",
+				CollectionsPath:             "path",
+				IgnoreCerts:                 true,
+				IgnoreErrors:                true,
+				IgnoreSignatureStatusCode:   "NO_PUBKEY",
+				Keyring:                     "keyring",
+				Offline:                     true,
+				RequiredValidSignatureCount: 1,
+				RequirementsFile:            "requirements",
+				Server:                      "server",
+				Signature:                   "signature",
+				Timeout:                     "10",
+				Token:                       "token",
+				Verbose:                     true,
+			},
+			expect: []string{
+				fmt.Sprintf("%s=%s", APIKeyFlag, "apikey"),
+				fmt.Sprintf("%s=%s", CollectionsPathFlag, "path"),
+				IgnoreCertsFlag,
+				IgnoreErrorsFlag,
+				fmt.Sprintf("%s=%s", IgnoreSignatureStatusCodeFlag, "NO_PUBKEY"),
+				fmt.Sprintf("%s=%s", KeyringFlag, "keyring"),
+				OfflineFlag,
+				fmt.Sprintf("%s=%s", RequiredValidSignatureCountFlag, "1"),
+				fmt.Sprintf("%s=%s", RequirementsFileFlag, "requirements"),
+				fmt.Sprintf("%s=%s", ServerFlag, "server"),
+				fmt.Sprintf("%s=%s", SignatureFlag, "signature"),
+				fmt.Sprintf("%s=%s", TimeoutFlag, "10"),
+				fmt.Sprintf("%s=%s", TokenFlag, "token"),
+				VerboseFlag,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			options, err := test.options.GenerateCommandOptions()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.expect, options, "Unexpected options value")
+			}
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionVerifyOptionsString(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionVerifyOptions
+		expect  string
+	}{
+		{
+			desc:    "Testing generate string from an empty AnsibleGalaxyCollectionVerifyOptions",
+			options: &AnsibleGalaxyCollectionVerifyOptions{},
+			expect:  "",
+		},
+		{
+			desc: "Testing generate string from an AnsibleGalaxyCollectionVerifyOptions with all flags",
+			options: &AnsibleGalaxyCollectionVerifyOptions{
+				APIKey:                      "apikey",
+				CollectionsPath:             "path",
+				IgnoreCerts:                 true,
+				IgnoreErrors:                true,
+				IgnoreSignatureStatusCode:   "NO_PUBKEY",
+				Keyring:                     "keyring",
+				Offline:                     true,
+				RequiredValidSignatureCount: 1,
+				RequirementsFile:            "requirements",
+				Server:                      "server",
+				Signature:                   "signature",
+				Timeout:                     "10",
+				Token:                       "token",
+				Verbose:                     true,
+			},
+			expect: "--api-key=apikey --collections-path=path --ignore-certs --ignore-errors --ignore-signature-status-code=NO_PUBKEY --keyring=keyring --offline --required-valid-signature-count=1 --requirements-file=requirements --server=server --signature=signature --timeout=10 --token=token --verbose",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expect, test.options.String())
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionVerifyOptionsValidate(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionVerifyOptions::Validate)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionVerifyOptions
+		err     error
+	}{
+		{
+			desc:    "Testing validate nil AnsibleGalaxyCollectionVerifyOptions",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyCollectionVerifyOptions is nil"),
+		},
+		{
+			desc: "Testing validate valid AnsibleGalaxyCollectionVerifyOptions",
+			options: &AnsibleGalaxyCollectionVerifyOptions{
+				CollectionsPath: "path",
+				Offline:         true,
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing validate invalid AnsibleGalaxyCollectionVerifyOptions",
+			options: &AnsibleGalaxyCollectionVerifyOptions{
+				APIKey:                      "apikey",
+				Keyring:                     "nonexistent-keyring.kbx",
+				Offline:                     true,
+				RequiredValidSignatureCount: -1,
+				RequirementsFile:            "nonexistent-requirements.yml",
+				Server:                      "server",
+				Timeout:                     "0",
+				Token:                       "token",
+			},
+			err: errors.New(errContext, "Invalid ansible-galaxy collection verify options",
+				fmt.Errorf("'%s' and '%s' are mutually exclusive", APIKeyFlag, TokenFlag),
+				fmt.Errorf("'%s' is meaningless with '%s'", ServerFlag, OfflineFlag),
+				fmt.Errorf("'%s' must not be negative, but '-1' was provided", RequiredValidSignatureCountFlag),
+				fmt.Errorf("'%s' must be a positive integer, but '0' was provided", TimeoutFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.Validate()
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+func TestAnsibleGalaxyCollectionVerifyOptionsValidateFiles(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyCollectionVerifyOptions::ValidateFiles)"
+
+	var err error
+	tempDir := t.TempDir()
+
+	err = os.WriteFile(filepath.Join(tempDir, "keyring.kbx"), []byte("---\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(filepath.Join(tempDir, "requirements.yml"), []byte("---\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyCollectionVerifyOptions
+		dir     string
+		err     error
+	}{
+		{
+			desc:    "Testing validate files of nil AnsibleGalaxyCollectionVerifyOptions",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyCollectionVerifyOptions is nil"),
+		},
+		{
+			desc: "Testing validate files of AnsibleGalaxyCollectionVerifyOptions resolving the relative paths against the directory",
+			options: &AnsibleGalaxyCollectionVerifyOptions{
+				Keyring:          "keyring.kbx",
+				RequirementsFile: "requirements.yml",
+			},
+			dir: tempDir,
+			err: nil,
+		},
+		{
+			desc: "Testing validate files of invalid AnsibleGalaxyCollectionVerifyOptions",
+			options: &AnsibleGalaxyCollectionVerifyOptions{
+				Keyring:          "nonexistent-keyring.kbx",
+				RequirementsFile: ".",
+			},
+			dir: tempDir,
+			err: errors.New(errContext, "Invalid ansible-galaxy collection verify option files",
+				fmt.Errorf("'%s' file is not valid: %w", KeyringFlag, &fs.PathError{Op: "stat", Path: filepath.Join(tempDir, "nonexistent-keyring.kbx"), Err: syscall.ENOENT}),
+				fmt.Errorf("'%s' file is not valid: %w", RequirementsFileFlag, fmt.Errorf("%s is a directory", ".")),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.ValidateFiles(test.dir)
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
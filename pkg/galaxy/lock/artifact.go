@@ -0,0 +1,117 @@
+package galaxylock
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+)
+
+const (
+	// manifestFile is the collection artifact file that describes the collection
+	manifestFile = "MANIFEST.json"
+	// fileScheme is the URL scheme of the local artifacts
+	fileScheme = "file://"
+)
+
+// collectionManifest is the content of the collection artifact MANIFEST.json file
+type collectionManifest struct {
+	CollectionInfo struct {
+		Dependencies map[string]string `json:"dependencies"`
+		Name         string            `json:"name"`
+		Namespace    string            `json:"namespace"`
+		Version      string            `json:"version"`
+	} `json:"collection_info"`
+}
+
+// isRemote returns whether the source is an http or https URL
+func isRemote(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// open returns a reader of the artifact source, which is either an http or https URL or a local file
+func open(ctx context.Context, client *http.Client, source string) (io.ReadCloser, error) {
+	if !isRemote(source) {
+		return os.Open(strings.TrimPrefix(source, fileScheme))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf("unexpected status '%s' getting '%s'", res.Status, source)
+	}
+
+	return res.Body, nil
+}
+
+// checksum returns the SHA256 checksum of the content read from reader
+func checksum(reader io.Reader) (string, error) {
+	hash := sha256.New()
+
+	_, err := io.Copy(hash, reader)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// fileChecksum returns the SHA256 checksum of a file
+func fileChecksum(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	return checksum(f)
+}
+
+// readManifest returns the manifest of a collection artifact
+func readManifest(reader io.Reader) (*collectionManifest, error) {
+	gz, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s not found", manifestFile)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if path.Clean(header.Name) != manifestFile {
+			continue
+		}
+
+		manifest := &collectionManifest{}
+		err = json.NewDecoder(tr).Decode(manifest)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding %s: %w", manifestFile, err)
+		}
+
+		return manifest, nil
+	}
+}
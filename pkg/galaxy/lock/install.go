@@ -0,0 +1,45 @@
+package galaxylock
+
+import (
+	galaxycollectioninstall "github.com/apenella/go-ansible/v2/pkg/galaxy/collection/install"
+	galaxyrequirements "github.com/apenella/go-ansible/v2/pkg/galaxy/requirements"
+	galaxyroleinstall "github.com/apenella/go-ansible/v2/pkg/galaxy/role/install"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// NewOfflineAnsibleGalaxyCollectionInstallCmd verifies the lockfile collections mirrored on dir and returns an AnsibleGalaxyCollectionInstallCmd that installs them from the mirror with the offline option enabled. The requirements are written to a temporary file created on tmpDir, which the caller must remove once the command is executed
+func NewOfflineAnsibleGalaxyCollectionInstallCmd(lockfile *Lockfile, dir, tmpDir string, options ...galaxycollectioninstall.AnsibleGalaxyCollectionInstallOptionsFunc) (*galaxycollectioninstall.AnsibleGalaxyCollectionInstallCmd, string, error) {
+	errContext := "(galaxy::NewOfflineAnsibleGalaxyCollectionInstallCmd)"
+
+	requirements, err := lockfile.OfflineRequirements(dir)
+	if err != nil {
+		return nil, "", errors.New(errContext, "Error creating the offline collections install command", err)
+	}
+
+	cmd, file, err := galaxyrequirements.NewAnsibleGalaxyCollectionInstallCmd(requirements, tmpDir, options...)
+	if err != nil {
+		return nil, "", errors.New(errContext, "Error creating the offline collections install command", err)
+	}
+
+	// the install options are already a copy of the user options
+	cmd.GalaxyCollectionInstallOptions.Offline = true
+
+	return cmd, file, nil
+}
+
+// NewOfflineAnsibleGalaxyRoleInstallCmd verifies the lockfile roles mirrored on dir and returns an AnsibleGalaxyRoleInstallCmd that installs them from the mirror. The requirements are written to a temporary file created on tmpDir, which the caller must remove once the command is executed
+func NewOfflineAnsibleGalaxyRoleInstallCmd(lockfile *Lockfile, dir, tmpDir string, options ...galaxyroleinstall.AnsibleGalaxyRoleInstallOptionsFunc) (*galaxyroleinstall.AnsibleGalaxyRoleInstallCmd, string, error) {
+	errContext := "(galaxy::NewOfflineAnsibleGalaxyRoleInstallCmd)"
+
+	requirements, err := lockfile.OfflineRequirements(dir)
+	if err != nil {
+		return nil, "", errors.New(errContext, "Error creating the offline roles install command", err)
+	}
+
+	cmd, file, err := galaxyrequirements.NewAnsibleGalaxyRoleInstallCmd(requirements, tmpDir, options...)
+	if err != nil {
+		return nil, "", errors.New(errContext, "Error creating the offline roles install command", err)
+	}
+
+	return cmd, file, nil
+}
@@ -0,0 +1,73 @@
+package galaxylock
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	galaxycollectioninstall "github.com/apenella/go-ansible/v2/pkg/galaxy/collection/install"
+	galaxyroleinstall "github.com/apenella/go-ansible/v2/pkg/galaxy/role/install"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOfflineAnsibleGalaxyCollectionInstallCmd(t *testing.T) {
+
+	standIn := newGalaxyStandIn(t)
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	mirror := t.TempDir()
+	lockfile := mirrorLockfile(t, standIn, server.URL, t.TempDir())
+	err := lockfile.Mirror(context.TODO(), nil, mirror)
+	assert.NoError(t, err)
+
+	options := &galaxycollectioninstall.AnsibleGalaxyCollectionInstallOptions{
+		CollectionsPath: "collections",
+	}
+
+	cmd, file, err := NewOfflineAnsibleGalaxyCollectionInstallCmd(lockfile, mirror, t.TempDir(),
+		galaxycollectioninstall.WithGalaxyCollectionInstallOptions(options),
+	)
+	assert.NoError(t, err)
+	defer os.Remove(file)
+
+	command, err := cmd.Command()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ansible-galaxy", "collection", "install", "--offline", "--collections-path=collections", fmt.Sprintf("--requirements-file=%s", file)}, command)
+	// the user options are not modified
+	assert.False(t, options.Offline)
+
+	// the install command is not created once the mirror drifts from the lockfile
+	err = os.WriteFile(filepath.Join(mirror, "collections", "internal-tools-1.0.0.tar.gz"), []byte("drifted"), 0600)
+	assert.NoError(t, err)
+
+	_, _, err = NewOfflineAnsibleGalaxyCollectionInstallCmd(lockfile, mirror, t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestNewOfflineAnsibleGalaxyRoleInstallCmd(t *testing.T) {
+
+	standIn := newGalaxyStandIn(t)
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	mirror := t.TempDir()
+	lockfile := mirrorLockfile(t, standIn, server.URL, t.TempDir())
+	err := lockfile.Mirror(context.TODO(), nil, mirror)
+	assert.NoError(t, err)
+
+	cmd, file, err := NewOfflineAnsibleGalaxyRoleInstallCmd(lockfile, mirror, t.TempDir(),
+		galaxyroleinstall.WithGalaxyRoleInstallOptions(&galaxyroleinstall.AnsibleGalaxyRoleInstallOptions{
+			RolesPath: "roles",
+		}),
+	)
+	assert.NoError(t, err)
+	defer os.Remove(file)
+
+	command, err := cmd.Command()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ansible-galaxy", "role", "install", fmt.Sprintf("--role-file=%s", file), "--roles-path=roles"}, command)
+}
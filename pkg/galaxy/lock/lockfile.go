@@ -0,0 +1,207 @@
+package galaxylock
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultLockfile is the default lockfile name
+	DefaultLockfile = "requirements.lock.yml"
+
+	// yamlIndent is the indentation used to write the lockfile
+	yamlIndent = 2
+)
+
+// Lockfile holds the exact versions and checksums of the collections and roles resolved from a requirements file
+type Lockfile struct {
+	// Collections are the locked collections, including the collections required as dependencies
+	Collections []*Artifact `yaml:"collections,omitempty"`
+	// Roles are the locked roles
+	Roles []*Artifact `yaml:"roles,omitempty"`
+}
+
+// Artifact is a locked collection or role artifact
+type Artifact struct {
+	// Name is the collection name, in the form namespace.collection, or the role name
+	Name string `yaml:"name"`
+	// SHA256 is the artifact checksum
+	SHA256 string `yaml:"sha256"`
+	// Source is the URL or the file to get the artifact from
+	Source string `yaml:"source"`
+	// Version is the exact artifact version. It is empty for roles that do not define a version
+	Version string `yaml:"version,omitempty"`
+}
+
+// NewLockfile creates a new Lockfile instance
+func NewLockfile() *Lockfile {
+	return &Lockfile{
+		Collections: []*Artifact{},
+		Roles:       []*Artifact{},
+	}
+}
+
+// Parse returns the lockfile defined on a lockfile content
+func Parse(reader io.Reader) (*Lockfile, error) {
+	errContext := "(galaxy::Parse)"
+
+	if reader == nil {
+		return nil, errors.New(errContext, "Reader is not defined")
+	}
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.New(errContext, "Error reading lockfile", err)
+	}
+
+	lockfile := NewLockfile()
+	err = yaml.Unmarshal(content, lockfile)
+	if err != nil {
+		return nil, errors.New(errContext, "Error unmarshaling lockfile", err)
+	}
+
+	err = lockfile.Validate()
+	if err != nil {
+		return nil, errors.New(errContext, "Invalid lockfile", err)
+	}
+
+	return lockfile, nil
+}
+
+// ParseFile returns the lockfile defined on a file
+func ParseFile(file string) (*Lockfile, error) {
+	errContext := "(galaxy::ParseFile)"
+
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error opening lockfile '%s'", file), err)
+	}
+	defer f.Close()
+
+	lockfile, err := Parse(f)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error parsing lockfile '%s'", file), err)
+	}
+
+	return lockfile, nil
+}
+
+// Validate checks the lockfile looking for artifacts without name, source or checksum. It returns an error that wraps all the detected issues
+func (l *Lockfile) Validate() error {
+	errContext := "(galaxy::Lockfile::Validate)"
+	errs := []error{}
+
+	if l == nil {
+		return errors.New(errContext, "Lockfile is nil")
+	}
+
+	errs = append(errs, validateArtifacts("collection", l.Collections)...)
+	errs = append(errs, validateArtifacts("role", l.Roles)...)
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid lockfile", errs...)
+	}
+
+	return nil
+}
+
+// validateArtifacts returns the issues found on the artifacts of a kind
+func validateArtifacts(kind string, artifacts []*Artifact) []error {
+	errs := []error{}
+
+	for i, artifact := range artifacts {
+		if artifact == nil {
+			errs = append(errs, fmt.Errorf("%s %d is not defined", kind, i))
+			continue
+		}
+
+		if artifact.Name == "" {
+			errs = append(errs, fmt.Errorf("%s %d has no name", kind, i))
+		}
+
+		if artifact.Source == "" {
+			errs = append(errs, fmt.Errorf("%s '%s' has no source", kind, artifact.Name))
+		}
+
+		if artifact.SHA256 == "" {
+			errs = append(errs, fmt.Errorf("%s '%s' has no checksum", kind, artifact.Name))
+		}
+	}
+
+	return errs
+}
+
+// Write writes the lockfile in YAML format
+func (l *Lockfile) Write(writer io.Writer) error {
+	errContext := "(galaxy::Lockfile::Write)"
+
+	if writer == nil {
+		return errors.New(errContext, "Writer is not defined")
+	}
+
+	err := l.Validate()
+	if err != nil {
+		return errors.New(errContext, "Lockfile can not be written", err)
+	}
+
+	content := &bytes.Buffer{}
+	content.WriteString("---\n")
+
+	encoder := yaml.NewEncoder(content)
+	encoder.SetIndent(yamlIndent)
+
+	err = encoder.Encode(l)
+	if err != nil {
+		return errors.New(errContext, "Error marshaling lockfile", err)
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return errors.New(errContext, "Error marshaling lockfile", err)
+	}
+
+	_, err = writer.Write(content.Bytes())
+	if err != nil {
+		return errors.New(errContext, "Error writing lockfile", err)
+	}
+
+	return nil
+}
+
+// WriteFile writes the lockfile to a file. The file is created when it does not exist and truncated otherwise
+func (l *Lockfile) WriteFile(file string) error {
+	errContext := "(galaxy::Lockfile::WriteFile)"
+
+	f, err := os.Create(file)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error creating lockfile '%s'", file), err)
+	}
+	defer f.Close()
+
+	err = l.Write(f)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error writing lockfile '%s'", file), err)
+	}
+
+	return nil
+}
+
+// filename returns the name of the artifact file on a mirror. Collections follow the ansible-galaxy artifacts naming, namespace-collection-version.tar.gz
+func (a *Artifact) filename(kind string) string {
+	name := a.Name
+	if kind == collectionsDir {
+		name = strings.ReplaceAll(name, ".", "-")
+	}
+
+	if a.Version == "" {
+		return name + ".tar.gz"
+	}
+
+	return fmt.Sprintf("%s-%s.tar.gz", name, a.Version)
+}
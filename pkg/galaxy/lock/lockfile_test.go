@@ -0,0 +1,119 @@
+package galaxylock
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+
+	errContext := "(galaxy::Parse)"
+
+	tests := []struct {
+		desc   string
+		reader io.Reader
+		res    *Lockfile
+		err    error
+	}{
+		{
+			desc: "Testing parse a lockfile",
+			reader: strings.NewReader(`---
+collections:
+  - name: internal.tools
+    sha256: 1a2b
+    source: https://galaxy.example.com/download/internal-tools-1.1.0.tar.gz
+    version: 1.1.0
+roles:
+  - name: acme.web
+    sha256: 3c4d
+    source: https://github.com/acme/ansible-role-web/archive/1.2.0.tar.gz
+    version: 1.2.0
+`),
+			res: &Lockfile{
+				Collections: []*Artifact{
+					{Name: "internal.tools", SHA256: "1a2b", Source: "https://galaxy.example.com/download/internal-tools-1.1.0.tar.gz", Version: "1.1.0"},
+				},
+				Roles: []*Artifact{
+					{Name: "acme.web", SHA256: "3c4d", Source: "https://github.com/acme/ansible-role-web/archive/1.2.0.tar.gz", Version: "1.2.0"},
+				},
+			},
+		},
+		{
+			desc: "Testing parse a lockfile with invalid artifacts",
+			reader: strings.NewReader(`collections:
+  - name: internal.tools
+roles:
+  - source: role.tar.gz
+    sha256: 3c4d
+`),
+			err: errors.New(errContext, "Invalid lockfile",
+				errors.New("(galaxy::Lockfile::Validate)", "Invalid lockfile",
+					fmt.Errorf("collection 'internal.tools' has no source"),
+					fmt.Errorf("collection 'internal.tools' has no checksum"),
+					fmt.Errorf("role 0 has no name"),
+				),
+			),
+		},
+		{
+			desc: "Testing parse without reader",
+			err:  errors.New(errContext, "Reader is not defined"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := Parse(test.reader)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	lockfile := &Lockfile{
+		Collections: []*Artifact{
+			{Name: "internal.tools", SHA256: "1a2b", Source: "https://galaxy.example.com/download/internal-tools-1.1.0.tar.gz", Version: "1.1.0"},
+		},
+		Roles: []*Artifact{
+			{Name: "legacy", SHA256: "3c4d", Source: "/roles/legacy.tar.gz"},
+		},
+	}
+
+	buff := &bytes.Buffer{}
+	err := lockfile.Write(buff)
+	assert.NoError(t, err)
+	assert.Equal(t, `---
+collections:
+  - name: internal.tools
+    sha256: 1a2b
+    source: https://galaxy.example.com/download/internal-tools-1.1.0.tar.gz
+    version: 1.1.0
+roles:
+  - name: legacy
+    sha256: 3c4d
+    source: /roles/legacy.tar.gz
+`, buff.String())
+
+	file := filepath.Join(t.TempDir(), DefaultLockfile)
+	err = lockfile.WriteFile(file)
+	assert.NoError(t, err)
+
+	res, err := ParseFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, lockfile, res)
+
+	err = (&Lockfile{Roles: []*Artifact{{Name: "legacy"}}}).Write(buff)
+	assert.Error(t, err)
+}
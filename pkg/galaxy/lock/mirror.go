@@ -0,0 +1,177 @@
+package galaxylock
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	galaxyrequirements "github.com/apenella/go-ansible/v2/pkg/galaxy/requirements"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// collectionsDir is the mirror subdirectory that holds the collections artifacts
+	collectionsDir = "collections"
+	// rolesDir is the mirror subdirectory that holds the roles artifacts
+	rolesDir = "roles"
+)
+
+// Mirror downloads the lockfile artifacts into the collections and roles subdirectories of dir. The artifacts already mirrored are kept when their checksum matches the locked one. It fails when a downloaded artifact checksum does not match the locked one. The http.DefaultClient is used when client is nil
+func (l *Lockfile) Mirror(ctx context.Context, client *http.Client, dir string) error {
+	errContext := "(galaxy::Lockfile::Mirror)"
+	errs := []error{}
+
+	err := l.Validate()
+	if err != nil {
+		return errors.New(errContext, "Lockfile can not be mirrored", err)
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	for _, group := range l.groups() {
+		if len(group.artifacts) == 0 {
+			continue
+		}
+
+		err = os.MkdirAll(filepath.Join(dir, group.kind), 0755)
+		if err != nil {
+			return errors.New(errContext, fmt.Sprintf("Error creating mirror directory '%s'", dir), err)
+		}
+
+		for _, artifact := range group.artifacts {
+			err = mirrorArtifact(ctx, client, artifact, filepath.Join(dir, group.kind, artifact.filename(group.kind)))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s '%s': %w", group.kind, artifact.Name, err))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Error mirroring lockfile artifacts", errs...)
+	}
+
+	return nil
+}
+
+// mirrorArtifact downloads an artifact into file when it is not already there. The artifact is written to a temporary file that is renamed once its checksum is verified
+func mirrorArtifact(ctx context.Context, client *http.Client, artifact *Artifact, file string) error {
+	sum, err := fileChecksum(file)
+	if err == nil && sum == artifact.SHA256 {
+		return nil
+	}
+
+	reader, err := open(ctx, client, artifact.Source)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(file), filepath.Base(file)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	_, err = io.Copy(tmp, io.TeeReader(reader, hash))
+	closeErr := tmp.Close()
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+
+	sum = hex.EncodeToString(hash.Sum(nil))
+	if sum != artifact.SHA256 {
+		return fmt.Errorf("checksum mismatch, '%s' is locked but '%s' was downloaded", artifact.SHA256, sum)
+	}
+
+	return os.Rename(tmp.Name(), file)
+}
+
+// Verify checks that the lockfile artifacts are mirrored on dir and that their checksums match the locked ones. It returns an error that wraps all the detected issues
+func (l *Lockfile) Verify(dir string) error {
+	errContext := "(galaxy::Lockfile::Verify)"
+	errs := []error{}
+
+	err := l.Validate()
+	if err != nil {
+		return errors.New(errContext, "Lockfile can not be verified", err)
+	}
+
+	for _, group := range l.groups() {
+		for _, artifact := range group.artifacts {
+			sum, err := fileChecksum(filepath.Join(dir, group.kind, artifact.filename(group.kind)))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s '%s' is not mirrored: %w", group.kind, artifact.Name, err))
+				continue
+			}
+
+			if sum != artifact.SHA256 {
+				errs = append(errs, fmt.Errorf("%s '%s' checksum mismatch, '%s' is locked but '%s' is mirrored", group.kind, artifact.Name, artifact.SHA256, sum))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Mirrored artifacts do not match the lockfile", errs...)
+	}
+
+	return nil
+}
+
+// OfflineRequirements returns the requirements that install the lockfile artifacts mirrored on dir. The mirrored artifacts are verified first, so it fails when any of them drifted from the lockfile
+func (l *Lockfile) OfflineRequirements(dir string) (*galaxyrequirements.Requirements, error) {
+	errContext := "(galaxy::Lockfile::OfflineRequirements)"
+
+	err := l.Verify(dir)
+	if err != nil {
+		return nil, errors.New(errContext, "Offline requirements can not be created", err)
+	}
+
+	absolute, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, errors.New(errContext, "Offline requirements can not be created", err)
+	}
+
+	requirements := galaxyrequirements.NewRequirements()
+
+	for _, artifact := range l.Collections {
+		requirements.AddCollections(&galaxyrequirements.Collection{
+			Name: filepath.Join(absolute, collectionsDir, artifact.filename(collectionsDir)),
+			Type: galaxyrequirements.TypeFile,
+		})
+	}
+
+	for _, artifact := range l.Roles {
+		requirements.AddRoles(&galaxyrequirements.Role{
+			Name:    artifact.Name,
+			Source:  filepath.Join(absolute, rolesDir, artifact.filename(rolesDir)),
+			Version: artifact.Version,
+		})
+	}
+
+	return requirements, nil
+}
+
+// artifactGroup are the artifacts stored on a mirror subdirectory
+type artifactGroup struct {
+	kind      string
+	artifacts []*Artifact
+}
+
+// groups returns the lockfile artifacts grouped by their mirror subdirectory
+func (l *Lockfile) groups() []artifactGroup {
+	return []artifactGroup{
+		{kind: collectionsDir, artifacts: l.Collections},
+		{kind: rolesDir, artifacts: l.Roles},
+	}
+}
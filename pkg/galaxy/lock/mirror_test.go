@@ -0,0 +1,156 @@
+package galaxylock
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	galaxyrequirements "github.com/apenella/go-ansible/v2/pkg/galaxy/requirements"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+// mirrorLockfile returns a lockfile with a collection served by the server and a role stored on dir
+func mirrorLockfile(t *testing.T, standIn *galaxyStandIn, serverURL, dir string) *Lockfile {
+	role := archive(t, map[string]string{"legacy/tasks/main.yml": "---\n"})
+	roleFile := filepath.Join(dir, "legacy.tar.gz")
+	err := os.WriteFile(roleFile, role, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &Lockfile{
+		Collections: []*Artifact{
+			{
+				Name:    "internal.tools",
+				SHA256:  sha256sum(standIn.artifacts["/download/internal-tools-1.0.0.tar.gz"]),
+				Source:  serverURL + "/download/internal-tools-1.0.0.tar.gz",
+				Version: "1.0.0",
+			},
+		},
+		Roles: []*Artifact{
+			{
+				Name:   "legacy",
+				SHA256: sha256sum(role),
+				Source: roleFile,
+			},
+		},
+	}
+}
+
+func TestMirror(t *testing.T) {
+
+	standIn := newGalaxyStandIn(t)
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	mirror := t.TempDir()
+	lockfile := mirrorLockfile(t, standIn, server.URL, t.TempDir())
+
+	err := lockfile.Mirror(context.TODO(), nil, mirror)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(mirror, "collections", "internal-tools-1.0.0.tar.gz"))
+	assert.NoError(t, err)
+	assert.Equal(t, standIn.artifacts["/download/internal-tools-1.0.0.tar.gz"], content)
+
+	_, err = os.Stat(filepath.Join(mirror, "roles", "legacy.tar.gz"))
+	assert.NoError(t, err)
+
+	assert.NoError(t, lockfile.Verify(mirror))
+
+	// the mirrored artifacts are kept, so the server is not required anymore
+	server.Close()
+	err = lockfile.Mirror(context.TODO(), nil, mirror)
+	assert.NoError(t, err)
+}
+
+func TestMirrorChecksumMismatch(t *testing.T) {
+
+	standIn := newGalaxyStandIn(t)
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	mirror := t.TempDir()
+	lockfile := &Lockfile{
+		Collections: []*Artifact{
+			{
+				Name:    "internal.tools",
+				SHA256:  "0000",
+				Source:  server.URL + "/download/internal-tools-1.0.0.tar.gz",
+				Version: "1.0.0",
+			},
+		},
+	}
+
+	err := lockfile.Mirror(context.TODO(), nil, mirror)
+	assert.Equal(t, errors.New("(galaxy::Lockfile::Mirror)", "Error mirroring lockfile artifacts",
+		fmt.Errorf("collections 'internal.tools': %w", fmt.Errorf("checksum mismatch, '0000' is locked but '%s' was downloaded", sha256sum(standIn.artifacts["/download/internal-tools-1.0.0.tar.gz"]))),
+	), err)
+
+	// the downloaded artifact is discarded
+	entries, err := os.ReadDir(filepath.Join(mirror, "collections"))
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestVerify(t *testing.T) {
+
+	standIn := newGalaxyStandIn(t)
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	mirror := t.TempDir()
+	lockfile := mirrorLockfile(t, standIn, server.URL, t.TempDir())
+
+	err := lockfile.Mirror(context.TODO(), nil, mirror)
+	assert.NoError(t, err)
+
+	drifted := filepath.Join(mirror, "collections", "internal-tools-1.0.0.tar.gz")
+	err = os.WriteFile(drifted, []byte("drifted"), 0600)
+	assert.NoError(t, err)
+	err = os.Remove(filepath.Join(mirror, "roles", "legacy.tar.gz"))
+	assert.NoError(t, err)
+
+	err = lockfile.Verify(mirror)
+	assert.Equal(t, errors.New("(galaxy::Lockfile::Verify)", "Mirrored artifacts do not match the lockfile",
+		fmt.Errorf("collections 'internal.tools' checksum mismatch, '%s' is locked but '%s' is mirrored", lockfile.Collections[0].SHA256, sha256sum([]byte("drifted"))),
+		fmt.Errorf("roles 'legacy' is not mirrored: %w", &os.PathError{Op: "open", Path: filepath.Join(mirror, "roles", "legacy.tar.gz"), Err: errNotExist(t)}),
+	), err)
+}
+
+// errNotExist returns the error reported when opening a file that does not exist
+func errNotExist(t *testing.T) error {
+	_, err := os.Open(filepath.Join(t.TempDir(), "nonexistent"))
+	return err.(*os.PathError).Err
+}
+
+func TestOfflineRequirements(t *testing.T) {
+
+	standIn := newGalaxyStandIn(t)
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	mirror := t.TempDir()
+	lockfile := mirrorLockfile(t, standIn, server.URL, t.TempDir())
+
+	_, err := lockfile.OfflineRequirements(mirror)
+	assert.Error(t, err)
+
+	err = lockfile.Mirror(context.TODO(), nil, mirror)
+	assert.NoError(t, err)
+
+	res, err := lockfile.OfflineRequirements(mirror)
+	assert.NoError(t, err)
+	assert.Equal(t, &galaxyrequirements.Requirements{
+		Collections: []*galaxyrequirements.Collection{
+			{Name: filepath.Join(mirror, "collections", "internal-tools-1.0.0.tar.gz"), Type: galaxyrequirements.TypeFile},
+		},
+		Roles: []*galaxyrequirements.Role{
+			{Name: "legacy", Source: filepath.Join(mirror, "roles", "legacy.tar.gz")},
+		},
+	}, res)
+}
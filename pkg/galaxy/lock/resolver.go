@@ -0,0 +1,582 @@
+// Package galaxylock resolves the ansible-galaxy requirements to a lockfile with exact versions and artifact checksums, mirrors the artifacts and installs them offline.
+//
+// The resolution is greedy and it does not backtrack. The collections defined on the requirements are resolved first, in order, and then their dependencies, breadth first. Each collection is resolved once, to the highest version that satisfies the constraints known at that moment, and a later constraint that the resolved version does not satisfy is reported as a conflict instead of choosing another version. Pinning the conflicting collection on the requirements usually solves it.
+package galaxylock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	galaxyrequirements "github.com/apenella/go-ansible/v2/pkg/galaxy/requirements"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// DefaultServer is the Galaxy server used to resolve the collections and roles that do not define a source
+	DefaultServer = "https://galaxy.ansible.com"
+	// DefaultRoleArchiveURL is the URL where the archives of the Galaxy roles are downloaded from
+	DefaultRoleArchiveURL = "https://github.com"
+
+	// apiPath is the path of the Galaxy API root
+	apiPath = "/api/"
+	// apiV1 is the Galaxy API version that serves the roles
+	apiV1 = "v1"
+	// apiV3 is the Galaxy API version that serves the collections
+	apiV3 = "v3"
+	// versionsPageLimit is the number of collection versions requested on each page
+	versionsPageLimit = 100
+)
+
+// ResolverOptionsFunc is a function to set the Resolver options
+type ResolverOptionsFunc func(*Resolver)
+
+// Resolver resolves the collections and roles of a requirements file to their exact versions and checksums, using the Galaxy API
+type Resolver struct {
+	client         *http.Client
+	pre            bool
+	roleArchiveURL string
+	server         string
+	// apis holds the Galaxy API versions available on each server
+	apis map[string]map[string]string
+}
+
+// NewResolver creates a new Resolver
+func NewResolver(options ...ResolverOptionsFunc) *Resolver {
+	resolver := &Resolver{
+		client:         http.DefaultClient,
+		roleArchiveURL: DefaultRoleArchiveURL,
+		server:         DefaultServer,
+		apis:           map[string]map[string]string{},
+	}
+
+	for _, option := range options {
+		option(resolver)
+	}
+
+	return resolver
+}
+
+// WithHTTPClient sets the HTTP client used to request the Galaxy API and to download the artifacts
+func WithHTTPClient(client *http.Client) ResolverOptionsFunc {
+	return func(r *Resolver) {
+		r.client = client
+	}
+}
+
+// WithPre sets the resolver to consider the pre-release versions. They are ignored by default, unless a version constraint pins them
+func WithPre() ResolverOptionsFunc {
+	return func(r *Resolver) {
+		r.pre = true
+	}
+}
+
+// WithRoleArchiveURL sets the URL where the archives of the Galaxy roles are downloaded from
+func WithRoleArchiveURL(archiveURL string) ResolverOptionsFunc {
+	return func(r *Resolver) {
+		r.roleArchiveURL = strings.TrimSuffix(archiveURL, "/")
+	}
+}
+
+// WithServer sets the Galaxy server used to resolve the collections and roles that do not define a source
+func WithServer(server string) ResolverOptionsFunc {
+	return func(r *Resolver) {
+		r.server = server
+	}
+}
+
+// pendingCollection is a collection waiting to be resolved
+type pendingCollection struct {
+	collection *galaxyrequirements.Collection
+	// requiredBy is the collection that depends on it. It is empty for the collections defined on the requirements
+	requiredBy string
+}
+
+// Resolve returns the lockfile with the exact versions and checksums of the requirements collections and roles. The collections dependencies are resolved and locked too. Collections of type dir, git and subdirs, and roles installed from a repository can not be locked
+func (r *Resolver) Resolve(ctx context.Context, requirements *galaxyrequirements.Requirements) (*Lockfile, error) {
+	errContext := "(galaxy::Resolver::Resolve)"
+
+	err := requirements.Validate()
+	if err != nil {
+		return nil, errors.New(errContext, "Requirements can not be resolved", err)
+	}
+
+	lockfile := NewLockfile()
+	errs := []error{}
+
+	resolved := map[string]*Artifact{}
+	pending := []*pendingCollection{}
+	for _, collection := range requirements.Collections {
+		pending = append(pending, &pendingCollection{collection: collection})
+	}
+
+	// the dependencies are appended to the pending collections, so the collections defined on the requirements are resolved first
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+
+		artifact, dependencies, err := r.resolveCollection(ctx, current, resolved)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if artifact == nil {
+			continue
+		}
+
+		resolved[artifact.Name] = artifact
+		lockfile.Collections = append(lockfile.Collections, artifact)
+
+		for _, name := range sortedKeys(dependencies) {
+			pending = append(pending, &pendingCollection{
+				collection: &galaxyrequirements.Collection{
+					Name:    name,
+					Source:  current.collection.Source,
+					Version: dependencies[name],
+				},
+				requiredBy: artifact.Name,
+			})
+		}
+	}
+
+	for _, role := range requirements.Roles {
+		artifact, err := r.resolveRole(ctx, role)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		lockfile.Roles = append(lockfile.Roles, artifact)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.New(errContext, "Error resolving requirements", errs...)
+	}
+
+	sort.Slice(lockfile.Collections, func(i, j int) bool {
+		return lockfile.Collections[i].Name < lockfile.Collections[j].Name
+	})
+
+	return lockfile, nil
+}
+
+// resolveCollection returns the artifact of a pending collection and its dependencies. It returns a nil artifact when the collection is already resolved with a version that satisfies the constraints, or with the same artifact for the collections of type file and url, and an error when the resolved version conflicts with them
+func (r *Resolver) resolveCollection(ctx context.Context, pending *pendingCollection, resolved map[string]*Artifact) (*Artifact, map[string]string, error) {
+	collection := pending.collection
+
+	switch collection.Type {
+	case galaxyrequirements.TypeFile, galaxyrequirements.TypeURL:
+		// the artifact name is only known once its manifest is read, then it is checked against the resolved collections afterwards
+		artifact, dependencies, err := r.resolveCollectionArtifact(ctx, collection)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		existing, isResolved := resolved[artifact.Name]
+		if isResolved {
+			if existing.Version == artifact.Version && existing.SHA256 == artifact.SHA256 {
+				return nil, nil, nil
+			}
+
+			return nil, nil, fmt.Errorf("collection '%s' version '%s' from '%s' conflicts with the resolved version '%s' from '%s'", artifact.Name, artifact.Version, artifact.Source, existing.Version, existing.Source)
+		}
+
+		return artifact, dependencies, nil
+	case "", galaxyrequirements.TypeGalaxy:
+	default:
+		return nil, nil, fmt.Errorf("collection '%s' of type '%s' can not be locked", collection.Name, collection.Type)
+	}
+
+	constraints, err := parseConstraints(collection.Version)
+	if err != nil {
+		return nil, nil, fmt.Errorf("collection '%s': %w", collection.Name, err)
+	}
+
+	existing, isResolved := resolved[collection.Name]
+	if isResolved {
+		v, err := parseVersion(existing.Version)
+		if err == nil && satisfies(v, constraints, true) {
+			return nil, nil, nil
+		}
+
+		return nil, nil, fmt.Errorf("collection '%s' version '%s' required by '%s' conflicts with the resolved version '%s'", collection.Name, collection.Version, pending.requiredBy, existing.Version)
+	}
+
+	artifact, dependencies, err := r.resolveGalaxyCollection(ctx, collection, constraints)
+	if err != nil {
+		if pending.requiredBy != "" {
+			return nil, nil, fmt.Errorf("collection '%s' required by '%s': %w", collection.Name, pending.requiredBy, err)
+		}
+		return nil, nil, fmt.Errorf("collection '%s': %w", collection.Name, err)
+	}
+
+	return artifact, dependencies, nil
+}
+
+// collectionVersionsPage is a page of the Galaxy API collection versions list
+type collectionVersionsPage struct {
+	Data []struct {
+		Version string `json:"version"`
+	} `json:"data"`
+	Links struct {
+		Next string `json:"next"`
+	} `json:"links"`
+}
+
+// collectionVersion is the Galaxy API collection version detail
+type collectionVersion struct {
+	Artifact struct {
+		SHA256 string `json:"sha256"`
+	} `json:"artifact"`
+	DownloadURL string `json:"download_url"`
+	Metadata    struct {
+		Dependencies map[string]string `json:"dependencies"`
+	} `json:"metadata"`
+	Version string `json:"version"`
+}
+
+// resolveGalaxyCollection returns the artifact of the highest collection version on a Galaxy server that satisfies the constraints, and its dependencies
+func (r *Resolver) resolveGalaxyCollection(ctx context.Context, collection *galaxyrequirements.Collection, constraints []*constraint) (*Artifact, map[string]string, error) {
+	namespace, name, found := strings.Cut(collection.Name, ".")
+	if !found || namespace == "" || name == "" {
+		return nil, nil, fmt.Errorf("invalid collection name, it must be in the form namespace.collection")
+	}
+
+	server := collection.Source
+	if server == "" {
+		server = r.server
+	}
+
+	base, err := r.api(ctx, server, apiV3)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	versionsURL := fmt.Sprintf("%scollections/%s/%s/versions/?limit=%d", base, namespace, name, versionsPageLimit)
+	versions := []string{}
+
+	for versionsURL != "" {
+		page := &collectionVersionsPage{}
+		err = r.getJSON(ctx, versionsURL, page)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, item := range page.Data {
+			versions = append(versions, item.Version)
+		}
+
+		versionsURL = ""
+		if page.Links.Next != "" {
+			versionsURL, err = resolveURL(base, page.Links.Next)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	version, found := highestVersion(versions, constraints, r.pre)
+	if !found {
+		return nil, nil, fmt.Errorf("no version satisfies '%s'", collection.Version)
+	}
+
+	detail := &collectionVersion{}
+	err = r.getJSON(ctx, fmt.Sprintf("%scollections/%s/%s/versions/%s/", base, namespace, name, version), detail)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if detail.DownloadURL == "" || detail.Artifact.SHA256 == "" {
+		return nil, nil, fmt.Errorf("version '%s' does not define its artifact", version)
+	}
+
+	downloadURL, err := resolveURL(base, detail.DownloadURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &Artifact{
+		Name:    collection.Name,
+		SHA256:  detail.Artifact.SHA256,
+		Source:  downloadURL,
+		Version: version,
+	}, detail.Metadata.Dependencies, nil
+}
+
+// resolveCollectionArtifact returns the artifact of a collection of type file or url, and its dependencies. The collection name and version are read from the artifact manifest
+func (r *Resolver) resolveCollectionArtifact(ctx context.Context, collection *galaxyrequirements.Collection) (*Artifact, map[string]string, error) {
+	source := collection.Name
+	if collection.Type == galaxyrequirements.TypeFile {
+		absolute, err := filepath.Abs(strings.TrimPrefix(source, fileScheme))
+		if err != nil {
+			return nil, nil, fmt.Errorf("collection '%s': %w", collection.Name, err)
+		}
+		source = absolute
+	}
+
+	content, err := r.read(ctx, source)
+	if err != nil {
+		return nil, nil, fmt.Errorf("collection '%s': %w", collection.Name, err)
+	}
+
+	sum, err := checksum(bytes.NewReader(content))
+	if err != nil {
+		return nil, nil, fmt.Errorf("collection '%s': %w", collection.Name, err)
+	}
+
+	manifest, err := readManifest(bytes.NewReader(content))
+	if err != nil {
+		return nil, nil, fmt.Errorf("collection '%s': %w", collection.Name, err)
+	}
+
+	info := manifest.CollectionInfo
+	return &Artifact{
+		Name:    fmt.Sprintf("%s.%s", info.Namespace, info.Name),
+		SHA256:  sum,
+		Source:  source,
+		Version: info.Version,
+	}, info.Dependencies, nil
+}
+
+// roleSearch is the Galaxy API roles list
+type roleSearch struct {
+	Results []struct {
+		GithubRepo    string `json:"github_repo"`
+		GithubUser    string `json:"github_user"`
+		SummaryFields struct {
+			Versions []struct {
+				Name string `json:"name"`
+			} `json:"versions"`
+		} `json:"summary_fields"`
+	} `json:"results"`
+}
+
+// resolveRole returns the artifact of a role. Roles defined by an archive URL or file are locked as they are, while the Galaxy roles are resolved to the requested version or to the highest one
+func (r *Resolver) resolveRole(ctx context.Context, role *galaxyrequirements.Role) (*Artifact, error) {
+	if role.Scm != "" {
+		return nil, fmt.Errorf("role '%s' installed from a repository can not be locked", role.Key())
+	}
+
+	if isRemote(role.Source) || isArchive(role.Source) {
+		return r.resolveRoleArchive(ctx, role)
+	}
+
+	owner, name, found := strings.Cut(role.Source, ".")
+	if !found || owner == "" || name == "" {
+		return nil, fmt.Errorf("role '%s' can not be locked, its source must be an archive or a Galaxy role in the form owner.name", role.Key())
+	}
+
+	base, err := r.api(ctx, r.server, apiV1)
+	if err != nil {
+		return nil, fmt.Errorf("role '%s': %w", role.Key(), err)
+	}
+
+	search := &roleSearch{}
+	err = r.getJSON(ctx, fmt.Sprintf("%sroles/?owner__username=%s&name=%s", base, url.QueryEscape(owner), url.QueryEscape(name)), search)
+	if err != nil {
+		return nil, fmt.Errorf("role '%s': %w", role.Key(), err)
+	}
+
+	if len(search.Results) == 0 {
+		return nil, fmt.Errorf("role '%s' not found", role.Key())
+	}
+
+	result := search.Results[0]
+	versions := []string{}
+	for _, v := range result.SummaryFields.Versions {
+		versions = append(versions, v.Name)
+	}
+
+	version := role.Version
+	if version == "" {
+		version, found = highestVersion(versions, []*constraint{}, r.pre)
+		if !found {
+			return nil, fmt.Errorf("role '%s' does not have any release version", role.Key())
+		}
+	} else if !contains(versions, version) {
+		return nil, fmt.Errorf("role '%s' does not have the version '%s'", role.Key(), version)
+	}
+
+	source := fmt.Sprintf("%s/%s/%s/archive/%s.tar.gz", r.roleArchiveURL, result.GithubUser, result.GithubRepo, version)
+	content, err := r.read(ctx, source)
+	if err != nil {
+		return nil, fmt.Errorf("role '%s': %w", role.Key(), err)
+	}
+
+	sum, err := checksum(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("role '%s': %w", role.Key(), err)
+	}
+
+	return &Artifact{
+		Name:    role.Key(),
+		SHA256:  sum,
+		Source:  source,
+		Version: version,
+	}, nil
+}
+
+// resolveRoleArchive returns the artifact of a role defined by an archive URL or file. The role name is the archive name when the role does not define it
+func (r *Resolver) resolveRoleArchive(ctx context.Context, role *galaxyrequirements.Role) (*Artifact, error) {
+	source := role.Source
+	if !isRemote(source) {
+		absolute, err := filepath.Abs(strings.TrimPrefix(source, fileScheme))
+		if err != nil {
+			return nil, fmt.Errorf("role '%s': %w", role.Key(), err)
+		}
+		source = absolute
+	}
+
+	content, err := r.read(ctx, source)
+	if err != nil {
+		return nil, fmt.Errorf("role '%s': %w", role.Key(), err)
+	}
+
+	sum, err := checksum(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("role '%s': %w", role.Key(), err)
+	}
+
+	name := role.Name
+	if name == "" {
+		name = archiveName(source)
+	}
+
+	return &Artifact{
+		Name:    name,
+		SHA256:  sum,
+		Source:  source,
+		Version: role.Version,
+	}, nil
+}
+
+// api returns the base URL of a Galaxy API version on a server. The available versions are requested once per server
+func (r *Resolver) api(ctx context.Context, server, apiVersion string) (string, error) {
+	root := strings.TrimSuffix(strings.TrimSuffix(server, "/"), strings.TrimSuffix(apiPath, "/")) + apiPath
+
+	versions, isCached := r.apis[root]
+	if !isCached {
+		available := struct {
+			AvailableVersions map[string]string `json:"available_versions"`
+		}{}
+
+		err := r.getJSON(ctx, root, &available)
+		if err != nil {
+			return "", err
+		}
+
+		versions = available.AvailableVersions
+		r.apis[root] = versions
+	}
+
+	versionPath, isAvailable := versions[apiVersion]
+	if !isAvailable {
+		return "", fmt.Errorf("Galaxy server '%s' does not support the API %s", server, apiVersion)
+	}
+
+	base, err := resolveURL(root, versionPath)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(base, "/") + "/", nil
+}
+
+// getJSON requests a Galaxy API URL and decodes its JSON response into value
+func (r *Resolver) getJSON(ctx context.Context, apiURL string, value interface{}) error {
+	reader, err := open(ctx, r.client, apiURL)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	err = json.NewDecoder(reader).Decode(value)
+	if err != nil {
+		return fmt.Errorf("error decoding '%s' response: %w", apiURL, err)
+	}
+
+	return nil
+}
+
+// read returns the content of an artifact source
+func (r *Resolver) read(ctx context.Context, source string) ([]byte, error) {
+	reader, err := open(ctx, r.client, source)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+// resolveURL resolves a reference, which can be relative, from a base URL
+func resolveURL(base, reference string) (string, error) {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+
+	referenceURL, err := url.Parse(reference)
+	if err != nil {
+		return "", err
+	}
+
+	return baseURL.ResolveReference(referenceURL).String(), nil
+}
+
+// archiveExtensions are the extensions of the role archives
+var archiveExtensions = []string{".tar.gz", ".tgz", ".tar"}
+
+// isArchive returns whether the source is a role archive file
+func isArchive(source string) bool {
+	for _, extension := range archiveExtensions {
+		if strings.HasSuffix(source, extension) {
+			return true
+		}
+	}
+
+	return strings.HasPrefix(source, fileScheme)
+}
+
+// archiveName returns the archive file name without its extension
+func archiveName(source string) string {
+	name := path.Base(source)
+	for _, extension := range archiveExtensions {
+		if strings.HasSuffix(name, extension) {
+			return strings.TrimSuffix(name, extension)
+		}
+	}
+
+	return name
+}
+
+// contains returns whether value is one of the items
+func contains(items []string, value string) bool {
+	for _, item := range items {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sortedKeys returns the map keys sorted
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
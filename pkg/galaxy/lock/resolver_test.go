@@ -0,0 +1,380 @@
+package galaxylock
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	galaxyrequirements "github.com/apenella/go-ansible/v2/pkg/galaxy/requirements"
+	"github.com/stretchr/testify/assert"
+)
+
+// standInCollection is a collection version served by the galaxyStandIn
+type standInCollection struct {
+	dependencies map[string]string
+	version      string
+}
+
+// galaxyStandIn is a minimal Galaxy server that serves the v1 roles API, the v3 collections API, the collections artifacts and the roles archives
+type galaxyStandIn struct {
+	t           *testing.T
+	collections map[string][]*standInCollection
+	roles       map[string][]string
+	// artifacts are the served files, indexed by their path
+	artifacts map[string][]byte
+}
+
+// newGalaxyStandIn returns a galaxyStandIn that serves the internal.tools and internal.base collections, and the acme.web role
+func newGalaxyStandIn(t *testing.T) *galaxyStandIn {
+	g := &galaxyStandIn{
+		t: t,
+		collections: map[string][]*standInCollection{
+			"internal.tools": {
+				{version: "1.0.0"},
+				{version: "1.1.0", dependencies: map[string]string{"internal.base": ">=1.2.0"}},
+				{version: "2.0.0-beta.1"},
+			},
+			"internal.base": {
+				{version: "1.0.0"},
+				{version: "1.2.0"},
+			},
+		},
+		roles: map[string][]string{
+			"acme.web": {"1.0.0", "1.2.0"},
+		},
+		artifacts: map[string][]byte{},
+	}
+
+	for name, versions := range g.collections {
+		namespace, collection, _ := strings.Cut(name, ".")
+		for _, v := range versions {
+			g.artifacts["/download/"+namespace+"-"+collection+"-"+v.version+".tar.gz"] = collectionArtifact(t, namespace, collection, v.version, v.dependencies)
+		}
+	}
+
+	for _, v := range g.roles["acme.web"] {
+		g.artifacts["/archive/acme/ansible-role-web/archive/"+v+".tar.gz"] = archive(t, map[string]string{"ansible-role-web-" + v + "/meta/main.yml": "galaxy_info: {}\n"})
+	}
+
+	return g
+}
+
+func (g *galaxyStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	artifact, isArtifact := g.artifacts[r.URL.Path]
+	if isArtifact {
+		_, _ = w.Write(artifact)
+		return
+	}
+
+	var body interface{}
+
+	switch {
+	case r.URL.Path == "/api/":
+		body = map[string]interface{}{
+			"available_versions": map[string]string{"v1": "v1/", "v3": "v3/"},
+		}
+	case r.URL.Path == "/api/v1/roles/":
+		results := []interface{}{}
+		name := r.URL.Query().Get("owner__username") + "." + r.URL.Query().Get("name")
+		if versions, exists := g.roles[name]; exists {
+			summaryVersions := []map[string]string{}
+			for _, v := range versions {
+				summaryVersions = append(summaryVersions, map[string]string{"name": v})
+			}
+			results = append(results, map[string]interface{}{
+				"github_user":    "acme",
+				"github_repo":    "ansible-role-web",
+				"summary_fields": map[string]interface{}{"versions": summaryVersions},
+			})
+		}
+		body = map[string]interface{}{"results": results}
+	case strings.HasPrefix(r.URL.Path, "/api/v3/collections/"):
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v3/collections/"), "/"), "/")
+		versions, exists := g.collections[parts[0]+"."+parts[1]]
+		if !exists || len(parts) < 3 {
+			http.NotFound(w, r)
+			return
+		}
+
+		// the versions list is served on pages of one version to exercise the pagination
+		if len(parts) == 3 {
+			offset := 0
+			if r.URL.Query().Get("offset") != "" {
+				offset = int(r.URL.Query().Get("offset")[0] - '0')
+			}
+
+			next := ""
+			if offset+1 < len(versions) {
+				next = r.URL.Path + "?offset=" + string(rune('0'+offset+1))
+			}
+
+			body = map[string]interface{}{
+				"data":  []map[string]string{{"version": versions[offset].version}},
+				"links": map[string]interface{}{"next": next},
+			}
+			break
+		}
+
+		for _, v := range versions {
+			if v.version != parts[3] {
+				continue
+			}
+
+			artifactPath := "/download/" + parts[0] + "-" + parts[1] + "-" + v.version + ".tar.gz"
+			sum := sha256.Sum256(g.artifacts[artifactPath])
+			body = map[string]interface{}{
+				"version":      v.version,
+				"download_url": "http://" + r.Host + artifactPath,
+				"artifact":     map[string]string{"sha256": hex.EncodeToString(sum[:])},
+				"metadata":     map[string]interface{}{"dependencies": v.dependencies},
+			}
+		}
+	}
+
+	if body == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// archive returns a tar.gz archive with the files
+func archive(t *testing.T, files map[string]string) []byte {
+	buff := &bytes.Buffer{}
+	gz := gzip.NewWriter(buff)
+	tw := tar.NewWriter(gz)
+
+	for _, name := range sortedKeys(files) {
+		err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(files[name]))})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = tw.Write([]byte(files[name]))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_ = tw.Close()
+	_ = gz.Close()
+
+	return buff.Bytes()
+}
+
+// collectionArtifact returns a collection artifact that only contains the MANIFEST.json file
+func collectionArtifact(t *testing.T, namespace, name, version string, dependencies map[string]string) []byte {
+	if dependencies == nil {
+		dependencies = map[string]string{}
+	}
+
+	manifest, err := json.Marshal(map[string]interface{}{
+		"collection_info": map[string]interface{}{
+			"namespace":    namespace,
+			"name":         name,
+			"version":      version,
+			"dependencies": dependencies,
+		},
+		"format": 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return archive(t, map[string]string{manifestFile: string(manifest)})
+}
+
+// sha256sum returns the SHA256 checksum of the content
+func sha256sum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestResolve(t *testing.T) {
+
+	standIn := newGalaxyStandIn(t)
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	dir := t.TempDir()
+	localCollection := filepath.Join(dir, "local-extras-0.1.0.tar.gz")
+	localContent := collectionArtifact(t, "local", "extras", "0.1.0", nil)
+	err := os.WriteFile(localCollection, localContent, 0600)
+	assert.NoError(t, err)
+
+	requirements := &galaxyrequirements.Requirements{
+		Collections: []*galaxyrequirements.Collection{
+			{Name: "internal.tools", Version: "<2.0.0"},
+			{Name: localCollection, Type: galaxyrequirements.TypeFile},
+			// the same artifact is locked once
+			{Name: localCollection, Type: galaxyrequirements.TypeFile},
+		},
+		Roles: []*galaxyrequirements.Role{
+			{Source: "acme.web"},
+			{Name: "legacy", Source: server.URL + "/archive/acme/ansible-role-web/archive/1.0.0.tar.gz", Version: "1.0.0"},
+		},
+	}
+
+	res, err := NewResolver(
+		WithServer(server.URL),
+		WithRoleArchiveURL(server.URL+"/archive/"),
+	).Resolve(context.TODO(), requirements)
+	assert.NoError(t, err)
+
+	assert.Equal(t, &Lockfile{
+		Collections: []*Artifact{
+			{
+				Name:    "internal.base",
+				SHA256:  sha256sum(standIn.artifacts["/download/internal-base-1.2.0.tar.gz"]),
+				Source:  server.URL + "/download/internal-base-1.2.0.tar.gz",
+				Version: "1.2.0",
+			},
+			{
+				Name:    "internal.tools",
+				SHA256:  sha256sum(standIn.artifacts["/download/internal-tools-1.1.0.tar.gz"]),
+				Source:  server.URL + "/download/internal-tools-1.1.0.tar.gz",
+				Version: "1.1.0",
+			},
+			{
+				Name:    "local.extras",
+				SHA256:  sha256sum(localContent),
+				Source:  localCollection,
+				Version: "0.1.0",
+			},
+		},
+		Roles: []*Artifact{
+			{
+				Name:    "acme.web",
+				SHA256:  sha256sum(standIn.artifacts["/archive/acme/ansible-role-web/archive/1.2.0.tar.gz"]),
+				Source:  server.URL + "/archive/acme/ansible-role-web/archive/1.2.0.tar.gz",
+				Version: "1.2.0",
+			},
+			{
+				Name:    "legacy",
+				SHA256:  sha256sum(standIn.artifacts["/archive/acme/ansible-role-web/archive/1.0.0.tar.gz"]),
+				Source:  server.URL + "/archive/acme/ansible-role-web/archive/1.0.0.tar.gz",
+				Version: "1.0.0",
+			},
+		},
+	}, res)
+}
+
+func TestResolvePre(t *testing.T) {
+
+	server := httptest.NewServer(newGalaxyStandIn(t))
+	defer server.Close()
+
+	res, err := NewResolver(WithServer(server.URL+"/api/"), WithPre()).Resolve(context.TODO(), &galaxyrequirements.Requirements{
+		Collections: []*galaxyrequirements.Collection{{Name: "internal.tools"}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, res.Collections, 1)
+	assert.Equal(t, "2.0.0-beta.1", res.Collections[0].Version)
+}
+
+func TestResolveErrors(t *testing.T) {
+
+	server := httptest.NewServer(newGalaxyStandIn(t))
+	defer server.Close()
+
+	baseCollection := filepath.Join(t.TempDir(), "internal-base-1.0.0.tar.gz")
+	err := os.WriteFile(baseCollection, collectionArtifact(t, "internal", "base", "1.0.0", nil), 0600)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		desc         string
+		requirements *galaxyrequirements.Requirements
+		errs         []string
+	}{
+		{
+			desc: "Testing resolve collections and roles that can not be locked",
+			requirements: &galaxyrequirements.Requirements{
+				Collections: []*galaxyrequirements.Collection{
+					{Name: "https://github.com/example/collection.git", Type: galaxyrequirements.TypeGit},
+					{Name: "internal.tools", Version: ">=3.0.0"},
+					{Name: "internal.missing"},
+				},
+				Roles: []*galaxyrequirements.Role{
+					{Source: "https://github.com/example/role.git", Scm: galaxyrequirements.ScmGit},
+					{Source: "acme.web", Version: "9.9.9"},
+					{Source: "acme.missing"},
+				},
+			},
+			errs: []string{
+				"collection 'https://github.com/example/collection.git' of type 'git' can not be locked",
+				"collection 'internal.tools': no version satisfies '>=3.0.0'",
+				"collection 'internal.missing': unexpected status '404 Not Found'",
+				"role 'https://github.com/example/role.git' installed from a repository can not be locked",
+				"role 'acme.web' does not have the version '9.9.9'",
+				"role 'acme.missing' not found",
+			},
+		},
+		{
+			desc: "Testing resolve a dependency that conflicts with a resolved collection",
+			requirements: &galaxyrequirements.Requirements{
+				Collections: []*galaxyrequirements.Collection{
+					{Name: "internal.base", Version: "1.0.0"},
+					{Name: "internal.tools", Version: "1.1.0"},
+				},
+			},
+			errs: []string{
+				"collection 'internal.base' version '>=1.2.0' required by 'internal.tools' conflicts with the resolved version '1.0.0'",
+			},
+		},
+		{
+			desc: "Testing resolve a collection artifact that conflicts with a resolved collection",
+			requirements: &galaxyrequirements.Requirements{
+				Collections: []*galaxyrequirements.Collection{
+					{Name: "internal.base", Version: "1.2.0"},
+					{Name: baseCollection, Type: galaxyrequirements.TypeFile},
+				},
+			},
+			errs: []string{
+				"collection 'internal.base' version '1.0.0' from '" + baseCollection + "' conflicts with the resolved version '1.2.0' from '" + server.URL + "/download/internal-base-1.2.0.tar.gz'",
+			},
+		},
+		{
+			desc: "Testing resolve a dependency that conflicts with a resolved collection artifact",
+			requirements: &galaxyrequirements.Requirements{
+				Collections: []*galaxyrequirements.Collection{
+					{Name: baseCollection, Type: galaxyrequirements.TypeFile},
+					{Name: "internal.tools", Version: "1.1.0"},
+				},
+			},
+			errs: []string{
+				"collection 'internal.base' version '>=1.2.0' required by 'internal.tools' conflicts with the resolved version '1.0.0'",
+			},
+		},
+		{
+			desc: "Testing resolve invalid requirements",
+			requirements: &galaxyrequirements.Requirements{
+				Collections: []*galaxyrequirements.Collection{{}},
+			},
+			errs: []string{"Requirements can not be resolved"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			_, err := NewResolver(WithServer(server.URL)).Resolve(context.TODO(), test.requirements)
+			if assert.Error(t, err) {
+				for _, msg := range test.errs {
+					assert.Contains(t, err.Error(), msg)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package galaxylock
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// constraintAny is the version constraint that matches any version
+	constraintAny = "*"
+)
+
+// constraintOperators are the operators supported on the version constraints. The two characters operators are listed first to be matched before their one character prefix
+var constraintOperators = []string{"==", "!=", ">=", "<=", ">", "<"}
+
+// version is a semantic version. Versions with less than three numbers, or with a 'v' prefix, are also accepted to support the role versions
+type version struct {
+	// numbers are the version numbers, such as major, minor and patch
+	numbers []int
+	// pre are the pre-release identifiers
+	pre []string
+	// raw is the version as it was defined
+	raw string
+}
+
+// constraint is a condition that a version must satisfy
+type constraint struct {
+	operator string
+	version  *version
+}
+
+// parseVersion returns the version represented by raw. The build metadata is ignored
+func parseVersion(raw string) (*version, error) {
+	v := &version{
+		raw: raw,
+	}
+
+	value := strings.TrimPrefix(strings.TrimSpace(raw), "v")
+	value, _, _ = strings.Cut(value, "+")
+	value, pre, hasPre := strings.Cut(value, "-")
+
+	if hasPre {
+		if pre == "" {
+			return nil, fmt.Errorf("invalid version '%s'", raw)
+		}
+		v.pre = strings.Split(pre, ".")
+	}
+
+	for _, item := range strings.Split(value, ".") {
+		number, err := strconv.Atoi(item)
+		if err != nil || number < 0 {
+			return nil, fmt.Errorf("invalid version '%s'", raw)
+		}
+		v.numbers = append(v.numbers, number)
+	}
+
+	return v, nil
+}
+
+// isPreRelease returns whether the version is a pre-release
+func (v *version) isPreRelease() bool {
+	return len(v.pre) > 0
+}
+
+// compare returns -1, 0 or 1 when the version is lower, equal or greater than other
+func (v *version) compare(other *version) int {
+	for i := 0; i < len(v.numbers) || i < len(other.numbers); i++ {
+		a, b := 0, 0
+		if i < len(v.numbers) {
+			a = v.numbers[i]
+		}
+		if i < len(other.numbers) {
+			b = other.numbers[i]
+		}
+
+		if a != b {
+			return compareInts(a, b)
+		}
+	}
+
+	// a pre-release has lower precedence than its release
+	switch {
+	case !v.isPreRelease() && !other.isPreRelease():
+		return 0
+	case !v.isPreRelease():
+		return 1
+	case !other.isPreRelease():
+		return -1
+	}
+
+	for i := 0; i < len(v.pre) && i < len(other.pre); i++ {
+		result := comparePreReleaseIdentifiers(v.pre[i], other.pre[i])
+		if result != 0 {
+			return result
+		}
+	}
+
+	return compareInts(len(v.pre), len(other.pre))
+}
+
+// comparePreReleaseIdentifiers compares two pre-release identifiers. Numeric identifiers are compared numerically and have lower precedence than alphanumeric ones
+func comparePreReleaseIdentifiers(a, b string) int {
+	numberA, errA := strconv.Atoi(a)
+	numberB, errB := strconv.Atoi(b)
+
+	switch {
+	case errA == nil && errB == nil:
+		return compareInts(numberA, numberB)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
+
+// compareInts returns -1, 0 or 1 when a is lower, equal or greater than b
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+// parseConstraints returns the constraints defined on a comma separated list, such as '>=1.0.0,<2.0.0'. A version without operator must be matched exactly, and an empty list or '*' matches any version
+func parseConstraints(raw string) ([]*constraint, error) {
+	constraints := []*constraint{}
+
+	if strings.TrimSpace(raw) == "" || strings.TrimSpace(raw) == constraintAny {
+		return constraints, nil
+	}
+
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		operator := "=="
+
+		for _, candidate := range constraintOperators {
+			if strings.HasPrefix(item, candidate) {
+				operator = candidate
+				item = strings.TrimSpace(strings.TrimPrefix(item, candidate))
+				break
+			}
+		}
+
+		v, err := parseVersion(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint '%s': %w", raw, err)
+		}
+
+		constraints = append(constraints, &constraint{
+			operator: operator,
+			version:  v,
+		})
+	}
+
+	return constraints, nil
+}
+
+// matches returns whether the version satisfies the constraint
+func (c *constraint) matches(v *version) bool {
+	result := v.compare(c.version)
+
+	switch c.operator {
+	case "!=":
+		return result != 0
+	case ">=":
+		return result >= 0
+	case "<=":
+		return result <= 0
+	case ">":
+		return result > 0
+	case "<":
+		return result < 0
+	}
+
+	return result == 0
+}
+
+// satisfies returns whether the version satisfies all the constraints. Pre-releases are only satisfied when pre is true or when a constraint pins them exactly
+func satisfies(v *version, constraints []*constraint, pre bool) bool {
+	if v.isPreRelease() && !pre && !pinned(v, constraints) {
+		return false
+	}
+
+	for _, c := range constraints {
+		if !c.matches(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// pinned returns whether a constraint requires exactly the version
+func pinned(v *version, constraints []*constraint) bool {
+	for _, c := range constraints {
+		if c.operator == "==" && v.compare(c.version) == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// highestVersion returns the highest of the raw versions that satisfies the constraints. The versions that can not be parsed are ignored
+func highestVersion(raws []string, constraints []*constraint, pre bool) (string, bool) {
+	var highest *version
+
+	for _, raw := range raws {
+		v, err := parseVersion(raw)
+		if err != nil {
+			continue
+		}
+
+		if !satisfies(v, constraints, pre) {
+			continue
+		}
+
+		if highest == nil || v.compare(highest) > 0 {
+			highest = v
+		}
+	}
+
+	if highest == nil {
+		return "", false
+	}
+
+	return highest.raw, true
+}
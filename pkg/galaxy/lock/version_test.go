@@ -0,0 +1,127 @@
+package galaxylock
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVersion(t *testing.T) {
+
+	tests := []struct {
+		desc string
+		raw  string
+		res  *version
+		err  error
+	}{
+		{
+			desc: "Testing parse a release version",
+			raw:  "1.2.3",
+			res:  &version{numbers: []int{1, 2, 3}, raw: "1.2.3"},
+		},
+		{
+			desc: "Testing parse a pre-release version with build metadata",
+			raw:  "2.0.0-beta.1+build.5",
+			res:  &version{numbers: []int{2, 0, 0}, pre: []string{"beta", "1"}, raw: "2.0.0-beta.1+build.5"},
+		},
+		{
+			desc: "Testing parse a role version with v prefix",
+			raw:  "v1.4",
+			res:  &version{numbers: []int{1, 4}, raw: "v1.4"},
+		},
+		{
+			desc: "Testing parse an invalid version",
+			raw:  "main",
+			err:  fmt.Errorf("invalid version 'main'"),
+		},
+		{
+			desc: "Testing parse a version with an empty pre-release",
+			raw:  "1.0.0-",
+			err:  fmt.Errorf("invalid version '1.0.0-'"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := parseVersion(test.raw)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+
+	tests := []struct {
+		desc string
+		a    string
+		b    string
+		res  int
+	}{
+		{desc: "Testing compare equal versions", a: "1.2.0", b: "1.2", res: 0},
+		{desc: "Testing compare a lower patch version", a: "1.2.3", b: "1.2.10", res: -1},
+		{desc: "Testing compare a higher major version", a: "2.0.0", b: "1.9.9", res: 1},
+		{desc: "Testing compare a pre-release with its release", a: "1.0.0-rc.1", b: "1.0.0", res: -1},
+		{desc: "Testing compare numeric pre-release identifiers", a: "1.0.0-rc.2", b: "1.0.0-rc.10", res: -1},
+		{desc: "Testing compare numeric and alphanumeric pre-release identifiers", a: "1.0.0-1", b: "1.0.0-alpha", res: -1},
+		{desc: "Testing compare pre-releases with different identifiers length", a: "1.0.0-alpha.1", b: "1.0.0-alpha", res: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			a, err := parseVersion(test.a)
+			assert.NoError(t, err)
+			b, err := parseVersion(test.b)
+			assert.NoError(t, err)
+
+			assert.Equal(t, test.res, a.compare(b))
+		})
+	}
+}
+
+func TestHighestVersion(t *testing.T) {
+
+	versions := []string{"1.0.0", "1.1.0", "1.2.0-beta.1", "2.0.0", "main"}
+
+	tests := []struct {
+		desc        string
+		constraints string
+		pre         bool
+		res         string
+		found       bool
+	}{
+		{desc: "Testing highest version without constraints", constraints: "*", res: "2.0.0", found: true},
+		{desc: "Testing highest version on a range", constraints: ">=1.0.0,<2.0.0", res: "1.1.0", found: true},
+		{desc: "Testing highest version on a range including pre-releases", constraints: ">=1.0.0,<2.0.0", pre: true, res: "1.2.0-beta.1", found: true},
+		{desc: "Testing highest version pinned to a pre-release", constraints: "==1.2.0-beta.1", res: "1.2.0-beta.1", found: true},
+		{desc: "Testing highest version pinned without operator", constraints: "1.0.0", res: "1.0.0", found: true},
+		{desc: "Testing highest version excluding a version", constraints: "!=2.0.0", res: "1.1.0", found: true},
+		{desc: "Testing highest version without matching versions", constraints: ">2.0.0", found: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			constraints, err := parseConstraints(test.constraints)
+			assert.NoError(t, err)
+
+			res, found := highestVersion(versions, constraints, test.pre)
+			assert.Equal(t, test.found, found)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestParseConstraintsError(t *testing.T) {
+	_, err := parseConstraints(">=one")
+	assert.Equal(t, fmt.Errorf("invalid version constraint '>=one': %w", fmt.Errorf("invalid version 'one'")), err)
+}
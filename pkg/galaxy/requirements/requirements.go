@@ -0,0 +1,211 @@
+package galaxyrequirements
+
+import (
+	"fmt"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// TypeDir is the collection type to install a collection from a local directory
+	TypeDir = "dir"
+	// TypeFile is the collection type to install a collection from a local tarball
+	TypeFile = "file"
+	// TypeGalaxy is the collection type to install a collection from a Galaxy server
+	TypeGalaxy = "galaxy"
+	// TypeGit is the collection type to install a collection from a git repository
+	TypeGit = "git"
+	// TypeSubdirs is the collection type to install all the collections found on the subdirectories of a local directory
+	TypeSubdirs = "subdirs"
+	// TypeURL is the collection type to install a collection from a tarball URL
+	TypeURL = "url"
+
+	// ScmGit is the source control management used to install a role from a git repository
+	ScmGit = "git"
+	// ScmHg is the source control management used to install a role from a mercurial repository
+	ScmHg = "hg"
+)
+
+// CollectionTypes are the types that can be set on a collection requirement
+var CollectionTypes = []string{TypeDir, TypeFile, TypeGalaxy, TypeGit, TypeSubdirs, TypeURL}
+
+// RoleScms are the source control managements that can be set on a role requirement
+var RoleScms = []string{ScmGit, ScmHg}
+
+// Requirements is the content of an ansible-galaxy requirements file
+type Requirements struct {
+	// Collections are the collections to be installed
+	Collections []*Collection `yaml:"collections,omitempty"`
+	// Roles are the roles to be installed
+	Roles []*Role `yaml:"roles,omitempty"`
+}
+
+// Collection is a collection requirement
+type Collection struct {
+	// Name is the collection name, in the form namespace.collection. Depending on the type, it is the path or the URL to the collection
+	Name string `yaml:"name"`
+	// Signatures are the signature sources used to verify the collection. They are only supported by galaxy collections
+	Signatures []string `yaml:"signatures,omitempty"`
+	// Source is the Galaxy server or the URL to install the collection from
+	Source string `yaml:"source,omitempty"`
+	// Type is the type of the collection source. The default is galaxy
+	Type string `yaml:"type,omitempty"`
+	// Version is the collection version constraint, such as '>=1.0.0,<2.0.0'
+	Version string `yaml:"version,omitempty"`
+}
+
+// Role is a role requirement
+type Role struct {
+	// Name is the role name. The default is the name computed from the source
+	Name string `yaml:"name,omitempty"`
+	// Scm is the source control management used to install the role from a repository
+	Scm string `yaml:"scm,omitempty"`
+	// Source is the Galaxy role name, the repository or the URL to install the role from
+	Source string `yaml:"src,omitempty"`
+	// Version is the role version, or the branch, tag or commit when the role is installed from a repository
+	Version string `yaml:"version,omitempty"`
+}
+
+// NewRequirements creates a new Requirements instance
+func NewRequirements() *Requirements {
+	return &Requirements{
+		Collections: []*Collection{},
+		Roles:       []*Role{},
+	}
+}
+
+// AddCollections adds the collections to the requirements. A collection with the same name as an existing one replaces it
+func (r *Requirements) AddCollections(collections ...*Collection) *Requirements {
+	for _, collection := range collections {
+		if collection == nil {
+			continue
+		}
+
+		replaced := false
+		for i, existing := range r.Collections {
+			if existing.Name == collection.Name {
+				r.Collections[i] = collection
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			r.Collections = append(r.Collections, collection)
+		}
+	}
+
+	return r
+}
+
+// AddRoles adds the roles to the requirements. A role with the same key as an existing one replaces it
+func (r *Requirements) AddRoles(roles ...*Role) *Requirements {
+	for _, role := range roles {
+		if role == nil {
+			continue
+		}
+
+		replaced := false
+		for i, existing := range r.Roles {
+			if existing.Key() == role.Key() {
+				r.Roles[i] = role
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			r.Roles = append(r.Roles, role)
+		}
+	}
+
+	return r
+}
+
+// Merge returns new requirements with the collections and roles of the requirements and the ones received as arguments. The requirements received later replace the collections and roles with the same name
+func (r *Requirements) Merge(others ...*Requirements) *Requirements {
+	merged := NewRequirements()
+
+	for _, requirements := range append([]*Requirements{r}, others...) {
+		if requirements == nil {
+			continue
+		}
+
+		merged.AddCollections(requirements.Collections...)
+		merged.AddRoles(requirements.Roles...)
+	}
+
+	return merged
+}
+
+// Validate checks the requirements looking for collections and roles without name, and unsupported types and source control managements. It returns an error that wraps all the detected issues
+func (r *Requirements) Validate() error {
+	errContext := "(galaxy::Requirements::Validate)"
+	errs := []error{}
+
+	if r == nil {
+		return errors.New(errContext, "Requirements is nil")
+	}
+
+	for i, collection := range r.Collections {
+		if collection == nil {
+			errs = append(errs, fmt.Errorf("collection %d is not defined", i))
+			continue
+		}
+
+		if collection.Name == "" {
+			errs = append(errs, fmt.Errorf("collection %d has no name", i))
+		}
+
+		if collection.Type != "" && !contains(CollectionTypes, collection.Type) {
+			errs = append(errs, fmt.Errorf("collection '%s' type must be one of '%s', but '%s' was provided", collection.Name, strings.Join(CollectionTypes, "', '"), collection.Type))
+		}
+
+		// ansible-galaxy only verifies the signatures of the collections installed from a Galaxy server
+		if len(collection.Signatures) > 0 && collection.Type != "" && collection.Type != TypeGalaxy {
+			errs = append(errs, fmt.Errorf("collection '%s' signatures are only supported by '%s' collections", collection.Name, TypeGalaxy))
+		}
+	}
+
+	for i, role := range r.Roles {
+		if role == nil {
+			errs = append(errs, fmt.Errorf("role %d is not defined", i))
+			continue
+		}
+
+		if role.Key() == "" {
+			errs = append(errs, fmt.Errorf("role %d has neither name nor source", i))
+		}
+
+		if role.Scm != "" && !contains(RoleScms, role.Scm) {
+			errs = append(errs, fmt.Errorf("role '%s' scm must be one of '%s', but '%s' was provided", role.Key(), strings.Join(RoleScms, "', '"), role.Scm))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid requirements", errs...)
+	}
+
+	return nil
+}
+
+// Key returns the role name when it is defined, or its source otherwise. It identifies the role on the requirements
+func (r *Role) Key() string {
+	if r.Name != "" {
+		return r.Name
+	}
+
+	return r.Source
+}
+
+// contains returns whether value is one of the items
+func contains(items []string, value string) bool {
+	for _, item := range items {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,81 @@
+package galaxyrequirements
+
+import (
+	"os"
+
+	galaxycollectioninstall "github.com/apenella/go-ansible/v2/pkg/galaxy/collection/install"
+	galaxyroleinstall "github.com/apenella/go-ansible/v2/pkg/galaxy/role/install"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// requirementsFilePattern is the pattern of the temporary requirements files names
+const requirementsFilePattern = "requirements-*.yml"
+
+// NewAnsibleGalaxyCollectionInstallCmd writes the collections requirements to a temporary requirements file created on dir, or on the default directory for temporary files when dir is empty, and returns an AnsibleGalaxyCollectionInstallCmd that installs the collections from it. It also returns the temporary file, which the caller must remove once the command is executed
+func NewAnsibleGalaxyCollectionInstallCmd(requirements *Requirements, dir string, options ...galaxycollectioninstall.AnsibleGalaxyCollectionInstallOptionsFunc) (*galaxycollectioninstall.AnsibleGalaxyCollectionInstallCmd, string, error) {
+	errContext := "(galaxy::NewAnsibleGalaxyCollectionInstallCmd)"
+
+	if requirements == nil || len(requirements.Collections) == 0 {
+		return nil, "", errors.New(errContext, "Requirements do not define any collection")
+	}
+
+	file, err := writeTempFile(&Requirements{Collections: requirements.Collections}, dir)
+	if err != nil {
+		return nil, "", errors.New(errContext, "Error writing the collections requirements file", err)
+	}
+
+	cmd := galaxycollectioninstall.NewAnsibleGalaxyCollectionInstallCmd(options...)
+
+	// the options are copied to set the requirements file without modifying the user options
+	installOptions := galaxycollectioninstall.AnsibleGalaxyCollectionInstallOptions{}
+	if cmd.GalaxyCollectionInstallOptions != nil {
+		installOptions = *cmd.GalaxyCollectionInstallOptions
+	}
+	installOptions.RequirementsFile = file
+	cmd.GalaxyCollectionInstallOptions = &installOptions
+
+	return cmd, file, nil
+}
+
+// NewAnsibleGalaxyRoleInstallCmd writes the roles requirements to a temporary requirements file created on dir, or on the default directory for temporary files when dir is empty, and returns an AnsibleGalaxyRoleInstallCmd that installs the roles from it. It also returns the temporary file, which the caller must remove once the command is executed
+func NewAnsibleGalaxyRoleInstallCmd(requirements *Requirements, dir string, options ...galaxyroleinstall.AnsibleGalaxyRoleInstallOptionsFunc) (*galaxyroleinstall.AnsibleGalaxyRoleInstallCmd, string, error) {
+	errContext := "(galaxy::NewAnsibleGalaxyRoleInstallCmd)"
+
+	if requirements == nil || len(requirements.Roles) == 0 {
+		return nil, "", errors.New(errContext, "Requirements do not define any role")
+	}
+
+	file, err := writeTempFile(&Requirements{Roles: requirements.Roles}, dir)
+	if err != nil {
+		return nil, "", errors.New(errContext, "Error writing the roles requirements file", err)
+	}
+
+	cmd := galaxyroleinstall.NewAnsibleGalaxyRoleInstallCmd(options...)
+
+	// the options are copied to set the requirements file without modifying the user options
+	installOptions := galaxyroleinstall.AnsibleGalaxyRoleInstallOptions{}
+	if cmd.GalaxyRoleInstallOptions != nil {
+		installOptions = *cmd.GalaxyRoleInstallOptions
+	}
+	installOptions.RoleFile = file
+	cmd.GalaxyRoleInstallOptions = &installOptions
+
+	return cmd, file, nil
+}
+
+// writeTempFile writes the requirements to a new temporary file on dir and returns its name. The file is removed when the requirements can not be written
+func writeTempFile(requirements *Requirements, dir string) (string, error) {
+	f, err := os.CreateTemp(dir, requirementsFilePattern)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	err = requirements.Write(f)
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+
+	return f.Name(), nil
+}
@@ -0,0 +1,89 @@
+package galaxyrequirements
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	galaxycollectioninstall "github.com/apenella/go-ansible/v2/pkg/galaxy/collection/install"
+	galaxyroleinstall "github.com/apenella/go-ansible/v2/pkg/galaxy/role/install"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleGalaxyCollectionInstallCmd(t *testing.T) {
+
+	dir := t.TempDir()
+	requirements := &Requirements{
+		Collections: []*Collection{{Name: "community.general", Version: ">=8.0.0"}},
+		Roles:       []*Role{{Source: "geerlingguy.docker"}},
+	}
+	options := &galaxycollectioninstall.AnsibleGalaxyCollectionInstallOptions{
+		Force: true,
+	}
+
+	cmd, file, err := NewAnsibleGalaxyCollectionInstallCmd(requirements, dir,
+		galaxycollectioninstall.WithGalaxyCollectionInstallOptions(options),
+	)
+	assert.NoError(t, err)
+	defer os.Remove(file)
+
+	command, err := cmd.Command()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ansible-galaxy", "collection", "install", "--force", fmt.Sprintf("--requirements-file=%s", file)}, command)
+
+	// the requirements file only includes the collections
+	res, err := ParseFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, &Requirements{Collections: requirements.Collections, Roles: []*Role{}}, res)
+
+	// the user options are not modified
+	assert.Equal(t, "", options.RequirementsFile)
+}
+
+func TestNewAnsibleGalaxyCollectionInstallCmdErrors(t *testing.T) {
+
+	errContext := "(galaxy::NewAnsibleGalaxyCollectionInstallCmd)"
+
+	_, _, err := NewAnsibleGalaxyCollectionInstallCmd(&Requirements{Roles: []*Role{{Source: "geerlingguy.docker"}}}, t.TempDir())
+	assert.Equal(t, errors.New(errContext, "Requirements do not define any collection"), err)
+
+	dir := t.TempDir()
+	_, _, err = NewAnsibleGalaxyCollectionInstallCmd(&Requirements{Collections: []*Collection{{Type: TypeGalaxy}}}, dir)
+	assert.Error(t, err)
+
+	// the temporary file is removed when the requirements are not valid
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestNewAnsibleGalaxyRoleInstallCmd(t *testing.T) {
+
+	errContext := "(galaxy::NewAnsibleGalaxyRoleInstallCmd)"
+	dir := t.TempDir()
+	requirements := &Requirements{
+		Collections: []*Collection{{Name: "community.general"}},
+		Roles:       []*Role{{Source: "geerlingguy.docker", Version: "6.1.0"}},
+	}
+
+	cmd, file, err := NewAnsibleGalaxyRoleInstallCmd(requirements, dir,
+		galaxyroleinstall.WithGalaxyRoleInstallOptions(&galaxyroleinstall.AnsibleGalaxyRoleInstallOptions{
+			RolesPath: "roles",
+		}),
+	)
+	assert.NoError(t, err)
+	defer os.Remove(file)
+
+	command, err := cmd.Command()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ansible-galaxy", "role", "install", fmt.Sprintf("--role-file=%s", file), "--roles-path=roles"}, command)
+
+	// the requirements file only includes the roles
+	res, err := ParseFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, &Requirements{Collections: []*Collection{}, Roles: requirements.Roles}, res)
+
+	_, _, err = NewAnsibleGalaxyRoleInstallCmd(NewRequirements(), dir)
+	assert.Equal(t, errors.New(errContext, "Requirements do not define any role"), err)
+}
@@ -0,0 +1,136 @@
+package galaxyrequirements
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"gopkg.in/yaml.v3"
+)
+
+// Parse returns the requirements defined on an ansible-galaxy requirements file content. It supports both the format with the roles and collections keys and the legacy format, which is a list of roles
+func Parse(reader io.Reader) (*Requirements, error) {
+	errContext := "(galaxy::Parse)"
+
+	if reader == nil {
+		return nil, errors.New(errContext, "Reader is not defined")
+	}
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.New(errContext, "Error reading requirements", err)
+	}
+
+	requirements := NewRequirements()
+	if strings.TrimSpace(string(content)) == "" {
+		return requirements, nil
+	}
+
+	err = yaml.Unmarshal(content, requirements)
+	if err != nil {
+		return nil, errors.New(errContext, "Error unmarshaling requirements", err)
+	}
+
+	return requirements, nil
+}
+
+// ParseFile returns the requirements defined on an ansible-galaxy requirements file
+func ParseFile(file string) (*Requirements, error) {
+	errContext := "(galaxy::ParseFile)"
+
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error opening requirements file '%s'", file), err)
+	}
+	defer f.Close()
+
+	requirements, err := Parse(f)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error parsing requirements file '%s'", file), err)
+	}
+
+	return requirements, nil
+}
+
+// UnmarshalYAML decodes the requirements from either a map with the roles and collections keys or a list of roles
+func (r *Requirements) UnmarshalYAML(value *yaml.Node) error {
+	// requirements is an alias that does not inherit the UnmarshalYAML method
+	type requirements Requirements
+
+	if value.Kind == yaml.SequenceNode {
+		roles := []*Role{}
+		err := value.Decode(&roles)
+		if err != nil {
+			return err
+		}
+		r.Roles = roles
+
+		return nil
+	}
+
+	decoded := requirements{}
+	err := value.Decode(&decoded)
+	if err != nil {
+		return err
+	}
+
+	if decoded.Collections != nil {
+		r.Collections = decoded.Collections
+	}
+
+	if decoded.Roles != nil {
+		r.Roles = decoded.Roles
+	}
+
+	return nil
+}
+
+// UnmarshalYAML decodes a collection from either a map or a string that holds the collection name
+func (c *Collection) UnmarshalYAML(value *yaml.Node) error {
+	// collection is an alias that does not inherit the UnmarshalYAML method
+	type collection Collection
+
+	if value.Kind == yaml.ScalarNode {
+		c.Name = value.Value
+		return nil
+	}
+
+	decoded := collection{}
+	err := value.Decode(&decoded)
+	if err != nil {
+		return err
+	}
+	*c = Collection(decoded)
+
+	return nil
+}
+
+// UnmarshalYAML decodes a role from either a map or a string in the form 'src[,version[,name]]'
+func (r *Role) UnmarshalYAML(value *yaml.Node) error {
+	// role is an alias that does not inherit the UnmarshalYAML method
+	type role Role
+
+	if value.Kind == yaml.ScalarNode {
+		parts := strings.SplitN(value.Value, ",", 3)
+		r.Source = strings.TrimSpace(parts[0])
+		if len(parts) > 1 {
+			r.Version = strings.TrimSpace(parts[1])
+		}
+		if len(parts) > 2 {
+			r.Name = strings.TrimSpace(parts[2])
+		}
+
+		return nil
+	}
+
+	decoded := role{}
+	err := value.Decode(&decoded)
+	if err != nil {
+		return err
+	}
+	*r = Role(decoded)
+
+	return nil
+}
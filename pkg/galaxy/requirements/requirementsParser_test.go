@@ -0,0 +1,115 @@
+package galaxyrequirements
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+
+	errContext := "(galaxy::Parse)"
+
+	tests := []struct {
+		desc   string
+		reader io.Reader
+		res    *Requirements
+		err    error
+	}{
+		{
+			desc: "Testing parse requirements with roles and collections",
+			reader: strings.NewReader(`---
+collections:
+  - community.general
+  - name: ansible.posix
+    version: ">=1.5.0,<2.0.0"
+    source: https://galaxy.example.com
+    signatures:
+      - https://example.com/ansible-posix.asc
+  - name: https://github.com/example/collection.git
+    type: git
+    version: main
+roles:
+  - geerlingguy.docker
+  - src: https://github.com/example/webserver.git
+    scm: git
+    version: v2
+    name: webserver
+`),
+			res: &Requirements{
+				Collections: []*Collection{
+					{Name: "community.general"},
+					{Name: "ansible.posix", Version: ">=1.5.0,<2.0.0", Source: "https://galaxy.example.com", Signatures: []string{"https://example.com/ansible-posix.asc"}},
+					{Name: "https://github.com/example/collection.git", Type: TypeGit, Version: "main"},
+				},
+				Roles: []*Role{
+					{Source: "geerlingguy.docker"},
+					{Name: "webserver", Source: "https://github.com/example/webserver.git", Scm: ScmGit, Version: "v2"},
+				},
+			},
+		},
+		{
+			desc: "Testing parse requirements on the legacy roles list format",
+			reader: strings.NewReader(`- geerlingguy.docker
+- git+https://github.com/example/webserver.git,v2,webserver
+`),
+			res: &Requirements{
+				Collections: []*Collection{},
+				Roles: []*Role{
+					{Source: "geerlingguy.docker"},
+					{Name: "webserver", Source: "git+https://github.com/example/webserver.git", Version: "v2"},
+				},
+			},
+		},
+		{
+			desc:   "Testing parse empty requirements",
+			reader: strings.NewReader("\n"),
+			res:    NewRequirements(),
+		},
+		{
+			desc:   "Testing parse requirements that are not YAML",
+			reader: strings.NewReader("collections: ["),
+			err:    errors.New(errContext, "Error unmarshaling requirements", errors.New("", "yaml: line 1: did not find expected node content")),
+		},
+		{
+			desc: "Testing parse without reader",
+			err:  errors.New(errContext, "Reader is not defined"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := Parse(test.reader)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestParseFile(t *testing.T) {
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "requirements.yml")
+	err := os.WriteFile(file, []byte("collections:\n  - community.general\n"), 0600)
+	assert.NoError(t, err)
+
+	res, err := ParseFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, &Requirements{
+		Collections: []*Collection{{Name: "community.general"}},
+		Roles:       []*Role{},
+	}, res)
+
+	_, err = ParseFile(filepath.Join(dir, "nonexistent.yml"))
+	assert.Error(t, err)
+}
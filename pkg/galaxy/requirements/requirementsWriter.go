@@ -0,0 +1,69 @@
+package galaxyrequirements
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"gopkg.in/yaml.v3"
+)
+
+// yamlIndent is the indentation used to write the requirements
+const yamlIndent = 2
+
+// Write writes the requirements in the ansible-galaxy requirements file format. The requirements are validated before they are written
+func (r *Requirements) Write(writer io.Writer) error {
+	errContext := "(galaxy::Requirements::Write)"
+
+	if writer == nil {
+		return errors.New(errContext, "Writer is not defined")
+	}
+
+	err := r.Validate()
+	if err != nil {
+		return errors.New(errContext, "Requirements can not be written", err)
+	}
+
+	content := &bytes.Buffer{}
+	content.WriteString("---\n")
+
+	encoder := yaml.NewEncoder(content)
+	encoder.SetIndent(yamlIndent)
+
+	err = encoder.Encode(r)
+	if err != nil {
+		return errors.New(errContext, "Error marshaling requirements", err)
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return errors.New(errContext, "Error marshaling requirements", err)
+	}
+
+	_, err = writer.Write(content.Bytes())
+	if err != nil {
+		return errors.New(errContext, "Error writing requirements", err)
+	}
+
+	return nil
+}
+
+// WriteFile writes the requirements to an ansible-galaxy requirements file. The file is created when it does not exist and truncated otherwise
+func (r *Requirements) WriteFile(file string) error {
+	errContext := "(galaxy::Requirements::WriteFile)"
+
+	f, err := os.Create(file)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error creating requirements file '%s'", file), err)
+	}
+	defer f.Close()
+
+	err = r.Write(f)
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Error writing requirements file '%s'", file), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package galaxyrequirements
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrite(t *testing.T) {
+
+	tests := []struct {
+		desc         string
+		requirements *Requirements
+		res          string
+		err          bool
+	}{
+		{
+			desc: "Testing write requirements with roles and collections",
+			requirements: &Requirements{
+				Collections: []*Collection{
+					{Name: "community.general"},
+					{Name: "ansible.posix", Version: ">=1.5.0,<2.0.0", Source: "https://galaxy.example.com", Signatures: []string{"https://example.com/ansible-posix.asc"}},
+				},
+				Roles: []*Role{
+					{Name: "webserver", Source: "https://github.com/example/webserver.git", Scm: ScmGit, Version: "v2"},
+				},
+			},
+			res: `---
+collections:
+  - name: community.general
+  - name: ansible.posix
+    signatures:
+      - https://example.com/ansible-posix.asc
+    source: https://galaxy.example.com
+    version: '>=1.5.0,<2.0.0'
+roles:
+  - name: webserver
+    scm: git
+    src: https://github.com/example/webserver.git
+    version: v2
+`,
+		},
+		{
+			desc: "Testing write invalid requirements",
+			requirements: &Requirements{
+				Collections: []*Collection{{Type: TypeGalaxy}},
+			},
+			err: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			buff := &bytes.Buffer{}
+			err := test.requirements.Write(buff)
+			if test.err {
+				assert.Error(t, err)
+				assert.Empty(t, buff.String())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.res, buff.String())
+			}
+		})
+	}
+}
+
+func TestWriteFileAndParseFile(t *testing.T) {
+	requirements := &Requirements{
+		Collections: []*Collection{
+			{Name: "https://github.com/example/collection.git", Type: TypeGit, Version: "main"},
+		},
+		Roles: []*Role{
+			{Source: "geerlingguy.docker", Version: "6.1.0"},
+		},
+	}
+
+	file := filepath.Join(t.TempDir(), "requirements.yml")
+
+	err := requirements.WriteFile(file)
+	assert.NoError(t, err)
+
+	res, err := ParseFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, requirements, res)
+
+	err = requirements.WriteFile(filepath.Join(file, "nonexistent", "requirements.yml"))
+	assert.Error(t, err)
+	_, err = os.Stat(file)
+	assert.NoError(t, err)
+}
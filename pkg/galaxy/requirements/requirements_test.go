@@ -0,0 +1,208 @@
+package galaxyrequirements
+
+import (
+	"fmt"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRequirements(t *testing.T) {
+	expect := &Requirements{
+		Collections: []*Collection{},
+		Roles:       []*Role{},
+	}
+
+	assert.Equal(t, expect, NewRequirements())
+}
+
+func TestAddCollections(t *testing.T) {
+
+	tests := []struct {
+		desc         string
+		requirements *Requirements
+		collections  []*Collection
+		res          *Requirements
+	}{
+		{
+			desc:         "Testing add collections to empty requirements",
+			requirements: NewRequirements(),
+			collections: []*Collection{
+				{Name: "community.general", Version: ">=8.0.0"},
+				nil,
+				{Name: "ansible.posix"},
+			},
+			res: &Requirements{
+				Collections: []*Collection{
+					{Name: "community.general", Version: ">=8.0.0"},
+					{Name: "ansible.posix"},
+				},
+				Roles: []*Role{},
+			},
+		},
+		{
+			desc: "Testing add a collection that replaces an existing one",
+			requirements: &Requirements{
+				Collections: []*Collection{
+					{Name: "community.general", Version: ">=8.0.0"},
+					{Name: "ansible.posix"},
+				},
+			},
+			collections: []*Collection{
+				{Name: "community.general", Version: "8.2.0"},
+			},
+			res: &Requirements{
+				Collections: []*Collection{
+					{Name: "community.general", Version: "8.2.0"},
+					{Name: "ansible.posix"},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res := test.requirements.AddCollections(test.collections...)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestAddRoles(t *testing.T) {
+
+	tests := []struct {
+		desc         string
+		requirements *Requirements
+		roles        []*Role
+		res          *Requirements
+	}{
+		{
+			desc: "Testing add roles identified by name and source",
+			requirements: &Requirements{
+				Roles: []*Role{
+					{Source: "geerlingguy.docker", Version: "6.0.0"},
+					{Name: "webserver", Source: "https://github.com/example/webserver.git", Scm: ScmGit},
+				},
+			},
+			roles: []*Role{
+				{Source: "geerlingguy.docker", Version: "6.1.0"},
+				{Name: "webserver", Source: "https://github.com/example/webserver.git", Scm: ScmGit, Version: "v2"},
+				{Source: "geerlingguy.pip"},
+			},
+			res: &Requirements{
+				Roles: []*Role{
+					{Source: "geerlingguy.docker", Version: "6.1.0"},
+					{Name: "webserver", Source: "https://github.com/example/webserver.git", Scm: ScmGit, Version: "v2"},
+					{Source: "geerlingguy.pip"},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res := test.requirements.AddRoles(test.roles...)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base := &Requirements{
+		Collections: []*Collection{
+			{Name: "community.general", Version: ">=8.0.0"},
+		},
+		Roles: []*Role{
+			{Source: "geerlingguy.docker"},
+		},
+	}
+
+	override := &Requirements{
+		Collections: []*Collection{
+			{Name: "ansible.posix"},
+			{Name: "community.general", Version: "8.2.0"},
+		},
+	}
+
+	res := base.Merge(nil, override)
+
+	assert.Equal(t, &Requirements{
+		Collections: []*Collection{
+			{Name: "community.general", Version: "8.2.0"},
+			{Name: "ansible.posix"},
+		},
+		Roles: []*Role{
+			{Source: "geerlingguy.docker"},
+		},
+	}, res)
+	// the merged requirements are not modified
+	assert.Equal(t, ">=8.0.0", base.Collections[0].Version)
+	assert.Len(t, base.Collections, 1)
+}
+
+func TestValidate(t *testing.T) {
+
+	errContext := "(galaxy::Requirements::Validate)"
+
+	tests := []struct {
+		desc         string
+		requirements *Requirements
+		err          error
+	}{
+		{
+			desc: "Testing validate valid requirements",
+			requirements: &Requirements{
+				Collections: []*Collection{
+					{Name: "community.general", Version: ">=8.0.0", Signatures: []string{"https://example.com/community-general.asc"}},
+					{Name: "https://github.com/example/collection.git", Type: TypeGit, Version: "main"},
+				},
+				Roles: []*Role{
+					{Source: "geerlingguy.docker"},
+					{Name: "webserver", Source: "https://github.com/example/webserver.git", Scm: ScmGit},
+				},
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing validate invalid requirements",
+			requirements: &Requirements{
+				Collections: []*Collection{
+					{Version: "1.0.0"},
+					nil,
+					{Name: "collection.tar.gz", Type: "tarball"},
+					{Name: "/src/collection", Type: TypeDir, Signatures: []string{"file:///src/collection.asc"}},
+				},
+				Roles: []*Role{
+					{Version: "1.0.0"},
+					{Source: "https://svn.example.com/role", Scm: "svn"},
+				},
+			},
+			err: errors.New(errContext, "Invalid requirements",
+				fmt.Errorf("collection 0 has no name"),
+				fmt.Errorf("collection 1 is not defined"),
+				fmt.Errorf("collection 'collection.tar.gz' type must be one of 'dir', 'file', 'galaxy', 'git', 'subdirs', 'url', but 'tarball' was provided"),
+				fmt.Errorf("collection '/src/collection' signatures are only supported by 'galaxy' collections"),
+				fmt.Errorf("role 0 has neither name nor source"),
+				fmt.Errorf("role 'https://svn.example.com/role' scm must be one of 'git', 'hg', but 'svn' was provided"),
+			),
+		},
+		{
+			desc: "Testing validate nil requirements",
+			err:  errors.New(errContext, "Requirements is nil"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.requirements.Validate()
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package galaxyroleinfo
+
+import (
+	"fmt"
+
+	galaxy "github.com/apenella/go-ansible/v2/pkg/galaxy"
+	galaxyrole "github.com/apenella/go-ansible/v2/pkg/galaxy/role"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// AnsibleGalaxyRoleInfoSubCommand is the ansible-galaxy role info subcommand
+	AnsibleGalaxyRoleInfoSubCommand = "info"
+)
+
+// AnsibleGalaxyRoleInfoOptionsFunc is a function to set executor options
+type AnsibleGalaxyRoleInfoOptionsFunc func(*AnsibleGalaxyRoleInfoCmd)
+
+// AnsibleGalaxyRoleInfoCmd object is the main object which defines the `ansible-galaxy` command to show the details of roles.
+type AnsibleGalaxyRoleInfoCmd struct {
+	// Binary is the ansible-galaxy binary file
+	Binary string
+
+	// RoleNames is the ansible-galaxy's role names to get the details from. A role version can be set using the form name,version
+	RoleNames []string
+
+	// GalaxyRoleInfoOptions are the ansible-galaxy's role info options
+	GalaxyRoleInfoOptions *AnsibleGalaxyRoleInfoOptions
+
+	// SkipValidation disables the role info options validation when the command is generated
+	SkipValidation bool
+}
+
+// NewAnsibleGalaxyRoleInfoCmd creates a new AnsibleGalaxyRoleInfoCmd instance
+func NewAnsibleGalaxyRoleInfoCmd(options ...AnsibleGalaxyRoleInfoOptionsFunc) *AnsibleGalaxyRoleInfoCmd {
+	cmd := &AnsibleGalaxyRoleInfoCmd{}
+
+	for _, option := range options {
+		option(cmd)
+	}
+
+	return cmd
+}
+
+// WithBinary set the ansible-galaxy binary file
+func WithBinary(binary string) AnsibleGalaxyRoleInfoOptionsFunc {
+	return func(p *AnsibleGalaxyRoleInfoCmd) {
+		p.Binary = binary
+	}
+}
+
+// WithRoleNames set the ansible-galaxy role names to get the details from
+func WithRoleNames(roleNames ...string) AnsibleGalaxyRoleInfoOptionsFunc {
+	return func(p *AnsibleGalaxyRoleInfoCmd) {
+		p.RoleNames = append([]string{}, roleNames...)
+	}
+}
+
+// WithGalaxyRoleInfoOptions set the ansible-galaxy role info options
+func WithGalaxyRoleInfoOptions(options *AnsibleGalaxyRoleInfoOptions) AnsibleGalaxyRoleInfoOptionsFunc {
+	return func(p *AnsibleGalaxyRoleInfoCmd) {
+		p.GalaxyRoleInfoOptions = options
+	}
+}
+
+// WithoutValidation disables the ansible-galaxy role info options validation
+func WithoutValidation() AnsibleGalaxyRoleInfoOptionsFunc {
+	return func(p *AnsibleGalaxyRoleInfoCmd) {
+		p.SkipValidation = true
+	}
+}
+
+// Command generate the ansible-galaxy role info command which will be executed
+func (p *AnsibleGalaxyRoleInfoCmd) Command() ([]string, error) {
+	errContext := "(galaxy::AnsibleGalaxyRoleInfoCmd::Command)"
+	cmd := []string{}
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = galaxy.DefaultAnsibleGalaxyBinary
+	}
+
+	if len(p.RoleNames) == 0 {
+		return nil, errors.New(errContext, "No roles defined")
+	}
+
+	cmd = append(cmd, p.Binary, galaxyrole.AnsibleGalaxyRoleSubCommand, AnsibleGalaxyRoleInfoSubCommand)
+
+	// Add the options
+	if p.GalaxyRoleInfoOptions != nil {
+		if !p.SkipValidation {
+			err := p.GalaxyRoleInfoOptions.Validate()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		options, err := p.GalaxyRoleInfoOptions.GenerateCommandOptions()
+		if err != nil {
+			return nil, err
+		}
+		cmd = append(cmd, options...)
+	}
+
+	// Add the role names
+	cmd = append(cmd, p.RoleNames...)
+
+	return cmd, nil
+}
+
+// String returns the ansible-galaxy role info command as a string
+func (p *AnsibleGalaxyRoleInfoCmd) String() string {
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = galaxy.DefaultAnsibleGalaxyBinary
+	}
+
+	str := fmt.Sprintf("%s %s %s", p.Binary, galaxyrole.AnsibleGalaxyRoleSubCommand, AnsibleGalaxyRoleInfoSubCommand)
+
+	if p.GalaxyRoleInfoOptions != nil {
+		str = fmt.Sprintf("%s %s", str, p.GalaxyRoleInfoOptions.String())
+	}
+
+	// Include the role names
+	for _, roleName := range p.RoleNames {
+		str = fmt.Sprintf("%s %s", str, roleName)
+	}
+
+	return str
+}
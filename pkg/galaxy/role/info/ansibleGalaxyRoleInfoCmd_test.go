@@ -0,0 +1,149 @@
+package galaxyroleinfo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/galaxy"
+	galaxyrole "github.com/apenella/go-ansible/v2/pkg/galaxy/role"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleGalaxyRoleInfoCmd(t *testing.T) {
+	cmd := NewAnsibleGalaxyRoleInfoCmd(
+		WithBinary("ansible-galaxy-binary"),
+		WithRoleNames("geerlingguy.docker", "geerlingguy.pip,2.0.0"),
+		WithGalaxyRoleInfoOptions(&AnsibleGalaxyRoleInfoOptions{
+			APIKey: "apikey",
+		}),
+	)
+
+	expect := &AnsibleGalaxyRoleInfoCmd{
+		Binary:    "ansible-galaxy-binary",
+		RoleNames: []string{"geerlingguy.docker", "geerlingguy.pip,2.0.0"},
+		GalaxyRoleInfoOptions: &AnsibleGalaxyRoleInfoOptions{
+			APIKey: "apikey",
+		},
+	}
+
+	assert.Equal(t, expect, cmd)
+}
+
+func TestAnsibleGalaxyRoleInfoCmdCommand(t *testing.T) {
+
+	tests := []struct {
+		desc    string
+		cmd     *AnsibleGalaxyRoleInfoCmd
+		command []string
+		err     error
+	}{
+		{
+			desc: "Testing generate a command for AnsibleGalaxyRoleInfoCmd with all flags using default binary",
+			cmd: NewAnsibleGalaxyRoleInfoCmd(
+				WithoutValidation(),
+				WithRoleNames("geerlingguy.docker", "geerlingguy.pip,2.0.0"),
+				WithGalaxyRoleInfoOptions(&AnsibleGalaxyRoleInfoOptions{
+					APIKey:      "apikey",
+					IgnoreCerts: true,
+					Offline:     true,
+					RolesPath:   "path",
+					Server:      "server",
+					Timeout:     "10",
+					Token:       "token",
+					Verbose:     true,
+				}),
+			),
+			err: &errors.Error{},
+			command: []string{
+				galaxy.DefaultAnsibleGalaxyBinary,
+				galaxyrole.AnsibleGalaxyRoleSubCommand,
+				AnsibleGalaxyRoleInfoSubCommand,
+				fmt.Sprintf("%s=%s", APIKeyFlag, "apikey"),
+				IgnoreCertsFlag,
+				OfflineFlag,
+				fmt.Sprintf("%s=%s", RolesPathFlag, "path"),
+				fmt.Sprintf("%s=%s", ServerFlag, "server"),
+				fmt.Sprintf("%s=%s", TimeoutFlag, "10"),
+				fmt.Sprintf("%s=%s", TokenFlag, "token"),
+				VerboseFlag,
+				"geerlingguy.docker",
+				"geerlingguy.pip,2.0.0",
+			},
+		},
+		{
+			desc: "Testing error generating a command for AnsibleGalaxyRoleInfoCmd without role names",
+			cmd:  NewAnsibleGalaxyRoleInfoCmd(),
+			err:  errors.New("(galaxy::AnsibleGalaxyRoleInfoCmd::Command)", "No roles defined"),
+		},
+		{
+			desc: "Testing error generating a command for AnsibleGalaxyRoleInfoCmd with invalid options",
+			cmd: NewAnsibleGalaxyRoleInfoCmd(
+				WithRoleNames("geerlingguy.docker"),
+				WithGalaxyRoleInfoOptions(&AnsibleGalaxyRoleInfoOptions{
+					Offline: true,
+					Server:  "server",
+				}),
+			),
+			err: errors.New("(galaxy::AnsibleGalaxyRoleInfoOptions::Validate)", "Invalid ansible-galaxy role info options",
+				fmt.Errorf("'%s' is meaningless with '%s'", ServerFlag, OfflineFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			command, err := test.cmd.Command()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.command, command)
+			}
+		})
+	}
+}
+
+func TestAnsibleGalaxyRoleInfoCmdString(t *testing.T) {
+
+	tests := []struct {
+		desc string
+		cmd  *AnsibleGalaxyRoleInfoCmd
+		res  string
+	}{
+		{
+			desc: "Testing AnsibleGalaxyRoleInfoCmd to string with all flags",
+			cmd: NewAnsibleGalaxyRoleInfoCmd(
+				WithRoleNames("geerlingguy.docker", "geerlingguy.pip,2.0.0"),
+				WithGalaxyRoleInfoOptions(&AnsibleGalaxyRoleInfoOptions{
+					APIKey:      "apikey",
+					IgnoreCerts: true,
+					Offline:     true,
+					RolesPath:   "path",
+					Server:      "server",
+					Timeout:     "10",
+					Token:       "token",
+					Verbose:     true,
+				}),
+			),
+			res: "ansible-galaxy role info --api-key=apikey --ignore-certs --offline --roles-path=path --server=server --timeout=10 --token=token --verbose geerlingguy.docker geerlingguy.pip,2.0.0",
+		},
+		{
+			desc: "Testing AnsibleGalaxyRoleInfoCmd to string using a custom binary",
+			cmd: NewAnsibleGalaxyRoleInfoCmd(
+				WithBinary("custom-binary"),
+				WithRoleNames("geerlingguy.docker", "geerlingguy.pip,2.0.0"),
+			),
+			res: "custom-binary role info geerlingguy.docker geerlingguy.pip,2.0.0",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, test.cmd.String())
+		})
+	}
+}
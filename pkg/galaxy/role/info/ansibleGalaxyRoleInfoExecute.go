@@ -0,0 +1,67 @@
+package galaxyroleinfo
+
+import (
+	"context"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+)
+
+// AnsibleGalaxyRoleInfoExecute is an executor for ansible-galaxy role info command that runs the command using a DefaultExecute with default options
+type AnsibleGalaxyRoleInfoExecute struct {
+	cmd  *AnsibleGalaxyRoleInfoCmd
+	exec execute.Executabler
+}
+
+// NewAnsibleGalaxyRoleInfoExecute returns a new AnsibleGalaxyRoleInfoExecute. It receives the role names to get the details from
+func NewAnsibleGalaxyRoleInfoExecute(roleNames ...string) *AnsibleGalaxyRoleInfoExecute {
+
+	exec := &AnsibleGalaxyRoleInfoExecute{
+		cmd: &AnsibleGalaxyRoleInfoCmd{
+			RoleNames: append([]string{}, roleNames...),
+		},
+	}
+
+	return exec
+}
+
+// WithBinary returns an AnsibleGalaxyRoleInfoExecute with the binary file set
+func (e *AnsibleGalaxyRoleInfoExecute) WithBinary(binary string) *AnsibleGalaxyRoleInfoExecute {
+	e.cmd.Binary = binary
+
+	return e
+}
+
+// WithExecutable returns an AnsibleGalaxyRoleInfoExecute with the executable used to run the command set
+func (e *AnsibleGalaxyRoleInfoExecute) WithExecutable(executable execute.Executabler) *AnsibleGalaxyRoleInfoExecute {
+	e.exec = executable
+
+	return e
+}
+
+// WithGalaxyRoleInfoOptions returns an AnsibleGalaxyRoleInfoExecute with the ansible-galaxy role info options set
+func (e *AnsibleGalaxyRoleInfoExecute) WithGalaxyRoleInfoOptions(options *AnsibleGalaxyRoleInfoOptions) *AnsibleGalaxyRoleInfoExecute {
+	e.cmd.GalaxyRoleInfoOptions = options
+
+	return e
+}
+
+// Execute method runs the ansible-galaxy role info command using a DefaultExecute with default options
+func (e *AnsibleGalaxyRoleInfoExecute) Execute(ctx context.Context) error {
+
+	options := []execute.ExecuteOptions{
+		execute.WithCmd(e.cmd),
+	}
+
+	if e.exec != nil {
+		options = append(options, execute.WithExecutable(e.exec))
+	}
+
+	exec := execute.NewDefaultExecute(options...)
+
+	err := exec.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
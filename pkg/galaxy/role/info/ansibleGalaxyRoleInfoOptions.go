@@ -0,0 +1,180 @@
+package galaxyroleinfo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+
+	// APIKeyFlag the Ansible Galaxy API key. Same as --token
+	APIKeyFlag = "--api-key"
+
+	// IgnoreCertsFlag ignores SSL certificate validation errors.
+	IgnoreCertsFlag = "--ignore-certs"
+
+	// OfflineFlag doesn't query the galaxy API when getting the roles details.
+	OfflineFlag = "--offline"
+
+	// RolesPathFlag is the path to the directory containing your roles. The default is the first writable one configured via DEFAULT_ROLES_PATH.
+	RolesPathFlag = "--roles-path"
+
+	// ServerFlag is the Galaxy API server URL.
+	ServerFlag = "--server"
+
+	// TimeoutFlag is the time to wait for operations against the galaxy server, defaults to 60s.
+	TimeoutFlag = "--timeout"
+
+	// TokenFlag the Ansible Galaxy API key. Same as --api-key
+	TokenFlag = "--token"
+
+	// VerboseFlag verbose mode enabled
+	VerboseFlag = "--verbose"
+)
+
+// AnsibleGalaxyRoleInfoOptions are the ansible-galaxy role info options
+type AnsibleGalaxyRoleInfoOptions struct {
+
+	// APIKey is the Ansible Galaxy API key.
+	APIKey string
+
+	// IgnoreCerts ignores SSL certificate validation errors.
+	IgnoreCerts bool
+
+	// Offline doesn't query the galaxy API when getting the roles details.
+	Offline bool
+
+	// RolesPath is the path to the directory containing your roles. The default is the first writable one configured via DEFAULT_ROLES_PATH.
+	RolesPath string
+
+	// Server is the Galaxy API server URL.
+	Server string
+
+	// Timeout is the time to wait for operations against the galaxy server, defaults to 60s.
+	Timeout string
+
+	// Token is the Ansible Galaxy API key.
+	Token string
+
+	// Verbose verbose mode enabled
+	Verbose bool
+}
+
+// GenerateCommandOptions return a list of command options flags to be used on ansible-galaxy role info execution
+func (o *AnsibleGalaxyRoleInfoOptions) GenerateCommandOptions() ([]string, error) {
+	errContext := "(galaxy::AnsibleGalaxyRoleInfoOptions::GenerateCommandOptions)"
+	options := []string{}
+
+	if o == nil {
+		return nil, errors.New(errContext, "AnsibleGalaxyRoleInfoOptions is nil")
+	}
+
+	if o.APIKey != "" {
+		options = append(options, fmt.Sprintf("%s=%s", APIKeyFlag, o.APIKey))
+	}
+
+	if o.IgnoreCerts {
+		options = append(options, IgnoreCertsFlag)
+	}
+
+	if o.Offline {
+		options = append(options, OfflineFlag)
+	}
+
+	if o.RolesPath != "" {
+		options = append(options, fmt.Sprintf("%s=%s", RolesPathFlag, o.RolesPath))
+	}
+
+	if o.Server != "" {
+		options = append(options, fmt.Sprintf("%s=%s", ServerFlag, o.Server))
+	}
+
+	if o.Timeout != "" {
+		options = append(options, fmt.Sprintf("%s=%s", TimeoutFlag, o.Timeout))
+	}
+
+	if o.Token != "" {
+		options = append(options, fmt.Sprintf("%s=%s", TokenFlag, o.Token))
+	}
+
+	if o.Verbose {
+		options = append(options, VerboseFlag)
+	}
+
+	return options, nil
+}
+
+// Validate checks the options looking for mutually exclusive or meaningless flag combinations and unsupported values. It returns an error that wraps all the detected issues
+func (o *AnsibleGalaxyRoleInfoOptions) Validate() error {
+
+	errContext := "(galaxy::AnsibleGalaxyRoleInfoOptions::Validate)"
+	errs := []error{}
+
+	if o == nil {
+		return errors.New(errContext, "AnsibleGalaxyRoleInfoOptions is nil")
+	}
+
+	if o.APIKey != "" && o.Token != "" {
+		errs = append(errs, fmt.Errorf("'%s' and '%s' are mutually exclusive", APIKeyFlag, TokenFlag))
+	}
+
+	// offline requests never contact a galaxy server
+	if o.Offline && o.Server != "" {
+		errs = append(errs, fmt.Errorf("'%s' is meaningless with '%s'", ServerFlag, OfflineFlag))
+	}
+
+	if o.Timeout != "" {
+		timeout, err := strconv.Atoi(o.Timeout)
+		if err != nil || timeout < 1 {
+			errs = append(errs, fmt.Errorf("'%s' must be a positive integer, but '%s' was provided", TimeoutFlag, o.Timeout))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid ansible-galaxy role info options", errs...)
+	}
+
+	return nil
+}
+
+// String return a string representation of the AnsibleGalaxyRoleInfoOptions
+func (o *AnsibleGalaxyRoleInfoOptions) String() string {
+	str := ""
+
+	if o.APIKey != "" {
+		str = fmt.Sprintf("%s %s=%s", str, APIKeyFlag, o.APIKey)
+	}
+
+	if o.IgnoreCerts {
+		str = fmt.Sprintf("%s %s", str, IgnoreCertsFlag)
+	}
+
+	if o.Offline {
+		str = fmt.Sprintf("%s %s", str, OfflineFlag)
+	}
+
+	if o.RolesPath != "" {
+		str = fmt.Sprintf("%s %s=%s", str, RolesPathFlag, o.RolesPath)
+	}
+
+	if o.Server != "" {
+		str = fmt.Sprintf("%s %s=%s", str, ServerFlag, o.Server)
+	}
+
+	if o.Timeout != "" {
+		str = fmt.Sprintf("%s %s=%s", str, TimeoutFlag, o.Timeout)
+	}
+
+	if o.Token != "" {
+		str = fmt.Sprintf("%s %s=%s", str, TokenFlag, o.Token)
+	}
+
+	if o.Verbose {
+		str = fmt.Sprintf("%s %s", str, VerboseFlag)
+	}
+
+	return strings.TrimSpace(str)
+}
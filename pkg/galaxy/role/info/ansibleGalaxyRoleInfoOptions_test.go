@@ -0,0 +1,160 @@
+package galaxyroleinfo
+
+import (
+	"fmt"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnsibleGalaxyRoleInfoOptionsGenerateCommandOptions(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyRoleInfoOptions::GenerateCommandOptions)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyRoleInfoOptions
+		err     error
+		expect  []string
+	}{
+		{
+			desc:    "Testing nil AnsibleGalaxyRoleInfoOptions definition",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyRoleInfoOptions is nil"),
+		},
+		{
+			desc:    "Testing an empty AnsibleGalaxyRoleInfoOptions definition",
+			options: &AnsibleGalaxyRoleInfoOptions{},
+			expect:  []string{},
+		},
+		{
+			desc: "Testing AnsibleGalaxyRoleInfoOptions with all flags",
+			options: &AnsibleGalaxyRoleInfoOptions{
+				APIKey:      "apikey",
+				IgnoreCerts: true,
+				Offline:     true,
+				RolesPath:   "path",
+				Server:      "server",
+				Timeout:     "10",
+				Token:       "token",
+				Verbose:     true,
+			},
+			expect: []string{
+				fmt.Sprintf("%s=%s", APIKeyFlag, "apikey"),
+				IgnoreCertsFlag,
+				OfflineFlag,
+				fmt.Sprintf("%s=%s", RolesPathFlag, "path"),
+				fmt.Sprintf("%s=%s", ServerFlag, "server"),
+				fmt.Sprintf("%s=%s", TimeoutFlag, "10"),
+				fmt.Sprintf("%s=%s", TokenFlag, "token"),
+				VerboseFlag,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			options, err := test.options.GenerateCommandOptions()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.expect, options, "Unexpected options value")
+			}
+		})
+	}
+}
+
+func TestAnsibleGalaxyRoleInfoOptionsString(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyRoleInfoOptions
+		expect  string
+	}{
+		{
+			desc:    "Testing generate string from an empty AnsibleGalaxyRoleInfoOptions",
+			options: &AnsibleGalaxyRoleInfoOptions{},
+			expect:  "",
+		},
+		{
+			desc: "Testing generate string from an AnsibleGalaxyRoleInfoOptions with all flags",
+			options: &AnsibleGalaxyRoleInfoOptions{
+				APIKey:      "apikey",
+				IgnoreCerts: true,
+				Offline:     true,
+				RolesPath:   "path",
+				Server:      "server",
+				Timeout:     "10",
+				Token:       "token",
+				Verbose:     true,
+			},
+			expect: "--api-key=apikey --ignore-certs --offline --roles-path=path --server=server --timeout=10 --token=token --verbose",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expect, test.options.String())
+		})
+	}
+}
+
+func TestAnsibleGalaxyRoleInfoOptionsValidate(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyRoleInfoOptions::Validate)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyRoleInfoOptions
+		err     error
+	}{
+		{
+			desc:    "Testing validate nil AnsibleGalaxyRoleInfoOptions",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyRoleInfoOptions is nil"),
+		},
+		{
+			desc: "Testing validate valid AnsibleGalaxyRoleInfoOptions",
+			options: &AnsibleGalaxyRoleInfoOptions{
+				Offline:   true,
+				RolesPath: "path",
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing validate invalid AnsibleGalaxyRoleInfoOptions",
+			options: &AnsibleGalaxyRoleInfoOptions{
+				APIKey:  "apikey",
+				Timeout: "ten",
+				Token:   "token",
+			},
+			err: errors.New(errContext, "Invalid ansible-galaxy role info options",
+				fmt.Errorf("'%s' and '%s' are mutually exclusive", APIKeyFlag, TokenFlag),
+				fmt.Errorf("'%s' must be a positive integer, but 'ten' was provided", TimeoutFlag),
+			),
+		},
+		{
+			desc: "Testing validate AnsibleGalaxyRoleInfoOptions with a server on offline mode",
+			options: &AnsibleGalaxyRoleInfoOptions{
+				Offline: true,
+				Server:  "server",
+			},
+			err: errors.New(errContext, "Invalid ansible-galaxy role info options",
+				fmt.Errorf("'%s' is meaningless with '%s'", ServerFlag, OfflineFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.Validate()
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
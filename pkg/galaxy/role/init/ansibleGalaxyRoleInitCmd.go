@@ -0,0 +1,152 @@
+package galaxyroleinit
+
+import (
+	"fmt"
+
+	galaxy "github.com/apenella/go-ansible/v2/pkg/galaxy"
+	galaxyrole "github.com/apenella/go-ansible/v2/pkg/galaxy/role"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// AnsibleGalaxyRoleInitSubCommand is the ansible-galaxy role init subcommand
+	AnsibleGalaxyRoleInitSubCommand = "init"
+)
+
+// AnsibleGalaxyRoleInitOptionsFunc is a function to set executor options
+type AnsibleGalaxyRoleInitOptionsFunc func(*AnsibleGalaxyRoleInitCmd)
+
+// AnsibleGalaxyRoleInitCmd object is the main object which defines the `ansible-galaxy` command to create the skeleton of a new role.
+type AnsibleGalaxyRoleInitCmd struct {
+	// Binary is the ansible-galaxy binary file
+	Binary string
+
+	// RoleName is the name of the role to be created
+	RoleName string
+
+	// GalaxyRoleInitOptions are the ansible-galaxy's role init options
+	GalaxyRoleInitOptions *AnsibleGalaxyRoleInitOptions
+
+	// SkipValidation disables the role init options validation when the command is generated
+	SkipValidation bool
+	// FileValidation enables checking that the paths referenced by the options are directories when the command is generated
+	FileValidation bool
+	// FileValidationDir is the directory the relative paths are resolved against when the files are validated
+	FileValidationDir string
+}
+
+// NewAnsibleGalaxyRoleInitCmd creates a new AnsibleGalaxyRoleInitCmd instance
+func NewAnsibleGalaxyRoleInitCmd(options ...AnsibleGalaxyRoleInitOptionsFunc) *AnsibleGalaxyRoleInitCmd {
+	cmd := &AnsibleGalaxyRoleInitCmd{}
+
+	for _, option := range options {
+		option(cmd)
+	}
+
+	return cmd
+}
+
+// WithBinary set the ansible-galaxy binary file
+func WithBinary(binary string) AnsibleGalaxyRoleInitOptionsFunc {
+	return func(p *AnsibleGalaxyRoleInitCmd) {
+		p.Binary = binary
+	}
+}
+
+// WithRoleName set the name of the role to be created
+func WithRoleName(roleName string) AnsibleGalaxyRoleInitOptionsFunc {
+	return func(p *AnsibleGalaxyRoleInitCmd) {
+		p.RoleName = roleName
+	}
+}
+
+// WithGalaxyRoleInitOptions set the ansible-galaxy role init options
+func WithGalaxyRoleInitOptions(options *AnsibleGalaxyRoleInitOptions) AnsibleGalaxyRoleInitOptionsFunc {
+	return func(p *AnsibleGalaxyRoleInitCmd) {
+		p.GalaxyRoleInitOptions = options
+	}
+}
+
+// WithoutValidation disables the ansible-galaxy role init options validation
+func WithoutValidation() AnsibleGalaxyRoleInitOptionsFunc {
+	return func(p *AnsibleGalaxyRoleInitCmd) {
+		p.SkipValidation = true
+	}
+}
+
+// WithFileValidation enables checking that the paths referenced by the ansible-galaxy role init options are directories. The relative paths are resolved against dir, which is the directory where the command runs, or against the working directory when dir is empty. Do not enable it when the command runs on another host or container
+func WithFileValidation(dir string) AnsibleGalaxyRoleInitOptionsFunc {
+	return func(p *AnsibleGalaxyRoleInitCmd) {
+		p.FileValidation = true
+		p.FileValidationDir = dir
+	}
+}
+
+// Command generate the ansible-galaxy role init command which will be executed
+func (p *AnsibleGalaxyRoleInitCmd) Command() ([]string, error) {
+	errContext := "(galaxy::AnsibleGalaxyRoleInitCmd::Command)"
+	cmd := []string{}
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = galaxy.DefaultAnsibleGalaxyBinary
+	}
+
+	if p.RoleName == "" {
+		return nil, errors.New(errContext, "No role name defined")
+	}
+
+	cmd = append(cmd, p.Binary, galaxyrole.AnsibleGalaxyRoleSubCommand, AnsibleGalaxyRoleInitSubCommand)
+
+	// Add the options
+	if p.GalaxyRoleInitOptions != nil {
+		if !p.SkipValidation {
+			err := p.GalaxyRoleInitOptions.Validate()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if p.FileValidation {
+			err := p.GalaxyRoleInitOptions.ValidateFiles(p.FileValidationDir)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		options, err := p.GalaxyRoleInitOptions.GenerateCommandOptions()
+		if err != nil {
+			return nil, err
+		}
+		cmd = append(cmd, options...)
+	}
+
+	// Add the role name
+	if p.RoleName != "" {
+		cmd = append(cmd, p.RoleName)
+	}
+
+	return cmd, nil
+}
+
+// String returns the ansible-galaxy role init command as a string
+func (p *AnsibleGalaxyRoleInitCmd) String() string {
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = galaxy.DefaultAnsibleGalaxyBinary
+	}
+
+	str := fmt.Sprintf("%s %s %s", p.Binary, galaxyrole.AnsibleGalaxyRoleSubCommand, AnsibleGalaxyRoleInitSubCommand)
+
+	if p.GalaxyRoleInitOptions != nil {
+		str = fmt.Sprintf("%s %s", str, p.GalaxyRoleInitOptions.String())
+	}
+
+	// Include the role name
+	if p.RoleName != "" {
+		str = fmt.Sprintf("%s %s", str, p.RoleName)
+	}
+
+	return str
+}
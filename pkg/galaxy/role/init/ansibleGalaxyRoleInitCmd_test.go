@@ -0,0 +1,156 @@
+package galaxyroleinit
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/galaxy"
+	galaxyrole "github.com/apenella/go-ansible/v2/pkg/galaxy/role"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleGalaxyRoleInitCmd(t *testing.T) {
+	cmd := NewAnsibleGalaxyRoleInitCmd(
+		WithBinary("ansible-galaxy-binary"),
+		WithRoleName("webserver"),
+		WithGalaxyRoleInitOptions(&AnsibleGalaxyRoleInitOptions{
+			Force: true,
+		}),
+	)
+
+	expect := &AnsibleGalaxyRoleInitCmd{
+		Binary:   "ansible-galaxy-binary",
+		RoleName: "webserver",
+		GalaxyRoleInitOptions: &AnsibleGalaxyRoleInitOptions{
+			Force: true,
+		},
+	}
+
+	assert.Equal(t, expect, cmd)
+}
+
+func TestAnsibleGalaxyRoleInitCmdCommand(t *testing.T) {
+
+	tests := []struct {
+		desc    string
+		cmd     *AnsibleGalaxyRoleInitCmd
+		command []string
+		err     error
+	}{
+		{
+			desc: "Testing generate a command for AnsibleGalaxyRoleInitCmd with all flags using default binary",
+			cmd: NewAnsibleGalaxyRoleInitCmd(
+				WithoutValidation(),
+				WithRoleName("webserver"),
+				WithGalaxyRoleInitOptions(&AnsibleGalaxyRoleInitOptions{
+					APIKey:       "apikey",
+					Force:        true,
+					IgnoreCerts:  true,
+					InitPath:     "path",
+					Offline:      true,
+					RoleSkeleton: "skeleton",
+					Server:       "server",
+					Timeout:      "10",
+					Token:        "token",
+					Type:         "container",
+					Verbose:      true,
+				}),
+			),
+			err: &errors.Error{},
+			command: []string{
+				galaxy.DefaultAnsibleGalaxyBinary,
+				galaxyrole.AnsibleGalaxyRoleSubCommand,
+				AnsibleGalaxyRoleInitSubCommand,
+				fmt.Sprintf("%s=%s", APIKeyFlag, "apikey"),
+				ForceFlag,
+				IgnoreCertsFlag,
+				fmt.Sprintf("%s=%s", InitPathFlag, "path"),
+				OfflineFlag,
+				fmt.Sprintf("%s=%s", RoleSkeletonFlag, "skeleton"),
+				fmt.Sprintf("%s=%s", ServerFlag, "server"),
+				fmt.Sprintf("%s=%s", TimeoutFlag, "10"),
+				fmt.Sprintf("%s=%s", TokenFlag, "token"),
+				fmt.Sprintf("%s=%s", TypeFlag, "container"),
+				VerboseFlag,
+				"webserver",
+			},
+		},
+		{
+			desc: "Testing error generating a command for AnsibleGalaxyRoleInitCmd without role name",
+			cmd:  NewAnsibleGalaxyRoleInitCmd(),
+			err:  errors.New("(galaxy::AnsibleGalaxyRoleInitCmd::Command)", "No role name defined"),
+		},
+		{
+			desc: "Testing error generating a command for AnsibleGalaxyRoleInitCmd with invalid options",
+			cmd: NewAnsibleGalaxyRoleInitCmd(
+				WithRoleName("webserver"),
+				WithGalaxyRoleInitOptions(&AnsibleGalaxyRoleInitOptions{
+					Type: "unknown",
+				}),
+			),
+			err: errors.New("(galaxy::AnsibleGalaxyRoleInitOptions::Validate)", "Invalid ansible-galaxy role init options",
+				fmt.Errorf("'%s' must be one of 'apb', 'container', 'network', but 'unknown' was provided", TypeFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			command, err := test.cmd.Command()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.command, command)
+			}
+		})
+	}
+}
+
+func TestAnsibleGalaxyRoleInitCmdString(t *testing.T) {
+
+	tests := []struct {
+		desc string
+		cmd  *AnsibleGalaxyRoleInitCmd
+		res  string
+	}{
+		{
+			desc: "Testing AnsibleGalaxyRoleInitCmd to string with all flags",
+			cmd: NewAnsibleGalaxyRoleInitCmd(
+				WithRoleName("webserver"),
+				WithGalaxyRoleInitOptions(&AnsibleGalaxyRoleInitOptions{
+					APIKey:       "apikey",
+					Force:        true,
+					IgnoreCerts:  true,
+					InitPath:     "path",
+					Offline:      true,
+					RoleSkeleton: "skeleton",
+					Server:       "server",
+					Timeout:      "10",
+					Token:        "token",
+					Type:         "container",
+					Verbose:      true,
+				}),
+			),
+			res: "ansible-galaxy role init --api-key=apikey --force --ignore-certs --init-path=path --offline --role-skeleton=skeleton --server=server --timeout=10 --token=token --type=container --verbose webserver",
+		},
+		{
+			desc: "Testing AnsibleGalaxyRoleInitCmd to string using a custom binary",
+			cmd: NewAnsibleGalaxyRoleInitCmd(
+				WithBinary("custom-binary"),
+				WithRoleName("webserver"),
+			),
+			res: "custom-binary role init webserver",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, test.cmd.String())
+		})
+	}
+}
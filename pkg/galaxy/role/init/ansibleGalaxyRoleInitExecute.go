@@ -0,0 +1,67 @@
+package galaxyroleinit
+
+import (
+	"context"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+)
+
+// AnsibleGalaxyRoleInitExecute is an executor for ansible-galaxy role init command that runs the command using a DefaultExecute with default options
+type AnsibleGalaxyRoleInitExecute struct {
+	cmd  *AnsibleGalaxyRoleInitCmd
+	exec execute.Executabler
+}
+
+// NewAnsibleGalaxyRoleInitExecute returns a new AnsibleGalaxyRoleInitExecute. It receives the name of the role to create
+func NewAnsibleGalaxyRoleInitExecute(roleName string) *AnsibleGalaxyRoleInitExecute {
+
+	exec := &AnsibleGalaxyRoleInitExecute{
+		cmd: &AnsibleGalaxyRoleInitCmd{
+			RoleName: roleName,
+		},
+	}
+
+	return exec
+}
+
+// WithBinary returns an AnsibleGalaxyRoleInitExecute with the binary file set
+func (e *AnsibleGalaxyRoleInitExecute) WithBinary(binary string) *AnsibleGalaxyRoleInitExecute {
+	e.cmd.Binary = binary
+
+	return e
+}
+
+// WithExecutable returns an AnsibleGalaxyRoleInitExecute with the executable used to run the command set
+func (e *AnsibleGalaxyRoleInitExecute) WithExecutable(executable execute.Executabler) *AnsibleGalaxyRoleInitExecute {
+	e.exec = executable
+
+	return e
+}
+
+// WithGalaxyRoleInitOptions returns an AnsibleGalaxyRoleInitExecute with the ansible-galaxy role init options set
+func (e *AnsibleGalaxyRoleInitExecute) WithGalaxyRoleInitOptions(options *AnsibleGalaxyRoleInitOptions) *AnsibleGalaxyRoleInitExecute {
+	e.cmd.GalaxyRoleInitOptions = options
+
+	return e
+}
+
+// Execute method runs the ansible-galaxy role init command using a DefaultExecute with default options
+func (e *AnsibleGalaxyRoleInitExecute) Execute(ctx context.Context) error {
+
+	options := []execute.ExecuteOptions{
+		execute.WithCmd(e.cmd),
+	}
+
+	if e.exec != nil {
+		options = append(options, execute.WithExecutable(e.exec))
+	}
+
+	exec := execute.NewDefaultExecute(options...)
+
+	err := exec.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
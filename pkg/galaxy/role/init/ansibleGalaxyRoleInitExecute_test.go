@@ -0,0 +1,51 @@
+package galaxyroleinit
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleGalaxyRoleInitExecute(t *testing.T) {
+	expect := &AnsibleGalaxyRoleInitExecute{
+		cmd: &AnsibleGalaxyRoleInitCmd{
+			RoleName: "webserver",
+		},
+	}
+
+	res := NewAnsibleGalaxyRoleInitExecute("webserver")
+
+	assert.Equal(t, expect, res)
+}
+
+func TestAnsibleGalaxyRoleInitExecuteExecute(t *testing.T) {
+
+	e := exec.NewMockExec()
+	cmd := exec.NewMockCmd()
+
+	cmd.On("StdoutPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+	cmd.On("StderrPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+	cmd.On("Start").Return(nil)
+	cmd.On("Wait").Return(nil)
+	// the compatibility check detects the ansible version, unless it is already cached
+	versionCmd := exec.NewMockCmd()
+	versionCmd.On("Output").Return([]byte("ansible-galaxy [core 2.16.3]\n"), nil).Maybe()
+	e.On("CommandContext", context.TODO(), "custom-binary", []string{"--version"}).Return(versionCmd).Maybe()
+	e.On("CommandContext", context.TODO(), "custom-binary", []string{"role", "init", "--force", "webserver"}).Return(cmd)
+
+	err := NewAnsibleGalaxyRoleInitExecute("webserver").
+		WithBinary("custom-binary").
+		WithExecutable(e).
+		WithGalaxyRoleInitOptions(&AnsibleGalaxyRoleInitOptions{
+			Force: true,
+		}).
+		Execute(context.TODO())
+
+	assert.NoError(t, err)
+	e.AssertExpectations(t)
+	cmd.AssertExpectations(t)
+}
@@ -0,0 +1,279 @@
+package galaxyroleinit
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/apenella/go-ansible/v2/pkg/internal/validation"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+
+	// APIKeyFlag the Ansible Galaxy API key. Same as --token
+	APIKeyFlag = "--api-key"
+
+	// ForceFlag forces overwriting an existing role.
+	ForceFlag = "--force"
+
+	// IgnoreCertsFlag ignores SSL certificate validation errors.
+	IgnoreCertsFlag = "--ignore-certs"
+
+	// InitPathFlag is the path in which the skeleton role will be created. The default is the current working directory.
+	InitPathFlag = "--init-path"
+
+	// OfflineFlag doesn't query the galaxy API when creating roles.
+	OfflineFlag = "--offline"
+
+	// RoleSkeletonFlag is the path to a role skeleton that the new role should be based upon.
+	RoleSkeletonFlag = "--role-skeleton"
+
+	// ServerFlag is the Galaxy API server URL.
+	ServerFlag = "--server"
+
+	// TimeoutFlag is the time to wait for operations against the galaxy server, defaults to 60s.
+	TimeoutFlag = "--timeout"
+
+	// TokenFlag the Ansible Galaxy API key. Same as --api-key
+	TokenFlag = "--token"
+
+	// TypeFlag initializes using an alternate role type. Valid types include: container, apb and network.
+	TypeFlag = "--type"
+
+	// VerboseFlag verbose mode enabled
+	VerboseFlag = "--verbose"
+
+	// TypeAPB is the role type to create an Ansible Playbook Bundle
+	TypeAPB = "apb"
+
+	// TypeContainer is the role type to create a container enabled role
+	TypeContainer = "container"
+
+	// TypeNetwork is the role type to create a network role
+	TypeNetwork = "network"
+)
+
+// RoleTypes are the role types that can be set on the type option
+var RoleTypes = []string{TypeAPB, TypeContainer, TypeNetwork}
+
+// AnsibleGalaxyRoleInitOptions are the ansible-galaxy role init options
+type AnsibleGalaxyRoleInitOptions struct {
+
+	// APIKey is the Ansible Galaxy API key.
+	APIKey string
+
+	// Force forces overwriting an existing role.
+	Force bool
+
+	// IgnoreCerts ignores SSL certificate validation errors.
+	IgnoreCerts bool
+
+	// InitPath is the path in which the skeleton role will be created. The default is the current working directory.
+	InitPath string
+
+	// Offline doesn't query the galaxy API when creating roles.
+	Offline bool
+
+	// RoleSkeleton is the path to a role skeleton that the new role should be based upon.
+	RoleSkeleton string
+
+	// Server is the Galaxy API server URL.
+	Server string
+
+	// Timeout is the time to wait for operations against the galaxy server, defaults to 60s.
+	Timeout string
+
+	// Token is the Ansible Galaxy API key.
+	Token string
+
+	// Type initializes using an alternate role type. Valid types include: container, apb and network.
+	Type string
+
+	// Verbose verbose mode enabled
+	Verbose bool
+}
+
+// GenerateCommandOptions return a list of command options flags to be used on ansible-galaxy role init execution
+func (o *AnsibleGalaxyRoleInitOptions) GenerateCommandOptions() ([]string, error) {
+	errContext := "(galaxy::AnsibleGalaxyRoleInitOptions::GenerateCommandOptions)"
+	options := []string{}
+
+	if o == nil {
+		return nil, errors.New(errContext, "AnsibleGalaxyRoleInitOptions is nil")
+	}
+
+	if o.APIKey != "" {
+		options = append(options, fmt.Sprintf("%s=%s", APIKeyFlag, o.APIKey))
+	}
+
+	if o.Force {
+		options = append(options, ForceFlag)
+	}
+
+	if o.IgnoreCerts {
+		options = append(options, IgnoreCertsFlag)
+	}
+
+	if o.InitPath != "" {
+		options = append(options, fmt.Sprintf("%s=%s", InitPathFlag, o.InitPath))
+	}
+
+	if o.Offline {
+		options = append(options, OfflineFlag)
+	}
+
+	if o.RoleSkeleton != "" {
+		options = append(options, fmt.Sprintf("%s=%s", RoleSkeletonFlag, o.RoleSkeleton))
+	}
+
+	if o.Server != "" {
+		options = append(options, fmt.Sprintf("%s=%s", ServerFlag, o.Server))
+	}
+
+	if o.Timeout != "" {
+		options = append(options, fmt.Sprintf("%s=%s", TimeoutFlag, o.Timeout))
+	}
+
+	if o.Token != "" {
+		options = append(options, fmt.Sprintf("%s=%s", TokenFlag, o.Token))
+	}
+
+	if o.Type != "" {
+		options = append(options, fmt.Sprintf("%s=%s", TypeFlag, o.Type))
+	}
+
+	if o.Verbose {
+		options = append(options, VerboseFlag)
+	}
+
+	return options, nil
+}
+
+// Validate checks the options looking for mutually exclusive flags and unsupported values. It returns an error that wraps all the detected issues
+func (o *AnsibleGalaxyRoleInitOptions) Validate() error {
+
+	errContext := "(galaxy::AnsibleGalaxyRoleInitOptions::Validate)"
+	errs := []error{}
+
+	if o == nil {
+		return errors.New(errContext, "AnsibleGalaxyRoleInitOptions is nil")
+	}
+
+	if o.APIKey != "" && o.Token != "" {
+		errs = append(errs, fmt.Errorf("'%s' and '%s' are mutually exclusive", APIKeyFlag, TokenFlag))
+	}
+
+	if o.Timeout != "" {
+		timeout, err := strconv.Atoi(o.Timeout)
+		if err != nil || timeout < 1 {
+			errs = append(errs, fmt.Errorf("'%s' must be a positive integer, but '%s' was provided", TimeoutFlag, o.Timeout))
+		}
+	}
+
+	if o.Type != "" && !isRoleType(o.Type) {
+		errs = append(errs, fmt.Errorf("'%s' must be one of '%s', but '%s' was provided", TypeFlag, strings.Join(RoleTypes, "', '"), o.Type))
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid ansible-galaxy role init options", errs...)
+	}
+
+	return nil
+}
+
+// ValidateFiles checks that the paths referenced by the options are directories. The relative paths are resolved against dir, which is the directory where the command runs, or against the working directory when dir is empty. It is not part of Validate because the command may run on another directory, host or container
+func (o *AnsibleGalaxyRoleInitOptions) ValidateFiles(dir string) error {
+	errContext := "(galaxy::AnsibleGalaxyRoleInitOptions::ValidateFiles)"
+	errs := []error{}
+
+	if o == nil {
+		return errors.New(errContext, "AnsibleGalaxyRoleInitOptions is nil")
+	}
+
+	if o.RoleSkeleton != "" {
+		info, err := os.Stat(validation.Path(dir, o.RoleSkeleton))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("'%s' directory is not valid: %w", RoleSkeletonFlag, err))
+		} else if !info.IsDir() {
+			errs = append(errs, fmt.Errorf("'%s' must be a directory, but '%s' is a file", RoleSkeletonFlag, o.RoleSkeleton))
+		}
+	}
+
+	// the init path is created when it does not exist, but it can not be a file
+	if o.InitPath != "" {
+		info, err := os.Stat(validation.Path(dir, o.InitPath))
+		if err == nil && !info.IsDir() {
+			errs = append(errs, fmt.Errorf("'%s' must be a directory, but '%s' is a file", InitPathFlag, o.InitPath))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid ansible-galaxy role init option files", errs...)
+	}
+
+	return nil
+}
+
+// isRoleType returns whether roleType is one of the RoleTypes
+func isRoleType(roleType string) bool {
+	for _, t := range RoleTypes {
+		if t == roleType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String return a string representation of the AnsibleGalaxyRoleInitOptions
+func (o *AnsibleGalaxyRoleInitOptions) String() string {
+	str := ""
+
+	if o.APIKey != "" {
+		str = fmt.Sprintf("%s %s=%s", str, APIKeyFlag, o.APIKey)
+	}
+
+	if o.Force {
+		str = fmt.Sprintf("%s %s", str, ForceFlag)
+	}
+
+	if o.IgnoreCerts {
+		str = fmt.Sprintf("%s %s", str, IgnoreCertsFlag)
+	}
+
+	if o.InitPath != "" {
+		str = fmt.Sprintf("%s %s=%s", str, InitPathFlag, o.InitPath)
+	}
+
+	if o.Offline {
+		str = fmt.Sprintf("%s %s", str, OfflineFlag)
+	}
+
+	if o.RoleSkeleton != "" {
+		str = fmt.Sprintf("%s %s=%s", str, RoleSkeletonFlag, o.RoleSkeleton)
+	}
+
+	if o.Server != "" {
+		str = fmt.Sprintf("%s %s=%s", str, ServerFlag, o.Server)
+	}
+
+	if o.Timeout != "" {
+		str = fmt.Sprintf("%s %s=%s", str, TimeoutFlag, o.Timeout)
+	}
+
+	if o.Token != "" {
+		str = fmt.Sprintf("%s %s=%s", str, TokenFlag, o.Token)
+	}
+
+	if o.Type != "" {
+		str = fmt.Sprintf("%s %s=%s", str, TypeFlag, o.Type)
+	}
+
+	if o.Verbose {
+		str = fmt.Sprintf("%s %s", str, VerboseFlag)
+	}
+
+	return strings.TrimSpace(str)
+}
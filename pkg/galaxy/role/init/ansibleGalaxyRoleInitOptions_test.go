@@ -0,0 +1,230 @@
+package galaxyroleinit
+
+import (
+	"fmt"
+	"io/fs"
+	"syscall"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnsibleGalaxyRoleInitOptionsGenerateCommandOptions(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyRoleInitOptions::GenerateCommandOptions)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyRoleInitOptions
+		err     error
+		expect  []string
+	}{
+		{
+			desc:    "Testing nil AnsibleGalaxyRoleInitOptions definition",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyRoleInitOptions is nil"),
+		},
+		{
+			desc:    "Testing an empty AnsibleGalaxyRoleInitOptions definition",
+			options: &AnsibleGalaxyRoleInitOptions{},
+			expect:  []string{},
+		},
+		{
+			desc: "Testing AnsibleGalaxyRoleInitOptions with all flags",
+			options: &AnsibleGalaxyRoleInitOptions{
+				APIKey:       "apikey",
+				Force:        true,
+				IgnoreCerts:  true,
+				InitPath:     "path",
+				Offline:      true,
+				RoleSkeleton: "skeleton",
+				Server:       "server",
+				Timeout:      "10",
+				Token:        "token",
+				Type:         "container",
+				Verbose:      true,
+			},
+			expect: []string{
+				fmt.Sprintf("%s=%s", APIKeyFlag, "apikey"),
+				ForceFlag,
+				IgnoreCertsFlag,
+				fmt.Sprintf("%s=%s", InitPathFlag, "path"),
+				OfflineFlag,
+				fmt.Sprintf("%s=%s", RoleSkeletonFlag, "skeleton"),
+				fmt.Sprintf("%s=%s", ServerFlag, "server"),
+				fmt.Sprintf("%s=%s", TimeoutFlag, "10"),
+				fmt.Sprintf("%s=%s", TokenFlag, "token"),
+				fmt.Sprintf("%s=%s", TypeFlag, "container"),
+				VerboseFlag,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			options, err := test.options.GenerateCommandOptions()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.expect, options, "Unexpected options value")
+			}
+		})
+	}
+}
+
+func TestAnsibleGalaxyRoleInitOptionsString(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyRoleInitOptions
+		expect  string
+	}{
+		{
+			desc:    "Testing generate string from an empty AnsibleGalaxyRoleInitOptions",
+			options: &AnsibleGalaxyRoleInitOptions{},
+			expect:  "",
+		},
+		{
+			desc: "Testing generate string from an AnsibleGalaxyRoleInitOptions with all flags",
+			options: &AnsibleGalaxyRoleInitOptions{
+				APIKey:       "apikey",
+				Force:        true,
+				IgnoreCerts:  true,
+				InitPath:     "path",
+				Offline:      true,
+				RoleSkeleton: "skeleton",
+				Server:       "server",
+				Timeout:      "10",
+				Token:        "token",
+				Type:         "container",
+				Verbose:      true,
+			},
+			expect: "--api-key=apikey --force --ignore-certs --init-path=path --offline --role-skeleton=skeleton --server=server --timeout=10 --token=token --type=container --verbose",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expect, test.options.String())
+		})
+	}
+}
+
+func TestAnsibleGalaxyRoleInitOptionsValidate(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyRoleInitOptions::Validate)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyRoleInitOptions
+		err     error
+	}{
+		{
+			desc:    "Testing validate nil AnsibleGalaxyRoleInitOptions",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyRoleInitOptions is nil"),
+		},
+		{
+			desc: "Testing validate valid AnsibleGalaxyRoleInitOptions",
+			options: &AnsibleGalaxyRoleInitOptions{
+				InitPath:     "nonexistent-path",
+				RoleSkeleton: ".",
+				Type:         TypeNetwork,
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing validate invalid AnsibleGalaxyRoleInitOptions",
+			options: &AnsibleGalaxyRoleInitOptions{
+				APIKey:       "apikey",
+				InitPath:     "ansibleGalaxyRoleInitOptions.go",
+				RoleSkeleton: "nonexistent-skeleton",
+				Timeout:      "0",
+				Token:        "token",
+				Type:         "unknown",
+			},
+			err: errors.New(errContext, "Invalid ansible-galaxy role init options",
+				fmt.Errorf("'%s' and '%s' are mutually exclusive", APIKeyFlag, TokenFlag),
+				fmt.Errorf("'%s' must be a positive integer, but '0' was provided", TimeoutFlag),
+				fmt.Errorf("'%s' must be one of 'apb', 'container', 'network', but 'unknown' was provided", TypeFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.Validate()
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+func TestAnsibleGalaxyRoleInitOptionsValidateFiles(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyRoleInitOptions::ValidateFiles)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyRoleInitOptions
+		dir     string
+		err     error
+	}{
+		{
+			desc:    "Testing validate files of nil AnsibleGalaxyRoleInitOptions",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyRoleInitOptions is nil"),
+		},
+		{
+			desc: "Testing validate files of valid AnsibleGalaxyRoleInitOptions",
+			options: &AnsibleGalaxyRoleInitOptions{
+				InitPath:     "nonexistent-path",
+				RoleSkeleton: ".",
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing validate files of AnsibleGalaxyRoleInitOptions resolving the relative paths against the directory",
+			options: &AnsibleGalaxyRoleInitOptions{
+				InitPath:     "init",
+				RoleSkeleton: "init",
+			},
+			dir: "..",
+			err: nil,
+		},
+		{
+			desc: "Testing validate files of invalid AnsibleGalaxyRoleInitOptions",
+			options: &AnsibleGalaxyRoleInitOptions{
+				InitPath:     "ansibleGalaxyRoleInitOptions.go",
+				RoleSkeleton: "nonexistent-skeleton",
+			},
+			err: errors.New(errContext, "Invalid ansible-galaxy role init option files",
+				fmt.Errorf("'%s' directory is not valid: %w", RoleSkeletonFlag, &fs.PathError{Op: "stat", Path: "nonexistent-skeleton", Err: syscall.ENOENT}),
+				fmt.Errorf("'%s' must be a directory, but 'ansibleGalaxyRoleInitOptions.go' is a file", InitPathFlag),
+			),
+		},
+		{
+			desc: "Testing validate files of AnsibleGalaxyRoleInitOptions with a skeleton that is a file",
+			options: &AnsibleGalaxyRoleInitOptions{
+				RoleSkeleton: "ansibleGalaxyRoleInitOptions.go",
+			},
+			err: errors.New(errContext, "Invalid ansible-galaxy role init option files",
+				fmt.Errorf("'%s' must be a directory, but 'ansibleGalaxyRoleInitOptions.go' is a file", RoleSkeletonFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.ValidateFiles(test.dir)
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
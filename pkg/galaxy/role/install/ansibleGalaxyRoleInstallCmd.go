@@ -31,6 +31,13 @@ type AnsibleGalaxyRoleInstallCmd struct {
 
 	// GalaxyRoleInstallOptions are the ansible-galaxy's role install options
 	GalaxyRoleInstallOptions *AnsibleGalaxyRoleInstallOptions
+
+	// SkipValidation disables the role install options validation when the command is generated
+	SkipValidation bool
+	// FileValidation enables checking that the files referenced by the options exist when the command is generated
+	FileValidation bool
+	// FileValidationDir is the directory the relative files are resolved against when the files are validated
+	FileValidationDir string
 }
 
 // NewAnsibleGalaxyRoleInstallCmd creates a new AnsibleGalaxyRoleInstallCmd instance
@@ -65,6 +72,21 @@ func WithRoleNames(roleNames ...string) AnsibleGalaxyRoleInstallOptionsFunc {
 	}
 }
 
+// WithoutValidation disables the ansible-galaxy role install options validation
+func WithoutValidation() AnsibleGalaxyRoleInstallOptionsFunc {
+	return func(p *AnsibleGalaxyRoleInstallCmd) {
+		p.SkipValidation = true
+	}
+}
+
+// WithFileValidation enables checking that the files referenced by the ansible-galaxy role install options exist. The relative files are resolved against dir, which is the directory where the command runs, or against the working directory when dir is empty. Do not enable it when the command runs on another host or container
+func WithFileValidation(dir string) AnsibleGalaxyRoleInstallOptionsFunc {
+	return func(p *AnsibleGalaxyRoleInstallCmd) {
+		p.FileValidation = true
+		p.FileValidationDir = dir
+	}
+}
+
 // Command generate the ansible-galaxy role install command which will be executed
 func (p *AnsibleGalaxyRoleInstallCmd) Command() ([]string, error) {
 	cmd := []string{}
@@ -78,6 +100,20 @@ func (p *AnsibleGalaxyRoleInstallCmd) Command() ([]string, error) {
 
 	// Add the options
 	if p.GalaxyRoleInstallOptions != nil {
+		if !p.SkipValidation {
+			err := p.GalaxyRoleInstallOptions.Validate()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if p.FileValidation {
+			err := p.GalaxyRoleInstallOptions.ValidateFiles(p.FileValidationDir)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		options, err := p.GalaxyRoleInstallOptions.GenerateCommandOptions()
 		if err != nil {
 			return nil, err
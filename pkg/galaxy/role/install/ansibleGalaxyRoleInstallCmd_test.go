@@ -41,6 +41,7 @@ func TestAnsibleGalaxyRoleInstallCmdCommand(t *testing.T) {
 		{
 			desc: "Testing generate a command for AnsibleGalaxyRoleInstallCmd with all flags using default binary",
 			cmd: NewAnsibleGalaxyRoleInstallCmd(
+				WithoutValidation(),
 				WithRoleNames("role-name"),
 				WithGalaxyRoleInstallOptions(&AnsibleGalaxyRoleInstallOptions{
 					ApiKey:        "apikey",
@@ -88,6 +89,7 @@ func TestAnsibleGalaxyRoleInstallCmdCommand(t *testing.T) {
 		{
 			desc: "Testing generate a command for AnsibleGalaxyRoleInstallCmd with all flags",
 			cmd: NewAnsibleGalaxyRoleInstallCmd(
+				WithoutValidation(),
 				WithBinary("ansible-galaxy-binary"),
 				WithRoleNames("role-name"),
 				WithGalaxyRoleInstallOptions(&AnsibleGalaxyRoleInstallOptions{
@@ -160,6 +162,7 @@ func TestAnsibleGalaxyRoleInstallCmdString(t *testing.T) {
 		{
 			desc: "Testing generate a command for AnsibleGalaxyRoleInstallCmd with all flags using default binary",
 			cmd: NewAnsibleGalaxyRoleInstallCmd(
+				WithoutValidation(),
 				WithRoleNames("role-name"),
 				WithGalaxyRoleInstallOptions(&AnsibleGalaxyRoleInstallOptions{
 					ApiKey:        "apikey",
@@ -187,6 +190,7 @@ func TestAnsibleGalaxyRoleInstallCmdString(t *testing.T) {
 		{
 			desc: "Testing generate a command for AnsibleGalaxyRoleInstallCmd with all flags",
 			cmd: NewAnsibleGalaxyRoleInstallCmd(
+				WithoutValidation(),
 				WithBinary("ansible-galaxy-binary"),
 				WithRoleNames("role-name"),
 				WithGalaxyRoleInstallOptions(&AnsibleGalaxyRoleInstallOptions{
@@ -2,7 +2,9 @@ package galaxyroleinstall
 
 import (
 	"fmt"
+	"strconv"
 
+	"github.com/apenella/go-ansible/v2/pkg/internal/validation"
 	errors "github.com/apenella/go-common-utils/error"
 )
 
@@ -195,6 +197,75 @@ func (o *AnsibleGalaxyRoleInstallOptions) GenerateCommandOptions() ([]string, er
 	return options, nil
 }
 
+// Validate checks the options looking for mutually exclusive or meaningless flag combinations and unsupported values. It returns an error that wraps all the detected issues
+func (o *AnsibleGalaxyRoleInstallOptions) Validate() error {
+
+	errContext := "(galaxy::AnsibleGalaxyRoleInstallOptions::Validate)"
+	errs := []error{}
+
+	if o == nil {
+		return errors.New(errContext, "AnsibleGalaxyRoleInstallOptions is nil")
+	}
+
+	verbosityFlags := 0
+	for _, enabled := range []bool{o.Verbose, o.VerboseV, o.VerboseVV, o.VerboseVVV, o.VerboseVVVV} {
+		if enabled {
+			verbosityFlags++
+		}
+	}
+	if verbosityFlags > 1 {
+		errs = append(errs, fmt.Errorf("only one verbosity level can be set, but %d were set", verbosityFlags))
+	}
+
+	if o.ApiKey != "" && o.Token != "" {
+		errs = append(errs, fmt.Errorf("'%s' and '%s' are mutually exclusive", APIKeyFlag, TokenFlag))
+	}
+
+	if o.Force && o.ForceWithDeps {
+		errs = append(errs, fmt.Errorf("'%s' is meaningless with '%s'", ForceFlag, ForceWithDepsFlag))
+	}
+
+	if o.NoDeps && o.ForceWithDeps {
+		errs = append(errs, fmt.Errorf("'%s' and '%s' are mutually exclusive", NoDepsFlag, ForceWithDepsFlag))
+	}
+
+	if o.Timeout != "" {
+		timeout, err := strconv.Atoi(o.Timeout)
+		if err != nil || timeout < 1 {
+			errs = append(errs, fmt.Errorf("'%s' must be a positive integer, but '%s' was provided", TimeoutFlag, o.Timeout))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid ansible-galaxy role install options", errs...)
+	}
+
+	return nil
+}
+
+// ValidateFiles checks that the files referenced by the options exist and are not directories. The relative files are resolved against dir, which is the directory where the command runs, or against the working directory when dir is empty. It is not part of Validate because the command may run on another directory, host or container
+func (o *AnsibleGalaxyRoleInstallOptions) ValidateFiles(dir string) error {
+	errContext := "(galaxy::AnsibleGalaxyRoleInstallOptions::ValidateFiles)"
+	errs := []error{}
+
+	if o == nil {
+		return errors.New(errContext, "AnsibleGalaxyRoleInstallOptions is nil")
+	}
+
+	if o.RoleFile != "" {
+		err := validation.FileExists(dir, o.RoleFile)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("'%s' file is not valid: %w", RoleFileFlag, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid ansible-galaxy role install option files", errs...)
+	}
+
+	return nil
+}
+
 // generateVerbosityFlag return a string with the verbose flag. Higher verbosity (more v's) has precedence over lower
 func (o *AnsibleGalaxyRoleInstallOptions) generateVerbosityFlag() (string, error) {
 	if o.Verbose {
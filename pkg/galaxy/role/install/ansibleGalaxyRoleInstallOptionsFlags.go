@@ -0,0 +1,99 @@
+package galaxyroleinstall
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// verboseFlag is the long name of the verbose flag, whose constants are the -v shorthands
+const verboseFlag = "--verbose"
+
+// flagUsages relates the AnsibleGalaxyRoleInstallOptions flags to their help text, copied from the ansible help. Every flag constant must have an entry
+var flagUsages = map[string]string{
+	APIKeyFlag:        "the API key to use to authenticate against the galaxy server",
+	ForceFlag:         "force overwriting an existing role or role file",
+	ForceWithDepsFlag: "force overwriting an existing role, role file, or dependencies",
+	IgnoreCertsFlag:   "ignore SSL certificate validation errors",
+	IgnoreErrorsFlag:  "continue processing even if a role fails to install",
+	KeepSCMMetaFlag:   "use tar instead of the scm archive option when packaging the role",
+	NoDepsFlag:        "don't download roles listed as dependencies",
+	RoleFileFlag:      "a file containing a list of roles to install",
+	RolesPathFlag:     "the path where roles should be installed on the local filesystem",
+	ServerFlag:        "the Galaxy API server URL",
+	TimeoutFlag:       "the time to wait for operations against the galaxy server, defaults to 60s",
+	TokenFlag:         "the token to use to authenticate against the galaxy server",
+	verboseFlag:       "verbose mode (-vvv for more, -vvvv to enable connection debugging)",
+	VersionFlag:       "show program's version number, config file location, configured module search path, module location, executable location and exit",
+}
+
+// AddFlags registers the AnsibleGalaxyRoleInstallOptions attributes as flags on the flag set, using the ansible flag names and shorthands. The current attribute values are used as the flag defaults and parsing the flag set populates the options. When prefix is not empty, it is prepended to the flag names and the shorthands are not registered to avoid collisions
+func (o *AnsibleGalaxyRoleInstallOptions) AddFlags(fs *pflag.FlagSet, prefix string) {
+	fs.StringVar(&o.ApiKey, flagName(prefix, APIKeyFlag), o.ApiKey, flagUsages[APIKeyFlag])
+	fs.BoolVarP(&o.Force, flagName(prefix, ForceFlag), flagShorthand(prefix, "f"), o.Force, flagUsages[ForceFlag])
+	fs.BoolVar(&o.ForceWithDeps, flagName(prefix, ForceWithDepsFlag), o.ForceWithDeps, flagUsages[ForceWithDepsFlag])
+	fs.BoolVarP(&o.IgnoreCerts, flagName(prefix, IgnoreCertsFlag), flagShorthand(prefix, "c"), o.IgnoreCerts, flagUsages[IgnoreCertsFlag])
+	fs.BoolVarP(&o.IgnoreErrors, flagName(prefix, IgnoreErrorsFlag), flagShorthand(prefix, "i"), o.IgnoreErrors, flagUsages[IgnoreErrorsFlag])
+	fs.BoolVarP(&o.KeepSCMMeta, flagName(prefix, KeepSCMMetaFlag), flagShorthand(prefix, "g"), o.KeepSCMMeta, flagUsages[KeepSCMMetaFlag])
+	fs.BoolVarP(&o.NoDeps, flagName(prefix, NoDepsFlag), flagShorthand(prefix, "n"), o.NoDeps, flagUsages[NoDepsFlag])
+	fs.StringVarP(&o.RoleFile, flagName(prefix, RoleFileFlag), flagShorthand(prefix, "r"), o.RoleFile, flagUsages[RoleFileFlag])
+	fs.StringVarP(&o.RolesPath, flagName(prefix, RolesPathFlag), flagShorthand(prefix, "p"), o.RolesPath, flagUsages[RolesPathFlag])
+	fs.StringVarP(&o.Server, flagName(prefix, ServerFlag), flagShorthand(prefix, "s"), o.Server, flagUsages[ServerFlag])
+	fs.StringVar(&o.Timeout, flagName(prefix, TimeoutFlag), o.Timeout, flagUsages[TimeoutFlag])
+	fs.StringVar(&o.Token, flagName(prefix, TokenFlag), o.Token, flagUsages[TokenFlag])
+	verbose := fs.VarPF(&verbosityValue{options: o}, flagName(prefix, verboseFlag), flagShorthand(prefix, "v"), flagUsages[verboseFlag])
+	verbose.NoOptDefVal = "+1"
+	fs.BoolVar(&o.Version, flagName(prefix, VersionFlag), o.Version, flagUsages[VersionFlag])
+}
+
+// flagName returns the flag name without the leading dashes and prepended by the prefix
+func flagName(prefix, flag string) string {
+	return prefix + strings.TrimLeft(flag, "-")
+}
+
+// flagShorthand returns the flag shorthand when there is no prefix, otherwise returns an empty shorthand
+func flagShorthand(prefix, shorthand string) string {
+	if prefix != "" {
+		return ""
+	}
+
+	return shorthand
+}
+
+// verbosityValue is a pflag.Value that counts the verbose flag occurrences and sets the verbosity attributes of the AnsibleGalaxyRoleInstallOptions. Four or more occurrences enable the Verbose attribute
+type verbosityValue struct {
+	count   int
+	options *AnsibleGalaxyRoleInstallOptions
+}
+
+// String returns the verbosity level
+func (v *verbosityValue) String() string {
+	return strconv.Itoa(v.count)
+}
+
+// Set increases the verbosity level when value is +1, otherwise value is the verbosity level
+func (v *verbosityValue) Set(value string) error {
+	if value == "+1" {
+		v.count++
+	} else {
+		count, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid verbosity level '%s'", value)
+		}
+		v.count = count
+	}
+
+	v.options.Verbose = v.count >= 4
+	v.options.VerboseVVV = v.count == 3
+	v.options.VerboseVV = v.count == 2
+	v.options.VerboseV = v.count == 1
+
+	return nil
+}
+
+// Type returns the verbosity value type
+func (v *verbosityValue) Type() string {
+	return "count"
+}
@@ -0,0 +1,91 @@
+package galaxyroleinstall
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/internal/flags/flagstest"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAddFlags tests
+func TestAddFlags(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyRoleInstallOptions
+		prefix  string
+		args    []string
+		res     *AnsibleGalaxyRoleInstallOptions
+		err     error
+	}{
+		{
+			desc:    "Testing add flags and parse short flags",
+			options: &AnsibleGalaxyRoleInstallOptions{},
+			args:    []string{"-p", "roles", "-r", "requirements.yml", "-g", "-vvvv"},
+			res: &AnsibleGalaxyRoleInstallOptions{
+				KeepSCMMeta: true,
+				RoleFile:    "requirements.yml",
+				RolesPath:   "roles",
+				Verbose:     true,
+			},
+		},
+		{
+			desc:    "Testing add flags with prefix and parse long flags",
+			options: &AnsibleGalaxyRoleInstallOptions{},
+			prefix:  "ansible-",
+			args:    []string{"--ansible-roles-path=roles", "--ansible-force", "--ansible-timeout", "60"},
+			res: &AnsibleGalaxyRoleInstallOptions{
+				Force:     true,
+				RolesPath: "roles",
+				Timeout:   "60",
+			},
+		},
+		{
+			desc:    "Testing add flags with prefix does not register shorthands",
+			options: &AnsibleGalaxyRoleInstallOptions{},
+			prefix:  "ansible-",
+			args:    []string{"-p"},
+			err:     fmt.Errorf("unknown shorthand flag: 'p' in -p"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			test.options.AddFlags(fs, test.prefix)
+
+			err := fs.Parse(test.args)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, test.options)
+			}
+		})
+	}
+}
+
+// TestAddFlagsUsages tests that every flag constant is registered with its help text, because the flagUsages entries are maintained by hand
+func TestAddFlagsUsages(t *testing.T) {
+	longFlags, err := flagstest.LongFlags("ansibleGalaxyRoleInstallOptions.go", "ansibleGalaxyRoleInstallOptionsFlags.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	options := &AnsibleGalaxyRoleInstallOptions{}
+	options.AddFlags(fs, "")
+
+	for _, longFlag := range longFlags {
+		flag := fs.Lookup(strings.TrimPrefix(longFlag, "--"))
+		if assert.NotNil(t, flag, "flag %s is not registered", longFlag) {
+			assert.NotEmpty(t, flag.Usage, "flag %s has no help text", longFlag)
+			assert.Equal(t, flagUsages[longFlag], flag.Usage)
+		}
+	}
+}
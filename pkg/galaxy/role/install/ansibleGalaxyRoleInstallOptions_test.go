@@ -2,6 +2,10 @@ package galaxyroleinstall
 
 import (
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
 	"testing"
 
 	errors "github.com/apenella/go-common-utils/error"
@@ -209,3 +213,122 @@ func TestAnsibleGalaxyRoleInstallOptionsString(t *testing.T) {
 		})
 	}
 }
+
+func TestAnsibleGalaxyRoleInstallOptionsValidate(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyRoleInstallOptions::Validate)"
+
+	tempDir := t.TempDir()
+	roleFile := filepath.Join(tempDir, "requirements.yml")
+	err := os.WriteFile(roleFile, []byte("---\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(tempDir, "missing.yml")
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyRoleInstallOptions
+		err     error
+	}{
+		{
+			desc:    "Testing error validating nil AnsibleGalaxyRoleInstallOptions",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyRoleInstallOptions is nil"),
+		},
+		{
+			desc: "Testing validate AnsibleGalaxyRoleInstallOptions",
+			options: &AnsibleGalaxyRoleInstallOptions{
+				ForceWithDeps: true,
+				RoleFile:      roleFile,
+				Timeout:       "60",
+				Token:         "token",
+				VerboseVV:     true,
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing error validating AnsibleGalaxyRoleInstallOptions",
+			options: &AnsibleGalaxyRoleInstallOptions{
+				ApiKey:        "apikey",
+				Force:         true,
+				ForceWithDeps: true,
+				NoDeps:        true,
+				RoleFile:      missing,
+				Timeout:       "timeout",
+				Token:         "token",
+				Verbose:       true,
+				VerboseV:      true,
+			},
+			err: errors.New(errContext, "Invalid ansible-galaxy role install options",
+				fmt.Errorf("only one verbosity level can be set, but 2 were set"),
+				fmt.Errorf("'%s' and '%s' are mutually exclusive", APIKeyFlag, TokenFlag),
+				fmt.Errorf("'%s' is meaningless with '%s'", ForceFlag, ForceWithDepsFlag),
+				fmt.Errorf("'%s' and '%s' are mutually exclusive", NoDepsFlag, ForceWithDepsFlag),
+				fmt.Errorf("'%s' must be a positive integer, but '%s' was provided", TimeoutFlag, "timeout"),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.Validate()
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+func TestAnsibleGalaxyRoleInstallOptionsValidateFiles(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyRoleInstallOptions::ValidateFiles)"
+
+	var err error
+	tempDir := t.TempDir()
+
+	err = os.WriteFile(filepath.Join(tempDir, "requirements.yml"), []byte("---\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyRoleInstallOptions
+		dir     string
+		err     error
+	}{
+		{
+			desc:    "Testing validate files of nil AnsibleGalaxyRoleInstallOptions",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyRoleInstallOptions is nil"),
+		},
+		{
+			desc: "Testing validate files of AnsibleGalaxyRoleInstallOptions resolving the relative paths against the directory",
+			options: &AnsibleGalaxyRoleInstallOptions{
+				RoleFile: "requirements.yml",
+			},
+			dir: tempDir,
+			err: nil,
+		},
+		{
+			desc: "Testing validate files of invalid AnsibleGalaxyRoleInstallOptions",
+			options: &AnsibleGalaxyRoleInstallOptions{
+				RoleFile: "nonexistent-requirements.yml",
+			},
+			dir: tempDir,
+			err: errors.New(errContext, "Invalid ansible-galaxy role install option files",
+				fmt.Errorf("'%s' file is not valid: %w", RoleFileFlag, &fs.PathError{Op: "stat", Path: filepath.Join(tempDir, "nonexistent-requirements.yml"), Err: syscall.ENOENT}),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.ValidateFiles(test.dir)
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package galaxyroleinstall
+
+import (
+	"fmt"
+	"io"
+
+	galaxyrole "github.com/apenella/go-ansible/v2/pkg/galaxy/role"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/spf13/pflag"
+)
+
+// ParseAnsibleGalaxyRoleInstallCmd creates an AnsibleGalaxyRoleInstallCmd from an ansible-galaxy role install command line. The first argument is the ansible-galaxy binary followed by the role install subcommand, or just the install subcommand, the flags are parsed into the role install options and the remaining arguments are the role names. It is the inverse of the AnsibleGalaxyRoleInstallCmd's Command method
+func ParseAnsibleGalaxyRoleInstallCmd(args []string) (*AnsibleGalaxyRoleInstallCmd, error) {
+
+	errContext := "(galaxy::ParseAnsibleGalaxyRoleInstallCmd)"
+
+	if len(args) == 0 {
+		return nil, errors.New(errContext, "No ansible-galaxy command line to parse")
+	}
+
+	var flags []string
+	switch {
+	case len(args) > 2 && args[1] == galaxyrole.AnsibleGalaxyRoleSubCommand && args[2] == AnsibleGalaxyRoleInstallSubCommand:
+		flags = args[3:]
+	// ansible-galaxy install is a shortcut to ansible-galaxy role install
+	case len(args) > 1 && args[1] == AnsibleGalaxyRoleInstallSubCommand:
+		flags = args[2:]
+	default:
+		return nil, errors.New(errContext, fmt.Sprintf("The command line is not an ansible-galaxy '%s %s' command", galaxyrole.AnsibleGalaxyRoleSubCommand, AnsibleGalaxyRoleInstallSubCommand))
+	}
+
+	options := &AnsibleGalaxyRoleInstallOptions{}
+
+	fs := pflag.NewFlagSet(args[0], pflag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	options.AddFlags(fs, "")
+
+	err := fs.Parse(flags)
+	if err != nil {
+		return nil, errors.New(errContext, "Error parsing ansible-galaxy role install command line", err)
+	}
+
+	cmd := NewAnsibleGalaxyRoleInstallCmd(
+		WithBinary(args[0]),
+	)
+
+	if fs.NArg() > 0 {
+		cmd.RoleNames = fs.Args()
+	}
+
+	if fs.NFlag() > 0 {
+		cmd.GalaxyRoleInstallOptions = options
+	}
+
+	return cmd, nil
+}
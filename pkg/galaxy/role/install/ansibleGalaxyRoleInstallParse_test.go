@@ -0,0 +1,121 @@
+package galaxyroleinstall
+
+import (
+	"fmt"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseAnsibleGalaxyRoleInstallCmd tests
+func TestParseAnsibleGalaxyRoleInstallCmd(t *testing.T) {
+
+	errContext := "(galaxy::ParseAnsibleGalaxyRoleInstallCmd)"
+
+	tests := []struct {
+		desc string
+		args []string
+		res  *AnsibleGalaxyRoleInstallCmd
+		err  error
+	}{
+		{
+			desc: "Testing parse an ansible-galaxy command line without arguments",
+			args: []string{},
+			err:  errors.New(errContext, "No ansible-galaxy command line to parse"),
+		},
+		{
+			desc: "Testing parse an ansible-galaxy command line which is not a role install command",
+			args: []string{"ansible-galaxy", "collection", "install", "community.general"},
+			err:  errors.New(errContext, "The command line is not an ansible-galaxy 'role install' command"),
+		},
+		{
+			desc: "Testing parse an ansible-galaxy role install command line with an unknown flag",
+			args: []string{"ansible-galaxy", "role", "install", "--unknown", "geerlingguy.docker"},
+			err:  errors.New(errContext, "Error parsing ansible-galaxy role install command line", fmt.Errorf("unknown flag: --unknown")),
+		},
+		{
+			desc: "Testing parse an ansible-galaxy install command line with short flags",
+			args: []string{"ansible-galaxy", "install", "-p", "roles", "-r", "requirements.yml", "-g", "-n", "-vv", "geerlingguy.docker", "geerlingguy.nginx"},
+			res: &AnsibleGalaxyRoleInstallCmd{
+				Binary:    "ansible-galaxy",
+				RoleNames: []string{"geerlingguy.docker", "geerlingguy.nginx"},
+				GalaxyRoleInstallOptions: &AnsibleGalaxyRoleInstallOptions{
+					KeepSCMMeta: true,
+					NoDeps:      true,
+					RoleFile:    "requirements.yml",
+					RolesPath:   "roles",
+					VerboseVV:   true,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := ParseAnsibleGalaxyRoleInstallCmd(test.args)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+// TestParseAnsibleGalaxyRoleInstallCmdRoundTrip tests that parsing the command generated by an AnsibleGalaxyRoleInstallCmd returns the same AnsibleGalaxyRoleInstallCmd
+func TestParseAnsibleGalaxyRoleInstallCmdRoundTrip(t *testing.T) {
+	tests := []struct {
+		desc string
+		cmd  *AnsibleGalaxyRoleInstallCmd
+	}{
+		{
+			desc: "Testing round trip of an ansible-galaxy role install command without options",
+			cmd: NewAnsibleGalaxyRoleInstallCmd(
+				WithBinary("ansible-galaxy"),
+				WithRoleNames("geerlingguy.docker"),
+			),
+		},
+		{
+			desc: "Testing round trip of an ansible-galaxy role install command with all options",
+			cmd: NewAnsibleGalaxyRoleInstallCmd(
+				WithBinary("custom-ansible-galaxy"),
+				WithRoleNames("geerlingguy.docker", "geerlingguy.nginx"),
+				WithGalaxyRoleInstallOptions(&AnsibleGalaxyRoleInstallOptions{
+					ApiKey:        "apikey",
+					Force:         true,
+					ForceWithDeps: true,
+					IgnoreCerts:   true,
+					IgnoreErrors:  true,
+					KeepSCMMeta:   true,
+					NoDeps:        true,
+					RoleFile:      "requirements.yml",
+					RolesPath:     "roles",
+					Server:        "https://galaxy.ansible.com",
+					Timeout:       "60",
+					Token:         "token",
+					Verbose:       true,
+					Version:       true,
+				}),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			test.cmd.SkipValidation = true
+			command, err := test.cmd.Command()
+			assert.NoError(t, err)
+
+			res, err := ParseAnsibleGalaxyRoleInstallCmd(command)
+			assert.NoError(t, err)
+
+			res.SkipValidation = true
+			assert.Equal(t, test.cmd, res)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package galaxyrolelist
+
+import (
+	"fmt"
+
+	galaxy "github.com/apenella/go-ansible/v2/pkg/galaxy"
+	galaxyrole "github.com/apenella/go-ansible/v2/pkg/galaxy/role"
+)
+
+const (
+	// AnsibleGalaxyRoleListSubCommand is the ansible-galaxy role list subcommand
+	AnsibleGalaxyRoleListSubCommand = "list"
+)
+
+// AnsibleGalaxyRoleListOptionsFunc is a function to set executor options
+type AnsibleGalaxyRoleListOptionsFunc func(*AnsibleGalaxyRoleListCmd)
+
+// AnsibleGalaxyRoleListCmd object is the main object which defines the `ansible-galaxy` command to list the installed roles.
+type AnsibleGalaxyRoleListCmd struct {
+	// Binary is the ansible-galaxy binary file
+	Binary string
+
+	// RoleName is the name of the role to be listed. All the installed roles are listed when it is empty
+	RoleName string
+
+	// GalaxyRoleListOptions are the ansible-galaxy's role list options
+	GalaxyRoleListOptions *AnsibleGalaxyRoleListOptions
+
+	// SkipValidation disables the role list options validation when the command is generated
+	SkipValidation bool
+}
+
+// NewAnsibleGalaxyRoleListCmd creates a new AnsibleGalaxyRoleListCmd instance
+func NewAnsibleGalaxyRoleListCmd(options ...AnsibleGalaxyRoleListOptionsFunc) *AnsibleGalaxyRoleListCmd {
+	cmd := &AnsibleGalaxyRoleListCmd{}
+
+	for _, option := range options {
+		option(cmd)
+	}
+
+	return cmd
+}
+
+// WithBinary set the ansible-galaxy binary file
+func WithBinary(binary string) AnsibleGalaxyRoleListOptionsFunc {
+	return func(p *AnsibleGalaxyRoleListCmd) {
+		p.Binary = binary
+	}
+}
+
+// WithRoleName set the name of the role to be listed
+func WithRoleName(roleName string) AnsibleGalaxyRoleListOptionsFunc {
+	return func(p *AnsibleGalaxyRoleListCmd) {
+		p.RoleName = roleName
+	}
+}
+
+// WithGalaxyRoleListOptions set the ansible-galaxy role list options
+func WithGalaxyRoleListOptions(options *AnsibleGalaxyRoleListOptions) AnsibleGalaxyRoleListOptionsFunc {
+	return func(p *AnsibleGalaxyRoleListCmd) {
+		p.GalaxyRoleListOptions = options
+	}
+}
+
+// WithoutValidation disables the ansible-galaxy role list options validation
+func WithoutValidation() AnsibleGalaxyRoleListOptionsFunc {
+	return func(p *AnsibleGalaxyRoleListCmd) {
+		p.SkipValidation = true
+	}
+}
+
+// Command generate the ansible-galaxy role list command which will be executed
+func (p *AnsibleGalaxyRoleListCmd) Command() ([]string, error) {
+	cmd := []string{}
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = galaxy.DefaultAnsibleGalaxyBinary
+	}
+
+	cmd = append(cmd, p.Binary, galaxyrole.AnsibleGalaxyRoleSubCommand, AnsibleGalaxyRoleListSubCommand)
+
+	// Add the options
+	if p.GalaxyRoleListOptions != nil {
+		if !p.SkipValidation {
+			err := p.GalaxyRoleListOptions.Validate()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		options, err := p.GalaxyRoleListOptions.GenerateCommandOptions()
+		if err != nil {
+			return nil, err
+		}
+		cmd = append(cmd, options...)
+	}
+
+	// Add the role name
+	if p.RoleName != "" {
+		cmd = append(cmd, p.RoleName)
+	}
+
+	return cmd, nil
+}
+
+// String returns the ansible-galaxy role list command as a string
+func (p *AnsibleGalaxyRoleListCmd) String() string {
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = galaxy.DefaultAnsibleGalaxyBinary
+	}
+
+	str := fmt.Sprintf("%s %s %s", p.Binary, galaxyrole.AnsibleGalaxyRoleSubCommand, AnsibleGalaxyRoleListSubCommand)
+
+	if p.GalaxyRoleListOptions != nil {
+		str = fmt.Sprintf("%s %s", str, p.GalaxyRoleListOptions.String())
+	}
+
+	// Include the role name
+	if p.RoleName != "" {
+		str = fmt.Sprintf("%s %s", str, p.RoleName)
+	}
+
+	return str
+}
@@ -0,0 +1,138 @@
+package galaxyrolelist
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/galaxy"
+	galaxyrole "github.com/apenella/go-ansible/v2/pkg/galaxy/role"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleGalaxyRoleListCmd(t *testing.T) {
+	cmd := NewAnsibleGalaxyRoleListCmd(
+		WithBinary("ansible-galaxy-binary"),
+		WithRoleName("geerlingguy.docker"),
+		WithGalaxyRoleListOptions(&AnsibleGalaxyRoleListOptions{
+			APIKey: "apikey",
+		}),
+	)
+
+	expect := &AnsibleGalaxyRoleListCmd{
+		Binary:   "ansible-galaxy-binary",
+		RoleName: "geerlingguy.docker",
+		GalaxyRoleListOptions: &AnsibleGalaxyRoleListOptions{
+			APIKey: "apikey",
+		},
+	}
+
+	assert.Equal(t, expect, cmd)
+}
+
+func TestAnsibleGalaxyRoleListCmdCommand(t *testing.T) {
+
+	tests := []struct {
+		desc    string
+		cmd     *AnsibleGalaxyRoleListCmd
+		command []string
+		err     error
+	}{
+		{
+			desc: "Testing generate a command for AnsibleGalaxyRoleListCmd with all flags using default binary",
+			cmd: NewAnsibleGalaxyRoleListCmd(
+				WithoutValidation(),
+				WithRoleName("geerlingguy.docker"),
+				WithGalaxyRoleListOptions(&AnsibleGalaxyRoleListOptions{
+					APIKey:      "apikey",
+					IgnoreCerts: true,
+					RolesPath:   "path",
+					Server:      "server",
+					Timeout:     "10",
+					Token:       "token",
+					Verbose:     true,
+				}),
+			),
+			err: &errors.Error{},
+			command: []string{
+				galaxy.DefaultAnsibleGalaxyBinary,
+				galaxyrole.AnsibleGalaxyRoleSubCommand,
+				AnsibleGalaxyRoleListSubCommand,
+				fmt.Sprintf("%s=%s", APIKeyFlag, "apikey"),
+				IgnoreCertsFlag,
+				fmt.Sprintf("%s=%s", RolesPathFlag, "path"),
+				fmt.Sprintf("%s=%s", ServerFlag, "server"),
+				fmt.Sprintf("%s=%s", TimeoutFlag, "10"),
+				fmt.Sprintf("%s=%s", TokenFlag, "token"),
+				VerboseFlag,
+				"geerlingguy.docker",
+			},
+		},
+		{
+			desc: "Testing error generating a command for AnsibleGalaxyRoleListCmd with invalid options",
+			cmd: NewAnsibleGalaxyRoleListCmd(
+				WithGalaxyRoleListOptions(&AnsibleGalaxyRoleListOptions{
+					Timeout: "0",
+				}),
+			),
+			err: errors.New("(galaxy::AnsibleGalaxyRoleListOptions::Validate)", "Invalid ansible-galaxy role list options",
+				fmt.Errorf("'%s' must be a positive integer, but '0' was provided", TimeoutFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			command, err := test.cmd.Command()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.command, command)
+			}
+		})
+	}
+}
+
+func TestAnsibleGalaxyRoleListCmdString(t *testing.T) {
+
+	tests := []struct {
+		desc string
+		cmd  *AnsibleGalaxyRoleListCmd
+		res  string
+	}{
+		{
+			desc: "Testing AnsibleGalaxyRoleListCmd to string with all flags",
+			cmd: NewAnsibleGalaxyRoleListCmd(
+				WithRoleName("geerlingguy.docker"),
+				WithGalaxyRoleListOptions(&AnsibleGalaxyRoleListOptions{
+					APIKey:      "apikey",
+					IgnoreCerts: true,
+					RolesPath:   "path",
+					Server:      "server",
+					Timeout:     "10",
+					Token:       "token",
+					Verbose:     true,
+				}),
+			),
+			res: "ansible-galaxy role list --api-key=apikey --ignore-certs --roles-path=path --server=server --timeout=10 --token=token --verbose geerlingguy.docker",
+		},
+		{
+			desc: "Testing AnsibleGalaxyRoleListCmd to string using a custom binary",
+			cmd: NewAnsibleGalaxyRoleListCmd(
+				WithBinary("custom-binary"),
+				WithRoleName("geerlingguy.docker"),
+			),
+			res: "custom-binary role list geerlingguy.docker",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, test.cmd.String())
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package galaxyrolelist
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// AnsibleGalaxyRoleListExecute is an executor for ansible-galaxy role list command that runs the command using a DefaultExecute with default options
+type AnsibleGalaxyRoleListExecute struct {
+	cmd  *AnsibleGalaxyRoleListCmd
+	exec execute.Executabler
+}
+
+// NewAnsibleGalaxyRoleListExecute returns a new AnsibleGalaxyRoleListExecute. It receives the name of the role to list
+func NewAnsibleGalaxyRoleListExecute(roleName string) *AnsibleGalaxyRoleListExecute {
+
+	exec := &AnsibleGalaxyRoleListExecute{
+		cmd: &AnsibleGalaxyRoleListCmd{
+			RoleName: roleName,
+		},
+	}
+
+	return exec
+}
+
+// WithBinary returns an AnsibleGalaxyRoleListExecute with the binary file set
+func (e *AnsibleGalaxyRoleListExecute) WithBinary(binary string) *AnsibleGalaxyRoleListExecute {
+	e.cmd.Binary = binary
+
+	return e
+}
+
+// WithExecutable returns an AnsibleGalaxyRoleListExecute with the executable used to run the command set
+func (e *AnsibleGalaxyRoleListExecute) WithExecutable(executable execute.Executabler) *AnsibleGalaxyRoleListExecute {
+	e.exec = executable
+
+	return e
+}
+
+// WithGalaxyRoleListOptions returns an AnsibleGalaxyRoleListExecute with the ansible-galaxy role list options set
+func (e *AnsibleGalaxyRoleListExecute) WithGalaxyRoleListOptions(options *AnsibleGalaxyRoleListOptions) *AnsibleGalaxyRoleListExecute {
+	e.cmd.GalaxyRoleListOptions = options
+
+	return e
+}
+
+// Execute method runs the ansible-galaxy role list command using a DefaultExecute with default options
+func (e *AnsibleGalaxyRoleListExecute) Execute(ctx context.Context) error {
+
+	options := []execute.ExecuteOptions{
+		execute.WithCmd(e.cmd),
+	}
+
+	if e.exec != nil {
+		options = append(options, execute.WithExecutable(e.exec))
+	}
+
+	exec := execute.NewDefaultExecute(options...)
+
+	err := exec.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// InstalledRoles runs the ansible-galaxy role list command and returns the installed roles
+func (e *AnsibleGalaxyRoleListExecute) InstalledRoles(ctx context.Context) ([]*InstalledRole, error) {
+	errContext := "(galaxy::AnsibleGalaxyRoleListExecute::InstalledRoles)"
+
+	stdout := &bytes.Buffer{}
+	options := []execute.ExecuteOptions{
+		execute.WithCmd(e.cmd),
+		execute.WithWrite(stdout),
+	}
+
+	if e.exec != nil {
+		options = append(options, execute.WithExecutable(e.exec))
+	}
+
+	exec := execute.NewDefaultExecute(options...)
+
+	err := exec.Execute(ctx)
+	if err != nil {
+		return nil, errors.New(errContext, "Error listing the installed roles", err)
+	}
+
+	installedRoles, err := ParseAnsibleGalaxyRoleListOutput(stdout)
+	if err != nil {
+		return nil, errors.New(errContext, "Error parsing the installed roles", err)
+	}
+
+	return installedRoles, nil
+}
@@ -0,0 +1,85 @@
+package galaxyrolelist
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleGalaxyRoleListExecute(t *testing.T) {
+	expect := &AnsibleGalaxyRoleListExecute{
+		cmd: &AnsibleGalaxyRoleListCmd{
+			RoleName: "geerlingguy.docker",
+		},
+	}
+
+	res := NewAnsibleGalaxyRoleListExecute("geerlingguy.docker")
+
+	assert.Equal(t, expect, res)
+}
+
+func TestAnsibleGalaxyRoleListExecuteExecute(t *testing.T) {
+
+	e := exec.NewMockExec()
+	cmd := exec.NewMockCmd()
+
+	cmd.On("StdoutPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+	cmd.On("StderrPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+	cmd.On("Start").Return(nil)
+	cmd.On("Wait").Return(nil)
+	// the compatibility check detects the ansible version, unless it is already cached
+	versionCmd := exec.NewMockCmd()
+	versionCmd.On("Output").Return([]byte("ansible-galaxy [core 2.16.3]\n"), nil).Maybe()
+	e.On("CommandContext", context.TODO(), "custom-binary", []string{"--version"}).Return(versionCmd).Maybe()
+	e.On("CommandContext", context.TODO(), "custom-binary", []string{"role", "list", "--api-key=apikey", "geerlingguy.docker"}).Return(cmd)
+
+	err := NewAnsibleGalaxyRoleListExecute("geerlingguy.docker").
+		WithBinary("custom-binary").
+		WithExecutable(e).
+		WithGalaxyRoleListOptions(&AnsibleGalaxyRoleListOptions{
+			APIKey: "apikey",
+		}).
+		Execute(context.TODO())
+
+	assert.NoError(t, err)
+	e.AssertExpectations(t)
+	cmd.AssertExpectations(t)
+}
+
+func TestInstalledRoles(t *testing.T) {
+
+	e := exec.NewMockExec()
+	cmd := exec.NewMockCmd()
+
+	cmd.On("StdoutPipe").Return(io.NopCloser(strings.NewReader("# /root/.ansible/roles\n- geerlingguy.docker, 6.1.0\n")), nil)
+	cmd.On("StderrPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+	cmd.On("Start").Return(nil)
+	cmd.On("Wait").Return(nil)
+	// the compatibility check detects the ansible version, unless it is already cached
+	versionCmd := exec.NewMockCmd()
+	versionCmd.On("Output").Return([]byte("ansible-galaxy [core 2.16.3]\n"), nil).Maybe()
+	e.On("CommandContext", context.TODO(), "ansible-galaxy", []string{"--version"}).Return(versionCmd).Maybe()
+	e.On("CommandContext", context.TODO(), "ansible-galaxy", []string{"role", "list", "--roles-path=/root/.ansible/roles", "geerlingguy.docker"}).Return(cmd)
+
+	res, err := NewAnsibleGalaxyRoleListExecute("geerlingguy.docker").
+		WithExecutable(e).
+		WithGalaxyRoleListOptions(&AnsibleGalaxyRoleListOptions{
+			RolesPath: "/root/.ansible/roles",
+		}).
+		InstalledRoles(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*InstalledRole{
+		{
+			Name:    "geerlingguy.docker",
+			Path:    "/root/.ansible/roles",
+			Version: "6.1.0",
+		},
+	}, res)
+	e.AssertExpectations(t)
+	cmd.AssertExpectations(t)
+}
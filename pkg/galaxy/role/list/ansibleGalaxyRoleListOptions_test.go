@@ -0,0 +1,147 @@
+package galaxyrolelist
+
+import (
+	"fmt"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnsibleGalaxyRoleListOptionsGenerateCommandOptions(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyRoleListOptions::GenerateCommandOptions)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyRoleListOptions
+		err     error
+		expect  []string
+	}{
+		{
+			desc:    "Testing nil AnsibleGalaxyRoleListOptions definition",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyRoleListOptions is nil"),
+		},
+		{
+			desc:    "Testing an empty AnsibleGalaxyRoleListOptions definition",
+			options: &AnsibleGalaxyRoleListOptions{},
+			expect:  []string{},
+		},
+		{
+			desc: "Testing AnsibleGalaxyRoleListOptions with all flags",
+			options: &AnsibleGalaxyRoleListOptions{
+				APIKey:      "apikey",
+				IgnoreCerts: true,
+				RolesPath:   "path",
+				Server:      "server",
+				Timeout:     "10",
+				Token:       "token",
+				Verbose:     true,
+			},
+			expect: []string{
+				fmt.Sprintf("%s=%s", APIKeyFlag, "apikey"),
+				IgnoreCertsFlag,
+				fmt.Sprintf("%s=%s", RolesPathFlag, "path"),
+				fmt.Sprintf("%s=%s", ServerFlag, "server"),
+				fmt.Sprintf("%s=%s", TimeoutFlag, "10"),
+				fmt.Sprintf("%s=%s", TokenFlag, "token"),
+				VerboseFlag,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			options, err := test.options.GenerateCommandOptions()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.expect, options, "Unexpected options value")
+			}
+		})
+	}
+}
+
+func TestAnsibleGalaxyRoleListOptionsString(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyRoleListOptions
+		expect  string
+	}{
+		{
+			desc:    "Testing generate string from an empty AnsibleGalaxyRoleListOptions",
+			options: &AnsibleGalaxyRoleListOptions{},
+			expect:  "",
+		},
+		{
+			desc: "Testing generate string from an AnsibleGalaxyRoleListOptions with all flags",
+			options: &AnsibleGalaxyRoleListOptions{
+				APIKey:      "apikey",
+				IgnoreCerts: true,
+				RolesPath:   "path",
+				Server:      "server",
+				Timeout:     "10",
+				Token:       "token",
+				Verbose:     true,
+			},
+			expect: "--api-key=apikey --ignore-certs --roles-path=path --server=server --timeout=10 --token=token --verbose",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expect, test.options.String())
+		})
+	}
+}
+
+func TestAnsibleGalaxyRoleListOptionsValidate(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyRoleListOptions::Validate)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyRoleListOptions
+		err     error
+	}{
+		{
+			desc:    "Testing validate nil AnsibleGalaxyRoleListOptions",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyRoleListOptions is nil"),
+		},
+		{
+			desc: "Testing validate valid AnsibleGalaxyRoleListOptions",
+			options: &AnsibleGalaxyRoleListOptions{
+				RolesPath: "path",
+				Timeout:   "10",
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing validate invalid AnsibleGalaxyRoleListOptions",
+			options: &AnsibleGalaxyRoleListOptions{
+				APIKey:  "apikey",
+				Timeout: "ten",
+				Token:   "token",
+			},
+			err: errors.New(errContext, "Invalid ansible-galaxy role list options",
+				fmt.Errorf("'%s' and '%s' are mutually exclusive", APIKeyFlag, TokenFlag),
+				fmt.Errorf("'%s' must be a positive integer, but 'ten' was provided", TimeoutFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.Validate()
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package galaxyrolelist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// rolesPathPrefix is the prefix of the ansible-galaxy role list output lines that hold a roles path
+	rolesPathPrefix = "# "
+	// rolePrefix is the prefix of the ansible-galaxy role list output lines that hold a role
+	rolePrefix = "- "
+	// unknownVersion is the version reported by ansible-galaxy role list for roles that do not define a version
+	unknownVersion = "(unknown version)"
+)
+
+// InstalledRole is a role reported by ansible-galaxy role list
+type InstalledRole struct {
+	// Name is the role name
+	Name string
+	// Path is the roles directory where the role is installed
+	Path string
+	// Version is the installed role version. It is empty when the role does not define a version
+	Version string
+}
+
+// ParseAnsibleGalaxyRoleListOutput returns the installed roles described by the ansible-galaxy role list output. The output has a line starting with '# ' for each roles path, followed by a line in the form '- name, version' for each role installed on it. The roles are returned in the same order ansible-galaxy lists them, which is the roles path precedence
+func ParseAnsibleGalaxyRoleListOutput(reader io.Reader) ([]*InstalledRole, error) {
+	errContext := "(galaxy::ParseAnsibleGalaxyRoleListOutput)"
+
+	if reader == nil {
+		return nil, errors.New(errContext, "Reader is not defined")
+	}
+
+	installedRoles := []*InstalledRole{}
+	path := ""
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case strings.HasPrefix(line, rolesPathPrefix):
+			path = strings.TrimSpace(strings.TrimPrefix(line, rolesPathPrefix))
+		case strings.HasPrefix(line, rolePrefix):
+			name, version, found := strings.Cut(strings.TrimPrefix(line, rolePrefix), ",")
+			// lines such as '- the role x was not found' are not roles
+			if !found {
+				continue
+			}
+
+			if path == "" {
+				return nil, errors.New(errContext, fmt.Sprintf("Role '%s' is not listed under any roles path", strings.TrimSpace(name)))
+			}
+
+			version = strings.TrimSpace(version)
+			if version == unknownVersion {
+				version = ""
+			}
+
+			installedRoles = append(installedRoles, &InstalledRole{
+				Name:    strings.TrimSpace(name),
+				Path:    path,
+				Version: version,
+			})
+		}
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, errors.New(errContext, "Error reading ansible-galaxy role list output", err)
+	}
+
+	return installedRoles, nil
+}
@@ -0,0 +1,84 @@
+package galaxyrolelist
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAnsibleGalaxyRoleListOutput(t *testing.T) {
+
+	errContext := "(galaxy::ParseAnsibleGalaxyRoleListOutput)"
+
+	tests := []struct {
+		desc   string
+		reader io.Reader
+		res    []*InstalledRole
+		err    error
+	}{
+		{
+			desc: "Testing parse the roles installed on several roles paths",
+			reader: strings.NewReader(`# /root/.ansible/roles
+- geerlingguy.docker, 6.1.0
+- webserver, (unknown version)
+# /usr/share/ansible/roles
+- geerlingguy.pip, 2.2.0
+`),
+			res: []*InstalledRole{
+				{
+					Name:    "geerlingguy.docker",
+					Path:    "/root/.ansible/roles",
+					Version: "6.1.0",
+				},
+				{
+					Name:    "webserver",
+					Path:    "/root/.ansible/roles",
+					Version: "",
+				},
+				{
+					Name:    "geerlingguy.pip",
+					Path:    "/usr/share/ansible/roles",
+					Version: "2.2.0",
+				},
+			},
+		},
+		{
+			desc: "Testing parse an output with warnings and roles not found",
+			reader: strings.NewReader(`[WARNING]: - the configured path /etc/ansible/roles does not exist.
+# /root/.ansible/roles
+- the role geerlingguy.nginx was not found
+`),
+			res: []*InstalledRole{},
+		},
+		{
+			desc:   "Testing parse an empty output",
+			reader: strings.NewReader("\n"),
+			res:    []*InstalledRole{},
+		},
+		{
+			desc:   "Testing parse a role without roles path",
+			reader: strings.NewReader("- geerlingguy.docker, 6.1.0\n"),
+			err:    errors.New(errContext, "Role 'geerlingguy.docker' is not listed under any roles path"),
+		},
+		{
+			desc: "Testing parse without reader",
+			err:  errors.New(errContext, "Reader is not defined"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := ParseAnsibleGalaxyRoleListOutput(test.reader)
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package galaxyroleremove
+
+import (
+	"fmt"
+
+	galaxy "github.com/apenella/go-ansible/v2/pkg/galaxy"
+	galaxyrole "github.com/apenella/go-ansible/v2/pkg/galaxy/role"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// AnsibleGalaxyRoleRemoveSubCommand is the ansible-galaxy role remove subcommand
+	AnsibleGalaxyRoleRemoveSubCommand = "remove"
+)
+
+// AnsibleGalaxyRoleRemoveOptionsFunc is a function to set executor options
+type AnsibleGalaxyRoleRemoveOptionsFunc func(*AnsibleGalaxyRoleRemoveCmd)
+
+// AnsibleGalaxyRoleRemoveCmd object is the main object which defines the `ansible-galaxy` command to remove installed roles.
+type AnsibleGalaxyRoleRemoveCmd struct {
+	// Binary is the ansible-galaxy binary file
+	Binary string
+
+	// RoleNames is the ansible-galaxy's role names to be removed
+	RoleNames []string
+
+	// GalaxyRoleRemoveOptions are the ansible-galaxy's role remove options
+	GalaxyRoleRemoveOptions *AnsibleGalaxyRoleRemoveOptions
+
+	// SkipValidation disables the role remove options validation when the command is generated
+	SkipValidation bool
+}
+
+// NewAnsibleGalaxyRoleRemoveCmd creates a new AnsibleGalaxyRoleRemoveCmd instance
+func NewAnsibleGalaxyRoleRemoveCmd(options ...AnsibleGalaxyRoleRemoveOptionsFunc) *AnsibleGalaxyRoleRemoveCmd {
+	cmd := &AnsibleGalaxyRoleRemoveCmd{}
+
+	for _, option := range options {
+		option(cmd)
+	}
+
+	return cmd
+}
+
+// WithBinary set the ansible-galaxy binary file
+func WithBinary(binary string) AnsibleGalaxyRoleRemoveOptionsFunc {
+	return func(p *AnsibleGalaxyRoleRemoveCmd) {
+		p.Binary = binary
+	}
+}
+
+// WithRoleNames set the ansible-galaxy role names to be removed
+func WithRoleNames(roleNames ...string) AnsibleGalaxyRoleRemoveOptionsFunc {
+	return func(p *AnsibleGalaxyRoleRemoveCmd) {
+		p.RoleNames = append([]string{}, roleNames...)
+	}
+}
+
+// WithGalaxyRoleRemoveOptions set the ansible-galaxy role remove options
+func WithGalaxyRoleRemoveOptions(options *AnsibleGalaxyRoleRemoveOptions) AnsibleGalaxyRoleRemoveOptionsFunc {
+	return func(p *AnsibleGalaxyRoleRemoveCmd) {
+		p.GalaxyRoleRemoveOptions = options
+	}
+}
+
+// WithoutValidation disables the ansible-galaxy role remove options validation
+func WithoutValidation() AnsibleGalaxyRoleRemoveOptionsFunc {
+	return func(p *AnsibleGalaxyRoleRemoveCmd) {
+		p.SkipValidation = true
+	}
+}
+
+// Command generate the ansible-galaxy role remove command which will be executed
+func (p *AnsibleGalaxyRoleRemoveCmd) Command() ([]string, error) {
+	errContext := "(galaxy::AnsibleGalaxyRoleRemoveCmd::Command)"
+	cmd := []string{}
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = galaxy.DefaultAnsibleGalaxyBinary
+	}
+
+	if len(p.RoleNames) == 0 {
+		return nil, errors.New(errContext, "No roles defined")
+	}
+
+	cmd = append(cmd, p.Binary, galaxyrole.AnsibleGalaxyRoleSubCommand, AnsibleGalaxyRoleRemoveSubCommand)
+
+	// Add the options
+	if p.GalaxyRoleRemoveOptions != nil {
+		if !p.SkipValidation {
+			err := p.GalaxyRoleRemoveOptions.Validate()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		options, err := p.GalaxyRoleRemoveOptions.GenerateCommandOptions()
+		if err != nil {
+			return nil, err
+		}
+		cmd = append(cmd, options...)
+	}
+
+	// Add the role names
+	cmd = append(cmd, p.RoleNames...)
+
+	return cmd, nil
+}
+
+// String returns the ansible-galaxy role remove command as a string
+func (p *AnsibleGalaxyRoleRemoveCmd) String() string {
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = galaxy.DefaultAnsibleGalaxyBinary
+	}
+
+	str := fmt.Sprintf("%s %s %s", p.Binary, galaxyrole.AnsibleGalaxyRoleSubCommand, AnsibleGalaxyRoleRemoveSubCommand)
+
+	if p.GalaxyRoleRemoveOptions != nil {
+		str = fmt.Sprintf("%s %s", str, p.GalaxyRoleRemoveOptions.String())
+	}
+
+	// Include the role names
+	for _, roleName := range p.RoleNames {
+		str = fmt.Sprintf("%s %s", str, roleName)
+	}
+
+	return str
+}
@@ -0,0 +1,145 @@
+package galaxyroleremove
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/galaxy"
+	galaxyrole "github.com/apenella/go-ansible/v2/pkg/galaxy/role"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleGalaxyRoleRemoveCmd(t *testing.T) {
+	cmd := NewAnsibleGalaxyRoleRemoveCmd(
+		WithBinary("ansible-galaxy-binary"),
+		WithRoleNames("geerlingguy.docker", "geerlingguy.pip"),
+		WithGalaxyRoleRemoveOptions(&AnsibleGalaxyRoleRemoveOptions{
+			APIKey: "apikey",
+		}),
+	)
+
+	expect := &AnsibleGalaxyRoleRemoveCmd{
+		Binary:    "ansible-galaxy-binary",
+		RoleNames: []string{"geerlingguy.docker", "geerlingguy.pip"},
+		GalaxyRoleRemoveOptions: &AnsibleGalaxyRoleRemoveOptions{
+			APIKey: "apikey",
+		},
+	}
+
+	assert.Equal(t, expect, cmd)
+}
+
+func TestAnsibleGalaxyRoleRemoveCmdCommand(t *testing.T) {
+
+	tests := []struct {
+		desc    string
+		cmd     *AnsibleGalaxyRoleRemoveCmd
+		command []string
+		err     error
+	}{
+		{
+			desc: "Testing generate a command for AnsibleGalaxyRoleRemoveCmd with all flags using default binary",
+			cmd: NewAnsibleGalaxyRoleRemoveCmd(
+				WithoutValidation(),
+				WithRoleNames("geerlingguy.docker", "geerlingguy.pip"),
+				WithGalaxyRoleRemoveOptions(&AnsibleGalaxyRoleRemoveOptions{
+					APIKey:      "apikey",
+					IgnoreCerts: true,
+					RolesPath:   "path",
+					Server:      "server",
+					Timeout:     "10",
+					Token:       "token",
+					Verbose:     true,
+				}),
+			),
+			err: &errors.Error{},
+			command: []string{
+				galaxy.DefaultAnsibleGalaxyBinary,
+				galaxyrole.AnsibleGalaxyRoleSubCommand,
+				AnsibleGalaxyRoleRemoveSubCommand,
+				fmt.Sprintf("%s=%s", APIKeyFlag, "apikey"),
+				IgnoreCertsFlag,
+				fmt.Sprintf("%s=%s", RolesPathFlag, "path"),
+				fmt.Sprintf("%s=%s", ServerFlag, "server"),
+				fmt.Sprintf("%s=%s", TimeoutFlag, "10"),
+				fmt.Sprintf("%s=%s", TokenFlag, "token"),
+				VerboseFlag,
+				"geerlingguy.docker",
+				"geerlingguy.pip",
+			},
+		},
+		{
+			desc: "Testing error generating a command for AnsibleGalaxyRoleRemoveCmd without role names",
+			cmd:  NewAnsibleGalaxyRoleRemoveCmd(),
+			err:  errors.New("(galaxy::AnsibleGalaxyRoleRemoveCmd::Command)", "No roles defined"),
+		},
+		{
+			desc: "Testing error generating a command for AnsibleGalaxyRoleRemoveCmd with invalid options",
+			cmd: NewAnsibleGalaxyRoleRemoveCmd(
+				WithRoleNames("geerlingguy.docker"),
+				WithGalaxyRoleRemoveOptions(&AnsibleGalaxyRoleRemoveOptions{
+					Timeout: "0",
+				}),
+			),
+			err: errors.New("(galaxy::AnsibleGalaxyRoleRemoveOptions::Validate)", "Invalid ansible-galaxy role remove options",
+				fmt.Errorf("'%s' must be a positive integer, but '0' was provided", TimeoutFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			command, err := test.cmd.Command()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.command, command)
+			}
+		})
+	}
+}
+
+func TestAnsibleGalaxyRoleRemoveCmdString(t *testing.T) {
+
+	tests := []struct {
+		desc string
+		cmd  *AnsibleGalaxyRoleRemoveCmd
+		res  string
+	}{
+		{
+			desc: "Testing AnsibleGalaxyRoleRemoveCmd to string with all flags",
+			cmd: NewAnsibleGalaxyRoleRemoveCmd(
+				WithRoleNames("geerlingguy.docker", "geerlingguy.pip"),
+				WithGalaxyRoleRemoveOptions(&AnsibleGalaxyRoleRemoveOptions{
+					APIKey:      "apikey",
+					IgnoreCerts: true,
+					RolesPath:   "path",
+					Server:      "server",
+					Timeout:     "10",
+					Token:       "token",
+					Verbose:     true,
+				}),
+			),
+			res: "ansible-galaxy role remove --api-key=apikey --ignore-certs --roles-path=path --server=server --timeout=10 --token=token --verbose geerlingguy.docker geerlingguy.pip",
+		},
+		{
+			desc: "Testing AnsibleGalaxyRoleRemoveCmd to string using a custom binary",
+			cmd: NewAnsibleGalaxyRoleRemoveCmd(
+				WithBinary("custom-binary"),
+				WithRoleNames("geerlingguy.docker", "geerlingguy.pip"),
+			),
+			res: "custom-binary role remove geerlingguy.docker geerlingguy.pip",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, test.cmd.String())
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package galaxyroleremove
+
+import (
+	"context"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+)
+
+// AnsibleGalaxyRoleRemoveExecute is an executor for ansible-galaxy role remove command that runs the command using a DefaultExecute with default options
+type AnsibleGalaxyRoleRemoveExecute struct {
+	cmd  *AnsibleGalaxyRoleRemoveCmd
+	exec execute.Executabler
+}
+
+// NewAnsibleGalaxyRoleRemoveExecute returns a new AnsibleGalaxyRoleRemoveExecute. It receives the role names to remove
+func NewAnsibleGalaxyRoleRemoveExecute(roleNames ...string) *AnsibleGalaxyRoleRemoveExecute {
+
+	exec := &AnsibleGalaxyRoleRemoveExecute{
+		cmd: &AnsibleGalaxyRoleRemoveCmd{
+			RoleNames: append([]string{}, roleNames...),
+		},
+	}
+
+	return exec
+}
+
+// WithBinary returns an AnsibleGalaxyRoleRemoveExecute with the binary file set
+func (e *AnsibleGalaxyRoleRemoveExecute) WithBinary(binary string) *AnsibleGalaxyRoleRemoveExecute {
+	e.cmd.Binary = binary
+
+	return e
+}
+
+// WithExecutable returns an AnsibleGalaxyRoleRemoveExecute with the executable used to run the command set
+func (e *AnsibleGalaxyRoleRemoveExecute) WithExecutable(executable execute.Executabler) *AnsibleGalaxyRoleRemoveExecute {
+	e.exec = executable
+
+	return e
+}
+
+// WithGalaxyRoleRemoveOptions returns an AnsibleGalaxyRoleRemoveExecute with the ansible-galaxy role remove options set
+func (e *AnsibleGalaxyRoleRemoveExecute) WithGalaxyRoleRemoveOptions(options *AnsibleGalaxyRoleRemoveOptions) *AnsibleGalaxyRoleRemoveExecute {
+	e.cmd.GalaxyRoleRemoveOptions = options
+
+	return e
+}
+
+// Execute method runs the ansible-galaxy role remove command using a DefaultExecute with default options
+func (e *AnsibleGalaxyRoleRemoveExecute) Execute(ctx context.Context) error {
+
+	options := []execute.ExecuteOptions{
+		execute.WithCmd(e.cmd),
+	}
+
+	if e.exec != nil {
+		options = append(options, execute.WithExecutable(e.exec))
+	}
+
+	exec := execute.NewDefaultExecute(options...)
+
+	err := exec.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package galaxyroleremove
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleGalaxyRoleRemoveExecute(t *testing.T) {
+	expect := &AnsibleGalaxyRoleRemoveExecute{
+		cmd: &AnsibleGalaxyRoleRemoveCmd{
+			RoleNames: []string{"geerlingguy.docker", "geerlingguy.pip"},
+		},
+	}
+
+	res := NewAnsibleGalaxyRoleRemoveExecute("geerlingguy.docker", "geerlingguy.pip")
+
+	assert.Equal(t, expect, res)
+}
+
+func TestAnsibleGalaxyRoleRemoveExecuteExecute(t *testing.T) {
+
+	e := exec.NewMockExec()
+	cmd := exec.NewMockCmd()
+
+	cmd.On("StdoutPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+	cmd.On("StderrPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+	cmd.On("Start").Return(nil)
+	cmd.On("Wait").Return(nil)
+	// the compatibility check detects the ansible version, unless it is already cached
+	versionCmd := exec.NewMockCmd()
+	versionCmd.On("Output").Return([]byte("ansible-galaxy [core 2.16.3]\n"), nil).Maybe()
+	e.On("CommandContext", context.TODO(), "custom-binary", []string{"--version"}).Return(versionCmd).Maybe()
+	e.On("CommandContext", context.TODO(), "custom-binary", []string{"role", "remove", "--api-key=apikey", "geerlingguy.docker", "geerlingguy.pip"}).Return(cmd)
+
+	err := NewAnsibleGalaxyRoleRemoveExecute("geerlingguy.docker", "geerlingguy.pip").
+		WithBinary("custom-binary").
+		WithExecutable(e).
+		WithGalaxyRoleRemoveOptions(&AnsibleGalaxyRoleRemoveOptions{
+			APIKey: "apikey",
+		}).
+		Execute(context.TODO())
+
+	assert.NoError(t, err)
+	e.AssertExpectations(t)
+	cmd.AssertExpectations(t)
+}
@@ -0,0 +1,146 @@
+package galaxyroleremove
+
+import (
+	"fmt"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnsibleGalaxyRoleRemoveOptionsGenerateCommandOptions(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyRoleRemoveOptions::GenerateCommandOptions)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyRoleRemoveOptions
+		err     error
+		expect  []string
+	}{
+		{
+			desc:    "Testing nil AnsibleGalaxyRoleRemoveOptions definition",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyRoleRemoveOptions is nil"),
+		},
+		{
+			desc:    "Testing an empty AnsibleGalaxyRoleRemoveOptions definition",
+			options: &AnsibleGalaxyRoleRemoveOptions{},
+			expect:  []string{},
+		},
+		{
+			desc: "Testing AnsibleGalaxyRoleRemoveOptions with all flags",
+			options: &AnsibleGalaxyRoleRemoveOptions{
+				APIKey:      "apikey",
+				IgnoreCerts: true,
+				RolesPath:   "path",
+				Server:      "server",
+				Timeout:     "10",
+				Token:       "token",
+				Verbose:     true,
+			},
+			expect: []string{
+				fmt.Sprintf("%s=%s", APIKeyFlag, "apikey"),
+				IgnoreCertsFlag,
+				fmt.Sprintf("%s=%s", RolesPathFlag, "path"),
+				fmt.Sprintf("%s=%s", ServerFlag, "server"),
+				fmt.Sprintf("%s=%s", TimeoutFlag, "10"),
+				fmt.Sprintf("%s=%s", TokenFlag, "token"),
+				VerboseFlag,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			options, err := test.options.GenerateCommandOptions()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.expect, options, "Unexpected options value")
+			}
+		})
+	}
+}
+
+func TestAnsibleGalaxyRoleRemoveOptionsString(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyRoleRemoveOptions
+		expect  string
+	}{
+		{
+			desc:    "Testing generate string from an empty AnsibleGalaxyRoleRemoveOptions",
+			options: &AnsibleGalaxyRoleRemoveOptions{},
+			expect:  "",
+		},
+		{
+			desc: "Testing generate string from an AnsibleGalaxyRoleRemoveOptions with all flags",
+			options: &AnsibleGalaxyRoleRemoveOptions{
+				APIKey:      "apikey",
+				IgnoreCerts: true,
+				RolesPath:   "path",
+				Server:      "server",
+				Timeout:     "10",
+				Token:       "token",
+				Verbose:     true,
+			},
+			expect: "--api-key=apikey --ignore-certs --roles-path=path --server=server --timeout=10 --token=token --verbose",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expect, test.options.String())
+		})
+	}
+}
+
+func TestAnsibleGalaxyRoleRemoveOptionsValidate(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyRoleRemoveOptions::Validate)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyRoleRemoveOptions
+		err     error
+	}{
+		{
+			desc:    "Testing validate nil AnsibleGalaxyRoleRemoveOptions",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyRoleRemoveOptions is nil"),
+		},
+		{
+			desc: "Testing validate valid AnsibleGalaxyRoleRemoveOptions",
+			options: &AnsibleGalaxyRoleRemoveOptions{
+				RolesPath: "path",
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing validate invalid AnsibleGalaxyRoleRemoveOptions",
+			options: &AnsibleGalaxyRoleRemoveOptions{
+				APIKey:  "apikey",
+				Timeout: "ten",
+				Token:   "token",
+			},
+			err: errors.New(errContext, "Invalid ansible-galaxy role remove options",
+				fmt.Errorf("'%s' and '%s' are mutually exclusive", APIKeyFlag, TokenFlag),
+				fmt.Errorf("'%s' must be a positive integer, but 'ten' was provided", TimeoutFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.Validate()
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
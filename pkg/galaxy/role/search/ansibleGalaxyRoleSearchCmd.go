@@ -0,0 +1,125 @@
+package galaxyrolesearch
+
+import (
+	"fmt"
+
+	galaxy "github.com/apenella/go-ansible/v2/pkg/galaxy"
+	galaxyrole "github.com/apenella/go-ansible/v2/pkg/galaxy/role"
+)
+
+const (
+	// AnsibleGalaxyRoleSearchSubCommand is the ansible-galaxy role search subcommand
+	AnsibleGalaxyRoleSearchSubCommand = "search"
+)
+
+// AnsibleGalaxyRoleSearchOptionsFunc is a function to set executor options
+type AnsibleGalaxyRoleSearchOptionsFunc func(*AnsibleGalaxyRoleSearchCmd)
+
+// AnsibleGalaxyRoleSearchCmd object is the main object which defines the `ansible-galaxy` command to search roles on the Galaxy server.
+type AnsibleGalaxyRoleSearchCmd struct {
+	// Binary is the ansible-galaxy binary file
+	Binary string
+
+	// SearchTerms are the terms to search roles by. The roles are only filtered by the options when it is empty
+	SearchTerms []string
+
+	// GalaxyRoleSearchOptions are the ansible-galaxy's role search options
+	GalaxyRoleSearchOptions *AnsibleGalaxyRoleSearchOptions
+
+	// SkipValidation disables the role search options validation when the command is generated
+	SkipValidation bool
+}
+
+// NewAnsibleGalaxyRoleSearchCmd creates a new AnsibleGalaxyRoleSearchCmd instance
+func NewAnsibleGalaxyRoleSearchCmd(options ...AnsibleGalaxyRoleSearchOptionsFunc) *AnsibleGalaxyRoleSearchCmd {
+	cmd := &AnsibleGalaxyRoleSearchCmd{}
+
+	for _, option := range options {
+		option(cmd)
+	}
+
+	return cmd
+}
+
+// WithBinary set the ansible-galaxy binary file
+func WithBinary(binary string) AnsibleGalaxyRoleSearchOptionsFunc {
+	return func(p *AnsibleGalaxyRoleSearchCmd) {
+		p.Binary = binary
+	}
+}
+
+// WithSearchTerms set the terms to search roles by
+func WithSearchTerms(searchTerms ...string) AnsibleGalaxyRoleSearchOptionsFunc {
+	return func(p *AnsibleGalaxyRoleSearchCmd) {
+		p.SearchTerms = append([]string{}, searchTerms...)
+	}
+}
+
+// WithGalaxyRoleSearchOptions set the ansible-galaxy role search options
+func WithGalaxyRoleSearchOptions(options *AnsibleGalaxyRoleSearchOptions) AnsibleGalaxyRoleSearchOptionsFunc {
+	return func(p *AnsibleGalaxyRoleSearchCmd) {
+		p.GalaxyRoleSearchOptions = options
+	}
+}
+
+// WithoutValidation disables the ansible-galaxy role search options validation
+func WithoutValidation() AnsibleGalaxyRoleSearchOptionsFunc {
+	return func(p *AnsibleGalaxyRoleSearchCmd) {
+		p.SkipValidation = true
+	}
+}
+
+// Command generate the ansible-galaxy role search command which will be executed
+func (p *AnsibleGalaxyRoleSearchCmd) Command() ([]string, error) {
+	cmd := []string{}
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = galaxy.DefaultAnsibleGalaxyBinary
+	}
+
+	cmd = append(cmd, p.Binary, galaxyrole.AnsibleGalaxyRoleSubCommand, AnsibleGalaxyRoleSearchSubCommand)
+
+	// Add the options
+	if p.GalaxyRoleSearchOptions != nil {
+		if !p.SkipValidation {
+			err := p.GalaxyRoleSearchOptions.Validate()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		options, err := p.GalaxyRoleSearchOptions.GenerateCommandOptions()
+		if err != nil {
+			return nil, err
+		}
+		cmd = append(cmd, options...)
+	}
+
+	// Add the search terms
+	cmd = append(cmd, p.SearchTerms...)
+
+	return cmd, nil
+}
+
+// String returns the ansible-galaxy role search command as a string
+func (p *AnsibleGalaxyRoleSearchCmd) String() string {
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = galaxy.DefaultAnsibleGalaxyBinary
+	}
+
+	str := fmt.Sprintf("%s %s %s", p.Binary, galaxyrole.AnsibleGalaxyRoleSubCommand, AnsibleGalaxyRoleSearchSubCommand)
+
+	if p.GalaxyRoleSearchOptions != nil {
+		str = fmt.Sprintf("%s %s", str, p.GalaxyRoleSearchOptions.String())
+	}
+
+	// Include the search terms
+	for _, searchTerm := range p.SearchTerms {
+		str = fmt.Sprintf("%s %s", str, searchTerm)
+	}
+
+	return str
+}
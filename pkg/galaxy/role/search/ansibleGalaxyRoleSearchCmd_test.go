@@ -0,0 +1,145 @@
+package galaxyrolesearch
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/galaxy"
+	galaxyrole "github.com/apenella/go-ansible/v2/pkg/galaxy/role"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleGalaxyRoleSearchCmd(t *testing.T) {
+	cmd := NewAnsibleGalaxyRoleSearchCmd(
+		WithBinary("ansible-galaxy-binary"),
+		WithSearchTerms("docker", "nginx"),
+		WithGalaxyRoleSearchOptions(&AnsibleGalaxyRoleSearchOptions{
+			APIKey: "apikey",
+		}),
+	)
+
+	expect := &AnsibleGalaxyRoleSearchCmd{
+		Binary:      "ansible-galaxy-binary",
+		SearchTerms: []string{"docker", "nginx"},
+		GalaxyRoleSearchOptions: &AnsibleGalaxyRoleSearchOptions{
+			APIKey: "apikey",
+		},
+	}
+
+	assert.Equal(t, expect, cmd)
+}
+
+func TestAnsibleGalaxyRoleSearchCmdCommand(t *testing.T) {
+
+	tests := []struct {
+		desc    string
+		cmd     *AnsibleGalaxyRoleSearchCmd
+		command []string
+		err     error
+	}{
+		{
+			desc: "Testing generate a command for AnsibleGalaxyRoleSearchCmd with all flags using default binary",
+			cmd: NewAnsibleGalaxyRoleSearchCmd(
+				WithoutValidation(),
+				WithSearchTerms("docker", "nginx"),
+				WithGalaxyRoleSearchOptions(&AnsibleGalaxyRoleSearchOptions{
+					APIKey:      "apikey",
+					Author:      "author",
+					GalaxyTags:  "web,docker",
+					IgnoreCerts: true,
+					Platforms:   "EL",
+					Server:      "server",
+					Timeout:     "10",
+					Token:       "token",
+					Verbose:     true,
+				}),
+			),
+			err: &errors.Error{},
+			command: []string{
+				galaxy.DefaultAnsibleGalaxyBinary,
+				galaxyrole.AnsibleGalaxyRoleSubCommand,
+				AnsibleGalaxyRoleSearchSubCommand,
+				fmt.Sprintf("%s=%s", APIKeyFlag, "apikey"),
+				fmt.Sprintf("%s=%s", AuthorFlag, "author"),
+				fmt.Sprintf("%s=%s", GalaxyTagsFlag, "web,docker"),
+				IgnoreCertsFlag,
+				fmt.Sprintf("%s=%s", PlatformsFlag, "EL"),
+				fmt.Sprintf("%s=%s", ServerFlag, "server"),
+				fmt.Sprintf("%s=%s", TimeoutFlag, "10"),
+				fmt.Sprintf("%s=%s", TokenFlag, "token"),
+				VerboseFlag,
+				"docker",
+				"nginx",
+			},
+		},
+		{
+			desc: "Testing error generating a command for AnsibleGalaxyRoleSearchCmd with invalid options",
+			cmd: NewAnsibleGalaxyRoleSearchCmd(
+				WithGalaxyRoleSearchOptions(&AnsibleGalaxyRoleSearchOptions{
+					Timeout: "0",
+				}),
+			),
+			err: errors.New("(galaxy::AnsibleGalaxyRoleSearchOptions::Validate)", "Invalid ansible-galaxy role search options",
+				fmt.Errorf("'%s' must be a positive integer, but '0' was provided", TimeoutFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			command, err := test.cmd.Command()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.command, command)
+			}
+		})
+	}
+}
+
+func TestAnsibleGalaxyRoleSearchCmdString(t *testing.T) {
+
+	tests := []struct {
+		desc string
+		cmd  *AnsibleGalaxyRoleSearchCmd
+		res  string
+	}{
+		{
+			desc: "Testing AnsibleGalaxyRoleSearchCmd to string with all flags",
+			cmd: NewAnsibleGalaxyRoleSearchCmd(
+				WithSearchTerms("docker", "nginx"),
+				WithGalaxyRoleSearchOptions(&AnsibleGalaxyRoleSearchOptions{
+					APIKey:      "apikey",
+					Author:      "author",
+					GalaxyTags:  "web,docker",
+					IgnoreCerts: true,
+					Platforms:   "EL",
+					Server:      "server",
+					Timeout:     "10",
+					Token:       "token",
+					Verbose:     true,
+				}),
+			),
+			res: "ansible-galaxy role search --api-key=apikey --author=author --galaxy-tags=web,docker --ignore-certs --platforms=EL --server=server --timeout=10 --token=token --verbose docker nginx",
+		},
+		{
+			desc: "Testing AnsibleGalaxyRoleSearchCmd to string using a custom binary",
+			cmd: NewAnsibleGalaxyRoleSearchCmd(
+				WithBinary("custom-binary"),
+				WithSearchTerms("docker", "nginx"),
+			),
+			res: "custom-binary role search docker nginx",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, test.cmd.String())
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package galaxyrolesearch
+
+import (
+	"context"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+)
+
+// AnsibleGalaxyRoleSearchExecute is an executor for ansible-galaxy role search command that runs the command using a DefaultExecute with default options
+type AnsibleGalaxyRoleSearchExecute struct {
+	cmd  *AnsibleGalaxyRoleSearchCmd
+	exec execute.Executabler
+}
+
+// NewAnsibleGalaxyRoleSearchExecute returns a new AnsibleGalaxyRoleSearchExecute. It receives the terms to search roles by
+func NewAnsibleGalaxyRoleSearchExecute(searchTerms ...string) *AnsibleGalaxyRoleSearchExecute {
+
+	exec := &AnsibleGalaxyRoleSearchExecute{
+		cmd: &AnsibleGalaxyRoleSearchCmd{
+			SearchTerms: append([]string{}, searchTerms...),
+		},
+	}
+
+	return exec
+}
+
+// WithBinary returns an AnsibleGalaxyRoleSearchExecute with the binary file set
+func (e *AnsibleGalaxyRoleSearchExecute) WithBinary(binary string) *AnsibleGalaxyRoleSearchExecute {
+	e.cmd.Binary = binary
+
+	return e
+}
+
+// WithExecutable returns an AnsibleGalaxyRoleSearchExecute with the executable used to run the command set
+func (e *AnsibleGalaxyRoleSearchExecute) WithExecutable(executable execute.Executabler) *AnsibleGalaxyRoleSearchExecute {
+	e.exec = executable
+
+	return e
+}
+
+// WithGalaxyRoleSearchOptions returns an AnsibleGalaxyRoleSearchExecute with the ansible-galaxy role search options set
+func (e *AnsibleGalaxyRoleSearchExecute) WithGalaxyRoleSearchOptions(options *AnsibleGalaxyRoleSearchOptions) *AnsibleGalaxyRoleSearchExecute {
+	e.cmd.GalaxyRoleSearchOptions = options
+
+	return e
+}
+
+// Execute method runs the ansible-galaxy role search command using a DefaultExecute with default options
+func (e *AnsibleGalaxyRoleSearchExecute) Execute(ctx context.Context) error {
+
+	options := []execute.ExecuteOptions{
+		execute.WithCmd(e.cmd),
+	}
+
+	if e.exec != nil {
+		options = append(options, execute.WithExecutable(e.exec))
+	}
+
+	exec := execute.NewDefaultExecute(options...)
+
+	err := exec.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package galaxyrolesearch
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+
+	// APIKeyFlag the Ansible Galaxy API key. Same as --token
+	APIKeyFlag = "--api-key"
+
+	// AuthorFlag is the GitHub username to filter the search by.
+	AuthorFlag = "--author"
+
+	// GalaxyTagsFlag is a comma separated list of galaxy tags to filter the search by.
+	GalaxyTagsFlag = "--galaxy-tags"
+
+	// IgnoreCertsFlag ignores SSL certificate validation errors.
+	IgnoreCertsFlag = "--ignore-certs"
+
+	// PlatformsFlag is a comma separated list of OS platforms to filter the search by.
+	PlatformsFlag = "--platforms"
+
+	// ServerFlag is the Galaxy API server URL.
+	ServerFlag = "--server"
+
+	// TimeoutFlag is the time to wait for operations against the galaxy server, defaults to 60s.
+	TimeoutFlag = "--timeout"
+
+	// TokenFlag the Ansible Galaxy API key. Same as --api-key
+	TokenFlag = "--token"
+
+	// VerboseFlag verbose mode enabled
+	VerboseFlag = "--verbose"
+)
+
+// AnsibleGalaxyRoleSearchOptions are the ansible-galaxy role search options
+type AnsibleGalaxyRoleSearchOptions struct {
+
+	// APIKey is the Ansible Galaxy API key.
+	APIKey string
+
+	// Author is the GitHub username to filter the search by.
+	Author string
+
+	// GalaxyTags is a comma separated list of galaxy tags to filter the search by.
+	GalaxyTags string
+
+	// IgnoreCerts ignores SSL certificate validation errors.
+	IgnoreCerts bool
+
+	// Platforms is a comma separated list of OS platforms to filter the search by.
+	Platforms string
+
+	// Server is the Galaxy API server URL.
+	Server string
+
+	// Timeout is the time to wait for operations against the galaxy server, defaults to 60s.
+	Timeout string
+
+	// Token is the Ansible Galaxy API key.
+	Token string
+
+	// Verbose verbose mode enabled
+	Verbose bool
+}
+
+// GenerateCommandOptions return a list of command options flags to be used on ansible-galaxy role search execution
+func (o *AnsibleGalaxyRoleSearchOptions) GenerateCommandOptions() ([]string, error) {
+	errContext := "(galaxy::AnsibleGalaxyRoleSearchOptions::GenerateCommandOptions)"
+	options := []string{}
+
+	if o == nil {
+		return nil, errors.New(errContext, "AnsibleGalaxyRoleSearchOptions is nil")
+	}
+
+	if o.APIKey != "" {
+		options = append(options, fmt.Sprintf("%s=%s", APIKeyFlag, o.APIKey))
+	}
+
+	if o.Author != "" {
+		options = append(options, fmt.Sprintf("%s=%s", AuthorFlag, o.Author))
+	}
+
+	if o.GalaxyTags != "" {
+		options = append(options, fmt.Sprintf("%s=%s", GalaxyTagsFlag, o.GalaxyTags))
+	}
+
+	if o.IgnoreCerts {
+		options = append(options, IgnoreCertsFlag)
+	}
+
+	if o.Platforms != "" {
+		options = append(options, fmt.Sprintf("%s=%s", PlatformsFlag, o.Platforms))
+	}
+
+	if o.Server != "" {
+		options = append(options, fmt.Sprintf("%s=%s", ServerFlag, o.Server))
+	}
+
+	if o.Timeout != "" {
+		options = append(options, fmt.Sprintf("%s=%s", TimeoutFlag, o.Timeout))
+	}
+
+	if o.Token != "" {
+		options = append(options, fmt.Sprintf("%s=%s", TokenFlag, o.Token))
+	}
+
+	if o.Verbose {
+		options = append(options, VerboseFlag)
+	}
+
+	return options, nil
+}
+
+// Validate checks the options looking for mutually exclusive flags and unsupported values. It returns an error that wraps all the detected issues
+func (o *AnsibleGalaxyRoleSearchOptions) Validate() error {
+
+	errContext := "(galaxy::AnsibleGalaxyRoleSearchOptions::Validate)"
+	errs := []error{}
+
+	if o == nil {
+		return errors.New(errContext, "AnsibleGalaxyRoleSearchOptions is nil")
+	}
+
+	if o.APIKey != "" && o.Token != "" {
+		errs = append(errs, fmt.Errorf("'%s' and '%s' are mutually exclusive", APIKeyFlag, TokenFlag))
+	}
+
+	if o.Timeout != "" {
+		timeout, err := strconv.Atoi(o.Timeout)
+		if err != nil || timeout < 1 {
+			errs = append(errs, fmt.Errorf("'%s' must be a positive integer, but '%s' was provided", TimeoutFlag, o.Timeout))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid ansible-galaxy role search options", errs...)
+	}
+
+	return nil
+}
+
+// String return a string representation of the AnsibleGalaxyRoleSearchOptions
+func (o *AnsibleGalaxyRoleSearchOptions) String() string {
+	str := ""
+
+	if o.APIKey != "" {
+		str = fmt.Sprintf("%s %s=%s", str, APIKeyFlag, o.APIKey)
+	}
+
+	if o.Author != "" {
+		str = fmt.Sprintf("%s %s=%s", str, AuthorFlag, o.Author)
+	}
+
+	if o.GalaxyTags != "" {
+		str = fmt.Sprintf("%s %s=%s", str, GalaxyTagsFlag, o.GalaxyTags)
+	}
+
+	if o.IgnoreCerts {
+		str = fmt.Sprintf("%s %s", str, IgnoreCertsFlag)
+	}
+
+	if o.Platforms != "" {
+		str = fmt.Sprintf("%s %s=%s", str, PlatformsFlag, o.Platforms)
+	}
+
+	if o.Server != "" {
+		str = fmt.Sprintf("%s %s=%s", str, ServerFlag, o.Server)
+	}
+
+	if o.Timeout != "" {
+		str = fmt.Sprintf("%s %s=%s", str, TimeoutFlag, o.Timeout)
+	}
+
+	if o.Token != "" {
+		str = fmt.Sprintf("%s %s=%s", str, TokenFlag, o.Token)
+	}
+
+	if o.Verbose {
+		str = fmt.Sprintf("%s %s", str, VerboseFlag)
+	}
+
+	return strings.TrimSpace(str)
+}
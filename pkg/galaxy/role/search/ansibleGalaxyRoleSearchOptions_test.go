@@ -0,0 +1,153 @@
+package galaxyrolesearch
+
+import (
+	"fmt"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnsibleGalaxyRoleSearchOptionsGenerateCommandOptions(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyRoleSearchOptions::GenerateCommandOptions)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyRoleSearchOptions
+		err     error
+		expect  []string
+	}{
+		{
+			desc:    "Testing nil AnsibleGalaxyRoleSearchOptions definition",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyRoleSearchOptions is nil"),
+		},
+		{
+			desc:    "Testing an empty AnsibleGalaxyRoleSearchOptions definition",
+			options: &AnsibleGalaxyRoleSearchOptions{},
+			expect:  []string{},
+		},
+		{
+			desc: "Testing AnsibleGalaxyRoleSearchOptions with all flags",
+			options: &AnsibleGalaxyRoleSearchOptions{
+				APIKey:      "apikey",
+				Author:      "author",
+				GalaxyTags:  "web,docker",
+				IgnoreCerts: true,
+				Platforms:   "EL",
+				Server:      "server",
+				Timeout:     "10",
+				Token:       "token",
+				Verbose:     true,
+			},
+			expect: []string{
+				fmt.Sprintf("%s=%s", APIKeyFlag, "apikey"),
+				fmt.Sprintf("%s=%s", AuthorFlag, "author"),
+				fmt.Sprintf("%s=%s", GalaxyTagsFlag, "web,docker"),
+				IgnoreCertsFlag,
+				fmt.Sprintf("%s=%s", PlatformsFlag, "EL"),
+				fmt.Sprintf("%s=%s", ServerFlag, "server"),
+				fmt.Sprintf("%s=%s", TimeoutFlag, "10"),
+				fmt.Sprintf("%s=%s", TokenFlag, "token"),
+				VerboseFlag,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			options, err := test.options.GenerateCommandOptions()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.expect, options, "Unexpected options value")
+			}
+		})
+	}
+}
+
+func TestAnsibleGalaxyRoleSearchOptionsString(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyRoleSearchOptions
+		expect  string
+	}{
+		{
+			desc:    "Testing generate string from an empty AnsibleGalaxyRoleSearchOptions",
+			options: &AnsibleGalaxyRoleSearchOptions{},
+			expect:  "",
+		},
+		{
+			desc: "Testing generate string from an AnsibleGalaxyRoleSearchOptions with all flags",
+			options: &AnsibleGalaxyRoleSearchOptions{
+				APIKey:      "apikey",
+				Author:      "author",
+				GalaxyTags:  "web,docker",
+				IgnoreCerts: true,
+				Platforms:   "EL",
+				Server:      "server",
+				Timeout:     "10",
+				Token:       "token",
+				Verbose:     true,
+			},
+			expect: "--api-key=apikey --author=author --galaxy-tags=web,docker --ignore-certs --platforms=EL --server=server --timeout=10 --token=token --verbose",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expect, test.options.String())
+		})
+	}
+}
+
+func TestAnsibleGalaxyRoleSearchOptionsValidate(t *testing.T) {
+
+	errContext := "(galaxy::AnsibleGalaxyRoleSearchOptions::Validate)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleGalaxyRoleSearchOptions
+		err     error
+	}{
+		{
+			desc:    "Testing validate nil AnsibleGalaxyRoleSearchOptions",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleGalaxyRoleSearchOptions is nil"),
+		},
+		{
+			desc: "Testing validate valid AnsibleGalaxyRoleSearchOptions",
+			options: &AnsibleGalaxyRoleSearchOptions{
+				Author:     "geerlingguy",
+				GalaxyTags: "web,docker",
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing validate invalid AnsibleGalaxyRoleSearchOptions",
+			options: &AnsibleGalaxyRoleSearchOptions{
+				APIKey:  "apikey",
+				Timeout: "ten",
+				Token:   "token",
+			},
+			err: errors.New(errContext, "Invalid ansible-galaxy role search options",
+				fmt.Errorf("'%s' and '%s' are mutually exclusive", APIKeyFlag, TokenFlag),
+				fmt.Errorf("'%s' must be a positive integer, but 'ten' was provided", TimeoutFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.Validate()
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
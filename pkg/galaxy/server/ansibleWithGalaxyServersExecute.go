@@ -0,0 +1,53 @@
+package galaxyserver
+
+import (
+	"context"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/configuration"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// AnsibleWithGalaxyServersExecute is an executor that configures the Galaxy server list through environment variables before running the executor it wraps
+type AnsibleWithGalaxyServersExecute struct {
+	executor configuration.ExecutorEnvVarSetter
+	servers  GalaxyServerList
+}
+
+// NewAnsibleWithGalaxyServersExecute returns a new AnsibleWithGalaxyServersExecute
+func NewAnsibleWithGalaxyServersExecute(executor configuration.ExecutorEnvVarSetter, servers ...*GalaxyServer) *AnsibleWithGalaxyServersExecute {
+	return &AnsibleWithGalaxyServersExecute{
+		executor: executor,
+		servers:  NewGalaxyServerList(servers...),
+	}
+}
+
+// WithExecutor sets the executor to run once the Galaxy server list is configured
+func (e *AnsibleWithGalaxyServersExecute) WithExecutor(exec configuration.ExecutorEnvVarSetter) *AnsibleWithGalaxyServersExecute {
+	e.executor = exec
+	return e
+}
+
+// Execute sets the Galaxy server list environment variables on the executor and runs it. The tokens and passwords are read right before the execution
+func (e *AnsibleWithGalaxyServersExecute) Execute(ctx context.Context) error {
+	errContext := "(galaxy::AnsibleWithGalaxyServersExecute::Execute)"
+
+	if e.executor == nil {
+		return errors.New(errContext, "AnsibleWithGalaxyServersExecute executor requires an executor")
+	}
+
+	envVars, err := e.servers.EnvVars()
+	if err != nil {
+		return errors.New(errContext, "Error configuring the galaxy server list", err)
+	}
+
+	for _, key := range sortedKeys(envVars) {
+		e.executor.AddEnvVar(key, envVars[key])
+	}
+
+	err = e.executor.Execute(ctx)
+	if err != nil {
+		return errors.New(errContext, "Error executing command", err)
+	}
+
+	return nil
+}
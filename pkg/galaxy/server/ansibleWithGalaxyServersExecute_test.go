@@ -0,0 +1,82 @@
+package galaxyserver
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+	"github.com/apenella/go-ansible/v2/pkg/execute/configuration"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAnsibleWithGalaxyServersExecuteExecute(t *testing.T) {
+	errContext := "(galaxy::AnsibleWithGalaxyServersExecute::Execute)"
+
+	tests := []struct {
+		desc              string
+		exec              *AnsibleWithGalaxyServersExecute
+		executor          *execute.MockExecute
+		prepareAssertFunc func(*execute.MockExecute)
+		err               error
+	}{
+		{
+			desc:     "Testing execute with a galaxy server list",
+			executor: execute.NewMockExecute(),
+			exec:     NewAnsibleWithGalaxyServersExecute(nil, testServerList()...),
+			prepareAssertFunc: func(executor *execute.MockExecute) {
+				executor.On("AddEnvVar", configuration.AnsibleGalaxyServerList, "automation_hub,galaxy").Return()
+				executor.On("AddEnvVar", "ANSIBLE_GALAXY_SERVER_AUTOMATION_HUB_AUTH_URL", "https://sso.example.com/token").Return()
+				executor.On("AddEnvVar", "ANSIBLE_GALAXY_SERVER_AUTOMATION_HUB_TOKEN", "hub-token").Return()
+				executor.On("AddEnvVar", "ANSIBLE_GALAXY_SERVER_AUTOMATION_HUB_URL", "https://hub.example.com/api/automation-hub/").Return()
+				executor.On("AddEnvVar", "ANSIBLE_GALAXY_SERVER_GALAXY_URL", "https://galaxy.ansible.com/").Return()
+				executor.On("Execute", context.TODO()).Return(nil)
+			},
+			err: nil,
+		},
+		{
+			desc:     "Testing error executing the executor",
+			executor: execute.NewMockExecute(),
+			exec:     NewAnsibleWithGalaxyServersExecute(nil, NewGalaxyServer("galaxy", "https://galaxy.ansible.com/")),
+			prepareAssertFunc: func(executor *execute.MockExecute) {
+				executor.On("AddEnvVar", mock.Anything, mock.Anything).Return()
+				executor.On("Execute", context.TODO()).Return(fmt.Errorf("error"))
+			},
+			err: errors.New(errContext, "Error executing command", fmt.Errorf("error")),
+		},
+		{
+			desc:     "Testing error with an invalid galaxy server list",
+			executor: execute.NewMockExecute(),
+			exec:     NewAnsibleWithGalaxyServersExecute(nil),
+			err: errors.New(errContext, "Error configuring the galaxy server list",
+				errors.New("(galaxy::GalaxyServerList::EnvVars)", "Galaxy server list can not be configured",
+					errors.New("(galaxy::GalaxyServerList::Validate)", "Invalid galaxy server list",
+						fmt.Errorf("galaxy server list must define at least one server"),
+					),
+				),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.executor)
+			}
+
+			err := test.exec.WithExecutor(test.executor).Execute(context.TODO())
+			assert.Equal(t, test.err, err)
+			test.executor.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAnsibleWithGalaxyServersExecuteWithoutExecutor(t *testing.T) {
+	err := NewAnsibleWithGalaxyServersExecute(nil).Execute(context.TODO())
+
+	assert.Equal(t, errors.New("(galaxy::AnsibleWithGalaxyServersExecute::Execute)", "AnsibleWithGalaxyServersExecute executor requires an executor"), err)
+}
@@ -0,0 +1,263 @@
+package galaxyserver
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// AnsibleGalaxyServerEnvVarPrefix is the prefix of the environment variables that configure a Galaxy server
+	AnsibleGalaxyServerEnvVarPrefix = "ANSIBLE_GALAXY_SERVER_"
+
+	// APIVersionKey is the Galaxy server setting for the API version
+	APIVersionKey = "api_version"
+	// AuthURLKey is the Galaxy server setting for the SSO authentication URL
+	AuthURLKey = "auth_url"
+	// ClientIDKey is the Galaxy server setting for the SSO client ID
+	ClientIDKey = "client_id"
+	// PasswordKey is the Galaxy server setting for the basic authentication password
+	PasswordKey = "password"
+	// TimeoutKey is the Galaxy server setting for the API calls timeout
+	TimeoutKey = "timeout"
+	// TokenKey is the Galaxy server setting for the API token
+	TokenKey = "token"
+	// URLKey is the Galaxy server setting for the server URL
+	URLKey = "url"
+	// UsernameKey is the Galaxy server setting for the basic authentication username
+	UsernameKey = "username"
+	// ValidateCertsKey is the Galaxy server setting to validate the server TLS certificates
+	ValidateCertsKey = "validate_certs"
+)
+
+// idRegexp validates the Galaxy server ID, which is part of the environment variables names
+var idRegexp = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// Setting is a Galaxy server setting
+type Setting struct {
+	// Key is the setting name, as it is defined on the ansible.cfg galaxy_server section
+	Key string
+	// Value is the setting value
+	Value string
+}
+
+// PasswordReader reads a secret, such as a Galaxy server token or password
+type PasswordReader interface {
+	Read() (string, error)
+}
+
+// GalaxyServerOptionsFunc is a function to set the GalaxyServer options
+type GalaxyServerOptionsFunc func(*GalaxyServer)
+
+// GalaxyServer defines a server of the ansible-galaxy server list
+type GalaxyServer struct {
+	// APIVersion is the Galaxy API version used, instead of detecting it
+	APIVersion int
+	// AuthURL is the URL of the SSO server used to exchange the token, such as the Automation Hub one
+	AuthURL string
+	// ClientID is the SSO client ID
+	ClientID string
+	// ID identifies the server on the server list. It is part of the environment variables names, so it only accepts letters, digits and underscores
+	ID string
+	// Password reads the basic authentication password
+	Password PasswordReader
+	// Timeout is the API calls timeout in seconds
+	Timeout int
+	// Token reads the API token
+	Token PasswordReader
+	// URL is the server URL
+	URL string
+	// Username is the basic authentication username
+	Username string
+	// ValidateCerts sets whether the server TLS certificates are validated. The ansible-galaxy default applies when it is nil
+	ValidateCerts *bool
+}
+
+// NewGalaxyServer creates a new GalaxyServer
+func NewGalaxyServer(id, serverURL string, options ...GalaxyServerOptionsFunc) *GalaxyServer {
+	server := &GalaxyServer{
+		ID:  id,
+		URL: serverURL,
+	}
+
+	for _, option := range options {
+		option(server)
+	}
+
+	return server
+}
+
+// WithAPIVersion sets the Galaxy API version
+func WithAPIVersion(version int) GalaxyServerOptionsFunc {
+	return func(s *GalaxyServer) {
+		s.APIVersion = version
+	}
+}
+
+// WithAuthURL sets the SSO authentication URL
+func WithAuthURL(authURL string) GalaxyServerOptionsFunc {
+	return func(s *GalaxyServer) {
+		s.AuthURL = authURL
+	}
+}
+
+// WithClientID sets the SSO client ID
+func WithClientID(clientID string) GalaxyServerOptionsFunc {
+	return func(s *GalaxyServer) {
+		s.ClientID = clientID
+	}
+}
+
+// WithPassword sets the reader of the basic authentication password
+func WithPassword(reader PasswordReader) GalaxyServerOptionsFunc {
+	return func(s *GalaxyServer) {
+		s.Password = reader
+	}
+}
+
+// WithTimeout sets the API calls timeout in seconds
+func WithTimeout(timeout int) GalaxyServerOptionsFunc {
+	return func(s *GalaxyServer) {
+		s.Timeout = timeout
+	}
+}
+
+// WithToken sets the reader of the API token
+func WithToken(reader PasswordReader) GalaxyServerOptionsFunc {
+	return func(s *GalaxyServer) {
+		s.Token = reader
+	}
+}
+
+// WithUsername sets the basic authentication username
+func WithUsername(username string) GalaxyServerOptionsFunc {
+	return func(s *GalaxyServer) {
+		s.Username = username
+	}
+}
+
+// WithValidateCerts sets whether the server TLS certificates are validated
+func WithValidateCerts(validate bool) GalaxyServerOptionsFunc {
+	return func(s *GalaxyServer) {
+		s.ValidateCerts = &validate
+	}
+}
+
+// Validate checks the GalaxyServer definition
+func (s *GalaxyServer) Validate() error {
+	errContext := "(galaxy::GalaxyServer::Validate)"
+	errs := []error{}
+
+	if s.ID == "" {
+		errs = append(errs, fmt.Errorf("galaxy server ID must be defined"))
+	} else if !idRegexp.MatchString(s.ID) {
+		errs = append(errs, fmt.Errorf("galaxy server ID '%s' must only contain letters, digits and underscores", s.ID))
+	}
+
+	if s.URL == "" {
+		errs = append(errs, fmt.Errorf("galaxy server '%s' URL must be defined", s.ID))
+	} else if !isHTTPURL(s.URL) {
+		errs = append(errs, fmt.Errorf("galaxy server '%s' URL '%s' must be an absolute http or https URL", s.ID, s.URL))
+	}
+
+	if s.AuthURL != "" && !isHTTPURL(s.AuthURL) {
+		errs = append(errs, fmt.Errorf("galaxy server '%s' auth URL '%s' must be an absolute http or https URL", s.ID, s.AuthURL))
+	}
+
+	if s.Password != nil && s.Username == "" {
+		errs = append(errs, fmt.Errorf("galaxy server '%s' password requires a username", s.ID))
+	}
+
+	if s.Token != nil && s.Username != "" {
+		errs = append(errs, fmt.Errorf("galaxy server '%s' token and username are mutually exclusive", s.ID))
+	}
+
+	if s.Timeout < 0 {
+		errs = append(errs, fmt.Errorf("galaxy server '%s' timeout must be a positive number", s.ID))
+	}
+
+	if s.APIVersion < 0 {
+		errs = append(errs, fmt.Errorf("galaxy server '%s' API version must be a positive number", s.ID))
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid galaxy server", errs...)
+	}
+
+	return nil
+}
+
+// Settings returns the server settings, reading the token and the password from their readers. The settings are sorted by key
+func (s *GalaxyServer) Settings() ([]*Setting, error) {
+	errContext := "(galaxy::GalaxyServer::Settings)"
+
+	err := s.Validate()
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Galaxy server '%s' can not be configured", s.ID), err)
+	}
+
+	settings := []*Setting{}
+
+	if s.APIVersion > 0 {
+		settings = append(settings, &Setting{Key: APIVersionKey, Value: strconv.Itoa(s.APIVersion)})
+	}
+
+	if s.AuthURL != "" {
+		settings = append(settings, &Setting{Key: AuthURLKey, Value: s.AuthURL})
+	}
+
+	if s.ClientID != "" {
+		settings = append(settings, &Setting{Key: ClientIDKey, Value: s.ClientID})
+	}
+
+	if s.Password != nil {
+		password, err := s.Password.Read()
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Error reading the galaxy server '%s' password", s.ID), err)
+		}
+		settings = append(settings, &Setting{Key: PasswordKey, Value: password})
+	}
+
+	if s.Timeout > 0 {
+		settings = append(settings, &Setting{Key: TimeoutKey, Value: strconv.Itoa(s.Timeout)})
+	}
+
+	if s.Token != nil {
+		token, err := s.Token.Read()
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Error reading the galaxy server '%s' token", s.ID), err)
+		}
+		settings = append(settings, &Setting{Key: TokenKey, Value: token})
+	}
+
+	settings = append(settings, &Setting{Key: URLKey, Value: s.URL})
+
+	if s.Username != "" {
+		settings = append(settings, &Setting{Key: UsernameKey, Value: s.Username})
+	}
+
+	if s.ValidateCerts != nil {
+		settings = append(settings, &Setting{Key: ValidateCertsKey, Value: strconv.FormatBool(*s.ValidateCerts)})
+	}
+
+	return settings, nil
+}
+
+// EnvVarName returns the name of the environment variable that configures the server setting key
+func (s *GalaxyServer) EnvVarName(key string) string {
+	return AnsibleGalaxyServerEnvVarPrefix + strings.ToUpper(s.ID) + "_" + strings.ToUpper(key)
+}
+
+// isHTTPURL returns whether value is an absolute http or https URL
+func isHTTPURL(value string) bool {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
@@ -0,0 +1,111 @@
+package galaxyserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// apiPath is the path of the Galaxy API root
+const apiPath = "/api/"
+
+// Check verifies that the server is reachable and serves the Galaxy API, and returns the available API versions. It requests the server URL and, when it is not the API root, the /api/ path under it. The token is sent on the Authorization header unless the server uses an SSO authentication URL, since that token must be exchanged first
+func (s *GalaxyServer) Check(ctx context.Context, client *http.Client) (map[string]string, error) {
+	errContext := "(galaxy::GalaxyServer::Check)"
+
+	err := s.Validate()
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Galaxy server '%s' can not be checked", s.ID), err)
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	candidates := []string{s.URL}
+	root := strings.TrimSuffix(s.URL, "/")
+	if !strings.HasSuffix(root, strings.TrimSuffix(apiPath, "/")) {
+		candidates = append(candidates, root+apiPath)
+	}
+
+	errs := []error{}
+	for _, candidate := range candidates {
+		versions, err := s.availableVersions(ctx, client, candidate)
+		if err == nil {
+			return versions, nil
+		}
+		errs = append(errs, err)
+	}
+
+	return nil, errors.New(errContext, fmt.Sprintf("Galaxy server '%s' is not reachable", s.ID), errs...)
+}
+
+// availableVersions requests the Galaxy API root endpoint and returns its available API versions
+func (s *GalaxyServer) availableVersions(ctx context.Context, client *http.Client, endpoint string) (map[string]string, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "application/json")
+
+	err = s.authenticate(request)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("'%s' responded with status '%s'", endpoint, response.Status)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	root := struct {
+		AvailableVersions map[string]string `json:"available_versions"`
+	}{}
+
+	err = json.Unmarshal(body, &root)
+	if err != nil || len(root.AvailableVersions) == 0 {
+		return nil, fmt.Errorf("'%s' is not a Galaxy API root", endpoint)
+	}
+
+	return root.AvailableVersions, nil
+}
+
+// authenticate sets the server credentials on the request
+func (s *GalaxyServer) authenticate(request *http.Request) error {
+	if s.Token != nil && s.AuthURL == "" {
+		token, err := s.Token.Read()
+		if err != nil {
+			return fmt.Errorf("error reading the galaxy server '%s' token: %w", s.ID, err)
+		}
+		request.Header.Set("Authorization", "Token "+token)
+	}
+
+	if s.Username != "" {
+		password := ""
+		if s.Password != nil {
+			var err error
+			password, err = s.Password.Read()
+			if err != nil {
+				return fmt.Errorf("error reading the galaxy server '%s' password: %w", s.ID, err)
+			}
+		}
+		request.SetBasicAuth(s.Username, password)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package galaxyserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGalaxyServerCheck(t *testing.T) {
+	apiRoot := `{"available_versions":{"v3":"v3/"}}`
+
+	standIn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/":
+			fmt.Fprint(w, apiRoot)
+		case "/api/automation-hub/":
+			if r.Header.Get("Authorization") != "Token hub-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, apiRoot)
+		case "/":
+			fmt.Fprint(w, "<html></html>")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer standIn.Close()
+
+	tests := []struct {
+		desc   string
+		server *GalaxyServer
+		res    map[string]string
+		err    bool
+	}{
+		{
+			desc:   "Testing check a galaxy server through its API root",
+			server: NewGalaxyServer("galaxy", standIn.URL+"/"),
+			res:    map[string]string{"v3": "v3/"},
+		},
+		{
+			desc: "Testing check a galaxy server that requires a token",
+			server: NewGalaxyServer("hub", standIn.URL+"/api/automation-hub/",
+				WithToken(text.NewReadPasswordFromText(text.WithText("hub-token"))),
+			),
+			res: map[string]string{"v3": "v3/"},
+		},
+		{
+			desc: "Testing error checking a galaxy server with a wrong token",
+			server: NewGalaxyServer("hub", standIn.URL+"/api/automation-hub/",
+				WithToken(text.NewReadPasswordFromText(text.WithText("wrong"))),
+			),
+			err: true,
+		},
+		{
+			desc:   "Testing error checking a server that does not serve the Galaxy API",
+			server: NewGalaxyServer("unknown", standIn.URL+"/unknown/"),
+			err:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := test.server.Check(context.TODO(), standIn.Client())
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
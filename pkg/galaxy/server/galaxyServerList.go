@@ -0,0 +1,145 @@
+package galaxyserver
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/configuration"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// galaxySection is the ansible.cfg section that holds the server list
+	galaxySection = "galaxy"
+	// galaxyServerSectionPrefix is the prefix of the ansible.cfg sections that define each server
+	galaxyServerSectionPrefix = "galaxy_server."
+	// serverListKey is the ansible.cfg key of the server list
+	serverListKey = "server_list"
+)
+
+// GalaxyServerList is the ordered list of Galaxy servers used by ansible-galaxy. The collections are resolved from the servers in the list order
+type GalaxyServerList []*GalaxyServer
+
+// NewGalaxyServerList creates a new GalaxyServerList
+func NewGalaxyServerList(servers ...*GalaxyServer) GalaxyServerList {
+	return GalaxyServerList(servers)
+}
+
+// IDs returns the servers IDs in the list order
+func (l GalaxyServerList) IDs() []string {
+	ids := []string{}
+	for _, server := range l {
+		ids = append(ids, server.ID)
+	}
+
+	return ids
+}
+
+// Validate checks the servers definition and that the IDs are unique. The IDs are compared case insensitively because they are upper cased on the environment variables names
+func (l GalaxyServerList) Validate() error {
+	errContext := "(galaxy::GalaxyServerList::Validate)"
+	errs := []error{}
+
+	if len(l) == 0 {
+		errs = append(errs, fmt.Errorf("galaxy server list must define at least one server"))
+	}
+
+	ids := map[string]string{}
+	for _, server := range l {
+		if server == nil {
+			errs = append(errs, fmt.Errorf("galaxy server list must not contain undefined servers"))
+			continue
+		}
+
+		err := server.Validate()
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		id := strings.ToUpper(server.ID)
+		previous, exists := ids[id]
+		if exists {
+			errs = append(errs, fmt.Errorf("galaxy server ID '%s' is duplicated by '%s'", server.ID, previous))
+			continue
+		}
+		ids[id] = server.ID
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid galaxy server list", errs...)
+	}
+
+	return nil
+}
+
+// EnvVars returns the ANSIBLE_GALAXY_SERVER_LIST environment variable and the ANSIBLE_GALAXY_SERVER_<ID>_<SETTING> environment variables of each server
+func (l GalaxyServerList) EnvVars() (map[string]string, error) {
+	errContext := "(galaxy::GalaxyServerList::EnvVars)"
+
+	err := l.Validate()
+	if err != nil {
+		return nil, errors.New(errContext, "Galaxy server list can not be configured", err)
+	}
+
+	envVars := map[string]string{
+		configuration.AnsibleGalaxyServerList: strings.Join(l.IDs(), ","),
+	}
+
+	for _, server := range l {
+		settings, err := server.Settings()
+		if err != nil {
+			return nil, errors.New(errContext, "Galaxy server list can not be configured", err)
+		}
+
+		for _, setting := range settings {
+			envVars[server.EnvVarName(setting.Key)] = setting.Value
+		}
+	}
+
+	return envVars, nil
+}
+
+// WriteConfig writes the galaxy and galaxy_server sections of an ansible.cfg file. The tokens and passwords are written in plain text, so the file must be protected accordingly
+func (l GalaxyServerList) WriteConfig(w io.Writer) error {
+	errContext := "(galaxy::GalaxyServerList::WriteConfig)"
+
+	err := l.Validate()
+	if err != nil {
+		return errors.New(errContext, "Galaxy server list can not be configured", err)
+	}
+
+	config := &strings.Builder{}
+	fmt.Fprintf(config, "[%s]\n%s = %s\n", galaxySection, serverListKey, strings.Join(l.IDs(), ", "))
+
+	for _, server := range l {
+		settings, err := server.Settings()
+		if err != nil {
+			return errors.New(errContext, "Galaxy server list can not be configured", err)
+		}
+
+		fmt.Fprintf(config, "\n[%s%s]\n", galaxyServerSectionPrefix, server.ID)
+		for _, setting := range settings {
+			fmt.Fprintf(config, "%s = %s\n", setting.Key, setting.Value)
+		}
+	}
+
+	_, err = io.WriteString(w, config.String())
+	if err != nil {
+		return errors.New(errContext, "Error writing the galaxy server list configuration", err)
+	}
+
+	return nil
+}
+
+// sortedKeys returns the keys of envVars sorted
+func sortedKeys(envVars map[string]string) []string {
+	keys := make([]string, 0, len(envVars))
+	for key := range envVars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,132 @@
+package galaxyserver
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/configuration"
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func testServerList() GalaxyServerList {
+	return NewGalaxyServerList(
+		NewGalaxyServer("automation_hub", "https://hub.example.com/api/automation-hub/",
+			WithAuthURL("https://sso.example.com/token"),
+			WithToken(text.NewReadPasswordFromText(text.WithText("hub-token"))),
+		),
+		NewGalaxyServer("galaxy", "https://galaxy.ansible.com/"),
+	)
+}
+
+func TestGalaxyServerListValidate(t *testing.T) {
+	errContext := "(galaxy::GalaxyServerList::Validate)"
+
+	tests := []struct {
+		desc    string
+		servers GalaxyServerList
+		err     error
+	}{
+		{
+			desc:    "Testing validate a galaxy server list",
+			servers: testServerList(),
+			err:     nil,
+		},
+		{
+			desc:    "Testing validate an empty galaxy server list",
+			servers: NewGalaxyServerList(),
+			err: errors.New(errContext, "Invalid galaxy server list",
+				fmt.Errorf("galaxy server list must define at least one server"),
+			),
+		},
+		{
+			desc: "Testing validate a galaxy server list with duplicated IDs",
+			servers: NewGalaxyServerList(
+				NewGalaxyServer("galaxy", "https://galaxy.ansible.com/"),
+				NewGalaxyServer("GALAXY", "https://old-galaxy.ansible.com/"),
+				nil,
+			),
+			err: errors.New(errContext, "Invalid galaxy server list",
+				fmt.Errorf("galaxy server ID 'GALAXY' is duplicated by 'galaxy'"),
+				fmt.Errorf("galaxy server list must not contain undefined servers"),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.servers.Validate()
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+func TestGalaxyServerListEnvVars(t *testing.T) {
+	tests := []struct {
+		desc    string
+		servers GalaxyServerList
+		res     map[string]string
+		err     error
+	}{
+		{
+			desc:    "Testing galaxy server list environment variables",
+			servers: testServerList(),
+			res: map[string]string{
+				configuration.AnsibleGalaxyServerList:           "automation_hub,galaxy",
+				"ANSIBLE_GALAXY_SERVER_AUTOMATION_HUB_AUTH_URL": "https://sso.example.com/token",
+				"ANSIBLE_GALAXY_SERVER_AUTOMATION_HUB_TOKEN":    "hub-token",
+				"ANSIBLE_GALAXY_SERVER_AUTOMATION_HUB_URL":      "https://hub.example.com/api/automation-hub/",
+				"ANSIBLE_GALAXY_SERVER_GALAXY_URL":              "https://galaxy.ansible.com/",
+			},
+			err: nil,
+		},
+		{
+			desc:    "Testing error on galaxy server list environment variables",
+			servers: NewGalaxyServerList(),
+			res:     nil,
+			err: errors.New("(galaxy::GalaxyServerList::EnvVars)", "Galaxy server list can not be configured",
+				errors.New("(galaxy::GalaxyServerList::Validate)", "Invalid galaxy server list",
+					fmt.Errorf("galaxy server list must define at least one server"),
+				),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := test.servers.EnvVars()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Nil(t, test.err)
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestGalaxyServerListWriteConfig(t *testing.T) {
+	expect := `[galaxy]
+server_list = automation_hub, galaxy
+
+[galaxy_server.automation_hub]
+auth_url = https://sso.example.com/token
+token = hub-token
+url = https://hub.example.com/api/automation-hub/
+
+[galaxy_server.galaxy]
+url = https://galaxy.ansible.com/
+`
+
+	buff := &bytes.Buffer{}
+	err := testServerList().WriteConfig(buff)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expect, buff.String())
+}
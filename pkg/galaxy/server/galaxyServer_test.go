@@ -0,0 +1,166 @@
+package galaxyserver
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGalaxyServer(t *testing.T) {
+	token := text.NewReadPasswordFromText(text.WithText("secret"))
+	validate := false
+
+	expect := &GalaxyServer{
+		APIVersion:    3,
+		AuthURL:       "https://sso.example.com/token",
+		ClientID:      "cloud-services",
+		ID:            "automation_hub",
+		Timeout:       60,
+		Token:         token,
+		URL:           "https://hub.example.com/api/",
+		ValidateCerts: &validate,
+	}
+
+	res := NewGalaxyServer("automation_hub", "https://hub.example.com/api/",
+		WithAPIVersion(3),
+		WithAuthURL("https://sso.example.com/token"),
+		WithClientID("cloud-services"),
+		WithTimeout(60),
+		WithToken(token),
+		WithValidateCerts(false),
+	)
+
+	assert.Equal(t, expect, res)
+}
+
+func TestGalaxyServerValidate(t *testing.T) {
+	errContext := "(galaxy::GalaxyServer::Validate)"
+
+	tests := []struct {
+		desc   string
+		server *GalaxyServer
+		err    error
+	}{
+		{
+			desc:   "Testing validate a galaxy server",
+			server: NewGalaxyServer("galaxy", "https://galaxy.ansible.com/"),
+			err:    nil,
+		},
+		{
+			desc:   "Testing validate a galaxy server without ID and URL",
+			server: NewGalaxyServer("", ""),
+			err: errors.New(errContext, "Invalid galaxy server",
+				fmt.Errorf("galaxy server ID must be defined"),
+				fmt.Errorf("galaxy server '' URL must be defined"),
+			),
+		},
+		{
+			desc: "Testing validate a galaxy server with invalid values",
+			server: NewGalaxyServer("private-hub", "hub.example.com",
+				WithAuthURL("ftp://sso.example.com"),
+				WithPassword(text.NewReadPasswordFromText(text.WithText("secret"))),
+				WithTimeout(-1),
+				WithAPIVersion(-1),
+			),
+			err: errors.New(errContext, "Invalid galaxy server",
+				fmt.Errorf("galaxy server ID 'private-hub' must only contain letters, digits and underscores"),
+				fmt.Errorf("galaxy server 'private-hub' URL 'hub.example.com' must be an absolute http or https URL"),
+				fmt.Errorf("galaxy server 'private-hub' auth URL 'ftp://sso.example.com' must be an absolute http or https URL"),
+				fmt.Errorf("galaxy server 'private-hub' password requires a username"),
+				fmt.Errorf("galaxy server 'private-hub' timeout must be a positive number"),
+				fmt.Errorf("galaxy server 'private-hub' API version must be a positive number"),
+			),
+		},
+		{
+			desc: "Testing validate a galaxy server with token and username",
+			server: NewGalaxyServer("hub", "https://hub.example.com/",
+				WithToken(text.NewReadPasswordFromText(text.WithText("secret"))),
+				WithUsername("user"),
+			),
+			err: errors.New(errContext, "Invalid galaxy server",
+				fmt.Errorf("galaxy server 'hub' token and username are mutually exclusive"),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.server.Validate()
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+func TestGalaxyServerSettings(t *testing.T) {
+	tests := []struct {
+		desc   string
+		server *GalaxyServer
+		res    []*Setting
+		err    error
+	}{
+		{
+			desc: "Testing galaxy server settings",
+			server: NewGalaxyServer("hub", "https://hub.example.com/",
+				WithAuthURL("https://sso.example.com/token"),
+				WithTimeout(30),
+				WithToken(text.NewReadPasswordFromText(text.WithText("secret"))),
+				WithValidateCerts(true),
+			),
+			res: []*Setting{
+				{Key: AuthURLKey, Value: "https://sso.example.com/token"},
+				{Key: TimeoutKey, Value: "30"},
+				{Key: TokenKey, Value: "secret"},
+				{Key: URLKey, Value: "https://hub.example.com/"},
+				{Key: ValidateCertsKey, Value: "true"},
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing galaxy server settings with basic authentication",
+			server: NewGalaxyServer("hub", "https://hub.example.com/",
+				WithUsername("user"),
+				WithPassword(text.NewReadPasswordFromText(text.WithText("secret"))),
+			),
+			res: []*Setting{
+				{Key: PasswordKey, Value: "secret"},
+				{Key: URLKey, Value: "https://hub.example.com/"},
+				{Key: UsernameKey, Value: "user"},
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing error reading the galaxy server token",
+			server: NewGalaxyServer("hub", "https://hub.example.com/",
+				WithToken(text.NewReadPasswordFromText()),
+			),
+			res: nil,
+			err: errors.New("(galaxy::GalaxyServer::Settings)", "Error reading the galaxy server 'hub' token",
+				fmt.Errorf("text must be specified to use the password input from text")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := test.server.Settings()
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestGalaxyServerEnvVarName(t *testing.T) {
+	server := NewGalaxyServer("automation_hub", "https://hub.example.com/")
+
+	assert.Equal(t, "ANSIBLE_GALAXY_SERVER_AUTOMATION_HUB_AUTH_URL", server.EnvVarName(AuthURLKey))
+}
@@ -0,0 +1,225 @@
+package flags
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// SingleValue is a pflag.Value for the flags that ansible accepts more than once, but that the options store as a single value. It returns an error when the flag is set more than once, instead of silently keeping the last value
+type SingleValue struct {
+	flag  string
+	set   bool
+	value *string
+}
+
+// NewSingleValue returns a SingleValue that stores the flag value into value
+func NewSingleValue(flag string, value *string) *SingleValue {
+	return &SingleValue{
+		flag:  flag,
+		value: value,
+	}
+}
+
+// String returns the flag value
+func (v *SingleValue) String() string {
+	if v.value == nil {
+		return ""
+	}
+
+	return *v.value
+}
+
+// Set stores the flag value. It returns an error when the value is already set by a previous occurrence of the flag
+func (v *SingleValue) Set(value string) error {
+	if v.set {
+		return fmt.Errorf("'%s' can only be set once, but it was already set to '%s'", v.flag, *v.value)
+	}
+
+	*v.value = value
+	v.set = true
+
+	return nil
+}
+
+// Type returns the flag value type
+func (v *SingleValue) Type() string {
+	return "string"
+}
+
+// ParseExtraVars parses an extra-vars value. It returns the variables defined as key=value pairs or as a YAML or JSON dictionary, and the files to load variables from, which are the values prepended with @
+func ParseExtraVars(value string) (map[string]interface{}, []string, error) {
+
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		return nil, nil, nil
+	}
+
+	if value[0] == '@' {
+		return nil, []string{value}, nil
+	}
+
+	// values starting as a JSON object or array are loaded as YAML, which is a superset of JSON, as ansible does
+	if value[0] == '{' || value[0] == '[' {
+		extraVars, err := parseDictionary(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid extra-vars '%s': %w", value, err)
+		}
+
+		return extraVars, nil, nil
+	}
+
+	extraVars, err := parseKeyValue(value)
+	if err != nil {
+		// a YAML dictionary, such as 'a: 1', is not a list of key=value pairs
+		yamlExtraVars, yamlErr := parseDictionary(value)
+		if yamlErr == nil {
+			return yamlExtraVars, nil, nil
+		}
+
+		return nil, nil, fmt.Errorf("invalid extra-vars '%s': %w", value, err)
+	}
+
+	return extraVars, nil, nil
+}
+
+// parseKeyValue parses a list of key=value pairs separated by whitespaces, whose values can be quoted
+func parseKeyValue(value string) (map[string]interface{}, error) {
+	tokens, err := splitArgs(value)
+	if err != nil {
+		return nil, err
+	}
+
+	extraVars := map[string]interface{}{}
+	for _, token := range tokens {
+		varName, varValue, found := strings.Cut(token, "=")
+		if !found || varName == "" {
+			return nil, fmt.Errorf("'%s' is not a key=value pair", token)
+		}
+		extraVars[varName] = varValue
+	}
+
+	return extraVars, nil
+}
+
+// parseDictionary parses a JSON or YAML dictionary. JSON is decoded first to keep the JSON number types
+func parseDictionary(value string) (map[string]interface{}, error) {
+	var data interface{}
+
+	// the JSON numbers are kept as json.Number, so the integers are not turned into floats and the large ones keep their precision
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+
+	err := decoder.Decode(&data)
+	if err == nil {
+		_, tokenErr := decoder.Token()
+		if tokenErr != io.EOF {
+			err = fmt.Errorf("unexpected content after the JSON value")
+		}
+	}
+	if err != nil {
+		data = nil
+		err = yaml.Unmarshal([]byte(value), &data)
+		if err != nil {
+			return nil, fmt.Errorf("it is neither valid JSON nor YAML: %w", err)
+		}
+	}
+
+	extraVars, isDictionary := data.(map[string]interface{})
+	if !isDictionary {
+		return nil, fmt.Errorf("it must be a dictionary")
+	}
+
+	for name, value := range extraVars {
+		extraVars[name] = vaultedValue(value)
+	}
+
+	return extraVars, nil
+}
+
+// vaultedValue returns a VaultVariableValue when value is the JSON representation of a vaulted variable, otherwise returns value
+func vaultedValue(value interface{}) interface{} {
+	object, isObject := value.(map[string]interface{})
+	if !isObject || len(object) != 1 {
+		return value
+	}
+
+	vaulted, isVaulted := object["__ansible_vault"]
+	if !isVaulted {
+		return value
+	}
+
+	return vault.NewVaultVariableValue(vaulted)
+}
+
+// splitArgs splits a string by whitespaces, keeping together the text enclosed by single or double quotes
+func splitArgs(str string) ([]string, error) {
+	args := []string{}
+	current := strings.Builder{}
+	inArg := false
+	var quote rune
+
+	runes := []rune(str)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case r == '\\' && quote != '\'' && i+1 < len(runes):
+			i++
+			current.WriteRune(runes[i])
+			inArg = true
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quoted string")
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
+
+// NormalizeAliases sets a normalization function on the flag set that translates the aliases, prepended by the prefix, to the flag names they stand for. The aliases map relates each alias to its flag, both with their leading dashes. The normalization function already set on the flag set is applied before translating the aliases
+func NormalizeAliases(fs *pflag.FlagSet, prefix string, aliases map[string]string) {
+	normalize := fs.GetNormalizeFunc()
+
+	fs.SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		normalized := string(normalize(f, name))
+		if !strings.HasPrefix(normalized, prefix) {
+			return pflag.NormalizedName(normalized)
+		}
+
+		flag, isAlias := aliases["--"+strings.TrimPrefix(normalized, prefix)]
+		if !isAlias {
+			return pflag.NormalizedName(normalized)
+		}
+
+		return pflag.NormalizedName(prefix + strings.TrimLeft(flag, "-"))
+	})
+}
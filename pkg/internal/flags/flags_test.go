@@ -0,0 +1,225 @@
+package flags
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSingleValue(t *testing.T) {
+	tests := []struct {
+		desc  string
+		value string
+		args  []string
+		res   string
+		err   error
+	}{
+		{
+			desc:  "Testing single value keeps the default when the flag is not set",
+			value: "default",
+			args:  []string{},
+			res:   "default",
+		},
+		{
+			desc:  "Testing single value set once",
+			value: "default",
+			args:  []string{"--vault-id", "dev@prompt"},
+			res:   "dev@prompt",
+		},
+		{
+			desc: "Testing error setting a single value more than once",
+			args: []string{"--vault-id", "dev@prompt", "--vault-id=prod@prompt"},
+			err:  fmt.Errorf("invalid argument \"prod@prompt\" for \"--vault-id\" flag: '--vault-id' can only be set once, but it was already set to 'dev@prompt'"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			value := test.value
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.Var(NewSingleValue("--vault-id", &value), "vault-id", "the vault identity to use")
+
+			err := fs.Parse(test.args)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, value)
+			}
+		})
+	}
+}
+
+func TestParseExtraVars(t *testing.T) {
+	tests := []struct {
+		desc      string
+		value     string
+		extraVars map[string]interface{}
+		files     []string
+		err       error
+	}{
+		{
+			desc:  "Testing parse an empty extra-vars",
+			value: " ",
+		},
+		{
+			desc:  "Testing parse an extra-vars file",
+			value: "@vars.yml",
+			files: []string{"@vars.yml"},
+		},
+		{
+			desc:      "Testing parse key=value extra-vars",
+			value:     `var1=value1 var2='value 2' var3="a=b"`,
+			extraVars: map[string]interface{}{"var1": "value1", "var2": "value 2", "var3": "a=b"},
+		},
+		{
+			desc:      "Testing parse JSON extra-vars",
+			value:     `{"var1":1.5,"var2":["a","b"],"secret":{"__ansible_vault":"encrypted"}}`,
+			extraVars: map[string]interface{}{"var1": json.Number("1.5"), "var2": []interface{}{"a", "b"}, "secret": vault.NewVaultVariableValue("encrypted")},
+		},
+		{
+			desc:      "Testing parse JSON extra-vars with integers",
+			value:     `{"count":3,"id":9007199254740993,"nested":{"port":8080}}`,
+			extraVars: map[string]interface{}{"count": json.Number("3"), "id": json.Number("9007199254740993"), "nested": map[string]interface{}{"port": json.Number("8080")}},
+		},
+		{
+			desc:      "Testing parse a YAML flow dictionary followed by content that is not JSON",
+			value:     `{var1: 1} `,
+			extraVars: map[string]interface{}{"var1": 1},
+		},
+		{
+			desc:      "Testing parse YAML flow extra-vars",
+			value:     `{var1: 1, var2: [a, b]}`,
+			extraVars: map[string]interface{}{"var1": 1, "var2": []interface{}{"a", "b"}},
+		},
+		{
+			desc:      "Testing parse YAML extra-vars",
+			value:     "a: 1",
+			extraVars: map[string]interface{}{"a": 1},
+		},
+		{
+			desc:  "Testing error parsing extra-vars that are not a dictionary",
+			value: "[1,2]",
+			err:   fmt.Errorf("invalid extra-vars '[1,2]': it must be a dictionary"),
+		},
+		{
+			desc:  "Testing error parsing extra-vars that are not a key=value pair",
+			value: "var1",
+			err:   fmt.Errorf("invalid extra-vars 'var1': 'var1' is not a key=value pair"),
+		},
+		{
+			desc:  "Testing error parsing extra-vars with an unterminated quote",
+			value: "var1='value",
+			err:   fmt.Errorf("invalid extra-vars 'var1='value': unterminated quoted string"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			extraVars, files, err := ParseExtraVars(test.value)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				assert.Equal(t, test.extraVars, extraVars)
+				assert.Equal(t, test.files, files)
+			}
+		})
+	}
+}
+
+// TestParseExtraVarsRoundTrip tests that the extra-vars numbers are encoded as they were parsed
+func TestParseExtraVarsRoundTrip(t *testing.T) {
+	tests := []struct {
+		desc  string
+		value string
+	}{
+		{
+			desc:  "Testing round trip of integer extra-vars",
+			value: `{"count":3,"negative":-42,"zero":0}`,
+		},
+		{
+			desc:  "Testing round trip of integer extra-vars larger than the float64 precision",
+			value: `{"id":9007199254740993,"max":9223372036854775807,"unsigned":18446744073709551615}`,
+		},
+		{
+			desc:  "Testing round trip of float extra-vars",
+			value: `{"exponent":1e+21,"ratio":0.1}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			extraVars, _, err := ParseExtraVars(test.value)
+			assert.NoError(t, err)
+
+			res, err := json.Marshal(extraVars)
+			assert.NoError(t, err)
+			assert.Equal(t, test.value, string(res))
+		})
+	}
+}
+
+func TestNormalizeAliases(t *testing.T) {
+	tests := []struct {
+		desc   string
+		prefix string
+		args   []string
+		res    string
+	}{
+		{
+			desc: "Testing normalize an alias",
+			args: []string{"--inventory-file", "127.0.0.1,"},
+			res:  "127.0.0.1,",
+		},
+		{
+			desc:   "Testing normalize an alias prepended by the prefix",
+			prefix: "ansible-",
+			args:   []string{"--ansible-inventory-file=127.0.0.1,"},
+			res:    "127.0.0.1,",
+		},
+		{
+			desc:   "Testing normalize keeps the flag names",
+			prefix: "ansible-",
+			args:   []string{"--ansible-inventory", "127.0.0.1,"},
+			res:    "127.0.0.1,",
+		},
+		{
+			desc:   "Testing normalize applies the normalization function already set",
+			prefix: "ansible-",
+			args:   []string{"--ansible_inventory_file", "127.0.0.1,"},
+			res:    "127.0.0.1,",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			value := ""
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+				return pflag.NormalizedName(strings.ReplaceAll(name, "_", "-"))
+			})
+			NormalizeAliases(fs, test.prefix, map[string]string{"--inventory-file": "--inventory"})
+			fs.StringVar(&value, test.prefix+"inventory", "", "specify inventory host path or comma separated host list")
+
+			err := fs.Parse(test.args)
+			assert.NoError(t, err)
+			assert.Equal(t, test.res, value)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Package flagstest provides helpers to test the flags registered by the options AddFlags methods
+package flagstest
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LongFlags returns the long flags declared as string constants on the Go source files, such as --inventory, sorted by name
+func LongFlags(files ...string) ([]string, error) {
+	longFlags := []string{}
+
+	for _, file := range files {
+		source, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, decl := range source.Decls {
+			genDecl, isGenDecl := decl.(*ast.GenDecl)
+			if !isGenDecl || genDecl.Tok != token.CONST {
+				continue
+			}
+
+			for _, spec := range genDecl.Specs {
+				for _, value := range spec.(*ast.ValueSpec).Values {
+					literal, isLiteral := value.(*ast.BasicLit)
+					if !isLiteral || literal.Kind != token.STRING {
+						continue
+					}
+
+					flag, err := strconv.Unquote(literal.Value)
+					if err != nil {
+						return nil, err
+					}
+
+					if strings.HasPrefix(flag, "--") && len(flag) > len("--") {
+						longFlags = append(longFlags, flag)
+					}
+				}
+			}
+		}
+	}
+
+	sort.Strings(longFlags)
+
+	return longFlags, nil
+}
@@ -0,0 +1,72 @@
+package flagstest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLongFlags(t *testing.T) {
+	dir := t.TempDir()
+
+	source := filepath.Join(dir, "options.go")
+	err := os.WriteFile(source, []byte(`package options
+
+const (
+	// InventoryFlag is the inventory flag
+	InventoryFlag = "--inventory"
+	// BecomeFlag is the become flag
+	BecomeFlag = "--become"
+	// VerboseVFlag is the verbose shorthand
+	VerboseVFlag = "-v"
+	// DefaultForks is not a flag
+	DefaultForks = 5
+)
+
+const name = "options"
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := filepath.Join(dir, "invalid.go")
+	err = os.WriteFile(invalid, []byte("package options\n\nconst ("), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc  string
+		files []string
+		res   []string
+		err   bool
+	}{
+		{
+			desc:  "Testing long flags declared as constants",
+			files: []string{source},
+			res:   []string{"--become", "--inventory"},
+		},
+		{
+			desc:  "Testing error parsing an invalid source file",
+			files: []string{invalid},
+			err:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := LongFlags(test.files...)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
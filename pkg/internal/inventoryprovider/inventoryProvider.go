@@ -0,0 +1,61 @@
+package inventoryprovider
+
+import (
+	"context"
+
+	"github.com/apenella/go-ansible/v2/pkg/inventory"
+	"github.com/apenella/go-ansible/v2/pkg/inventory/dynamic"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// InventoryProvider provides to ansible the in-memory inventory or the inventory computed by a function, which the executors accept besides the inventory option
+type InventoryProvider struct {
+	// Inventory is the in-memory inventory, which is rendered to an ephemeral file only readable by the owner
+	Inventory *inventory.Inventory
+	// InventoryFunc computes the inventory, which is served through a dynamic inventory script without writing it to disk
+	InventoryFunc dynamic.InventoryFunc
+}
+
+// Provide returns the inventory source to pass to ansible and a function that removes it once the command finishes. The source is empty when there is no inventory to provide. The inventoryOption is the inventory set on the command options, which is mutually exclusive with the in-memory inventory and the inventory function
+func (p *InventoryProvider) Provide(ctx context.Context, inventoryOption string) (string, func(), error) {
+	errContext := "(inventoryprovider::InventoryProvider::Provide)"
+
+	noop := func() {}
+
+	if p == nil {
+		return "", noop, nil
+	}
+
+	if p.Inventory != nil && p.InventoryFunc != nil {
+		return "", noop, errors.New(errContext, "In-memory inventory and inventory function are mutually exclusive")
+	}
+
+	if p.InventoryFunc != nil {
+		if inventoryOption != "" {
+			return "", noop, errors.New(errContext, "Inventory option and inventory function are mutually exclusive")
+		}
+
+		dynamicInventory := dynamic.NewDynamicInventory(p.InventoryFunc)
+		err := dynamicInventory.Start(ctx)
+		if err != nil {
+			return "", noop, errors.New(errContext, "Error providing the dynamic inventory", err)
+		}
+
+		return dynamicInventory.ScriptPath(), func() { _ = dynamicInventory.Close() }, nil
+	}
+
+	if p.Inventory != nil {
+		if inventoryOption != "" {
+			return "", noop, errors.New(errContext, "Inventory option and in-memory inventory are mutually exclusive")
+		}
+
+		file, err := inventory.WriteInventoryFile(p.Inventory, "", inventory.YAMLFormat)
+		if err != nil {
+			return "", noop, errors.New(errContext, "Error providing the inventory", err)
+		}
+
+		return file.Path, func() { _ = file.Close() }, nil
+	}
+
+	return "", noop, nil
+}
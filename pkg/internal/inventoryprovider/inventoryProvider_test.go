@@ -0,0 +1,103 @@
+package inventoryprovider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/inventory"
+	"github.com/apenella/go-ansible/v2/pkg/inventory/dynamic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvide(t *testing.T) {
+	inv := inventory.NewInventory()
+	inv.AddHost("web1")
+	inv.AddGroup("web").AddHost("web1")
+
+	fn := func(ctx context.Context) (*inventory.Inventory, error) {
+		return inv, nil
+	}
+
+	tests := []struct {
+		desc            string
+		provider        *InventoryProvider
+		inventoryOption string
+		script          bool
+		file            bool
+		err             string
+	}{
+		{
+			desc:     "Testing provide nothing when the provider is nil",
+			provider: nil,
+		},
+		{
+			desc:            "Testing provide nothing when there is no inventory to provide",
+			provider:        &InventoryProvider{},
+			inventoryOption: "inventory.ini",
+		},
+		{
+			desc:     "Testing provide an in-memory inventory through an ephemeral file",
+			provider: &InventoryProvider{Inventory: inv},
+			file:     true,
+		},
+		{
+			desc:     "Testing provide the inventory computed by a function through a dynamic inventory script",
+			provider: &InventoryProvider{InventoryFunc: fn},
+			script:   true,
+		},
+		{
+			desc:     "Testing error providing an in-memory inventory and an inventory function",
+			provider: &InventoryProvider{Inventory: inv, InventoryFunc: fn},
+			err:      "In-memory inventory and inventory function are mutually exclusive",
+		},
+		{
+			desc:            "Testing error providing an in-memory inventory and the inventory option",
+			provider:        &InventoryProvider{Inventory: inv},
+			inventoryOption: "inventory.ini",
+			err:             "Inventory option and in-memory inventory are mutually exclusive",
+		},
+		{
+			desc:            "Testing error providing an inventory function and the inventory option",
+			provider:        &InventoryProvider{InventoryFunc: fn},
+			inventoryOption: "inventory.ini",
+			err:             "Inventory option and inventory function are mutually exclusive",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			source, closeInventory, err := test.provider.Provide(context.TODO(), test.inventoryOption)
+			assert.NotNil(t, closeInventory)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				assert.Empty(t, source)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			switch {
+			case test.file:
+				assert.Equal(t, inventory.InventoryFileName+inventory.YAMLFormat.Extension(), filepath.Base(source))
+			case test.script:
+				assert.Equal(t, dynamic.DynamicInventoryScriptName, filepath.Base(source))
+			default:
+				assert.Empty(t, source)
+				closeInventory()
+				return
+			}
+
+			_, err = os.Stat(source)
+			assert.NoError(t, err)
+
+			closeInventory()
+
+			_, err = os.Stat(source)
+			assert.True(t, os.IsNotExist(err))
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package socketscript
+
+import (
+	"text/template"
+)
+
+// DefaultInterpreter is the interpreter of the scripts. Python is always available where ansible runs
+const DefaultInterpreter = "/usr/bin/env python3"
+
+// scriptTemplate is the common part of the scripts. The request function sends a request to the Server through its Unix socket and returns the response status and content. The script runs the main function defined by each script, and the data given to the template must define the Interpreter, Socket and Token fields
+const scriptTemplate = `#!{{ .Interpreter }}
+# {{ template "description" . }} generated by go-ansible. It is removed once the execution finishes
+import socket
+import sys
+
+SOCKET = {{ printf "%q" .Socket }}
+TOKEN = {{ printf "%q" .Token }}
+
+
+def request(message):
+    client = socket.socket(socket.AF_UNIX, socket.SOCK_STREAM)
+    client.connect(SOCKET)
+    client.sendall((TOKEN + " " + message + "\n").encode())
+
+    response = b""
+    while True:
+        chunk = client.recv(4096)
+        if not chunk:
+            break
+        response += chunk
+    client.close()
+
+    status, _, content = response.partition(b"\n")
+    return status.decode(), content.decode()
+
+
+{{ template "main" . }}
+
+sys.exit(main())
+`
+
+// NewScriptTemplate returns the template of a script described by description, whose main function is defined by main. The main function must return the script exit code
+func NewScriptTemplate(name, description, main string) *template.Template {
+	tmpl := template.Must(template.New(name).Parse(scriptTemplate))
+	template.Must(tmpl.New("description").Parse(description))
+	template.Must(tmpl.New("main").Parse(main))
+
+	return tmpl
+}
@@ -0,0 +1,210 @@
+package socketscript
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// StatusError is the response status when the request can not be answered
+	StatusError = "ERROR"
+	// StatusOK is the response status followed by the requested content
+	StatusOK = "OK"
+
+	// requestTimeout is the time given to the script to send its request
+	requestTimeout = 10 * time.Second
+	// tokenLength is the number of random bytes of the token that authenticates the script requests
+	tokenLength = 32
+)
+
+// Handler answers the request sent by the script, once its token is validated. It returns the response status and the content written after it
+type Handler func(request string) (status string, content string)
+
+// ScriptWriter writes the script that sends the requests to the socket authenticated with the token
+type ScriptWriter func(w io.Writer, socket, token string) error
+
+// Config is the configuration of the Server
+type Config struct {
+	// Name describes what the server provides on the error messages, such as vault client
+	Name string
+	// Dir is the directory where the private directory is created. The default temporary directory is used when it is empty
+	Dir string
+	// Pattern is the pattern of the private directory name, as expected by os.MkdirTemp
+	Pattern string
+	// ScriptName is the name of the script on the private directory
+	ScriptName string
+	// SocketName is the name of the Unix socket on the private directory
+	SocketName string
+	// Handler answers the requests
+	Handler Handler
+	// WriteScript writes the script
+	WriteScript ScriptWriter
+}
+
+// Server serves the requests of a script through a Unix socket. The socket and the script are placed on a private directory, and the requests are authenticated with a random token written to the script
+type Server struct {
+	handler  Handler
+	listener net.Listener
+	name     string
+	runDir   string
+	script   string
+	socket   string
+	token    string
+	wg       sync.WaitGroup
+}
+
+// Start creates the private directory and the script, and starts serving the requests on the Unix socket. It must be closed once the script is no longer used
+func Start(config Config) (server *Server, err error) {
+	if config.Handler == nil {
+		return nil, fmt.Errorf("The %s requires a request handler", config.Name)
+	}
+
+	if config.WriteScript == nil {
+		return nil, fmt.Errorf("The %s requires a script writer", config.Name)
+	}
+
+	token := make([]byte, tokenLength)
+	_, err = rand.Read(token)
+	if err != nil {
+		return nil, fmt.Errorf("Error generating the %s token: %w", config.Name, err)
+	}
+
+	// os.MkdirTemp creates the directory only accessible by its owner, which protects the socket and the script from other users
+	runDir, err := os.MkdirTemp(config.Dir, config.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating the %s directory: %w", config.Name, err)
+	}
+
+	server = &Server{
+		handler: config.Handler,
+		name:    config.Name,
+		runDir:  runDir,
+		script:  filepath.Join(runDir, config.ScriptName),
+		socket:  filepath.Join(runDir, config.SocketName),
+		token:   hex.EncodeToString(token),
+	}
+
+	defer func() {
+		if err != nil {
+			_ = server.Close()
+			server = nil
+		}
+	}()
+
+	server.listener, err = net.Listen("unix", server.socket)
+	if err != nil {
+		return server, fmt.Errorf("Error listening on the %s socket: %w", config.Name, err)
+	}
+
+	err = server.writeScript(config.WriteScript)
+	if err != nil {
+		return server, fmt.Errorf("Error writing the %s script: %w", config.Name, err)
+	}
+
+	server.wg.Add(1)
+	go server.serve(server.listener)
+
+	return server, nil
+}
+
+// Close stops serving the requests and removes the private directory. It is safe to call it more than once
+func (s *Server) Close() error {
+	var err error
+
+	if s == nil {
+		return nil
+	}
+
+	if s.listener != nil {
+		_ = s.listener.Close()
+		s.wg.Wait()
+		s.listener = nil
+	}
+
+	if s.runDir != "" {
+		err = os.RemoveAll(s.runDir)
+		s.runDir = ""
+	}
+
+	if err != nil {
+		return fmt.Errorf("Error removing the %s directory: %w", s.name, err)
+	}
+
+	return nil
+}
+
+// ScriptPath returns the path of the script
+func (s *Server) ScriptPath() string {
+	return s.script
+}
+
+// SocketPath returns the path of the Unix socket
+func (s *Server) SocketPath() string {
+	return s.socket
+}
+
+// Token returns the token that authenticates the script requests
+func (s *Server) Token() string {
+	return s.token
+}
+
+// writeScript writes the script to the private directory. It is only executable by its owner and it must not exist yet
+func (s *Server) writeScript(write ScriptWriter) error {
+	file, err := os.OpenFile(s.script, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0700)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return write(file, s.socket, s.token)
+}
+
+// serve handles the connections until the listener is closed
+func (s *Server) serve(listener net.Listener) {
+	defer s.wg.Done()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+		}()
+	}
+}
+
+// handle answers a request. The request is the token followed by the handler request, and the response is a status line followed by the handler content or an error message
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+
+	// the deadline only applies to the request, because the handler may take longer to answer it
+	_ = conn.SetReadDeadline(time.Now().Add(requestTimeout))
+
+	request, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return
+	}
+
+	token, request, _ := strings.Cut(strings.TrimRight(request, "\r\n"), " ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+		_, _ = fmt.Fprintf(conn, "%s\ninvalid %s token", StatusError, s.name)
+		return
+	}
+
+	status, content := s.handler(request)
+	_, _ = fmt.Fprintf(conn, "%s\n%s", status, content)
+}
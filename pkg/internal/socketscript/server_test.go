@@ -0,0 +1,239 @@
+package socketscript
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// echoHandler answers the requests with the request itself
+func echoHandler(request string) (string, string) {
+	if request == "" {
+		return StatusError, "empty request"
+	}
+
+	return StatusOK, request
+}
+
+// testScriptWriter writes a script with the socket and the token
+func testScriptWriter(w io.Writer, socket, token string) error {
+	_, err := fmt.Fprintf(w, "%s %s", socket, token)
+	return err
+}
+
+// request sends a request to the server socket and returns the response
+func request(t *testing.T, s *Server, token, message string) string {
+	conn, err := net.Dial("unix", s.SocketPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	_, err = fmt.Fprintf(conn, "%s %s\n", token, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := io.ReadAll(bufio.NewReader(conn))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(response)
+}
+
+func TestStart(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		desc   string
+		config Config
+		err    string
+	}{
+		{
+			desc: "Testing starting a server",
+			config: Config{
+				Name:        "test",
+				Dir:         dir,
+				Pattern:     "test-*",
+				ScriptName:  "script",
+				SocketName:  "test.sock",
+				Handler:     echoHandler,
+				WriteScript: testScriptWriter,
+			},
+		},
+		{
+			desc: "Testing error starting a server without handler",
+			config: Config{
+				Name:        "test",
+				WriteScript: testScriptWriter,
+			},
+			err: "The test requires a request handler",
+		},
+		{
+			desc: "Testing error starting a server without script writer",
+			config: Config{
+				Name:    "test",
+				Handler: echoHandler,
+			},
+			err: "The test requires a script writer",
+		},
+		{
+			desc: "Testing error starting a server when the directory does not exist",
+			config: Config{
+				Name:        "test",
+				Dir:         filepath.Join(dir, "missing"),
+				Handler:     echoHandler,
+				WriteScript: testScriptWriter,
+			},
+			err: "Error creating the test directory",
+		},
+		{
+			desc: "Testing error starting a server when the script can not be written",
+			config: Config{
+				Name:        "test",
+				Dir:         dir,
+				ScriptName:  "test.sock",
+				SocketName:  "test.sock",
+				Handler:     echoHandler,
+				WriteScript: testScriptWriter,
+			},
+			err: "Error writing the test script",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			server, err := Start(test.config)
+			if test.err != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), test.err)
+				assert.Nil(t, server)
+				return
+			}
+
+			assert.NoError(t, err)
+			defer server.Close()
+
+			info, err := os.Stat(server.ScriptPath())
+			assert.NoError(t, err)
+			assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+			script, err := os.ReadFile(server.ScriptPath())
+			assert.NoError(t, err)
+			assert.Equal(t, server.SocketPath()+" "+server.Token(), string(script))
+		})
+	}
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestClose(t *testing.T) {
+	t.Log("Testing closing a server removes its private directory and it is safe to close it twice")
+
+	server, err := Start(Config{
+		Name:        "test",
+		Dir:         t.TempDir(),
+		ScriptName:  "script",
+		SocketName:  "test.sock",
+		Handler:     echoHandler,
+		WriteScript: testScriptWriter,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, server.Close())
+	assert.NoError(t, server.Close())
+
+	_, err = os.Stat(filepath.Dir(server.ScriptPath()))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestHandle(t *testing.T) {
+	server, err := Start(Config{
+		Name:        "test",
+		Dir:         t.TempDir(),
+		ScriptName:  "script",
+		SocketName:  "test.sock",
+		Handler:     echoHandler,
+		WriteScript: testScriptWriter,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	tests := []struct {
+		desc    string
+		token   string
+		message string
+		res     string
+	}{
+		{
+			desc:    "Testing a request answered by the handler",
+			token:   server.Token(),
+			message: "host web1",
+			res:     "OK\nhost web1",
+		},
+		{
+			desc:    "Testing a request that the handler fails to answer",
+			token:   server.Token(),
+			message: "",
+			res:     "ERROR\nempty request",
+		},
+		{
+			desc:    "Testing a request with an invalid token",
+			token:   "invalid",
+			message: "host web1",
+			res:     "ERROR\ninvalid test token",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res := request(t, server, test.token, test.message)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestNewScriptTemplate(t *testing.T) {
+	t.Log("Testing the script template includes the description, the request function and the main function")
+
+	tmpl := NewScriptTemplate("test", "Test script", `def main():
+    return {{ .ExitCode }}`)
+
+	script := &strings.Builder{}
+	err := tmpl.Execute(script, struct {
+		ExitCode    int
+		Interpreter string
+		Socket      string
+		Token       string
+	}{
+		ExitCode:    3,
+		Interpreter: "/usr/bin/python3",
+		Socket:      "/tmp/test.sock",
+		Token:       "token",
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(script.String(), "#!/usr/bin/python3\n# Test script generated by go-ansible."))
+	assert.Contains(t, script.String(), `SOCKET = "/tmp/test.sock"`)
+	assert.Contains(t, script.String(), `TOKEN = "token"`)
+	assert.Contains(t, script.String(), "def request(message):")
+	assert.Contains(t, script.String(), "def main():\n    return 3\n\nsys.exit(main())\n")
+}
@@ -0,0 +1,67 @@
+package validation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+var (
+	// BecomeMethods are the become methods shipped with ansible. They are suggested on the validation errors, but any valid plugin name is accepted
+	BecomeMethods = []string{"ksu", "pbrun", "enable", "sesu", "pmrun", "runas", "sudo", "su", "doas", "pfexec", "machinectl", "dzdo"}
+
+	// Connections are the connection plugins shipped with ansible. They are suggested on the validation errors, but any valid plugin name is accepted
+	Connections = []string{"local", "ssh", "paramiko", "paramiko_ssh", "psrp", "winrm", "network_cli", "netconf", "httpapi", "persistent", "smart", "docker", "podman", "buildah", "chroot", "jail", "lxc", "lxd", "zone"}
+
+	// fqcnRegexp matches a fully qualified collection name such as ansible.builtin.sudo
+	fqcnRegexp = regexp.MustCompile(`^[a-z0-9_]+\.[a-z0-9_]+\.[a-zA-Z0-9_]+$`)
+	// pluginNameRegexp matches a short plugin name, which is a Python identifier such as sudo or aws_ssm. Ansible resolves the short names against the builtin plugins, the routing redirects and the collections search path
+	pluginNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// FlagStatus relates a flag to whether it is enabled
+type FlagStatus struct {
+	Flag    string
+	Enabled bool
+}
+
+// EnabledFlags returns the flags that are enabled, keeping the order they are provided
+func EnabledFlags(flags ...FlagStatus) []string {
+	enabled := []string{}
+	for _, f := range flags {
+		if f.Enabled {
+			enabled = append(enabled, f.Flag)
+		}
+	}
+
+	return enabled
+}
+
+// IsPluginName returns whether name is a valid short plugin name or a fully qualified collection name. The plugin existence is not checked, because it depends on the ansible version and the installed collections
+func IsPluginName(name string) bool {
+	return pluginNameRegexp.MatchString(name) || fqcnRegexp.MatchString(name)
+}
+
+// Path returns the path of file, which is resolved against dir when it is relative. dir is the directory where the command runs, and the working directory is used when it is empty
+func Path(dir, file string) string {
+	if dir == "" || filepath.IsAbs(file) {
+		return file
+	}
+
+	return filepath.Join(dir, file)
+}
+
+// FileExists returns an error when the file does not exist or it is a directory. A relative file is resolved against dir, as Path does
+func FileExists(dir, file string) error {
+	info, err := os.Stat(Path(dir, file))
+	if err != nil {
+		return err
+	}
+
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory", file)
+	}
+
+	return nil
+}
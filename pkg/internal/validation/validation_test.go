@@ -0,0 +1,178 @@
+package validation
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnabledFlags(t *testing.T) {
+	tests := []struct {
+		desc  string
+		flags []FlagStatus
+		res   []string
+	}{
+		{
+			desc: "Testing enabled flags keeping their order",
+			flags: []FlagStatus{
+				{Flag: "--check", Enabled: true},
+				{Flag: "--diff", Enabled: false},
+				{Flag: "--list-tasks", Enabled: true},
+			},
+			res: []string{"--check", "--list-tasks"},
+		},
+		{
+			desc: "Testing enabled flags when none is enabled",
+			flags: []FlagStatus{
+				{Flag: "--check", Enabled: false},
+			},
+			res: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res := EnabledFlags(test.flags...)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestIsPluginName(t *testing.T) {
+	tests := []struct {
+		desc string
+		name string
+		res  bool
+	}{
+		{
+			desc: "Testing a builtin plugin name",
+			name: "sudo",
+			res:  true,
+		},
+		{
+			desc: "Testing a builtin plugin fully qualified collection name",
+			name: "ansible.builtin.sudo",
+			res:  true,
+		},
+		{
+			desc: "Testing a plugin from a collection",
+			name: "community.general.sudosu",
+			res:  true,
+		},
+		{
+			desc: "Testing a plugin name that is not shipped with ansible",
+			name: "aws_ssm",
+			res:  true,
+		},
+		{
+			desc: "Testing an invalid plugin name",
+			name: "sudo su",
+			res:  false,
+		},
+		{
+			desc: "Testing an invalid fully qualified collection name",
+			name: "community.docker.",
+			res:  false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res := IsPluginName(test.name)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestPath(t *testing.T) {
+	tests := []struct {
+		desc string
+		dir  string
+		file string
+		res  string
+	}{
+		{
+			desc: "Testing path of a relative file without directory",
+			file: "inventory.yml",
+			res:  "inventory.yml",
+		},
+		{
+			desc: "Testing path of a relative file resolved against the directory",
+			dir:  "/project",
+			file: "inventory.yml",
+			res:  filepath.Join("/project", "inventory.yml"),
+		},
+		{
+			desc: "Testing path of an absolute file",
+			dir:  "/project",
+			file: "/etc/ansible/hosts",
+			res:  "/etc/ansible/hosts",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res := Path(test.dir, test.file)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestFileExists(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "password.txt"), []byte("secret"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc string
+		dir  string
+		file string
+		err  error
+	}{
+		{
+			desc: "Testing an existing relative file resolved against the directory",
+			dir:  dir,
+			file: "password.txt",
+			err:  nil,
+		},
+		{
+			desc: "Testing an existing absolute file",
+			dir:  "/nonexistent",
+			file: filepath.Join(dir, "password.txt"),
+			err:  nil,
+		},
+		{
+			desc: "Testing a file that does not exist",
+			dir:  dir,
+			file: "missing.txt",
+			err:  &fs.PathError{Op: "stat", Path: filepath.Join(dir, "missing.txt"), Err: syscall.ENOENT},
+		},
+		{
+			desc: "Testing a directory",
+			file: dir,
+			err:  fmt.Errorf("%s is a directory", dir),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := FileExists(test.dir, test.file)
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
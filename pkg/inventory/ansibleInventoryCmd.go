@@ -2,7 +2,10 @@ package inventory
 
 import (
 	"fmt"
+	"sort"
 
+	"github.com/apenella/go-ansible/v2/pkg/internal/validation"
+	"github.com/apenella/go-ansible/v2/pkg/vault/client"
 	errors "github.com/apenella/go-common-utils/error"
 )
 
@@ -82,6 +85,12 @@ type AnsibleInventoryCmd struct {
 	Pattern string
 	// Options are the ansible's inventory options
 	InventoryOptions *AnsibleInventoryOptions
+	// SkipValidation disables the inventory options validation when the command is generated
+	SkipValidation bool
+	// FileValidation enables checking that the files referenced by the options exist when the command is generated
+	FileValidation bool
+	// FileValidationDir is the directory the relative files are resolved against when the files are validated
+	FileValidationDir string
 }
 
 // NewAnsibleInventoryCmd creates a new AnsibleInventoryCmd instance
@@ -116,6 +125,21 @@ func WithInventoryOptions(options *AnsibleInventoryOptions) AnsibleInventoryOpti
 	}
 }
 
+// WithoutValidation disables the ansible-inventory options validation
+func WithoutValidation() AnsibleInventoryOptionFunc {
+	return func(p *AnsibleInventoryCmd) {
+		p.SkipValidation = true
+	}
+}
+
+// WithFileValidation enables checking that the files referenced by the ansible-inventory options exist. The relative files are resolved against dir, which is the directory where the command runs, or against the working directory when dir is empty. Do not enable it when the command runs on another host or container
+func WithFileValidation(dir string) AnsibleInventoryOptionFunc {
+	return func(p *AnsibleInventoryCmd) {
+		p.FileValidation = true
+		p.FileValidationDir = dir
+	}
+}
+
 // Command generate the ansible command which will be executed
 func (p *AnsibleInventoryCmd) Command() ([]string, error) {
 	cmd := []string{}
@@ -133,6 +157,20 @@ func (p *AnsibleInventoryCmd) Command() ([]string, error) {
 
 	// Determine the options to be set
 	if p.InventoryOptions != nil {
+		if !p.SkipValidation {
+			err := p.InventoryOptions.Validate()
+			if err != nil {
+				return nil, errors.New("(inventory::Command)", "Error validating options", err)
+			}
+		}
+
+		if p.FileValidation {
+			err := p.InventoryOptions.ValidateFiles(p.FileValidationDir)
+			if err != nil {
+				return nil, errors.New("(inventory::Command)", "Error validating option files", err)
+			}
+		}
+
 		options, err := p.InventoryOptions.GenerateCommandOptions()
 		if err != nil {
 			return nil, errors.New("(inventory::Command)", "Error creating options", err)
@@ -203,6 +241,9 @@ type AnsibleInventoryOptions struct {
 	// VaultPasswordFile vault password file
 	VaultPasswordFile string
 
+	// VaultPasswordReaders are the readers of the vault passwords, by vault id label. The passwords are served to ansible-inventory through a vault client script, without writing them to disk, when the command runs through the AnsibleInventoryExecute or an executor wrapped by the vault client AnsibleWithVaultPasswordClientExecute with the VaultClientOptions
+	VaultPasswordReaders map[string]client.PasswordReader
+
 	// Verbose verbose mode enabled
 	Verbose bool
 
@@ -309,6 +350,119 @@ func (o *AnsibleInventoryOptions) GenerateCommandOptions() ([]string, error) {
 	return cmd, nil
 }
 
+// VaultClientOptions returns the options of the vault password client that serves the VaultPasswordReaders passwords, sorted by vault id label
+func (o *AnsibleInventoryOptions) VaultClientOptions() []client.OptionsFunc {
+	if o == nil || len(o.VaultPasswordReaders) == 0 {
+		return nil
+	}
+
+	labels := make([]string, 0, len(o.VaultPasswordReaders))
+	for label := range o.VaultPasswordReaders {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	options := make([]client.OptionsFunc, 0, len(labels))
+	for _, label := range labels {
+		options = append(options, client.WithPasswordReader(label, o.VaultPasswordReaders[label]))
+	}
+
+	return options
+}
+
+// Validate checks the options looking for mutually exclusive or meaningless flag combinations. It returns an error that wraps all the detected issues
+func (o *AnsibleInventoryOptions) Validate() error {
+
+	errContext := "(inventory::Validate)"
+	errs := []error{}
+
+	if o == nil {
+		return errors.New(errContext, "AnsibleInventoryOptions is nil")
+	}
+
+	verbosityFlags := validation.EnabledFlags(
+		validation.FlagStatus{Flag: VerboseFlag, Enabled: o.Verbose},
+		validation.FlagStatus{Flag: VerboseVFlag, Enabled: o.VerboseV},
+		validation.FlagStatus{Flag: VerboseVVFlag, Enabled: o.VerboseVV},
+		validation.FlagStatus{Flag: VerboseVVVFlag, Enabled: o.VerboseVVV},
+		validation.FlagStatus{Flag: VerboseVVVVFlag, Enabled: o.VerboseVVVV},
+	)
+	if len(verbosityFlags) > 1 {
+		errs = append(errs, fmt.Errorf("only one verbosity level can be set, but %d were set", len(verbosityFlags)))
+	}
+
+	actionFlags := validation.EnabledFlags(
+		validation.FlagStatus{Flag: GraphFlag, Enabled: o.Graph},
+		validation.FlagStatus{Flag: HostFlag, Enabled: o.Host != ""},
+		validation.FlagStatus{Flag: ListFlag, Enabled: o.List},
+	)
+	if len(actionFlags) > 1 {
+		errs = append(errs, fmt.Errorf("'%s', '%s' and '%s' are mutually exclusive", GraphFlag, HostFlag, ListFlag))
+	}
+	if len(actionFlags) == 0 && !o.Version {
+		errs = append(errs, fmt.Errorf("one of '%s', '%s' or '%s' must be set", GraphFlag, HostFlag, ListFlag))
+	}
+
+	if o.AskVaultPassword && o.VaultPasswordFile != "" {
+		errs = append(errs, fmt.Errorf("'%s' and '%s' are mutually exclusive", AskVaultPasswordFlag, VaultPasswordFileFlag))
+	}
+
+	if o.Toml && o.Yaml {
+		errs = append(errs, fmt.Errorf("'%s' and '%s' are mutually exclusive", TomlFlag, YamlFlag))
+	}
+
+	if !o.List {
+		for _, flag := range validation.EnabledFlags(
+			validation.FlagStatus{Flag: ExportFlag, Enabled: o.Export},
+			validation.FlagStatus{Flag: OutputFlag, Enabled: o.Output != ""},
+		) {
+			errs = append(errs, fmt.Errorf("'%s' is meaningless without '%s'", flag, ListFlag))
+		}
+	}
+
+	if o.Graph {
+		for _, flag := range validation.EnabledFlags(
+			validation.FlagStatus{Flag: TomlFlag, Enabled: o.Toml},
+			validation.FlagStatus{Flag: YamlFlag, Enabled: o.Yaml},
+		) {
+			errs = append(errs, fmt.Errorf("'%s' is meaningless with '%s'", flag, GraphFlag))
+		}
+	}
+
+	if o.Vars && !o.Graph {
+		errs = append(errs, fmt.Errorf("'%s' is meaningless without '%s'", VarsFlag, GraphFlag))
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid ansible-inventory options", errs...)
+	}
+
+	return nil
+}
+
+// ValidateFiles checks that the files referenced by the options exist and are not directories. The relative files are resolved against dir, which is the directory where the command runs, or against the working directory when dir is empty. It is not part of Validate because the command may run on another directory, host or container
+func (o *AnsibleInventoryOptions) ValidateFiles(dir string) error {
+	errContext := "(inventory::ValidateFiles)"
+	errs := []error{}
+
+	if o == nil {
+		return errors.New(errContext, "AnsibleInventoryOptions is nil")
+	}
+
+	if o.VaultPasswordFile != "" {
+		err := validation.FileExists(dir, o.VaultPasswordFile)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("'%s' file is not valid: %w", VaultPasswordFileFlag, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid ansible-inventory option files", errs...)
+	}
+
+	return nil
+}
+
 // generateVerbosityFlag return a string with the verbose flag. Higher verbosity (more v's) has precedence over lower
 func (o *AnsibleInventoryOptions) generateVerbosityFlag() (string, error) {
 	if o.Verbose {
@@ -1,8 +1,16 @@
 package inventory
 
 import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
 	"testing"
 
+	"github.com/apenella/go-ansible/v2/pkg/vault/client"
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
 	errors "github.com/apenella/go-common-utils/error"
 	"github.com/stretchr/testify/assert"
 )
@@ -207,7 +215,8 @@ func TestCommand(t *testing.T) {
 			desc: "Testing generate AnsibleInventoryCmd command",
 			err:  nil,
 			AnsibleInventoryCmd: &AnsibleInventoryCmd{
-				Pattern: "all",
+				Pattern:        "all",
+				SkipValidation: true,
 				InventoryOptions: &AnsibleInventoryOptions{
 					AskVaultPassword:  true,
 					Export:            true,
@@ -248,6 +257,39 @@ func TestCommand(t *testing.T) {
 				"--yaml",
 			},
 		},
+		{
+			desc: "Testing generate AnsibleInventoryCmd command without validating the option files",
+			err:  nil,
+			AnsibleInventoryCmd: NewAnsibleInventoryCmd(
+				WithPattern("all"),
+				WithInventoryOptions(&AnsibleInventoryOptions{
+					List:              true,
+					VaultPasswordFile: "missing",
+				}),
+			),
+			command: []string{
+				"ansible-inventory",
+				"all",
+				"--list",
+				"--vault-password-file=missing",
+			},
+		},
+		{
+			desc: "Testing error generating AnsibleInventoryCmd command validating the option files",
+			err: errors.New("(inventory::Command)", "Error validating option files",
+				errors.New("(inventory::ValidateFiles)", "Invalid ansible-inventory option files",
+					fmt.Errorf("'%s' file is not valid: %w", VaultPasswordFileFlag, &fs.PathError{Op: "stat", Path: filepath.Join("test", "missing"), Err: syscall.ENOENT}),
+				),
+			),
+			AnsibleInventoryCmd: NewAnsibleInventoryCmd(
+				WithPattern("all"),
+				WithInventoryOptions(&AnsibleInventoryOptions{
+					List:              true,
+					VaultPasswordFile: "missing",
+				}),
+				WithFileValidation("test"),
+			),
+		},
 	}
 
 	for _, test := range tests {
@@ -338,3 +380,190 @@ func TestGenerateVerbosityFlag(t *testing.T) {
 		})
 	}
 }
+
+// TestValidate tests
+func TestValidate(t *testing.T) {
+
+	tempDir := t.TempDir()
+	passwordFile := filepath.Join(tempDir, "vault_password")
+	err := os.WriteFile(passwordFile, []byte("password"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc    string
+		options *AnsibleInventoryOptions
+		err     error
+	}{
+		{
+			desc:    "Testing error validating nil AnsibleInventoryOptions",
+			options: nil,
+			err:     errors.New("(inventory::Validate)", "AnsibleInventoryOptions is nil"),
+		},
+		{
+			desc: "Testing validate AnsibleInventoryOptions",
+			options: &AnsibleInventoryOptions{
+				Export:            true,
+				List:              true,
+				Output:            "/tmp/inventory.json",
+				VaultPasswordFile: passwordFile,
+				Yaml:              true,
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing error validating AnsibleInventoryOptions without action",
+			options: &AnsibleInventoryOptions{
+				Inventory: "inventory.yml",
+			},
+			err: errors.New("(inventory::Validate)", "Invalid ansible-inventory options",
+				fmt.Errorf("one of '%s', '%s' or '%s' must be set", GraphFlag, HostFlag, ListFlag),
+			),
+		},
+		{
+			desc: "Testing error validating AnsibleInventoryOptions with conflicting flags",
+			options: &AnsibleInventoryOptions{
+				AskVaultPassword:  true,
+				Export:            true,
+				Graph:             true,
+				Host:              "localhost",
+				Toml:              true,
+				VaultPasswordFile: filepath.Join(tempDir, "missing"),
+				Verbose:           true,
+				VerboseVVV:        true,
+				Yaml:              true,
+			},
+			err: errors.New("(inventory::Validate)", "Invalid ansible-inventory options",
+				fmt.Errorf("only one verbosity level can be set, but 2 were set"),
+				fmt.Errorf("'%s', '%s' and '%s' are mutually exclusive", GraphFlag, HostFlag, ListFlag),
+				fmt.Errorf("'%s' and '%s' are mutually exclusive", AskVaultPasswordFlag, VaultPasswordFileFlag),
+				fmt.Errorf("'%s' and '%s' are mutually exclusive", TomlFlag, YamlFlag),
+				fmt.Errorf("'%s' is meaningless without '%s'", ExportFlag, ListFlag),
+				fmt.Errorf("'%s' is meaningless with '%s'", TomlFlag, GraphFlag),
+				fmt.Errorf("'%s' is meaningless with '%s'", YamlFlag, GraphFlag),
+			),
+		},
+		{
+			desc: "Testing error validating AnsibleInventoryOptions with vars and no graph",
+			options: &AnsibleInventoryOptions{
+				List: true,
+				Vars: true,
+			},
+			err: errors.New("(inventory::Validate)", "Invalid ansible-inventory options",
+				fmt.Errorf("'%s' is meaningless without '%s'", VarsFlag, GraphFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.Validate()
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+// TestValidateFiles tests
+func TestValidateFiles(t *testing.T) {
+
+	tempDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(tempDir, "vault_password"), []byte("password"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc    string
+		options *AnsibleInventoryOptions
+		dir     string
+		err     error
+	}{
+		{
+			desc:    "Testing error validating files of nil AnsibleInventoryOptions",
+			options: nil,
+			err:     errors.New("(inventory::ValidateFiles)", "AnsibleInventoryOptions is nil"),
+		},
+		{
+			desc: "Testing validate files of AnsibleInventoryOptions resolving the relative files against the directory",
+			options: &AnsibleInventoryOptions{
+				VaultPasswordFile: "vault_password",
+			},
+			dir: tempDir,
+			err: nil,
+		},
+		{
+			desc: "Testing error validating files of AnsibleInventoryOptions with a missing file",
+			options: &AnsibleInventoryOptions{
+				VaultPasswordFile: "missing",
+			},
+			dir: tempDir,
+			err: errors.New("(inventory::ValidateFiles)", "Invalid ansible-inventory option files",
+				fmt.Errorf("'%s' file is not valid: %w", VaultPasswordFileFlag, &fs.PathError{Op: "stat", Path: filepath.Join(tempDir, "missing"), Err: syscall.ENOENT}),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.ValidateFiles(test.dir)
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+func TestVaultClientOptions(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *AnsibleInventoryOptions
+		labels  []string
+	}{
+		{
+			desc: "Testing vault client options sorted by vault id label",
+			options: &AnsibleInventoryOptions{
+				VaultPasswordReaders: map[string]client.PasswordReader{
+					"prod": text.NewReadPasswordFromText(text.WithText("prod-password")),
+					"dev":  text.NewReadPasswordFromText(text.WithText("dev-password")),
+				},
+			},
+			labels: []string{"dev", "prod"},
+		},
+		{
+			desc:    "Testing vault client options without vault password readers",
+			options: &AnsibleInventoryOptions{},
+		},
+		{
+			desc: "Testing vault client options of nil options",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			options := test.options.VaultClientOptions()
+			assert.Len(t, options, len(test.labels))
+			if len(options) == 0 {
+				return
+			}
+
+			vaultClient := client.NewVaultPasswordClient(options...)
+			err := vaultClient.Start()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer vaultClient.Close()
+
+			labels := []string{}
+			for _, vaultID := range vaultClient.VaultIDs() {
+				label, _, _ := strings.Cut(vaultID, "@")
+				labels = append(labels, label)
+			}
+			assert.Equal(t, test.labels, labels)
+		})
+	}
+}
@@ -1,14 +1,18 @@
 package inventory
 
 import (
+	"bytes"
 	"context"
 
 	"github.com/apenella/go-ansible/v2/pkg/execute"
+	"github.com/apenella/go-ansible/v2/pkg/vault/client"
+	errors "github.com/apenella/go-common-utils/error"
 )
 
 // AnsibleInventoryExecute is an executor for ansible-inventory command that runs the command using a DefaultExecute with default options
 type AnsibleInventoryExecute struct {
-	cmd *AnsibleInventoryCmd
+	cmd                *AnsibleInventoryCmd
+	vaultClientOptions []client.OptionsFunc
 }
 
 // NewAnsibleInventoryExecute returns a new AnsibleInventoryExecute. It receives a pattern to be used to filter the inventory
@@ -44,6 +48,13 @@ func (e *AnsibleInventoryExecute) WithPattern(pattern string) *AnsibleInventoryE
 	return e
 }
 
+// WithVaultPasswordReader returns an AnsibleInventoryExecute that reads the password of the vault id label from the reader. The password is served to ansible-inventory through a vault client script, without writing it to disk
+func (e *AnsibleInventoryExecute) WithVaultPasswordReader(label string, reader client.PasswordReader) *AnsibleInventoryExecute {
+	e.vaultClientOptions = append(e.vaultClientOptions, client.WithPasswordReader(label, reader))
+
+	return e
+}
+
 // Execute method runs the ansible-inventory command using a DefaultExecute with default options
 func (e *AnsibleInventoryExecute) Execute(ctx context.Context) error {
 
@@ -51,6 +62,11 @@ func (e *AnsibleInventoryExecute) Execute(ctx context.Context) error {
 		execute.WithCmd(e.cmd),
 	)
 
+	vaultClientOptions := e.vaultPasswordClientOptions()
+	if len(vaultClientOptions) > 0 {
+		return client.NewAnsibleWithVaultPasswordClientExecute(exec, vaultClientOptions...).Execute(ctx)
+	}
+
 	err := exec.Execute(ctx)
 	if err != nil {
 		return err
@@ -58,3 +74,132 @@ func (e *AnsibleInventoryExecute) Execute(ctx context.Context) error {
 
 	return nil
 }
+
+// List runs ansible-inventory --list and parses its output into an Inventory. The output format is set by the Yaml and Toml options, and the pattern is ignored
+func (e *AnsibleInventoryExecute) List(ctx context.Context) (*Inventory, error) {
+	errContext := "(inventory::AnsibleInventoryExecute::List)"
+
+	cmd := e.cmd.queryCmd(false)
+	cmd.InventoryOptions.List = true
+	cmd.InventoryOptions.Vars = false
+
+	content, err := e.output(ctx, cmd)
+	if err != nil {
+		return nil, errors.New(errContext, "Error listing the inventory", err)
+	}
+
+	inventory, err := ParseList(content, cmd.InventoryOptions.outputFormat())
+	if err != nil {
+		return nil, errors.New(errContext, "Error listing the inventory", err)
+	}
+
+	return inventory, nil
+}
+
+// HostVars runs ansible-inventory --host and parses its output into the host variables. The output format is set by the Yaml and Toml options, and the pattern is ignored
+func (e *AnsibleInventoryExecute) HostVars(ctx context.Context, host string) (map[string]interface{}, error) {
+	errContext := "(inventory::AnsibleInventoryExecute::HostVars)"
+
+	cmd := e.cmd.queryCmd(false)
+	cmd.InventoryOptions.Host = host
+	cmd.InventoryOptions.Export = false
+	cmd.InventoryOptions.Vars = false
+
+	content, err := e.output(ctx, cmd)
+	if err != nil {
+		return nil, errors.New(errContext, "Error getting the host variables", err)
+	}
+
+	vars, err := ParseHostVars(content, cmd.InventoryOptions.outputFormat())
+	if err != nil {
+		return nil, errors.New(errContext, "Error getting the host variables", err)
+	}
+
+	return vars, nil
+}
+
+// Graph runs ansible-inventory --graph and parses its output into the tree of the group set by the pattern, or the all group when there is no pattern. The variables are included when the Vars option is set
+func (e *AnsibleInventoryExecute) Graph(ctx context.Context) (*GraphGroup, error) {
+	errContext := "(inventory::AnsibleInventoryExecute::Graph)"
+
+	cmd := e.cmd.queryCmd(true)
+	cmd.InventoryOptions.Graph = true
+	cmd.InventoryOptions.Export = false
+	cmd.InventoryOptions.Toml = false
+	cmd.InventoryOptions.Yaml = false
+
+	content, err := e.output(ctx, cmd)
+	if err != nil {
+		return nil, errors.New(errContext, "Error getting the inventory graph", err)
+	}
+
+	graph, err := ParseGraph(content)
+	if err != nil {
+		return nil, errors.New(errContext, "Error getting the inventory graph", err)
+	}
+
+	return graph, nil
+}
+
+// output runs the command and returns its standard output
+func (e *AnsibleInventoryExecute) output(ctx context.Context, cmd *AnsibleInventoryCmd) ([]byte, error) {
+	buff := &bytes.Buffer{}
+
+	exec := execute.NewDefaultExecute(
+		execute.WithCmd(cmd),
+		execute.WithWrite(buff),
+	)
+
+	var err error
+	vaultClientOptions := e.vaultPasswordClientOptions()
+	if len(vaultClientOptions) > 0 {
+		err = client.NewAnsibleWithVaultPasswordClientExecute(exec, vaultClientOptions...).Execute(ctx)
+	} else {
+		err = exec.Execute(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return buff.Bytes(), nil
+}
+
+// queryCmd returns a copy of the command whose options do not set any action, so the caller sets the one to run and unsets the options that are meaningless for it. The output is written to the standard output, and the pattern is only kept when keepPattern is true
+func (c *AnsibleInventoryCmd) queryCmd(keepPattern bool) *AnsibleInventoryCmd {
+	cmd := *c
+
+	options := &AnsibleInventoryOptions{}
+	if c.InventoryOptions != nil {
+		optionsCopy := *c.InventoryOptions
+		options = &optionsCopy
+	}
+	options.List = false
+	options.Host = ""
+	options.Graph = false
+	options.Output = ""
+	options.Version = false
+
+	if !keepPattern {
+		cmd.Pattern = ""
+	}
+	cmd.InventoryOptions = options
+
+	return &cmd
+}
+
+// outputFormat returns the format of the --list and --host outputs
+func (o *AnsibleInventoryOptions) outputFormat() Format {
+	switch {
+	case o.Yaml:
+		return YAMLFormat
+	case o.Toml:
+		return TOMLFormat
+	default:
+		return JSONFormat
+	}
+}
+
+// vaultPasswordClientOptions returns the options of the vault password client that serves the passwords of the options VaultPasswordReaders and of the executor vault password readers
+func (e *AnsibleInventoryExecute) vaultPasswordClientOptions() []client.OptionsFunc {
+	return append(e.cmd.InventoryOptions.VaultClientOptions(), e.vaultClientOptions...)
+}
@@ -1,8 +1,13 @@
 package inventory
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/apenella/go-ansible/v2/pkg/vault/client"
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -117,3 +122,147 @@ func TestWithPattern(t *testing.T) {
 		})
 	}
 }
+
+func TestWithVaultPasswordReader(t *testing.T) {
+	tests := []struct {
+		desc    string
+		labels  []string
+		options int
+	}{
+		{
+			desc:    "Testing setting vault password readers to AnsibleInventoryExecute",
+			labels:  []string{"dev", "prod"},
+			options: 2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			e := &AnsibleInventoryExecute{
+				cmd: &AnsibleInventoryCmd{},
+			}
+
+			for _, label := range test.labels {
+				e = e.WithVaultPasswordReader(label, text.NewReadPasswordFromText(text.WithText(label)))
+			}
+
+			assert.Len(t, e.vaultClientOptions, test.options)
+		})
+	}
+}
+
+func TestVaultPasswordClientOptions(t *testing.T) {
+	t.Log("Testing the vault password client options include the options vault password readers")
+
+	e := &AnsibleInventoryExecute{
+		cmd: &AnsibleInventoryCmd{
+			InventoryOptions: &AnsibleInventoryOptions{
+				VaultPasswordReaders: map[string]client.PasswordReader{
+					"dev": text.NewReadPasswordFromText(text.WithText("dev-password")),
+				},
+			},
+		},
+	}
+	e = e.WithVaultPasswordReader("prod", text.NewReadPasswordFromText(text.WithText("prod-password")))
+
+	assert.Len(t, e.vaultPasswordClientOptions(), 2)
+}
+
+// writeFakeInventoryBinary writes a fake ansible-inventory binary that writes its arguments to the args file and prints the output of the --list, --host and --graph actions
+func writeFakeInventoryBinary(t *testing.T) (string, string) {
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "ansible-inventory")
+	args := filepath.Join(dir, "args")
+
+	script := `#!/bin/sh
+echo "$@" > "` + args + `"
+for arg in "$@"; do
+  case "$arg" in
+    --list) echo '{"_meta": {"hostvars": {"web1": {"http_port": 80}}}, "all": {"children": ["ungrouped", "web"]}, "web": {"hosts": ["web1"]}}' ;;
+    --host=*) echo "http_port: 80" ;;
+    --graph) printf '@web:\n  |--web1\n' ;;
+  esac
+done
+`
+	err := os.WriteFile(binary, []byte(script), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return binary, args
+}
+
+func TestList(t *testing.T) {
+	t.Log("Testing list the inventory running ansible-inventory --list")
+
+	binary, args := writeFakeInventoryBinary(t)
+
+	inv, err := NewAnsibleInventoryExecute().
+		WithBinary(binary).
+		WithPattern("web").
+		WithInventoryOptions(&AnsibleInventoryOptions{
+			Graph:     true,
+			Inventory: "hosts.yml",
+			Output:    "inventory.json",
+			Vars:      true,
+		}).
+		List(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"web1"}, inv.HostsInGroup("web"))
+	assert.Equal(t, map[string]interface{}{"http_port": 80}, inv.Host("web1").Vars)
+
+	content, err := os.ReadFile(args)
+	assert.NoError(t, err)
+	assert.Equal(t, " --inventory=hosts.yml --list\n", string(content))
+}
+
+func TestHostVars(t *testing.T) {
+	t.Log("Testing get the host variables running ansible-inventory --host")
+
+	binary, args := writeFakeInventoryBinary(t)
+
+	vars, err := NewAnsibleInventoryExecute().
+		WithBinary(binary).
+		WithInventoryOptions(&AnsibleInventoryOptions{
+			Inventory: "hosts.yml",
+			Yaml:      true,
+		}).
+		HostVars(context.TODO(), "web1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"http_port": 80}, vars)
+
+	content, err := os.ReadFile(args)
+	assert.NoError(t, err)
+	assert.Equal(t, " --host=web1 --inventory=hosts.yml --yaml\n", string(content))
+}
+
+func TestGraph(t *testing.T) {
+	t.Log("Testing get the inventory graph running ansible-inventory --graph")
+
+	binary, args := writeFakeInventoryBinary(t)
+
+	graph, err := NewAnsibleInventoryExecute().
+		WithBinary(binary).
+		WithPattern("web").
+		WithInventoryOptions(&AnsibleInventoryOptions{
+			Inventory: "hosts.yml",
+			List:      true,
+			Yaml:      true,
+		}).
+		Graph(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, &GraphGroup{
+		Name:   "web",
+		Groups: []*GraphGroup{},
+		Hosts:  []*GraphHost{{Name: "web1", Vars: map[string]string{}}},
+		Vars:   map[string]string{},
+	}, graph)
+
+	content, err := os.ReadFile(args)
+	assert.NoError(t, err)
+	assert.Equal(t, "web --graph --inventory=hosts.yml\n", string(content))
+}
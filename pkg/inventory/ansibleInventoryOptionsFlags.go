@@ -0,0 +1,113 @@
+package inventory
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/apenella/go-ansible/v2/pkg/internal/flags"
+	"github.com/spf13/pflag"
+)
+
+// flagAliases relates the alternative long flag names accepted by ansible-inventory to the names used by the AnsibleInventoryOptions flags
+var flagAliases = map[string]string{
+	"--ask-vault-pass":  AskVaultPasswordFlag,
+	"--inventory-file":  InventoryFlag,
+	"--vault-pass-file": VaultPasswordFileFlag,
+}
+
+// verboseFlag is the long name of the verbose flag, whose constants are the -v shorthands
+const verboseFlag = "--verbose"
+
+// flagUsages relates the AnsibleInventoryOptions flags to their help text, copied from the ansible help. Every flag constant must have an entry
+var flagUsages = map[string]string{
+	AskVaultPasswordFlag:  "ask for vault password",
+	ExportFlag:            "when doing an --list, represent in a way that is optimized for export, not as an accurate representation of how Ansible has processed it",
+	GraphFlag:             "create inventory graph, if supplying pattern it must be a valid group name",
+	HostFlag:              "output specific host info, works as inventory script",
+	InventoryFlag:         "specify inventory host path or comma separated host list",
+	LimitFlag:             "further limit selected hosts to an additional pattern",
+	ListFlag:              "output all hosts info, works as inventory script",
+	OutputFlag:            "when doing --list, send the inventory to a file instead of to the screen",
+	PlaybookDirFlag:       "since this tool does not use playbooks, use this as a substitute inventory directory. This sets the relative path for many features including roles/ group_vars/ etc",
+	TomlFlag:              "use TOML format instead of default JSON, ignored for --graph",
+	VarsFlag:              "add vars to graph display, ignored unless used with --graph",
+	VaultIdFlag:           "the vault identity to use",
+	VaultPasswordFileFlag: "vault password file",
+	verboseFlag:           "verbose mode (-vvv for more, -vvvv to enable connection debugging)",
+	VersionFlag:           "show program’s version number, config file location, configured module search path, module location, executable location and exit",
+	YamlFlag:              "use YAML format instead of default JSON, ignored for --graph",
+}
+
+// AddFlags registers the AnsibleInventoryOptions attributes as flags on the flag set, using the ansible flag names and shorthands. The current attribute values are used as the flag defaults and parsing the flag set populates the options. When prefix is not empty, it is prepended to the flag names and the shorthands are not registered to avoid collisions. The ansible flag aliases, such as --inventory-file, are accepted as well
+func (o *AnsibleInventoryOptions) AddFlags(fs *pflag.FlagSet, prefix string) {
+	flags.NormalizeAliases(fs, prefix, flagAliases)
+
+	fs.BoolVarP(&o.AskVaultPassword, flagName(prefix, AskVaultPasswordFlag), flagShorthand(prefix, "J"), o.AskVaultPassword, flagUsages[AskVaultPasswordFlag])
+	fs.BoolVar(&o.Export, flagName(prefix, ExportFlag), o.Export, flagUsages[ExportFlag])
+	fs.BoolVar(&o.Graph, flagName(prefix, GraphFlag), o.Graph, flagUsages[GraphFlag])
+	fs.StringVar(&o.Host, flagName(prefix, HostFlag), o.Host, flagUsages[HostFlag])
+	fs.StringVarP(&o.Inventory, flagName(prefix, InventoryFlag), flagShorthand(prefix, "i"), o.Inventory, flagUsages[InventoryFlag])
+	fs.StringVarP(&o.Limit, flagName(prefix, LimitFlag), flagShorthand(prefix, "l"), o.Limit, flagUsages[LimitFlag])
+	fs.BoolVar(&o.List, flagName(prefix, ListFlag), o.List, flagUsages[ListFlag])
+	fs.StringVar(&o.Output, flagName(prefix, OutputFlag), o.Output, flagUsages[OutputFlag])
+	fs.StringVar(&o.PlaybookDir, flagName(prefix, PlaybookDirFlag), o.PlaybookDir, flagUsages[PlaybookDirFlag])
+	fs.BoolVar(&o.Toml, flagName(prefix, TomlFlag), o.Toml, flagUsages[TomlFlag])
+	fs.BoolVar(&o.Vars, flagName(prefix, VarsFlag), o.Vars, flagUsages[VarsFlag])
+	fs.Var(flags.NewSingleValue(VaultIdFlag, &o.VaultID), flagName(prefix, VaultIdFlag), flagUsages[VaultIdFlag])
+	fs.StringVar(&o.VaultPasswordFile, flagName(prefix, VaultPasswordFileFlag), o.VaultPasswordFile, flagUsages[VaultPasswordFileFlag])
+	verbose := fs.VarPF(&verbosityValue{options: o}, flagName(prefix, verboseFlag), flagShorthand(prefix, "v"), flagUsages[verboseFlag])
+	verbose.NoOptDefVal = "+1"
+	fs.BoolVar(&o.Version, flagName(prefix, VersionFlag), o.Version, flagUsages[VersionFlag])
+	fs.BoolVarP(&o.Yaml, flagName(prefix, YamlFlag), flagShorthand(prefix, "y"), o.Yaml, flagUsages[YamlFlag])
+}
+
+// flagName returns the flag name without the leading dashes and prepended by the prefix
+func flagName(prefix, flag string) string {
+	return prefix + strings.TrimLeft(flag, "-")
+}
+
+// flagShorthand returns the flag shorthand when there is no prefix, otherwise returns an empty shorthand
+func flagShorthand(prefix, shorthand string) string {
+	if prefix != "" {
+		return ""
+	}
+
+	return shorthand
+}
+
+// verbosityValue is a pflag.Value that counts the verbose flag occurrences and sets the verbosity attributes of the AnsibleInventoryOptions. Four or more occurrences enable the Verbose attribute
+type verbosityValue struct {
+	count   int
+	options *AnsibleInventoryOptions
+}
+
+// String returns the verbosity level
+func (v *verbosityValue) String() string {
+	return strconv.Itoa(v.count)
+}
+
+// Set increases the verbosity level when value is +1, otherwise value is the verbosity level
+func (v *verbosityValue) Set(value string) error {
+	if value == "+1" {
+		v.count++
+	} else {
+		count, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid verbosity level '%s'", value)
+		}
+		v.count = count
+	}
+
+	v.options.Verbose = v.count >= 4
+	v.options.VerboseVVV = v.count == 3
+	v.options.VerboseVV = v.count == 2
+	v.options.VerboseV = v.count == 1
+
+	return nil
+}
+
+// Type returns the verbosity value type
+func (v *verbosityValue) Type() string {
+	return "count"
+}
@@ -0,0 +1,101 @@
+package inventory
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/internal/flags/flagstest"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAddFlags tests
+func TestAddFlags(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *AnsibleInventoryOptions
+		prefix  string
+		args    []string
+		res     *AnsibleInventoryOptions
+		err     error
+	}{
+		{
+			desc:    "Testing add flags and parse short flags",
+			options: &AnsibleInventoryOptions{},
+			args:    []string{"-i", "inventory.ini", "-l", "all", "-y", "--list"},
+			res: &AnsibleInventoryOptions{
+				Inventory: "inventory.ini",
+				Limit:     "all",
+				List:      true,
+				Yaml:      true,
+			},
+		},
+		{
+			desc:    "Testing add flags with prefix and parse long flags",
+			options: &AnsibleInventoryOptions{},
+			prefix:  "ansible-",
+			args:    []string{"--ansible-graph", "--ansible-vars", "--ansible-inventory=inventory.ini"},
+			res: &AnsibleInventoryOptions{
+				Graph:     true,
+				Inventory: "inventory.ini",
+				Vars:      true,
+			},
+		},
+		{
+			desc:    "Testing add flags with prefix and parse flag aliases",
+			options: &AnsibleInventoryOptions{},
+			prefix:  "ansible-",
+			args:    []string{"--ansible-inventory-file", "127.0.0.1,", "--ansible-vault-pass-file=vault.txt"},
+			res: &AnsibleInventoryOptions{
+				Inventory:         "127.0.0.1,",
+				VaultPasswordFile: "vault.txt",
+			},
+		},
+		{
+			desc:    "Testing add flags with prefix does not register shorthands",
+			options: &AnsibleInventoryOptions{},
+			prefix:  "ansible-",
+			args:    []string{"-i"},
+			err:     fmt.Errorf("unknown shorthand flag: 'i' in -i"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			test.options.AddFlags(fs, test.prefix)
+
+			err := fs.Parse(test.args)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, test.options)
+			}
+		})
+	}
+}
+
+// TestAddFlagsUsages tests that every flag constant is registered with its help text, because the flagUsages entries are maintained by hand
+func TestAddFlagsUsages(t *testing.T) {
+	longFlags, err := flagstest.LongFlags("ansibleInventoryCmd.go", "ansibleInventoryOptionsFlags.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	options := &AnsibleInventoryOptions{}
+	options.AddFlags(fs, "")
+
+	for _, longFlag := range longFlags {
+		flag := fs.Lookup(strings.TrimPrefix(longFlag, "--"))
+		if assert.NotNil(t, flag, "flag %s is not registered", longFlag) {
+			assert.NotEmpty(t, flag.Usage, "flag %s has no help text", longFlag)
+			assert.Equal(t, flagUsages[longFlag], flag.Usage)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package inventory
+
+import (
+	"fmt"
+	"io"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/spf13/pflag"
+)
+
+// ParseAnsibleInventoryCmd creates an AnsibleInventoryCmd from an ansible-inventory command line. The first argument is the ansible-inventory binary, the flags are parsed into the inventory options and the remaining argument, when it is present, is the pattern. It is the inverse of the AnsibleInventoryCmd's Command method
+func ParseAnsibleInventoryCmd(args []string) (*AnsibleInventoryCmd, error) {
+
+	errContext := "(inventory::ParseAnsibleInventoryCmd)"
+
+	if len(args) == 0 {
+		return nil, errors.New(errContext, "No ansible-inventory command line to parse")
+	}
+
+	options := &AnsibleInventoryOptions{}
+
+	fs := pflag.NewFlagSet(args[0], pflag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	options.AddFlags(fs, "")
+
+	err := fs.Parse(args[1:])
+	if err != nil {
+		return nil, errors.New(errContext, "Error parsing ansible-inventory command line", err)
+	}
+
+	if fs.NArg() > 1 {
+		return nil, errors.New(errContext, fmt.Sprintf("Only one pattern can be defined, but '%d' were found", fs.NArg()))
+	}
+
+	cmd := NewAnsibleInventoryCmd(
+		WithBinary(args[0]),
+		WithPattern(fs.Arg(0)),
+	)
+
+	if fs.NFlag() > 0 {
+		cmd.InventoryOptions = options
+	}
+
+	return cmd, nil
+}
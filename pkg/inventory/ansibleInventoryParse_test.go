@@ -0,0 +1,135 @@
+package inventory
+
+import (
+	"fmt"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseAnsibleInventoryCmd tests
+func TestParseAnsibleInventoryCmd(t *testing.T) {
+
+	errContext := "(inventory::ParseAnsibleInventoryCmd)"
+
+	tests := []struct {
+		desc string
+		args []string
+		res  *AnsibleInventoryCmd
+		err  error
+	}{
+		{
+			desc: "Testing parse an ansible-inventory command line without arguments",
+			args: []string{},
+			err:  errors.New(errContext, "No ansible-inventory command line to parse"),
+		},
+		{
+			desc: "Testing parse an ansible-inventory command line with several patterns",
+			args: []string{"ansible-inventory", "--graph", "all", "webservers"},
+			err:  errors.New(errContext, "Only one pattern can be defined, but '2' were found"),
+		},
+		{
+			desc: "Testing parse an ansible-inventory command line with an unknown flag",
+			args: []string{"ansible-inventory", "--list", "--unknown"},
+			err:  errors.New(errContext, "Error parsing ansible-inventory command line", fmt.Errorf("unknown flag: --unknown")),
+		},
+		{
+			desc: "Testing parse an ansible-inventory command line without pattern",
+			args: []string{"ansible-inventory", "-i", "inventory.ini", "--list", "-y"},
+			res: &AnsibleInventoryCmd{
+				Binary: "ansible-inventory",
+				InventoryOptions: &AnsibleInventoryOptions{
+					Inventory: "inventory.ini",
+					List:      true,
+					Yaml:      true,
+				},
+			},
+		},
+		{
+			desc: "Testing parse an ansible-inventory command line with long flags and equal signs",
+			args: []string{"ansible-inventory", "all", "--inventory-file=inventory.ini", "--graph", "--vars", "--vault-pass-file", "vault.txt", "-vvv"},
+			res: &AnsibleInventoryCmd{
+				Binary:  "ansible-inventory",
+				Pattern: "all",
+				InventoryOptions: &AnsibleInventoryOptions{
+					Graph:             true,
+					Inventory:         "inventory.ini",
+					Vars:              true,
+					VaultPasswordFile: "vault.txt",
+					VerboseVVV:        true,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := ParseAnsibleInventoryCmd(test.args)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+// TestParseAnsibleInventoryCmdRoundTrip tests that parsing the command generated by an AnsibleInventoryCmd returns the same AnsibleInventoryCmd
+func TestParseAnsibleInventoryCmdRoundTrip(t *testing.T) {
+	tests := []struct {
+		desc string
+		cmd  *AnsibleInventoryCmd
+	}{
+		{
+			desc: "Testing round trip of an ansible-inventory command without options",
+			cmd: NewAnsibleInventoryCmd(
+				WithBinary("ansible-inventory"),
+				WithPattern("all"),
+			),
+		},
+		{
+			desc: "Testing round trip of an ansible-inventory command with all options",
+			cmd: NewAnsibleInventoryCmd(
+				WithBinary("custom-ansible-inventory"),
+				WithPattern("all"),
+				WithInventoryOptions(&AnsibleInventoryOptions{
+					AskVaultPassword:  true,
+					Export:            true,
+					Graph:             true,
+					Host:              "localhost",
+					Inventory:         "127.0.0.1,",
+					Limit:             "myhost",
+					List:              true,
+					Output:            "/tmp/inventory.json",
+					PlaybookDir:       "playbook-dir",
+					Toml:              true,
+					Vars:              true,
+					VaultID:           "dev@prompt",
+					VaultPasswordFile: "/dev/null",
+					Verbose:           true,
+					Version:           true,
+					Yaml:              true,
+				}),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			test.cmd.SkipValidation = true
+			command, err := test.cmd.Command()
+			assert.NoError(t, err)
+
+			res, err := ParseAnsibleInventoryCmd(command)
+			assert.NoError(t, err)
+
+			res.SkipValidation = true
+			assert.Equal(t, test.cmd, res)
+		})
+	}
+}
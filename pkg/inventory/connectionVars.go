@@ -0,0 +1,69 @@
+package inventory
+
+const (
+	// AnsibleConnectionVar sets the connection plugin
+	AnsibleConnectionVar = "ansible_connection"
+	// AnsibleHostVar sets the address to connect to
+	AnsibleHostVar = "ansible_host"
+	// AnsiblePortVar sets the port to connect to
+	AnsiblePortVar = "ansible_port"
+	// AnsibleUserVar sets the user to connect as
+	AnsibleUserVar = "ansible_user"
+	// AnsiblePasswordVar sets the password to connect with
+	AnsiblePasswordVar = "ansible_password"
+	// AnsibleSSHPrivateKeyFileVar sets the private key file used by ssh
+	AnsibleSSHPrivateKeyFileVar = "ansible_ssh_private_key_file"
+	// AnsibleSSHCommonArgsVar sets the arguments appended to the sftp, scp and ssh command lines
+	AnsibleSSHCommonArgsVar = "ansible_ssh_common_args"
+	// AnsibleSSHExtraArgsVar sets the arguments appended to the ssh command line
+	AnsibleSSHExtraArgsVar = "ansible_ssh_extra_args"
+	// AnsiblePythonInterpreterVar sets the python interpreter of the host
+	AnsiblePythonInterpreterVar = "ansible_python_interpreter"
+)
+
+// ConnectionVars are the behavioral inventory variables that define how Ansible connects to the hosts. The empty attributes are not set
+type ConnectionVars struct {
+	// Connection is the connection plugin, such as ssh, local or docker
+	Connection string
+	// Host is the address to connect to, when it differs from the inventory host name
+	Host string
+	// Port is the port to connect to
+	Port int
+	// User is the user to connect as
+	User string
+	// Password is the password to connect with. Consider providing it through a connection password reader instead
+	Password string
+	// PrivateKeyFile is the private key file used by ssh
+	PrivateKeyFile string
+	// SSHCommonArgs are the arguments appended to the sftp, scp and ssh command lines
+	SSHCommonArgs string
+	// SSHExtraArgs are the arguments appended to the ssh command line
+	SSHExtraArgs string
+	// PythonInterpreter is the python interpreter of the host
+	PythonInterpreter string
+}
+
+// Vars returns the inventory variables of the connection attributes that are set
+func (c ConnectionVars) Vars() map[string]interface{} {
+	vars := map[string]interface{}{}
+
+	setVar := func(name, value string) {
+		if value != "" {
+			vars[name] = value
+		}
+	}
+
+	setVar(AnsibleConnectionVar, c.Connection)
+	setVar(AnsibleHostVar, c.Host)
+	if c.Port > 0 {
+		vars[AnsiblePortVar] = c.Port
+	}
+	setVar(AnsibleUserVar, c.User)
+	setVar(AnsiblePasswordVar, c.Password)
+	setVar(AnsibleSSHPrivateKeyFileVar, c.PrivateKeyFile)
+	setVar(AnsibleSSHCommonArgsVar, c.SSHCommonArgs)
+	setVar(AnsibleSSHExtraArgsVar, c.SSHExtraArgs)
+	setVar(AnsiblePythonInterpreterVar, c.PythonInterpreter)
+
+	return vars
+}
@@ -0,0 +1,58 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionVars(t *testing.T) {
+	tests := []struct {
+		desc       string
+		connection ConnectionVars
+		expected   map[string]interface{}
+	}{
+		{
+			desc: "Testing the variables of all the connection attributes",
+			connection: ConnectionVars{
+				Connection:        "ssh",
+				Host:              "10.0.0.1",
+				Port:              2222,
+				User:              "deploy",
+				Password:          "secret",
+				PrivateKeyFile:    "~/.ssh/id_ed25519",
+				SSHCommonArgs:     "-o ProxyJump=bastion",
+				SSHExtraArgs:      "-o ForwardAgent=yes",
+				PythonInterpreter: "/usr/bin/python3",
+			},
+			expected: map[string]interface{}{
+				"ansible_connection":           "ssh",
+				"ansible_host":                 "10.0.0.1",
+				"ansible_port":                 2222,
+				"ansible_user":                 "deploy",
+				"ansible_password":             "secret",
+				"ansible_ssh_private_key_file": "~/.ssh/id_ed25519",
+				"ansible_ssh_common_args":      "-o ProxyJump=bastion",
+				"ansible_ssh_extra_args":       "-o ForwardAgent=yes",
+				"ansible_python_interpreter":   "/usr/bin/python3",
+			},
+		},
+		{
+			desc: "Testing the variables of the connection attributes that are set",
+			connection: ConnectionVars{
+				Connection: "local",
+			},
+			expected: map[string]interface{}{
+				"ansible_connection": "local",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expected, test.connection.Vars())
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package dynamic
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/apenella/go-ansible/v2/pkg/internal/socketscript"
+	"github.com/apenella/go-ansible/v2/pkg/inventory"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// DynamicInventoryScriptName is the name of the dynamic inventory script. It has no extension, so the script inventory plugin is the one that loads it
+	DynamicInventoryScriptName = "go-ansible-inventory"
+
+	// requestHost is the request of the variables of a host, followed by the host name
+	requestHost = "host"
+	// requestList is the request of the whole inventory
+	requestList = "list"
+	// socketName is the name of the Unix socket where the inventory is served
+	socketName = "inventory.sock"
+)
+
+// InventoryFunc computes the inventory. It is called on every request of the dynamic inventory script, so ansible always gets fresh data
+type InventoryFunc func(ctx context.Context) (*inventory.Inventory, error)
+
+// OptionsFunc is a function used to configure DynamicInventory
+type OptionsFunc func(*DynamicInventory)
+
+// DynamicInventory serves the inventory computed by an InventoryFunc to ansible without writing it to disk. It creates a private directory with a Unix socket and a dynamic inventory script, which ansible calls with the --list and --host arguments. The script asks the inventory to the DynamicInventory through the socket
+type DynamicInventory struct {
+	ctx         context.Context
+	dir         string
+	fn          InventoryFunc
+	interpreter string
+	mutex       sync.Mutex
+	server      *socketscript.Server
+}
+
+// NewDynamicInventory returns a DynamicInventory that serves the inventory computed by fn. It must be started before ansible runs and closed afterwards
+func NewDynamicInventory(fn InventoryFunc, options ...OptionsFunc) *DynamicInventory {
+	dynamicInventory := &DynamicInventory{
+		fn:          fn,
+		interpreter: DefaultInterpreter,
+	}
+	dynamicInventory.Options(options...)
+
+	return dynamicInventory
+}
+
+// WithDir sets the directory where the private directory of the socket and the script is created. The default temporary directory is used by default
+func WithDir(dir string) OptionsFunc {
+	return func(d *DynamicInventory) {
+		d.dir = dir
+	}
+}
+
+// WithInterpreter sets the interpreter of the dynamic inventory script. It must run Python scripts
+func WithInterpreter(interpreter string) OptionsFunc {
+	return func(d *DynamicInventory) {
+		d.interpreter = interpreter
+	}
+}
+
+// Options configure the DynamicInventory
+func (d *DynamicInventory) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(d)
+	}
+}
+
+// Start creates the private directory, the dynamic inventory script and starts serving the inventory on the Unix socket. The context is given to the InventoryFunc on every request
+func (d *DynamicInventory) Start(ctx context.Context) error {
+	errContext := "(dynamic::DynamicInventory::Start)"
+
+	if d == nil {
+		return errors.New(errContext, "DynamicInventory must be initialized before starting it")
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.server != nil {
+		return errors.New(errContext, "DynamicInventory is already started")
+	}
+
+	if d.fn == nil {
+		return errors.New(errContext, "DynamicInventory requires an inventory function")
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	d.ctx = ctx
+
+	server, err := socketscript.Start(socketscript.Config{
+		Name:        "dynamic inventory",
+		Dir:         d.dir,
+		Pattern:     "go-ansible-inventory-*",
+		ScriptName:  DynamicInventoryScriptName,
+		SocketName:  socketName,
+		Handler:     d.handle,
+		WriteScript: d.writeScript,
+	})
+	if err != nil {
+		return errors.New(errContext, "Error starting the dynamic inventory", err)
+	}
+	d.server = server
+
+	return nil
+}
+
+// Close stops serving the inventory and removes the private directory. It is safe to call it more than once
+func (d *DynamicInventory) Close() error {
+	if d == nil {
+		return nil
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	err := d.server.Close()
+	d.server = nil
+	if err != nil {
+		return errors.New("(dynamic::DynamicInventory::Close)", "Error closing the dynamic inventory", err)
+	}
+
+	return nil
+}
+
+// ScriptPath returns the path of the dynamic inventory script, which is the inventory source given to ansible. It is empty until the DynamicInventory is started
+func (d *DynamicInventory) ScriptPath() string {
+	if d == nil || d.server == nil {
+		return ""
+	}
+
+	return d.server.ScriptPath()
+}
+
+// writeScript writes the dynamic inventory script
+func (d *DynamicInventory) writeScript(w io.Writer, socket, token string) error {
+	script := &dynamicInventoryScript{
+		Interpreter: d.interpreter,
+		RequestHost: requestHost,
+		RequestList: requestList,
+		Socket:      socket,
+		StatusOK:    socketscript.StatusOK,
+		Token:       token,
+	}
+
+	return script.Write(w)
+}
+
+// handle answers a request with the inventory, or with the variables of a host, in the JSON format of the dynamic inventory scripts. The request is either list or host and the host name
+func (d *DynamicInventory) handle(request string) (string, string) {
+	content, err := d.respond(request)
+	if err != nil {
+		return socketscript.StatusError, err.Error()
+	}
+
+	return socketscript.StatusOK, string(content)
+}
+
+// respond computes the inventory and renders the requested content
+func (d *DynamicInventory) respond(request string) ([]byte, error) {
+	action, host, _ := strings.Cut(request, " ")
+	if action != requestList && action != requestHost {
+		return nil, fmt.Errorf("unknown dynamic inventory request '%s'", action)
+	}
+
+	inv, err := d.fn(d.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error computing the inventory: %s", err.Error())
+	}
+
+	if inv == nil {
+		inv = inventory.NewInventory()
+	}
+
+	if action == requestHost {
+		return inv.RenderScriptHost(host)
+	}
+
+	return inv.RenderScriptList()
+}
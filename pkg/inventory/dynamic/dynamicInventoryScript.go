@@ -0,0 +1,44 @@
+package dynamic
+
+import (
+	"io"
+
+	"github.com/apenella/go-ansible/v2/pkg/internal/socketscript"
+)
+
+// DefaultInterpreter is the interpreter of the dynamic inventory script. Python is always available where ansible runs
+const DefaultInterpreter = socketscript.DefaultInterpreter
+
+// dynamicInventoryScriptTemplate is the dynamic inventory script loaded by the ansible script inventory plugin. It asks the inventory, or the variables of a host, to the DynamicInventory through its Unix socket and writes them to stdout
+var dynamicInventoryScriptTemplate = socketscript.NewScriptTemplate("dynamic-inventory", "Dynamic inventory", `def main():
+    args = sys.argv[1:]
+    if args == ["--list"]:
+        message = "{{ .RequestList }}"
+    elif len(args) == 2 and args[0] == "--host":
+        message = "{{ .RequestHost }} " + args[1]
+    else:
+        sys.stderr.write("usage: %s --list | --host <hostname>\n" % sys.argv[0])
+        return 2
+
+    status, content = request(message)
+    if status == "{{ .StatusOK }}":
+        sys.stdout.write(content)
+        return 0
+
+    sys.stderr.write(content + "\n")
+    return 1`)
+
+// dynamicInventoryScript are the values of the dynamic inventory script template
+type dynamicInventoryScript struct {
+	Interpreter string
+	RequestHost string
+	RequestList string
+	Socket      string
+	StatusOK    string
+	Token       string
+}
+
+// Write writes the dynamic inventory script
+func (s *dynamicInventoryScript) Write(w io.Writer) error {
+	return dynamicInventoryScriptTemplate.Execute(w, s)
+}
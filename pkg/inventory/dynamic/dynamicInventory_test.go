@@ -0,0 +1,247 @@
+package dynamic
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/inventory"
+	"github.com/stretchr/testify/assert"
+)
+
+// testInventoryFunc returns an InventoryFunc that computes an inventory with the web1 host on the web group
+func testInventoryFunc(t *testing.T) InventoryFunc {
+	return func(ctx context.Context) (*inventory.Inventory, error) {
+		return inventory.NewInventoryBuilder().
+			WithHost("web1", "web").
+			WithHostVars("web1", map[string]interface{}{"ansible_host": "10.0.0.1"}).
+			Build()
+	}
+}
+
+// request sends a request to the dynamic inventory socket and returns the response
+func request(t *testing.T, d *DynamicInventory, token, req string) string {
+	conn, err := net.Dial("unix", d.server.SocketPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	_, err = fmt.Fprintf(conn, "%s %s\n", token, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := io.ReadAll(bufio.NewReader(conn))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(response)
+}
+
+func TestDynamicInventoryStart(t *testing.T) {
+	tests := []struct {
+		desc      string
+		inventory *DynamicInventory
+		err       string
+	}{
+		{
+			desc:      "Testing start a dynamic inventory",
+			inventory: NewDynamicInventory(testInventoryFunc(t)),
+		},
+		{
+			desc:      "Testing error starting a dynamic inventory without inventory function",
+			inventory: NewDynamicInventory(nil),
+			err:       "DynamicInventory requires an inventory function",
+		},
+		{
+			desc:      "Testing error starting a dynamic inventory on a directory that does not exist",
+			inventory: NewDynamicInventory(testInventoryFunc(t), WithDir(filepath.Join(t.TempDir(), "unknown"))),
+			err:       "Error creating the dynamic inventory directory",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.inventory.Start(context.TODO())
+			defer test.inventory.Close()
+			if err != nil {
+				assert.Contains(t, err.Error(), test.err)
+				assert.Empty(t, test.inventory.ScriptPath())
+				return
+			}
+
+			assert.Empty(t, test.err)
+
+			script := test.inventory.ScriptPath()
+			assert.Equal(t, DynamicInventoryScriptName, filepath.Base(script))
+
+			info, err := os.Stat(script)
+			assert.NoError(t, err)
+			assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+			info, err = os.Stat(filepath.Dir(script))
+			assert.NoError(t, err)
+			assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+			err = test.inventory.Start(context.TODO())
+			assert.EqualError(t, err, "DynamicInventory is already started")
+		})
+	}
+}
+
+func TestDynamicInventoryClose(t *testing.T) {
+	t.Log("Testing close a dynamic inventory removes its directory")
+
+	dynamicInventory := NewDynamicInventory(testInventoryFunc(t), WithDir(t.TempDir()))
+
+	err := dynamicInventory.Start(context.TODO())
+	assert.NoError(t, err)
+	dir := filepath.Dir(dynamicInventory.ScriptPath())
+
+	assert.NoError(t, dynamicInventory.Close())
+	assert.NoError(t, dynamicInventory.Close())
+
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err))
+	assert.Empty(t, dynamicInventory.ScriptPath())
+}
+
+func TestDynamicInventoryHandle(t *testing.T) {
+	calls := 0
+	dynamicInventory := NewDynamicInventory(func(ctx context.Context) (*inventory.Inventory, error) {
+		calls++
+		if calls == 4 {
+			return nil, fmt.Errorf("cmdb unavailable")
+		}
+		return testInventoryFunc(t)(ctx)
+	})
+	err := dynamicInventory.Start(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dynamicInventory.Close()
+
+	tests := []struct {
+		desc    string
+		token   string
+		request string
+		res     string
+	}{
+		{
+			desc:    "Testing request the inventory",
+			token:   dynamicInventory.server.Token(),
+			request: "list",
+			res:     `OK` + "\n" + `{"all":{"hosts":[]},"web":{"hosts":["web1"]},"_meta":{"hostvars":{"web1":{"ansible_host":"10.0.0.1"}}}}`,
+		},
+		{
+			desc:    "Testing request the variables of a host",
+			token:   dynamicInventory.server.Token(),
+			request: "host web1",
+			res:     "OK\n" + `{"ansible_host":"10.0.0.1"}`,
+		},
+		{
+			desc:    "Testing request the variables of an unknown host",
+			token:   dynamicInventory.server.Token(),
+			request: "host unknown",
+			res:     "OK\n{}",
+		},
+		{
+			desc:    "Testing request the inventory when it can not be computed",
+			token:   dynamicInventory.server.Token(),
+			request: "list",
+			res:     "ERROR\nerror computing the inventory: cmdb unavailable",
+		},
+		{
+			desc:    "Testing an unknown request",
+			token:   dynamicInventory.server.Token(),
+			request: "refresh",
+			res:     "ERROR\nunknown dynamic inventory request 'refresh'",
+		},
+		{
+			desc:    "Testing request the inventory with an invalid token",
+			token:   "invalid",
+			request: "list",
+			res:     "ERROR\ninvalid dynamic inventory token",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res := request(t, dynamicInventory, test.token, test.request)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestDynamicInventoryScript(t *testing.T) {
+	_, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 is required to run the dynamic inventory script")
+	}
+
+	dynamicInventory := NewDynamicInventory(testInventoryFunc(t))
+	err = dynamicInventory.Start(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dynamicInventory.Close()
+
+	tests := []struct {
+		desc     string
+		args     []string
+		res      string
+		exitCode int
+	}{
+		{
+			desc: "Testing the dynamic inventory script returns the inventory",
+			args: []string{"--list"},
+			res:  `{"all":{"hosts":[]},"web":{"hosts":["web1"]},"_meta":{"hostvars":{"web1":{"ansible_host":"10.0.0.1"}}}}`,
+		},
+		{
+			desc: "Testing the dynamic inventory script returns the variables of a host",
+			args: []string{"--host", "web1"},
+			res:  `{"ansible_host":"10.0.0.1"}`,
+		},
+		{
+			desc:     "Testing the dynamic inventory script fails with invalid arguments",
+			args:     []string{"--refresh"},
+			exitCode: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			stdout := &strings.Builder{}
+			cmd := exec.Command(dynamicInventory.ScriptPath(), test.args...)
+			cmd.Stdout = stdout
+
+			err := cmd.Run()
+			if test.exitCode != 0 {
+				exitErr, isExitErr := err.(*exec.ExitError)
+				assert.True(t, isExitErr)
+				if isExitErr {
+					assert.Equal(t, test.exitCode, exitErr.ExitCode())
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.res, stdout.String())
+		})
+	}
+}
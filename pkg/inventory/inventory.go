@@ -0,0 +1,148 @@
+package inventory
+
+const (
+	// AllGroup is the group that contains every host of the inventory
+	AllGroup = "all"
+	// UngroupedGroup is the group that contains the hosts that only belong to the all group
+	UngroupedGroup = "ungrouped"
+)
+
+// Host is a host of the inventory and its variables
+type Host struct {
+	// Name is the name of the host
+	Name string
+	// Vars are the host variables
+	Vars map[string]interface{}
+}
+
+// Group is a group of the inventory, its hosts, its children groups and its variables
+type Group struct {
+	// Name is the name of the group
+	Name string
+	// Hosts are the names of the hosts that belong to the group
+	Hosts []string
+	// Children are the names of the children groups
+	Children []string
+	// Vars are the group variables
+	Vars map[string]interface{}
+
+	// fileVars are the variables loaded from the group_vars files, which are also included on Vars. Ansible gives them a different precedence than the variables defined on the inventory
+	fileVars map[string]interface{}
+}
+
+// Inventory is an in-memory Ansible inventory. The hosts and groups keep the order in which they are defined
+type Inventory struct {
+	hosts  []*Host
+	groups []*Group
+}
+
+// NewInventory returns an empty Inventory
+func NewInventory() *Inventory {
+	return &Inventory{
+		hosts:  []*Host{},
+		groups: []*Group{},
+	}
+}
+
+// Hosts returns the hosts of the inventory
+func (i *Inventory) Hosts() []*Host {
+	return i.hosts
+}
+
+// Groups returns every group defined on the inventory, including the all and ungrouped groups when they are defined, in the order in which they are added
+func (i *Inventory) Groups() []*Group {
+	return i.groups
+}
+
+// Host returns the host with the given name, or nil when it is not defined
+func (i *Inventory) Host(name string) *Host {
+	for _, host := range i.hosts {
+		if host.Name == name {
+			return host
+		}
+	}
+
+	return nil
+}
+
+// Group returns the group with the given name, or nil when it is not defined
+func (i *Inventory) Group(name string) *Group {
+	for _, group := range i.groups {
+		if group.Name == name {
+			return group
+		}
+	}
+
+	return nil
+}
+
+// AddHost returns the host with the given name, which is added to the inventory when it is not defined
+func (i *Inventory) AddHost(name string) *Host {
+	host := i.Host(name)
+	if host == nil {
+		host = &Host{
+			Name: name,
+			Vars: map[string]interface{}{},
+		}
+		i.hosts = append(i.hosts, host)
+	}
+
+	return host
+}
+
+// AddGroup returns the group with the given name, which is added to the inventory when it is not defined
+func (i *Inventory) AddGroup(name string) *Group {
+	group := i.Group(name)
+	if group == nil {
+		group = &Group{
+			Name:     name,
+			Hosts:    []string{},
+			Children: []string{},
+			Vars:     map[string]interface{}{},
+		}
+		i.groups = append(i.groups, group)
+	}
+
+	return group
+}
+
+// HostGroups returns the names of the groups the host directly belongs to
+func (i *Inventory) HostGroups(name string) []string {
+	groups := []string{}
+	for _, group := range i.groups {
+		if contains(group.Hosts, name) {
+			groups = append(groups, group.Name)
+		}
+	}
+
+	return groups
+}
+
+// AddHost adds the hosts to the group, skipping the ones that already belong to it
+func (g *Group) AddHost(hosts ...string) {
+	for _, host := range hosts {
+		if !contains(g.Hosts, host) {
+			g.Hosts = append(g.Hosts, host)
+		}
+	}
+}
+
+// AddChild adds the children groups to the group, skipping the ones that are already its children
+func (g *Group) AddChild(children ...string) {
+	for _, child := range children {
+		if !contains(g.Children, child) {
+			g.Children = append(g.Children, child)
+		}
+	}
+}
+
+// contains returns whether the item is on the list
+func contains(list []string, item string) bool {
+	for _, i := range list {
+		if i == item {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,216 @@
+package inventory
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+var (
+	// varNameRegexp matches the valid variable names
+	varNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	// invalidHostChars are the characters that a host name can not contain
+	invalidHostChars = " \t\r\n#=,[]"
+	// invalidGroupChars are the characters that a group name can not contain
+	invalidGroupChars = " \t\r\n#=,[]:"
+)
+
+// InventoryBuilder builds an Inventory through a fluent API. The hosts and groups are added when they are first referenced, and the errors are reported by Build
+type InventoryBuilder struct {
+	inventory *Inventory
+}
+
+// NewInventoryBuilder returns an InventoryBuilder of an empty inventory
+func NewInventoryBuilder() *InventoryBuilder {
+	return &InventoryBuilder{
+		inventory: NewInventory(),
+	}
+}
+
+// WithHost adds the host to the inventory and to the given groups
+func (b *InventoryBuilder) WithHost(name string, groups ...string) *InventoryBuilder {
+	b.inventory.AddHost(name)
+	for _, group := range groups {
+		b.inventory.AddGroup(group).AddHost(name)
+	}
+
+	return b
+}
+
+// WithHostVars sets variables of the host, which is added to the inventory when it is not defined
+func (b *InventoryBuilder) WithHostVars(name string, vars map[string]interface{}) *InventoryBuilder {
+	host := b.inventory.AddHost(name)
+	for key, value := range vars {
+		host.Vars[key] = value
+	}
+
+	return b
+}
+
+// WithHostConnection sets the connection variables of the host, which is added to the inventory when it is not defined
+func (b *InventoryBuilder) WithHostConnection(name string, connection ConnectionVars) *InventoryBuilder {
+	return b.WithHostVars(name, connection.Vars())
+}
+
+// WithGroup adds the group and its hosts to the inventory
+func (b *InventoryBuilder) WithGroup(name string, hosts ...string) *InventoryBuilder {
+	group := b.inventory.AddGroup(name)
+	for _, host := range hosts {
+		b.inventory.AddHost(host)
+		group.AddHost(host)
+	}
+
+	return b
+}
+
+// WithGroupChildren adds the children groups to the group. Both the group and its children are added to the inventory when they are not defined
+func (b *InventoryBuilder) WithGroupChildren(name string, children ...string) *InventoryBuilder {
+	group := b.inventory.AddGroup(name)
+	for _, child := range children {
+		b.inventory.AddGroup(child)
+		group.AddChild(child)
+	}
+
+	return b
+}
+
+// WithGroupVars sets variables of the group, which is added to the inventory when it is not defined. The variables of the all group apply to every host
+func (b *InventoryBuilder) WithGroupVars(name string, vars map[string]interface{}) *InventoryBuilder {
+	group := b.inventory.AddGroup(name)
+	for key, value := range vars {
+		group.Vars[key] = value
+	}
+
+	return b
+}
+
+// WithGroupConnection sets the connection variables of the group, which is added to the inventory when it is not defined
+func (b *InventoryBuilder) WithGroupConnection(name string, connection ConnectionVars) *InventoryBuilder {
+	return b.WithGroupVars(name, connection.Vars())
+}
+
+// Build validates the inventory and returns it. It returns an error that wraps all the detected issues, such as invalid names or cyclic children groups
+func (b *InventoryBuilder) Build() (*Inventory, error) {
+	err := ValidateInventory(b.inventory)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.inventory, nil
+}
+
+// ValidateInventory checks that the inventory can be rendered and loaded by Ansible. It returns an error that wraps all the detected issues
+func ValidateInventory(inventory *Inventory) error {
+	errContext := "(inventory::ValidateInventory)"
+
+	if inventory == nil {
+		return errors.New(errContext, "Inventory is nil")
+	}
+
+	errs := []error{}
+
+	for _, host := range inventory.hosts {
+		if host.Name == "" || strings.ContainsAny(host.Name, invalidHostChars) {
+			errs = append(errs, fmt.Errorf("invalid host name '%s'", host.Name))
+		}
+		errs = append(errs, validateVars(fmt.Sprintf("host '%s'", host.Name), host.Vars)...)
+	}
+
+	for _, group := range inventory.groups {
+		if group.Name == "" || strings.ContainsAny(group.Name, invalidGroupChars) {
+			errs = append(errs, fmt.Errorf("invalid group name '%s'", group.Name))
+		}
+		errs = append(errs, validateVars(fmt.Sprintf("group '%s'", group.Name), group.Vars)...)
+
+		for _, host := range group.Hosts {
+			if inventory.Host(host) == nil {
+				errs = append(errs, fmt.Errorf("host '%s' of the group '%s' is not defined", host, group.Name))
+			}
+		}
+
+		for _, child := range group.Children {
+			if child == AllGroup {
+				errs = append(errs, fmt.Errorf("group '%s' can not be a child of the group '%s'", AllGroup, group.Name))
+			} else if inventory.Group(child) == nil {
+				errs = append(errs, fmt.Errorf("child group '%s' of the group '%s' is not defined", child, group.Name))
+			}
+		}
+	}
+
+	cycle := findCycle(inventory)
+	if len(cycle) > 0 {
+		errs = append(errs, fmt.Errorf("cyclic children groups '%s'", strings.Join(cycle, " -> ")))
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid inventory", errs...)
+	}
+
+	return nil
+}
+
+// validateVars checks the variables names
+func validateVars(owner string, vars map[string]interface{}) []error {
+	errs := []error{}
+	for _, name := range sortedKeys(vars) {
+		if !varNameRegexp.MatchString(name) {
+			errs = append(errs, fmt.Errorf("invalid variable name '%s' on the %s", name, owner))
+		}
+	}
+
+	return errs
+}
+
+// findCycle returns the groups of the first cycle found on the children groups, or nil when there are no cycles
+func findCycle(inventory *Inventory) []string {
+	const (
+		visiting = iota + 1
+		visited
+	)
+
+	state := map[string]int{}
+	path := []string{}
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		switch state[name] {
+		case visiting:
+			for i, group := range path {
+				if group == name {
+					return append(append([]string{}, path[i:]...), name)
+				}
+			}
+		case visited:
+			return nil
+		}
+
+		group := inventory.Group(name)
+		if group == nil {
+			return nil
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, child := range group.Children {
+			cycle := visit(child)
+			if cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+
+		return nil
+	}
+
+	for _, group := range inventory.groups {
+		cycle := visit(group.Name)
+		if cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
+}
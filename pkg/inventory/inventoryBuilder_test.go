@@ -0,0 +1,112 @@
+package inventory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInventoryBuilder(t *testing.T) {
+	t.Log("Testing building an inventory with hosts, groups, children and variables")
+
+	inv, err := NewInventoryBuilder().
+		WithHost("web1", "web", "eu").
+		WithHostConnection("web1", ConnectionVars{Host: "10.0.0.1", User: "deploy"}).
+		WithGroup("db", "db1").
+		WithHostVars("db1", map[string]interface{}{"pg_version": 16}).
+		WithGroupChildren("prod", "web", "db").
+		WithGroupVars("web", map[string]interface{}{"http_port": 80}).
+		WithGroupConnection("all", ConnectionVars{Connection: "ssh"}).
+		Build()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*Host{
+		{Name: "web1", Vars: map[string]interface{}{"ansible_host": "10.0.0.1", "ansible_user": "deploy"}},
+		{Name: "db1", Vars: map[string]interface{}{"pg_version": 16}},
+	}, inv.Hosts())
+	assert.Equal(t, []*Group{
+		{Name: "web", Hosts: []string{"web1"}, Children: []string{}, Vars: map[string]interface{}{"http_port": 80}},
+		{Name: "eu", Hosts: []string{"web1"}, Children: []string{}, Vars: map[string]interface{}{}},
+		{Name: "db", Hosts: []string{"db1"}, Children: []string{}, Vars: map[string]interface{}{}},
+		{Name: "prod", Hosts: []string{}, Children: []string{"web", "db"}, Vars: map[string]interface{}{}},
+		{Name: "all", Hosts: []string{}, Children: []string{}, Vars: map[string]interface{}{"ansible_connection": "ssh"}},
+	}, inv.Groups())
+}
+
+func TestValidateInventory(t *testing.T) {
+	tests := []struct {
+		desc      string
+		inventory func() *Inventory
+		err       error
+	}{
+		{
+			desc: "Testing validate a valid inventory",
+			inventory: func() *Inventory {
+				inv, _ := NewInventoryBuilder().WithHost("web-1.example.com", "web_servers").Build()
+				return inv
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing error validating a nil inventory",
+			inventory: func() *Inventory {
+				return nil
+			},
+			err: errors.New("Inventory is nil"),
+		},
+		{
+			desc: "Testing error validating an inventory with invalid names",
+			inventory: func() *Inventory {
+				inv := NewInventory()
+				inv.AddHost("web 1").Vars["http-port"] = 80
+				inv.AddGroup("web:servers").AddHost("web 1")
+				return inv
+			},
+			err: errors.New("Invalid inventory\n invalid host name 'web 1'\n invalid variable name 'http-port' on the host 'web 1'\n invalid group name 'web:servers'"),
+		},
+		{
+			desc: "Testing error validating an inventory with undefined hosts and groups",
+			inventory: func() *Inventory {
+				inv := NewInventory()
+				inv.AddGroup("web").AddHost("web1")
+				inv.AddGroup("prod").AddChild("web", "db", "all")
+				return inv
+			},
+			err: errors.New("Invalid inventory\n host 'web1' of the group 'web' is not defined\n child group 'db' of the group 'prod' is not defined\n group 'all' can not be a child of the group 'prod'"),
+		},
+		{
+			desc: "Testing error validating an inventory with cyclic children groups",
+			inventory: func() *Inventory {
+				inv, _ := NewInventoryBuilder().
+					WithGroupChildren("prod", "web").
+					WithGroupChildren("web", "frontend").
+					WithGroupChildren("frontend", "web").
+					Build()
+				if inv != nil {
+					t.Fatal("inventory with cyclic children groups must not be built")
+				}
+
+				inv = NewInventory()
+				inv.AddGroup("prod").AddChild("web")
+				inv.AddGroup("web").AddChild("frontend")
+				inv.AddGroup("frontend").AddChild("web")
+				return inv
+			},
+			err: errors.New("Invalid inventory\n cyclic children groups 'web -> frontend -> web'"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := ValidateInventory(test.inventory())
+			if test.err != nil {
+				assert.EqualError(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package inventory
+
+import (
+	"os"
+	"path/filepath"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// InventoryFileName is the name of the ephemeral inventory file, without its extension
+	InventoryFileName = "inventory"
+)
+
+// InventoryFile is an ephemeral file where an inventory is rendered to be loaded by Ansible
+type InventoryFile struct {
+	// Path is the path of the inventory file
+	Path string
+	dir  string
+}
+
+// WriteInventoryFile renders the inventory in the given format and writes it to an ephemeral file, only readable by its owner, on a private directory created on dir. The default temporary directory is used when dir is empty. The returned InventoryFile must be closed to remove the file
+func WriteInventoryFile(inventory *Inventory, dir string, format Format) (*InventoryFile, error) {
+	errContext := "(inventory::WriteInventoryFile)"
+
+	if inventory == nil {
+		return nil, errors.New(errContext, "Inventory is nil")
+	}
+
+	content, err := inventory.Render(format)
+	if err != nil {
+		return nil, errors.New(errContext, "Error writing the inventory file", err)
+	}
+
+	privateDir, err := os.MkdirTemp(dir, "go-ansible-inventory-")
+	if err != nil {
+		return nil, errors.New(errContext, "Error creating the inventory file directory", err)
+	}
+
+	file := &InventoryFile{
+		Path: filepath.Join(privateDir, InventoryFileName+format.Extension()),
+		dir:  privateDir,
+	}
+
+	err = os.WriteFile(file.Path, content, 0600)
+	if err != nil {
+		_ = file.Close()
+		return nil, errors.New(errContext, "Error writing the inventory file", err)
+	}
+
+	return file, nil
+}
+
+// Close removes the ephemeral inventory file. It is safe to call it more than once
+func (f *InventoryFile) Close() error {
+	if f == nil || f.dir == "" {
+		return nil
+	}
+
+	err := os.RemoveAll(f.dir)
+	if err != nil {
+		return errors.New("(inventory::InventoryFile::Close)", "Error removing the inventory file", err)
+	}
+	f.dir = ""
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package inventory
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteInventoryFile(t *testing.T) {
+	tests := []struct {
+		desc      string
+		inventory *Inventory
+		format    Format
+		name      string
+		err       error
+	}{
+		{
+			desc:      "Testing write the inventory to an ephemeral YAML file",
+			inventory: renderTestInventory(t),
+			format:    YAMLFormat,
+			name:      "inventory.yml",
+		},
+		{
+			desc:      "Testing write the inventory to an ephemeral INI file",
+			inventory: renderTestInventory(t),
+			format:    INIFormat,
+			name:      "inventory.ini",
+		},
+		{
+			desc:      "Testing error writing a nil inventory",
+			inventory: nil,
+			format:    YAMLFormat,
+			err:       errors.New("Inventory is nil"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			dir := t.TempDir()
+
+			file, err := WriteInventoryFile(test.inventory, dir, test.format)
+			if test.err != nil {
+				assert.EqualError(t, err, test.err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.name, filepath.Base(file.Path))
+
+			info, err := os.Stat(file.Path)
+			assert.NoError(t, err)
+			assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+			content, err := os.ReadFile(file.Path)
+			assert.NoError(t, err)
+			expected, _ := test.inventory.Render(test.format)
+			assert.Equal(t, expected, content)
+
+			assert.NoError(t, file.Close())
+			assert.NoError(t, file.Close())
+
+			_, err = os.Stat(filepath.Dir(file.Path))
+			assert.True(t, os.IsNotExist(err))
+		})
+	}
+}
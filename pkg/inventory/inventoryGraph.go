@@ -0,0 +1,273 @@
+package inventory
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// graphIndent is the indentation of every depth level of the ansible-inventory --graph output
+	graphIndent = "  |"
+	// graphBranch is the mark that precedes the items of the ansible-inventory --graph output
+	graphBranch = "--"
+)
+
+// GraphGroup is a group of the inventory graph, with its children groups, its hosts and, when the graph shows them, its variables
+type GraphGroup struct {
+	// Name is the name of the group
+	Name string
+	// Groups are the children groups
+	Groups []*GraphGroup
+	// Hosts are the hosts that directly belong to the group
+	Hosts []*GraphHost
+	// Vars are the group variables, as they are printed by ansible-inventory
+	Vars map[string]string
+}
+
+// GraphHost is a host of the inventory graph and, when the graph shows them, its variables
+type GraphHost struct {
+	// Name is the name of the host
+	Name string
+	// Vars are the host variables, as they are printed by ansible-inventory
+	Vars map[string]string
+}
+
+// graphItem is an item of the graph being parsed, which is either a group or a host
+type graphItem struct {
+	group *GraphGroup
+	host  *GraphHost
+}
+
+// vars returns the variables of the item
+func (i graphItem) vars() map[string]string {
+	if i.group != nil {
+		return i.group.Vars
+	}
+
+	return i.host.Vars
+}
+
+// ParseGraph parses the output of ansible-inventory --graph, with or without the --vars flag, into the tree of its root group
+func ParseGraph(content []byte) (*GraphGroup, error) {
+	errContext := "(inventory::ParseGraph)"
+
+	var root *GraphGroup
+	stack := []graphItem{}
+	lastVar := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	number := 0
+	for scanner.Scan() {
+		number++
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		depth, item, ok := parseGraphLine(line)
+		if !ok {
+			// a multiline variable value continues on the lines that are not graph items
+			if lastVar != "" {
+				vars := stack[len(stack)-1].vars()
+				vars[lastVar] = vars[lastVar] + "\n" + strings.TrimSuffix(line, "}")
+				continue
+			}
+			return nil, errors.New(errContext, fmt.Sprintf("Invalid graph line %d: '%s'", number, line))
+		}
+
+		if depth == 0 {
+			if root != nil || !isGraphGroup(item) {
+				return nil, errors.New(errContext, fmt.Sprintf("Invalid graph root on line %d: '%s'", number, line))
+			}
+			root = newGraphGroup(item)
+			stack = []graphItem{{group: root}}
+			lastVar = ""
+			continue
+		}
+
+		if root == nil || depth > len(stack) {
+			return nil, errors.New(errContext, fmt.Sprintf("Invalid graph depth on line %d: '%s'", number, line))
+		}
+		stack = stack[:depth]
+		parent := stack[depth-1]
+		lastVar = ""
+
+		switch {
+		case isGraphVar(item):
+			name, value := parseGraphVar(item)
+			parent.vars()[name] = value
+			lastVar = name
+		case parent.group == nil:
+			return nil, errors.New(errContext, fmt.Sprintf("Host '%s' can not contain items, found on line %d: '%s'", parent.host.Name, number, line))
+		case isGraphGroup(item):
+			group := newGraphGroup(item)
+			parent.group.Groups = append(parent.group.Groups, group)
+			stack = append(stack, graphItem{group: group})
+		default:
+			host := &GraphHost{Name: item, Vars: map[string]string{}}
+			parent.group.Hosts = append(parent.group.Hosts, host)
+			stack = append(stack, graphItem{host: host})
+		}
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, errors.New(errContext, "Error reading the graph", err)
+	}
+
+	if root == nil {
+		return nil, errors.New(errContext, "Graph has no root group")
+	}
+
+	return root, nil
+}
+
+// parseGraphLine returns the depth and the item of a graph line
+func parseGraphLine(line string) (int, string, bool) {
+	if !strings.HasPrefix(line, graphIndent) {
+		if strings.HasPrefix(line, "@") {
+			return 0, line, true
+		}
+		return 0, "", false
+	}
+
+	depth := 0
+	for strings.HasPrefix(line, graphIndent) {
+		line = line[len(graphIndent):]
+		depth++
+	}
+
+	if !strings.HasPrefix(line, graphBranch) {
+		return 0, "", false
+	}
+
+	return depth, line[len(graphBranch):], true
+}
+
+// isGraphGroup returns whether the item is a group, such as @all:
+func isGraphGroup(item string) bool {
+	return strings.HasPrefix(item, "@") && strings.HasSuffix(item, ":")
+}
+
+// isGraphVar returns whether the item is a variable, such as {name = value}
+func isGraphVar(item string) bool {
+	return strings.HasPrefix(item, "{") && strings.Contains(item, " = ")
+}
+
+// newGraphGroup returns the group of the item
+func newGraphGroup(item string) *GraphGroup {
+	return &GraphGroup{
+		Name:   strings.TrimSuffix(strings.TrimPrefix(item, "@"), ":"),
+		Groups: []*GraphGroup{},
+		Hosts:  []*GraphHost{},
+		Vars:   map[string]string{},
+	}
+}
+
+// parseGraphVar returns the name and the value of the variable item
+func parseGraphVar(item string) (string, string) {
+	name, value, _ := strings.Cut(strings.TrimPrefix(item, "{"), " = ")
+	return name, strings.TrimSuffix(value, "}")
+}
+
+// DOT returns the graph in the Graphviz DOT language. The groups are boxes, whose identifiers are prefixed by @ as ansible-inventory does, and the hosts are ellipses
+func (g *GraphGroup) DOT() string {
+	buff := &bytes.Buffer{}
+	nodes := []string{}
+	edges := []string{}
+	seen := map[string]struct{}{}
+
+	add := func(list *[]string, line string) {
+		if _, exists := seen[line]; !exists {
+			seen[line] = struct{}{}
+			*list = append(*list, line)
+		}
+	}
+
+	var visit func(group *GraphGroup)
+	visit = func(group *GraphGroup) {
+		id := dotQuote("@" + group.Name)
+		add(&nodes, fmt.Sprintf("  %s [label=%s, shape=box];", id, dotQuote(group.Name)))
+
+		for _, child := range group.Groups {
+			add(&edges, fmt.Sprintf("  %s -> %s;", id, dotQuote("@"+child.Name)))
+			visit(child)
+		}
+
+		for _, host := range group.Hosts {
+			add(&nodes, fmt.Sprintf("  %s [label=%s, shape=ellipse];", dotQuote(host.Name), dotQuote(host.Name)))
+			add(&edges, fmt.Sprintf("  %s -> %s;", id, dotQuote(host.Name)))
+		}
+	}
+	visit(g)
+
+	buff.WriteString("digraph inventory {\n")
+	for _, line := range append(nodes, edges...) {
+		buff.WriteString(line + "\n")
+	}
+	buff.WriteString("}\n")
+
+	return buff.String()
+}
+
+// Mermaid returns the graph as a Mermaid flowchart. The groups are rectangles and the hosts are rounded rectangles
+func (g *GraphGroup) Mermaid() string {
+	buff := &bytes.Buffer{}
+	nodes := []string{}
+	edges := []string{}
+	ids := map[string]string{}
+	seenEdges := map[string]struct{}{}
+
+	nodeID := func(key, label, shape string) string {
+		id, exists := ids[key]
+		if !exists {
+			id = fmt.Sprintf("n%d", len(ids))
+			ids[key] = id
+			nodes = append(nodes, fmt.Sprintf("  %s%s", id, fmt.Sprintf(shape, mermaidQuote(label))))
+		}
+		return id
+	}
+
+	addEdge := func(from, to string) {
+		edge := fmt.Sprintf("  %s --> %s", from, to)
+		if _, exists := seenEdges[edge]; !exists {
+			seenEdges[edge] = struct{}{}
+			edges = append(edges, edge)
+		}
+	}
+
+	var visit func(group *GraphGroup, id string)
+	visit = func(group *GraphGroup, id string) {
+		for _, child := range group.Groups {
+			childID := nodeID("@"+child.Name, child.Name, "[%s]")
+			addEdge(id, childID)
+			visit(child, childID)
+		}
+
+		for _, host := range group.Hosts {
+			addEdge(id, nodeID(host.Name, host.Name, "(%s)"))
+		}
+	}
+	visit(g, nodeID("@"+g.Name, g.Name, "[%s]"))
+
+	buff.WriteString("flowchart LR\n")
+	for _, line := range append(nodes, edges...) {
+		buff.WriteString(line + "\n")
+	}
+
+	return buff.String()
+}
+
+// dotQuote returns the string as a DOT quoted identifier
+func dotQuote(str string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(str) + `"`
+}
+
+// mermaidQuote returns the string as a Mermaid quoted label
+func mermaidQuote(str string) string {
+	return `"` + strings.ReplaceAll(str, `"`, "#quot;") + `"`
+}
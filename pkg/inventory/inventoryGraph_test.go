@@ -0,0 +1,173 @@
+package inventory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const graphWithVars = `@all:
+  |--@ungrouped:
+  |  |--bastion
+  |--@prod:
+  |  |--@web:
+  |  |  |--web1
+  |  |  |  |--{ansible_host = 10.0.0.1}
+  |  |  |  |--{motd = first line
+second line}
+  |  |  |--web2
+  |  |  |--{http_port = 80}
+  |  |--@db:
+  |  |  |--db1
+`
+
+func graphTestTree() *GraphGroup {
+	return &GraphGroup{
+		Name: "all",
+		Groups: []*GraphGroup{
+			{
+				Name:   "ungrouped",
+				Groups: []*GraphGroup{},
+				Hosts:  []*GraphHost{{Name: "bastion", Vars: map[string]string{}}},
+				Vars:   map[string]string{},
+			},
+			{
+				Name: "prod",
+				Groups: []*GraphGroup{
+					{
+						Name:   "web",
+						Groups: []*GraphGroup{},
+						Hosts: []*GraphHost{
+							{Name: "web1", Vars: map[string]string{"ansible_host": "10.0.0.1", "motd": "first line\nsecond line"}},
+							{Name: "web2", Vars: map[string]string{}},
+						},
+						Vars: map[string]string{"http_port": "80"},
+					},
+					{
+						Name:   "db",
+						Groups: []*GraphGroup{},
+						Hosts:  []*GraphHost{{Name: "db1", Vars: map[string]string{}}},
+						Vars:   map[string]string{},
+					},
+				},
+				Hosts: []*GraphHost{},
+				Vars:  map[string]string{},
+			},
+		},
+		Hosts: []*GraphHost{},
+		Vars:  map[string]string{},
+	}
+}
+
+func TestParseGraph(t *testing.T) {
+	tests := []struct {
+		desc     string
+		content  string
+		expected *GraphGroup
+		err      error
+	}{
+		{
+			desc:     "Testing parse an inventory graph with variables",
+			content:  graphWithVars,
+			expected: graphTestTree(),
+		},
+		{
+			desc:    "Testing parse an inventory graph of a group",
+			content: "@web:\n  |--web1\n",
+			expected: &GraphGroup{
+				Name:   "web",
+				Groups: []*GraphGroup{},
+				Hosts:  []*GraphHost{{Name: "web1", Vars: map[string]string{}}},
+				Vars:   map[string]string{},
+			},
+		},
+		{
+			desc:    "Testing error parsing an empty inventory graph",
+			content: "\n",
+			err:     errors.New("Graph has no root group"),
+		},
+		{
+			desc:    "Testing error parsing an inventory graph with an invalid line",
+			content: "@all:\nweb1\n",
+			err:     errors.New("Invalid graph line 2: 'web1'"),
+		},
+		{
+			desc:    "Testing error parsing an inventory graph with an invalid depth",
+			content: "@all:\n  |  |--web1\n",
+			err:     errors.New("Invalid graph depth on line 2: '  |  |--web1'"),
+		},
+		{
+			desc:    "Testing error parsing an inventory graph whose host contains items",
+			content: "@all:\n  |--web1\n  |  |--db1\n",
+			err:     errors.New("Host 'web1' can not contain items, found on line 3: '  |  |--db1'"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			graph, err := ParseGraph([]byte(test.content))
+			if test.err != nil {
+				assert.EqualError(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, graph)
+			}
+		})
+	}
+}
+
+func TestGraphDOT(t *testing.T) {
+	t.Log("Testing export the inventory graph to the DOT language")
+
+	expected := `digraph inventory {
+  "@all" [label="all", shape=box];
+  "@ungrouped" [label="ungrouped", shape=box];
+  "bastion" [label="bastion", shape=ellipse];
+  "@prod" [label="prod", shape=box];
+  "@web" [label="web", shape=box];
+  "web1" [label="web1", shape=ellipse];
+  "web2" [label="web2", shape=ellipse];
+  "@db" [label="db", shape=box];
+  "db1" [label="db1", shape=ellipse];
+  "@all" -> "@ungrouped";
+  "@ungrouped" -> "bastion";
+  "@all" -> "@prod";
+  "@prod" -> "@web";
+  "@web" -> "web1";
+  "@web" -> "web2";
+  "@prod" -> "@db";
+  "@db" -> "db1";
+}
+`
+
+	assert.Equal(t, expected, graphTestTree().DOT())
+}
+
+func TestGraphMermaid(t *testing.T) {
+	t.Log("Testing export the inventory graph to a Mermaid flowchart")
+
+	expected := `flowchart LR
+  n0["all"]
+  n1["ungrouped"]
+  n2("bastion")
+  n3["prod"]
+  n4["web"]
+  n5("web1")
+  n6("web2")
+  n7["db"]
+  n8("db1")
+  n0 --> n1
+  n1 --> n2
+  n0 --> n3
+  n3 --> n4
+  n4 --> n5
+  n4 --> n6
+  n3 --> n7
+  n7 --> n8
+`
+
+	assert.Equal(t, expected, graphTestTree().Mermaid())
+}
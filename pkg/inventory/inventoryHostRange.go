@@ -0,0 +1,150 @@
+package inventory
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// asciiLetters are the characters of the alphabetic host ranges, in the python string.ascii_letters order
+	asciiLetters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	// portVar is the variable set to the port of a host defined as host:port
+	portVar = "ansible_port"
+)
+
+var (
+	// bracketedAddressRegexp matches a bracketed address followed by a port, such as [::1]:22
+	bracketedAddressRegexp = regexp.MustCompile(`^\[(.+)\]:([0-9]+)$`)
+	// hostPortRegexp matches a host, which may contain ranges, followed by a port
+	hostPortRegexp = regexp.MustCompile(`^((?:[^:\[\]]|\[[^\]]*\])+):([0-9]+)$`)
+)
+
+// expandHostPattern returns the hosts defined by a host pattern of a static inventory, expanding its ranges, and the port it defines, which is 0 when it is not defined
+func expandHostPattern(pattern string) ([]string, int, error) {
+	if strings.HasSuffix(strings.TrimSpace(pattern), ":") {
+		return nil, 0, fmt.Errorf("invalid host pattern '%s' supplied, ending in ':' is not allowed, this character is reserved to provide a port", pattern)
+	}
+
+	host, port := splitHostPort(pattern)
+
+	if !strings.Contains(host, "[") {
+		return []string{host}, port, nil
+	}
+
+	hosts, err := expandHostRange(host)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return hosts, port, nil
+}
+
+// splitHostPort splits the port of a host pattern. IPv6 addresses only define a port when they are bracketed
+func splitHostPort(pattern string) (string, int) {
+	matches := bracketedAddressRegexp.FindStringSubmatch(pattern)
+	if matches != nil && net.ParseIP(matches[1]) != nil {
+		port, err := strconv.Atoi(matches[2])
+		if err == nil {
+			return matches[1], port
+		}
+	}
+
+	matches = hostPortRegexp.FindStringSubmatch(pattern)
+	if matches != nil {
+		port, err := strconv.Atoi(matches[2])
+		if err == nil {
+			return matches[1], port
+		}
+	}
+
+	return pattern, 0
+}
+
+// expandHostRange expands the [begin:end] and [begin:end:step] numeric or alphabetic ranges of a host. Numeric ranges whose begin has leading zeros keep its width
+func expandHostRange(host string) ([]string, error) {
+	start := strings.Index(host, "[")
+	end := strings.Index(host, "]")
+	if end < start {
+		return nil, fmt.Errorf("invalid host range on '%s'", host)
+	}
+
+	head := host[:start]
+	tail := host[end+1:]
+	bounds := strings.Split(host[start+1:end], ":")
+
+	if len(bounds) != 2 && len(bounds) != 3 {
+		return nil, fmt.Errorf("host range must be begin:end or begin:end:step on '%s'", host)
+	}
+
+	begin := bounds[0]
+	last := bounds[1]
+	step := 1
+	if len(bounds) == 3 {
+		var err error
+		step, err = strconv.Atoi(bounds[2])
+		if err != nil || step < 1 {
+			return nil, fmt.Errorf("host range step must be a positive number on '%s'", host)
+		}
+	}
+
+	if begin == "" {
+		begin = "0"
+	}
+
+	if last == "" {
+		return nil, fmt.Errorf("host range must specify end value on '%s'", host)
+	}
+
+	width := 0
+	if len(begin) > 1 && begin[0] == '0' {
+		if len(begin) != len(last) {
+			return nil, fmt.Errorf("host range must specify equal-length begin and end formats on '%s'", host)
+		}
+		width = len(begin)
+	}
+
+	sequence := []string{}
+
+	beginLetter := strings.Index(asciiLetters, begin)
+	lastLetter := strings.Index(asciiLetters, last)
+	if len(begin) == 1 && len(last) == 1 && beginLetter >= 0 && lastLetter >= 0 {
+		if beginLetter > lastLetter {
+			return nil, fmt.Errorf("host range must have begin <= end on '%s'", host)
+		}
+		for i := beginLetter; i <= lastLetter; i += step {
+			sequence = append(sequence, string(asciiLetters[i]))
+		}
+	} else {
+		beginNumber, err := strconv.Atoi(begin)
+		if err != nil {
+			return nil, fmt.Errorf("invalid host range begin '%s' on '%s'", begin, host)
+		}
+		lastNumber, err := strconv.Atoi(last)
+		if err != nil {
+			return nil, fmt.Errorf("invalid host range end '%s' on '%s'", last, host)
+		}
+		for i := beginNumber; i <= lastNumber; i += step {
+			sequence = append(sequence, fmt.Sprintf("%0*d", width, i))
+		}
+	}
+
+	hosts := []string{}
+	for _, item := range sequence {
+		name := head + item + tail
+		if !strings.Contains(name, "[") {
+			hosts = append(hosts, name)
+			continue
+		}
+
+		expanded, err := expandHostRange(name)
+		if err != nil {
+			return nil, err
+		}
+		hosts = append(hosts, expanded...)
+	}
+
+	return hosts, nil
+}
@@ -0,0 +1,92 @@
+package inventory
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandHostPattern(t *testing.T) {
+	tests := []struct {
+		desc          string
+		pattern       string
+		expectedHosts []string
+		expectedPort  int
+		err           error
+	}{
+		{
+			desc:          "Testing a host without range",
+			pattern:       "web1.example.com",
+			expectedHosts: []string{"web1.example.com"},
+		},
+		{
+			desc:          "Testing a host with a port",
+			pattern:       "web1.example.com:2222",
+			expectedHosts: []string{"web1.example.com"},
+			expectedPort:  2222,
+		},
+		{
+			desc:          "Testing an IPv6 address",
+			pattern:       "fe80::1",
+			expectedHosts: []string{"fe80::1"},
+		},
+		{
+			desc:          "Testing a bracketed IPv6 address with a port",
+			pattern:       "[fe80::1]:2222",
+			expectedHosts: []string{"fe80::1"},
+			expectedPort:  2222,
+		},
+		{
+			desc:          "Testing a numeric range with leading zeros and a port",
+			pattern:       "web[08:10].example.com:22",
+			expectedHosts: []string{"web08.example.com", "web09.example.com", "web10.example.com"},
+			expectedPort:  22,
+		},
+		{
+			desc:          "Testing an alphabetic range with step",
+			pattern:       "db-[a:e:2]",
+			expectedHosts: []string{"db-a", "db-c", "db-e"},
+		},
+		{
+			desc:          "Testing multiple ranges and an empty begin",
+			pattern:       "rack[:1]-node[1:2]",
+			expectedHosts: []string{"rack0-node1", "rack0-node2", "rack1-node1", "rack1-node2"},
+		},
+		{
+			desc:    "Testing a pattern ending in colon",
+			pattern: "web1:",
+			err:     errors.New("", "invalid host pattern 'web1:' supplied, ending in ':' is not allowed, this character is reserved to provide a port"),
+		},
+		{
+			desc:    "Testing a range without end",
+			pattern: "web[1:]",
+			err:     errors.New("", "host range must specify end value on 'web[1:]'"),
+		},
+		{
+			desc:    "Testing a range with different widths",
+			pattern: "web[01:100]",
+			err:     errors.New("", "host range must specify equal-length begin and end formats on 'web[01:100]'"),
+		},
+		{
+			desc:    "Testing a range with invalid bounds",
+			pattern: "web[1]",
+			err:     errors.New("", "host range must be begin:end or begin:end:step on 'web[1]'"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			hosts, port, err := expandHostPattern(test.pattern)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				assert.Equal(t, test.expectedHosts, hosts)
+				assert.Equal(t, test.expectedPort, port)
+			}
+		})
+	}
+}
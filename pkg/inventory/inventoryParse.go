@@ -0,0 +1,294 @@
+package inventory
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// metaKey is the key of the ansible-inventory --list JSON output that contains the hosts variables
+	metaKey = "_meta"
+	// hostvarsKey is the key of the meta section that contains the hosts variables
+	hostvarsKey = "hostvars"
+	// hostsKey is the key of a group that contains its hosts
+	hostsKey = "hosts"
+	// childrenKey is the key of a group that contains its children groups
+	childrenKey = "children"
+	// varsKey is the key of a group that contains its variables
+	varsKey = "vars"
+)
+
+// ParseList parses the output of ansible-inventory --list, in the JSONFormat, YAMLFormat or TOMLFormat formats, into an Inventory. The hosts variables of the JSON _meta.hostvars section, or the ones defined on the hosts of the YAML and TOML formats, become the hosts variables. The groups variables are only present when the output was exported with the --export flag
+func ParseList(content []byte, format Format) (*Inventory, error) {
+	errContext := "(inventory::ParseList)"
+
+	data, err := decode(content, format)
+	if err != nil {
+		return nil, errors.New(errContext, "Error decoding the inventory list", err)
+	}
+
+	inventory := NewInventory()
+
+	if format == JSONFormat {
+		err = parseScriptList(inventory, data)
+	} else {
+		err = parseTreeList(inventory, data)
+	}
+	if err != nil {
+		return nil, errors.New(errContext, "Error parsing the inventory list", err)
+	}
+
+	return inventory, nil
+}
+
+// ParseHostVars parses the output of ansible-inventory --host, in the JSONFormat, YAMLFormat or TOMLFormat formats, into the host variables
+func ParseHostVars(content []byte, format Format) (map[string]interface{}, error) {
+	errContext := "(inventory::ParseHostVars)"
+
+	data, err := decode(content, format)
+	if err != nil {
+		return nil, errors.New(errContext, "Error decoding the host variables", err)
+	}
+
+	return data, nil
+}
+
+// decode decodes the content into a map whose numbers are normalized to int or float64
+func decode(content []byte, format Format) (map[string]interface{}, error) {
+	var data map[string]interface{}
+	var err error
+
+	switch format {
+	case JSONFormat:
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.UseNumber()
+		err = decoder.Decode(&data)
+	case YAMLFormat:
+		err = yaml.Unmarshal(content, &data)
+	case TOMLFormat:
+		err = toml.Unmarshal(content, &data)
+	default:
+		return nil, fmt.Errorf("unsupported format '%s'", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if data == nil {
+		return map[string]interface{}{}, nil
+	}
+
+	return normalize(data).(map[string]interface{}), nil
+}
+
+// normalize converts the numbers of the decoded value to int, when they are integers, or to float64
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalize(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalize(item)
+		}
+		return v
+	case json.Number:
+		integer, err := v.Int64()
+		if err == nil && integer >= math.MinInt && integer <= math.MaxInt {
+			return int(integer)
+		}
+		float, err := v.Float64()
+		if err == nil {
+			return float
+		}
+		return v.String()
+	case int64:
+		if v >= math.MinInt && v <= math.MaxInt {
+			return int(v)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// parseScriptList parses the JSON inventory script format, where every group is a top level key and the hosts variables are defined on the _meta section
+func parseScriptList(inventory *Inventory, data map[string]interface{}) error {
+	for _, name := range sortedKeys(data) {
+		if name == metaKey {
+			continue
+		}
+
+		group := inventory.AddGroup(name)
+
+		groupData, err := asMap(data[name], fmt.Sprintf("group '%s'", name))
+		if err != nil {
+			return err
+		}
+
+		hosts, err := asStringList(groupData[hostsKey], fmt.Sprintf("hosts of the group '%s'", name))
+		if err != nil {
+			return err
+		}
+		for _, host := range hosts {
+			inventory.AddHost(host)
+			group.AddHost(host)
+		}
+
+		children, err := asStringList(groupData[childrenKey], fmt.Sprintf("children of the group '%s'", name))
+		if err != nil {
+			return err
+		}
+		for _, child := range children {
+			inventory.AddGroup(child)
+			group.AddChild(child)
+		}
+
+		err = setVars(group.Vars, groupData[varsKey], fmt.Sprintf("variables of the group '%s'", name))
+		if err != nil {
+			return err
+		}
+	}
+
+	meta, err := asMap(data[metaKey], "meta section")
+	if err != nil {
+		return err
+	}
+
+	hostvars, err := asMap(meta[hostvarsKey], "hosts variables")
+	if err != nil {
+		return err
+	}
+
+	for _, name := range sortedKeys(hostvars) {
+		err = setVars(inventory.AddHost(name).Vars, hostvars[name], fmt.Sprintf("variables of the host '%s'", name))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseTreeList parses the YAML and TOML formats, where the groups define their hosts with their variables, and their children groups either nested or by name
+func parseTreeList(inventory *Inventory, data map[string]interface{}) error {
+	for _, name := range sortedKeys(data) {
+		err := parseTreeGroup(inventory, name, data[name])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseTreeGroup parses a group of the YAML and TOML formats
+func parseTreeGroup(inventory *Inventory, name string, value interface{}) error {
+	group := inventory.AddGroup(name)
+
+	groupData, err := asMap(value, fmt.Sprintf("group '%s'", name))
+	if err != nil {
+		return err
+	}
+
+	hosts, err := asMap(groupData[hostsKey], fmt.Sprintf("hosts of the group '%s'", name))
+	if err != nil {
+		return err
+	}
+	for _, host := range sortedKeys(hosts) {
+		group.AddHost(host)
+		err = setVars(inventory.AddHost(host).Vars, hosts[host], fmt.Sprintf("variables of the host '%s'", host))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = setVars(group.Vars, groupData[varsKey], fmt.Sprintf("variables of the group '%s'", name))
+	if err != nil {
+		return err
+	}
+
+	switch children := groupData[childrenKey].(type) {
+	case nil:
+	case []interface{}:
+		names, err := asStringList(children, fmt.Sprintf("children of the group '%s'", name))
+		if err != nil {
+			return err
+		}
+		for _, child := range names {
+			inventory.AddGroup(child)
+			group.AddChild(child)
+		}
+	case map[string]interface{}:
+		for _, child := range sortedKeys(children) {
+			group.AddChild(child)
+			err = parseTreeGroup(inventory, child, children[child])
+			if err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("children of the group '%s' must be a list or a map", name)
+	}
+
+	return nil
+}
+
+// asMap returns the value as a map. A nil value is an empty map
+func asMap(value interface{}, description string) (map[string]interface{}, error) {
+	if value == nil {
+		return map[string]interface{}{}, nil
+	}
+
+	data, isMap := value.(map[string]interface{})
+	if !isMap {
+		return nil, fmt.Errorf("%s must be a map", description)
+	}
+
+	return data, nil
+}
+
+// asStringList returns the value as a list of strings. A nil value is an empty list
+func asStringList(value interface{}, description string) ([]string, error) {
+	if value == nil {
+		return []string{}, nil
+	}
+
+	items, isList := value.([]interface{})
+	if !isList {
+		return nil, fmt.Errorf("%s must be a list", description)
+	}
+
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		str, isString := item.(string)
+		if !isString {
+			return nil, fmt.Errorf("%s must be a list of strings", description)
+		}
+		list = append(list, str)
+	}
+
+	return list, nil
+}
+
+// setVars sets the variables of the value into vars
+func setVars(vars map[string]interface{}, value interface{}, description string) error {
+	data, err := asMap(value, description)
+	if err != nil {
+		return err
+	}
+
+	for key, item := range data {
+		vars[key] = item
+	}
+
+	return nil
+}
@@ -0,0 +1,283 @@
+package inventory
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// iniHostsSection is the type of the INI sections that define the hosts of a group
+	iniHostsSection = "hosts"
+	// iniVarsSection is the type of the INI sections that define the variables of a group
+	iniVarsSection = "vars"
+	// iniChildrenSection is the type of the INI sections that define the children groups of a group
+	iniChildrenSection = "children"
+)
+
+var (
+	// iniSectionRegexp matches an INI section header, such as [group], [group:vars] or [group:children]
+	iniSectionRegexp = regexp.MustCompile(`^\[([^:\]\s]+)(?::(\w+))?\]\s*(?:#.*)?$`)
+	// iniGroupNameRegexp matches a group name on a children section
+	iniGroupNameRegexp = regexp.MustCompile(`^([^:\]\s]+)\s*(?:#.*)?$`)
+)
+
+// iniPendingDeclaration is a group referenced by a section before the group is defined
+type iniPendingDeclaration struct {
+	line    int
+	state   string
+	parents []string
+}
+
+// ParseINI parses a static inventory in the Ansible INI format into an Inventory, following the Ansible ini inventory plugin rules. The hosts can define ranges, such as web[01:20], and a port, and the values of the variables are evaluated as python literals. The returned inventory follows the ansible-inventory --list --export structure
+func ParseINI(content []byte) (*Inventory, error) {
+	errContext := "(inventory::ParseINI)"
+
+	inventory := newStaticInventory()
+
+	err := parseINI(inventory, content)
+	if err != nil {
+		return nil, errors.New(errContext, "Error parsing the INI inventory", err)
+	}
+
+	err = reconcileStaticInventory(inventory)
+	if err != nil {
+		return nil, errors.New(errContext, "Error parsing the INI inventory", err)
+	}
+
+	return inventory, nil
+}
+
+// parseINI parses an INI inventory into the inventory
+func parseINI(inventory *Inventory, content []byte) error {
+	groupname := UngroupedGroup
+	state := iniHostsSection
+	pending := map[string]*iniPendingDeclaration{}
+	pendingOrder := []string{}
+	lineno := 0
+
+	addPending := func(name string, declaration *iniPendingDeclaration) {
+		pending[name] = declaration
+		pendingOrder = append(pendingOrder, name)
+	}
+
+	var addPendingChildren func(name string)
+	addPendingChildren = func(name string) {
+		declaration := pending[name]
+		delete(pending, name)
+		for _, parent := range declaration.parents {
+			inventory.Group(parent).AddChild(name)
+			if parentDeclaration, exists := pending[parent]; exists && parentDeclaration.state == iniChildrenSection {
+				addPendingChildren(parent)
+			}
+		}
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		lineno++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+
+		matches := iniSectionRegexp.FindStringSubmatch(line)
+		if matches != nil {
+			groupname = matches[1]
+			state = matches[2]
+			if state == "" {
+				state = iniHostsSection
+			}
+
+			if state != iniHostsSection && state != iniVarsSection && state != iniChildrenSection {
+				return fmt.Errorf("line %d: section [%s:%s] has unknown type: %s", lineno, groupname, state, state)
+			}
+
+			if inventory.Group(groupname) == nil {
+				if _, exists := pending[groupname]; state == iniVarsSection && !exists {
+					addPending(groupname, &iniPendingDeclaration{line: lineno, state: state})
+				}
+				inventory.AddGroup(groupname)
+			}
+
+			if declaration, exists := pending[groupname]; exists && state != iniVarsSection {
+				if declaration.state == iniChildrenSection {
+					addPendingChildren(groupname)
+				} else {
+					delete(pending, groupname)
+				}
+			}
+
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			return fmt.Errorf("line %d: invalid section entry: '%s'. Please make sure that there are no spaces in the section entry, and that there are no other invalid characters", lineno, line)
+		}
+
+		switch state {
+		case iniHostsSection:
+			err := parseINIHost(inventory, groupname, line)
+			if err != nil {
+				return fmt.Errorf("line %d: %w", lineno, err)
+			}
+		case iniVarsSection:
+			key, value, found := strings.Cut(line, "=")
+			if !found {
+				return fmt.Errorf("line %d: expected key=value, got: %s", lineno, line)
+			}
+			inventory.Group(groupname).Vars[strings.TrimSpace(key)] = parseINIValue(strings.TrimSpace(value))
+		case iniChildrenSection:
+			childMatches := iniGroupNameRegexp.FindStringSubmatch(line)
+			if childMatches == nil {
+				return fmt.Errorf("line %d: '%s' is not a valid group name", lineno, line)
+			}
+			child := childMatches[1]
+
+			if inventory.Group(child) != nil {
+				inventory.Group(groupname).AddChild(child)
+				continue
+			}
+
+			declaration, exists := pending[child]
+			if !exists {
+				addPending(child, &iniPendingDeclaration{line: lineno, state: state, parents: []string{groupname}})
+				continue
+			}
+			declaration.parents = append(declaration.parents, groupname)
+		}
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range pendingOrder {
+		declaration, exists := pending[name]
+		if !exists {
+			continue
+		}
+
+		if declaration.state == iniVarsSection {
+			return fmt.Errorf("line %d: section [%s:vars] not valid for undefined group: %s", declaration.line, name, name)
+		}
+
+		return fmt.Errorf("line %d: section [%s:children] includes undefined group: %s", declaration.line, declaration.parents[len(declaration.parents)-1], name)
+	}
+
+	return nil
+}
+
+// parseINIHost parses a host definition of an INI hosts section, which is a host pattern followed by key=value variables
+func parseINIHost(inventory *Inventory, groupname, line string) error {
+	tokens, err := shlexSplit(line)
+	if err != nil {
+		return fmt.Errorf("error parsing host definition '%s': %w", line, err)
+	}
+
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	hosts, port, err := expandHostPattern(tokens[0])
+	if err != nil {
+		return err
+	}
+
+	vars := map[string]interface{}{}
+	for _, token := range tokens[1:] {
+		key, value, found := strings.Cut(token, "=")
+		if !found {
+			return fmt.Errorf("expected key=value host variable assignment, got: %s", token)
+		}
+		vars[key] = parseINIValue(value)
+	}
+
+	addStaticHosts(inventory, groupname, hosts, port, vars)
+
+	return nil
+}
+
+// shlexSplit splits a line like the python shlex.split function does in POSIX mode with comments enabled
+func shlexSplit(line string) ([]string, error) {
+	const (
+		whitespaceState = ' '
+		wordState       = 'a'
+		escapeChar      = '\\'
+	)
+
+	tokens := []string{}
+	token := &strings.Builder{}
+	quoted := false
+	state := rune(whitespaceState)
+	escapedState := rune(wordState)
+
+	emit := func() {
+		if token.Len() > 0 || quoted {
+			tokens = append(tokens, token.String())
+		}
+		token.Reset()
+		quoted = false
+	}
+
+	for _, c := range line {
+		switch state {
+		case whitespaceState, wordState:
+			switch {
+			case unicode.IsSpace(c):
+				if state == wordState {
+					emit()
+				}
+				state = whitespaceState
+			case c == '#':
+				emit()
+				return tokens, nil
+			case c == '\'' || c == '"':
+				state = c
+			case c == escapeChar:
+				escapedState = wordState
+				state = escapeChar
+			default:
+				token.WriteRune(c)
+				state = wordState
+			}
+		case escapeChar:
+			// inside double quotes, the backslash only escapes the backslash and the double quote
+			if escapedState == '"' && c != escapeChar && c != escapedState {
+				token.WriteRune(escapeChar)
+			}
+			token.WriteRune(c)
+			state = escapedState
+		default:
+			switch {
+			case c == state:
+				quoted = true
+				state = wordState
+			case c == escapeChar && state == '"':
+				escapedState = state
+				state = escapeChar
+			default:
+				token.WriteRune(c)
+			}
+		}
+	}
+
+	switch state {
+	case whitespaceState, wordState:
+	case escapeChar:
+		return nil, fmt.Errorf("no escaped character")
+	default:
+		return nil, fmt.Errorf("no closing quotation")
+	}
+
+	emit()
+
+	return tokens, nil
+}
@@ -0,0 +1,548 @@
+package inventory
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// parseINIValue converts a value of an INI inventory to the type Ansible gives to it, which evaluates it as a python literal and keeps the raw string when it is not a valid literal
+func parseINIValue(raw string) interface{} {
+	value, err := evalPythonLiteral(raw)
+	if err != nil {
+		return raw
+	}
+
+	return value
+}
+
+// evalPythonLiteral evaluates a python literal, like the python ast.literal_eval function does. Strings, numbers, booleans, None, lists, tuples, sets and dicts are supported. Tuples and sets become lists, and the dict keys become strings, as they are rendered on JSON
+func evalPythonLiteral(raw string) (interface{}, error) {
+	p := &literalParser{
+		input: []rune(strings.TrimLeft(raw, " \t")),
+	}
+
+	value, err := p.parseTestList()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return nil, fmt.Errorf("unexpected character '%c' at position %d", p.input[p.pos], p.pos)
+	}
+
+	return value, nil
+}
+
+// literalParser is a recursive descent parser of python literals
+type literalParser struct {
+	input []rune
+	pos   int
+}
+
+// peek returns the current character, or 0 at the end of the input
+func (p *literalParser) peek() rune {
+	if p.pos >= len(p.input) {
+		return 0
+	}
+
+	return p.input[p.pos]
+}
+
+// skipSpaces skips the whitespaces and the comments
+func (p *literalParser) skipSpaces() {
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		switch {
+		case c == '#':
+			for p.pos < len(p.input) && p.input[p.pos] != '\n' {
+				p.pos++
+			}
+		case unicode.IsSpace(c):
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+// parseTestList parses a comma-separated list of values, which is a tuple when it contains a comma
+func (p *literalParser) parseTestList() (interface{}, error) {
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+	if p.peek() != ',' {
+		return value, nil
+	}
+
+	items := []interface{}{value}
+	for p.peek() == ',' {
+		p.pos++
+		p.skipSpaces()
+		if p.pos >= len(p.input) || strings.ContainsRune(")]}", p.peek()) {
+			break
+		}
+
+		value, err = p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, value)
+		p.skipSpaces()
+	}
+
+	return items, nil
+}
+
+// parseValue parses a single value
+func (p *literalParser) parseValue() (interface{}, error) {
+	p.skipSpaces()
+
+	c := p.peek()
+	switch {
+	case c == 0:
+		return nil, fmt.Errorf("unexpected end of input")
+	case c == '[':
+		p.pos++
+		return p.parseItems(']')
+	case c == '(':
+		p.pos++
+		p.skipSpaces()
+		if p.peek() == ')' {
+			p.pos++
+			return []interface{}{}, nil
+		}
+		value, err := p.parseTestList()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("expected ')' at position %d", p.pos)
+		}
+		p.pos++
+		return value, nil
+	case c == '{':
+		p.pos++
+		return p.parseBraces()
+	case c == '+' || c == '-':
+		p.pos++
+		p.skipSpaces()
+		if !p.startsNumber() {
+			return nil, fmt.Errorf("unary operators are only allowed on numbers")
+		}
+		value, err := p.parseNumber()
+		if err != nil {
+			return nil, err
+		}
+		if c == '+' {
+			return value, nil
+		}
+		switch v := value.(type) {
+		case int:
+			return -v, nil
+		case float64:
+			return -v, nil
+		}
+		return nil, fmt.Errorf("unary operators are only allowed on numbers")
+	case p.startsNumber():
+		return p.parseNumber()
+	case c == '\'' || c == '"':
+		return p.parseStrings()
+	case unicode.IsLetter(c) || c == '_':
+		return p.parseName()
+	}
+
+	return nil, fmt.Errorf("unexpected character '%c' at position %d", c, p.pos)
+}
+
+// parseItems parses the items of a list, up to the closing character
+func (p *literalParser) parseItems(closing rune) ([]interface{}, error) {
+	items := []interface{}{}
+
+	for {
+		p.skipSpaces()
+		if p.peek() == closing {
+			p.pos++
+			return items, nil
+		}
+
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, value)
+
+		p.skipSpaces()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case closing:
+		default:
+			return nil, fmt.Errorf("expected ',' or '%c' at position %d", closing, p.pos)
+		}
+	}
+}
+
+// parseBraces parses a dict or, when its items are not key-value pairs, a set
+func (p *literalParser) parseBraces() (interface{}, error) {
+	p.skipSpaces()
+	if p.peek() == '}' {
+		p.pos++
+		return map[string]interface{}{}, nil
+	}
+
+	first, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+	if p.peek() != ':' {
+		items := []interface{}{first}
+		switch p.peek() {
+		case ',':
+			p.pos++
+			rest, err := p.parseItems('}')
+			if err != nil {
+				return nil, err
+			}
+			return append(items, rest...), nil
+		case '}':
+			p.pos++
+			return items, nil
+		}
+		return nil, fmt.Errorf("expected ',', ':' or '}' at position %d", p.pos)
+	}
+
+	dict := map[string]interface{}{}
+	key := first
+	for {
+		if !isHashable(key) {
+			return nil, fmt.Errorf("unhashable dict key")
+		}
+
+		// the colon that follows the key
+		p.pos++
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		dict[jsonKey(key)] = value
+
+		p.skipSpaces()
+		switch p.peek() {
+		case ',':
+			p.pos++
+			p.skipSpaces()
+			if p.peek() == '}' {
+				p.pos++
+				return dict, nil
+			}
+		case '}':
+			p.pos++
+			return dict, nil
+		default:
+			return nil, fmt.Errorf("expected ',' or '}' at position %d", p.pos)
+		}
+
+		key, err = p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if p.peek() != ':' {
+			return nil, fmt.Errorf("expected ':' at position %d", p.pos)
+		}
+	}
+}
+
+// isHashable returns whether the value can be a python dict key
+func isHashable(value interface{}) bool {
+	switch value.(type) {
+	case map[string]interface{}:
+		return false
+	case []interface{}:
+		// tuples are hashable, but there is no way to render them as a JSON key
+		return false
+	}
+
+	return true
+}
+
+// jsonKey returns the dict key as it is rendered on JSON
+func jsonKey(key interface{}) string {
+	switch k := key.(type) {
+	case nil:
+		return "null"
+	case string:
+		return k
+	case float64:
+		return strconv.FormatFloat(k, 'g', -1, 64)
+	default:
+		return fmt.Sprint(k)
+	}
+}
+
+// parseName parses the True, False and None names
+func (p *literalParser) parseName() (interface{}, error) {
+	start := p.pos
+	for p.pos < len(p.input) && (unicode.IsLetter(p.input[p.pos]) || unicode.IsDigit(p.input[p.pos]) || p.input[p.pos] == '_') {
+		p.pos++
+	}
+	name := string(p.input[start:p.pos])
+
+	// string prefixes
+	if p.peek() == '\'' || p.peek() == '"' {
+		switch strings.ToLower(name) {
+		case "r", "u", "b", "br", "rb":
+			p.pos = start
+			return p.parseStrings()
+		}
+	}
+
+	switch name {
+	case "True":
+		return true, nil
+	case "False":
+		return false, nil
+	case "None":
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("name '%s' is not a literal", name)
+}
+
+// startsNumber returns whether a number starts at the current position
+func (p *literalParser) startsNumber() bool {
+	c := p.peek()
+	if unicode.IsDigit(c) {
+		return true
+	}
+
+	return c == '.' && p.pos+1 < len(p.input) && unicode.IsDigit(p.input[p.pos+1])
+}
+
+// parseNumber parses an integer, in decimal, hexadecimal, octal or binary notation, or a float
+func (p *literalParser) parseNumber() (interface{}, error) {
+	start := p.pos
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '.' {
+			p.pos++
+			continue
+		}
+		// exponent sign
+		if (c == '+' || c == '-') && p.pos > start && strings.ContainsRune("eE", p.input[p.pos-1]) && !isPrefixedInteger(string(p.input[start:p.pos])) {
+			p.pos++
+			continue
+		}
+		break
+	}
+
+	literal := string(p.input[start:p.pos])
+	if strings.HasPrefix(literal, "_") || strings.HasSuffix(literal, "_") || strings.Contains(literal, "__") {
+		return nil, fmt.Errorf("invalid number '%s'", literal)
+	}
+	clean := strings.ReplaceAll(literal, "_", "")
+
+	if isPrefixedInteger(clean) {
+		integer, err := strconv.ParseInt(clean, 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s'", literal)
+		}
+		return intOrFloat(integer), nil
+	}
+
+	if strings.ContainsAny(clean, ".eE") {
+		float, err := strconv.ParseFloat(clean, 64)
+		if err != nil || strings.ContainsAny(clean, "xXpP") {
+			return nil, fmt.Errorf("invalid number '%s'", literal)
+		}
+		return float, nil
+	}
+
+	// decimal integers can not have leading zeros, unless they are zero
+	if len(clean) > 1 && clean[0] == '0' && strings.Trim(clean, "0") != "" {
+		return nil, fmt.Errorf("leading zeros are not allowed on '%s'", literal)
+	}
+
+	integer, err := strconv.ParseInt(clean, 10, 64)
+	if err != nil {
+		float, errFloat := strconv.ParseFloat(clean, 64)
+		if errFloat != nil || strings.Trim(clean, "0123456789") != "" {
+			return nil, fmt.Errorf("invalid number '%s'", literal)
+		}
+		return float, nil
+	}
+
+	return intOrFloat(integer), nil
+}
+
+// isPrefixedInteger returns whether the literal is a hexadecimal, octal or binary integer
+func isPrefixedInteger(literal string) bool {
+	lower := strings.ToLower(literal)
+	return strings.HasPrefix(lower, "0x") || strings.HasPrefix(lower, "0o") || strings.HasPrefix(lower, "0b")
+}
+
+// intOrFloat returns the integer as an int, or as a float64 when it overflows the int type
+func intOrFloat(integer int64) interface{} {
+	if integer < math.MinInt || integer > math.MaxInt {
+		return float64(integer)
+	}
+
+	return int(integer)
+}
+
+// parseStrings parses a sequence of adjacent strings, which are concatenated
+func (p *literalParser) parseStrings() (interface{}, error) {
+	buff := &strings.Builder{}
+	var isBytes *bool
+
+	for {
+		p.skipSpaces()
+
+		start := p.pos
+		for p.pos < len(p.input) && unicode.IsLetter(p.input[p.pos]) {
+			p.pos++
+		}
+		prefix := strings.ToLower(string(p.input[start:p.pos]))
+
+		if p.peek() != '\'' && p.peek() != '"' {
+			p.pos = start
+			break
+		}
+
+		switch prefix {
+		case "", "r", "u", "b", "br", "rb":
+		default:
+			return nil, fmt.Errorf("unsupported string prefix '%s'", prefix)
+		}
+
+		bytesString := strings.Contains(prefix, "b")
+		if isBytes != nil && *isBytes != bytesString {
+			return nil, fmt.Errorf("cannot mix bytes and nonbytes literals")
+		}
+		isBytes = &bytesString
+
+		str, err := p.parseString(strings.Contains(prefix, "r"))
+		if err != nil {
+			return nil, err
+		}
+		buff.WriteString(str)
+	}
+
+	return buff.String(), nil
+}
+
+// parseString parses a single quoted string, which may be triple quoted
+func (p *literalParser) parseString(raw bool) (string, error) {
+	quote := p.input[p.pos]
+	delimiter := string(quote)
+	if p.pos+2 < len(p.input) && p.input[p.pos+1] == quote && p.input[p.pos+2] == quote {
+		delimiter = strings.Repeat(string(quote), 3)
+	}
+	p.pos += len(delimiter)
+
+	buff := &strings.Builder{}
+	for {
+		if p.pos >= len(p.input) {
+			return "", fmt.Errorf("unterminated string literal")
+		}
+
+		if strings.HasPrefix(string(p.input[p.pos:min(p.pos+len(delimiter), len(p.input))]), delimiter) {
+			p.pos += len(delimiter)
+			return buff.String(), nil
+		}
+
+		c := p.input[p.pos]
+		if c == '\n' && len(delimiter) == 1 {
+			return "", fmt.Errorf("unterminated string literal")
+		}
+
+		if c != '\\' {
+			buff.WriteRune(c)
+			p.pos++
+			continue
+		}
+
+		if p.pos+1 >= len(p.input) {
+			return "", fmt.Errorf("unterminated string literal")
+		}
+
+		if raw {
+			buff.WriteRune(c)
+			buff.WriteRune(p.input[p.pos+1])
+			p.pos += 2
+			continue
+		}
+
+		err := p.parseEscape(buff)
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+// parseEscape parses a backslash escape sequence of a string
+func (p *literalParser) parseEscape(buff *strings.Builder) error {
+	c := p.input[p.pos+1]
+	p.pos += 2
+
+	simple := map[rune]string{
+		'\n': "",
+		'\\': "\\",
+		'\'': "'",
+		'"':  "\"",
+		'a':  "\a",
+		'b':  "\b",
+		'f':  "\f",
+		'n':  "\n",
+		'r':  "\r",
+		't':  "\t",
+		'v':  "\v",
+	}
+
+	if str, exists := simple[c]; exists {
+		buff.WriteString(str)
+		return nil
+	}
+
+	hexLength := map[rune]int{'x': 2, 'u': 4, 'U': 8}
+	if length, exists := hexLength[c]; exists {
+		if p.pos+length > len(p.input) {
+			return fmt.Errorf("truncated \\%cXX escape", c)
+		}
+		code, err := strconv.ParseUint(string(p.input[p.pos:p.pos+length]), 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return fmt.Errorf("invalid \\%c escape", c)
+		}
+		buff.WriteRune(rune(code))
+		p.pos += length
+		return nil
+	}
+
+	if c >= '0' && c <= '7' {
+		digits := string(c)
+		for len(digits) < 3 && p.pos < len(p.input) && p.input[p.pos] >= '0' && p.input[p.pos] <= '7' {
+			digits += string(p.input[p.pos])
+			p.pos++
+		}
+		code, _ := strconv.ParseUint(digits, 8, 32)
+		buff.WriteRune(rune(code))
+		return nil
+	}
+
+	// unknown escapes are kept
+	buff.WriteRune('\\')
+	buff.WriteRune(c)
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseINIValue(t *testing.T) {
+	tests := []struct {
+		desc     string
+		raw      string
+		expected interface{}
+	}{
+		{desc: "Testing a decimal integer", raw: "1_000", expected: 1000},
+		{desc: "Testing a negative hexadecimal integer", raw: "-0x1F", expected: -31},
+		{desc: "Testing an octal and a binary integer", raw: "0o17, 0b101", expected: []interface{}{15, 5}},
+		{desc: "Testing an integer with leading zeros", raw: "010", expected: "010"},
+		{desc: "Testing floats", raw: "[1.5, .5, 5., 1e3]", expected: []interface{}{1.5, 0.5, 5.0, 1000.0}},
+		{desc: "Testing booleans and None", raw: "(True, False, None)", expected: []interface{}{true, false, nil}},
+		{desc: "Testing a lowercase boolean, which is not a python literal", raw: "true", expected: "true"},
+		{desc: "Testing concatenated strings with escapes", raw: `'a\tb' "c" r'\n'`, expected: "a\tbc\\n"},
+		{desc: "Testing a triple quoted string", raw: `'''it's'''`, expected: "it's"},
+		{desc: "Testing a bytes string", raw: `b'bytes'`, expected: "bytes"},
+		{desc: "Testing a dict with non string keys", raw: "{'a': [1, 2,], 2: {'b': None},}", expected: map[string]interface{}{"a": []interface{}{1, 2}, "2": map[string]interface{}{"b": nil}}},
+		{desc: "Testing a set", raw: "{1, 2}", expected: []interface{}{1, 2}},
+		{desc: "Testing an empty tuple and dict", raw: "(), {}", expected: []interface{}{[]interface{}{}, map[string]interface{}{}}},
+		{desc: "Testing a value with a trailing comment", raw: "1 # comment", expected: 1},
+		{desc: "Testing an IP address", raw: "192.0.2.10", expected: "192.0.2.10"},
+		{desc: "Testing a name", raw: "production", expected: "production"},
+		{desc: "Testing a signed string", raw: "-'a'", expected: "-'a'"},
+		{desc: "Testing a double sign", raw: "--1", expected: "--1"},
+		{desc: "Testing a complex number", raw: "1j", expected: "1j"},
+		{desc: "Testing an unterminated string", raw: "'abc", expected: "'abc"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expected, parseINIValue(test.raw))
+		})
+	}
+}
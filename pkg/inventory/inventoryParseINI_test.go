@@ -0,0 +1,179 @@
+package inventory
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseINI(t *testing.T) {
+	tests := []struct {
+		desc     string
+		content  string
+		expected *Inventory
+		err      error
+	}{
+		{
+			desc: "Testing parsing an INI inventory",
+			content: `
+; comment
+bastion
+[web]
+web[1:2] http_port=80 'motd=hello world'
+[web:vars]
+env = prod # comment
+[site:children]
+web
+`,
+			expected: &Inventory{
+				hosts: []*Host{
+					{Name: "bastion", Vars: map[string]interface{}{}},
+					{Name: "web1", Vars: map[string]interface{}{"http_port": 80, "motd": "hello world"}},
+					{Name: "web2", Vars: map[string]interface{}{"http_port": 80, "motd": "hello world"}},
+				},
+				groups: []*Group{
+					{Name: AllGroup, Hosts: []string{}, Children: []string{UngroupedGroup, "site"}, Vars: map[string]interface{}{}},
+					{Name: UngroupedGroup, Hosts: []string{"bastion"}, Children: []string{}, Vars: map[string]interface{}{}},
+					{Name: "web", Hosts: []string{"web1", "web2"}, Children: []string{}, Vars: map[string]interface{}{"env": "prod # comment"}},
+					{Name: "site", Hosts: []string{}, Children: []string{"web"}, Vars: map[string]interface{}{}},
+				},
+			},
+		},
+		{
+			desc: "Testing parsing an INI inventory whose children are defined after their parent",
+			content: `
+[site:children]
+prod
+[prod:children]
+web
+[web]
+web1
+[prod]
+`,
+			expected: &Inventory{
+				hosts: []*Host{
+					{Name: "web1", Vars: map[string]interface{}{}},
+				},
+				groups: []*Group{
+					{Name: AllGroup, Hosts: []string{}, Children: []string{UngroupedGroup, "site"}, Vars: map[string]interface{}{}},
+					{Name: UngroupedGroup, Hosts: []string{}, Children: []string{}, Vars: map[string]interface{}{}},
+					{Name: "site", Hosts: []string{}, Children: []string{"prod"}, Vars: map[string]interface{}{}},
+					{Name: "prod", Hosts: []string{}, Children: []string{"web"}, Vars: map[string]interface{}{}},
+					{Name: "web", Hosts: []string{"web1"}, Children: []string{}, Vars: map[string]interface{}{}},
+				},
+			},
+		},
+		{
+			desc:    "Testing parsing an INI inventory with an unknown section type",
+			content: "[web:hostvars]\n",
+			err: errors.New("(inventory::ParseINI)", "Error parsing the INI inventory",
+				errors.New("", "line 1: section [web:hostvars] has unknown type: hostvars")),
+		},
+		{
+			desc:    "Testing parsing an INI inventory with an invalid section entry",
+			content: "[web servers]\n",
+			err: errors.New("(inventory::ParseINI)", "Error parsing the INI inventory",
+				errors.New("", "line 1: invalid section entry: '[web servers]'. Please make sure that there are no spaces in the section entry, and that there are no other invalid characters")),
+		},
+		{
+			desc:    "Testing parsing an INI inventory with variables of an undefined group",
+			content: "[web:vars]\nhttp_port=80\n",
+			err: errors.New("(inventory::ParseINI)", "Error parsing the INI inventory",
+				errors.New("", "line 1: section [web:vars] not valid for undefined group: web")),
+		},
+		{
+			desc:    "Testing parsing an INI inventory with an undefined child group",
+			content: "[site:children]\nweb\n",
+			err: errors.New("(inventory::ParseINI)", "Error parsing the INI inventory",
+				errors.New("", "line 2: section [site:children] includes undefined group: web")),
+		},
+		{
+			desc:    "Testing parsing an INI inventory with a variable without value",
+			content: "[web]\nweb1 http_port\n",
+			err: errors.New("(inventory::ParseINI)", "Error parsing the INI inventory",
+				errors.New("", "line 2: expected key=value host variable assignment, got: http_port")),
+		},
+		{
+			desc:    "Testing parsing an INI inventory with an invalid group variable",
+			content: "[web]\n[web:vars]\nhttp_port\n",
+			err: errors.New("(inventory::ParseINI)", "Error parsing the INI inventory",
+				errors.New("", "line 3: expected key=value, got: http_port")),
+		},
+		{
+			desc:    "Testing parsing an INI inventory with an unclosed quotation",
+			content: "web1 motd='hello\n",
+			err: errors.New("(inventory::ParseINI)", "Error parsing the INI inventory",
+				errors.New("", "line 1: error parsing host definition 'web1 motd='hello': no closing quotation")),
+		},
+		{
+			desc:    "Testing parsing an INI inventory with cyclic children groups",
+			content: "[a]\n[b]\n[a:children]\nb\n[b:children]\na\n",
+			err: errors.New("(inventory::ParseINI)", "Error parsing the INI inventory",
+				errors.New("", "cyclic children groups 'a -> b -> a'")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			inv, err := ParseINI([]byte(test.content))
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				assert.Equal(t, test.expected, inv)
+			}
+		})
+	}
+}
+
+func TestShlexSplit(t *testing.T) {
+	tests := []struct {
+		desc     string
+		line     string
+		expected []string
+		err      error
+	}{
+		{
+			desc:     "Testing splitting a line by whitespaces",
+			line:     "web1  a=1\tb=2",
+			expected: []string{"web1", "a=1", "b=2"},
+		},
+		{
+			desc:     "Testing splitting a line with quotes",
+			line:     `web1 a='x y' b="x \"y\" \z" c=x' 'y d=""`,
+			expected: []string{"web1", "a=x y", `b=x "y" \z`, "c=x y", "d="},
+		},
+		{
+			desc:     "Testing splitting a line with escapes and comments",
+			line:     `web1 a=x\ y b=1#comment`,
+			expected: []string{"web1", "a=x y", "b=1"},
+		},
+		{
+			desc: "Testing splitting a line with an unclosed quotation",
+			line: `web1 a="x`,
+			err:  errors.New("", "no closing quotation"),
+		},
+		{
+			desc: "Testing splitting a line with a trailing escape",
+			line: `web1 a=x\`,
+			err:  errors.New("", "no escaped character"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			tokens, err := shlexSplit(test.line)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				assert.Equal(t, test.expected, tokens)
+			}
+		})
+	}
+}
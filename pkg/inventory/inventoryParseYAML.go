@@ -0,0 +1,419 @@
+package inventory
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// vaultTag is the tag of the vault encrypted values
+	vaultTag = "!vault"
+	// unsafeTag is the tag of the values that must not be templated
+	unsafeTag = "!unsafe"
+	// vaultKey is the key Ansible uses to render a vault encrypted value on JSON
+	vaultKey = "__ansible_vault"
+	// pluginKey is the key of the inventory plugins configuration files
+	pluginKey = "plugin"
+	// mergeKey is the YAML merge key
+	mergeKey = "<<"
+)
+
+var (
+	// yaml11BoolRegexp matches the YAML 1.1 booleans, which Ansible resolves through PyYAML
+	yaml11BoolRegexp = regexp.MustCompile(`^(?:yes|Yes|YES|no|No|NO|true|True|TRUE|false|False|FALSE|on|On|ON|off|Off|OFF)$`)
+	// yaml11IntRegexp matches the YAML 1.1 integers
+	yaml11IntRegexp = regexp.MustCompile(`^(?:[-+]?0b[0-1_]+|[-+]?0[0-7_]+|[-+]?(?:0|[1-9][0-9_]*)|[-+]?0x[0-9a-fA-F_]+|[-+]?[1-9][0-9_]*(?::[0-5]?[0-9])+)$`)
+	// yaml11FloatRegexp matches the YAML 1.1 floats
+	yaml11FloatRegexp = regexp.MustCompile(`^(?:[-+]?(?:[0-9][0-9_]*)\.[0-9_]*(?:[eE][-+][0-9]+)?|\.[0-9][0-9_]*(?:[eE][-+][0-9]+)?|[-+]?[0-9][0-9_]*(?::[0-5]?[0-9])+\.[0-9_]*|[-+]?\.(?:inf|Inf|INF)|\.(?:nan|NaN|NAN))$`)
+	// yaml11NullRegexp matches the YAML 1.1 nulls
+	yaml11NullRegexp = regexp.MustCompile(`^(?:~|null|Null|NULL|)$`)
+)
+
+// yamlPair is a key-value pair of a YAML mapping
+type yamlPair struct {
+	key   string
+	value *yaml.Node
+}
+
+// ParseYAML parses a static inventory in the Ansible YAML format into an Inventory, following the Ansible yaml inventory plugin rules. The top level keys are groups, which define their hosts, variables and children groups. The hosts can define ranges, such as web[01:20], and a port. The scalars are resolved following the YAML 1.1 rules, as Ansible does. The returned inventory follows the ansible-inventory --list --export structure
+func ParseYAML(content []byte) (*Inventory, error) {
+	errContext := "(inventory::ParseYAML)"
+
+	inventory := newStaticInventory()
+
+	err := parseYAML(inventory, content)
+	if err != nil {
+		return nil, errors.New(errContext, "Error parsing the YAML inventory", err)
+	}
+
+	err = reconcileStaticInventory(inventory)
+	if err != nil {
+		return nil, errors.New(errContext, "Error parsing the YAML inventory", err)
+	}
+
+	return inventory, nil
+}
+
+// parseYAML parses a YAML inventory into the inventory
+func parseYAML(inventory *Inventory, content []byte) error {
+	document := &yaml.Node{}
+
+	err := yaml.Unmarshal(content, document)
+	if err != nil {
+		return err
+	}
+
+	if len(document.Content) == 0 {
+		return fmt.Errorf("parsed empty YAML file")
+	}
+
+	root := resolveYAMLAlias(document.Content[0])
+	if isYAMLNull(root) || (root.Kind == yaml.MappingNode && len(root.Content) == 0) {
+		return fmt.Errorf("parsed empty YAML file")
+	}
+
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("YAML inventory has invalid structure, it should be a dictionary")
+	}
+
+	pairs := yamlPairs(root)
+	for _, pair := range pairs {
+		if pair.key == pluginKey {
+			return fmt.Errorf("plugin configuration YAML file, not YAML inventory")
+		}
+	}
+
+	for _, pair := range pairs {
+		_, err = parseYAMLGroup(inventory, pair.key, pair.value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseYAMLGroup parses a group of a YAML inventory. It returns false when the group definition is not valid and it is skipped, as Ansible does
+func parseYAMLGroup(inventory *Inventory, name string, node *yaml.Node) (bool, error) {
+	if !isYAMLNull(node) && node.Kind != yaml.MappingNode {
+		return false, nil
+	}
+
+	group := inventory.AddGroup(name)
+
+	if isYAMLNull(node) {
+		return true, nil
+	}
+
+	for _, pair := range yamlPairs(node) {
+		section := pair.value
+
+		switch pair.key {
+		case varsKey, childrenKey, hostsKey:
+		default:
+			// unexpected keys are skipped
+			continue
+		}
+
+		// a single string is a section with a single item
+		if section.Kind == yaml.ScalarNode && !isYAMLNull(section) {
+			value, err := yamlScalar(section)
+			if _, isString := value.(string); err != nil || !isString {
+				return false, fmt.Errorf("invalid \"%s\" entry for \"%s\" group, requires a dictionary", pair.key, name)
+			}
+			section = &yaml.Node{
+				Kind:    yaml.MappingNode,
+				Content: []*yaml.Node{section, {Kind: yaml.ScalarNode, Tag: "!!null"}},
+			}
+		}
+
+		if isYAMLNull(section) {
+			continue
+		}
+
+		if section.Kind != yaml.MappingNode {
+			return false, fmt.Errorf("invalid \"%s\" entry for \"%s\" group, requires a dictionary", pair.key, name)
+		}
+
+		switch pair.key {
+		case varsKey:
+			vars, err := yamlValue(section)
+			if err != nil {
+				return false, fmt.Errorf("error parsing the variables of the group '%s': %w", name, err)
+			}
+			for key, value := range vars.(map[string]interface{}) {
+				group.Vars[key] = value
+			}
+		case childrenKey:
+			for _, child := range yamlPairs(section) {
+				valid, err := parseYAMLGroup(inventory, child.key, child.value)
+				if err != nil {
+					return false, err
+				}
+				if !valid {
+					return false, fmt.Errorf("%s is not a known host nor group", child.key)
+				}
+				group.AddChild(child.key)
+			}
+		case hostsKey:
+			for _, host := range yamlPairs(section) {
+				hosts, port, err := expandHostPattern(host.key)
+				if err != nil {
+					return false, err
+				}
+
+				vars := map[string]interface{}{}
+				if !isYAMLNull(host.value) {
+					value, err := yamlValue(host.value)
+					if err != nil {
+						return false, fmt.Errorf("error parsing the variables of the host '%s': %w", host.key, err)
+					}
+					hostVars, isMap := value.(map[string]interface{})
+					if !isMap {
+						return false, fmt.Errorf("variables of the host '%s' must be a map", host.key)
+					}
+					vars = hostVars
+				}
+
+				addStaticHosts(inventory, name, hosts, port, vars)
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// decodeYAML decodes a YAML document, resolving the scalars following the YAML 1.1 rules
+func decodeYAML(content []byte) (interface{}, error) {
+	document := &yaml.Node{}
+
+	err := yaml.Unmarshal(content, document)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(document.Content) == 0 {
+		return nil, nil
+	}
+
+	return yamlValue(document.Content[0])
+}
+
+// yamlValue converts a YAML node to a value. The vault encrypted values are converted to the map Ansible renders on JSON
+func yamlValue(node *yaml.Node) (interface{}, error) {
+	node = resolveYAMLAlias(node)
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		value := map[string]interface{}{}
+		for _, pair := range yamlPairs(node) {
+			item, err := yamlValue(pair.value)
+			if err != nil {
+				return nil, err
+			}
+			value[pair.key] = item
+		}
+		return value, nil
+	case yaml.SequenceNode:
+		value := make([]interface{}, 0, len(node.Content))
+		for _, itemNode := range node.Content {
+			item, err := yamlValue(itemNode)
+			if err != nil {
+				return nil, err
+			}
+			value = append(value, item)
+		}
+		return value, nil
+	case yaml.ScalarNode:
+		return yamlScalar(node)
+	}
+
+	return nil, fmt.Errorf("unsupported YAML node at line %d", node.Line)
+}
+
+// yamlScalar resolves a scalar following the YAML 1.1 rules
+func yamlScalar(node *yaml.Node) (interface{}, error) {
+	switch node.Tag {
+	case vaultTag:
+		return map[string]interface{}{vaultKey: node.Value}, nil
+	case unsafeTag:
+		return node.Value, nil
+	}
+
+	// quoted, block and explicitly tagged scalars are not resolved
+	if node.Style&yaml.TaggedStyle != 0 {
+		var value interface{}
+		err := node.Decode(&value)
+		if err != nil {
+			return nil, err
+		}
+		return normalize(value), nil
+	}
+
+	if node.Style != 0 {
+		return node.Value, nil
+	}
+
+	value := node.Value
+
+	switch {
+	case yaml11NullRegexp.MatchString(value):
+		return nil, nil
+	case yaml11BoolRegexp.MatchString(value):
+		switch strings.ToLower(value) {
+		case "yes", "true", "on":
+			return true, nil
+		}
+		return false, nil
+	case yaml11IntRegexp.MatchString(value):
+		return yaml11Int(value), nil
+	case yaml11FloatRegexp.MatchString(value):
+		return yaml11Float(value), nil
+	}
+
+	return value, nil
+}
+
+// yaml11Int converts a YAML 1.1 integer, which can be binary, octal, hexadecimal or sexagesimal
+func yaml11Int(value string) interface{} {
+	value = strings.ReplaceAll(value, "_", "")
+
+	sign := int64(1)
+	switch value[0] {
+	case '-':
+		sign = -1
+		value = value[1:]
+	case '+':
+		value = value[1:]
+	}
+
+	var integer int64
+	var err error
+
+	switch {
+	case value == "0":
+	case strings.HasPrefix(value, "0b"):
+		integer, err = strconv.ParseInt(value[2:], 2, 64)
+	case strings.HasPrefix(value, "0x"):
+		integer, err = strconv.ParseInt(value[2:], 16, 64)
+	case strings.HasPrefix(value, "0"):
+		integer, err = strconv.ParseInt(value[1:], 8, 64)
+	case strings.Contains(value, ":"):
+		for _, digit := range strings.Split(value, ":") {
+			part, errPart := strconv.ParseInt(digit, 10, 64)
+			if errPart != nil {
+				err = errPart
+				break
+			}
+			integer = integer*60 + part
+		}
+	default:
+		integer, err = strconv.ParseInt(value, 10, 64)
+	}
+	if err != nil {
+		return value
+	}
+
+	return intOrFloat(sign * integer)
+}
+
+// yaml11Float converts a YAML 1.1 float, which can be sexagesimal, infinite or not a number
+func yaml11Float(value string) interface{} {
+	value = strings.ToLower(strings.ReplaceAll(value, "_", ""))
+
+	sign := 1.0
+	switch value[0] {
+	case '-':
+		sign = -1
+		value = value[1:]
+	case '+':
+		value = value[1:]
+	}
+
+	switch {
+	case value == ".inf":
+		return sign * math.Inf(1)
+	case value == ".nan":
+		return math.NaN()
+	case strings.Contains(value, ":"):
+		float := 0.0
+		for _, digit := range strings.Split(value, ":") {
+			part, _ := strconv.ParseFloat(digit, 64)
+			float = float*60 + part
+		}
+		return sign * float
+	}
+
+	float, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return value
+	}
+
+	return sign * float
+}
+
+// yamlPairs returns the key-value pairs of a mapping, in order. The pairs of the merge keys are added when their keys are not defined
+func yamlPairs(node *yaml.Node) []yamlPair {
+	pairs := []yamlPair{}
+	merged := []yamlPair{}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i]
+		value := resolveYAMLAlias(node.Content[i+1])
+
+		if key.Value == mergeKey && key.Style == 0 {
+			sources := []*yaml.Node{value}
+			if value.Kind == yaml.SequenceNode {
+				sources = value.Content
+			}
+			for _, source := range sources {
+				source = resolveYAMLAlias(source)
+				if source.Kind == yaml.MappingNode {
+					merged = append(merged, yamlPairs(source)...)
+				}
+			}
+			continue
+		}
+
+		pairs = append(pairs, yamlPair{key: key.Value, value: value})
+	}
+
+	for _, pair := range merged {
+		defined := false
+		for _, existing := range pairs {
+			if existing.key == pair.key {
+				defined = true
+				break
+			}
+		}
+		if !defined {
+			pairs = append(pairs, pair)
+		}
+	}
+
+	return pairs
+}
+
+// resolveYAMLAlias returns the node an alias refers to
+func resolveYAMLAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	return node
+}
+
+// isYAMLNull returns whether the node is a null value
+func isYAMLNull(node *yaml.Node) bool {
+	if node == nil {
+		return true
+	}
+
+	return node.Kind == yaml.ScalarNode && node.Style == 0 && (node.Tag == "!!null" || (node.Tag != "!!str" && yaml11NullRegexp.MatchString(node.Value)))
+}
@@ -0,0 +1,135 @@
+package inventory
+
+import (
+	"math"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseYAML(t *testing.T) {
+	tests := []struct {
+		desc     string
+		content  string
+		expected *Inventory
+		err      error
+	}{
+		{
+			desc: "Testing parsing a YAML inventory",
+			content: `
+all:
+  hosts:
+    bastion:
+  children:
+    web:
+      hosts:
+        web[1:2]:2222:
+          http_port: 80
+      vars:
+        env: prod
+      unexpected: value
+site:
+  children: web
+invalid: value
+`,
+			expected: &Inventory{
+				hosts: []*Host{
+					{Name: "bastion", Vars: map[string]interface{}{}},
+					{Name: "web1", Vars: map[string]interface{}{"ansible_port": 2222, "http_port": 80}},
+					{Name: "web2", Vars: map[string]interface{}{"ansible_port": 2222, "http_port": 80}},
+				},
+				groups: []*Group{
+					{Name: AllGroup, Hosts: []string{}, Children: []string{UngroupedGroup, "web", "site"}, Vars: map[string]interface{}{}},
+					{Name: UngroupedGroup, Hosts: []string{"bastion"}, Children: []string{}, Vars: map[string]interface{}{}},
+					{Name: "web", Hosts: []string{"web1", "web2"}, Children: []string{}, Vars: map[string]interface{}{"env": "prod"}},
+					{Name: "site", Hosts: []string{}, Children: []string{"web"}, Vars: map[string]interface{}{}},
+				},
+			},
+		},
+		{
+			desc:    "Testing parsing an empty YAML inventory",
+			content: "---\n",
+			err: errors.New("(inventory::ParseYAML)", "Error parsing the YAML inventory",
+				errors.New("", "parsed empty YAML file")),
+		},
+		{
+			desc:    "Testing parsing a YAML inventory that is not a map",
+			content: "- web1\n",
+			err: errors.New("(inventory::ParseYAML)", "Error parsing the YAML inventory",
+				errors.New("", "YAML inventory has invalid structure, it should be a dictionary")),
+		},
+		{
+			desc:    "Testing parsing an inventory plugin configuration",
+			content: "plugin: aws_ec2\n",
+			err: errors.New("(inventory::ParseYAML)", "Error parsing the YAML inventory",
+				errors.New("", "plugin configuration YAML file, not YAML inventory")),
+		},
+		{
+			desc:    "Testing parsing a YAML inventory with an invalid section",
+			content: "web:\n  hosts:\n    - web1\n",
+			err: errors.New("(inventory::ParseYAML)", "Error parsing the YAML inventory",
+				errors.New("", "invalid \"hosts\" entry for \"web\" group, requires a dictionary")),
+		},
+		{
+			desc:    "Testing parsing a YAML inventory with invalid host variables",
+			content: "web:\n  hosts:\n    web1: value\n",
+			err: errors.New("(inventory::ParseYAML)", "Error parsing the YAML inventory",
+				errors.New("", "variables of the host 'web1' must be a map")),
+		},
+		{
+			desc:    "Testing parsing a YAML inventory with an invalid child group",
+			content: "web:\n  children:\n    db: value\n",
+			err: errors.New("(inventory::ParseYAML)", "Error parsing the YAML inventory",
+				errors.New("", "db is not a known host nor group")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			inv, err := ParseYAML([]byte(test.content))
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				assert.Equal(t, test.expected, inv)
+			}
+		})
+	}
+}
+
+func TestYAMLValue(t *testing.T) {
+	tests := []struct {
+		desc     string
+		content  string
+		expected interface{}
+	}{
+		{desc: "Testing YAML 1.1 booleans", content: "[yes, No, on, OFF, true]", expected: []interface{}{true, false, true, false, true}},
+		{desc: "Testing YAML 1.1 integers", content: "[0644, 0b11, 0x1F, 1_000, '1:30', 1:30, -0]", expected: []interface{}{420, 3, 31, 1000, "1:30", 90, 0}},
+		{desc: "Testing YAML 1.1 floats", content: "[1.5, 1e3, 1.0e+3, .5, -.inf]", expected: []interface{}{1.5, "1e3", 1000.0, 0.5, math.Inf(-1)}},
+		{desc: "Testing YAML 1.1 nulls", content: "[~, null, '']", expected: []interface{}{nil, nil, ""}},
+		{desc: "Testing YAML 1.2 octal integers, which are strings on YAML 1.1", content: "0o17", expected: "0o17"},
+		{desc: "Testing explicitly tagged scalars", content: "[!!str yes, !!int '10']", expected: []interface{}{"yes", 10}},
+		{desc: "Testing vault and unsafe tagged scalars", content: "[!vault cipher, !unsafe '{{ x }}']", expected: []interface{}{map[string]interface{}{"__ansible_vault": "cipher"}, "{{ x }}"}},
+		{desc: "Testing merge keys and aliases", content: "base: &base {a: 1, b: 2}\nitem:\n  <<: *base\n  b: 3", expected: map[string]interface{}{"base": map[string]interface{}{"a": 1, "b": 2}, "item": map[string]interface{}{"b": 3, "a": 1}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			document := &yaml.Node{}
+			err := yaml.Unmarshal([]byte(test.content), document)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			value, err := yamlValue(document.Content[0])
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, value)
+		})
+	}
+}
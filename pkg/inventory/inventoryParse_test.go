@@ -0,0 +1,246 @@
+package inventory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	listJSON = `{
+    "_meta": {
+        "hostvars": {
+            "db1": {
+                "pg_version": 16
+            },
+            "web1": {
+                "ansible_host": "10.0.0.1",
+                "weight": 1.5
+            }
+        }
+    },
+    "all": {
+        "children": [
+            "ungrouped",
+            "prod"
+        ]
+    },
+    "db": {
+        "hosts": [
+            "db1"
+        ]
+    },
+    "prod": {
+        "children": [
+            "db",
+            "web"
+        ]
+    },
+    "ungrouped": {
+        "hosts": [
+            "bastion"
+        ]
+    },
+    "web": {
+        "hosts": [
+            "web1",
+            "web2"
+        ],
+        "vars": {
+            "http_port": 80
+        }
+    }
+}`
+
+	listYAML = `all:
+  children:
+    prod:
+      children:
+        db:
+          hosts:
+            db1:
+              pg_version: 16
+        web:
+          hosts:
+            web1:
+              ansible_host: 10.0.0.1
+              weight: 1.5
+            web2: {}
+          vars:
+            http_port: 80
+    ungrouped:
+      hosts:
+        bastion: {}
+`
+
+	listTOML = `[all]
+children = ["prod", "ungrouped"]
+
+[db.hosts.db1]
+pg_version = 16
+
+[prod]
+children = ["db", "web"]
+
+[ungrouped.hosts]
+bastion = {}
+
+[web.hosts.web1]
+ansible_host = "10.0.0.1"
+weight = 1.5
+
+[web.hosts.web2]
+
+[web.vars]
+http_port = 80
+`
+)
+
+func TestParseList(t *testing.T) {
+	tests := []struct {
+		desc    string
+		content string
+		format  Format
+	}{
+		{
+			desc:    "Testing parse the inventory list in JSON format",
+			content: listJSON,
+			format:  JSONFormat,
+		},
+		{
+			desc:    "Testing parse the inventory list in YAML format",
+			content: listYAML,
+			format:  YAMLFormat,
+		},
+		{
+			desc:    "Testing parse the inventory list in TOML format",
+			content: listTOML,
+			format:  TOMLFormat,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			inv, err := ParseList([]byte(test.content), test.format)
+			assert.NoError(t, err)
+
+			assert.ElementsMatch(t, []string{"bastion", "db1", "web1", "web2"}, inv.HostsInGroup(AllGroup))
+			assert.ElementsMatch(t, []string{"db1", "web1", "web2"}, inv.HostsInGroup("prod"))
+			assert.Equal(t, []string{"bastion"}, inv.HostsInGroup(UngroupedGroup))
+			assert.ElementsMatch(t, []string{"all", "prod", "web"}, inv.GroupsOfHost("web1"))
+			assert.Equal(t, map[string]interface{}{"ansible_host": "10.0.0.1", "weight": 1.5}, inv.Host("web1").Vars)
+			assert.Equal(t, map[string]interface{}{"pg_version": 16}, inv.Host("db1").Vars)
+			assert.Equal(t, map[string]interface{}{}, inv.Host("web2").Vars)
+			assert.Equal(t, map[string]interface{}{"http_port": 80}, inv.Group("web").Vars)
+			assert.Equal(t, []string{"db", "web"}, inv.Group("prod").Children)
+			assert.NoError(t, ValidateInventory(inv))
+		})
+	}
+}
+
+func TestParseListErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		content string
+		format  Format
+		err     error
+	}{
+		{
+			desc:    "Testing error parsing the inventory list in an unsupported format",
+			content: listJSON,
+			format:  INIFormat,
+			err:     errors.New("Error decoding the inventory list\n unsupported format 'ini'"),
+		},
+		{
+			desc:    "Testing error parsing an inventory list with invalid content",
+			content: "{",
+			format:  JSONFormat,
+			err:     errors.New("Error decoding the inventory list\n unexpected EOF"),
+		},
+		{
+			desc:    "Testing error parsing an inventory list whose group hosts are not a list",
+			content: `{"web": {"hosts": "web1"}}`,
+			format:  JSONFormat,
+			err:     errors.New("Error parsing the inventory list\n hosts of the group 'web' must be a list"),
+		},
+		{
+			desc:    "Testing error parsing an inventory list whose group children are not valid",
+			content: "all:\n  children: prod\n",
+			format:  YAMLFormat,
+			err:     errors.New("Error parsing the inventory list\n children of the group 'all' must be a list or a map"),
+		},
+		{
+			desc:    "Testing error parsing an inventory list whose host variables are not a map",
+			content: "web:\n  hosts:\n    web1: [1]\n",
+			format:  YAMLFormat,
+			err:     errors.New("Error parsing the inventory list\n variables of the host 'web1' must be a map"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			_, err := ParseList([]byte(test.content), test.format)
+			assert.EqualError(t, err, test.err.Error())
+		})
+	}
+}
+
+func TestParseHostVars(t *testing.T) {
+	tests := []struct {
+		desc     string
+		content  string
+		format   Format
+		expected map[string]interface{}
+		err      error
+	}{
+		{
+			desc:     "Testing parse the host variables in JSON format",
+			content:  `{"ansible_host": "10.0.0.1", "http_port": 80, "packages": ["nginx"]}`,
+			format:   JSONFormat,
+			expected: map[string]interface{}{"ansible_host": "10.0.0.1", "http_port": 80, "packages": []interface{}{"nginx"}},
+		},
+		{
+			desc:     "Testing parse the host variables in YAML format",
+			content:  "ansible_host: 10.0.0.1\nhttp_port: 80\npackages:\n- nginx\n",
+			format:   YAMLFormat,
+			expected: map[string]interface{}{"ansible_host": "10.0.0.1", "http_port": 80, "packages": []interface{}{"nginx"}},
+		},
+		{
+			desc:     "Testing parse the host variables in TOML format",
+			content:  "ansible_host = \"10.0.0.1\"\nhttp_port = 80\npackages = [\"nginx\"]\n",
+			format:   TOMLFormat,
+			expected: map[string]interface{}{"ansible_host": "10.0.0.1", "http_port": 80, "packages": []interface{}{"nginx"}},
+		},
+		{
+			desc:     "Testing parse empty host variables",
+			content:  "{}",
+			format:   JSONFormat,
+			expected: map[string]interface{}{},
+		},
+		{
+			desc:    "Testing error parsing host variables with invalid content",
+			content: "[1]",
+			format:  JSONFormat,
+			err:     errors.New("Error decoding the host variables\n json: cannot unmarshal array into Go value of type map[string]interface {}"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			vars, err := ParseHostVars([]byte(test.content), test.format)
+			if test.err != nil {
+				assert.EqualError(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, vars)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package inventory
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// groupPriorityVar is the variable that sets the priority of a group, which decides the order in which the variables of the groups at the same depth are merged
+const groupPriorityVar = "ansible_group_priority"
+
+// HostsInGroup returns the names of the hosts that belong to the group, directly or through its children groups, in the order they are found. Like Ansible, every host belongs to the all group
+func (i *Inventory) HostsInGroup(name string) []string {
+	hosts := []string{}
+
+	if name == AllGroup {
+		for _, host := range i.hosts {
+			hosts = append(hosts, host.Name)
+		}
+		return hosts
+	}
+
+	visited := map[string]struct{}{}
+
+	var visit func(name string)
+	visit = func(name string) {
+		if _, exists := visited[name]; exists {
+			return
+		}
+		visited[name] = struct{}{}
+
+		group := i.Group(name)
+		if group == nil {
+			return
+		}
+
+		for _, host := range group.Hosts {
+			if !contains(hosts, host) {
+				hosts = append(hosts, host)
+			}
+		}
+
+		for _, child := range group.Children {
+			visit(child)
+		}
+	}
+	visit(name)
+
+	return hosts
+}
+
+// GroupsOfHost returns the names of the groups the host belongs to, directly or through their children groups, in the inventory groups order. Like Ansible, every host belongs to the all group
+func (i *Inventory) GroupsOfHost(name string) []string {
+	groups := []string{}
+
+	if i.Host(name) == nil {
+		return groups
+	}
+
+	for _, group := range i.groups {
+		if group.Name == AllGroup || contains(i.HostsInGroup(group.Name), name) {
+			groups = append(groups, group.Name)
+		}
+	}
+
+	if !contains(groups, AllGroup) {
+		groups = append(groups, AllGroup)
+	}
+
+	return groups
+}
+
+// MergedHostVars returns the variables of the host merged with the variables of its groups, as ansible-inventory --host or --list without --export return them. The variables are merged following the Ansible precedence: the all group variables, the groups variables, sorted by depth, priority and name, the all group and groups variables loaded from the group_vars files, in the same order, and finally the host variables
+func (i *Inventory) MergedHostVars(name string) map[string]interface{} {
+	vars := map[string]interface{}{}
+
+	host := i.Host(name)
+	if host == nil {
+		return vars
+	}
+
+	merge := func(source map[string]interface{}) {
+		for key, value := range source {
+			vars[key] = value
+		}
+	}
+
+	groups := []*Group{}
+	for _, groupName := range i.GroupsOfHost(name) {
+		group := i.Group(groupName)
+		if group != nil && group.Name != AllGroup {
+			groups = append(groups, group)
+		}
+	}
+
+	depths := i.groupDepths()
+	sort.SliceStable(groups, func(a, b int) bool {
+		if depths[groups[a].Name] != depths[groups[b].Name] {
+			return depths[groups[a].Name] < depths[groups[b].Name]
+		}
+		if groupPriority(groups[a]) != groupPriority(groups[b]) {
+			return groupPriority(groups[a]) < groupPriority(groups[b])
+		}
+		return groups[a].Name < groups[b].Name
+	})
+
+	all := i.Group(AllGroup)
+	if all != nil {
+		merge(groupInventoryVars(all))
+	}
+	for _, group := range groups {
+		merge(groupInventoryVars(group))
+	}
+
+	if all != nil {
+		merge(all.fileVars)
+	}
+	for _, group := range groups {
+		merge(group.fileVars)
+	}
+
+	merge(host.Vars)
+
+	return vars
+}
+
+// groupDepths returns the depth of each group, which is the length of the longest path from the all group. The groups without parents are children of the all group
+func (i *Inventory) groupDepths() map[string]int {
+	parents := map[string][]string{}
+	for _, group := range i.groups {
+		for _, child := range group.Children {
+			parents[child] = append(parents[child], group.Name)
+		}
+	}
+
+	depths := map[string]int{
+		AllGroup: 0,
+	}
+
+	var depth func(name string, visiting map[string]struct{}) int
+	depth = func(name string, visiting map[string]struct{}) int {
+		if value, exists := depths[name]; exists {
+			return value
+		}
+
+		if _, exists := visiting[name]; exists {
+			return 0
+		}
+		visiting[name] = struct{}{}
+
+		value := 1
+		for _, parent := range parents[name] {
+			parentDepth := depth(parent, visiting)
+			if parentDepth+1 > value {
+				value = parentDepth + 1
+			}
+		}
+		depths[name] = value
+
+		return value
+	}
+
+	for _, group := range i.groups {
+		depth(group.Name, map[string]struct{}{})
+	}
+
+	return depths
+}
+
+// groupInventoryVars returns the variables of the group defined on the inventory, excluding the ones loaded from the group_vars files and the group priority
+func groupInventoryVars(group *Group) map[string]interface{} {
+	vars := map[string]interface{}{}
+	for key, value := range group.Vars {
+		if _, fromFile := group.fileVars[key]; fromFile {
+			continue
+		}
+		if key == groupPriorityVar {
+			continue
+		}
+		vars[key] = value
+	}
+
+	return vars
+}
+
+// groupPriority returns the priority of the group, which is 1 when it is not defined
+func groupPriority(group *Group) int {
+	value, exists := group.Vars[groupPriorityVar]
+	if _, fromFile := group.fileVars[groupPriorityVar]; !exists || fromFile {
+		return 1
+	}
+
+	priority, err := strconv.Atoi(fmt.Sprint(value))
+	if err != nil {
+		return 1
+	}
+
+	return priority
+}
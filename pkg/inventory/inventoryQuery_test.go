@@ -0,0 +1,152 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func queryTestInventory(t *testing.T) *Inventory {
+	inv, err := NewInventoryBuilder().
+		WithHost("web1", "web", "eu").
+		WithHost("web2", "web").
+		WithHost("db1", "db", "eu").
+		WithHost("bastion").
+		WithGroupChildren("prod", "web", "db").
+		WithGroupChildren("site", "prod").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return inv
+}
+
+func TestHostsInGroup(t *testing.T) {
+	tests := []struct {
+		desc     string
+		group    string
+		expected []string
+	}{
+		{
+			desc:     "Testing the hosts of a group without children",
+			group:    "web",
+			expected: []string{"web1", "web2"},
+		},
+		{
+			desc:     "Testing the hosts of a group through its children groups",
+			group:    "site",
+			expected: []string{"web1", "web2", "db1"},
+		},
+		{
+			desc:     "Testing the hosts of the all group",
+			group:    AllGroup,
+			expected: []string{"web1", "web2", "db1", "bastion"},
+		},
+		{
+			desc:     "Testing the hosts of an undefined group",
+			group:    "missing",
+			expected: []string{},
+		},
+	}
+
+	inv := queryTestInventory(t)
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expected, inv.HostsInGroup(test.group))
+		})
+	}
+}
+
+func TestGroupsOfHost(t *testing.T) {
+	tests := []struct {
+		desc     string
+		host     string
+		expected []string
+	}{
+		{
+			desc:     "Testing the groups of a host including the parent groups",
+			host:     "web1",
+			expected: []string{"web", "eu", "prod", "site", "all"},
+		},
+		{
+			desc:     "Testing the groups of a host without groups",
+			host:     "bastion",
+			expected: []string{"all"},
+		},
+		{
+			desc:     "Testing the groups of an undefined host",
+			host:     "missing",
+			expected: []string{},
+		},
+	}
+
+	inv := queryTestInventory(t)
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expected, inv.GroupsOfHost(test.host))
+		})
+	}
+}
+
+func TestMergedHostVars(t *testing.T) {
+	inv, err := NewInventoryBuilder().
+		WithHost("web1", "web", "canary", "blue").
+		WithHostVars("web1", map[string]interface{}{"host": "web1"}).
+		WithHost("bastion").
+		WithGroupChildren("prod", "web", "canary").
+		WithGroupVars(AllGroup, map[string]interface{}{"color": "none", "env": "dev", "user": "deploy"}).
+		WithGroupVars("prod", map[string]interface{}{"env": "prod", "color": "prod"}).
+		WithGroupVars("web", map[string]interface{}{"color": "web"}).
+		WithGroupVars("canary", map[string]interface{}{"color": "canary", groupPriorityVar: 10}).
+		WithGroupVars("blue", map[string]interface{}{"color": "blue"}).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc     string
+		host     string
+		expected map[string]interface{}
+	}{
+		{
+			desc: "Testing the variables of a host merged by group depth, priority and name",
+			host: "web1",
+			expected: map[string]interface{}{
+				"color": "canary",
+				"env":   "prod",
+				"user":  "deploy",
+				"host":  "web1",
+			},
+		},
+		{
+			desc: "Testing the variables of a host without groups",
+			host: "bastion",
+			expected: map[string]interface{}{
+				"color": "none",
+				"env":   "dev",
+				"user":  "deploy",
+			},
+		},
+		{
+			desc:     "Testing the variables of an undefined host",
+			host:     "missing",
+			expected: map[string]interface{}{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expected, inv.MergedHostVars(test.host))
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package inventory
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an inventory file format
+type Format string
+
+const (
+	// INIFormat is the format loaded by the ini inventory plugin
+	INIFormat Format = "ini"
+	// YAMLFormat is the format loaded by the yaml inventory plugin
+	YAMLFormat Format = "yaml"
+	// JSONFormat is the JSON representation of the YAMLFormat, which is also loaded by the yaml inventory plugin
+	JSONFormat Format = "json"
+	// TOMLFormat is the format written by ansible-inventory when the --toml flag is set. It is only parsed
+	TOMLFormat Format = "toml"
+)
+
+// Extension returns the file extension that makes Ansible load the format with the right inventory plugin
+func (f Format) Extension() string {
+	switch f {
+	case INIFormat:
+		return ".ini"
+	case JSONFormat:
+		return ".json"
+	case TOMLFormat:
+		return ".toml"
+	default:
+		return ".yml"
+	}
+}
+
+// Render validates the inventory and renders it in the given format
+func (i *Inventory) Render(format Format) ([]byte, error) {
+	errContext := "(inventory::Render)"
+
+	err := ValidateInventory(i)
+	if err != nil {
+		return nil, errors.New(errContext, "Error rendering the inventory", err)
+	}
+
+	switch format {
+	case INIFormat:
+		return i.renderINI()
+	case YAMLFormat:
+		return i.renderYAML()
+	case JSONFormat:
+		return i.renderJSON()
+	default:
+		return nil, errors.New(errContext, fmt.Sprintf("Unsupported inventory format '%s'", format))
+	}
+}
+
+// renderYAML renders the inventory tree in YAML format
+func (i *Inventory) renderYAML() ([]byte, error) {
+	buff := &bytes.Buffer{}
+
+	encoder := yaml.NewEncoder(buff)
+	encoder.SetIndent(2)
+
+	err := encoder.Encode(i.tree())
+	if err != nil {
+		return nil, errors.New("(inventory::renderYAML)", "Error encoding the inventory to YAML", err)
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return nil, errors.New("(inventory::renderYAML)", "Error encoding the inventory to YAML", err)
+	}
+
+	return buff.Bytes(), nil
+}
+
+// renderJSON renders the inventory tree in JSON format
+func (i *Inventory) renderJSON() ([]byte, error) {
+	content, err := json.Marshal(i.tree())
+	if err != nil {
+		return nil, errors.New("(inventory::renderJSON)", "Error encoding the inventory to JSON", err)
+	}
+
+	buff := &bytes.Buffer{}
+	err = json.Indent(buff, content, "", "  ")
+	if err != nil {
+		return nil, errors.New("(inventory::renderJSON)", "Error encoding the inventory to JSON", err)
+	}
+	buff.WriteString("\n")
+
+	return buff.Bytes(), nil
+}
+
+// tree returns the inventory structure loaded by the yaml inventory plugin. Every host is declared with its variables on the all group, and every group is a child of the all group that references its hosts and children
+func (i *Inventory) tree() *orderedMap {
+	all := newOrderedMap()
+
+	if len(i.hosts) > 0 {
+		hosts := newOrderedMap()
+		for _, host := range i.hosts {
+			hosts.Set(host.Name, nonNilVars(host.Vars))
+		}
+		all.Set("hosts", hosts)
+	}
+
+	allGroup := i.Group(AllGroup)
+	if allGroup != nil && len(allGroup.Vars) > 0 {
+		all.Set("vars", allGroup.Vars)
+	}
+
+	children := newOrderedMap()
+	for _, group := range i.groups {
+		if group.Name == AllGroup {
+			continue
+		}
+
+		groupTree := newOrderedMap()
+		if len(group.Hosts) > 0 {
+			hosts := newOrderedMap()
+			for _, host := range group.Hosts {
+				hosts.Set(host, map[string]interface{}{})
+			}
+			groupTree.Set("hosts", hosts)
+		}
+
+		if len(group.Vars) > 0 {
+			groupTree.Set("vars", group.Vars)
+		}
+
+		if len(group.Children) > 0 {
+			groupChildren := newOrderedMap()
+			for _, child := range group.Children {
+				groupChildren.Set(child, map[string]interface{}{})
+			}
+			groupTree.Set("children", groupChildren)
+		}
+
+		children.Set(group.Name, groupTree)
+	}
+
+	if len(children.keys) > 0 {
+		all.Set("children", children)
+	}
+
+	tree := newOrderedMap()
+	tree.Set(AllGroup, all)
+
+	return tree
+}
+
+// nonNilVars returns the variables, or an empty map when they are nil
+func nonNilVars(vars map[string]interface{}) map[string]interface{} {
+	if vars == nil {
+		return map[string]interface{}{}
+	}
+
+	return vars
+}
+
+// sortedKeys returns the sorted keys of the variables
+func sortedKeys(vars map[string]interface{}) []string {
+	keys := make([]string, 0, len(vars))
+	for key := range vars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// orderedMap is a map that is encoded to JSON and YAML keeping the order in which its keys are set
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// newOrderedMap returns an empty orderedMap
+func newOrderedMap() *orderedMap {
+	return &orderedMap{
+		keys:   []string{},
+		values: map[string]interface{}{},
+	}
+}
+
+// Set sets the value of the key
+func (m *orderedMap) Set(key string, value interface{}) {
+	if _, exists := m.values[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// MarshalJSON encodes the map to JSON keeping the keys order
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	buff := &bytes.Buffer{}
+	buff.WriteString("{")
+
+	for it, key := range m.keys {
+		if it > 0 {
+			buff.WriteString(",")
+		}
+
+		keyContent, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+
+		valueContent, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+
+		buff.Write(keyContent)
+		buff.WriteString(":")
+		buff.Write(valueContent)
+	}
+
+	buff.WriteString("}")
+
+	return buff.Bytes(), nil
+}
+
+// MarshalYAML encodes the map to a YAML mapping node keeping the keys order
+func (m *orderedMap) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{
+		Kind: yaml.MappingNode,
+	}
+
+	for _, key := range m.keys {
+		valueNode := &yaml.Node{}
+		err := valueNode.Encode(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+			valueNode,
+		)
+	}
+
+	return node, nil
+}
@@ -0,0 +1,168 @@
+package inventory
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+var (
+	// iniRawValueRegexp matches the strings that the ini inventory plugin loads as strings without quoting them
+	iniRawValueRegexp = regexp.MustCompile(`^([A-Za-z_/][A-Za-z0-9_./:@%-]*|[0-9]+\.[0-9]+(\.[0-9]+)+)$`)
+	// iniShellSafeRegexp matches the host variable tokens that are not broken by the shell-like split of the host line
+	iniShellSafeRegexp = regexp.MustCompile(`^[^\s'"\\#]*$`)
+	// iniPythonNames are the names that the ini inventory plugin does not load as strings
+	iniPythonNames = map[string]struct{}{"None": {}, "True": {}, "False": {}}
+)
+
+// renderINI renders the inventory in INI format. Every host is declared with its variables on the all group section, followed by the sections of every group
+func (i *Inventory) renderINI() ([]byte, error) {
+	errContext := "(inventory::renderINI)"
+
+	buff := &bytes.Buffer{}
+
+	if len(i.hosts) > 0 {
+		fmt.Fprintf(buff, "[%s]\n", AllGroup)
+		for _, host := range i.hosts {
+			line := host.Name
+			for _, key := range sortedKeys(host.Vars) {
+				value, err := iniValue(host.Vars[key])
+				if err != nil {
+					return nil, errors.New(errContext, fmt.Sprintf("Error rendering the variable '%s' of the host '%s'", key, host.Name), err)
+				}
+				line = fmt.Sprintf("%s %s", line, iniHostToken(key, value))
+			}
+			fmt.Fprintln(buff, line)
+		}
+	}
+
+	allGroup := i.Group(AllGroup)
+	if allGroup != nil && len(allGroup.Vars) > 0 {
+		err := writeINIVars(buff, allGroup)
+		if err != nil {
+			return nil, errors.New(errContext, "Error rendering the inventory", err)
+		}
+	}
+
+	for _, group := range i.groups {
+		if group.Name == AllGroup {
+			continue
+		}
+
+		writeINISectionHeader(buff, group.Name)
+		for _, host := range group.Hosts {
+			fmt.Fprintln(buff, host)
+		}
+
+		if len(group.Vars) > 0 {
+			err := writeINIVars(buff, group)
+			if err != nil {
+				return nil, errors.New(errContext, "Error rendering the inventory", err)
+			}
+		}
+
+		if len(group.Children) > 0 {
+			writeINISectionHeader(buff, group.Name+":children")
+			for _, child := range group.Children {
+				fmt.Fprintln(buff, child)
+			}
+		}
+	}
+
+	return buff.Bytes(), nil
+}
+
+// writeINISectionHeader writes a section header, separated from the previous section by an empty line
+func writeINISectionHeader(buff *bytes.Buffer, section string) {
+	if buff.Len() > 0 {
+		buff.WriteString("\n")
+	}
+	fmt.Fprintf(buff, "[%s]\n", section)
+}
+
+// writeINIVars writes the variables section of the group
+func writeINIVars(buff *bytes.Buffer, group *Group) error {
+	writeINISectionHeader(buff, group.Name+":vars")
+	for _, key := range sortedKeys(group.Vars) {
+		value, err := iniValue(group.Vars[key])
+		if err != nil {
+			return fmt.Errorf("error rendering the variable '%s' of the group '%s': %w", key, group.Name, err)
+		}
+		fmt.Fprintf(buff, "%s=%s\n", key, value)
+	}
+
+	return nil
+}
+
+// iniHostToken returns the key=value token of a host variable, quoted when the shell-like split of the host line would break it
+func iniHostToken(key, value string) string {
+	token := key + "=" + value
+	if iniShellSafeRegexp.MatchString(token) {
+		return token
+	}
+
+	return "'" + strings.ReplaceAll(token, "'", `'"'"'`) + "'"
+}
+
+// iniValue returns the value as the ini inventory plugin expects it. The plugin evaluates the values as python literals, so the strings are quoted unless they are always loaded as strings
+func iniValue(value interface{}) (string, error) {
+	// the values are normalized to the JSON types, keeping the numbers as they are written
+	content, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	var normalized interface{}
+	err = decoder.Decode(&normalized)
+	if err != nil {
+		return "", err
+	}
+
+	str, isString := normalized.(string)
+	if isString && iniRawValueRegexp.MatchString(str) {
+		if _, isName := iniPythonNames[str]; !isName {
+			return str, nil
+		}
+	}
+
+	return pythonLiteral(normalized), nil
+}
+
+// pythonLiteral returns the python literal of a value normalized to the JSON types
+func pythonLiteral(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "None"
+	case bool:
+		if v {
+			return "True"
+		}
+		return "False"
+	case json.Number:
+		return v.String()
+	case string:
+		return strconv.Quote(v)
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, pythonLiteral(item))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]interface{}:
+		items := make([]string, 0, len(v))
+		for _, key := range sortedKeys(v) {
+			items = append(items, strconv.Quote(key)+": "+pythonLiteral(v[key]))
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	default:
+		return strconv.Quote(fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,61 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestINIValue(t *testing.T) {
+	tests := []struct {
+		desc     string
+		value    interface{}
+		expected string
+	}{
+		{desc: "Testing a plain string", value: "deploy", expected: "deploy"},
+		{desc: "Testing a path", value: "/usr/bin/python3", expected: "/usr/bin/python3"},
+		{desc: "Testing an IP address", value: "10.0.0.1", expected: "10.0.0.1"},
+		{desc: "Testing a numeric string", value: "16", expected: `"16"`},
+		{desc: "Testing a python name string", value: "True", expected: `"True"`},
+		{desc: "Testing a string with spaces and quotes", value: `it's "quoted"`, expected: `"it's \"quoted\""`},
+		{desc: "Testing an integer", value: 22, expected: "22"},
+		{desc: "Testing a float", value: 1.5, expected: "1.5"},
+		{desc: "Testing a boolean", value: false, expected: "False"},
+		{desc: "Testing a null value", value: nil, expected: "None"},
+		{desc: "Testing a list", value: []interface{}{"a", 1, true}, expected: `["a", 1, True]`},
+		{desc: "Testing a map", value: map[string]interface{}{"b": "x", "a": []string{}}, expected: `{"a": [], "b": "x"}`},
+		{desc: "Testing a struct", value: struct{ Name string }{Name: "web"}, expected: `{"Name": "web"}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			value, err := iniValue(test.value)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, value)
+		})
+	}
+}
+
+func TestINIHostToken(t *testing.T) {
+	tests := []struct {
+		desc     string
+		key      string
+		value    string
+		expected string
+	}{
+		{desc: "Testing a token without special characters", key: "ansible_port", value: "22", expected: "ansible_port=22"},
+		{desc: "Testing a token with spaces", key: "args", value: `"-o A=b"`, expected: `'args="-o A=b"'`},
+		{desc: "Testing a token with single quotes", key: "note", value: `"it's"`, expected: `'note="it'"'"'s"'`},
+		{desc: "Testing a token with a comment character", key: "color", value: `"#fff"`, expected: `'color="#fff"'`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expected, iniHostToken(test.key, test.value))
+		})
+	}
+}
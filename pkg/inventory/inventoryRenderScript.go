@@ -0,0 +1,106 @@
+package inventory
+
+import (
+	"encoding/json"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// RenderScriptList validates the inventory and renders it in the JSON format returned by the dynamic inventory scripts when they are called with the --list argument. Every group is a top level key, the hosts that do not belong to any group are defined on the all group and the hosts variables are defined on the _meta section, which prevents the script from being called with the --host argument
+func (i *Inventory) RenderScriptList() ([]byte, error) {
+	errContext := "(inventory::RenderScriptList)"
+
+	err := ValidateInventory(i)
+	if err != nil {
+		return nil, errors.New(errContext, "Error rendering the inventory", err)
+	}
+
+	content, err := json.Marshal(i.scriptList())
+	if err != nil {
+		return nil, errors.New(errContext, "Error encoding the inventory to JSON", err)
+	}
+
+	return content, nil
+}
+
+// RenderScriptHost renders the variables of the host in the JSON format returned by the dynamic inventory scripts when they are called with the --host argument. The variables of an unknown host are empty
+func (i *Inventory) RenderScriptHost(name string) ([]byte, error) {
+	vars := map[string]interface{}{}
+
+	host := i.Host(name)
+	if host != nil {
+		vars = nonNilVars(host.Vars)
+	}
+
+	content, err := json.Marshal(vars)
+	if err != nil {
+		return nil, errors.New("(inventory::RenderScriptHost)", "Error encoding the host variables to JSON", err)
+	}
+
+	return content, nil
+}
+
+// scriptList returns the inventory structure loaded by the script inventory plugin. Every group always defines its hosts, because the plugin handles a group without hosts, vars or children keys as a host
+func (i *Inventory) scriptList() *orderedMap {
+	list := newOrderedMap()
+
+	all := &Group{Name: AllGroup}
+	if group := i.Group(AllGroup); group != nil {
+		all = group
+	}
+
+	grouped := map[string]bool{}
+	for _, group := range i.groups {
+		if group.Name == AllGroup {
+			continue
+		}
+
+		for _, host := range group.Hosts {
+			grouped[host] = true
+		}
+	}
+
+	allHosts := append([]string{}, all.Hosts...)
+	for _, host := range i.hosts {
+		if !grouped[host.Name] && !contains(all.Hosts, host.Name) {
+			allHosts = append(allHosts, host.Name)
+		}
+	}
+	list.Set(AllGroup, scriptGroup(allHosts, all.Vars, all.Children))
+
+	for _, group := range i.groups {
+		if group.Name == AllGroup {
+			continue
+		}
+
+		list.Set(group.Name, scriptGroup(group.Hosts, group.Vars, group.Children))
+	}
+
+	hostvars := newOrderedMap()
+	for _, host := range i.hosts {
+		hostvars.Set(host.Name, nonNilVars(host.Vars))
+	}
+
+	meta := newOrderedMap()
+	meta.Set(hostvarsKey, hostvars)
+	list.Set(metaKey, meta)
+
+	return list
+}
+
+// scriptGroup returns a group of the script inventory format
+func scriptGroup(hosts []string, vars map[string]interface{}, children []string) *orderedMap {
+	group := newOrderedMap()
+
+	group.Set(hostsKey, append([]string{}, hosts...))
+
+	if len(vars) > 0 {
+		group.Set(varsKey, vars)
+	}
+
+	if len(children) > 0 {
+		group.Set(childrenKey, children)
+	}
+
+	return group
+}
@@ -0,0 +1,110 @@
+package inventory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderScriptList(t *testing.T) {
+	tests := []struct {
+		desc      string
+		inventory *Inventory
+		expected  string
+		err       error
+	}{
+		{
+			desc: "Testing render the inventory in the inventory script format",
+			inventory: func() *Inventory {
+				inv := renderTestInventory(t)
+				inv.AddHost("lonely")
+				return inv
+			}(),
+			expected: `{"all":{"hosts":["lonely"],"vars":{"ntp":"pool.ntp.org"}},"web":{"hosts":["web1","web2"],"vars":{"http_port":80}},"db":{"hosts":["db1"]},"prod":{"hosts":[],"children":["web","db"]},"_meta":{"hostvars":{"web1":{"ansible_host":"10.0.0.1","ansible_port":2222},"web2":{},"db1":{"pg_version":"16","tags":["a","b c"]},"lonely":{}}}}`,
+		},
+		{
+			desc:      "Testing render an empty inventory in the inventory script format",
+			inventory: NewInventory(),
+			expected:  `{"all":{"hosts":[]},"_meta":{"hostvars":{}}}`,
+		},
+		{
+			desc: "Testing error rendering an invalid inventory in the inventory script format",
+			inventory: func() *Inventory {
+				inv := NewInventory()
+				inv.AddGroup("web").AddHost("web1")
+				return inv
+			}(),
+			err: errors.New("Error rendering the inventory\n Invalid inventory\n host 'web1' of the group 'web' is not defined"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			content, err := test.inventory.RenderScriptList()
+			if test.err != nil {
+				assert.EqualError(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, string(content))
+			}
+		})
+	}
+}
+
+func TestRenderScriptListParseList(t *testing.T) {
+	t.Log("Testing the inventory script format is parsed back into the same hosts, groups and variables")
+
+	inv := renderTestInventory(t)
+
+	content, err := inv.RenderScriptList()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := ParseList(content, JSONFormat)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"web1", "web2"}, parsed.HostsInGroup("web"))
+	assert.ElementsMatch(t, []string{"web1", "web2", "db1"}, parsed.HostsInGroup("prod"))
+	assert.Equal(t, map[string]interface{}{"ntp": "pool.ntp.org"}, parsed.Group(AllGroup).Vars)
+	assert.Equal(t, map[string]interface{}{"http_port": 80}, parsed.Group("web").Vars)
+	assert.Equal(t, map[string]interface{}{"ansible_host": "10.0.0.1", "ansible_port": 2222}, parsed.Host("web1").Vars)
+}
+
+func TestRenderScriptHost(t *testing.T) {
+	tests := []struct {
+		desc     string
+		host     string
+		expected string
+	}{
+		{
+			desc:     "Testing render the variables of a host in the inventory script format",
+			host:     "web1",
+			expected: `{"ansible_host":"10.0.0.1","ansible_port":2222}`,
+		},
+		{
+			desc:     "Testing render the variables of a host without variables in the inventory script format",
+			host:     "web2",
+			expected: `{}`,
+		},
+		{
+			desc:     "Testing render the variables of an unknown host in the inventory script format",
+			host:     "unknown",
+			expected: `{}`,
+		},
+	}
+
+	inv := renderTestInventory(t)
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			content, err := inv.RenderScriptHost(test.host)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, string(content))
+		})
+	}
+}
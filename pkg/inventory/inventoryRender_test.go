@@ -0,0 +1,191 @@
+package inventory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func renderTestInventory(t *testing.T) *Inventory {
+	inv, err := NewInventoryBuilder().
+		WithHost("web1", "web").
+		WithHostConnection("web1", ConnectionVars{Host: "10.0.0.1", Port: 2222}).
+		WithHost("web2", "web").
+		WithGroup("db", "db1").
+		WithHostVars("db1", map[string]interface{}{"pg_version": "16", "tags": []string{"a", "b c"}}).
+		WithGroupChildren("prod", "web", "db").
+		WithGroupVars("web", map[string]interface{}{"http_port": 80}).
+		WithGroupVars("all", map[string]interface{}{"ntp": "pool.ntp.org"}).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return inv
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		desc      string
+		inventory *Inventory
+		format    Format
+		expected  string
+		err       error
+	}{
+		{
+			desc:      "Testing render the inventory in INI format",
+			inventory: renderTestInventory(t),
+			format:    INIFormat,
+			expected: `[all]
+web1 ansible_host=10.0.0.1 ansible_port=2222
+web2
+db1 'pg_version="16"' 'tags=["a", "b c"]'
+
+[all:vars]
+ntp=pool.ntp.org
+
+[web]
+web1
+web2
+
+[web:vars]
+http_port=80
+
+[db]
+db1
+
+[prod]
+
+[prod:children]
+web
+db
+`,
+		},
+		{
+			desc:      "Testing render the inventory in YAML format",
+			inventory: renderTestInventory(t),
+			format:    YAMLFormat,
+			expected: `all:
+  hosts:
+    web1:
+      ansible_host: 10.0.0.1
+      ansible_port: 2222
+    web2: {}
+    db1:
+      pg_version: "16"
+      tags:
+        - a
+        - b c
+  vars:
+    ntp: pool.ntp.org
+  children:
+    web:
+      hosts:
+        web1: {}
+        web2: {}
+      vars:
+        http_port: 80
+    db:
+      hosts:
+        db1: {}
+    prod:
+      children:
+        web: {}
+        db: {}
+`,
+		},
+		{
+			desc:      "Testing render the inventory in JSON format",
+			inventory: renderTestInventory(t),
+			format:    JSONFormat,
+			expected: `{
+  "all": {
+    "hosts": {
+      "web1": {
+        "ansible_host": "10.0.0.1",
+        "ansible_port": 2222
+      },
+      "web2": {},
+      "db1": {
+        "pg_version": "16",
+        "tags": [
+          "a",
+          "b c"
+        ]
+      }
+    },
+    "vars": {
+      "ntp": "pool.ntp.org"
+    },
+    "children": {
+      "web": {
+        "hosts": {
+          "web1": {},
+          "web2": {}
+        },
+        "vars": {
+          "http_port": 80
+        }
+      },
+      "db": {
+        "hosts": {
+          "db1": {}
+        }
+      },
+      "prod": {
+        "children": {
+          "web": {},
+          "db": {}
+        }
+      }
+    }
+  }
+}
+`,
+		},
+		{
+			desc:      "Testing render an empty inventory in YAML format",
+			inventory: NewInventory(),
+			format:    YAMLFormat,
+			expected:  "all: {}\n",
+		},
+		{
+			desc:      "Testing error rendering the inventory in an unsupported format",
+			inventory: renderTestInventory(t),
+			format:    TOMLFormat,
+			err:       errors.New("Unsupported inventory format 'toml'"),
+		},
+		{
+			desc: "Testing error rendering an invalid inventory",
+			inventory: func() *Inventory {
+				inv := NewInventory()
+				inv.AddGroup("web").AddHost("web1")
+				return inv
+			}(),
+			format: YAMLFormat,
+			err:    errors.New("Error rendering the inventory\n Invalid inventory\n host 'web1' of the group 'web' is not defined"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			content, err := test.inventory.Render(test.format)
+			if test.err != nil {
+				assert.EqualError(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, string(content))
+			}
+		})
+	}
+}
+
+func TestFormatExtension(t *testing.T) {
+	assert.Equal(t, ".ini", INIFormat.Extension())
+	assert.Equal(t, ".yml", YAMLFormat.Extension())
+	assert.Equal(t, ".json", JSONFormat.Extension())
+	assert.Equal(t, ".toml", TOMLFormat.Extension())
+}
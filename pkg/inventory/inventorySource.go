@@ -0,0 +1,8 @@
+package inventory
+
+import "context"
+
+// InventorySource provides an Inventory computed from an external source, such as a Terraform state or an OpenSSH client configuration. The Inventory method has the signature of the dynamic inventory functions, so the executors serve any InventorySource to Ansible as a dynamic inventory
+type InventorySource interface {
+	Inventory(ctx context.Context) (*Inventory, error)
+}
@@ -0,0 +1,321 @@
+package inventory
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// GroupVarsDir is the directory, next to the inventory, that contains the groups variables files
+	GroupVarsDir = "group_vars"
+	// HostVarsDir is the directory, next to the inventory, that contains the hosts variables files
+	HostVarsDir = "host_vars"
+)
+
+var (
+	// yamlExtensions are the extensions of the YAML inventories and variables files. A file without extension is also accepted
+	yamlExtensions = []string{".yml", ".yaml", ".json"}
+	// ignoredInventoryFilesRegexp matches the files that Ansible ignores on an inventory directory
+	ignoredInventoryFilesRegexp = regexp.MustCompile(`^\.|^host_vars$|^group_vars$|^vars_plugins$|(\.pyc|\.pyo|\.swp|\.bak|~|\.rpm|\.md|\.txt|\.rst|\.orig|\.ini|\.cfg|\.retry)$`)
+)
+
+// LoadInventory loads a static inventory from a file or a directory, without calling ansible-inventory. The files with the .yml, .yaml or .json extensions are parsed as YAML inventories, the files without extension are parsed as YAML inventories or, when they are not, as INI inventories, and any other file is parsed as an INI inventory. The files of a directory are loaded in name order, ignoring the same files Ansible does. The variables of the group_vars and host_vars directories located next to the inventory file, or inside the inventory directory, are loaded too. The returned inventory follows the ansible-inventory --list --export structure
+func LoadInventory(path string) (*Inventory, error) {
+	errContext := "(inventory::LoadInventory)"
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error loading the inventory '%s'", path), err)
+	}
+
+	inventory := newStaticInventory()
+	basedir := path
+
+	if info.IsDir() {
+		err = loadInventoryDir(inventory, path)
+	} else {
+		basedir = filepath.Dir(path)
+		err = loadInventoryFile(inventory, path)
+	}
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error loading the inventory '%s'", path), err)
+	}
+
+	err = reconcileStaticInventory(inventory)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error loading the inventory '%s'", path), err)
+	}
+
+	err = loadVarsDirs(inventory, basedir)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error loading the variables of the inventory '%s'", path), err)
+	}
+
+	return inventory, nil
+}
+
+// loadInventoryDir loads the inventory files of a directory, recursively
+func loadInventoryDir(inventory *Inventory, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if ignoredInventoryFilesRegexp.MatchString(entry.Name()) {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		if entry.IsDir() {
+			err = loadInventoryDir(inventory, path)
+		} else {
+			err = loadInventoryFile(inventory, path)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadInventoryFile loads an inventory file, choosing the parser by its extension
+func loadInventoryFile(inventory *Inventory, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	extension := filepath.Ext(path)
+	if extension != "" && !contains(yamlExtensions, extension) {
+		err = parseINI(inventory, content)
+		if err != nil {
+			return fmt.Errorf("error parsing '%s' as an INI inventory: %w", path, err)
+		}
+		return nil
+	}
+
+	errYAML := parseYAML(inventory, content)
+	if errYAML == nil {
+		return nil
+	}
+
+	errINI := parseINI(inventory, content)
+	if errINI != nil {
+		return fmt.Errorf("error parsing '%s' as a YAML inventory: %w, or as an INI inventory: %w", path, errYAML, errINI)
+	}
+
+	return nil
+}
+
+// newStaticInventory returns an inventory with the all and ungrouped groups, which Ansible always defines
+func newStaticInventory() *Inventory {
+	inventory := NewInventory()
+	inventory.AddGroup(AllGroup).AddChild(UngroupedGroup)
+	inventory.AddGroup(UngroupedGroup)
+
+	return inventory
+}
+
+// addStaticHosts adds the hosts to the group, setting their port and variables
+func addStaticHosts(inventory *Inventory, groupname string, hosts []string, port int, vars map[string]interface{}) {
+	group := inventory.AddGroup(groupname)
+
+	for _, name := range hosts {
+		host := inventory.AddHost(name)
+		group.AddHost(name)
+
+		if port > 0 {
+			host.Vars[portVar] = port
+		}
+
+		for key, value := range vars {
+			host.Vars[key] = value
+		}
+	}
+}
+
+// reconcileStaticInventory applies the Ansible inventory rules once the sources are parsed. The groups without parents become children of the all group, the hosts without groups belong to the ungrouped group and the hosts that belong to other groups are removed from it. The membership to the all group is implicit, as on the ansible-inventory --list output
+func reconcileStaticInventory(inventory *Inventory) error {
+	cycle := findCycle(inventory)
+	if len(cycle) > 0 {
+		return fmt.Errorf("cyclic children groups '%s'", strings.Join(cycle, " -> "))
+	}
+
+	all := inventory.AddGroup(AllGroup)
+	ungrouped := inventory.AddGroup(UngroupedGroup)
+
+	parents := map[string]struct{}{}
+	for _, group := range inventory.groups {
+		for _, child := range group.Children {
+			parents[child] = struct{}{}
+		}
+	}
+
+	for _, group := range inventory.groups {
+		if _, exists := parents[group.Name]; !exists && group.Name != AllGroup {
+			all.AddChild(group.Name)
+		}
+	}
+
+	all.Hosts = []string{}
+
+	for _, host := range inventory.hosts {
+		grouped := false
+		for _, group := range inventory.GroupsOfHost(host.Name) {
+			if group != AllGroup && group != UngroupedGroup {
+				grouped = true
+				break
+			}
+		}
+
+		if grouped {
+			ungrouped.Hosts = removeItem(ungrouped.Hosts, host.Name)
+			continue
+		}
+		ungrouped.AddHost(host.Name)
+	}
+
+	return nil
+}
+
+// loadVarsDirs loads the variables of the group_vars and host_vars directories into the groups and hosts of the inventory
+func loadVarsDirs(inventory *Inventory, basedir string) error {
+	for _, group := range inventory.groups {
+		vars, err := loadVarsFiles(filepath.Join(basedir, GroupVarsDir), group.Name)
+		if err != nil {
+			return fmt.Errorf("error loading the variables of the group '%s': %w", group.Name, err)
+		}
+
+		if len(vars) == 0 {
+			continue
+		}
+
+		group.fileVars = vars
+		for key, value := range vars {
+			group.Vars[key] = value
+		}
+	}
+
+	for _, host := range inventory.hosts {
+		vars, err := loadVarsFiles(filepath.Join(basedir, HostVarsDir), host.Name)
+		if err != nil {
+			return fmt.Errorf("error loading the variables of the host '%s': %w", host.Name, err)
+		}
+
+		for key, value := range vars {
+			host.Vars[key] = value
+		}
+	}
+
+	return nil
+}
+
+// loadVarsFiles loads the variables of a group or host, defined on the file named after it, with or without a YAML extension, or on the files of the directory named after it
+func loadVarsFiles(dir, name string) (map[string]interface{}, error) {
+	vars := map[string]interface{}{}
+
+	files, err := findVarsFiles(dir, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := decodeYAML(content)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding the variables file '%s': %w", file, err)
+		}
+
+		switch fileVars := data.(type) {
+		case nil:
+		case map[string]interface{}:
+			for key, value := range fileVars {
+				vars[key] = value
+			}
+		default:
+			return nil, fmt.Errorf("variables file '%s' must be a map", file)
+		}
+	}
+
+	return vars, nil
+}
+
+// findVarsFiles returns the variables files of a group or host. The first existing path among the name, with or without a YAML extension, is used. When it is a directory, its files are used, recursively
+func findVarsFiles(dir, name string) ([]string, error) {
+	for _, extension := range append([]string{""}, yamlExtensions...) {
+		path := filepath.Join(dir, name+extension)
+
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+
+		if info.IsDir() {
+			return findDirVarsFiles(path)
+		}
+
+		return []string{path}, nil
+	}
+
+	return []string{}, nil
+}
+
+// findDirVarsFiles returns the variables files of a directory, recursively, skipping hidden and backup files and the files with extensions that are not YAML extensions
+func findDirVarsFiles(dir string) ([]string, error) {
+	files := []string{}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~") {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		extension := filepath.Ext(name)
+
+		if entry.IsDir() {
+			if extension == "" {
+				dirFiles, err := findDirVarsFiles(path)
+				if err != nil {
+					return nil, err
+				}
+				files = append(files, dirFiles...)
+			}
+			continue
+		}
+
+		if extension == "" || contains(yamlExtensions, extension) {
+			files = append(files, path)
+		}
+	}
+
+	return files, nil
+}
+
+// removeItem returns the list without the item
+func removeItem(list []string, item string) []string {
+	result := []string{}
+	for _, i := range list {
+		if i != item {
+			result = append(result, i)
+		}
+	}
+
+	return result
+}
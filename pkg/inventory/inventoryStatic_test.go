@@ -0,0 +1,182 @@
+package inventory
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+// comparableGroup is a group whose children are sorted, to compare inventories regardless of the groups order
+type comparableGroup struct {
+	Hosts    []string
+	Children []string
+	Vars     map[string]interface{}
+}
+
+// comparableInventory returns the groups and hosts of the inventory in a form that does not depend on the order of the groups. When withVars is false, the variables are not included
+func comparableInventory(inv *Inventory, withVars bool) (map[string]comparableGroup, map[string]map[string]interface{}) {
+	groups := map[string]comparableGroup{}
+	for _, group := range inv.Groups() {
+		children := append([]string{}, group.Children...)
+		sort.Strings(children)
+
+		item := comparableGroup{
+			Hosts:    group.Hosts,
+			Children: children,
+		}
+		if withVars {
+			item.Vars = group.Vars
+		}
+		groups[group.Name] = item
+	}
+
+	hosts := map[string]map[string]interface{}{}
+	for _, host := range inv.Hosts() {
+		hosts[host.Name] = map[string]interface{}{}
+		if withVars {
+			hosts[host.Name] = host.Vars
+		}
+	}
+
+	return groups, hosts
+}
+
+// readListFixture parses an ansible-inventory --list output fixture
+func readListFixture(t *testing.T, path string) *Inventory {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inv, err := ParseList(content, JSONFormat)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return inv
+}
+
+func TestLoadInventoryFixtures(t *testing.T) {
+	tests := []struct {
+		desc      string
+		inventory string
+		dir       string
+	}{
+		{
+			desc:      "Testing loading an INI inventory with group_vars and host_vars directories",
+			inventory: filepath.Join("test", "static", "ini", "hosts.ini"),
+			dir:       filepath.Join("test", "static", "ini"),
+		},
+		{
+			desc:      "Testing loading a YAML inventory with a host_vars directory",
+			inventory: filepath.Join("test", "static", "yaml", "hosts.yml"),
+			dir:       filepath.Join("test", "static", "yaml"),
+		},
+		{
+			desc:      "Testing loading an inventory directory",
+			inventory: filepath.Join("test", "static", "dir", "inventory"),
+			dir:       filepath.Join("test", "static", "dir"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			inv, err := LoadInventory(test.inventory)
+			if !assert.Nil(t, err) {
+				return
+			}
+
+			// ansible-inventory --list --export
+			export := readListFixture(t, filepath.Join(test.dir, "export.json"))
+			expectedGroups, expectedHosts := comparableInventory(export, true)
+			groups, hosts := comparableInventory(inv, true)
+			assert.Equal(t, expectedGroups, groups)
+			assert.Equal(t, expectedHosts, hosts)
+
+			// ansible-inventory --list
+			list := readListFixture(t, filepath.Join(test.dir, "list.json"))
+			expectedGroups, _ = comparableInventory(list, false)
+			groups, _ = comparableInventory(inv, false)
+			assert.Equal(t, expectedGroups, groups)
+			for _, host := range list.Hosts() {
+				assert.Equal(t, host.Vars, inv.MergedHostVars(host.Name), host.Name)
+			}
+		})
+	}
+}
+
+func TestLoadInventory(t *testing.T) {
+	dir := t.TempDir()
+
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		err := os.MkdirAll(filepath.Dir(path), 0700)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(path, []byte(content), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		desc     string
+		path     string
+		expected []string
+		err      error
+	}{
+		{
+			desc:     "Testing loading an INI inventory without extension",
+			path:     write("noext/hosts", "web1\n[db]\ndb1\n"),
+			expected: []string{"web1", "db1"},
+		},
+		{
+			desc:     "Testing loading a YAML inventory without extension",
+			path:     write("yamlnoext/hosts", "web:\n  hosts:\n    web1:\n"),
+			expected: []string{"web1"},
+		},
+		{
+			desc: "Testing loading an unexisting inventory",
+			path: filepath.Join(dir, "missing"),
+			err: errors.New("(inventory::LoadInventory)", "Error loading the inventory '"+filepath.Join(dir, "missing")+"'",
+				errors.New("", "stat "+filepath.Join(dir, "missing")+": no such file or directory")),
+		},
+		{
+			desc: "Testing loading an invalid YAML inventory",
+			path: write("invalid/hosts.yml", "- web1\n"),
+			err: errors.New("(inventory::LoadInventory)", "Error loading the inventory '"+filepath.Join(dir, "invalid", "hosts.yml")+"'",
+				errors.New("", "error parsing '"+filepath.Join(dir, "invalid", "hosts.yml")+"' as a YAML inventory: YAML inventory has invalid structure, it should be a dictionary, or as an INI inventory: line 1: expected key=value host variable assignment, got: web1")),
+		},
+		{
+			desc: "Testing loading an inventory with an invalid variables file",
+			path: func() string {
+				write("vars/group_vars/web.yml", "- http_port\n")
+				return write("vars/hosts.ini", "[web]\nweb1\n")
+			}(),
+			err: errors.New("(inventory::LoadInventory)", "Error loading the variables of the inventory '"+filepath.Join(dir, "vars", "hosts.ini")+"'",
+				errors.New("", "error loading the variables of the group 'web': variables file '"+filepath.Join(dir, "vars", "group_vars", "web.yml")+"' must be a map")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			inv, err := LoadInventory(test.path)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				assert.Equal(t, test.expected, inv.HostsInGroup(AllGroup))
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInventoryAddHost(t *testing.T) {
+	t.Log("Testing adding hosts to the inventory keeping their order")
+
+	inv := NewInventory()
+	web := inv.AddHost("web1")
+	web.Vars["http_port"] = 80
+	inv.AddHost("db1")
+
+	assert.Same(t, web, inv.AddHost("web1"))
+	assert.Equal(t, []*Host{
+		{Name: "web1", Vars: map[string]interface{}{"http_port": 80}},
+		{Name: "db1", Vars: map[string]interface{}{}},
+	}, inv.Hosts())
+	assert.Nil(t, inv.Host("missing"))
+}
+
+func TestInventoryAddGroup(t *testing.T) {
+	t.Log("Testing adding groups to the inventory keeping their order")
+
+	inv := NewInventory()
+	web := inv.AddGroup("web")
+	web.AddHost("web1", "web2", "web1")
+	prod := inv.AddGroup("prod")
+	prod.AddChild("web", "web")
+
+	assert.Same(t, web, inv.AddGroup("web"))
+	assert.Equal(t, []*Group{
+		{Name: "web", Hosts: []string{"web1", "web2"}, Children: []string{}, Vars: map[string]interface{}{}},
+		{Name: "prod", Hosts: []string{}, Children: []string{"web"}, Vars: map[string]interface{}{}},
+	}, inv.Groups())
+	assert.Nil(t, inv.Group("missing"))
+}
+
+func TestInventoryGroupsIncludeAll(t *testing.T) {
+	t.Log("Testing the groups of the inventory include the all and ungrouped groups without variables or hosts")
+
+	inv := newStaticInventory()
+
+	assert.Equal(t, []*Group{
+		{Name: AllGroup, Hosts: []string{}, Children: []string{UngroupedGroup}, Vars: map[string]interface{}{}},
+		{Name: UngroupedGroup, Hosts: []string{}, Children: []string{}, Vars: map[string]interface{}{}},
+	}, inv.Groups())
+}
+
+func TestInventoryHostGroups(t *testing.T) {
+	tests := []struct {
+		desc     string
+		host     string
+		expected []string
+	}{
+		{
+			desc:     "Testing the groups of a host that belongs to several groups",
+			host:     "web1",
+			expected: []string{"web", "eu"},
+		},
+		{
+			desc:     "Testing the groups of a host that belongs to no group",
+			host:     "db1",
+			expected: []string{},
+		},
+	}
+
+	inv := NewInventory()
+	inv.AddGroup("web").AddHost("web1")
+	inv.AddGroup("eu").AddHost("web1")
+	inv.AddHost("db1")
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expected, inv.HostGroups(test.host))
+		})
+	}
+}
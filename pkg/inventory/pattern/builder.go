@@ -0,0 +1,114 @@
+package pattern
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// Builder builds host patterns from terms, validating that the resulting pattern is parsed back into the same terms
+type Builder struct {
+	terms []Term
+}
+
+// NewBuilder returns an empty Builder
+func NewBuilder() *Builder {
+	return &Builder{
+		terms: []Term{},
+	}
+}
+
+// Union adds the terms whose hosts are added to the selection
+func (b *Builder) Union(terms ...Term) *Builder {
+	return b.add(UnionOperator, terms...)
+}
+
+// Intersection adds the terms whose hosts must also be matched to be selected
+func (b *Builder) Intersection(terms ...Term) *Builder {
+	return b.add(IntersectionOperator, terms...)
+}
+
+// Exclusion adds the terms whose hosts are removed from the selection
+func (b *Builder) Exclusion(terms ...Term) *Builder {
+	return b.add(ExclusionOperator, terms...)
+}
+
+// add adds the terms with the operator
+func (b *Builder) add(operator Operator, terms ...Term) *Builder {
+	for _, term := range terms {
+		term.Operator = operator
+		b.terms = append(b.terms, term)
+	}
+
+	return b
+}
+
+// Build validates the terms and returns the Pattern. Its String method returns a value that can be used as the Limit option
+func (b *Builder) Build() (*Pattern, error) {
+	errContext := "(pattern::Builder::Build)"
+
+	if len(b.terms) == 0 {
+		return nil, errors.New(errContext, "Pattern must have at least one term")
+	}
+
+	p := &Pattern{
+		Terms: []Term{},
+	}
+
+	for _, term := range b.terms {
+		err := validateTerm(term)
+		if err != nil {
+			return nil, errors.New(errContext, "Error building the pattern", err)
+		}
+		p.Terms = append(p.Terms, term)
+	}
+
+	return p, nil
+}
+
+// validateTerm returns an error when the term can not be written on a pattern without changing its meaning
+func validateTerm(term Term) error {
+	errContext := "(pattern::validateTerm)"
+
+	if term.File != "" {
+		if term.Operator != UnionOperator {
+			return errors.New(errContext, fmt.Sprintf("Invalid term '%s'. File terms can not be intersected or excluded", term.String()))
+		}
+
+		if strings.ContainsAny(term.File, ",\n") {
+			return errors.New(errContext, fmt.Sprintf("Invalid term '%s'. File path can not contain commas or new lines", term.String()))
+		}
+
+		return nil
+	}
+
+	if term.Expr == "" {
+		return errors.New(errContext, "Invalid term. Expression must be provided")
+	}
+
+	if strings.Contains(term.Expr, ",") || strings.IndexFunc(term.Expr, unicode.IsSpace) >= 0 {
+		return errors.New(errContext, fmt.Sprintf("Invalid term '%s'. Expression can not contain commas or whitespaces", term.String()))
+	}
+
+	if strings.ContainsAny(term.Expr[:1], string(IntersectionOperator)+string(ExclusionOperator)+regexPrefix+filePrefix) {
+		return errors.New(errContext, fmt.Sprintf("Invalid term '%s'. Expression can not start with '&', '!', '~' or '@'", term.String()))
+	}
+
+	if term.Regex && term.Subscript != nil {
+		return errors.New(errContext, fmt.Sprintf("Invalid term '%s'. Regular expressions can not have subscripts", term.String()))
+	}
+
+	parsed, err := parseTerm(term.String())
+	if err != nil {
+		return errors.New(errContext, fmt.Sprintf("Invalid term '%s'", term.String()), err)
+	}
+
+	if !reflect.DeepEqual(parsed, term) {
+		return errors.New(errContext, fmt.Sprintf("Invalid term '%s'. It is parsed as a different term", term.String()))
+	}
+
+	return nil
+}
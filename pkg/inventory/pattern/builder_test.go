@@ -0,0 +1,111 @@
+package pattern
+
+import (
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilderBuild(t *testing.T) {
+	tests := []struct {
+		desc     string
+		builder  *Builder
+		expected string
+		err      error
+	}{
+		{
+			desc: "Testing building a pattern",
+			builder: NewBuilder().
+				Union(Name("web"), Name("db").Index(0)).
+				Intersection(Glob("prod*")).
+				Exclusion(Regex("db[0-9]+"), Name("web[1]")).
+				Union(File("site.retry")),
+			expected: "web,db[0],&prod*,!~db[0-9]+,!web[[]1],@site.retry",
+		},
+		{
+			desc:    "Testing building a pattern without terms",
+			builder: NewBuilder(),
+			err:     errors.New("(pattern::Builder::Build)", "Pattern must have at least one term"),
+		},
+		{
+			desc:    "Testing building a pattern with a name that contains a comma",
+			builder: NewBuilder().Union(Name("web,db")),
+			err: errors.New("(pattern::Builder::Build)", "Error building the pattern",
+				errors.New("(pattern::validateTerm)", "Invalid term 'web,db'. Expression can not contain commas or whitespaces")),
+		},
+		{
+			desc:    "Testing building a pattern with a name that starts with an operator",
+			builder: NewBuilder().Union(Name("!web")),
+			err: errors.New("(pattern::Builder::Build)", "Error building the pattern",
+				errors.New("(pattern::validateTerm)", "Invalid term '!web'. Expression can not start with '&', '!', '~' or '@'")),
+		},
+		{
+			desc:    "Testing building a pattern with a glob that is parsed as a subscript",
+			builder: NewBuilder().Union(Glob("web[0]")),
+			err: errors.New("(pattern::Builder::Build)", "Error building the pattern",
+				errors.New("(pattern::validateTerm)", "Invalid term 'web[0]'. It is parsed as a different term")),
+		},
+		{
+			desc:    "Testing building a pattern with a regular expression with a subscript",
+			builder: NewBuilder().Union(Regex("web").Index(0)),
+			err: errors.New("(pattern::Builder::Build)", "Error building the pattern",
+				errors.New("(pattern::validateTerm)", "Invalid term '~web[0]'. Regular expressions can not have subscripts")),
+		},
+		{
+			desc:    "Testing building a pattern with an invalid regular expression",
+			builder: NewBuilder().Union(Regex("web(")),
+			err: errors.New("(pattern::Builder::Build)", "Error building the pattern",
+				errors.New("(pattern::validateTerm)", "Invalid term '~web('",
+					errors.New("(pattern::Term::matcher)", "Invalid host list pattern '~web('",
+						errors.New("", "error parsing regexp: missing closing ): `^(?:web()`")))),
+		},
+		{
+			desc:    "Testing building a pattern with a negative start of range",
+			builder: NewBuilder().Union(Name("web").Slice(-2, 1)),
+			err: errors.New("(pattern::Builder::Build)", "Error building the pattern",
+				errors.New("(pattern::validateTerm)", "Invalid term 'web[-2:1]'. It is parsed as a different term")),
+		},
+		{
+			desc:    "Testing building a pattern with an excluded file",
+			builder: NewBuilder().Exclusion(File("site.retry")),
+			err: errors.New("(pattern::Builder::Build)", "Error building the pattern",
+				errors.New("(pattern::validateTerm)", "Invalid term '@site.retry'. File terms can not be intersected or excluded")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			p, err := test.builder.Build()
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				assert.Equal(t, test.expected, p.String())
+			}
+		})
+	}
+}
+
+func TestBuilderRoundTrip(t *testing.T) {
+	inv := selectionTestInventory(t)
+
+	p, err := NewBuilder().
+		Union(Name("prod"), Regex("app1")).
+		Intersection(Name("eu")).
+		Exclusion(Name("db1")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := Parse(p.String())
+	assert.Nil(t, err)
+	assert.Equal(t, p, parsed)
+
+	selection, err := parsed.Select(inv)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"web1"}, selection.Hosts)
+}
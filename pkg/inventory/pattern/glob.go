@@ -0,0 +1,99 @@
+package pattern
+
+import (
+	"regexp"
+	"strings"
+)
+
+// globSpecialChars are the characters that make a pattern to be matched against the hosts, even when it matches groups
+const globSpecialChars = ".?*["
+
+// globMetaChars are the characters with a special meaning on a glob
+const globMetaChars = "*?["
+
+// globToRegexp translates a glob, following the python fnmatch rules, to an anchored regular expression
+func globToRegexp(glob string) string {
+	buff := &strings.Builder{}
+	buff.WriteString("^(?s:")
+
+	runes := []rune(glob)
+	n := len(runes)
+	for i := 0; i < n; i++ {
+		c := runes[i]
+		switch c {
+		case '*':
+			buff.WriteString(".*")
+		case '?':
+			buff.WriteString(".")
+		case '[':
+			j := i + 1
+			if j < n && runes[j] == '!' {
+				j++
+			}
+			if j < n && runes[j] == ']' {
+				j++
+			}
+			for j < n && runes[j] != ']' {
+				j++
+			}
+
+			// an unclosed bracket is a literal bracket
+			if j >= n {
+				buff.WriteString(`\[`)
+				continue
+			}
+
+			buff.WriteString(globClass(runes[i+1 : j]))
+			i = j
+		default:
+			buff.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	buff.WriteString(")$")
+
+	return buff.String()
+}
+
+// globClass translates the content of a glob bracket expression to a regular expression character class
+func globClass(content []rune) string {
+	buff := &strings.Builder{}
+	buff.WriteString("[")
+
+	if len(content) > 0 && content[0] == '!' {
+		buff.WriteString("^")
+		content = content[1:]
+	}
+
+	for _, c := range content {
+		switch c {
+		case '\\', '[', ']', '^':
+			buff.WriteString(`\`)
+			buff.WriteRune(c)
+		default:
+			buff.WriteRune(c)
+		}
+	}
+
+	buff.WriteString("]")
+
+	return buff.String()
+}
+
+// escapeGlob returns a glob that only matches the name. The glob metacharacters are enclosed on bracket expressions
+func escapeGlob(name string) string {
+	if !strings.ContainsAny(name, globMetaChars) {
+		return name
+	}
+
+	buff := &strings.Builder{}
+	for _, c := range name {
+		if strings.ContainsRune(globMetaChars, c) {
+			buff.WriteString("[" + string(c) + "]")
+			continue
+		}
+		buff.WriteRune(c)
+	}
+
+	return buff.String()
+}
@@ -0,0 +1,103 @@
+package pattern
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobToRegexp(t *testing.T) {
+	tests := []struct {
+		desc        string
+		glob        string
+		matching    []string
+		notMatching []string
+	}{
+		{
+			desc:        "Testing a glob without metacharacters",
+			glob:        "web1.example.com",
+			matching:    []string{"web1.example.com"},
+			notMatching: []string{"web1Xexample.com", "web1.example.com.ar"},
+		},
+		{
+			desc:        "Testing a glob with a star",
+			glob:        "db*",
+			matching:    []string{"db", "db1", "db-prod"},
+			notMatching: []string{"xdb1"},
+		},
+		{
+			desc:        "Testing a glob with a question mark",
+			glob:        "web?",
+			matching:    []string{"web1", "webX"},
+			notMatching: []string{"web", "web10"},
+		},
+		{
+			desc:        "Testing a glob with a bracket expression",
+			glob:        "web[1-3]",
+			matching:    []string{"web1", "web3"},
+			notMatching: []string{"web4", "web[1-3]"},
+		},
+		{
+			desc:        "Testing a glob with a negated bracket expression",
+			glob:        "web[!1]",
+			matching:    []string{"web2"},
+			notMatching: []string{"web1"},
+		},
+		{
+			desc:        "Testing a glob with an unclosed bracket",
+			glob:        "web[1",
+			matching:    []string{"web[1"},
+			notMatching: []string{"web1"},
+		},
+		{
+			desc:        "Testing a glob with a bracket expression that contains special characters",
+			glob:        `web[]\^]`,
+			matching:    []string{"web]", `web\`, "web^"},
+			notMatching: []string{"web1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			re := regexp.MustCompile(globToRegexp(test.glob))
+			for _, name := range test.matching {
+				assert.True(t, re.MatchString(name), name)
+			}
+			for _, name := range test.notMatching {
+				assert.False(t, re.MatchString(name), name)
+			}
+		})
+	}
+}
+
+func TestEscapeGlob(t *testing.T) {
+	tests := []struct {
+		desc     string
+		name     string
+		expected string
+	}{
+		{
+			desc:     "Testing a name without metacharacters",
+			name:     "web1.example.com",
+			expected: "web1.example.com",
+		},
+		{
+			desc:     "Testing a name with metacharacters",
+			name:     "web*[1]?",
+			expected: "web[*][[]1][?]",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			escaped := escapeGlob(test.name)
+			assert.Equal(t, test.expected, escaped)
+			assert.True(t, regexp.MustCompile(globToRegexp(escaped)).MatchString(test.name))
+		})
+	}
+}
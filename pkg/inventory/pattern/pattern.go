@@ -0,0 +1,336 @@
+package pattern
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// Operator defines how the hosts matched by a term are combined with the hosts matched by the previous terms
+type Operator string
+
+const (
+	// UnionOperator adds the hosts matched by the term
+	UnionOperator Operator = ""
+	// IntersectionOperator keeps only the hosts that are also matched by the term
+	IntersectionOperator Operator = "&"
+	// ExclusionOperator removes the hosts matched by the term
+	ExclusionOperator Operator = "!"
+)
+
+const (
+	// regexPrefix is the prefix of the terms that are regular expressions
+	regexPrefix = "~"
+	// filePrefix is the prefix of the limit terms that refer to a file of patterns, like the retry files
+	filePrefix = "@"
+)
+
+var (
+	// termsRegexp matches the terms of a colon-separated pattern, which are sequences of characters other than whitespaces, colons and brackets, or complete bracket expressions
+	termsRegexp = regexp.MustCompile(`(?:[^\s:\[\]]|\[[^\]]*\])+`)
+	// hostPortRegexp matches a host followed by a port, which is a single term
+	hostPortRegexp = regexp.MustCompile(`^[A-Za-z0-9._-]+:[0-9]+$`)
+	// subscriptRegexp matches a term followed by an index, [x], or a range, [x:y] or [x:], subscript
+	subscriptRegexp = regexp.MustCompile(`^(.+)\[(?:(-?[0-9]+)|([0-9]+)[:-]([0-9]*))\]$`)
+)
+
+// Subscript selects a subset of the hosts matched by a term, by their position
+type Subscript struct {
+	// Start is the index of the first selected host. A negative index counts from the end
+	Start int
+	// End is the index of the last selected host, inclusive. A negative value selects up to the last host
+	End int
+	// Range is true when the subscript selects the hosts from Start to End, otherwise it only selects the host at Start
+	Range bool
+}
+
+// String returns the subscript as it is written on a pattern
+func (s *Subscript) String() string {
+	if !s.Range {
+		return fmt.Sprintf("[%d]", s.Start)
+	}
+
+	if s.End < 0 {
+		return fmt.Sprintf("[%d:]", s.Start)
+	}
+
+	return fmt.Sprintf("[%d:%d]", s.Start, s.End)
+}
+
+// Term is a single element of a host pattern
+type Term struct {
+	// Operator defines how the term is combined with the other terms
+	Operator Operator
+	// Expr is the glob that matches the groups and hosts names or, when Regex is true, the regular expression
+	Expr string
+	// Regex is true when Expr is a regular expression
+	Regex bool
+	// Subscript selects a subset of the matched hosts
+	Subscript *Subscript
+	// File is the path of a file of patterns, one per line. It is only allowed on limits
+	File string
+}
+
+// Name returns a term that only matches the group or host with the given name
+func Name(name string) Term {
+	return Term{Expr: escapeGlob(name)}
+}
+
+// Glob returns a term that matches the groups and hosts names with the glob
+func Glob(glob string) Term {
+	return Term{Expr: glob}
+}
+
+// Regex returns a term that matches the groups and hosts names with the regular expression. As in Ansible, the regular expression is anchored at the beginning of the names
+func Regex(expr string) Term {
+	return Term{Expr: expr, Regex: true}
+}
+
+// File returns a limit term that includes the patterns of the file, one per line, such as an Ansible retry file
+func File(path string) Term {
+	return Term{File: path}
+}
+
+// Index returns a copy of the term that only selects the matched host at the index
+func (t Term) Index(index int) Term {
+	t.Subscript = &Subscript{Start: index}
+	return t
+}
+
+// Slice returns a copy of the term that selects the matched hosts from start to end, both inclusive
+func (t Term) Slice(start, end int) Term {
+	t.Subscript = &Subscript{Start: start, End: end, Range: true}
+	return t
+}
+
+// From returns a copy of the term that selects the matched hosts from start to the last one
+func (t Term) From(start int) Term {
+	t.Subscript = &Subscript{Start: start, End: -1, Range: true}
+	return t
+}
+
+// String returns the term as it is written on a pattern
+func (t Term) String() string {
+	if t.File != "" {
+		return filePrefix + t.File
+	}
+
+	str := string(t.Operator)
+	if t.Regex {
+		str = str + regexPrefix
+	}
+	str = str + t.Expr
+	if t.Subscript != nil {
+		str = str + t.Subscript.String()
+	}
+
+	return str
+}
+
+// matcher returns the regular expression that matches the names selected by the term
+func (t Term) matcher() (*regexp.Regexp, error) {
+	errContext := "(pattern::Term::matcher)"
+
+	expr := globToRegexp(t.Expr)
+	if t.Regex {
+		expr = "^(?:" + t.Expr + ")"
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Invalid host list pattern '%s'", t.String()), err)
+	}
+
+	return re, nil
+}
+
+// Pattern is a host pattern, such as the hosts of a play or the Limit option
+type Pattern struct {
+	// Terms are the elements of the pattern, in the order they are written
+	Terms []Term
+}
+
+// String returns the pattern as a comma-separated list of terms, which is a valid value for the Limit option. A single term that would be split on its colons is followed by a comma
+func (p *Pattern) String() string {
+	terms := make([]string, 0, len(p.Terms))
+	for _, term := range p.Terms {
+		terms = append(terms, term.String())
+	}
+
+	str := strings.Join(terms, ",")
+	if len(terms) == 1 && len(splitPattern(str)) != 1 {
+		str = str + ","
+	}
+
+	return str
+}
+
+// Parse parses a host pattern. As in Ansible, the terms are separated by commas or, when there are no commas, by colons, which are kept on IPv6 addresses, host ports and bracket expressions
+func Parse(pattern string) (*Pattern, error) {
+	errContext := "(pattern::Parse)"
+
+	p := &Pattern{
+		Terms: []Term{},
+	}
+
+	for _, str := range splitPattern(pattern) {
+		term, err := parseTerm(str)
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Error parsing the host pattern '%s'", pattern), err)
+		}
+		p.Terms = append(p.Terms, term)
+	}
+
+	return p, nil
+}
+
+// ParseLimit parses a limit pattern. In addition to Parse, the terms prefixed by '@' are replaced by the patterns of the file they refer to, one per line
+func ParseLimit(limit string) (*Pattern, error) {
+	errContext := "(pattern::ParseLimit)"
+
+	p := &Pattern{
+		Terms: []Term{},
+	}
+
+	for _, str := range splitPattern(limit) {
+		if strings.HasPrefix(str, filePrefix) {
+			terms, err := readFile(strings.TrimPrefix(str, filePrefix))
+			if err != nil {
+				return nil, errors.New(errContext, fmt.Sprintf("Error parsing the limit '%s'", limit), err)
+			}
+			p.Terms = append(p.Terms, terms...)
+			continue
+		}
+
+		term, err := parseTerm(str)
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Error parsing the limit '%s'", limit), err)
+		}
+		p.Terms = append(p.Terms, term)
+	}
+
+	return p, nil
+}
+
+// readFile returns the terms defined on a limit file, one per line
+func readFile(path string) ([]Term, error) {
+	errContext := "(pattern::readFile)"
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Unable to find limit file '%s'", path), err)
+	}
+
+	if info.IsDir() {
+		return nil, errors.New(errContext, fmt.Sprintf("Limit starting with '@' must be a file, not a directory: '%s'", path))
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error reading limit file '%s'", path), err)
+	}
+
+	terms := []Term{}
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		term, err := parseTerm(line)
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Error parsing limit file '%s'", path), err)
+		}
+		terms = append(terms, term)
+	}
+
+	return terms, nil
+}
+
+// splitPattern splits a pattern into its terms
+func splitPattern(pattern string) []string {
+	var items []string
+
+	switch {
+	case strings.Contains(pattern, ","):
+		items = strings.Split(pattern, ",")
+	case isSingleAddress(strings.TrimSpace(pattern)):
+		items = []string{pattern}
+	default:
+		items = termsRegexp.FindAllString(pattern, -1)
+	}
+
+	terms := []string{}
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			terms = append(terms, item)
+		}
+	}
+
+	return terms
+}
+
+// isSingleAddress returns whether the pattern is an IPv6 address or a host followed by a port, whose colons are not separators
+func isSingleAddress(pattern string) bool {
+	if strings.Contains(pattern, ":") && net.ParseIP(strings.Trim(pattern, "[]")) != nil {
+		return true
+	}
+
+	return hostPortRegexp.MatchString(pattern)
+}
+
+// parseTerm parses a single term of a pattern
+func parseTerm(str string) (Term, error) {
+	errContext := "(pattern::parseTerm)"
+
+	term := Term{}
+
+	switch {
+	case strings.HasPrefix(str, string(IntersectionOperator)):
+		term.Operator = IntersectionOperator
+	case strings.HasPrefix(str, string(ExclusionOperator)):
+		term.Operator = ExclusionOperator
+	}
+	str = strings.TrimPrefix(str, string(term.Operator))
+
+	if str == "" {
+		return term, errors.New(errContext, fmt.Sprintf("Empty term after the '%s' operator", term.Operator))
+	}
+
+	// regular expressions are not parsed for subscripts
+	if strings.HasPrefix(str, regexPrefix) {
+		term.Regex = true
+		term.Expr = strings.TrimPrefix(str, regexPrefix)
+		_, err := term.matcher()
+		return term, err
+	}
+
+	term.Expr = str
+
+	matches := subscriptRegexp.FindStringSubmatch(str)
+	if matches != nil {
+		term.Expr = matches[1]
+		subscript := &Subscript{}
+
+		if matches[2] != "" {
+			subscript.Start, _ = strconv.Atoi(matches[2])
+		} else {
+			subscript.Range = true
+			subscript.Start, _ = strconv.Atoi(matches[3])
+			subscript.End = -1
+			if matches[4] != "" {
+				subscript.End, _ = strconv.Atoi(matches[4])
+			}
+		}
+
+		term.Subscript = subscript
+	}
+
+	return term, nil
+}
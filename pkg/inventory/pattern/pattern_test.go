@@ -0,0 +1,217 @@
+package pattern
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		desc     string
+		pattern  string
+		expected *Pattern
+		err      error
+	}{
+		{
+			desc:    "Testing parsing a colon-separated pattern",
+			pattern: "web:&prod:!db*:~app[0-9]+",
+			expected: &Pattern{
+				Terms: []Term{
+					{Expr: "web"},
+					{Operator: IntersectionOperator, Expr: "prod"},
+					{Operator: ExclusionOperator, Expr: "db*"},
+					{Expr: "app[0-9]+", Regex: true},
+				},
+			},
+		},
+		{
+			desc:    "Testing parsing a comma-separated pattern with whitespaces",
+			pattern: " web , &prod,,!db1 ",
+			expected: &Pattern{
+				Terms: []Term{
+					{Expr: "web"},
+					{Operator: IntersectionOperator, Expr: "prod"},
+					{Operator: ExclusionOperator, Expr: "db1"},
+				},
+			},
+		},
+		{
+			desc:    "Testing parsing subscripts",
+			pattern: "web[0]:db[-1]:app[1:3]:lb[2:]:cache[0-1]",
+			expected: &Pattern{
+				Terms: []Term{
+					{Expr: "web", Subscript: &Subscript{Start: 0}},
+					{Expr: "db", Subscript: &Subscript{Start: -1}},
+					{Expr: "app", Subscript: &Subscript{Start: 1, End: 3, Range: true}},
+					{Expr: "lb", Subscript: &Subscript{Start: 2, End: -1, Range: true}},
+					{Expr: "cache", Subscript: &Subscript{Start: 0, End: 1, Range: true}},
+				},
+			},
+		},
+		{
+			desc:    "Testing parsing a glob with a bracket expression",
+			pattern: "web[a-c]",
+			expected: &Pattern{
+				Terms: []Term{
+					{Expr: "web[a-c]"},
+				},
+			},
+		},
+		{
+			desc:    "Testing parsing an IPv6 address",
+			pattern: "fe80::1",
+			expected: &Pattern{
+				Terms: []Term{
+					{Expr: "fe80::1"},
+				},
+			},
+		},
+		{
+			desc:    "Testing parsing a host followed by a port",
+			pattern: "web1:2222",
+			expected: &Pattern{
+				Terms: []Term{
+					{Expr: "web1:2222"},
+				},
+			},
+		},
+		{
+			desc:    "Testing parsing a pattern with an invalid regular expression",
+			pattern: "~web(",
+			err: errors.New("(pattern::Parse)", "Error parsing the host pattern '~web('",
+				errors.New("(pattern::Term::matcher)", "Invalid host list pattern '~web('",
+					errors.New("", "error parsing regexp: missing closing ): `^(?:web()`"))),
+		},
+		{
+			desc:    "Testing parsing a pattern with an empty term",
+			pattern: "web,!",
+			err: errors.New("(pattern::Parse)", "Error parsing the host pattern 'web,!'",
+				errors.New("(pattern::parseTerm)", "Empty term after the '!' operator")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			p, err := Parse(test.pattern)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				assert.Equal(t, test.expected, p)
+			}
+		})
+	}
+}
+
+func TestParseLimit(t *testing.T) {
+	dir := t.TempDir()
+	retry := filepath.Join(dir, "site.retry")
+	err := os.WriteFile(retry, []byte("web1\n\n  db1  \n!web2\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc     string
+		limit    string
+		expected *Pattern
+		err      error
+	}{
+		{
+			desc:  "Testing parsing a limit with a file",
+			limit: "web:@" + retry,
+			expected: &Pattern{
+				Terms: []Term{
+					{Expr: "web"},
+					{Expr: "web1"},
+					{Expr: "db1"},
+					{Operator: ExclusionOperator, Expr: "web2"},
+				},
+			},
+		},
+		{
+			desc:  "Testing parsing a limit with an unexisting file",
+			limit: "@" + filepath.Join(dir, "missing.retry"),
+			err: errors.New("(pattern::ParseLimit)", "Error parsing the limit '@"+filepath.Join(dir, "missing.retry")+"'",
+				errors.New("(pattern::readFile)", "Unable to find limit file '"+filepath.Join(dir, "missing.retry")+"'",
+					errors.New("", "stat "+filepath.Join(dir, "missing.retry")+": no such file or directory"))),
+		},
+		{
+			desc:  "Testing parsing a limit with a directory",
+			limit: "@" + dir,
+			err: errors.New("(pattern::ParseLimit)", "Error parsing the limit '@"+dir+"'",
+				errors.New("(pattern::readFile)", "Limit starting with '@' must be a file, not a directory: '"+dir+"'")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			p, err := ParseLimit(test.limit)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				assert.Equal(t, test.expected, p)
+			}
+		})
+	}
+}
+
+func TestPatternString(t *testing.T) {
+	tests := []struct {
+		desc     string
+		pattern  *Pattern
+		expected string
+	}{
+		{
+			desc: "Testing the string of a pattern with several terms",
+			pattern: &Pattern{
+				Terms: []Term{
+					Name("web"),
+					{Operator: IntersectionOperator, Expr: "prod"},
+					{Operator: ExclusionOperator, Expr: "db*"},
+					Regex("app[0-9]+"),
+					Name("lb").Slice(0, 1),
+					Name("cache").From(2),
+					Name("queue").Index(-1),
+					File("site.retry"),
+				},
+			},
+			expected: "web,&prod,!db*,~app[0-9]+,lb[0:1],cache[2:],queue[-1],@site.retry",
+		},
+		{
+			desc: "Testing the string of a single term with colons",
+			pattern: &Pattern{
+				Terms: []Term{
+					Regex("web:db"),
+				},
+			},
+			expected: "~web:db,",
+		},
+		{
+			desc: "Testing the string of an IPv6 address",
+			pattern: &Pattern{
+				Terms: []Term{
+					Name("fe80::1"),
+				},
+			},
+			expected: "fe80::1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expected, test.pattern.String())
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package pattern
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apenella/go-ansible/v2/pkg/inventory"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// localhostNames are the names that match the implicit localhost when no group or host matches them
+var localhostNames = []string{"localhost", "127.0.0.1", "::1"}
+
+// Selection is the result of evaluating a pattern over an inventory
+type Selection struct {
+	// Hosts are the names of the selected hosts, in the order Ansible targets them
+	Hosts []string
+	// Unmatched are the terms that do not match any group or host, for which Ansible warns that they are ignored
+	Unmatched []string
+}
+
+// Select returns the hosts of the inventory selected by the pattern. As in Ansible, the union terms are evaluated first, then the intersection terms and finally the exclusion terms. When the pattern has no union terms, it starts from the all group
+func (p *Pattern) Select(inv *inventory.Inventory) (*Selection, error) {
+	errContext := "(pattern::Select)"
+
+	if inv == nil {
+		return nil, errors.New(errContext, "Inventory must be provided to select hosts")
+	}
+
+	selection, err := evaluate(inv, p.Terms)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error evaluating the host pattern '%s'", p.String()), err)
+	}
+
+	return selection, nil
+}
+
+// SelectWithLimit returns the hosts of the inventory selected by the hosts pattern, such as the hosts of a play, that are also selected by the limit pattern. A nil or empty limit does not restrict the selection
+func SelectWithLimit(inv *inventory.Inventory, hosts, limit *Pattern) (*Selection, error) {
+	errContext := "(pattern::SelectWithLimit)"
+
+	if hosts == nil {
+		return nil, errors.New(errContext, "Hosts pattern must be provided to select hosts")
+	}
+
+	selection, err := hosts.Select(inv)
+	if err != nil {
+		return nil, errors.New(errContext, "Error selecting the hosts", err)
+	}
+
+	if limit == nil || len(limit.Terms) == 0 {
+		return selection, nil
+	}
+
+	limited, err := limit.Select(inv)
+	if err != nil {
+		return nil, errors.New(errContext, "Error selecting the limited hosts", err)
+	}
+
+	hostsInLimit := []string{}
+	for _, host := range selection.Hosts {
+		if contains(limited.Hosts, host) {
+			hostsInLimit = append(hostsInLimit, host)
+		}
+	}
+
+	selection.Hosts = hostsInLimit
+	for _, term := range limited.Unmatched {
+		if !contains(selection.Unmatched, term) {
+			selection.Unmatched = append(selection.Unmatched, term)
+		}
+	}
+
+	return selection, nil
+}
+
+// evaluate combines the hosts matched by each term
+func evaluate(inv *inventory.Inventory, terms []Term) (*Selection, error) {
+	errContext := "(pattern::evaluate)"
+
+	selection := &Selection{
+		Hosts:     []string{},
+		Unmatched: []string{},
+	}
+
+	for _, term := range orderTerms(terms) {
+		if term.File != "" {
+			return nil, errors.New(errContext, fmt.Sprintf("File term '%s' is only allowed on limits and must be parsed by ParseLimit", term.String()))
+		}
+
+		// a term that is the name of a host selects that host
+		if term.Operator == UnionOperator && !term.Regex && term.Subscript == nil && inv.Host(term.Expr) != nil {
+			if !contains(selection.Hosts, term.Expr) {
+				selection.Hosts = append(selection.Hosts, term.Expr)
+			}
+			continue
+		}
+
+		matched, found, err := match(inv, term)
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Error matching the term '%s'", term.String()), err)
+		}
+
+		if !found {
+			selection.Unmatched = append(selection.Unmatched, term.String())
+		}
+
+		switch term.Operator {
+		case IntersectionOperator:
+			selection.Hosts = filter(selection.Hosts, func(host string) bool { return contains(matched, host) })
+		case ExclusionOperator:
+			selection.Hosts = filter(selection.Hosts, func(host string) bool { return !contains(matched, host) })
+		default:
+			for _, host := range matched {
+				if !contains(selection.Hosts, host) {
+					selection.Hosts = append(selection.Hosts, host)
+				}
+			}
+		}
+	}
+
+	return selection, nil
+}
+
+// orderTerms returns the union terms followed by the intersection and the exclusion terms. When there are no union terms, the all group is used
+func orderTerms(terms []Term) []Term {
+	unions := []Term{}
+	intersections := []Term{}
+	exclusions := []Term{}
+
+	for _, term := range terms {
+		switch term.Operator {
+		case IntersectionOperator:
+			intersections = append(intersections, term)
+		case ExclusionOperator:
+			exclusions = append(exclusions, term)
+		default:
+			unions = append(unions, term)
+		}
+	}
+
+	if len(unions) == 0 {
+		unions = append(unions, Name(inventory.AllGroup))
+	}
+
+	ordered := append(unions, intersections...)
+	return append(ordered, exclusions...)
+}
+
+// match returns the hosts matched by a single term, ignoring its operator, and whether the term matches any group or host
+func match(inv *inventory.Inventory, term Term) ([]string, bool, error) {
+	errContext := "(pattern::match)"
+
+	re, err := term.matcher()
+	if err != nil {
+		return nil, false, errors.New(errContext, "Error compiling the term", err)
+	}
+
+	hosts := []string{}
+	add := func(names ...string) {
+		for _, name := range names {
+			if !contains(hosts, name) {
+				hosts = append(hosts, name)
+			}
+		}
+	}
+
+	matchingGroups := []string{}
+	for _, group := range groupNames(inv) {
+		if re.MatchString(group) {
+			matchingGroups = append(matchingGroups, group)
+			add(hostsInGroup(inv, group)...)
+		}
+	}
+
+	// hosts are also matched when no group matches or the term is a regular expression or a glob
+	if len(matchingGroups) == 0 || term.Regex || strings.ContainsAny(term.Expr, globSpecialChars) {
+		for _, host := range inv.Hosts() {
+			if re.MatchString(host.Name) {
+				add(host.Name)
+			}
+		}
+	}
+
+	if len(hosts) == 0 && !term.Regex && contains(localhostNames, term.Expr) {
+		add(term.Expr)
+	}
+
+	found := len(hosts) > 0 || len(matchingGroups) > 0
+	if term.Subscript == nil {
+		return hosts, found, nil
+	}
+
+	hosts, err = applySubscript(hosts, term.Subscript)
+	if err != nil {
+		return nil, false, errors.New(errContext, fmt.Sprintf("No hosts matched the subscripted pattern '%s'", term.String()), err)
+	}
+
+	return hosts, found, nil
+}
+
+// applySubscript returns the hosts selected by the subscript. As in Ansible, a range whose end is 0 only selects the host at the start index
+func applySubscript(hosts []string, subscript *Subscript) ([]string, error) {
+	errContext := "(pattern::applySubscript)"
+
+	if !subscript.Range || subscript.End == 0 {
+		index := subscript.Start
+		if index < 0 {
+			index = len(hosts) + index
+		}
+
+		if index < 0 || index >= len(hosts) {
+			return nil, errors.New(errContext, fmt.Sprintf("Index %d is out of range for %d hosts", subscript.Start, len(hosts)))
+		}
+
+		return []string{hosts[index]}, nil
+	}
+
+	end := subscript.End + 1
+	if subscript.End < 0 || end > len(hosts) {
+		end = len(hosts)
+	}
+
+	if subscript.Start >= end {
+		return []string{}, nil
+	}
+
+	return hosts[subscript.Start:end], nil
+}
+
+// groupNames returns the names of the inventory groups, including the implicit all and ungrouped groups
+func groupNames(inv *inventory.Inventory) []string {
+	names := []string{}
+	for _, group := range inv.Groups() {
+		names = append(names, group.Name)
+	}
+
+	for _, implicit := range []string{inventory.AllGroup, inventory.UngroupedGroup} {
+		if !contains(names, implicit) {
+			names = append(names, implicit)
+		}
+	}
+
+	return names
+}
+
+// hostsInGroup returns the hosts of the group. When the inventory does not define the ungrouped group, it contains the hosts that do not belong to any group
+func hostsInGroup(inv *inventory.Inventory, name string) []string {
+	if name != inventory.UngroupedGroup || inv.Group(inventory.UngroupedGroup) != nil {
+		return inv.HostsInGroup(name)
+	}
+
+	hosts := []string{}
+	for _, host := range inv.Hosts() {
+		groups := filter(inv.HostGroups(host.Name), func(group string) bool { return group != inventory.AllGroup })
+		if len(groups) == 0 {
+			hosts = append(hosts, host.Name)
+		}
+	}
+
+	return hosts
+}
+
+// filter returns the items for which keep returns true
+func filter(items []string, keep func(string) bool) []string {
+	filtered := []string{}
+	for _, item := range items {
+		if keep(item) {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return filtered
+}
+
+// contains returns whether the item is on the list
+func contains(list []string, item string) bool {
+	for _, i := range list {
+		if i == item {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,186 @@
+package pattern
+
+import (
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/inventory"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func selectionTestInventory(t *testing.T) *inventory.Inventory {
+	inv, err := inventory.NewInventoryBuilder().
+		WithHost("web1", "web", "eu").
+		WithHost("web2", "web").
+		WithHost("db1", "db", "eu").
+		WithHost("db2", "db").
+		WithHost("app1", "app").
+		WithHost("app10", "app").
+		WithHost("bastion").
+		WithGroupChildren("prod", "web", "db").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return inv
+}
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		desc     string
+		pattern  string
+		expected *Selection
+		err      error
+	}{
+		{
+			desc:    "Testing selecting the hosts of groups and hosts",
+			pattern: "web:bastion:db1",
+			expected: &Selection{
+				Hosts:     []string{"web1", "web2", "bastion", "db1"},
+				Unmatched: []string{},
+			},
+		},
+		{
+			desc:    "Testing selecting with intersection, exclusion and regex terms",
+			pattern: "!db2:prod:&eu:~app1",
+			expected: &Selection{
+				Hosts:     []string{"web1", "db1"},
+				Unmatched: []string{},
+			},
+		},
+		{
+			desc:    "Testing selecting with a regular expression that is anchored at the beginning",
+			pattern: "~app1",
+			expected: &Selection{
+				Hosts:     []string{"app1", "app10"},
+				Unmatched: []string{},
+			},
+		},
+		{
+			desc:    "Testing selecting with a glob that matches groups and hosts",
+			pattern: "db*:!db",
+			expected: &Selection{
+				Hosts:     []string{},
+				Unmatched: []string{},
+			},
+		},
+		{
+			desc:    "Testing selecting with only exclusions, which starts from the all group",
+			pattern: "!prod",
+			expected: &Selection{
+				Hosts:     []string{"app1", "app10", "bastion"},
+				Unmatched: []string{},
+			},
+		},
+		{
+			desc:    "Testing selecting the implicit ungrouped group",
+			pattern: "ungrouped",
+			expected: &Selection{
+				Hosts:     []string{"bastion"},
+				Unmatched: []string{},
+			},
+		},
+		{
+			desc:    "Testing selecting with subscripts",
+			pattern: "prod[1:2]:app[-1]:web[0:]",
+			expected: &Selection{
+				Hosts:     []string{"web2", "db1", "app10", "web1"},
+				Unmatched: []string{},
+			},
+		},
+		{
+			desc:    "Testing selecting the implicit localhost and unmatched terms",
+			pattern: "localhost:missing:!other",
+			expected: &Selection{
+				Hosts:     []string{"localhost"},
+				Unmatched: []string{"missing", "!other"},
+			},
+		},
+		{
+			desc:    "Testing selecting with an out of range subscript",
+			pattern: "web[5]",
+			err: errors.New("(pattern::Select)", "Error evaluating the host pattern 'web[5]'",
+				errors.New("(pattern::evaluate)", "Error matching the term 'web[5]'",
+					errors.New("(pattern::match)", "No hosts matched the subscripted pattern 'web[5]'",
+						errors.New("(pattern::applySubscript)", "Index 5 is out of range for 2 hosts")))),
+		},
+	}
+
+	inv := selectionTestInventory(t)
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			p, err := Parse(test.pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			selection, err := p.Select(inv)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				assert.Equal(t, test.expected, selection)
+			}
+		})
+	}
+}
+
+func TestSelectWithLimit(t *testing.T) {
+	tests := []struct {
+		desc     string
+		hosts    *Pattern
+		limit    *Pattern
+		expected *Selection
+		err      error
+	}{
+		{
+			desc:  "Testing selecting the hosts of a play restricted by a limit",
+			hosts: &Pattern{Terms: []Term{Name("prod")}},
+			limit: &Pattern{Terms: []Term{Name("db2"), Name("web1"), Name("missing")}},
+			expected: &Selection{
+				Hosts:     []string{"web1", "db2"},
+				Unmatched: []string{"missing"},
+			},
+		},
+		{
+			desc:  "Testing selecting the hosts of a play without limit",
+			hosts: &Pattern{Terms: []Term{Name("web")}},
+			expected: &Selection{
+				Hosts:     []string{"web1", "web2"},
+				Unmatched: []string{},
+			},
+		},
+		{
+			desc:  "Testing selecting with a file term that is not expanded",
+			hosts: &Pattern{Terms: []Term{Name("web")}},
+			limit: &Pattern{Terms: []Term{File("site.retry")}},
+			err: errors.New("(pattern::SelectWithLimit)", "Error selecting the limited hosts",
+				errors.New("(pattern::Select)", "Error evaluating the host pattern '@site.retry'",
+					errors.New("(pattern::evaluate)", "File term '@site.retry' is only allowed on limits and must be parsed by ParseLimit"))),
+		},
+		{
+			desc: "Testing selecting without hosts pattern",
+			err:  errors.New("(pattern::SelectWithLimit)", "Hosts pattern must be provided to select hosts"),
+		},
+	}
+
+	inv := selectionTestInventory(t)
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			selection, err := SelectWithLimit(inv, test.hosts, test.limit)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Nil(t, test.err)
+				assert.Equal(t, test.expected, selection)
+			}
+		})
+	}
+}
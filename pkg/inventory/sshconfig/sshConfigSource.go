@@ -0,0 +1,442 @@
+package sshconfig
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/apenella/go-ansible/v2/pkg/inventory"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// DefaultConfigFile is the OpenSSH user configuration file
+	DefaultConfigFile = "~/.ssh/config"
+
+	// maxIncludeDepth is the maximum number of nested Include directives, as OpenSSH allows
+	maxIncludeDepth = 16
+
+	keywordHost         = "host"
+	keywordHostName     = "hostname"
+	keywordIdentityFile = "identityfile"
+	keywordInclude      = "include"
+	keywordMatch        = "match"
+	keywordPort         = "port"
+	keywordUser         = "user"
+)
+
+// OptionsFunc is a function used to configure SSHConfigSource
+type OptionsFunc func(*SSHConfigSource)
+
+// SSHConfigSource is an InventorySource that reads the hosts from an OpenSSH client configuration file. Each name of a Host block without wildcards or negations becomes a host, whose HostName, User, Port and IdentityFile are the first values obtained for it, as ssh does, from the blocks that match it
+type SSHConfigSource struct {
+	configFile string
+	groups     []string
+}
+
+// NewSSHConfigSource returns an SSHConfigSource that reads the DefaultConfigFile by default
+func NewSSHConfigSource(options ...OptionsFunc) *SSHConfigSource {
+	source := &SSHConfigSource{
+		configFile: DefaultConfigFile,
+	}
+	source.Options(options...)
+
+	return source
+}
+
+// WithConfigFile sets the OpenSSH client configuration file. A leading ~ is replaced by the home directory
+func WithConfigFile(file string) OptionsFunc {
+	return func(s *SSHConfigSource) {
+		s.configFile = file
+	}
+}
+
+// WithGroup adds every host to the group
+func WithGroup(group string) OptionsFunc {
+	return func(s *SSHConfigSource) {
+		s.groups = append(s.groups, group)
+	}
+}
+
+// Options configure the SSHConfigSource
+func (s *SSHConfigSource) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(s)
+	}
+}
+
+// Inventory reads the configuration file and returns the inventory of its hosts, with the ansible_host, ansible_user, ansible_port and ansible_ssh_private_key_file variables
+func (s *SSHConfigSource) Inventory(ctx context.Context) (*inventory.Inventory, error) {
+	errContext := "(sshconfig::SSHConfigSource::Inventory)"
+
+	if ctx != nil && ctx.Err() != nil {
+		return nil, errors.New(errContext, "Error reading the ssh configuration", ctx.Err())
+	}
+
+	// the home directory is only required to expand the paths relative to it
+	p := &parser{}
+	p.home, p.homeErr = os.UserHomeDir()
+
+	configFile, err := p.expandHome(s.configFile)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error reading the ssh configuration '%s'", s.configFile), err)
+	}
+
+	err = p.parseFile(configFile, &block{global: true}, 0)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error reading the ssh configuration '%s'", configFile), err)
+	}
+
+	builder := inventory.NewInventoryBuilder()
+	for _, host := range p.hosts() {
+		connection, err := p.connection(host)
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Error reading the connection of the host '%s' from the ssh configuration '%s'", host, configFile), err)
+		}
+
+		builder.WithHost(host, s.groups...).
+			WithHostConnection(host, connection)
+	}
+
+	inv, err := builder.Build()
+	if err != nil {
+		return nil, errors.New(errContext, "Error building the inventory from the ssh configuration", err)
+	}
+
+	return inv, nil
+}
+
+// block is a Host or Match block of the configuration, or the global options that precede them
+type block struct {
+	global   bool
+	match    bool
+	patterns []string
+	options  []option
+}
+
+// option is a configuration keyword, in lower case, and its arguments
+type option struct {
+	keyword string
+	args    []string
+}
+
+// matches returns whether the block applies to the host. A Match block never applies, because its criteria can not be evaluated without connecting
+func (b *block) matches(host string) bool {
+	if b.global {
+		return true
+	}
+
+	if b.match {
+		return false
+	}
+
+	matched := false
+	for _, pattern := range b.patterns {
+		negated := strings.HasPrefix(pattern, "!")
+		if globRegexp(strings.TrimPrefix(pattern, "!")).MatchString(strings.ToLower(host)) {
+			if negated {
+				return false
+			}
+			matched = true
+		}
+	}
+
+	return matched
+}
+
+// parser reads the blocks of a configuration file and the files it includes
+type parser struct {
+	blocks  []*block
+	home    string
+	homeErr error
+}
+
+// parseFile reads the blocks of the file. The options that precede the first Host or Match block belong to the current block, as the file may be included from inside a block
+func (p *parser) parseFile(file string, current *block, depth int) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	current = &block{global: current.global, match: current.match, patterns: current.patterns}
+	p.blocks = append(p.blocks, current)
+
+	for number, line := range strings.Split(string(content), "\n") {
+		keyword, args, err := splitLine(line)
+		if err != nil {
+			return fmt.Errorf("line %d: %s", number+1, err.Error())
+		}
+
+		if keyword == "" {
+			continue
+		}
+
+		if len(args) == 0 {
+			return fmt.Errorf("line %d: missing argument of '%s'", number+1, keyword)
+		}
+
+		switch keyword {
+		case keywordHost:
+			current = &block{patterns: args}
+			p.blocks = append(p.blocks, current)
+		case keywordMatch:
+			current = &block{match: true}
+			p.blocks = append(p.blocks, current)
+		case keywordInclude:
+			if depth+1 >= maxIncludeDepth {
+				return fmt.Errorf("line %d: too many nested includes", number+1)
+			}
+
+			for _, arg := range args {
+				err = p.include(arg, current, depth+1)
+				if err != nil {
+					return fmt.Errorf("line %d: %s", number+1, err.Error())
+				}
+			}
+
+			current = &block{global: current.global, match: current.match, patterns: current.patterns}
+			p.blocks = append(p.blocks, current)
+		case keywordPort:
+			_, err = strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("line %d: invalid port '%s'", number+1, args[0])
+			}
+			current.options = append(current.options, option{keyword: keyword, args: args})
+		default:
+			current.options = append(current.options, option{keyword: keyword, args: args})
+		}
+	}
+
+	return nil
+}
+
+// include reads the blocks of the files matched by the glob. The relative paths are relative to the ~/.ssh directory, and the globs that do not match any file are ignored, as ssh does
+func (p *parser) include(glob string, current *block, depth int) error {
+	glob, err := p.expandHome(glob)
+	if err != nil {
+		return err
+	}
+
+	if !filepath.IsAbs(glob) {
+		home, err := p.homeDir()
+		if err != nil {
+			return fmt.Errorf("include '%s': %s", glob, err.Error())
+		}
+		glob = filepath.Join(home, ".ssh", glob)
+	}
+
+	files, err := filepath.Glob(glob)
+	if err != nil {
+		return fmt.Errorf("invalid include '%s'", glob)
+	}
+
+	for _, file := range files {
+		err = p.parseFile(file, current, depth)
+		if err != nil {
+			return fmt.Errorf("%s: %s", file, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// hosts returns the names of the Host blocks without wildcards or negations, in the order in which they are defined
+func (p *parser) hosts() []string {
+	hosts := []string{}
+	seen := map[string]bool{}
+
+	for _, b := range p.blocks {
+		for _, pattern := range b.patterns {
+			if strings.ContainsAny(pattern, "*?!") || seen[pattern] {
+				continue
+			}
+			seen[pattern] = true
+			hosts = append(hosts, pattern)
+		}
+	}
+
+	return hosts
+}
+
+// connection returns the connection variables of the host. The first value obtained for each keyword is used, as ssh does
+func (p *parser) connection(host string) (inventory.ConnectionVars, error) {
+	values := map[string]string{}
+
+	for _, b := range p.blocks {
+		if !b.matches(host) {
+			continue
+		}
+
+		for _, opt := range b.options {
+			if _, exists := values[opt.keyword]; !exists {
+				values[opt.keyword] = opt.args[0]
+			}
+		}
+	}
+
+	connection := inventory.ConnectionVars{
+		User: values[keywordUser],
+	}
+
+	hostname := host
+	if value, exists := values[keywordHostName]; exists {
+		hostname = expandTokens(value, map[byte]string{'h': host})
+		connection.Host = hostname
+	}
+
+	if value, exists := values[keywordPort]; exists {
+		connection.Port, _ = strconv.Atoi(value)
+	}
+
+	if value, exists := values[keywordIdentityFile]; exists && !strings.EqualFold(value, "none") {
+		tokens := map[byte]string{'h': hostname}
+		if connection.User != "" {
+			tokens['r'] = connection.User
+		}
+
+		if strings.Contains(value, "%d") {
+			home, err := p.homeDir()
+			if err != nil {
+				return connection, fmt.Errorf("identity file '%s': %s", value, err.Error())
+			}
+			tokens['d'] = home
+		}
+
+		privateKeyFile, err := p.expandHome(expandTokens(value, tokens))
+		if err != nil {
+			return connection, err
+		}
+		connection.PrivateKeyFile = privateKeyFile
+	}
+
+	return connection, nil
+}
+
+// homeDir returns the home directory, or an error when it can not be determined
+func (p *parser) homeDir() (string, error) {
+	if p.homeErr != nil {
+		return "", fmt.Errorf("the home directory can not be determined: %s", p.homeErr.Error())
+	}
+
+	return p.home, nil
+}
+
+// expandHome replaces a leading ~ by the home directory. It returns an error when the path starts with ~ and the home directory can not be determined
+func (p *parser) expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := p.homeDir()
+	if err != nil {
+		return "", fmt.Errorf("path '%s': %s", path, err.Error())
+	}
+
+	if path == "~" {
+		return home, nil
+	}
+
+	return filepath.Join(home, path[2:]), nil
+}
+
+// splitLine returns the keyword, in lower case, and the arguments of a configuration line. The keyword is separated by whitespaces or an equal sign, the arguments can be quoted and a # starts a comment
+func splitLine(line string) (string, []string, error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", nil, nil
+	}
+
+	end := strings.IndexAny(line, " \t=")
+	if end < 0 {
+		return strings.ToLower(line), nil, nil
+	}
+
+	keyword := strings.ToLower(line[:end])
+	rest := strings.TrimLeft(line[end:], " \t")
+	rest = strings.TrimLeft(strings.TrimPrefix(rest, "="), " \t")
+
+	args := []string{}
+	for rest != "" {
+		if rest[0] == '#' {
+			break
+		}
+
+		arg := &strings.Builder{}
+		var quote byte
+		i := 0
+		for ; i < len(rest); i++ {
+			c := rest[i]
+			if quote != 0 {
+				if c == quote {
+					quote = 0
+				} else {
+					arg.WriteByte(c)
+				}
+				continue
+			}
+
+			if c == '"' || c == '\'' {
+				quote = c
+				continue
+			}
+
+			if c == ' ' || c == '\t' {
+				break
+			}
+
+			arg.WriteByte(c)
+		}
+
+		if quote != 0 {
+			return "", nil, fmt.Errorf("unterminated quoted argument of '%s'", keyword)
+		}
+
+		args = append(args, arg.String())
+		rest = strings.TrimLeft(rest[i:], " \t")
+	}
+
+	return keyword, args, nil
+}
+
+// globRegexp returns the regular expression of an ssh pattern, where * matches any sequence of characters and ? matches a single character. Host names are matched in lower case
+func globRegexp(pattern string) *regexp.Regexp {
+	expr := regexp.QuoteMeta(strings.ToLower(pattern))
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+
+	return regexp.MustCompile("^(?s:" + expr + ")$")
+}
+
+// expandTokens replaces the %% token and the given % tokens of the value. The unknown tokens are kept
+func expandTokens(value string, tokens map[byte]string) string {
+	result := &strings.Builder{}
+
+	for i := 0; i < len(value); i++ {
+		if value[i] != '%' || i+1 == len(value) {
+			result.WriteByte(value[i])
+			continue
+		}
+
+		token := value[i+1]
+		if token == '%' {
+			result.WriteByte('%')
+			i++
+			continue
+		}
+
+		replacement, exists := tokens[token]
+		if !exists {
+			result.WriteByte(value[i])
+			continue
+		}
+
+		result.WriteString(replacement)
+		i++
+	}
+
+	return result.String()
+}
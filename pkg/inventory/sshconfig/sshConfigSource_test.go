@@ -0,0 +1,338 @@
+package sshconfig
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/inventory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSSHConfigSourceInventory(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	dir := t.TempDir()
+	writeConfig := func(name, content string) string {
+		file := filepath.Join(dir, name)
+		err := os.WriteFile(file, []byte(content), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return file
+	}
+
+	tests := []struct {
+		desc   string
+		source *SSHConfigSource
+		hosts  map[string]map[string]interface{}
+		groups map[string][]string
+		err    string
+	}{
+		{
+			desc:   "Testing read the hosts of an ssh configuration",
+			source: NewSSHConfigSource(WithConfigFile("../test/sshconfig/config")),
+			hosts: map[string]map[string]interface{}{
+				"jump.work": {
+					"ansible_host": "192.0.2.1",
+					"ansible_user": "worker",
+					"ansible_port": 22,
+				},
+				"bastion": {
+					"ansible_host":                 "bastion.example.com",
+					"ansible_user":                 "admin",
+					"ansible_port":                 2222,
+					"ansible_ssh_private_key_file": filepath.Join(home, ".ssh/bastion_ed25519"),
+				},
+				"web1": {
+					"ansible_host":                 "web1.internal.example.com",
+					"ansible_user":                 "deploy",
+					"ansible_port":                 22,
+					"ansible_ssh_private_key_file": filepath.Join(home, ".ssh/web_ed25519"),
+				},
+				"web2": {
+					"ansible_host":                 "web2.internal.example.com",
+					"ansible_user":                 "deploy",
+					"ansible_port":                 2200,
+					"ansible_ssh_private_key_file": filepath.Join(home, ".ssh/web_ed25519"),
+				},
+				"db1": {
+					"ansible_host":                 "10.0.2.10",
+					"ansible_user":                 "postgres admin",
+					"ansible_port":                 22,
+					"ansible_ssh_private_key_file": filepath.Join(home, ".ssh/keys/10.0.2.10 postgres admin.pem"),
+				},
+			},
+			groups: map[string][]string{},
+		},
+		{
+			desc: "Testing read the hosts of an ssh configuration on a group",
+			source: NewSSHConfigSource(
+				WithConfigFile(writeConfig("group", "Host web1\n  User deploy\n")),
+				WithGroup("ssh"),
+			),
+			hosts: map[string]map[string]interface{}{
+				"web1": {"ansible_user": "deploy"},
+			},
+			groups: map[string][]string{
+				"ssh": {"web1"},
+			},
+		},
+		{
+			desc:   "Testing read the hosts of the ssh configuration on the home directory",
+			source: NewSSHConfigSource(),
+			hosts: map[string]map[string]interface{}{
+				"home": {"ansible_port": 2022},
+			},
+			groups: map[string][]string{},
+		},
+		{
+			desc:   "Testing error reading the hosts of an ssh configuration that does not exist",
+			source: NewSSHConfigSource(WithConfigFile(filepath.Join(dir, "unknown"))),
+			err:    "no such file or directory",
+		},
+		{
+			desc:   "Testing error reading the hosts of an ssh configuration with an invalid port",
+			source: NewSSHConfigSource(WithConfigFile(writeConfig("port", "Host web1\n  Port ssh\n"))),
+			err:    "line 2: invalid port 'ssh'",
+		},
+		{
+			desc:   "Testing error reading the hosts of an ssh configuration with an unterminated quote",
+			source: NewSSHConfigSource(WithConfigFile(writeConfig("quote", "Host web1\n  User \"deploy\n"))),
+			err:    "line 2: unterminated quoted argument of 'user'",
+		},
+		{
+			desc:   "Testing error reading the hosts of an ssh configuration with a missing argument",
+			source: NewSSHConfigSource(WithConfigFile(writeConfig("argument", "Host\n"))),
+			err:    "line 1: missing argument of 'host'",
+		},
+		{
+			desc:   "Testing error reading the hosts of an ssh configuration that includes itself",
+			source: NewSSHConfigSource(WithConfigFile(writeConfig("loop", "Include "+filepath.Join(dir, "loop")+"\n"))),
+			err:    "too many nested includes",
+		},
+		{
+			desc:   "Testing error reading the hosts of an ssh configuration with an invalid host name",
+			source: NewSSHConfigSource(WithConfigFile(writeConfig("invalid", "Host web#1\n"))),
+			err:    "invalid host name 'web#1'",
+		},
+	}
+
+	// the relative includes are resolved on the ~/.ssh directory
+	err := os.MkdirAll(filepath.Join(home, ".ssh", "conf.d"), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	included, err := os.ReadFile("../test/sshconfig/conf.d/work.conf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(home, ".ssh", "conf.d", "work.conf"), included, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(home, ".ssh", "config"), []byte("Host home\n  Port 2022\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			inv, err := test.source.Inventory(context.TODO())
+			if test.err != "" {
+				assert.ErrorContains(t, err, test.err)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			hosts := map[string]map[string]interface{}{}
+			for _, host := range inv.Hosts() {
+				hosts[host.Name] = host.Vars
+			}
+			assert.Equal(t, test.hosts, hosts)
+
+			groups := map[string][]string{}
+			for _, group := range inv.Groups() {
+				groups[group.Name] = group.Hosts
+			}
+			assert.Equal(t, test.groups, groups)
+		})
+	}
+}
+
+func TestSSHConfigSourceInventoryWithoutHome(t *testing.T) {
+	// os.UserHomeDir can not determine the home directory when HOME is empty
+	t.Setenv("HOME", "")
+
+	dir := t.TempDir()
+	writeConfig := func(name, content string) string {
+		file := filepath.Join(dir, name)
+		err := os.WriteFile(file, []byte(content), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return file
+	}
+
+	tests := []struct {
+		desc   string
+		source *SSHConfigSource
+		hosts  map[string]map[string]interface{}
+		err    string
+	}{
+		{
+			desc:   "Testing read the hosts of an ssh configuration without paths relative to the home directory",
+			source: NewSSHConfigSource(WithConfigFile(writeConfig("absolute", "Host web1\n  IdentityFile /keys/web1.pem\n"))),
+			hosts: map[string]map[string]interface{}{
+				"web1": {"ansible_ssh_private_key_file": "/keys/web1.pem"},
+			},
+		},
+		{
+			desc:   "Testing error reading the default ssh configuration",
+			source: NewSSHConfigSource(),
+			err:    "path '~/.ssh/config': the home directory can not be determined",
+		},
+		{
+			desc:   "Testing error reading an ssh configuration with an identity file on the home directory",
+			source: NewSSHConfigSource(WithConfigFile(writeConfig("identity", "Host web1\n  IdentityFile ~/.ssh/web1.pem\n"))),
+			err:    "path '~/.ssh/web1.pem': the home directory can not be determined",
+		},
+		{
+			desc:   "Testing error reading an ssh configuration with an identity file with the home directory token",
+			source: NewSSHConfigSource(WithConfigFile(writeConfig("token", "Host web1\n  IdentityFile %d/.ssh/web1.pem\n"))),
+			err:    "identity file '%d/.ssh/web1.pem': the home directory can not be determined",
+		},
+		{
+			desc:   "Testing error reading an ssh configuration with a relative include",
+			source: NewSSHConfigSource(WithConfigFile(writeConfig("include", "Include conf.d/*.conf\n"))),
+			err:    "include 'conf.d/*.conf': the home directory can not be determined",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			inv, err := test.source.Inventory(context.TODO())
+			if test.err != "" {
+				assert.ErrorContains(t, err, test.err)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			hosts := map[string]map[string]interface{}{}
+			for _, host := range inv.Hosts() {
+				hosts[host.Name] = host.Vars
+			}
+			assert.Equal(t, test.hosts, hosts)
+		})
+	}
+}
+
+func TestSSHConfigSourceIsInventorySource(t *testing.T) {
+	t.Log("Testing SSHConfigSource implements InventorySource")
+
+	var source inventory.InventorySource = NewSSHConfigSource()
+	assert.NotNil(t, source)
+}
+
+func TestSplitLine(t *testing.T) {
+	tests := []struct {
+		desc    string
+		line    string
+		keyword string
+		args    []string
+	}{
+		{
+			desc: "Testing split an empty line",
+			line: "   ",
+		},
+		{
+			desc: "Testing split a comment",
+			line: "  # Host web1",
+		},
+		{
+			desc:    "Testing split a keyword separated by whitespaces",
+			line:    "  HostName\tweb1.example.com",
+			keyword: "hostname",
+			args:    []string{"web1.example.com"},
+		},
+		{
+			desc:    "Testing split a keyword separated by an equal sign",
+			line:    "Port=22",
+			keyword: "port",
+			args:    []string{"22"},
+		},
+		{
+			desc:    "Testing split quoted arguments and a trailing comment",
+			line:    `Host "web 1" 'web 2' web3 # servers`,
+			keyword: "host",
+			args:    []string{"web 1", "web 2", "web3"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			keyword, args, err := splitLine(test.line)
+			assert.NoError(t, err)
+			assert.Equal(t, test.keyword, keyword)
+			assert.Equal(t, test.args, args)
+		})
+	}
+}
+
+func TestBlockMatches(t *testing.T) {
+	tests := []struct {
+		desc     string
+		block    *block
+		host     string
+		expected bool
+	}{
+		{
+			desc:     "Testing a global block matches any host",
+			block:    &block{global: true},
+			host:     "web1",
+			expected: true,
+		},
+		{
+			desc:     "Testing a Match block never matches",
+			block:    &block{match: true},
+			host:     "web1",
+			expected: false,
+		},
+		{
+			desc:     "Testing a block matches a host through a wildcard",
+			block:    &block{patterns: []string{"WEB?"}},
+			host:     "web1",
+			expected: true,
+		},
+		{
+			desc:     "Testing a block does not match a negated host",
+			block:    &block{patterns: []string{"web*", "!web2"}},
+			host:     "web2",
+			expected: false,
+		},
+		{
+			desc:     "Testing a block does not match a host that is not listed",
+			block:    &block{patterns: []string{"db*"}},
+			host:     "web1",
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expected, test.block.matches(test.host))
+		})
+	}
+}
@@ -0,0 +1,392 @@
+package terraform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/apenella/go-ansible/v2/pkg/inventory"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// stateVersion is the version of the Terraform state format, used since Terraform 0.12
+	stateVersion = 4
+	// managedMode is the mode of the resources managed by Terraform, as opposed to the data sources
+	managedMode = "managed"
+)
+
+var (
+	// DefaultAddressAttributes are the attributes that hold the address of the most common compute resources, such as aws_instance, google_compute_instance, digitalocean_droplet, hcloud_server, openstack_compute_instance_v2 or vsphere_virtual_machine. The public addresses come before the private ones
+	DefaultAddressAttributes = []string{
+		"public_ip",
+		"ipv4_address",
+		"access_ip_v4",
+		"network_interface.0.access_config.0.nat_ip",
+		"default_ip_address",
+		"ip_address",
+		"private_ip",
+		"ipv4_address_private",
+		"network_interface.0.network_ip",
+	}
+
+	// invalidGroupCharsRegexp matches the characters that Ansible replaces on the group names
+	invalidGroupCharsRegexp = regexp.MustCompile(`[^A-Za-z0-9_]`)
+	// invalidHostCharsReplacer turns the instance address into a valid host name, replacing its index brackets by dots
+	invalidHostCharsReplacer = strings.NewReplacer(`["`, ".", `"]`, "", "[", ".", "]", "", " ", "_", "\t", "_", "#", "_", "=", "_", ",", "_")
+)
+
+// Rule maps the instances of the matching Terraform resources to inventory hosts
+type Rule struct {
+	// Type is a glob that matches the resource type, such as aws_instance. Any type matches when it is empty
+	Type string
+	// Name is a glob that matches the resource name. Any name matches when it is empty
+	Name string
+	// AddressAttributes are the attributes whose first non-empty value is the ansible_host variable. DefaultAddressAttributes is used when it is empty. The instances without address are skipped
+	AddressAttributes []string
+	// HostNameAttribute is the attribute whose value is the host name, such as tags.Name. The instance address, such as module.app.aws_instance.web.0, is used when it is empty or the attribute is not set
+	HostNameAttribute string
+	// Groups are the groups the hosts are added to
+	Groups []string
+	// GroupAttributes are the attributes whose values are the groups the hosts are added to. A list adds a group for each item and a map, such as tags, adds a key_value group for each entry
+	GroupAttributes []string
+	// TypeGroup adds the hosts to a group named after the resource type
+	TypeGroup bool
+	// Vars maps host variable names to the attributes that hold their values
+	Vars map[string]string
+}
+
+// OptionsFunc is a function used to configure TerraformStateSource
+type OptionsFunc func(*TerraformStateSource)
+
+// TerraformStateSource is an InventorySource that reads the hosts from Terraform state files. Each instance of a managed resource that matches a rule, and has an address, becomes a host
+type TerraformStateSource struct {
+	files []string
+	rules []Rule
+}
+
+// NewTerraformStateSource returns a TerraformStateSource. When no rule is set, every resource with an address attribute is mapped to a host on a group named after its type
+func NewTerraformStateSource(options ...OptionsFunc) *TerraformStateSource {
+	source := &TerraformStateSource{}
+	source.Options(options...)
+
+	return source
+}
+
+// WithStateFile adds a Terraform state file, such as terraform.tfstate
+func WithStateFile(file string) OptionsFunc {
+	return func(s *TerraformStateSource) {
+		s.files = append(s.files, file)
+	}
+}
+
+// WithRule adds a rule. Each instance is mapped by the first rule that matches its resource
+func WithRule(rule Rule) OptionsFunc {
+	return func(s *TerraformStateSource) {
+		s.rules = append(s.rules, rule)
+	}
+}
+
+// Options configure the TerraformStateSource
+func (s *TerraformStateSource) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(s)
+	}
+}
+
+// Inventory reads the state files and returns the inventory of the hosts mapped by the rules
+func (s *TerraformStateSource) Inventory(ctx context.Context) (*inventory.Inventory, error) {
+	errContext := "(terraform::TerraformStateSource::Inventory)"
+
+	if len(s.files) == 0 {
+		return nil, errors.New(errContext, "TerraformStateSource requires at least one state file")
+	}
+
+	rules := s.rules
+	if len(rules) == 0 {
+		rules = []Rule{{TypeGroup: true}}
+	}
+
+	builder := inventory.NewInventoryBuilder()
+
+	for _, file := range s.files {
+		if ctx != nil && ctx.Err() != nil {
+			return nil, errors.New(errContext, "Error reading the Terraform state", ctx.Err())
+		}
+
+		state, err := readState(file)
+		if err != nil {
+			return nil, errors.New(errContext, fmt.Sprintf("Error reading the Terraform state '%s'", file), err)
+		}
+
+		for _, resource := range state.Resources {
+			if resource.Mode != managedMode {
+				continue
+			}
+
+			rule := matchRule(rules, resource)
+			if rule == nil {
+				continue
+			}
+
+			for _, instance := range resource.Instances {
+				addInstance(builder, rule, resource, instance)
+			}
+		}
+	}
+
+	inv, err := builder.Build()
+	if err != nil {
+		return nil, errors.New(errContext, "Error building the inventory from the Terraform state", err)
+	}
+
+	return inv, nil
+}
+
+// state is the Terraform state format
+type state struct {
+	Version   int        `json:"version"`
+	Resources []resource `json:"resources"`
+}
+
+// resource is a resource of the Terraform state
+type resource struct {
+	Module    string     `json:"module"`
+	Mode      string     `json:"mode"`
+	Type      string     `json:"type"`
+	Name      string     `json:"name"`
+	Instances []instance `json:"instances"`
+}
+
+// instance is an instance of a resource of the Terraform state. The index key is set when the resource uses count or for_each
+type instance struct {
+	IndexKey   interface{}            `json:"index_key"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// readState reads and decodes a Terraform state file
+func readState(file string) (*state, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	s := &state{}
+	err = decoder.Decode(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Version != stateVersion {
+		return nil, fmt.Errorf("unsupported state version %d", s.Version)
+	}
+
+	for _, r := range s.Resources {
+		for _, i := range r.Instances {
+			normalize(i.Attributes)
+		}
+	}
+
+	return s, nil
+}
+
+// matchRule returns the first rule that matches the resource, or nil when none does
+func matchRule(rules []Rule, r resource) *Rule {
+	for i := range rules {
+		if globMatch(rules[i].Type, r.Type) && globMatch(rules[i].Name, r.Name) {
+			return &rules[i]
+		}
+	}
+
+	return nil
+}
+
+// globMatch returns whether the value matches the glob. An empty glob matches any value
+func globMatch(glob, value string) bool {
+	if glob == "" {
+		return true
+	}
+
+	matched, err := path.Match(glob, value)
+	return err == nil && matched
+}
+
+// addInstance adds the instance to the inventory as a host, when it has an address
+func addInstance(builder *inventory.InventoryBuilder, rule *Rule, r resource, i instance) {
+	addressAttributes := rule.AddressAttributes
+	if len(addressAttributes) == 0 {
+		addressAttributes = DefaultAddressAttributes
+	}
+
+	address := ""
+	for _, attribute := range addressAttributes {
+		address = stringValue(lookup(i.Attributes, attribute))
+		if address != "" {
+			break
+		}
+	}
+	if address == "" {
+		return
+	}
+
+	name := ""
+	if rule.HostNameAttribute != "" {
+		name = stringValue(lookup(i.Attributes, rule.HostNameAttribute))
+	}
+	if name == "" {
+		name = instanceAddress(r, i)
+	}
+
+	groups := append([]string{}, rule.Groups...)
+	if rule.TypeGroup {
+		groups = append(groups, groupName(r.Type))
+	}
+	for _, attribute := range rule.GroupAttributes {
+		groups = append(groups, attributeGroups(lookup(i.Attributes, attribute))...)
+	}
+
+	vars := map[string]interface{}{
+		inventory.AnsibleHostVar: address,
+	}
+	for _, variable := range sortedKeys(rule.Vars) {
+		value := lookup(i.Attributes, rule.Vars[variable])
+		if value != nil {
+			vars[variable] = value
+		}
+	}
+
+	builder.WithHost(name, groups...).WithHostVars(name, vars)
+}
+
+// instanceAddress returns the address of the instance as a valid host name, where the index brackets are replaced by dots
+func instanceAddress(r resource, i instance) string {
+	address := fmt.Sprintf("%s.%s", r.Type, r.Name)
+	if r.Module != "" {
+		address = fmt.Sprintf("%s.%s", r.Module, address)
+	}
+
+	switch key := i.IndexKey.(type) {
+	case nil:
+	case string:
+		address = fmt.Sprintf("%s.%s", address, key)
+	default:
+		address = fmt.Sprintf("%s.%v", address, key)
+	}
+
+	return invalidHostCharsReplacer.Replace(address)
+}
+
+// attributeGroups returns the groups defined by an attribute value
+func attributeGroups(value interface{}) []string {
+	groups := []string{}
+
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			group := stringValue(item)
+			if group != "" {
+				groups = append(groups, groupName(group))
+			}
+		}
+	case map[string]interface{}:
+		for _, key := range sortedKeys(v) {
+			groups = append(groups, groupName(fmt.Sprintf("%s_%s", key, stringValue(v[key]))))
+		}
+	default:
+		group := stringValue(v)
+		if group != "" {
+			groups = append(groups, groupName(group))
+		}
+	}
+
+	return groups
+}
+
+// groupName replaces the characters that are not valid on a group name by underscores
+func groupName(name string) string {
+	return invalidGroupCharsRegexp.ReplaceAllString(name, "_")
+}
+
+// lookup returns the value of the attribute path, whose elements are separated by dots and are either map keys or list indexes. It returns nil when the attribute is not set
+func lookup(attributes map[string]interface{}, attribute string) interface{} {
+	var value interface{} = attributes
+
+	for _, element := range strings.Split(attribute, ".") {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			value = v[element]
+		case []interface{}:
+			index, err := strconv.Atoi(element)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil
+			}
+			value = v[index]
+		default:
+			return nil
+		}
+	}
+
+	return value
+}
+
+// stringValue returns the value as a string when it is a string or a number, or an empty string otherwise
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int, float64:
+		return fmt.Sprint(v)
+	default:
+		return ""
+	}
+}
+
+// normalize converts the decoded numbers to int, when they are integers, or to float64
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalize(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalize(item)
+		}
+		return v
+	case json.Number:
+		integer, err := v.Int64()
+		if err == nil && integer >= math.MinInt && integer <= math.MaxInt {
+			return int(integer)
+		}
+		float, err := v.Float64()
+		if err == nil {
+			return float
+		}
+		return v.String()
+	default:
+		return v
+	}
+}
+
+// sortedKeys returns the sorted keys of the map
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
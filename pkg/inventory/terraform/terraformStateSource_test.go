@@ -0,0 +1,197 @@
+package terraform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/inventory"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	stateFile       = "../test/terraform/terraform.tfstate"
+	legacyStateFile = "../test/terraform/legacy.tfstate"
+)
+
+func TestTerraformStateSourceInventory(t *testing.T) {
+	tests := []struct {
+		desc   string
+		source *TerraformStateSource
+		hosts  map[string]map[string]interface{}
+		groups map[string][]string
+		err    string
+	}{
+		{
+			desc:   "Testing read the hosts of a Terraform state with the default rule",
+			source: NewTerraformStateSource(WithStateFile(stateFile)),
+			hosts: map[string]map[string]interface{}{
+				"aws_instance.web.0":                           {"ansible_host": "203.0.113.10"},
+				"aws_instance.web.1":                           {"ansible_host": "10.0.1.11"},
+				"module.db.eu.aws_instance.primary":            {"ansible_host": "10.0.2.10"},
+				"google_compute_instance.bastion.europe-west1": {"ansible_host": "198.51.100.7"},
+			},
+			groups: map[string][]string{
+				"aws_instance":            {"aws_instance.web.0", "aws_instance.web.1", "module.db.eu.aws_instance.primary"},
+				"google_compute_instance": {"google_compute_instance.bastion.europe-west1"},
+			},
+		},
+		{
+			desc: "Testing read the hosts of a Terraform state with rules",
+			source: NewTerraformStateSource(
+				WithStateFile(stateFile),
+				WithRule(Rule{
+					Type:              "aws_*",
+					Name:              "web",
+					AddressAttributes: []string{"private_ip"},
+					HostNameAttribute: "tags.Name",
+					Groups:            []string{"frontend"},
+					GroupAttributes:   []string{"tags"},
+					Vars: map[string]string{
+						"instance_type": "instance_type",
+						"disk_size":     "root_block_device.0.volume_size",
+						"missing":       "root_block_device.1.volume_size",
+					},
+				}),
+				WithRule(Rule{
+					Type:            "aws_instance",
+					GroupAttributes: []string{"tags.Role"},
+				}),
+				WithRule(Rule{
+					Type:              "google_compute_instance",
+					HostNameAttribute: "name",
+					AddressAttributes: []string{"network_interface.0.network_ip"},
+					TypeGroup:         true,
+				}),
+			),
+			hosts: map[string]map[string]interface{}{
+				"web-0":                             {"ansible_host": "10.0.1.10", "instance_type": "t3.micro", "disk_size": 20},
+				"web-1":                             {"ansible_host": "10.0.1.11", "instance_type": "t3.micro", "disk_size": 20},
+				"module.db.eu.aws_instance.primary": {"ansible_host": "10.0.2.10"},
+				"bastion-ew1":                       {"ansible_host": "10.132.0.2"},
+			},
+			groups: map[string][]string{
+				"frontend":                {"web-0", "web-1"},
+				"Name_web_0":              {"web-0"},
+				"Name_web_1":              {"web-1"},
+				"Role_web":                {"web-0", "web-1"},
+				"db":                      {"module.db.eu.aws_instance.primary"},
+				"google_compute_instance": {"bastion-ew1"},
+			},
+		},
+		{
+			desc: "Testing read the hosts of a Terraform state with rules that do not match any resource",
+			source: NewTerraformStateSource(
+				WithStateFile(stateFile),
+				WithRule(Rule{Type: "azurerm_*"}),
+			),
+			hosts:  map[string]map[string]interface{}{},
+			groups: map[string][]string{},
+		},
+		{
+			desc:   "Testing error reading the hosts without Terraform state files",
+			source: NewTerraformStateSource(),
+			err:    "TerraformStateSource requires at least one state file",
+		},
+		{
+			desc:   "Testing error reading the hosts of a Terraform state that does not exist",
+			source: NewTerraformStateSource(WithStateFile("../test/terraform/unknown.tfstate")),
+			err:    "Error reading the Terraform state '../test/terraform/unknown.tfstate'\n open ../test/terraform/unknown.tfstate: no such file or directory",
+		},
+		{
+			desc:   "Testing error reading the hosts of a Terraform state with an unsupported version",
+			source: NewTerraformStateSource(WithStateFile(legacyStateFile)),
+			err:    "Error reading the Terraform state '../test/terraform/legacy.tfstate'\n unsupported state version 3",
+		},
+		{
+			desc: "Testing error reading the hosts of a Terraform state with an invalid group",
+			source: NewTerraformStateSource(
+				WithStateFile(stateFile),
+				WithRule(Rule{Groups: []string{"web servers"}}),
+			),
+			err: "Error building the inventory from the Terraform state",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			inv, err := test.source.Inventory(context.TODO())
+			if test.err != "" {
+				assert.ErrorContains(t, err, test.err)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			hosts := map[string]map[string]interface{}{}
+			for _, host := range inv.Hosts() {
+				hosts[host.Name] = host.Vars
+			}
+			assert.Equal(t, test.hosts, hosts)
+
+			groups := map[string][]string{}
+			for _, group := range inv.Groups() {
+				groups[group.Name] = group.Hosts
+			}
+			assert.Equal(t, test.groups, groups)
+		})
+	}
+}
+
+func TestTerraformStateSourceIsInventorySource(t *testing.T) {
+	t.Log("Testing TerraformStateSource implements InventorySource")
+
+	var source inventory.InventorySource = NewTerraformStateSource()
+	assert.NotNil(t, source)
+}
+
+func TestLookup(t *testing.T) {
+	attributes := map[string]interface{}{
+		"tags": map[string]interface{}{"Name": "web-0"},
+		"network_interface": []interface{}{
+			map[string]interface{}{"network_ip": "10.0.0.1"},
+		},
+	}
+
+	tests := []struct {
+		desc      string
+		attribute string
+		expected  interface{}
+	}{
+		{
+			desc:      "Testing lookup a map attribute",
+			attribute: "tags.Name",
+			expected:  "web-0",
+		},
+		{
+			desc:      "Testing lookup a list attribute",
+			attribute: "network_interface.0.network_ip",
+			expected:  "10.0.0.1",
+		},
+		{
+			desc:      "Testing lookup a list attribute out of range",
+			attribute: "network_interface.1.network_ip",
+		},
+		{
+			desc:      "Testing lookup a list attribute with an invalid index",
+			attribute: "network_interface.first.network_ip",
+		},
+		{
+			desc:      "Testing lookup an attribute that is not set",
+			attribute: "tags.Role",
+		},
+		{
+			desc:      "Testing lookup an attribute below a scalar",
+			attribute: "tags.Name.first",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.expected, lookup(attributes, test.attribute))
+		})
+	}
+}
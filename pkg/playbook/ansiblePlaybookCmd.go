@@ -22,6 +22,12 @@ type AnsiblePlaybookCmd struct {
 	Playbooks []string
 	// PlaybookOptions are the ansible's playbook options
 	PlaybookOptions *AnsiblePlaybookOptions
+	// SkipValidation disables the playbook options validation when the command is generated
+	SkipValidation bool
+	// FileValidation enables checking that the files referenced by the options exist when the command is generated
+	FileValidation bool
+	// FileValidationDir is the directory the relative files are resolved against when the files are validated
+	FileValidationDir string
 }
 
 // NewAnsiblePlaybookCmd creates a new AnsiblePlaybookCmd instance
@@ -56,6 +62,21 @@ func WithPlaybooks(playbooks ...string) AnsiblePlaybookOptionsFunc {
 	}
 }
 
+// WithoutValidation disables the ansible-playbook options validation
+func WithoutValidation() AnsiblePlaybookOptionsFunc {
+	return func(p *AnsiblePlaybookCmd) {
+		p.SkipValidation = true
+	}
+}
+
+// WithFileValidation enables checking that the files referenced by the ansible-playbook options exist. The relative files are resolved against dir, which is the directory where the command runs, or against the working directory when dir is empty. Do not enable it when the command runs on another host or container
+func WithFileValidation(dir string) AnsiblePlaybookOptionsFunc {
+	return func(p *AnsiblePlaybookCmd) {
+		p.FileValidation = true
+		p.FileValidationDir = dir
+	}
+}
+
 // Command generate the ansible-playbook command which will be executed
 func (p *AnsiblePlaybookCmd) Command() ([]string, error) {
 	cmd := []string{}
@@ -74,6 +95,20 @@ func (p *AnsiblePlaybookCmd) Command() ([]string, error) {
 
 	// Determine the options to be set
 	if p.PlaybookOptions != nil {
+		if !p.SkipValidation {
+			err := p.PlaybookOptions.Validate()
+			if err != nil {
+				return nil, errors.New("(playbook::Command)", "Error validating options", err)
+			}
+		}
+
+		if p.FileValidation {
+			err := p.PlaybookOptions.ValidateFiles(p.FileValidationDir)
+			if err != nil {
+				return nil, errors.New("(playbook::Command)", "Error validating option files", err)
+			}
+		}
+
 		options, err := p.PlaybookOptions.GenerateCommandOptions()
 		if err != nil {
 			return nil, errors.New("(playbook::Command)", "Error creating options", err)
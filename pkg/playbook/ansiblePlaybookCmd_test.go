@@ -1,8 +1,13 @@
 package playbook
 
 import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"syscall"
 	"testing"
 
+	errors "github.com/apenella/go-common-utils/error"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -65,7 +70,8 @@ func TestCommand(t *testing.T) {
 			desc: "Testing generate AnsiblePlaybookCmd command",
 			err:  nil,
 			ansiblePlaybookCmd: &AnsiblePlaybookCmd{
-				Playbooks: []string{"test/ansible/site.yml"},
+				Playbooks:      []string{"test/ansible/site.yml"},
+				SkipValidation: true,
 
 				PlaybookOptions: &AnsiblePlaybookOptions{
 					AskBecomePass:    true,
@@ -127,6 +133,51 @@ func TestCommand(t *testing.T) {
 				"test/ansible/site.yml",
 			},
 		},
+		{
+			desc: "Testing error generating AnsiblePlaybookCmd command with invalid options",
+			err: errors.New("(playbook::Command)", "Error validating options",
+				errors.New("(playbook::Validate)", "Invalid ansible-playbook options",
+					fmt.Errorf("only one verbosity level can be set, but 2 were set"),
+				),
+			),
+			ansiblePlaybookCmd: &AnsiblePlaybookCmd{
+				Playbooks: []string{"test/ansible/site.yml"},
+				PlaybookOptions: &AnsiblePlaybookOptions{
+					Verbose:  true,
+					VerboseV: true,
+				},
+			},
+		},
+		{
+			desc: "Testing generate AnsiblePlaybookCmd command without validating the option files",
+			err:  nil,
+			ansiblePlaybookCmd: NewAnsiblePlaybookCmd(
+				WithPlaybooks("site.yml"),
+				WithPlaybookOptions(&AnsiblePlaybookOptions{
+					PrivateKey: "missing",
+				}),
+			),
+			command: []string{
+				"ansible-playbook",
+				"--private-key=missing",
+				"site.yml",
+			},
+		},
+		{
+			desc: "Testing error generating AnsiblePlaybookCmd command validating the option files",
+			err: errors.New("(playbook::Command)", "Error validating option files",
+				errors.New("(playbook::ValidateFiles)", "Invalid ansible-playbook option files",
+					fmt.Errorf("'%s' file is not valid: %w", PrivateKeyFlag, &fs.PathError{Op: "stat", Path: filepath.Join("test", "missing"), Err: syscall.ENOENT}),
+				),
+			),
+			ansiblePlaybookCmd: NewAnsiblePlaybookCmd(
+				WithPlaybooks("site.yml"),
+				WithPlaybookOptions(&AnsiblePlaybookOptions{
+					PrivateKey: "missing",
+				}),
+				WithFileValidation("test"),
+			),
+		},
 	}
 
 	for _, test := range tests {
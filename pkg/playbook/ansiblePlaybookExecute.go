@@ -4,11 +4,22 @@ import (
 	"context"
 
 	"github.com/apenella/go-ansible/v2/pkg/execute"
+	"github.com/apenella/go-ansible/v2/pkg/execute/credentials"
+	"github.com/apenella/go-ansible/v2/pkg/internal/inventoryprovider"
+	"github.com/apenella/go-ansible/v2/pkg/inventory"
+	"github.com/apenella/go-ansible/v2/pkg/inventory/dynamic"
+	"github.com/apenella/go-ansible/v2/pkg/vault/client"
+	errors "github.com/apenella/go-common-utils/error"
 )
 
 // AnsiblePlaybookExecute is an executor for ansible-playbook command that runs the command using a DefaultExecute with default options
 type AnsiblePlaybookExecute struct {
-	cmd *AnsiblePlaybookCmd
+	cmd                *AnsiblePlaybookCmd
+	credentialsOptions []credentials.OptionsFunc
+	inventory          *inventory.Inventory
+	inventoryFunc      dynamic.InventoryFunc
+	inventorySourceErr error
+	vaultClientOptions []client.OptionsFunc
 }
 
 // NewAnsiblePlaybookExecute returns a new AnsiblePlaybookExecute. It receives a list of playbooks to be executed
@@ -39,18 +50,176 @@ func (e *AnsiblePlaybookExecute) WithPlaybookOptions(options *AnsiblePlaybookOpt
 	return e
 }
 
+// WithBecomePasswordReader returns an AnsiblePlaybookExecute that reads the become password from the reader. The password is provided to ansible-playbook through an ephemeral file, only readable by the owner, that is removed once the command finishes
+func (e *AnsiblePlaybookExecute) WithBecomePasswordReader(reader credentials.PasswordReader) *AnsiblePlaybookExecute {
+	e.credentialsOptions = append(e.credentialsOptions, credentials.WithBecomePasswordReader(reader))
+
+	return e
+}
+
+// WithConnectionPasswordReader returns an AnsiblePlaybookExecute that reads the connection password from the reader. The password is provided to ansible-playbook through an ephemeral file, only readable by the owner, that is removed once the command finishes
+func (e *AnsiblePlaybookExecute) WithConnectionPasswordReader(reader credentials.PasswordReader) *AnsiblePlaybookExecute {
+	e.credentialsOptions = append(e.credentialsOptions, credentials.WithConnectionPasswordReader(reader))
+
+	return e
+}
+
+// WithInventory returns an AnsiblePlaybookExecute that runs against the in-memory inventory. The inventory is rendered to an ephemeral file, only readable by the owner, that is removed once the command finishes
+func (e *AnsiblePlaybookExecute) WithInventory(inventory *inventory.Inventory) *AnsiblePlaybookExecute {
+	e.inventory = inventory
+
+	return e
+}
+
+// WithInventoryFunc returns an AnsiblePlaybookExecute that runs against the inventory computed by fn. The inventory is served to ansible-playbook through a dynamic inventory script, without writing it to disk, and fn is called every time ansible-playbook loads the inventory
+func (e *AnsiblePlaybookExecute) WithInventoryFunc(fn dynamic.InventoryFunc) *AnsiblePlaybookExecute {
+	e.inventoryFunc = fn
+	e.inventorySourceErr = nil
+
+	return e
+}
+
+// WithInventorySource returns an AnsiblePlaybookExecute that runs against the inventory provided by the source, such as a Terraform state or an OpenSSH client configuration. The inventory is served to ansible-playbook through a dynamic inventory script, as WithInventoryFunc does. Execute returns an error when the source is nil
+func (e *AnsiblePlaybookExecute) WithInventorySource(source inventory.InventorySource) *AnsiblePlaybookExecute {
+	e.inventoryFunc = nil
+	e.inventorySourceErr = nil
+
+	if source == nil {
+		e.inventorySourceErr = errors.New("(playbook::AnsiblePlaybookExecute::WithInventorySource)", "Inventory source is nil")
+		return e
+	}
+	e.inventoryFunc = source.Inventory
+
+	return e
+}
+
+// WithoutValidation returns an AnsiblePlaybookExecute that does not validate the playbook options when the command is generated
+func (e *AnsiblePlaybookExecute) WithoutValidation() *AnsiblePlaybookExecute {
+	e.cmd.SkipValidation = true
+
+	return e
+}
+
+// WithVaultPasswordReader returns an AnsiblePlaybookExecute that reads the password of the vault id label from the reader. The password is served to ansible-playbook through a vault client script, without writing it to disk
+func (e *AnsiblePlaybookExecute) WithVaultPasswordReader(label string, reader client.PasswordReader) *AnsiblePlaybookExecute {
+	e.vaultClientOptions = append(e.vaultClientOptions, client.WithPasswordReader(label, reader))
+
+	return e
+}
+
 // Execute method runs the ansible-playbook command using a DefaultExecute with default options
 func (e *AnsiblePlaybookExecute) Execute(ctx context.Context) error {
+	errContext := "(playbook::AnsiblePlaybookExecute::Execute)"
+
+	cmd := e.cmd
+
+	if e.inventorySourceErr != nil {
+		return e.inventorySourceErr
+	}
+
+	inventoryOption := ""
+	if cmd.PlaybookOptions != nil {
+		inventoryOption = cmd.PlaybookOptions.Inventory
+	}
+
+	inventoryProvider := &inventoryprovider.InventoryProvider{
+		Inventory:     e.inventory,
+		InventoryFunc: e.inventoryFunc,
+	}
+	inventorySource, closeInventory, err := inventoryProvider.Provide(ctx, inventoryOption)
+	if err != nil {
+		return err
+	}
+	defer closeInventory()
+
+	if inventorySource != "" {
+		cmd = cmd.withInventoryFile(inventorySource)
+	}
 
 	exec := execute.NewDefaultExecute(
-		execute.WithCmd(e.cmd),
 		execute.WithErrorEnrich(NewAnsiblePlaybookErrorEnrich()),
 	)
 
-	err := exec.Execute(ctx)
+	if len(e.credentialsOptions) > 0 {
+		binary := e.cmd.Binary
+		if binary == "" {
+			binary = DefaultAnsiblePlaybookBinary
+		}
+
+		// the binary version is detected as the executor runs the command
+		options := append([]credentials.OptionsFunc{
+			credentials.WithBinary(binary),
+			credentials.WithExecution(exec.Execution()),
+		}, e.credentialsOptions...)
+		files, err := credentials.NewCredentials(options...).Write(ctx)
+		if err != nil {
+			return errors.New(errContext, "Error providing the become and connection passwords", err)
+		}
+		defer files.Close()
+
+		cmd = cmd.withCredentialFiles(files)
+	}
+
+	exec.Cmd = cmd
+
+	vaultClientOptions := e.vaultPasswordClientOptions()
+	if len(vaultClientOptions) > 0 {
+		return client.NewAnsibleWithVaultPasswordClientExecute(exec, vaultClientOptions...).Execute(ctx)
+	}
+
+	err = exec.Execute(ctx)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// withCredentialFiles returns a copy of the command whose options pass the credential files to ansible-playbook
+func (c *AnsiblePlaybookCmd) withCredentialFiles(files *credentials.CredentialFiles) *AnsiblePlaybookCmd {
+	cmd := *c
+
+	options := &AnsiblePlaybookOptions{}
+	if c.PlaybookOptions != nil {
+		optionsCopy := *c.PlaybookOptions
+		options = &optionsCopy
+	}
+	options.ExtraVarsFile = append([]string{}, options.ExtraVarsFile...)
+
+	if files.BecomePasswordFile != "" {
+		options.BecomePasswordFile = files.BecomePasswordFile
+	}
+
+	if files.ConnectionPasswordFile != "" {
+		options.ConnectionPasswordFile = files.ConnectionPasswordFile
+	}
+
+	if files.ExtraVarsFile != "" {
+		options.ExtraVarsFile = append(options.ExtraVarsFile, files.ExtraVarsFileArg())
+	}
+
+	cmd.PlaybookOptions = options
+
+	return &cmd
+}
+
+// withInventoryFile returns a copy of the command whose options pass the inventory file to ansible-playbook
+func (c *AnsiblePlaybookCmd) withInventoryFile(file string) *AnsiblePlaybookCmd {
+	cmd := *c
+
+	options := &AnsiblePlaybookOptions{}
+	if c.PlaybookOptions != nil {
+		optionsCopy := *c.PlaybookOptions
+		options = &optionsCopy
+	}
+	options.Inventory = file
+
+	cmd.PlaybookOptions = options
+
+	return &cmd
+}
+
+// vaultPasswordClientOptions returns the options of the vault password client that serves the passwords of the options VaultPasswordReaders and of the executor vault password readers
+func (e *AnsiblePlaybookExecute) vaultPasswordClientOptions() []client.OptionsFunc {
+	return append(e.cmd.PlaybookOptions.VaultClientOptions(), e.vaultClientOptions...)
+}
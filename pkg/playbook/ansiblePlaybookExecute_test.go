@@ -1,8 +1,18 @@
 package playbook
 
 import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
 
+	"github.com/apenella/go-ansible/v2/pkg/execute/credentials"
+	"github.com/apenella/go-ansible/v2/pkg/inventory"
+	"github.com/apenella/go-ansible/v2/pkg/inventory/dynamic"
+	"github.com/apenella/go-ansible/v2/pkg/inventory/sshconfig"
+	"github.com/apenella/go-ansible/v2/pkg/vault/client"
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -92,3 +102,411 @@ func TestWithPlaybookOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestWithVaultPasswordReader(t *testing.T) {
+	tests := []struct {
+		desc    string
+		labels  []string
+		options int
+	}{
+		{
+			desc:    "Testing setting vault password readers to AnsiblePlaybookExecute",
+			labels:  []string{"dev", "prod"},
+			options: 2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			e := &AnsiblePlaybookExecute{
+				cmd: &AnsiblePlaybookCmd{},
+			}
+
+			for _, label := range test.labels {
+				e = e.WithVaultPasswordReader(label, text.NewReadPasswordFromText(text.WithText(label)))
+			}
+
+			assert.Len(t, e.vaultClientOptions, test.options)
+		})
+	}
+}
+
+func TestVaultPasswordClientOptions(t *testing.T) {
+	t.Log("Testing the vault password client options include the options vault password readers")
+
+	e := &AnsiblePlaybookExecute{
+		cmd: &AnsiblePlaybookCmd{
+			PlaybookOptions: &AnsiblePlaybookOptions{
+				VaultPasswordReaders: map[string]client.PasswordReader{
+					"dev": text.NewReadPasswordFromText(text.WithText("dev-password")),
+				},
+			},
+		},
+	}
+	e = e.WithVaultPasswordReader("prod", text.NewReadPasswordFromText(text.WithText("prod-password")))
+
+	assert.Len(t, e.vaultPasswordClientOptions(), 2)
+}
+
+func TestWithBecomeAndConnectionPasswordReader(t *testing.T) {
+	t.Log("Testing setting become and connection password readers to AnsiblePlaybookExecute")
+
+	e := &AnsiblePlaybookExecute{
+		cmd: &AnsiblePlaybookCmd{},
+	}
+
+	e = e.WithBecomePasswordReader(text.NewReadPasswordFromText(text.WithText("become"))).
+		WithConnectionPasswordReader(text.NewReadPasswordFromText(text.WithText("connection")))
+
+	assert.Len(t, e.credentialsOptions, 2)
+}
+
+func TestWithCredentialFiles(t *testing.T) {
+	tests := []struct {
+		desc     string
+		cmd      *AnsiblePlaybookCmd
+		files    *credentials.CredentialFiles
+		expected *AnsiblePlaybookOptions
+	}{
+		{
+			desc: "Testing set the password files on the options",
+			cmd: &AnsiblePlaybookCmd{
+				PlaybookOptions: &AnsiblePlaybookOptions{
+					Become:        true,
+					ExtraVarsFile: []string{"@vars.yml"},
+				},
+			},
+			files: &credentials.CredentialFiles{
+				BecomePasswordFile:     "/tmp/become-password",
+				ConnectionPasswordFile: "/tmp/connection-password",
+			},
+			expected: &AnsiblePlaybookOptions{
+				Become:                 true,
+				BecomePasswordFile:     "/tmp/become-password",
+				ConnectionPasswordFile: "/tmp/connection-password",
+				ExtraVarsFile:          []string{"@vars.yml"},
+			},
+		},
+		{
+			desc: "Testing set the extra vars file on undefined options",
+			cmd:  &AnsiblePlaybookCmd{},
+			files: &credentials.CredentialFiles{
+				ExtraVarsFile: "/tmp/credentials.json",
+			},
+			expected: &AnsiblePlaybookOptions{
+				ExtraVarsFile: []string{"@/tmp/credentials.json"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			original := test.cmd.PlaybookOptions
+			var originalCopy AnsiblePlaybookOptions
+			if original != nil {
+				originalCopy = *original
+			}
+
+			res := test.cmd.withCredentialFiles(test.files)
+			assert.Equal(t, test.expected, res.PlaybookOptions)
+
+			// the command options are not modified
+			assert.Equal(t, original, test.cmd.PlaybookOptions)
+			if original != nil {
+				assert.Equal(t, originalCopy, *original)
+			}
+		})
+	}
+}
+
+func TestExecuteWithBecomePasswordReader(t *testing.T) {
+	t.Log("Testing execute providing the become password through a password file")
+
+	dir := t.TempDir()
+	output := filepath.Join(dir, "become-password")
+	binary := filepath.Join(dir, "ansible-playbook")
+
+	// the fake binary reports a version that supports the password files and copies the become password file to the output file
+	script := `#!/bin/sh
+if [ "$1" = "--version" ]; then
+  echo "ansible-playbook [core 2.15.5]"
+  exit 0
+fi
+for arg in "$@"; do
+  case "$arg" in
+    --become-password-file=*) cat "${arg#--become-password-file=}" > "` + output + `" ;;
+  esac
+done
+`
+	err := os.WriteFile(binary, []byte(script), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = NewAnsiblePlaybookExecute("site.yml").
+		WithBinary(binary).
+		WithBecomePasswordReader(text.NewReadPasswordFromText(text.WithText("become-secret"))).
+		Execute(context.TODO())
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Equal(t, "become-secret", string(content))
+}
+
+func TestWithInventory(t *testing.T) {
+	t.Log("Testing setting an in-memory inventory to AnsiblePlaybookExecute")
+
+	inv, err := inventory.NewInventoryBuilder().WithHost("web1", "web").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := &AnsiblePlaybookExecute{
+		cmd: &AnsiblePlaybookCmd{},
+	}
+
+	e = e.WithInventory(inv)
+
+	assert.Equal(t, inv, e.inventory)
+}
+
+func TestWithInventoryFile(t *testing.T) {
+	tests := []struct {
+		desc     string
+		cmd      *AnsiblePlaybookCmd
+		expected *AnsiblePlaybookOptions
+	}{
+		{
+			desc: "Testing set the inventory file on the options",
+			cmd: &AnsiblePlaybookCmd{
+				PlaybookOptions: &AnsiblePlaybookOptions{
+					Become: true,
+				},
+			},
+			expected: &AnsiblePlaybookOptions{
+				Become:    true,
+				Inventory: "/tmp/inventory.yml",
+			},
+		},
+		{
+			desc: "Testing set the inventory file on undefined options",
+			cmd:  &AnsiblePlaybookCmd{},
+			expected: &AnsiblePlaybookOptions{
+				Inventory: "/tmp/inventory.yml",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			original := test.cmd.PlaybookOptions
+			var originalCopy AnsiblePlaybookOptions
+			if original != nil {
+				originalCopy = *original
+			}
+
+			res := test.cmd.withInventoryFile("/tmp/inventory.yml")
+			assert.Equal(t, test.expected, res.PlaybookOptions)
+
+			// the command options are not modified
+			assert.Equal(t, original, test.cmd.PlaybookOptions)
+			if original != nil {
+				assert.Equal(t, originalCopy, *original)
+			}
+		})
+	}
+}
+
+func TestExecuteWithInventory(t *testing.T) {
+	t.Log("Testing execute providing an in-memory inventory through an ephemeral file")
+
+	dir := t.TempDir()
+	output := filepath.Join(dir, "inventory")
+	paths := filepath.Join(dir, "inventory-path")
+	binary := filepath.Join(dir, "ansible-playbook")
+
+	// the fake binary copies the inventory file to the output file and writes its path to the paths file
+	script := `#!/bin/sh
+for arg in "$@"; do
+  case "$arg" in
+    --inventory=*) cat "${arg#--inventory=}" > "` + output + `"; echo "${arg#--inventory=}" > "` + paths + `" ;;
+  esac
+done
+`
+	err := os.WriteFile(binary, []byte(script), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inv, err := inventory.NewInventoryBuilder().
+		WithHost("web1", "web").
+		WithHostConnection("web1", inventory.ConnectionVars{Host: "10.0.0.1"}).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = NewAnsiblePlaybookExecute("site.yml").
+		WithBinary(binary).
+		WithInventory(inv).
+		Execute(context.TODO())
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Equal(t, "all:\n  hosts:\n    web1:\n      ansible_host: 10.0.0.1\n  children:\n    web:\n      hosts:\n        web1: {}\n", string(content))
+
+	// the inventory file is removed once the command finishes
+	path, err := os.ReadFile(paths)
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Dir(string(path[:len(path)-1])))
+	assert.True(t, os.IsNotExist(err))
+
+	t.Log("Testing error executing with an in-memory inventory and the inventory option")
+	err = NewAnsiblePlaybookExecute("site.yml").
+		WithBinary(binary).
+		WithPlaybookOptions(&AnsiblePlaybookOptions{Inventory: "hosts.ini"}).
+		WithInventory(inv).
+		Execute(context.TODO())
+	assert.EqualError(t, err, "Inventory option and in-memory inventory are mutually exclusive")
+}
+
+func TestWithInventoryFunc(t *testing.T) {
+	t.Log("Testing setting an inventory function to AnsiblePlaybookExecute")
+
+	e := &AnsiblePlaybookExecute{
+		cmd: &AnsiblePlaybookCmd{},
+	}
+
+	e = e.WithInventoryFunc(func(ctx context.Context) (*inventory.Inventory, error) {
+		return inventory.NewInventory(), nil
+	})
+
+	assert.NotNil(t, e.inventoryFunc)
+}
+
+func TestWithoutValidation(t *testing.T) {
+	t.Log("Testing disabling the options validation of AnsiblePlaybookExecute")
+
+	e := &AnsiblePlaybookExecute{
+		cmd: &AnsiblePlaybookCmd{
+			Playbooks: []string{"site.yml"},
+			PlaybookOptions: &AnsiblePlaybookOptions{
+				Forks: "ten",
+			},
+		},
+	}
+
+	_, err := e.cmd.Command()
+	assert.Error(t, err)
+
+	e = e.WithoutValidation()
+
+	assert.True(t, e.cmd.SkipValidation)
+	_, err = e.cmd.Command()
+	assert.NoError(t, err)
+}
+
+func TestWithInventorySource(t *testing.T) {
+	t.Log("Testing setting an inventory source to AnsiblePlaybookExecute")
+
+	e := &AnsiblePlaybookExecute{
+		cmd: &AnsiblePlaybookCmd{},
+	}
+
+	e = e.WithInventorySource(sshconfig.NewSSHConfigSource(sshconfig.WithConfigFile("../inventory/test/sshconfig/config")))
+
+	assert.NotNil(t, e.inventoryFunc)
+
+	inv, err := e.inventoryFunc(context.TODO())
+	assert.NoError(t, err)
+	assert.NotNil(t, inv.Host("bastion"))
+}
+
+func TestExecuteWithNilInventorySource(t *testing.T) {
+	t.Log("Testing error executing with a nil inventory source")
+
+	e := &AnsiblePlaybookExecute{
+		cmd: &AnsiblePlaybookCmd{},
+	}
+
+	err := e.WithInventoryFunc(func(ctx context.Context) (*inventory.Inventory, error) {
+		return inventory.NewInventory(), nil
+	}).WithInventorySource(nil).Execute(context.TODO())
+
+	assert.Nil(t, e.inventoryFunc)
+	assert.EqualError(t, err, "Inventory source is nil")
+}
+
+func TestExecuteWithInventoryFunc(t *testing.T) {
+	_, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 is required to run the dynamic inventory script")
+	}
+
+	t.Log("Testing execute providing the inventory computed by a function through a dynamic inventory script")
+
+	dir := t.TempDir()
+	output := filepath.Join(dir, "inventory")
+	paths := filepath.Join(dir, "inventory-path")
+	binary := filepath.Join(dir, "ansible-playbook")
+
+	// the fake binary runs the dynamic inventory script, writing its output to the output file and its path to the paths file
+	script := `#!/bin/sh
+for arg in "$@"; do
+  case "$arg" in
+    --inventory=*) "${arg#--inventory=}" --list > "` + output + `"; echo "${arg#--inventory=}" > "` + paths + `" ;;
+  esac
+done
+`
+	err = os.WriteFile(binary, []byte(script), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(ctx context.Context) (*inventory.Inventory, error) {
+		return inventory.NewInventoryBuilder().
+			WithHost("web1", "web").
+			WithHostConnection("web1", inventory.ConnectionVars{Host: "10.0.0.1"}).
+			Build()
+	}
+
+	err = NewAnsiblePlaybookExecute("site.yml").
+		WithBinary(binary).
+		WithInventoryFunc(fn).
+		Execute(context.TODO())
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"all":{"hosts":[]},"web":{"hosts":["web1"]},"_meta":{"hostvars":{"web1":{"ansible_host":"10.0.0.1"}}}}`, string(content))
+
+	// the dynamic inventory script is removed once the command finishes
+	path, err := os.ReadFile(paths)
+	assert.NoError(t, err)
+	assert.Equal(t, dynamic.DynamicInventoryScriptName, filepath.Base(string(path[:len(path)-1])))
+	_, err = os.Stat(filepath.Dir(string(path[:len(path)-1])))
+	assert.True(t, os.IsNotExist(err))
+
+	t.Log("Testing error executing with an inventory function and the inventory option")
+	err = NewAnsiblePlaybookExecute("site.yml").
+		WithBinary(binary).
+		WithPlaybookOptions(&AnsiblePlaybookOptions{Inventory: "hosts.ini"}).
+		WithInventoryFunc(fn).
+		Execute(context.TODO())
+	assert.EqualError(t, err, "Inventory option and inventory function are mutually exclusive")
+
+	t.Log("Testing error executing with an inventory function and an in-memory inventory")
+	err = NewAnsiblePlaybookExecute("site.yml").
+		WithBinary(binary).
+		WithInventory(inventory.NewInventory()).
+		WithInventoryFunc(fn).
+		Execute(context.TODO())
+	assert.EqualError(t, err, "In-memory inventory and inventory function are mutually exclusive")
+}
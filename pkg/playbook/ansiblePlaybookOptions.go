@@ -2,7 +2,12 @@ package playbook
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/apenella/go-ansible/v2/pkg/internal/validation"
+	"github.com/apenella/go-ansible/v2/pkg/vault/client"
 	common "github.com/apenella/go-common-utils/data"
 	errors "github.com/apenella/go-common-utils/error"
 )
@@ -95,6 +100,9 @@ const (
 	// ConnectionFlag is the connection flag for ansible-playbook
 	ConnectionFlag = "--connection"
 
+	// ConnectionPasswordFileFlag connection password file
+	ConnectionPasswordFileFlag = "--connection-password-file"
+
 	// PrivateKeyFlag is the private key file flag for ansible-playbook
 	PrivateKeyFlag = "--private-key"
 
@@ -122,6 +130,9 @@ const (
 	// BecomeMethodFlag is ansble-playbook's become method flag
 	BecomeMethodFlag = "--become-method"
 
+	// BecomePasswordFileFlag become password file
+	BecomePasswordFileFlag = "--become-password-file"
+
 	// BecomeUserFlag is ansble-playbook's become user flag
 	BecomeUserFlag = "--become-user"
 
@@ -132,6 +143,14 @@ const (
 	BecomeFlag = "--become"
 )
 
+var (
+	// BecomeMethods are the become methods shipped with ansible. They are suggested on the validation errors, but any valid plugin name is accepted
+	BecomeMethods = validation.BecomeMethods
+
+	// Connections are the connection plugins shipped with ansible. They are suggested on the validation errors, but any valid plugin name is accepted
+	Connections = validation.Connections
+)
+
 // AnsiblePlaybookOptions object has those parameters described on `Options` section within ansible-playbook's man page, and which defines which should be the ansible-playbook execution behavior.
 type AnsiblePlaybookOptions struct {
 
@@ -198,6 +217,9 @@ type AnsiblePlaybookOptions struct {
 	// VaultPasswordFile path to the file holding vault decryption key
 	VaultPasswordFile string
 
+	// VaultPasswordReaders are the readers of the vault passwords, by vault id label. The passwords are served to ansible-playbook through a vault client script, without writing them to disk, when the command runs through the AnsiblePlaybookExecute or an executor wrapped by the vault client AnsibleWithVaultPasswordClientExecute with the VaultClientOptions
+	VaultPasswordReaders map[string]client.PasswordReader
+
 	// Verbose verbose mode enabled
 	Verbose bool
 
@@ -224,6 +246,9 @@ type AnsiblePlaybookOptions struct {
 	// Connection is the type of connection used by ansible-playbook
 	Connection string
 
+	// ConnectionPasswordFile is the file holding the password used to connect to a host
+	ConnectionPasswordFile string
+
 	// PrivateKey is the user's private key file used to connect to a host
 	PrivateKey string
 
@@ -268,6 +293,9 @@ type AnsiblePlaybookOptions struct {
 	// 	- dzdo       Centrify's Direct Authorize
 	BecomeMethod string
 
+	// BecomePasswordFile is the file holding the become user password
+	BecomePasswordFile string
+
 	// BecomeUser is ansble-playbook's become user
 	BecomeUser string
 }
@@ -393,6 +421,10 @@ func (o *AnsiblePlaybookOptions) GenerateCommandOptions() ([]string, error) {
 		cmd = append(cmd, fmt.Sprintf("%s=%s", ConnectionFlag, o.Connection))
 	}
 
+	if o.ConnectionPasswordFile != "" {
+		cmd = append(cmd, fmt.Sprintf("%s=%s", ConnectionPasswordFileFlag, o.ConnectionPasswordFile))
+	}
+
 	if o.PrivateKey != "" {
 		cmd = append(cmd, fmt.Sprintf("%s=%s", PrivateKeyFlag, o.PrivateKey))
 	}
@@ -435,6 +467,10 @@ func (o *AnsiblePlaybookOptions) GenerateCommandOptions() ([]string, error) {
 		cmd = append(cmd, fmt.Sprintf("%s=%s", BecomeMethodFlag, o.BecomeMethod))
 	}
 
+	if o.BecomePasswordFile != "" {
+		cmd = append(cmd, fmt.Sprintf("%s=%s", BecomePasswordFileFlag, o.BecomePasswordFile))
+	}
+
 	if o.BecomeUser != "" {
 		cmd = append(cmd, fmt.Sprintf("%s=%s", BecomeUserFlag, o.BecomeUser))
 	}
@@ -442,6 +478,154 @@ func (o *AnsiblePlaybookOptions) GenerateCommandOptions() ([]string, error) {
 	return cmd, nil
 }
 
+// VaultClientOptions returns the options of the vault password client that serves the VaultPasswordReaders passwords, sorted by vault id label
+func (o *AnsiblePlaybookOptions) VaultClientOptions() []client.OptionsFunc {
+	if o == nil || len(o.VaultPasswordReaders) == 0 {
+		return nil
+	}
+
+	labels := make([]string, 0, len(o.VaultPasswordReaders))
+	for label := range o.VaultPasswordReaders {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	options := make([]client.OptionsFunc, 0, len(labels))
+	for _, label := range labels {
+		options = append(options, client.WithPasswordReader(label, o.VaultPasswordReaders[label]))
+	}
+
+	return options
+}
+
+// Validate checks the options looking for mutually exclusive or meaningless flag combinations and unsupported values. It returns an error that wraps all the detected issues
+func (o *AnsiblePlaybookOptions) Validate() error {
+
+	errContext := "(playbook::Validate)"
+	errs := []error{}
+
+	if o == nil {
+		return errors.New(errContext, "AnsiblePlaybookOptions is nil")
+	}
+
+	verbosityFlags := validation.EnabledFlags(
+		validation.FlagStatus{Flag: VerboseFlag, Enabled: o.Verbose},
+		validation.FlagStatus{Flag: VerboseVFlag, Enabled: o.VerboseV},
+		validation.FlagStatus{Flag: VerboseVVFlag, Enabled: o.VerboseVV},
+		validation.FlagStatus{Flag: VerboseVVVFlag, Enabled: o.VerboseVVV},
+		validation.FlagStatus{Flag: VerboseVVVVFlag, Enabled: o.VerboseVVVV},
+	)
+	if len(verbosityFlags) > 1 {
+		errs = append(errs, fmt.Errorf("only one verbosity level can be set, but %d were set", len(verbosityFlags)))
+	}
+
+	if o.AskVaultPassword && o.VaultPasswordFile != "" {
+		errs = append(errs, fmt.Errorf("'%s' and '%s' are mutually exclusive", AskVaultPasswordFlag, VaultPasswordFileFlag))
+	}
+
+	if o.AskPass && o.ConnectionPasswordFile != "" {
+		errs = append(errs, fmt.Errorf("'%s' and '%s' are mutually exclusive", AskPassFlag, ConnectionPasswordFileFlag))
+	}
+
+	if o.AskBecomePass && o.BecomePasswordFile != "" {
+		errs = append(errs, fmt.Errorf("'%s' and '%s' are mutually exclusive", AskBecomePassFlag, BecomePasswordFileFlag))
+	}
+
+	// listing and syntax check modes do not run any task, so the flags that modify how tasks run are meaningless
+	listFlags := validation.EnabledFlags(
+		validation.FlagStatus{Flag: ListHostsFlag, Enabled: o.ListHosts},
+		validation.FlagStatus{Flag: ListTagsFlag, Enabled: o.ListTags},
+		validation.FlagStatus{Flag: ListTasksFlag, Enabled: o.ListTasks},
+		validation.FlagStatus{Flag: SyntaxCheckFlag, Enabled: o.SyntaxCheck},
+	)
+	runFlags := validation.EnabledFlags(
+		validation.FlagStatus{Flag: CheckFlag, Enabled: o.Check},
+		validation.FlagStatus{Flag: DiffFlag, Enabled: o.Diff},
+		validation.FlagStatus{Flag: StepFlag, Enabled: o.Step},
+	)
+	for _, listFlag := range listFlags {
+		for _, runFlag := range runFlags {
+			errs = append(errs, fmt.Errorf("'%s' is meaningless with '%s'", runFlag, listFlag))
+		}
+	}
+
+	if o.Forks != "" {
+		forks, err := strconv.Atoi(o.Forks)
+		if err != nil || forks < 1 {
+			errs = append(errs, fmt.Errorf("'%s' must be a positive integer, but '%s' was provided", ForksFlag, o.Forks))
+		}
+	}
+
+	if o.Timeout < 0 {
+		errs = append(errs, fmt.Errorf("'%s' must not be negative, but '%d' was provided", TimeoutFlag, o.Timeout))
+	}
+
+	if o.BecomeMethod != "" && !validation.IsPluginName(o.BecomeMethod) {
+		errs = append(errs, fmt.Errorf("'%s' is not a valid become method. Use a plugin name, such as '%s', or a fully qualified collection name", o.BecomeMethod, strings.Join(BecomeMethods, "', '")))
+	}
+
+	if o.Connection != "" && !validation.IsPluginName(o.Connection) {
+		errs = append(errs, fmt.Errorf("'%s' is not a valid connection. Use a plugin name, such as '%s', or a fully qualified collection name", o.Connection, strings.Join(Connections, "', '")))
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid ansible-playbook options", errs...)
+	}
+
+	return nil
+}
+
+// ValidateFiles checks that the files referenced by the options exist and are not directories. The relative files are resolved against dir, which is the directory where the command runs, or against the working directory when dir is empty. It is not part of Validate because the command may run on another directory, host or container
+func (o *AnsiblePlaybookOptions) ValidateFiles(dir string) error {
+	errContext := "(playbook::ValidateFiles)"
+	errs := []error{}
+
+	if o == nil {
+		return errors.New(errContext, "AnsiblePlaybookOptions is nil")
+	}
+
+	if o.PrivateKey != "" {
+		err := validation.FileExists(dir, o.PrivateKey)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("'%s' file is not valid: %w", PrivateKeyFlag, err))
+		}
+	}
+
+	if o.VaultPasswordFile != "" {
+		err := validation.FileExists(dir, o.VaultPasswordFile)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("'%s' file is not valid: %w", VaultPasswordFileFlag, err))
+		}
+	}
+
+	if o.ConnectionPasswordFile != "" {
+		err := validation.FileExists(dir, o.ConnectionPasswordFile)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("'%s' file is not valid: %w", ConnectionPasswordFileFlag, err))
+		}
+	}
+
+	if o.BecomePasswordFile != "" {
+		err := validation.FileExists(dir, o.BecomePasswordFile)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("'%s' file is not valid: %w", BecomePasswordFileFlag, err))
+		}
+	}
+
+	for _, file := range o.ExtraVarsFile {
+		err := validation.FileExists(dir, strings.TrimPrefix(file, "@"))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("'%s' file is not valid: %w", ExtraVarsFlag, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid ansible-playbook option files", errs...)
+	}
+
+	return nil
+}
+
 // generateVerbosityFlag return a string with the verbose flag. Higher verbosity (more v's) has precedence over lower
 func (o *AnsiblePlaybookOptions) generateVerbosityFlag() (string, error) {
 	if o.Verbose {
@@ -645,6 +829,10 @@ func (o *AnsiblePlaybookOptions) String() string {
 		str = fmt.Sprintf("%s %s=%s", str, ConnectionFlag, o.Connection)
 	}
 
+	if o.ConnectionPasswordFile != "" {
+		str = fmt.Sprintf("%s %s=%s", str, ConnectionPasswordFileFlag, o.ConnectionPasswordFile)
+	}
+
 	if o.PrivateKey != "" {
 		str = fmt.Sprintf("%s %s=%s", str, PrivateKeyFlag, o.PrivateKey)
 	}
@@ -687,6 +875,10 @@ func (o *AnsiblePlaybookOptions) String() string {
 		str = fmt.Sprintf("%s %s=%s", str, BecomeMethodFlag, o.BecomeMethod)
 	}
 
+	if o.BecomePasswordFile != "" {
+		str = fmt.Sprintf("%s %s=%s", str, BecomePasswordFileFlag, o.BecomePasswordFile)
+	}
+
 	if o.BecomeUser != "" {
 		str = fmt.Sprintf("%s %s=%s", str, BecomeUserFlag, o.BecomeUser)
 	}
@@ -0,0 +1,231 @@
+package playbook
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/apenella/go-ansible/v2/pkg/internal/flags"
+	"github.com/spf13/pflag"
+)
+
+// flagAliases relates the alternative long flag names accepted by ansible-playbook to the names used by the AnsiblePlaybookOptions flags
+var flagAliases = map[string]string{
+	"--ask-vault-pass":   AskVaultPasswordFlag,
+	"--become-pass-file": BecomePasswordFileFlag,
+	"--conn-pass-file":   ConnectionPasswordFileFlag,
+	"--inventory-file":   InventoryFlag,
+	"--key-file":         PrivateKeyFlag,
+	"--vault-pass-file":  VaultPasswordFileFlag,
+}
+
+// verboseFlag is the long name of the verbose flag, whose constants are the -v shorthands
+const verboseFlag = "--verbose"
+
+// flagUsages relates the AnsiblePlaybookOptions flags to their help text, copied from the ansible help. Every flag constant must have an entry
+var flagUsages = map[string]string{
+	AskVaultPasswordFlag:       "ask for vault password",
+	CheckFlag:                  "don't make any changes; instead, try to predict some of the changes that may occur",
+	DiffFlag:                   "when changing (small) files and templates, show the differences in those files; works great with --check",
+	ExtraVarsFlag:              "set additional variables as key=value pairs or as a YAML/JSON dictionary, if filename prepend with @",
+	FlushCacheFlag:             "clear the fact cache for every host in inventory",
+	ForceHandlersFlag:          "run handlers even if a task fails",
+	ForksFlag:                  "specify number of parallel processes to use (default=50)",
+	InventoryFlag:              "specify inventory host path or comma separated host list",
+	LimitFlag:                  "further limit selected hosts to an additional pattern",
+	ListHostsFlag:              "outputs a list of matching hosts; does not execute anything else",
+	ListTagsFlag:               "list all available tags",
+	ListTasksFlag:              "list all tasks that would be executed",
+	ModulePathFlag:             "prepend colon-separated path(s) to module library (default=~/.ansible/plugins/modules:/usr/share/ansible/plugins/modules)",
+	SkipTagsFlag:               "only run plays and tasks whose tags do not match these values",
+	StartAtTaskFlag:            "start the playbook at the task matching this name",
+	StepFlag:                   "one-step-at-a-time: confirm each task before running",
+	SyntaxCheckFlag:            "perform a syntax check on the playbook, but do not execute it",
+	TagsFlag:                   "only run plays and tasks tagged with these values",
+	VaultIDFlag:                "the vault identity to use",
+	VaultPasswordFileFlag:      "vault password file",
+	verboseFlag:                "verbose mode (-vvv for more, -vvvv to enable connection debugging)",
+	VersionFlag:                "show program's version number, config file location, configured module search path, module location, executable location and exit",
+	AskPassFlag:                "ask for connection password",
+	ConnectionFlag:             "connection type to use",
+	ConnectionPasswordFileFlag: "connection password file",
+	PrivateKeyFlag:             "use this file to authenticate the connection",
+	SCPExtraArgsFlag:           "specify extra arguments to pass to scp only",
+	SFTPExtraArgsFlag:          "specify extra arguments to pass to sftp only",
+	SSHCommonArgsFlag:          "specify common arguments to pass to sftp/scp/ssh",
+	SSHExtraArgsFlag:           "specify extra arguments to pass to ssh only",
+	TimeoutFlag:                "override the connection timeout in seconds",
+	UserFlag:                   "connect as this user",
+	AskBecomePassFlag:          "ask for privilege escalation password",
+	BecomeFlag:                 "run operations with become",
+	BecomeMethodFlag:           "privilege escalation method to use",
+	BecomePasswordFileFlag:     "become password file",
+	BecomeUserFlag:             "run operations as this user",
+}
+
+// AddFlags registers the AnsiblePlaybookOptions attributes as flags on the flag set, using the ansible flag names and shorthands. The current attribute values are used as the flag defaults and parsing the flag set populates the options. When prefix is not empty, it is prepended to the flag names and the shorthands are not registered to avoid collisions. The ansible flag aliases, such as --inventory-file, are accepted as well
+func (o *AnsiblePlaybookOptions) AddFlags(fs *pflag.FlagSet, prefix string) {
+	flags.NormalizeAliases(fs, prefix, flagAliases)
+
+	fs.BoolVarP(&o.AskVaultPassword, flagName(prefix, AskVaultPasswordFlag), flagShorthand(prefix, "J"), o.AskVaultPassword, flagUsages[AskVaultPasswordFlag])
+	fs.BoolVarP(&o.Check, flagName(prefix, CheckFlag), flagShorthand(prefix, "C"), o.Check, flagUsages[CheckFlag])
+	fs.BoolVarP(&o.Diff, flagName(prefix, DiffFlag), flagShorthand(prefix, "D"), o.Diff, flagUsages[DiffFlag])
+	fs.VarP(&extraVarsValue{options: o}, flagName(prefix, ExtraVarsFlag), flagShorthand(prefix, "e"), flagUsages[ExtraVarsFlag])
+	fs.BoolVar(&o.FlushCache, flagName(prefix, FlushCacheFlag), o.FlushCache, flagUsages[FlushCacheFlag])
+	fs.BoolVar(&o.ForceHandlers, flagName(prefix, ForceHandlersFlag), o.ForceHandlers, flagUsages[ForceHandlersFlag])
+	fs.StringVarP(&o.Forks, flagName(prefix, ForksFlag), flagShorthand(prefix, "f"), o.Forks, flagUsages[ForksFlag])
+	fs.StringVarP(&o.Inventory, flagName(prefix, InventoryFlag), flagShorthand(prefix, "i"), o.Inventory, flagUsages[InventoryFlag])
+	fs.StringVarP(&o.Limit, flagName(prefix, LimitFlag), flagShorthand(prefix, "l"), o.Limit, flagUsages[LimitFlag])
+	fs.BoolVar(&o.ListHosts, flagName(prefix, ListHostsFlag), o.ListHosts, flagUsages[ListHostsFlag])
+	fs.BoolVar(&o.ListTags, flagName(prefix, ListTagsFlag), o.ListTags, flagUsages[ListTagsFlag])
+	fs.BoolVar(&o.ListTasks, flagName(prefix, ListTasksFlag), o.ListTasks, flagUsages[ListTasksFlag])
+	fs.StringVarP(&o.ModulePath, flagName(prefix, ModulePathFlag), flagShorthand(prefix, "M"), o.ModulePath, flagUsages[ModulePathFlag])
+	fs.Var(&listValue{value: &o.SkipTags}, flagName(prefix, SkipTagsFlag), flagUsages[SkipTagsFlag])
+	fs.StringVar(&o.StartAtTask, flagName(prefix, StartAtTaskFlag), o.StartAtTask, flagUsages[StartAtTaskFlag])
+	fs.BoolVar(&o.Step, flagName(prefix, StepFlag), o.Step, flagUsages[StepFlag])
+	fs.BoolVar(&o.SyntaxCheck, flagName(prefix, SyntaxCheckFlag), o.SyntaxCheck, flagUsages[SyntaxCheckFlag])
+	fs.VarP(&listValue{value: &o.Tags}, flagName(prefix, TagsFlag), flagShorthand(prefix, "t"), flagUsages[TagsFlag])
+	fs.Var(flags.NewSingleValue(VaultIDFlag, &o.VaultID), flagName(prefix, VaultIDFlag), flagUsages[VaultIDFlag])
+	fs.StringVar(&o.VaultPasswordFile, flagName(prefix, VaultPasswordFileFlag), o.VaultPasswordFile, flagUsages[VaultPasswordFileFlag])
+	verbose := fs.VarPF(&verbosityValue{options: o}, flagName(prefix, verboseFlag), flagShorthand(prefix, "v"), flagUsages[verboseFlag])
+	verbose.NoOptDefVal = "+1"
+	fs.BoolVar(&o.Version, flagName(prefix, VersionFlag), o.Version, flagUsages[VersionFlag])
+
+	// Connection options
+	fs.BoolVarP(&o.AskPass, flagName(prefix, AskPassFlag), flagShorthand(prefix, "k"), o.AskPass, flagUsages[AskPassFlag])
+	fs.StringVarP(&o.Connection, flagName(prefix, ConnectionFlag), flagShorthand(prefix, "c"), o.Connection, flagUsages[ConnectionFlag])
+	fs.StringVar(&o.ConnectionPasswordFile, flagName(prefix, ConnectionPasswordFileFlag), o.ConnectionPasswordFile, flagUsages[ConnectionPasswordFileFlag])
+	fs.StringVar(&o.PrivateKey, flagName(prefix, PrivateKeyFlag), o.PrivateKey, flagUsages[PrivateKeyFlag])
+	fs.StringVar(&o.SCPExtraArgs, flagName(prefix, SCPExtraArgsFlag), o.SCPExtraArgs, flagUsages[SCPExtraArgsFlag])
+	fs.StringVar(&o.SFTPExtraArgs, flagName(prefix, SFTPExtraArgsFlag), o.SFTPExtraArgs, flagUsages[SFTPExtraArgsFlag])
+	fs.StringVar(&o.SSHCommonArgs, flagName(prefix, SSHCommonArgsFlag), o.SSHCommonArgs, flagUsages[SSHCommonArgsFlag])
+	fs.StringVar(&o.SSHExtraArgs, flagName(prefix, SSHExtraArgsFlag), o.SSHExtraArgs, flagUsages[SSHExtraArgsFlag])
+	fs.IntVarP(&o.Timeout, flagName(prefix, TimeoutFlag), flagShorthand(prefix, "T"), o.Timeout, flagUsages[TimeoutFlag])
+	fs.StringVarP(&o.User, flagName(prefix, UserFlag), flagShorthand(prefix, "u"), o.User, flagUsages[UserFlag])
+
+	// Privilege escalation options
+	fs.BoolVarP(&o.AskBecomePass, flagName(prefix, AskBecomePassFlag), flagShorthand(prefix, "K"), o.AskBecomePass, flagUsages[AskBecomePassFlag])
+	fs.BoolVarP(&o.Become, flagName(prefix, BecomeFlag), flagShorthand(prefix, "b"), o.Become, flagUsages[BecomeFlag])
+	fs.StringVar(&o.BecomeMethod, flagName(prefix, BecomeMethodFlag), o.BecomeMethod, flagUsages[BecomeMethodFlag])
+	fs.StringVar(&o.BecomePasswordFile, flagName(prefix, BecomePasswordFileFlag), o.BecomePasswordFile, flagUsages[BecomePasswordFileFlag])
+	fs.StringVar(&o.BecomeUser, flagName(prefix, BecomeUserFlag), o.BecomeUser, flagUsages[BecomeUserFlag])
+}
+
+// flagName returns the flag name without the leading dashes and prepended by the prefix
+func flagName(prefix, flag string) string {
+	return prefix + strings.TrimLeft(flag, "-")
+}
+
+// flagShorthand returns the flag shorthand when there is no prefix, otherwise returns an empty shorthand
+func flagShorthand(prefix, shorthand string) string {
+	if prefix != "" {
+		return ""
+	}
+
+	return shorthand
+}
+
+// extraVarsValue is a pflag.Value that stores the extra-vars flag values into the AnsiblePlaybookOptions. Values prepended with @ are stored as extra-vars files, the rest are parsed as key=value pairs or as a YAML or JSON dictionary
+type extraVarsValue struct {
+	options *AnsiblePlaybookOptions
+}
+
+// String returns the extra-vars as a string
+func (v *extraVarsValue) String() string {
+	if v.options == nil || len(v.options.ExtraVars) == 0 {
+		return ""
+	}
+
+	extraVars, _ := v.options.generateExtraVarsCommand()
+	return extraVars
+}
+
+// Set adds an extra-vars value to the options
+func (v *extraVarsValue) Set(value string) error {
+	extraVars, files, err := flags.ParseExtraVars(value)
+	if err != nil {
+		return err
+	}
+
+	v.options.ExtraVarsFile = append(v.options.ExtraVarsFile, files...)
+
+	if len(extraVars) > 0 && v.options.ExtraVars == nil {
+		v.options.ExtraVars = map[string]interface{}{}
+	}
+	for name, value := range extraVars {
+		v.options.ExtraVars[name] = value
+	}
+
+	return nil
+}
+
+// Type returns the extra-vars value type
+func (v *extraVarsValue) Type() string {
+	return "extra-vars"
+}
+
+// listValue is a pflag.Value for flags whose values are accumulated as a comma separated list
+type listValue struct {
+	value *string
+}
+
+// String returns the list as a string
+func (v *listValue) String() string {
+	if v.value == nil {
+		return ""
+	}
+
+	return *v.value
+}
+
+// Set appends a value to the list
+func (v *listValue) Set(value string) error {
+	if *v.value == "" {
+		*v.value = value
+		return nil
+	}
+
+	*v.value = fmt.Sprintf("%s,%s", *v.value, value)
+	return nil
+}
+
+// Type returns the list value type
+func (v *listValue) Type() string {
+	return "list"
+}
+
+// verbosityValue is a pflag.Value that counts the verbose flag occurrences and sets the verbosity attributes of the AnsiblePlaybookOptions. Four or more occurrences enable the Verbose attribute
+type verbosityValue struct {
+	count   int
+	options *AnsiblePlaybookOptions
+}
+
+// String returns the verbosity level
+func (v *verbosityValue) String() string {
+	return strconv.Itoa(v.count)
+}
+
+// Set increases the verbosity level when value is +1, otherwise value is the verbosity level
+func (v *verbosityValue) Set(value string) error {
+	if value == "+1" {
+		v.count++
+	} else {
+		count, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid verbosity level '%s'", value)
+		}
+		v.count = count
+	}
+
+	v.options.Verbose = v.count >= 4
+	v.options.VerboseVVV = v.count == 3
+	v.options.VerboseVV = v.count == 2
+	v.options.VerboseV = v.count == 1
+
+	return nil
+}
+
+// Type returns the verbosity value type
+func (v *verbosityValue) Type() string {
+	return "count"
+}
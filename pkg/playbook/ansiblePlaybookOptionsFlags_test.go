@@ -0,0 +1,141 @@
+package playbook
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/internal/flags/flagstest"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAddFlags tests
+func TestAddFlags(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *AnsiblePlaybookOptions
+		prefix  string
+		args    []string
+		res     *AnsiblePlaybookOptions
+		err     error
+	}{
+		{
+			desc:    "Testing add flags and parse short flags",
+			options: &AnsiblePlaybookOptions{},
+			args:    []string{"-i", "127.0.0.1,", "-c", "local", "-b", "-K", "-e", "foo=bar", "-e", "@vars.yml", "-t", "tag1", "-t", "tag2", "-vvv"},
+			res: &AnsiblePlaybookOptions{
+				AskBecomePass: true,
+				Become:        true,
+				Connection:    "local",
+				ExtraVars:     map[string]interface{}{"foo": "bar"},
+				ExtraVarsFile: []string{"@vars.yml"},
+				Inventory:     "127.0.0.1,",
+				Tags:          "tag1,tag2",
+				VerboseVVV:    true,
+			},
+		},
+		{
+			desc:    "Testing add flags with prefix and parse long flags",
+			options: &AnsiblePlaybookOptions{},
+			prefix:  "ansible-",
+			args:    []string{"--ansible-inventory=127.0.0.1,", "--ansible-connection", "local", "--ansible-timeout=10", "--ansible-verbose"},
+			res: &AnsiblePlaybookOptions{
+				Connection: "local",
+				Inventory:  "127.0.0.1,",
+				Timeout:    10,
+				VerboseV:   true,
+			},
+		},
+		{
+			desc:    "Testing add flags with prefix does not register shorthands",
+			options: &AnsiblePlaybookOptions{},
+			prefix:  "ansible-",
+			args:    []string{"-i", "127.0.0.1,"},
+			err:     fmt.Errorf("unknown shorthand flag: 'i' in -i"),
+		},
+		{
+			desc:    "Testing add flags and parse flag aliases",
+			options: &AnsiblePlaybookOptions{},
+			args:    []string{"--inventory-file", "127.0.0.1,", "--key-file=id_rsa", "--vault-pass-file", "vault.txt"},
+			res: &AnsiblePlaybookOptions{
+				Inventory:         "127.0.0.1,",
+				PrivateKey:        "id_rsa",
+				VaultPasswordFile: "vault.txt",
+			},
+		},
+		{
+			desc:    "Testing add flags with prefix and parse flag aliases",
+			options: &AnsiblePlaybookOptions{},
+			prefix:  "ansible-",
+			args:    []string{"--ansible-inventory-file", "127.0.0.1,", "--ansible-conn-pass-file=password.txt"},
+			res: &AnsiblePlaybookOptions{
+				ConnectionPasswordFile: "password.txt",
+				Inventory:              "127.0.0.1,",
+			},
+		},
+		{
+			desc: "Testing add flags keeps the options values as defaults",
+			options: &AnsiblePlaybookOptions{
+				Connection: "local",
+				Inventory:  "127.0.0.1,",
+			},
+			args: []string{"--user", "apenella"},
+			res: &AnsiblePlaybookOptions{
+				Connection: "local",
+				Inventory:  "127.0.0.1,",
+				User:       "apenella",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			test.options.AddFlags(fs, test.prefix)
+
+			err := fs.Parse(test.args)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, test.options)
+			}
+		})
+	}
+}
+
+// TestAddFlagsUsage tests that the flags usage is the ansible-playbook help text
+func TestAddFlagsUsage(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	options := &AnsiblePlaybookOptions{}
+	options.AddFlags(fs, "")
+
+	inventory := fs.Lookup("inventory")
+	assert.NotNil(t, inventory)
+	assert.Equal(t, "i", inventory.Shorthand)
+	assert.Equal(t, "specify inventory host path or comma separated host list", inventory.Usage)
+}
+
+// TestAddFlagsUsages tests that every flag constant is registered with its help text, because the flagUsages entries are maintained by hand
+func TestAddFlagsUsages(t *testing.T) {
+	longFlags, err := flagstest.LongFlags("ansiblePlaybookOptions.go", "ansiblePlaybookOptionsFlags.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	options := &AnsiblePlaybookOptions{}
+	options.AddFlags(fs, "")
+
+	for _, longFlag := range longFlags {
+		flag := fs.Lookup(strings.TrimPrefix(longFlag, "--"))
+		if assert.NotNil(t, flag, "flag %s is not registered", longFlag) {
+			assert.NotEmpty(t, flag.Usage, "flag %s has no help text", longFlag)
+			assert.Equal(t, flagUsages[longFlag], flag.Usage)
+		}
+	}
+}
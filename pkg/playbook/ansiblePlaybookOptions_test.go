@@ -1,9 +1,17 @@
 package playbook
 
 import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
 	"testing"
 
 	"github.com/apenella/go-ansible/v2/pkg/vault"
+	"github.com/apenella/go-ansible/v2/pkg/vault/client"
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
 	errors "github.com/apenella/go-common-utils/error"
 	"github.com/stretchr/testify/assert"
 )
@@ -31,15 +39,17 @@ func TestGenerateCommandOptions(t *testing.T) {
 		{
 			desc: "Testing AnsiblePlaybookOptions except extra vars",
 			ansiblePlaybookOptions: &AnsiblePlaybookOptions{
-				AskBecomePass:    true,
-				AskPass:          true,
-				AskVaultPassword: true,
-				Become:           true,
-				BecomeMethod:     "become-method",
-				BecomeUser:       "become-user",
-				Check:            true,
-				Connection:       "local",
-				Diff:             true,
+				AskBecomePass:          true,
+				AskPass:                true,
+				AskVaultPassword:       true,
+				Become:                 true,
+				BecomeMethod:           "become-method",
+				BecomePasswordFile:     "become-password-file",
+				BecomeUser:             "become-user",
+				Check:                  true,
+				Connection:             "local",
+				ConnectionPasswordFile: "connection-password-file",
+				Diff:                   true,
 				ExtraVars: map[string]interface{}{
 					"extra": "var",
 				},
@@ -97,6 +107,7 @@ func TestGenerateCommandOptions(t *testing.T) {
 				"--version",
 				"--ask-pass",
 				"--connection=local",
+				"--connection-password-file=connection-password-file",
 				"--private-key=private-key",
 				"--scp-extra-args=scp-extra-args1 scp-extra-args2",
 				"--sftp-extra-args=sftp-extra-args1 sftp-extra-args2",
@@ -107,6 +118,7 @@ func TestGenerateCommandOptions(t *testing.T) {
 				"--ask-become-pass",
 				"--become",
 				"--become-method=become-method",
+				"--become-password-file=become-password-file",
 				"--become-user=become-user",
 			},
 		},
@@ -470,15 +482,17 @@ func TestAnsiblePlaybookOptionsString(t *testing.T) {
 		{
 			desc: "Testing AnsiblePlaybookOptions except extra vars",
 			ansiblePlaybookOptions: &AnsiblePlaybookOptions{
-				AskBecomePass:    true,
-				AskPass:          true,
-				AskVaultPassword: true,
-				Become:           true,
-				BecomeMethod:     "become-method",
-				BecomeUser:       "become-user",
-				Check:            true,
-				Connection:       "local",
-				Diff:             true,
+				AskBecomePass:          true,
+				AskPass:                true,
+				AskVaultPassword:       true,
+				Become:                 true,
+				BecomeMethod:           "become-method",
+				BecomePasswordFile:     "become-password-file",
+				BecomeUser:             "become-user",
+				Check:                  true,
+				Connection:             "local",
+				ConnectionPasswordFile: "connection-password-file",
+				Diff:                   true,
 				ExtraVars: map[string]interface{}{
 					"extra": "var",
 				},
@@ -509,7 +523,7 @@ func TestAnsiblePlaybookOptionsString(t *testing.T) {
 				Verbose:           true,
 				Version:           true,
 			},
-			res: " --ask-vault-password --check --diff --extra-vars='{\"extra\":\"var\"}' --extra-vars=@test.yml --flush-cache --force-handlers --forks=10 --inventory=inventory --limit=limit --list-hosts --list-tags --list-tasks --module-path=module-path --skip-tags=skip-tags --start-at-task=start-at-task --step --syntax-check --tags=tags --vault-id=vault-ID --vault-password-file=vault-password-file -vvvv --version --ask-pass --connection=local --private-key=private-key --scp-extra-args='scp-extra-args' --sftp-extra-args='sftp-extra-args' --ssh-common-args='ssh-common-args' --ssh-extra-args='ssh-extra-args' --timeout=11 --user=user --ask-become-pass --become --become-method=become-method --become-user=become-user",
+			res: " --ask-vault-password --check --diff --extra-vars='{\"extra\":\"var\"}' --extra-vars=@test.yml --flush-cache --force-handlers --forks=10 --inventory=inventory --limit=limit --list-hosts --list-tags --list-tasks --module-path=module-path --skip-tags=skip-tags --start-at-task=start-at-task --step --syntax-check --tags=tags --vault-id=vault-ID --vault-password-file=vault-password-file -vvvv --version --ask-pass --connection=local --connection-password-file=connection-password-file --private-key=private-key --scp-extra-args='scp-extra-args' --sftp-extra-args='sftp-extra-args' --ssh-common-args='ssh-common-args' --ssh-extra-args='ssh-extra-args' --timeout=11 --user=user --ask-become-pass --become --become-method=become-method --become-password-file=become-password-file --become-user=become-user",
 		},
 		{
 			desc: "Testing AnsiblePlaybookOptions setting the VerboseV flag as true",
@@ -553,3 +567,222 @@ func TestAnsiblePlaybookOptionsString(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate(t *testing.T) {
+
+	tempDir := t.TempDir()
+	privateKey := filepath.Join(tempDir, "id_rsa")
+	err := os.WriteFile(privateKey, []byte("key"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(tempDir, "missing")
+
+	tests := []struct {
+		desc    string
+		options *AnsiblePlaybookOptions
+		err     error
+	}{
+		{
+			desc:    "Testing error validating nil AnsiblePlaybookOptions",
+			options: nil,
+			err:     errors.New("(playbook::Validate)", "AnsiblePlaybookOptions is nil"),
+		},
+		{
+			desc: "Testing validate AnsiblePlaybookOptions",
+			options: &AnsiblePlaybookOptions{
+				AskBecomePass: true,
+				Become:        true,
+				BecomeMethod:  "sudo",
+				Check:         true,
+				Connection:    "community.docker.docker",
+				ExtraVarsFile: []string{"@" + privateKey},
+				Forks:         "10",
+				PrivateKey:    privateKey,
+				VerboseVV:     true,
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing error validating AnsiblePlaybookOptions with conflicting flags",
+			options: &AnsiblePlaybookOptions{
+				AskVaultPassword:  true,
+				Check:             true,
+				Diff:              true,
+				ListTasks:         true,
+				Verbose:           true,
+				VerboseV:          true,
+				VaultPasswordFile: privateKey,
+			},
+			err: errors.New("(playbook::Validate)", "Invalid ansible-playbook options",
+				fmt.Errorf("only one verbosity level can be set, but 2 were set"),
+				fmt.Errorf("'%s' and '%s' are mutually exclusive", AskVaultPasswordFlag, VaultPasswordFileFlag),
+				fmt.Errorf("'%s' is meaningless with '%s'", CheckFlag, ListTasksFlag),
+				fmt.Errorf("'%s' is meaningless with '%s'", DiffFlag, ListTasksFlag),
+			),
+		},
+		{
+			desc: "Testing validate AnsiblePlaybookOptions with prompts and plugins that are not shipped with ansible",
+			options: &AnsiblePlaybookOptions{
+				AskPass:    true,
+				Connection: "aws_ssm",
+				Step:       true,
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing error validating AnsiblePlaybookOptions with invalid values",
+			options: &AnsiblePlaybookOptions{
+				BecomeMethod:      "sudo su",
+				Connection:        "ssh://",
+				ExtraVarsFile:     []string{"@" + missing},
+				Forks:             "ten",
+				PrivateKey:        missing,
+				Timeout:           -1,
+				VaultPasswordFile: tempDir,
+			},
+			err: errors.New("(playbook::Validate)", "Invalid ansible-playbook options",
+				fmt.Errorf("'%s' must be a positive integer, but '%s' was provided", ForksFlag, "ten"),
+				fmt.Errorf("'%s' must not be negative, but '%d' was provided", TimeoutFlag, -1),
+				fmt.Errorf("'%s' is not a valid become method. Use a plugin name, such as '%s', or a fully qualified collection name", "sudo su", strings.Join(BecomeMethods, "', '")),
+				fmt.Errorf("'%s' is not a valid connection. Use a plugin name, such as '%s', or a fully qualified collection name", "ssh://", strings.Join(Connections, "', '")),
+			),
+		},
+		{
+			desc: "Testing error validating AnsiblePlaybookOptions with password files",
+			options: &AnsiblePlaybookOptions{
+				AskBecomePass:          true,
+				AskPass:                true,
+				BecomePasswordFile:     missing,
+				ConnectionPasswordFile: privateKey,
+			},
+			err: errors.New("(playbook::Validate)", "Invalid ansible-playbook options",
+				fmt.Errorf("'%s' and '%s' are mutually exclusive", AskPassFlag, ConnectionPasswordFileFlag),
+				fmt.Errorf("'%s' and '%s' are mutually exclusive", AskBecomePassFlag, BecomePasswordFileFlag),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.Validate()
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+func TestValidateFiles(t *testing.T) {
+
+	tempDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(tempDir, "id_rsa"), []byte("key"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(tempDir, "missing")
+
+	tests := []struct {
+		desc    string
+		options *AnsiblePlaybookOptions
+		dir     string
+		err     error
+	}{
+		{
+			desc:    "Testing error validating files of nil AnsiblePlaybookOptions",
+			options: nil,
+			err:     errors.New("(playbook::ValidateFiles)", "AnsiblePlaybookOptions is nil"),
+		},
+		{
+			desc: "Testing validate files of AnsiblePlaybookOptions resolving the relative files against the directory",
+			options: &AnsiblePlaybookOptions{
+				BecomePasswordFile:     "id_rsa",
+				ConnectionPasswordFile: filepath.Join(tempDir, "id_rsa"),
+				ExtraVarsFile:          []string{"@id_rsa"},
+				PrivateKey:             "id_rsa",
+				VaultPasswordFile:      "id_rsa",
+			},
+			dir: tempDir,
+			err: nil,
+		},
+		{
+			desc: "Testing error validating files of AnsiblePlaybookOptions with invalid files",
+			options: &AnsiblePlaybookOptions{
+				BecomePasswordFile:     missing,
+				ConnectionPasswordFile: "missing",
+				ExtraVarsFile:          []string{"@" + missing},
+				PrivateKey:             missing,
+				VaultPasswordFile:      tempDir,
+			},
+			dir: tempDir,
+			err: errors.New("(playbook::ValidateFiles)", "Invalid ansible-playbook option files",
+				fmt.Errorf("'%s' file is not valid: %w", PrivateKeyFlag, &fs.PathError{Op: "stat", Path: missing, Err: syscall.ENOENT}),
+				fmt.Errorf("'%s' file is not valid: %w", VaultPasswordFileFlag, fmt.Errorf("%s is a directory", tempDir)),
+				fmt.Errorf("'%s' file is not valid: %w", ConnectionPasswordFileFlag, &fs.PathError{Op: "stat", Path: missing, Err: syscall.ENOENT}),
+				fmt.Errorf("'%s' file is not valid: %w", BecomePasswordFileFlag, &fs.PathError{Op: "stat", Path: missing, Err: syscall.ENOENT}),
+				fmt.Errorf("'%s' file is not valid: %w", ExtraVarsFlag, &fs.PathError{Op: "stat", Path: missing, Err: syscall.ENOENT}),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.ValidateFiles(test.dir)
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+func TestVaultClientOptions(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *AnsiblePlaybookOptions
+		labels  []string
+	}{
+		{
+			desc: "Testing vault client options sorted by vault id label",
+			options: &AnsiblePlaybookOptions{
+				VaultPasswordReaders: map[string]client.PasswordReader{
+					"prod": text.NewReadPasswordFromText(text.WithText("prod-password")),
+					"dev":  text.NewReadPasswordFromText(text.WithText("dev-password")),
+				},
+			},
+			labels: []string{"dev", "prod"},
+		},
+		{
+			desc:    "Testing vault client options without vault password readers",
+			options: &AnsiblePlaybookOptions{},
+		},
+		{
+			desc: "Testing vault client options of nil options",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			options := test.options.VaultClientOptions()
+			assert.Len(t, options, len(test.labels))
+			if len(options) == 0 {
+				return
+			}
+
+			vaultClient := client.NewVaultPasswordClient(options...)
+			err := vaultClient.Start()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer vaultClient.Close()
+
+			labels := []string{}
+			for _, vaultID := range vaultClient.VaultIDs() {
+				label, _, _ := strings.Cut(vaultID, "@")
+				labels = append(labels, label)
+			}
+			assert.Equal(t, test.labels, labels)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package playbook
+
+import (
+	"io"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/spf13/pflag"
+)
+
+// ParseAnsiblePlaybookCmd creates an AnsiblePlaybookCmd from an ansible-playbook command line. The first argument is the ansible-playbook binary, the flags are parsed into the playbook options and the remaining arguments are the playbooks. It is the inverse of the AnsiblePlaybookCmd's Command method
+func ParseAnsiblePlaybookCmd(args []string) (*AnsiblePlaybookCmd, error) {
+
+	errContext := "(playbook::ParseAnsiblePlaybookCmd)"
+
+	if len(args) == 0 {
+		return nil, errors.New(errContext, "No ansible-playbook command line to parse")
+	}
+
+	options := &AnsiblePlaybookOptions{}
+
+	fs := pflag.NewFlagSet(args[0], pflag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	options.AddFlags(fs, "")
+
+	err := fs.Parse(args[1:])
+	if err != nil {
+		return nil, errors.New(errContext, "Error parsing ansible-playbook command line", err)
+	}
+
+	if fs.NArg() == 0 {
+		return nil, errors.New(errContext, "No playbooks defined")
+	}
+
+	cmd := NewAnsiblePlaybookCmd(
+		WithBinary(args[0]),
+		WithPlaybooks(fs.Args()...),
+	)
+
+	if fs.NFlag() > 0 {
+		cmd.PlaybookOptions = options
+	}
+
+	return cmd, nil
+}
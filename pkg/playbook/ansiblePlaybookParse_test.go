@@ -0,0 +1,233 @@
+package playbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseAnsiblePlaybookCmd tests
+func TestParseAnsiblePlaybookCmd(t *testing.T) {
+
+	errContext := "(playbook::ParseAnsiblePlaybookCmd)"
+
+	tests := []struct {
+		desc string
+		args []string
+		res  *AnsiblePlaybookCmd
+		err  error
+	}{
+		{
+			desc: "Testing parse an ansible-playbook command line without arguments",
+			args: []string{},
+			err:  errors.New(errContext, "No ansible-playbook command line to parse"),
+		},
+		{
+			desc: "Testing parse an ansible-playbook command line without playbooks",
+			args: []string{"ansible-playbook", "--check"},
+			err:  errors.New(errContext, "No playbooks defined"),
+		},
+		{
+			desc: "Testing parse an ansible-playbook command line with an unknown flag",
+			args: []string{"ansible-playbook", "--unknown", "site.yml"},
+			err:  errors.New(errContext, "Error parsing ansible-playbook command line", fmt.Errorf("unknown flag: --unknown")),
+		},
+		{
+			desc: "Testing parse an ansible-playbook command line with an unknown shorthand flag",
+			args: []string{"ansible-playbook", "-Z", "site.yml"},
+			err:  errors.New(errContext, "Error parsing ansible-playbook command line", fmt.Errorf("unknown shorthand flag: 'Z' in -Z")),
+		},
+		{
+			desc: "Testing parse an ansible-playbook command line without flags",
+			args: []string{"ansible-playbook", "site.yml", "site2.yml"},
+			res: &AnsiblePlaybookCmd{
+				Binary:    "ansible-playbook",
+				Playbooks: []string{"site.yml", "site2.yml"},
+			},
+		},
+		{
+			desc: "Testing parse an ansible-playbook command line with short flags",
+			args: []string{"ansible-playbook", "-i", "127.0.0.1,", "-l", "all", "-C", "-D", "-b", "-u", "apenella", "-T", "10", "-f", "5", "-t", "tag1", "-t", "tag2", "-vv", "site.yml"},
+			res: &AnsiblePlaybookCmd{
+				Binary:    "ansible-playbook",
+				Playbooks: []string{"site.yml"},
+				PlaybookOptions: &AnsiblePlaybookOptions{
+					Become:    true,
+					Check:     true,
+					Diff:      true,
+					Forks:     "5",
+					Inventory: "127.0.0.1,",
+					Limit:     "all",
+					Tags:      "tag1,tag2",
+					Timeout:   10,
+					User:      "apenella",
+					VerboseVV: true,
+				},
+			},
+		},
+		{
+			desc: "Testing parse an ansible-playbook command line with long flags, equal signs and aliases",
+			args: []string{"ansible-playbook", "--inventory-file=inventory.ini", "--key-file", "id_rsa", "--vault-pass-file=vault.txt", "--become-method=sudo", "--verbose", "--verbose", "--verbose", "--verbose", "site.yml"},
+			res: &AnsiblePlaybookCmd{
+				Binary:    "ansible-playbook",
+				Playbooks: []string{"site.yml"},
+				PlaybookOptions: &AnsiblePlaybookOptions{
+					BecomeMethod:      "sudo",
+					Inventory:         "inventory.ini",
+					PrivateKey:        "id_rsa",
+					VaultPasswordFile: "vault.txt",
+					Verbose:           true,
+				},
+			},
+		},
+		{
+			desc: "Testing parse an ansible-playbook command line with repeated extra-vars",
+			args: []string{"ansible-playbook", "-e", "var1=value1 var2='value 2'", "--extra-vars", `{"var3":true,"var4":{"__ansible_vault":"encrypted"}}`, "-e", "@vars.yml", "--extra-vars=@vars.json", "site.yml"},
+			res: &AnsiblePlaybookCmd{
+				Binary:    "ansible-playbook",
+				Playbooks: []string{"site.yml"},
+				PlaybookOptions: &AnsiblePlaybookOptions{
+					ExtraVars: map[string]interface{}{
+						"var1": "value1",
+						"var2": "value 2",
+						"var3": true,
+						"var4": vault.NewVaultVariableValue("encrypted"),
+					},
+					ExtraVarsFile: []string{"@vars.yml", "@vars.json"},
+				},
+			},
+		},
+		{
+			desc: "Testing parse an ansible-playbook command line with an invalid extra-vars",
+			args: []string{"ansible-playbook", "-e", "var1", "site.yml"},
+			err: errors.New(errContext, "Error parsing ansible-playbook command line",
+				fmt.Errorf("invalid argument \"var1\" for \"-e, --extra-vars\" flag: %w",
+					fmt.Errorf("invalid extra-vars 'var1': 'var1' is not a key=value pair"))),
+		},
+		{
+			desc: "Testing parse an ansible-playbook command line with YAML extra-vars",
+			args: []string{"ansible-playbook", "-e", "var1: 1", "site.yml"},
+			res: &AnsiblePlaybookCmd{
+				Binary:    "ansible-playbook",
+				Playbooks: []string{"site.yml"},
+				PlaybookOptions: &AnsiblePlaybookOptions{
+					ExtraVars: map[string]interface{}{"var1": 1},
+				},
+			},
+		},
+		{
+			desc: "Testing parse an ansible-playbook command line with a repeated vault-id",
+			args: []string{"ansible-playbook", "--vault-id", "dev@prompt", "--vault-id", "prod@prompt", "site.yml"},
+			err: errors.New(errContext, "Error parsing ansible-playbook command line",
+				fmt.Errorf("invalid argument \"prod@prompt\" for \"--vault-id\" flag: %w",
+					fmt.Errorf("'--vault-id' can only be set once, but it was already set to 'dev@prompt'"))),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := ParseAnsiblePlaybookCmd(test.args)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+// TestParseAnsiblePlaybookCmdRoundTrip tests that parsing the command generated by an AnsiblePlaybookCmd returns the same AnsiblePlaybookCmd
+func TestParseAnsiblePlaybookCmdRoundTrip(t *testing.T) {
+	tests := []struct {
+		desc string
+		cmd  *AnsiblePlaybookCmd
+	}{
+		{
+			desc: "Testing round trip of an ansible-playbook command without options",
+			cmd: NewAnsiblePlaybookCmd(
+				WithBinary("ansible-playbook"),
+				WithPlaybooks("site.yml", "site2.yml"),
+			),
+		},
+		{
+			desc: "Testing round trip of an ansible-playbook command with all options",
+			cmd: NewAnsiblePlaybookCmd(
+				WithBinary("custom-ansible-playbook"),
+				WithPlaybooks("site.yml"),
+				WithPlaybookOptions(&AnsiblePlaybookOptions{
+					AskBecomePass:          true,
+					AskPass:                true,
+					AskVaultPassword:       true,
+					Become:                 true,
+					BecomeMethod:           "sudo",
+					BecomePasswordFile:     "become-password-file",
+					BecomeUser:             "root",
+					Check:                  true,
+					Connection:             "local",
+					ConnectionPasswordFile: "connection-password-file",
+					Diff:                   true,
+					ExtraVars:              map[string]interface{}{"string": "value", "bool": true, "float": json.Number("1.5"), "int": json.Number("3"), "large_int": json.Number("9007199254740993"), "list": []interface{}{"a", "b"}},
+					ExtraVarsFile:          []string{"@vars.yml"},
+					FlushCache:             true,
+					ForceHandlers:          true,
+					Forks:                  "10",
+					Inventory:              "127.0.0.1,",
+					Limit:                  "myhost",
+					ListHosts:              true,
+					ListTags:               true,
+					ListTasks:              true,
+					ModulePath:             "/dev/null",
+					PrivateKey:             "pk",
+					SCPExtraArgs:           "-o StrictHostKeyChecking=no",
+					SFTPExtraArgs:          "-o StrictHostKeyChecking=no",
+					SkipTags:               "tagN",
+					SSHCommonArgs:          "-o StrictHostKeyChecking=no",
+					SSHExtraArgs:           "-o StrictHostKeyChecking=no",
+					StartAtTask:            "second task",
+					Step:                   true,
+					SyntaxCheck:            true,
+					Tags:                   "tag1,tag2",
+					Timeout:                10,
+					User:                   "apenella",
+					VaultID:                "dev@prompt",
+					VaultPasswordFile:      "/dev/null",
+					Verbose:                true,
+					Version:                true,
+				}),
+			),
+		},
+		{
+			desc: "Testing round trip of an ansible-playbook command with vaulted extra-vars",
+			cmd: NewAnsiblePlaybookCmd(
+				WithBinary("ansible-playbook"),
+				WithPlaybooks("site.yml"),
+				WithPlaybookOptions(&AnsiblePlaybookOptions{
+					ExtraVars:  map[string]interface{}{"secret": vault.NewVaultVariableValue("encrypted")},
+					VerboseVVV: true,
+				}),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			test.cmd.SkipValidation = true
+			command, err := test.cmd.Command()
+			assert.NoError(t, err)
+
+			res, err := ParseAnsiblePlaybookCmd(command)
+			assert.NoError(t, err)
+
+			res.SkipValidation = true
+			assert.Equal(t, test.cmd, res)
+		})
+	}
+}
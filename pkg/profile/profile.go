@@ -0,0 +1,110 @@
+package profile
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+	"github.com/apenella/go-ansible/v2/pkg/execute/configuration"
+	"github.com/apenella/go-ansible/v2/pkg/execute/stdoutcallback"
+	"github.com/apenella/go-ansible/v2/pkg/playbook"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// StdoutCallbacks are the stdout callbacks that can be defined on a profile
+var StdoutCallbacks = []string{
+	stdoutcallback.AnsiblePosixJsonlStdoutCallback,
+	stdoutcallback.DebugStdoutCallback,
+	stdoutcallback.DefaultStdoutCallback,
+	stdoutcallback.DenseStdoutCallback,
+	stdoutcallback.JSONStdoutCallback,
+	stdoutcallback.MinimalStdoutCallback,
+	stdoutcallback.NullStdoutCallback,
+	stdoutcallback.OnelineStdoutCallback,
+	stdoutcallback.StderrStdoutCallback,
+	stdoutcallback.TimerStdoutCallback,
+	stdoutcallback.YAMLStdoutCallback,
+}
+
+// Profile defines how to run ansible-playbook. It holds the ansible-playbook options, the ansible configuration settings and the stdout callback
+type Profile struct {
+	// Configuration are the ansible configuration settings, where the key is the setting name and the value is the setting value
+	Configuration map[string]string
+	// PlaybookOptions are the ansible-playbook options
+	PlaybookOptions *playbook.AnsiblePlaybookOptions
+	// StdoutCallback is the stdout callback used to run ansible-playbook
+	StdoutCallback string
+}
+
+// ConfigurationSettings returns the profile configuration settings as a list of ConfigurationSettingsFunc, sorted by the setting name
+func (p *Profile) ConfigurationSettings() []configuration.ConfigurationSettingsFunc {
+	names := make([]string, 0, len(p.Configuration))
+	for name := range p.Configuration {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	settings := make([]configuration.ConfigurationSettingsFunc, 0, len(names))
+	for _, name := range names {
+		settings = append(settings, configuration.WithConfigurationSetting(name, p.Configuration[name]))
+	}
+
+	return settings
+}
+
+// Execute returns an executor that runs the executor received as argument using the profile configuration settings and stdout callback
+func (p *Profile) Execute(executor stdoutcallback.ExecutorQuietStdoutCallbackSetter) (execute.Executor, error) {
+	errContext := "(profile::Execute)"
+
+	if executor == nil {
+		return nil, errors.New(errContext, "Profile executor requires an executor")
+	}
+
+	var exec execute.Executor
+
+	switch p.StdoutCallback {
+	case "":
+		exec = executor
+	case stdoutcallback.AnsiblePosixJsonlStdoutCallback:
+		exec = stdoutcallback.NewAnsiblePosixJsonlStdoutCallbackExecute(executor)
+	case stdoutcallback.DebugStdoutCallback:
+		exec = stdoutcallback.NewDebugStdoutCallbackExecute(executor)
+	case stdoutcallback.DefaultStdoutCallback:
+		exec = stdoutcallback.NewDefaultStdoutCallbackExecute(executor)
+	case stdoutcallback.DenseStdoutCallback:
+		exec = stdoutcallback.NewDenseStdoutCallbackExecute(executor)
+	case stdoutcallback.JSONStdoutCallback:
+		exec = stdoutcallback.NewJSONStdoutCallbackExecute(executor)
+	case stdoutcallback.MinimalStdoutCallback:
+		exec = stdoutcallback.NewMinimalStdoutCallbackExecute(executor)
+	case stdoutcallback.NullStdoutCallback:
+		exec = stdoutcallback.NewNullStdoutCallbackExecute(executor)
+	case stdoutcallback.OnelineStdoutCallback:
+		exec = stdoutcallback.NewOnelineStdoutCallbackExecute(executor)
+	case stdoutcallback.StderrStdoutCallback:
+		exec = stdoutcallback.NewStderrStdoutCallbackExecute(executor)
+	case stdoutcallback.TimerStdoutCallback:
+		exec = stdoutcallback.NewTimerStdoutCallbackExecute(executor)
+	case stdoutcallback.YAMLStdoutCallback:
+		exec = stdoutcallback.NewYAMLStdoutCallbackExecute(executor)
+	default:
+		return nil, errors.New(errContext, fmt.Sprintf("Unknown stdout callback '%s'", p.StdoutCallback))
+	}
+
+	// The configuration settings are set on the executor, while the stdout callback executor wraps it
+	return configuration.NewAnsibleWithConfigurationSettingsExecute(
+		&envVarSetter{Executor: exec, setter: executor},
+		p.ConfigurationSettings()...,
+	), nil
+}
+
+// envVarSetter is an executor that sets the environment variables on a wrapped executor
+type envVarSetter struct {
+	execute.Executor
+	setter configuration.ExecutorEnvVarSetter
+}
+
+// AddEnvVar sets an environment variable on the wrapped executor
+func (e *envVarSetter) AddEnvVar(key, value string) {
+	e.setter.AddEnvVar(key, value)
+}
@@ -0,0 +1,413 @@
+package profile
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/configuration"
+	"github.com/apenella/go-ansible/v2/pkg/playbook"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ExtendsKey is the profile key that defines the profile to inherit from
+	ExtendsKey = "extends"
+	// PlaybookKey is the profile key that defines the ansible-playbook options. Its keys are the AnsiblePlaybookOptions field names in snake case, such as extra_vars for ExtraVars
+	PlaybookKey = "playbook"
+	// ConfigurationKey is the profile key that defines the ansible configuration settings. Its keys are the configuration settings environment variable names
+	ConfigurationKey = "configuration"
+	// StdoutCallbackKey is the profile key that defines the stdout callback
+	StdoutCallbackKey = "stdout_callback"
+)
+
+// profileExtensions are the extensions tried to find a profile file when extends key does not include it
+var profileExtensions = []string{".yml", ".yaml"}
+
+// ProfileLoaderOptionsFunc is a function to set the ProfileLoader options
+type ProfileLoaderOptionsFunc func(*ProfileLoader)
+
+// ProfileLoader loads profiles from YAML files. A profile file can inherit from another profile file through the extends key, and then it only defines the attributes to override
+type ProfileLoader struct {
+	fs afero.Fs
+}
+
+// NewProfileLoader creates a new ProfileLoader
+func NewProfileLoader(options ...ProfileLoaderOptionsFunc) *ProfileLoader {
+	loader := &ProfileLoader{}
+
+	for _, option := range options {
+		option(loader)
+	}
+
+	return loader
+}
+
+// WithFs sets the filesystem where the profiles are read from
+func WithFs(fs afero.Fs) ProfileLoaderOptionsFunc {
+	return func(l *ProfileLoader) {
+		l.fs = fs
+	}
+}
+
+// rawProfile is the content of a profile file, before it is merged with the profiles it extends
+type rawProfile struct {
+	Extends        string
+	Playbook       map[string]interface{}
+	Configuration  map[string]interface{}
+	StdoutCallback *string
+}
+
+// Load reads the profile file and the profiles it extends and returns the resulting Profile. The attributes defined on a profile override the ones defined on the profile it extends, and a null value removes the inherited attribute. It returns an error that wraps all the unknown keys found
+func (l *ProfileLoader) Load(file string) (*Profile, error) {
+	errContext := "(profile::Load)"
+
+	if l.fs == nil {
+		l.fs = afero.NewOsFs()
+	}
+
+	merged, err := l.load(file, []string{})
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Error loading profile '%s'", file), err)
+	}
+
+	profile, err := newProfile(merged)
+	if err != nil {
+		return nil, errors.New(errContext, fmt.Sprintf("Invalid profile '%s'", file), err)
+	}
+
+	return profile, nil
+}
+
+// load reads a profile file and merges it over the profile it extends. The chain argument holds the files already loaded, to detect cyclic inheritance
+func (l *ProfileLoader) load(file string, chain []string) (*rawProfile, error) {
+
+	for _, loaded := range chain {
+		if loaded == file {
+			return nil, fmt.Errorf("cyclic inheritance: %s -> %s", strings.Join(chain, " -> "), file)
+		}
+	}
+	chain = append(chain, file)
+
+	profile, err := l.read(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile.Extends == "" {
+		return profile, nil
+	}
+
+	parentFile, err := l.resolve(filepath.Dir(file), profile.Extends)
+	if err != nil {
+		return nil, err
+	}
+
+	parent, err := l.load(parentFile, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	return merge(parent, profile), nil
+}
+
+// read unmarshals a profile file. It returns an error that wraps all the unknown keys
+func (l *ProfileLoader) read(file string) (*rawProfile, error) {
+
+	f, err := l.fs.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("error opening profile '%s': %w", file, err)
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("error reading profile '%s': %w", file, err)
+	}
+
+	data := map[string]interface{}{}
+	err = yaml.Unmarshal(content, &data)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling profile '%s': %w", file, err)
+	}
+
+	profile := &rawProfile{}
+	errs := []error{}
+
+	for _, key := range sortedKeys(data) {
+		value := data[key]
+
+		switch key {
+		case ExtendsKey:
+			extends, isString := value.(string)
+			if !isString {
+				errs = append(errs, fmt.Errorf("'%s' key in profile '%s' must be a string", key, file))
+				continue
+			}
+			profile.Extends = extends
+		case PlaybookKey:
+			playbookOptions, isMap := value.(map[string]interface{})
+			if value != nil && !isMap {
+				errs = append(errs, fmt.Errorf("'%s' key in profile '%s' must be a map", key, file))
+				continue
+			}
+			profile.Playbook = playbookOptions
+		case ConfigurationKey:
+			settings, isMap := value.(map[string]interface{})
+			if value != nil && !isMap {
+				errs = append(errs, fmt.Errorf("'%s' key in profile '%s' must be a map", key, file))
+				continue
+			}
+			profile.Configuration = settings
+		case StdoutCallbackKey:
+			stdoutCallback := ""
+			if value != nil {
+				stdoutCallback = fmt.Sprint(value)
+			}
+			profile.StdoutCallback = &stdoutCallback
+		default:
+			errs = append(errs, fmt.Errorf("unknown key '%s' in profile '%s'", key, file))
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.New("(profile::read)", fmt.Sprintf("Invalid profile '%s'", file), errs...)
+	}
+
+	return profile, nil
+}
+
+// resolve returns the file of the extended profile. A relative path is resolved from the directory of the extending profile, and the profile extension can be omitted
+func (l *ProfileLoader) resolve(dir, extends string) (string, error) {
+
+	file := extends
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(dir, file)
+	}
+
+	candidates := []string{file}
+	if filepath.Ext(file) == "" {
+		for _, ext := range profileExtensions {
+			candidates = append(candidates, file+ext)
+		}
+	}
+
+	for _, candidate := range candidates {
+		info, err := l.fs.Stat(candidate)
+		if err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("extended profile '%s' not found", extends)
+}
+
+// merge returns a profile where the child attributes override the parent ones
+func merge(parent, child *rawProfile) *rawProfile {
+	profile := &rawProfile{
+		Playbook:       mergeMaps(parent.Playbook, child.Playbook),
+		Configuration:  mergeMaps(parent.Configuration, child.Configuration),
+		StdoutCallback: parent.StdoutCallback,
+	}
+
+	if child.StdoutCallback != nil {
+		profile.StdoutCallback = child.StdoutCallback
+	}
+
+	return profile
+}
+
+// mergeMaps returns a map with the parent values overridden by the child values. A nil child value removes the key
+func mergeMaps(parent, child map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+
+	for key, value := range parent {
+		merged[key] = value
+	}
+
+	for key, value := range child {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// newProfile creates a Profile from a merged profile. It returns an error that wraps all the unknown keys and invalid values
+func newProfile(raw *rawProfile) (*Profile, error) {
+	errs := []error{}
+
+	profile := &Profile{
+		Configuration: map[string]string{},
+	}
+
+	if len(raw.Playbook) > 0 {
+		profile.PlaybookOptions = &playbook.AnsiblePlaybookOptions{}
+		options := reflect.ValueOf(profile.PlaybookOptions).Elem()
+		fields := playbookOptionsFields()
+
+		for _, key := range sortedKeys(raw.Playbook) {
+			field, exists := fields[key]
+			if !exists {
+				errs = append(errs, fmt.Errorf("unknown key '%s.%s'", PlaybookKey, key))
+				continue
+			}
+
+			err := setPlaybookOption(options.FieldByName(field), raw.Playbook[key])
+			if err != nil {
+				errs = append(errs, fmt.Errorf("invalid value for '%s.%s': %w", PlaybookKey, key, err))
+			}
+		}
+	}
+
+	for _, key := range sortedKeys(raw.Configuration) {
+		if !configuration.IsConfigurationSetting(key) {
+			errs = append(errs, fmt.Errorf("unknown key '%s.%s'", ConfigurationKey, key))
+			continue
+		}
+
+		profile.Configuration[key] = settingValue(raw.Configuration[key])
+	}
+
+	if raw.StdoutCallback != nil {
+		profile.StdoutCallback = *raw.StdoutCallback
+	}
+
+	if profile.StdoutCallback != "" && !isStdoutCallback(profile.StdoutCallback) {
+		errs = append(errs, fmt.Errorf("unknown stdout callback '%s'. Use one of '%s'", profile.StdoutCallback, strings.Join(StdoutCallbacks, "', '")))
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.New("(profile::newProfile)", "Invalid profile attributes", errs...)
+	}
+
+	return profile, nil
+}
+
+// playbookOptionsFields returns the AnsiblePlaybookOptions field names by profile key. The fields whose type can not be defined on a YAML file, such as the vault password readers, are not included
+func playbookOptionsFields() map[string]string {
+	fields := map[string]string{}
+
+	optionsType := reflect.TypeOf(playbook.AnsiblePlaybookOptions{})
+	for i := 0; i < optionsType.NumField(); i++ {
+		field := optionsType.Field(i)
+
+		switch field.Type {
+		case reflect.TypeOf(true), reflect.TypeOf(""), reflect.TypeOf(0), reflect.TypeOf([]string{}), reflect.TypeOf(map[string]interface{}{}):
+			fields[snakeCase(field.Name)] = field.Name
+		}
+	}
+
+	return fields
+}
+
+// setPlaybookOption sets the value defined on the profile to an AnsiblePlaybookOptions field. The string fields accept a list, which is represented as comma separated values, and the list fields accept a single value
+func setPlaybookOption(field reflect.Value, value interface{}) error {
+	switch field.Kind() {
+	case reflect.Bool:
+		b, isBool := value.(bool)
+		if !isBool {
+			return fmt.Errorf("'%v' is not a boolean", value)
+		}
+		field.SetBool(b)
+	case reflect.Int:
+		i, isInt := value.(int)
+		if !isInt {
+			return fmt.Errorf("'%v' is not an integer", value)
+		}
+		field.SetInt(int64(i))
+	case reflect.String:
+		if _, isMap := value.(map[string]interface{}); isMap {
+			return fmt.Errorf("a map is not a string")
+		}
+		field.SetString(settingValue(value))
+	case reflect.Slice:
+		items, isList := value.([]interface{})
+		if !isList {
+			items = []interface{}{value}
+		}
+
+		values := make([]string, 0, len(items))
+		for _, item := range items {
+			values = append(values, fmt.Sprint(item))
+		}
+		field.Set(reflect.ValueOf(values))
+	case reflect.Map:
+		m, isMap := value.(map[string]interface{})
+		if !isMap {
+			return fmt.Errorf("'%v' is not a map", value)
+		}
+		field.Set(reflect.ValueOf(m))
+	}
+
+	return nil
+}
+
+// snakeCase returns the name in snake case. The acronyms are kept together, so SSHCommonArgs becomes ssh_common_args
+func snakeCase(name string) string {
+	runes := []rune(name)
+	snake := &strings.Builder{}
+
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			previousLower := !unicode.IsUpper(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if previousLower || nextLower {
+				snake.WriteRune('_')
+			}
+		}
+		snake.WriteRune(unicode.ToLower(r))
+	}
+
+	return snake.String()
+}
+
+// settingValue returns the value of a configuration setting. Lists are represented as comma separated values
+func settingValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	list, isList := value.([]interface{})
+	if !isList {
+		return fmt.Sprint(value)
+	}
+
+	items := make([]string, 0, len(list))
+	for _, item := range list {
+		items = append(items, fmt.Sprint(item))
+	}
+
+	return strings.Join(items, ",")
+}
+
+// isStdoutCallback returns whether name is one of the StdoutCallbacks
+func isStdoutCallback(name string) bool {
+	for _, stdoutCallback := range StdoutCallbacks {
+		if stdoutCallback == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sortedKeys returns the map keys sorted
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,275 @@
+package profile
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/configuration"
+	"github.com/apenella/go-ansible/v2/pkg/execute/stdoutcallback"
+	"github.com/apenella/go-ansible/v2/pkg/playbook"
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLoad tests
+func TestLoad(t *testing.T) {
+
+	errContext := "(profile::Load)"
+
+	files := map[string]string{
+		"/profiles/base.yml": `
+playbook:
+  inventory: inventory.ini
+  forks: 10
+  become: true
+  extra_vars:
+    app: go-ansible
+    replicas: 2
+configuration:
+  ANSIBLE_FORCE_COLOR: true
+  ANSIBLE_COW_ACCEPTLIST:
+    - default
+    - tux
+stdout_callback: yaml
+`,
+		"/profiles/prod-deploy.yaml": `
+extends: base
+playbook:
+  limit: prod
+  become: null
+  tags:
+    - deploy
+    - web
+  extra_vars:
+    env: prod
+  extra_vars_file: "@vars/prod.yml"
+  ssh_common_args: -o ProxyJump=bastion
+configuration:
+  ANSIBLE_FORCE_COLOR: false
+stdout_callback: json
+`,
+		"/profiles/staging-check.yml": `
+extends: /profiles/base.yml
+playbook:
+  check: true
+  diff: true
+  verbose_vv: true
+`,
+		"/profiles/unknown-keys.yml": `
+extends: base
+playbook:
+  unknown-flag: true
+  extra-vars: {}
+  inventory: staging.ini
+  verbose: 2
+  timeout: 30s
+  vault_password_readers: {}
+configuration:
+  ANSIBLE_UNKNOWN_SETTING: true
+stdout_callback: fancy
+`,
+		"/profiles/unknown-root-keys.yml": `
+playbooks:
+  inventory: staging.ini
+settings: {}
+`,
+		"/profiles/verbose.yml": `
+playbook:
+  verbose: true
+  timeout: 30
+  skip_tags:
+    - slow
+    - flaky
+`,
+		"/profiles/cycle-a.yml":        "extends: cycle-b\n",
+		"/profiles/cycle-b.yml":        "extends: cycle-a\n",
+		"/profiles/missing-parent.yml": "extends: missing\n",
+	}
+
+	fs := afero.NewMemMapFs()
+	for file, content := range files {
+		_ = afero.WriteFile(fs, file, []byte(content), 0644)
+	}
+
+	tests := []struct {
+		desc string
+		file string
+		res  *Profile
+		err  error
+	}{
+		{
+			desc: "Testing load a profile",
+			file: "/profiles/base.yml",
+			res: &Profile{
+				Configuration: map[string]string{
+					configuration.AnsibleCowAcceptlist: "default,tux",
+					configuration.AnsibleForceColor:    "true",
+				},
+				PlaybookOptions: &playbook.AnsiblePlaybookOptions{
+					Become: true,
+					ExtraVars: map[string]interface{}{
+						"app":      "go-ansible",
+						"replicas": 2,
+					},
+					Forks:     "10",
+					Inventory: "inventory.ini",
+				},
+				StdoutCallback: stdoutcallback.YAMLStdoutCallback,
+			},
+		},
+		{
+			desc: "Testing load a profile that extends another profile and overrides its attributes",
+			file: "/profiles/prod-deploy.yaml",
+			res: &Profile{
+				Configuration: map[string]string{
+					configuration.AnsibleCowAcceptlist: "default,tux",
+					configuration.AnsibleForceColor:    "false",
+				},
+				PlaybookOptions: &playbook.AnsiblePlaybookOptions{
+					ExtraVars: map[string]interface{}{
+						"env": "prod",
+					},
+					ExtraVarsFile: []string{"@vars/prod.yml"},
+					Forks:         "10",
+					Inventory:     "inventory.ini",
+					Limit:         "prod",
+					SSHCommonArgs: "-o ProxyJump=bastion",
+					Tags:          "deploy,web",
+				},
+				StdoutCallback: stdoutcallback.JSONStdoutCallback,
+			},
+		},
+		{
+			desc: "Testing load a profile that extends another profile by its path",
+			file: "/profiles/staging-check.yml",
+			res: &Profile{
+				Configuration: map[string]string{
+					configuration.AnsibleCowAcceptlist: "default,tux",
+					configuration.AnsibleForceColor:    "true",
+				},
+				PlaybookOptions: &playbook.AnsiblePlaybookOptions{
+					Become: true,
+					Check:  true,
+					Diff:   true,
+					ExtraVars: map[string]interface{}{
+						"app":      "go-ansible",
+						"replicas": 2,
+					},
+					Forks:     "10",
+					Inventory: "inventory.ini",
+					VerboseVV: true,
+				},
+				StdoutCallback: stdoutcallback.YAMLStdoutCallback,
+			},
+		},
+		{
+			desc: "Testing load a profile that enables the verbose mode",
+			file: "/profiles/verbose.yml",
+			res: &Profile{
+				Configuration: map[string]string{},
+				PlaybookOptions: &playbook.AnsiblePlaybookOptions{
+					SkipTags: "slow,flaky",
+					Timeout:  30,
+					Verbose:  true,
+				},
+			},
+		},
+		{
+			desc: "Testing load a profile with unknown keys",
+			file: "/profiles/unknown-keys.yml",
+			err: errors.New(errContext, "Invalid profile '/profiles/unknown-keys.yml'",
+				errors.New("(profile::newProfile)", "Invalid profile attributes",
+					fmt.Errorf("unknown key 'playbook.extra-vars'"),
+					fmt.Errorf("invalid value for 'playbook.timeout': '30s' is not an integer"),
+					fmt.Errorf("unknown key 'playbook.unknown-flag'"),
+					fmt.Errorf("unknown key 'playbook.vault_password_readers'"),
+					fmt.Errorf("invalid value for 'playbook.verbose': '2' is not a boolean"),
+					fmt.Errorf("unknown key 'configuration.ANSIBLE_UNKNOWN_SETTING'"),
+					fmt.Errorf("unknown stdout callback 'fancy'. Use one of 'ansible.posix.jsonl', 'debug', 'default', 'dense', 'json', 'minimal', 'null', 'oneline', 'stderr', 'timer', 'yaml'"),
+				),
+			),
+		},
+		{
+			desc: "Testing load a profile with unknown root keys",
+			file: "/profiles/unknown-root-keys.yml",
+			err: errors.New(errContext, "Error loading profile '/profiles/unknown-root-keys.yml'",
+				errors.New("(profile::read)", "Invalid profile '/profiles/unknown-root-keys.yml'",
+					fmt.Errorf("unknown key 'playbooks' in profile '/profiles/unknown-root-keys.yml'"),
+					fmt.Errorf("unknown key 'settings' in profile '/profiles/unknown-root-keys.yml'"),
+				),
+			),
+		},
+		{
+			desc: "Testing load a profile with cyclic inheritance",
+			file: "/profiles/cycle-a.yml",
+			err: errors.New(errContext, "Error loading profile '/profiles/cycle-a.yml'",
+				fmt.Errorf("cyclic inheritance: /profiles/cycle-a.yml -> /profiles/cycle-b.yml -> /profiles/cycle-a.yml"),
+			),
+		},
+		{
+			desc: "Testing load a profile that extends a profile that does not exist",
+			file: "/profiles/missing-parent.yml",
+			err: errors.New(errContext, "Error loading profile '/profiles/missing-parent.yml'",
+				fmt.Errorf("extended profile 'missing' not found"),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			loader := NewProfileLoader(WithFs(fs))
+			res, err := loader.Load(test.file)
+			if err != nil {
+				assert.Equal(t, test.err.Error(), err.Error())
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+// TestSnakeCase tests
+func TestSnakeCase(t *testing.T) {
+	tests := []struct {
+		desc string
+		name string
+		res  string
+	}{
+		{
+			desc: "Testing snake case of a single word",
+			name: "Inventory",
+			res:  "inventory",
+		},
+		{
+			desc: "Testing snake case of several words",
+			name: "ExtraVarsFile",
+			res:  "extra_vars_file",
+		},
+		{
+			desc: "Testing snake case of a name starting with an acronym",
+			name: "SSHCommonArgs",
+			res:  "ssh_common_args",
+		},
+		{
+			desc: "Testing snake case of a name ending with an acronym",
+			name: "VaultID",
+			res:  "vault_id",
+		},
+		{
+			desc: "Testing snake case of a verbosity level",
+			name: "VerboseVVVV",
+			res:  "verbose_vvvv",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, snakeCase(test.name))
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package profile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+	"github.com/apenella/go-ansible/v2/pkg/execute/configuration"
+	"github.com/apenella/go-ansible/v2/pkg/execute/stdoutcallback"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestExecute tests
+func TestExecute(t *testing.T) {
+	t.Run("Testing execute a profile with configuration settings and stdout callback", func(t *testing.T) {
+		exec := execute.NewMockExecute()
+
+		exec.On("AddEnvVar", configuration.AnsibleForceColor, "true")
+		exec.On("AddEnvVar", configuration.AnsibleNocows, "true")
+		exec.On("Quiet")
+		exec.On("WithOutput", mock.Anything)
+		exec.On("AddEnvVar", configuration.AnsibleStdoutCallback, stdoutcallback.JSONStdoutCallback)
+		exec.On("Execute", mock.Anything).Return(nil)
+
+		profile := &Profile{
+			Configuration: map[string]string{
+				configuration.AnsibleForceColor: "true",
+				configuration.AnsibleNocows:     "true",
+			},
+			StdoutCallback: stdoutcallback.JSONStdoutCallback,
+		}
+
+		e, err := profile.Execute(exec)
+		assert.NoError(t, err)
+
+		err = e.Execute(context.TODO())
+		assert.NoError(t, err)
+		exec.AssertExpectations(t)
+	})
+
+	t.Run("Testing execute a profile without stdout callback", func(t *testing.T) {
+		exec := execute.NewMockExecute()
+
+		exec.On("AddEnvVar", configuration.AnsibleForceColor, "true")
+		exec.On("Execute", mock.Anything).Return(nil)
+
+		profile := &Profile{
+			Configuration: map[string]string{
+				configuration.AnsibleForceColor: "true",
+			},
+		}
+
+		e, err := profile.Execute(exec)
+		assert.NoError(t, err)
+
+		err = e.Execute(context.TODO())
+		assert.NoError(t, err)
+		exec.AssertExpectations(t)
+	})
+
+	t.Run("Testing error on execute a profile with an unknown stdout callback", func(t *testing.T) {
+		profile := &Profile{
+			StdoutCallback: "fancy",
+		}
+
+		_, err := profile.Execute(execute.NewMockExecute())
+		assert.ErrorContains(t, err, "Unknown stdout callback 'fancy'")
+	})
+
+	t.Run("Testing error on execute a profile without executor", func(t *testing.T) {
+		profile := &Profile{}
+
+		_, err := profile.Execute(nil)
+		assert.ErrorContains(t, err, "Profile executor requires an executor")
+	})
+}
@@ -0,0 +1,285 @@
+package vault
+
+import (
+	"fmt"
+	"strings"
+
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// DefaultAnsibleVaultBinary is the ansible-vault binary file default value
+	DefaultAnsibleVaultBinary = "ansible-vault"
+
+	// AnsibleVaultCreateSubCommand is the ansible-vault create subcommand
+	AnsibleVaultCreateSubCommand = "create"
+	// AnsibleVaultDecryptSubCommand is the ansible-vault decrypt subcommand
+	AnsibleVaultDecryptSubCommand = "decrypt"
+	// AnsibleVaultEditSubCommand is the ansible-vault edit subcommand
+	AnsibleVaultEditSubCommand = "edit"
+	// AnsibleVaultEncryptSubCommand is the ansible-vault encrypt subcommand
+	AnsibleVaultEncryptSubCommand = "encrypt"
+	// AnsibleVaultEncryptStringSubCommand is the ansible-vault encrypt_string subcommand
+	AnsibleVaultEncryptStringSubCommand = "encrypt_string"
+	// AnsibleVaultRekeySubCommand is the ansible-vault rekey subcommand
+	AnsibleVaultRekeySubCommand = "rekey"
+	// AnsibleVaultViewSubCommand is the ansible-vault view subcommand
+	AnsibleVaultViewSubCommand = "view"
+)
+
+var (
+	// AnsibleVaultSubCommands are the supported ansible-vault subcommands
+	AnsibleVaultSubCommands = []string{
+		AnsibleVaultCreateSubCommand,
+		AnsibleVaultDecryptSubCommand,
+		AnsibleVaultEditSubCommand,
+		AnsibleVaultEncryptSubCommand,
+		AnsibleVaultEncryptStringSubCommand,
+		AnsibleVaultRekeySubCommand,
+		AnsibleVaultViewSubCommand,
+	}
+
+	// fileSubCommands are the subcommands that require at least one file
+	fileSubCommands = []string{
+		AnsibleVaultCreateSubCommand,
+		AnsibleVaultEditSubCommand,
+		AnsibleVaultRekeySubCommand,
+		AnsibleVaultViewSubCommand,
+	}
+)
+
+// AnsibleVaultOptionsFunc is a function to set executor options
+type AnsibleVaultOptionsFunc func(*AnsibleVaultCmd)
+
+// AnsibleVaultCmd object is the main object which defines the `ansible-vault` command and how to execute it.
+type AnsibleVaultCmd struct {
+	// Binary is the ansible-vault binary file
+	Binary string
+	// SubCommand is the ansible-vault subcommand to run
+	SubCommand string
+	// Args are the subcommand arguments. They are the files to manage, or the strings to encrypt for encrypt_string
+	Args []string
+	// VaultOptions are the ansible-vault options
+	VaultOptions *AnsibleVaultOptions
+	// SkipValidation disables the vault options validation when the command is generated
+	SkipValidation bool
+	// FileValidation enables checking that the files referenced by the options exist when the command is generated
+	FileValidation bool
+	// FileValidationDir is the directory the relative files are resolved against when the files are validated
+	FileValidationDir string
+}
+
+// NewAnsibleVaultCmd creates a new AnsibleVaultCmd instance
+func NewAnsibleVaultCmd(options ...AnsibleVaultOptionsFunc) *AnsibleVaultCmd {
+	cmd := &AnsibleVaultCmd{}
+
+	for _, option := range options {
+		option(cmd)
+	}
+
+	return cmd
+}
+
+// NewAnsibleVaultCreateCmd creates a new AnsibleVaultCmd instance for the create subcommand
+func NewAnsibleVaultCreateCmd(options ...AnsibleVaultOptionsFunc) *AnsibleVaultCmd {
+	return NewAnsibleVaultCmd(append([]AnsibleVaultOptionsFunc{WithSubCommand(AnsibleVaultCreateSubCommand)}, options...)...)
+}
+
+// NewAnsibleVaultDecryptCmd creates a new AnsibleVaultCmd instance for the decrypt subcommand
+func NewAnsibleVaultDecryptCmd(options ...AnsibleVaultOptionsFunc) *AnsibleVaultCmd {
+	return NewAnsibleVaultCmd(append([]AnsibleVaultOptionsFunc{WithSubCommand(AnsibleVaultDecryptSubCommand)}, options...)...)
+}
+
+// NewAnsibleVaultEditCmd creates a new AnsibleVaultCmd instance for the edit subcommand
+func NewAnsibleVaultEditCmd(options ...AnsibleVaultOptionsFunc) *AnsibleVaultCmd {
+	return NewAnsibleVaultCmd(append([]AnsibleVaultOptionsFunc{WithSubCommand(AnsibleVaultEditSubCommand)}, options...)...)
+}
+
+// NewAnsibleVaultEncryptCmd creates a new AnsibleVaultCmd instance for the encrypt subcommand
+func NewAnsibleVaultEncryptCmd(options ...AnsibleVaultOptionsFunc) *AnsibleVaultCmd {
+	return NewAnsibleVaultCmd(append([]AnsibleVaultOptionsFunc{WithSubCommand(AnsibleVaultEncryptSubCommand)}, options...)...)
+}
+
+// NewAnsibleVaultEncryptStringCmd creates a new AnsibleVaultCmd instance for the encrypt_string subcommand
+func NewAnsibleVaultEncryptStringCmd(options ...AnsibleVaultOptionsFunc) *AnsibleVaultCmd {
+	return NewAnsibleVaultCmd(append([]AnsibleVaultOptionsFunc{WithSubCommand(AnsibleVaultEncryptStringSubCommand)}, options...)...)
+}
+
+// NewAnsibleVaultRekeyCmd creates a new AnsibleVaultCmd instance for the rekey subcommand
+func NewAnsibleVaultRekeyCmd(options ...AnsibleVaultOptionsFunc) *AnsibleVaultCmd {
+	return NewAnsibleVaultCmd(append([]AnsibleVaultOptionsFunc{WithSubCommand(AnsibleVaultRekeySubCommand)}, options...)...)
+}
+
+// NewAnsibleVaultViewCmd creates a new AnsibleVaultCmd instance for the view subcommand
+func NewAnsibleVaultViewCmd(options ...AnsibleVaultOptionsFunc) *AnsibleVaultCmd {
+	return NewAnsibleVaultCmd(append([]AnsibleVaultOptionsFunc{WithSubCommand(AnsibleVaultViewSubCommand)}, options...)...)
+}
+
+// WithBinary set the ansible-vault binary file
+func WithBinary(binary string) AnsibleVaultOptionsFunc {
+	return func(p *AnsibleVaultCmd) {
+		p.Binary = binary
+	}
+}
+
+// WithSubCommand set the ansible-vault subcommand
+func WithSubCommand(subCommand string) AnsibleVaultOptionsFunc {
+	return func(p *AnsibleVaultCmd) {
+		p.SubCommand = subCommand
+	}
+}
+
+// WithArgs set the ansible-vault subcommand arguments
+func WithArgs(args ...string) AnsibleVaultOptionsFunc {
+	return func(p *AnsibleVaultCmd) {
+		p.Args = append([]string{}, args...)
+	}
+}
+
+// WithVaultOptions set the ansible-vault options
+func WithVaultOptions(options *AnsibleVaultOptions) AnsibleVaultOptionsFunc {
+	return func(p *AnsibleVaultCmd) {
+		p.VaultOptions = options
+	}
+}
+
+// WithoutValidation disables the ansible-vault options validation
+func WithoutValidation() AnsibleVaultOptionsFunc {
+	return func(p *AnsibleVaultCmd) {
+		p.SkipValidation = true
+	}
+}
+
+// WithFileValidation enables checking that the files referenced by the ansible-vault options exist. The relative files are resolved against dir, which is the directory where the command runs, or against the working directory when dir is empty. Do not enable it when the command runs on another host or container
+func WithFileValidation(dir string) AnsibleVaultOptionsFunc {
+	return func(p *AnsibleVaultCmd) {
+		p.FileValidation = true
+		p.FileValidationDir = dir
+	}
+}
+
+// Command generate the ansible-vault command which will be executed
+func (p *AnsibleVaultCmd) Command() ([]string, error) {
+	errContext := "(vault::AnsibleVaultCmd::Command)"
+	cmd := []string{}
+
+	if p.SubCommand == "" {
+		return nil, errors.New(errContext, "No subcommand defined")
+	}
+
+	if !contains(AnsibleVaultSubCommands, p.SubCommand) {
+		return nil, errors.New(errContext, fmt.Sprintf("Subcommand '%s' is not supported. Use one of '%s'", p.SubCommand, strings.Join(AnsibleVaultSubCommands, "', '")))
+	}
+
+	if len(p.Args) == 0 && contains(fileSubCommands, p.SubCommand) {
+		return nil, errors.New(errContext, fmt.Sprintf("No files defined for the '%s' subcommand", p.SubCommand))
+	}
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = DefaultAnsibleVaultBinary
+	}
+
+	cmd = append(cmd, p.Binary, p.SubCommand)
+
+	// Determine the options to be set
+	if p.VaultOptions != nil {
+		if !p.SkipValidation {
+			err := p.validate()
+			if err != nil {
+				return nil, errors.New(errContext, "Error validating options", err)
+			}
+		}
+
+		if p.FileValidation {
+			err := p.VaultOptions.ValidateFiles(p.FileValidationDir)
+			if err != nil {
+				return nil, errors.New(errContext, "Error validating option files", err)
+			}
+		}
+
+		options, err := p.VaultOptions.GenerateCommandOptions()
+		if err != nil {
+			return nil, errors.New(errContext, "Error creating options", err)
+		}
+		cmd = append(cmd, options...)
+	}
+
+	// Include the subcommand arguments
+	cmd = append(cmd, p.Args...)
+
+	return cmd, nil
+}
+
+// String returns AnsibleVaultCmd as string
+func (p *AnsibleVaultCmd) String() string {
+
+	// Use default binary when it is not already defined
+	if p.Binary == "" {
+		p.Binary = DefaultAnsibleVaultBinary
+	}
+
+	str := fmt.Sprintf("%s %s", p.Binary, p.SubCommand)
+
+	if p.VaultOptions != nil {
+		str = fmt.Sprintf("%s %s", str, p.VaultOptions.String())
+	}
+
+	// Include the subcommand arguments
+	for _, arg := range p.Args {
+		str = fmt.Sprintf("%s %s", str, arg)
+	}
+
+	return str
+}
+
+// validate checks the options and that the subcommand accepts them
+func (p *AnsibleVaultCmd) validate() error {
+	errContext := "(vault::AnsibleVaultCmd::validate)"
+	errs := []error{}
+
+	err := p.VaultOptions.Validate()
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	o := p.VaultOptions
+	subCommandFlags := []struct {
+		flag        string
+		enabled     bool
+		subCommands []string
+	}{
+		{EncryptVaultIDFlag, o.EncryptVaultID != "", []string{AnsibleVaultCreateSubCommand, AnsibleVaultEditSubCommand, AnsibleVaultEncryptSubCommand, AnsibleVaultEncryptStringSubCommand}},
+		{NameFlag, len(o.Names) > 0, []string{AnsibleVaultEncryptStringSubCommand}},
+		{NewVaultIDFlag, o.NewVaultID != "", []string{AnsibleVaultRekeySubCommand}},
+		{NewVaultPasswordFileFlag, o.NewVaultPasswordFile != "", []string{AnsibleVaultRekeySubCommand}},
+		{OutputFlag, o.Output != "", []string{AnsibleVaultDecryptSubCommand, AnsibleVaultEncryptSubCommand, AnsibleVaultEncryptStringSubCommand}},
+		{PromptFlag, o.Prompt, []string{AnsibleVaultEncryptStringSubCommand}},
+		{ShowInputFlag, o.ShowInput, []string{AnsibleVaultEncryptStringSubCommand}},
+		{SkipTTYCheckFlag, o.SkipTTYCheck, []string{AnsibleVaultCreateSubCommand}},
+		{StdinNameFlag, o.StdinName != "", []string{AnsibleVaultEncryptStringSubCommand}},
+	}
+
+	for _, subCommandFlag := range subCommandFlags {
+		if subCommandFlag.enabled && !contains(subCommandFlag.subCommands, p.SubCommand) {
+			errs = append(errs, fmt.Errorf("'%s' is not supported by the '%s' subcommand", subCommandFlag.flag, p.SubCommand))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, fmt.Sprintf("Invalid ansible-vault %s options", p.SubCommand), errs...)
+	}
+
+	return nil
+}
+
+// contains returns whether the item is in the list
+func contains(list []string, item string) bool {
+	for _, element := range list {
+		if element == item {
+			return true
+		}
+	}
+
+	return false
+}
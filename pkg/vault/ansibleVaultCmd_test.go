@@ -0,0 +1,172 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleVaultCmd(t *testing.T) {
+	options := &AnsibleVaultOptions{
+		Output: "-",
+	}
+
+	expect := &AnsibleVaultCmd{
+		Binary:         "custom-binary",
+		SubCommand:     AnsibleVaultDecryptSubCommand,
+		Args:           []string{"secrets.yml"},
+		VaultOptions:   options,
+		SkipValidation: true,
+	}
+
+	res := NewAnsibleVaultDecryptCmd(
+		WithBinary("custom-binary"),
+		WithArgs("secrets.yml"),
+		WithVaultOptions(options),
+		WithoutValidation(),
+	)
+
+	assert.Equal(t, expect, res)
+}
+
+func TestAnsibleVaultSubCommandCmd(t *testing.T) {
+	tests := []struct {
+		desc       string
+		cmd        *AnsibleVaultCmd
+		subCommand string
+	}{
+		{"Testing create command", NewAnsibleVaultCreateCmd(), AnsibleVaultCreateSubCommand},
+		{"Testing decrypt command", NewAnsibleVaultDecryptCmd(), AnsibleVaultDecryptSubCommand},
+		{"Testing edit command", NewAnsibleVaultEditCmd(), AnsibleVaultEditSubCommand},
+		{"Testing encrypt command", NewAnsibleVaultEncryptCmd(), AnsibleVaultEncryptSubCommand},
+		{"Testing encrypt_string command", NewAnsibleVaultEncryptStringCmd(), AnsibleVaultEncryptStringSubCommand},
+		{"Testing rekey command", NewAnsibleVaultRekeyCmd(), AnsibleVaultRekeySubCommand},
+		{"Testing view command", NewAnsibleVaultViewCmd(), AnsibleVaultViewSubCommand},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.subCommand, test.cmd.SubCommand)
+		})
+	}
+}
+
+func TestAnsibleVaultCmdCommand(t *testing.T) {
+	errContext := "(vault::AnsibleVaultCmd::Command)"
+
+	tests := []struct {
+		desc string
+		cmd  *AnsibleVaultCmd
+		res  []string
+		err  error
+	}{
+		{
+			desc: "Testing generate an ansible-vault encrypt command",
+			cmd: NewAnsibleVaultEncryptCmd(
+				WithArgs("secrets.yml", "passwords.yml"),
+				WithVaultOptions(&AnsibleVaultOptions{
+					EncryptVaultID: "prod",
+					VaultIDs:       []string{"dev@dev.txt", "prod@prod.txt"},
+				}),
+			),
+			res: []string{DefaultAnsibleVaultBinary, "encrypt", "--encrypt-vault-id=prod", "--vault-id=dev@dev.txt", "--vault-id=prod@prod.txt", "secrets.yml", "passwords.yml"},
+			err: nil,
+		},
+		{
+			desc: "Testing generate an ansible-vault encrypt_string command",
+			cmd: NewAnsibleVaultEncryptStringCmd(
+				WithBinary("custom-binary"),
+				WithArgs("secret"),
+				WithVaultOptions(&AnsibleVaultOptions{
+					Names:  []string{"db_password"},
+					Output: "-",
+				}),
+			),
+			res: []string{"custom-binary", "encrypt_string", "--name=db_password", "--output=-", "secret"},
+			err: nil,
+		},
+		{
+			desc: "Testing generate an ansible-vault encrypt command reading from stdin",
+			cmd:  NewAnsibleVaultEncryptCmd(),
+			res:  []string{DefaultAnsibleVaultBinary, "encrypt"},
+			err:  nil,
+		},
+		{
+			desc: "Testing error generating an ansible-vault command without subcommand",
+			cmd:  NewAnsibleVaultCmd(),
+			res:  nil,
+			err:  errors.New(errContext, "No subcommand defined"),
+		},
+		{
+			desc: "Testing error generating an ansible-vault command with an unsupported subcommand",
+			cmd:  NewAnsibleVaultCmd(WithSubCommand("unknown")),
+			res:  nil,
+			err:  errors.New(errContext, "Subcommand 'unknown' is not supported. Use one of 'create', 'decrypt', 'edit', 'encrypt', 'encrypt_string', 'rekey', 'view'"),
+		},
+		{
+			desc: "Testing error generating an ansible-vault view command without files",
+			cmd:  NewAnsibleVaultViewCmd(),
+			res:  nil,
+			err:  errors.New(errContext, "No files defined for the 'view' subcommand"),
+		},
+		{
+			desc: "Testing error generating an ansible-vault command with options not supported by the subcommand",
+			cmd: NewAnsibleVaultViewCmd(
+				WithArgs("secrets.yml"),
+				WithVaultOptions(&AnsibleVaultOptions{
+					Output:     "-",
+					NewVaultID: "new@new.txt",
+				}),
+			),
+			res: nil,
+			err: errors.New(errContext, "Error validating options",
+				errors.New("(vault::AnsibleVaultCmd::validate)", "Invalid ansible-vault view options",
+					fmt.Errorf("'--new-vault-id' is not supported by the 'view' subcommand"),
+					fmt.Errorf("'--output' is not supported by the 'view' subcommand"),
+				),
+			),
+		},
+		{
+			desc: "Testing generate an ansible-vault command without validation",
+			cmd: NewAnsibleVaultViewCmd(
+				WithArgs("secrets.yml"),
+				WithVaultOptions(&AnsibleVaultOptions{
+					Output: "-",
+				}),
+				WithoutValidation(),
+			),
+			res: []string{DefaultAnsibleVaultBinary, "view", "--output=-", "secrets.yml"},
+			err: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := test.cmd.Command()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Nil(t, test.err)
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestAnsibleVaultCmdString(t *testing.T) {
+	cmd := NewAnsibleVaultRekeyCmd(
+		WithArgs("secrets.yml"),
+		WithVaultOptions(&AnsibleVaultOptions{
+			NewVaultID: "new@new.txt",
+			VaultIDs:   []string{"old@old.txt"},
+		}),
+	)
+
+	assert.Equal(t, "ansible-vault rekey --new-vault-id=new@new.txt --vault-id=old@old.txt secrets.yml", cmd.String())
+}
@@ -0,0 +1,56 @@
+package vault
+
+import (
+	"github.com/pkg/errors"
+)
+
+const (
+	// AnsibleVaultErrorCodeGeneralError is the error code for a general error
+	AnsibleVaultErrorCodeGeneralError = 1
+	// AnsibleVaultErrorCodeBadOrIncompleteOptions is the error code for a bad or incomplete options
+	AnsibleVaultErrorCodeBadOrIncompleteOptions = 5
+	// AnsibleVaultErrorCodeUserInterruptedExecution is the error code for a user interrupted execution
+	AnsibleVaultErrorCodeUserInterruptedExecution = 99
+	// AnsibleVaultErrorCodeUnexpectedError is the error code for a unexpected error
+	AnsibleVaultErrorCodeUnexpectedError = 250
+
+	// AnsibleVaultErrorMessageGeneralError is the error message for a general error
+	AnsibleVaultErrorMessageGeneralError = "ansible-vault error: general error"
+	// AnsibleVaultErrorMessageBadOrIncompleteOptions is the error message for a bad or incomplete options
+	AnsibleVaultErrorMessageBadOrIncompleteOptions = "ansible-vault error: bad or incomplete options"
+	// AnsibleVaultErrorMessageUserInterruptedExecution is the error message for a user interrupted execution
+	AnsibleVaultErrorMessageUserInterruptedExecution = "ansible-vault error: user interrupted execution"
+	// AnsibleVaultErrorMessageUnexpectedError is the error message for a unexpected error
+	AnsibleVaultErrorMessageUnexpectedError = "ansible-vault error: unexpected error"
+)
+
+// AnsibleVaultErrorEnrich is an error enricher for ansible-vault errors
+type AnsibleVaultErrorEnrich struct{}
+
+// NewAnsibleVaultErrorEnrich creates a new AnsibleVaultErrorEnrich instance
+func NewAnsibleVaultErrorEnrich() *AnsibleVaultErrorEnrich {
+	return &AnsibleVaultErrorEnrich{}
+}
+
+// Enrich return an error enriched with ansible-vault error information
+func (e *AnsibleVaultErrorEnrich) Enrich(err error) error {
+
+	var errorMessage string
+
+	exitCodeErr, hasExitCode := err.(ExitCodeErrorer)
+
+	if hasExitCode {
+		switch exitCodeErr.ExitCode() {
+		case AnsibleVaultErrorCodeGeneralError:
+			errorMessage = AnsibleVaultErrorMessageGeneralError
+		case AnsibleVaultErrorCodeBadOrIncompleteOptions:
+			errorMessage = AnsibleVaultErrorMessageBadOrIncompleteOptions
+		case AnsibleVaultErrorCodeUserInterruptedExecution:
+			errorMessage = AnsibleVaultErrorMessageUserInterruptedExecution
+		case AnsibleVaultErrorCodeUnexpectedError:
+			errorMessage = AnsibleVaultErrorMessageUnexpectedError
+		}
+	}
+
+	return errors.Wrap(err, errorMessage)
+}
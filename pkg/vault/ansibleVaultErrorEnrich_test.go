@@ -0,0 +1,60 @@
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnsibleVaultErrorEnrich(t *testing.T) {
+
+	tests := []struct {
+		desc     string
+		err      error
+		expected string
+	}{
+		{
+			desc: "Testing enrich with a ansible-vault general error",
+			err: &mocks.MockExitCodeErr{
+				Code:    AnsibleVaultErrorCodeGeneralError,
+				Message: "error cause",
+			},
+			expected: fmt.Sprintf("%s: %s", AnsibleVaultErrorMessageGeneralError, "error cause"),
+		},
+		{
+			desc: "Testing enrich with a ansible-vault bad or incomplete options error",
+			err: &mocks.MockExitCodeErr{
+				Code:    AnsibleVaultErrorCodeBadOrIncompleteOptions,
+				Message: "error cause",
+			},
+			expected: fmt.Sprintf("%s: %s", AnsibleVaultErrorMessageBadOrIncompleteOptions, "error cause"),
+		},
+		{
+			desc: "Testing enrich with a ansible-vault user interrupted execution error",
+			err: &mocks.MockExitCodeErr{
+				Code:    AnsibleVaultErrorCodeUserInterruptedExecution,
+				Message: "error cause",
+			},
+			expected: fmt.Sprintf("%s: %s", AnsibleVaultErrorMessageUserInterruptedExecution, "error cause"),
+		},
+		{
+			desc: "Testing enrich with a ansible-vault unexpected error",
+			err: &mocks.MockExitCodeErr{
+				Code:    AnsibleVaultErrorCodeUnexpectedError,
+				Message: "error cause",
+			},
+			expected: fmt.Sprintf("%s: %s", AnsibleVaultErrorMessageUnexpectedError, "error cause"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := NewAnsibleVaultErrorEnrich().Enrich(test.err)
+			assert.Equal(t, test.expected, err.Error())
+		})
+	}
+}
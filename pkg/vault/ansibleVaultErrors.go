@@ -0,0 +1,69 @@
+package vault
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrWrongPassword is the error when none of the vault passwords can decrypt the data
+	ErrWrongPassword = fmt.Errorf("ansible-vault error: decryption failed, no vault password can decrypt the data")
+	// ErrNoVaultSecrets is the error when the data is encrypted but no vault password is provided
+	ErrNoVaultSecrets = fmt.Errorf("ansible-vault error: no vault password provided to decrypt the data")
+	// ErrNotVaultEncrypted is the error when the data to decrypt is not encrypted
+	ErrNotVaultEncrypted = fmt.Errorf("ansible-vault error: the data is not vault encrypted")
+	// ErrAlreadyEncrypted is the error when the data to encrypt is already encrypted
+	ErrAlreadyEncrypted = fmt.Errorf("ansible-vault error: the data is already vault encrypted")
+)
+
+// ansibleVaultErrorPatterns relates the ansible-vault error messages to their typed error
+var ansibleVaultErrorPatterns = []struct {
+	pattern string
+	err     error
+}{
+	{"Decryption failed", ErrWrongPassword},
+	{"no vault secrets", ErrNoVaultSecrets},
+	{"is not vault encrypted", ErrNotVaultEncrypted},
+	{"already encrypted", ErrAlreadyEncrypted},
+}
+
+// AnsibleVaultError is the error returned when ansible-vault fails for a known reason. It matches its Kind using errors.Is
+type AnsibleVaultError struct {
+	// Kind is the typed error that describes the failure, such as ErrWrongPassword
+	Kind error
+	// Message is the error message reported by ansible-vault
+	Message string
+	// Err is the execution error
+	Err error
+}
+
+// Error returns the error message
+func (e *AnsibleVaultError) Error() string {
+	if e.Message == "" {
+		return e.Kind.Error()
+	}
+
+	return fmt.Sprintf("%s: %s", e.Kind.Error(), e.Message)
+}
+
+// Unwrap returns the typed error and the execution error
+func (e *AnsibleVaultError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// newAnsibleVaultError returns an AnsibleVaultError when the ansible-vault stderr reports a known failure. Otherwise, it returns nil
+func newAnsibleVaultError(stderr string, err error) *AnsibleVaultError {
+	for _, line := range strings.Split(stderr, "\n") {
+		for _, errorPattern := range ansibleVaultErrorPatterns {
+			if strings.Contains(line, errorPattern.pattern) {
+				return &AnsibleVaultError{
+					Kind:    errorPattern.err,
+					Message: strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "ERROR!")),
+					Err:     err,
+				}
+			}
+		}
+	}
+
+	return nil
+}
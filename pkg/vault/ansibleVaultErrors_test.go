@@ -0,0 +1,65 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAnsibleVaultError(t *testing.T) {
+	execErr := fmt.Errorf("exit status 1")
+
+	tests := []struct {
+		desc   string
+		stderr string
+		kind   error
+		res    string
+	}{
+		{
+			desc:   "Testing ansible-vault wrong password error",
+			stderr: "ERROR! Decryption failed (no vault secrets were found that could decrypt) on secrets.yml\n",
+			kind:   ErrWrongPassword,
+			res:    fmt.Sprintf("%s: %s", ErrWrongPassword, "Decryption failed (no vault secrets were found that could decrypt) on secrets.yml"),
+		},
+		{
+			desc:   "Testing ansible-vault no vault secrets error",
+			stderr: "[WARNING]: a warning\nERROR! Attempting to decrypt but no vault secrets found\n",
+			kind:   ErrNoVaultSecrets,
+			res:    fmt.Sprintf("%s: %s", ErrNoVaultSecrets, "Attempting to decrypt but no vault secrets found"),
+		},
+		{
+			desc:   "Testing ansible-vault not encrypted error",
+			stderr: "ERROR! input is not vault encrypted data. secrets.yml is not a vault encrypted file\n",
+			kind:   ErrNotVaultEncrypted,
+			res:    fmt.Sprintf("%s: %s", ErrNotVaultEncrypted, "input is not vault encrypted data. secrets.yml is not a vault encrypted file"),
+		},
+		{
+			desc:   "Testing ansible-vault already encrypted error",
+			stderr: "ERROR! input is already encrypted\n",
+			kind:   ErrAlreadyEncrypted,
+			res:    fmt.Sprintf("%s: %s", ErrAlreadyEncrypted, "input is already encrypted"),
+		},
+		{
+			desc:   "Testing ansible-vault unknown error",
+			stderr: "ERROR! something else failed\n",
+			kind:   nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := newAnsibleVaultError(test.stderr, execErr)
+			if test.kind == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.True(t, errors.Is(err, test.kind))
+				assert.True(t, errors.Is(err, execErr))
+				assert.Equal(t, test.res, err.Error())
+			}
+		})
+	}
+}
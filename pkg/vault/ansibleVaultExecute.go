@@ -0,0 +1,178 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+	"github.com/apenella/go-ansible/v2/pkg/vault/client"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+// vaultPasswordReader relates a vault id label to the reader of its password
+type vaultPasswordReader struct {
+	label  string
+	reader PasswordReader
+}
+
+// AnsibleVaultExecute is an executor for ansible-vault command that runs the command using a DefaultExecute with default options
+type AnsibleVaultExecute struct {
+	cmd             *AnsibleVaultCmd
+	exec            execute.Executabler
+	passwordReaders []vaultPasswordReader
+	write           io.Writer
+	writeError      io.Writer
+}
+
+// NewAnsibleVaultExecute returns a new AnsibleVaultExecute. It receives the subcommand to run and its arguments
+func NewAnsibleVaultExecute(subCommand string, args ...string) *AnsibleVaultExecute {
+
+	ansibleVaultCmd := &AnsibleVaultCmd{
+		SubCommand: subCommand,
+		Args:       append([]string{}, args...),
+	}
+
+	exec := &AnsibleVaultExecute{
+		cmd: ansibleVaultCmd,
+	}
+
+	return exec
+}
+
+// NewAnsibleVaultCreateExecute returns a new AnsibleVaultExecute that creates the encrypted files
+func NewAnsibleVaultCreateExecute(files ...string) *AnsibleVaultExecute {
+	return NewAnsibleVaultExecute(AnsibleVaultCreateSubCommand, files...)
+}
+
+// NewAnsibleVaultDecryptExecute returns a new AnsibleVaultExecute that decrypts the files
+func NewAnsibleVaultDecryptExecute(files ...string) *AnsibleVaultExecute {
+	return NewAnsibleVaultExecute(AnsibleVaultDecryptSubCommand, files...)
+}
+
+// NewAnsibleVaultEditExecute returns a new AnsibleVaultExecute that edits the encrypted files
+func NewAnsibleVaultEditExecute(files ...string) *AnsibleVaultExecute {
+	return NewAnsibleVaultExecute(AnsibleVaultEditSubCommand, files...)
+}
+
+// NewAnsibleVaultEncryptExecute returns a new AnsibleVaultExecute that encrypts the files
+func NewAnsibleVaultEncryptExecute(files ...string) *AnsibleVaultExecute {
+	return NewAnsibleVaultExecute(AnsibleVaultEncryptSubCommand, files...)
+}
+
+// NewAnsibleVaultEncryptStringExecute returns a new AnsibleVaultExecute that encrypts the strings
+func NewAnsibleVaultEncryptStringExecute(strings ...string) *AnsibleVaultExecute {
+	return NewAnsibleVaultExecute(AnsibleVaultEncryptStringSubCommand, strings...)
+}
+
+// NewAnsibleVaultRekeyExecute returns a new AnsibleVaultExecute that rekeys the encrypted files
+func NewAnsibleVaultRekeyExecute(files ...string) *AnsibleVaultExecute {
+	return NewAnsibleVaultExecute(AnsibleVaultRekeySubCommand, files...)
+}
+
+// NewAnsibleVaultViewExecute returns a new AnsibleVaultExecute that shows the encrypted files content
+func NewAnsibleVaultViewExecute(files ...string) *AnsibleVaultExecute {
+	return NewAnsibleVaultExecute(AnsibleVaultViewSubCommand, files...)
+}
+
+// WithBinary return an AnsibleVaultExecute with the binary file set
+func (e *AnsibleVaultExecute) WithBinary(binary string) *AnsibleVaultExecute {
+	e.cmd.Binary = binary
+
+	return e
+}
+
+// WithExecutable return an AnsibleVaultExecute with the executable used to run the command
+func (e *AnsibleVaultExecute) WithExecutable(executable execute.Executabler) *AnsibleVaultExecute {
+	e.exec = executable
+
+	return e
+}
+
+// WithVaultOptions returns an AnsibleVaultExecute with the ansible-vault options set
+func (e *AnsibleVaultExecute) WithVaultOptions(options *AnsibleVaultOptions) *AnsibleVaultExecute {
+	e.cmd.VaultOptions = options
+
+	return e
+}
+
+// WithVaultPasswordReader returns an AnsibleVaultExecute that reads the password of the vault id label from the reader. The password is served to ansible-vault through a vault client script, without writing it to disk
+func (e *AnsibleVaultExecute) WithVaultPasswordReader(label string, reader PasswordReader) *AnsibleVaultExecute {
+	e.passwordReaders = append(e.passwordReaders, vaultPasswordReader{
+		label:  label,
+		reader: reader,
+	})
+
+	return e
+}
+
+// WithWrite returns an AnsibleVaultExecute that writes the command stdout, such as the view or encrypt_string output, to w
+func (e *AnsibleVaultExecute) WithWrite(w io.Writer) *AnsibleVaultExecute {
+	e.write = w
+
+	return e
+}
+
+// WithWriteError returns an AnsibleVaultExecute that writes the command stderr to w
+func (e *AnsibleVaultExecute) WithWriteError(w io.Writer) *AnsibleVaultExecute {
+	e.writeError = w
+
+	return e
+}
+
+// Execute method runs the ansible-vault command using a DefaultExecute with default options. When ansible-vault fails for a known reason, such as a wrong password, it returns an AnsibleVaultError
+func (e *AnsibleVaultExecute) Execute(ctx context.Context) error {
+	errContext := "(vault::AnsibleVaultExecute::Execute)"
+
+	cmd := *e.cmd
+
+	if len(e.passwordReaders) > 0 {
+		clientOptions := make([]client.OptionsFunc, 0, len(e.passwordReaders))
+		for _, passwordReader := range e.passwordReaders {
+			clientOptions = append(clientOptions, client.WithPasswordReader(passwordReader.label, passwordReader.reader))
+		}
+
+		passwordClient := client.NewVaultPasswordClient(clientOptions...)
+		err := passwordClient.Start()
+		if err != nil {
+			return errors.New(errContext, "Error providing the vault password", err)
+		}
+		defer passwordClient.Close()
+
+		cmd.VaultOptions = e.cmd.VaultOptions.copy()
+		cmd.VaultOptions.VaultIDs = append(cmd.VaultOptions.VaultIDs, passwordClient.VaultIDs()...)
+	}
+
+	writeError := e.writeError
+	if writeError == nil {
+		writeError = os.Stderr
+	}
+	stderr := &bytes.Buffer{}
+
+	options := []execute.ExecuteOptions{
+		execute.WithCmd(&cmd),
+		execute.WithErrorEnrich(NewAnsibleVaultErrorEnrich()),
+		execute.WithWriteError(io.MultiWriter(writeError, stderr)),
+	}
+
+	if e.exec != nil {
+		options = append(options, execute.WithExecutable(e.exec))
+	}
+
+	if e.write != nil {
+		options = append(options, execute.WithWrite(e.write))
+	}
+
+	err := execute.NewDefaultExecute(options...).Execute(ctx)
+	if err != nil {
+		vaultErr := newAnsibleVaultError(stderr.String(), err)
+		if vaultErr != nil {
+			return vaultErr
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	osexec "os/exec"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/exec"
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewAnsibleVaultExecute(t *testing.T) {
+	expect := &AnsibleVaultExecute{
+		cmd: &AnsibleVaultCmd{
+			SubCommand: AnsibleVaultViewSubCommand,
+			Args:       []string{"secrets.yml"},
+		},
+	}
+
+	res := NewAnsibleVaultViewExecute("secrets.yml")
+
+	assert.Equal(t, expect, res)
+}
+
+func TestAnsibleVaultExecuteExecute(t *testing.T) {
+
+	t.Run("Testing execute an ansible-vault command", func(t *testing.T) {
+		t.Log("Testing execute an ansible-vault command")
+
+		e := exec.NewMockExec()
+		cmd := exec.NewMockCmd()
+
+		cmd.On("StdoutPipe").Return(io.NopCloser(strings.NewReader("db_password: secret\n")), nil)
+		cmd.On("StderrPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+		cmd.On("Start").Return(nil)
+		cmd.On("Wait").Return(nil)
+		// the compatibility check detects the ansible version, unless it is already cached
+		versionCmd := exec.NewMockCmd()
+		versionCmd.On("Output").Return([]byte("ansible-vault [core 2.16.3]\n"), nil).Maybe()
+		e.On("CommandContext", context.TODO(), "custom-binary", []string{"--version"}).Return(versionCmd).Maybe()
+		e.On("CommandContext", context.TODO(), "custom-binary", []string{"view", "--vault-id=prod@prod.txt", "secrets.yml"}).Return(cmd)
+
+		output := &bytes.Buffer{}
+		err := NewAnsibleVaultViewExecute("secrets.yml").
+			WithBinary("custom-binary").
+			WithExecutable(e).
+			WithVaultOptions(&AnsibleVaultOptions{
+				VaultIDs: []string{"prod@prod.txt"},
+			}).
+			WithWrite(output).
+			Execute(context.TODO())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "db_password: secret\n", output.String())
+		e.AssertExpectations(t)
+		cmd.AssertExpectations(t)
+	})
+
+	t.Run("Testing execute an ansible-vault command with a password reader", func(t *testing.T) {
+		t.Log("Testing execute an ansible-vault command with a password reader")
+
+		_, err := osexec.LookPath("python3")
+		if err != nil {
+			t.Skip("python3 is required to run the vault client script")
+		}
+
+		e := exec.NewMockExec()
+		cmd := exec.NewMockCmd()
+		script := ""
+
+		cmd.On("StdoutPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+		cmd.On("StderrPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+		cmd.On("Start").Return(nil)
+		cmd.On("Wait").Return(nil)
+		// the compatibility check detects the ansible version, unless it is already cached
+		versionCmd := exec.NewMockCmd()
+		versionCmd.On("Output").Return([]byte("ansible-vault [core 2.16.3]\n"), nil).Maybe()
+		e.On("CommandContext", context.TODO(), DefaultAnsibleVaultBinary, []string{"--version"}).Return(versionCmd).Maybe()
+		e.On("CommandContext", context.TODO(), DefaultAnsibleVaultBinary, mock.MatchedBy(func(args []string) bool {
+			if len(args) != 4 || args[0] != "encrypt" || args[1] != "--vault-id=dev@dev.txt" || !strings.HasPrefix(args[2], "--vault-id=prod@") || args[3] != "secrets.yml" {
+				return false
+			}
+
+			// ansible-vault runs the vault client script with the vault id label
+			script = strings.TrimPrefix(args[2], "--vault-id=prod@")
+			password, err := osexec.Command(script, "--vault-id", "prod").Output()
+			return err == nil && string(password) == "secret"
+		})).Return(cmd)
+
+		options := &AnsibleVaultOptions{
+			VaultIDs: []string{"dev@dev.txt"},
+		}
+
+		err = NewAnsibleVaultEncryptExecute("secrets.yml").
+			WithExecutable(e).
+			WithVaultOptions(options).
+			WithVaultPasswordReader("prod", text.NewReadPasswordFromText(text.WithText("secret"))).
+			Execute(context.TODO())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"dev@dev.txt"}, options.VaultIDs)
+		_, err = os.Stat(script)
+		assert.True(t, os.IsNotExist(err))
+		e.AssertExpectations(t)
+	})
+
+	t.Run("Testing execute an ansible-vault command with a wrong password", func(t *testing.T) {
+		t.Log("Testing execute an ansible-vault command with a wrong password")
+
+		e := exec.NewMockExec()
+		cmd := exec.NewMockCmd()
+
+		cmd.On("StdoutPipe").Return(io.NopCloser(strings.NewReader("")), nil)
+		cmd.On("StderrPipe").Return(io.NopCloser(strings.NewReader("ERROR! Decryption failed (no vault secrets were found that could decrypt) on secrets.yml\n")), nil)
+		cmd.On("Start").Return(nil)
+		cmd.On("Wait").Return(fmt.Errorf("exit status 1"))
+		// the compatibility check detects the ansible version, unless it is already cached
+		versionCmd := exec.NewMockCmd()
+		versionCmd.On("Output").Return([]byte("ansible-vault [core 2.16.3]\n"), nil).Maybe()
+		e.On("CommandContext", context.TODO(), DefaultAnsibleVaultBinary, []string{"--version"}).Return(versionCmd).Maybe()
+		e.On("CommandContext", context.TODO(), DefaultAnsibleVaultBinary, []string{"decrypt", "secrets.yml"}).Return(cmd)
+
+		err := NewAnsibleVaultDecryptExecute("secrets.yml").
+			WithExecutable(e).
+			WithWriteError(io.Discard).
+			Execute(context.TODO())
+
+		assert.True(t, errors.Is(err, ErrWrongPassword))
+		vaultErr := &AnsibleVaultError{}
+		assert.True(t, errors.As(err, &vaultErr))
+		assert.Equal(t, "Decryption failed (no vault secrets were found that could decrypt) on secrets.yml", vaultErr.Message)
+	})
+
+	t.Run("Testing error providing the vault password", func(t *testing.T) {
+		t.Log("Testing error providing the vault password")
+
+		e := exec.NewMockExec()
+
+		err := NewAnsibleVaultEncryptStringExecute("secret").
+			WithExecutable(e).
+			WithVaultPasswordReader("dev@prod", text.NewReadPasswordFromText(text.WithText("secret"))).
+			Execute(context.TODO())
+
+		assert.Error(t, err)
+		e.AssertNotCalled(t, "CommandContext", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,321 @@
+package vault
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apenella/go-ansible/v2/pkg/internal/validation"
+	errors "github.com/apenella/go-common-utils/error"
+)
+
+const (
+	// AskVaultPasswordFlag ask for vault password
+	AskVaultPasswordFlag = "--ask-vault-password"
+
+	// EncryptVaultIDFlag the vault id used to encrypt. It is required when more than one vault id is provided
+	EncryptVaultIDFlag = "--encrypt-vault-id"
+
+	// NameFlag the variable name used by encrypt_string
+	NameFlag = "--name"
+
+	// NewVaultIDFlag the new vault identity used by rekey
+	NewVaultIDFlag = "--new-vault-id"
+
+	// NewVaultPasswordFileFlag the new vault password file used by rekey
+	NewVaultPasswordFileFlag = "--new-vault-password-file"
+
+	// OutputFlag output file name for encrypt, decrypt or encrypt_string. Use - for stdout
+	OutputFlag = "--output"
+
+	// PromptFlag prompt for the string to encrypt on encrypt_string
+	PromptFlag = "--prompt"
+
+	// ShowInputFlag does not hide the input when prompted for the string to encrypt on encrypt_string
+	ShowInputFlag = "--show-input"
+
+	// SkipTTYCheckFlag allows to open the editor on create when there is no tty attached
+	SkipTTYCheckFlag = "--skip-tty-check"
+
+	// StdinNameFlag the variable name used for the encrypt_string stdin input
+	StdinNameFlag = "--stdin-name"
+
+	// VaultIDFlag the vault identity to use
+	VaultIDFlag = "--vault-id"
+
+	// VaultPasswordFileFlag vault password file
+	VaultPasswordFileFlag = "--vault-password-file"
+
+	// VerboseFlag verbose mode enabled
+	VerboseFlag = "--verbose"
+)
+
+// AnsibleVaultOptions object has those parameters described on `Options` section within ansible-vault's man page, and which defines which should be the ansible-vault execution behavior.
+type AnsibleVaultOptions struct {
+
+	// AskVaultPassword ask for vault password
+	AskVaultPassword bool
+
+	// EncryptVaultID the vault id used to encrypt. It is required when more than one vault id is provided
+	EncryptVaultID string
+
+	// Names are the variables names used by encrypt_string, one for each string to encrypt
+	Names []string
+
+	// NewVaultID the new vault identity used by rekey
+	NewVaultID string
+
+	// NewVaultPasswordFile the new vault password file used by rekey
+	NewVaultPasswordFile string
+
+	// Output output file name for encrypt, decrypt or encrypt_string. Use - for stdout
+	Output string
+
+	// Prompt prompt for the string to encrypt on encrypt_string
+	Prompt bool
+
+	// ShowInput does not hide the input when prompted for the string to encrypt on encrypt_string
+	ShowInput bool
+
+	// SkipTTYCheck allows to open the editor on create when there is no tty attached
+	SkipTTYCheck bool
+
+	// StdinName the variable name used for the encrypt_string stdin input
+	StdinName string
+
+	// VaultIDs are the vault identities to use, defined as label@source or source. The source is a password file, a client script or prompt
+	VaultIDs []string
+
+	// VaultPasswordFiles are the files holding the vault decryption keys
+	VaultPasswordFiles []string
+
+	// Verbose verbose mode enabled
+	Verbose bool
+}
+
+// AddVaultID adds a vault identity defined by its label and source
+func (o *AnsibleVaultOptions) AddVaultID(label, source string) {
+	vaultID := source
+	if label != "" {
+		vaultID = fmt.Sprintf("%s@%s", label, source)
+	}
+
+	o.VaultIDs = append(o.VaultIDs, vaultID)
+}
+
+// GenerateCommandOptions return a list of options flags to be used on ansible-vault execution
+func (o *AnsibleVaultOptions) GenerateCommandOptions() ([]string, error) {
+	errContext := "(vault::AnsibleVaultOptions::GenerateCommandOptions)"
+	options := []string{}
+
+	if o == nil {
+		return nil, errors.New(errContext, "AnsibleVaultOptions is nil")
+	}
+
+	if o.AskVaultPassword {
+		options = append(options, AskVaultPasswordFlag)
+	}
+
+	if o.EncryptVaultID != "" {
+		options = append(options, fmt.Sprintf("%s=%s", EncryptVaultIDFlag, o.EncryptVaultID))
+	}
+
+	for _, name := range o.Names {
+		options = append(options, fmt.Sprintf("%s=%s", NameFlag, name))
+	}
+
+	if o.NewVaultID != "" {
+		options = append(options, fmt.Sprintf("%s=%s", NewVaultIDFlag, o.NewVaultID))
+	}
+
+	if o.NewVaultPasswordFile != "" {
+		options = append(options, fmt.Sprintf("%s=%s", NewVaultPasswordFileFlag, o.NewVaultPasswordFile))
+	}
+
+	if o.Output != "" {
+		options = append(options, fmt.Sprintf("%s=%s", OutputFlag, o.Output))
+	}
+
+	if o.Prompt {
+		options = append(options, PromptFlag)
+	}
+
+	if o.ShowInput {
+		options = append(options, ShowInputFlag)
+	}
+
+	if o.SkipTTYCheck {
+		options = append(options, SkipTTYCheckFlag)
+	}
+
+	if o.StdinName != "" {
+		options = append(options, fmt.Sprintf("%s=%s", StdinNameFlag, o.StdinName))
+	}
+
+	for _, vaultID := range o.VaultIDs {
+		options = append(options, fmt.Sprintf("%s=%s", VaultIDFlag, vaultID))
+	}
+
+	for _, file := range o.VaultPasswordFiles {
+		options = append(options, fmt.Sprintf("%s=%s", VaultPasswordFileFlag, file))
+	}
+
+	if o.Verbose {
+		options = append(options, VerboseFlag)
+	}
+
+	return options, nil
+}
+
+// Validate checks the options looking for mutually exclusive flags and unsupported values. It returns an error that wraps all the detected issues
+func (o *AnsibleVaultOptions) Validate() error {
+	errContext := "(vault::AnsibleVaultOptions::Validate)"
+	errs := []error{}
+
+	if o == nil {
+		return errors.New(errContext, "AnsibleVaultOptions is nil")
+	}
+
+	if o.AskVaultPassword && len(o.VaultPasswordFiles) > 0 {
+		errs = append(errs, fmt.Errorf("'%s' and '%s' are mutually exclusive", AskVaultPasswordFlag, VaultPasswordFileFlag))
+	}
+
+	if o.Prompt && o.StdinName != "" {
+		errs = append(errs, fmt.Errorf("'%s' and '%s' are mutually exclusive", PromptFlag, StdinNameFlag))
+	}
+
+	if o.ShowInput && !o.Prompt {
+		errs = append(errs, fmt.Errorf("'%s' requires '%s'", ShowInputFlag, PromptFlag))
+	}
+
+	for _, vaultID := range o.VaultIDs {
+		label, source, hasLabel := strings.Cut(vaultID, "@")
+		if vaultID == "" || (hasLabel && (label == "" || source == "")) {
+			errs = append(errs, fmt.Errorf("'%s' value '%s' must be defined as label@source or source", VaultIDFlag, vaultID))
+		}
+	}
+
+	if o.EncryptVaultID != "" && len(o.VaultIDs) > 0 && !hasVaultIDLabel(o.VaultIDs, o.EncryptVaultID) {
+		errs = append(errs, fmt.Errorf("'%s' label '%s' is not defined by any '%s'", EncryptVaultIDFlag, o.EncryptVaultID, VaultIDFlag))
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid ansible-vault options", errs...)
+	}
+
+	return nil
+}
+
+// ValidateFiles checks that the files referenced by the options exist and are not directories. The relative files are resolved against dir, which is the directory where the command runs, or against the working directory when dir is empty. It is not part of Validate because the command may run on another directory, host or container
+func (o *AnsibleVaultOptions) ValidateFiles(dir string) error {
+	errContext := "(vault::AnsibleVaultOptions::ValidateFiles)"
+	errs := []error{}
+
+	if o == nil {
+		return errors.New(errContext, "AnsibleVaultOptions is nil")
+	}
+
+	for _, file := range o.VaultPasswordFiles {
+		err := validation.FileExists(dir, file)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("'%s' file is not valid: %w", VaultPasswordFileFlag, err))
+		}
+	}
+
+	if o.NewVaultPasswordFile != "" {
+		err := validation.FileExists(dir, o.NewVaultPasswordFile)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("'%s' file is not valid: %w", NewVaultPasswordFileFlag, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(errContext, "Invalid ansible-vault option files", errs...)
+	}
+
+	return nil
+}
+
+// String return a string representation of the AnsibleVaultOptions
+func (o *AnsibleVaultOptions) String() string {
+	str := ""
+
+	if o.AskVaultPassword {
+		str = fmt.Sprintf("%s %s", str, AskVaultPasswordFlag)
+	}
+
+	if o.EncryptVaultID != "" {
+		str = fmt.Sprintf("%s %s=%s", str, EncryptVaultIDFlag, o.EncryptVaultID)
+	}
+
+	for _, name := range o.Names {
+		str = fmt.Sprintf("%s %s=%s", str, NameFlag, name)
+	}
+
+	if o.NewVaultID != "" {
+		str = fmt.Sprintf("%s %s=%s", str, NewVaultIDFlag, o.NewVaultID)
+	}
+
+	if o.NewVaultPasswordFile != "" {
+		str = fmt.Sprintf("%s %s=%s", str, NewVaultPasswordFileFlag, o.NewVaultPasswordFile)
+	}
+
+	if o.Output != "" {
+		str = fmt.Sprintf("%s %s=%s", str, OutputFlag, o.Output)
+	}
+
+	if o.Prompt {
+		str = fmt.Sprintf("%s %s", str, PromptFlag)
+	}
+
+	if o.ShowInput {
+		str = fmt.Sprintf("%s %s", str, ShowInputFlag)
+	}
+
+	if o.SkipTTYCheck {
+		str = fmt.Sprintf("%s %s", str, SkipTTYCheckFlag)
+	}
+
+	if o.StdinName != "" {
+		str = fmt.Sprintf("%s %s=%s", str, StdinNameFlag, o.StdinName)
+	}
+
+	for _, vaultID := range o.VaultIDs {
+		str = fmt.Sprintf("%s %s=%s", str, VaultIDFlag, vaultID)
+	}
+
+	for _, file := range o.VaultPasswordFiles {
+		str = fmt.Sprintf("%s %s=%s", str, VaultPasswordFileFlag, file)
+	}
+
+	if o.Verbose {
+		str = fmt.Sprintf("%s %s", str, VerboseFlag)
+	}
+
+	return strings.TrimSpace(str)
+}
+
+// copy returns a copy of the options
+func (o *AnsibleVaultOptions) copy() *AnsibleVaultOptions {
+	if o == nil {
+		return &AnsibleVaultOptions{}
+	}
+
+	options := *o
+	options.Names = append([]string{}, o.Names...)
+	options.VaultIDs = append([]string{}, o.VaultIDs...)
+	options.VaultPasswordFiles = append([]string{}, o.VaultPasswordFiles...)
+
+	return &options
+}
+
+// hasVaultIDLabel returns whether any of the vault ids is defined with label
+func hasVaultIDLabel(vaultIDs []string, label string) bool {
+	for _, vaultID := range vaultIDs {
+		vaultIDLabel, _, hasLabel := strings.Cut(vaultID, "@")
+		if hasLabel && vaultIDLabel == label {
+			return true
+		}
+	}
+
+	return false
+}
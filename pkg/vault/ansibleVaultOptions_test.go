@@ -0,0 +1,221 @@
+package vault
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	errors "github.com/apenella/go-common-utils/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnsibleVaultOptionsGenerateCommandOptions(t *testing.T) {
+	tests := []struct {
+		desc    string
+		options *AnsibleVaultOptions
+		res     []string
+		err     error
+	}{
+		{
+			desc: "Testing generate ansible-vault command options",
+			options: &AnsibleVaultOptions{
+				AskVaultPassword:     true,
+				EncryptVaultID:       "prod",
+				Names:                []string{"db_password", "api_token"},
+				NewVaultID:           "new@new.txt",
+				NewVaultPasswordFile: "new.txt",
+				Output:               "-",
+				Prompt:               true,
+				ShowInput:            true,
+				SkipTTYCheck:         true,
+				StdinName:            "secret",
+				VaultIDs:             []string{"dev@dev.txt", "prod@prompt"},
+				VaultPasswordFiles:   []string{"password.txt"},
+				Verbose:              true,
+			},
+			res: []string{
+				"--ask-vault-password",
+				"--encrypt-vault-id=prod",
+				"--name=db_password",
+				"--name=api_token",
+				"--new-vault-id=new@new.txt",
+				"--new-vault-password-file=new.txt",
+				"--output=-",
+				"--prompt",
+				"--show-input",
+				"--skip-tty-check",
+				"--stdin-name=secret",
+				"--vault-id=dev@dev.txt",
+				"--vault-id=prod@prompt",
+				"--vault-password-file=password.txt",
+				"--verbose",
+			},
+			err: nil,
+		},
+		{
+			desc:    "Testing generate command options from nil options",
+			options: nil,
+			res:     nil,
+			err:     errors.New("(vault::AnsibleVaultOptions::GenerateCommandOptions)", "AnsibleVaultOptions is nil"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := test.options.GenerateCommandOptions()
+			if err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestAnsibleVaultOptionsValidate(t *testing.T) {
+	dir := t.TempDir()
+	passwordFile := filepath.Join(dir, "password.txt")
+	err := os.WriteFile(passwordFile, []byte("secret"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errContext := "(vault::AnsibleVaultOptions::Validate)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleVaultOptions
+		err     error
+	}{
+		{
+			desc: "Testing validate ansible-vault options",
+			options: &AnsibleVaultOptions{
+				EncryptVaultID:     "prod",
+				VaultIDs:           []string{"dev@dev.txt", "prod@prompt", "source.txt"},
+				VaultPasswordFiles: []string{passwordFile},
+			},
+			err: nil,
+		},
+		{
+			desc: "Testing validate ansible-vault options with invalid values",
+			options: &AnsibleVaultOptions{
+				AskVaultPassword:     true,
+				EncryptVaultID:       "prod",
+				NewVaultPasswordFile: dir,
+				ShowInput:            true,
+				StdinName:            "secret",
+				VaultIDs:             []string{"@dev.txt", "dev@"},
+				VaultPasswordFiles:   []string{passwordFile},
+			},
+			err: errors.New(errContext, "Invalid ansible-vault options",
+				fmt.Errorf("'--ask-vault-password' and '--vault-password-file' are mutually exclusive"),
+				fmt.Errorf("'--show-input' requires '--prompt'"),
+				fmt.Errorf("'--vault-id' value '@dev.txt' must be defined as label@source or source"),
+				fmt.Errorf("'--vault-id' value 'dev@' must be defined as label@source or source"),
+				fmt.Errorf("'--encrypt-vault-id' label 'prod' is not defined by any '--vault-id'"),
+			),
+		},
+		{
+			desc: "Testing validate ansible-vault prompt and stdin name",
+			options: &AnsibleVaultOptions{
+				Prompt:    true,
+				StdinName: "secret",
+			},
+			err: errors.New(errContext, "Invalid ansible-vault options",
+				fmt.Errorf("'--prompt' and '--stdin-name' are mutually exclusive"),
+			),
+		},
+		{
+			desc:    "Testing validate nil options",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleVaultOptions is nil"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.Validate()
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+func TestAnsibleVaultOptionsValidateFiles(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "password.txt"), []byte("secret"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errContext := "(vault::AnsibleVaultOptions::ValidateFiles)"
+
+	tests := []struct {
+		desc    string
+		options *AnsibleVaultOptions
+		dir     string
+		err     error
+	}{
+		{
+			desc: "Testing validate ansible-vault option files resolving the relative files against the directory",
+			options: &AnsibleVaultOptions{
+				NewVaultPasswordFile: "password.txt",
+				VaultPasswordFiles:   []string{"password.txt", filepath.Join(dir, "password.txt")},
+			},
+			dir: dir,
+			err: nil,
+		},
+		{
+			desc: "Testing validate ansible-vault option files with invalid files",
+			options: &AnsibleVaultOptions{
+				NewVaultPasswordFile: dir,
+				VaultPasswordFiles:   []string{"missing.txt"},
+			},
+			dir: dir,
+			err: errors.New(errContext, "Invalid ansible-vault option files",
+				fmt.Errorf("'--vault-password-file' file is not valid: %w", &fs.PathError{Op: "stat", Path: filepath.Join(dir, "missing.txt"), Err: syscall.ENOENT}),
+				fmt.Errorf("'--new-vault-password-file' file is not valid: %w", fmt.Errorf("%s is a directory", dir)),
+			),
+		},
+		{
+			desc:    "Testing validate ansible-vault option files of nil options",
+			options: nil,
+			err:     errors.New(errContext, "AnsibleVaultOptions is nil"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.options.ValidateFiles(test.dir)
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+func TestAnsibleVaultOptionsString(t *testing.T) {
+	options := &AnsibleVaultOptions{
+		EncryptVaultID: "prod",
+		Names:          []string{"db_password"},
+		Output:         "-",
+		VaultIDs:       []string{"prod@prod.txt"},
+		Verbose:        true,
+	}
+
+	assert.Equal(t, "--encrypt-vault-id=prod --name=db_password --output=- --vault-id=prod@prod.txt --verbose", options.String())
+}
+
+func TestAnsibleVaultOptionsAddVaultID(t *testing.T) {
+	options := &AnsibleVaultOptions{}
+	options.AddVaultID("prod", "prod.txt")
+	options.AddVaultID("", "default.txt")
+
+	assert.Equal(t, []string{"prod@prod.txt", "default.txt"}, options.VaultIDs)
+}
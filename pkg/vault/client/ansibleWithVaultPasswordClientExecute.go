@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute/configuration"
+	"github.com/pkg/errors"
+)
+
+// envVarGetter is implemented by the executors that expose their environment variables, such as the DefaultExecute
+type envVarGetter interface {
+	EnvVar(key string) (string, bool)
+}
+
+// AnsibleWithVaultPasswordClientExecute is an executor that serves the vault passwords through a VaultPasswordClient while the executor it wraps runs. The vault ids are appended to the ANSIBLE_VAULT_IDENTITY_LIST environment variable, which ansible, ansible-playbook and ansible-inventory combine with the --vault-id arguments
+type AnsibleWithVaultPasswordClientExecute struct {
+	executor configuration.ExecutorEnvVarSetter
+	options  []OptionsFunc
+}
+
+// NewAnsibleWithVaultPasswordClientExecute returns a new AnsibleWithVaultPasswordClientExecute. The options configure the VaultPasswordClient created on each execution
+func NewAnsibleWithVaultPasswordClientExecute(executor configuration.ExecutorEnvVarSetter, options ...OptionsFunc) *AnsibleWithVaultPasswordClientExecute {
+	return &AnsibleWithVaultPasswordClientExecute{
+		executor: executor,
+		options:  append([]OptionsFunc{}, options...),
+	}
+}
+
+// WithExecutor sets the executor to run while the vault passwords are served
+func (e *AnsibleWithVaultPasswordClientExecute) WithExecutor(exec configuration.ExecutorEnvVarSetter) *AnsibleWithVaultPasswordClientExecute {
+	e.executor = exec
+	return e
+}
+
+// WithPasswordReader adds the reader of the password of the vault id label
+func (e *AnsibleWithVaultPasswordClientExecute) WithPasswordReader(label string, reader PasswordReader) *AnsibleWithVaultPasswordClientExecute {
+	e.options = append(e.options, WithPasswordReader(label, reader))
+	return e
+}
+
+// Execute starts a VaultPasswordClient, appends its vault ids to the vault identity list of the executor and runs it. The vault identity list already set on the executor, or on the current process environment when the executor does not set it, is kept. The VaultPasswordClient is closed once the executor finishes, even when it fails
+func (e *AnsibleWithVaultPasswordClientExecute) Execute(ctx context.Context) error {
+	if e.executor == nil {
+		return errors.New("AnsibleWithVaultPasswordClientExecute executor requires an executor")
+	}
+
+	client := NewVaultPasswordClient(e.options...)
+	err := client.Start()
+	if err != nil {
+		return errors.Wrap(err, "Error starting the vault password client")
+	}
+	defer client.Close()
+
+	e.executor.AddEnvVar(configuration.AnsibleVaultIdentityList, VaultIdentityList(append(e.vaultIdentityList(), client.VaultIDs()...)...))
+
+	err = e.executor.Execute(ctx)
+	if err != nil {
+		return errors.Wrap(err, "Error executing command")
+	}
+
+	return nil
+}
+
+// vaultIdentityList returns the vault ids already set on the executor or, when the executor does not set them, on the current process environment
+func (e *AnsibleWithVaultPasswordClientExecute) vaultIdentityList() []string {
+	identityList := os.Getenv(configuration.AnsibleVaultIdentityList)
+
+	getter, isEnvVarGetter := e.executor.(envVarGetter)
+	if isEnvVarGetter {
+		value, isSet := getter.EnvVar(configuration.AnsibleVaultIdentityList)
+		if isSet {
+			identityList = value
+		}
+	}
+
+	vaultIDs := []string{}
+	for _, vaultID := range strings.Split(identityList, ",") {
+		vaultID = strings.TrimSpace(vaultID)
+		if vaultID != "" {
+			vaultIDs = append(vaultIDs, vaultID)
+		}
+	}
+
+	return vaultIDs
+}
+
+// VaultIdentityList returns the vault ids in the ANSIBLE_VAULT_IDENTITY_LIST format
+func VaultIdentityList(vaultIDs ...string) string {
+	return strings.Join(vaultIDs, ",")
+}
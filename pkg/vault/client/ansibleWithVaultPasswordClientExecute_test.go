@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/execute"
+	"github.com/apenella/go-ansible/v2/pkg/execute/configuration"
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAnsibleWithVaultPasswordClientExecuteExecute(t *testing.T) {
+	reader := text.NewReadPasswordFromText(text.WithText("password"))
+
+	tests := []struct {
+		desc              string
+		exec              *AnsibleWithVaultPasswordClientExecute
+		executor          *execute.MockExecute
+		prepareAssertFunc func(*execute.MockExecute)
+		vaultIDs          []string
+		labels            []string
+		err               string
+	}{
+		{
+			desc:     "Testing execute serving the vault passwords",
+			executor: execute.NewMockExecute(),
+			exec:     NewAnsibleWithVaultPasswordClientExecute(nil, WithPasswordReader("dev", reader)).WithPasswordReader("prod", reader),
+			prepareAssertFunc: func(executor *execute.MockExecute) {
+				executor.On("EnvVar", configuration.AnsibleVaultIdentityList).Return("", false)
+				executor.On("AddEnvVar", configuration.AnsibleVaultIdentityList, mock.Anything).Return()
+				executor.On("Execute", context.TODO()).Return(nil)
+			},
+			labels: []string{"dev", "prod"},
+		},
+		{
+			desc:     "Testing execute keeping the vault identity list set on the executor",
+			executor: execute.NewMockExecute(),
+			exec:     NewAnsibleWithVaultPasswordClientExecute(nil, WithPasswordReader("dev", reader)),
+			prepareAssertFunc: func(executor *execute.MockExecute) {
+				executor.On("EnvVar", configuration.AnsibleVaultIdentityList).Return("prod@/etc/ansible/prod-client, test@prompt", true)
+				executor.On("AddEnvVar", configuration.AnsibleVaultIdentityList, mock.Anything).Return()
+				executor.On("Execute", context.TODO()).Return(nil)
+			},
+			vaultIDs: []string{"prod@/etc/ansible/prod-client", "test@prompt"},
+			labels:   []string{"dev"},
+		},
+		{
+			desc:     "Testing error executing the executor",
+			executor: execute.NewMockExecute(),
+			exec:     NewAnsibleWithVaultPasswordClientExecute(nil, WithPasswordReader("dev", reader)),
+			prepareAssertFunc: func(executor *execute.MockExecute) {
+				executor.On("EnvVar", configuration.AnsibleVaultIdentityList).Return("", false)
+				executor.On("AddEnvVar", configuration.AnsibleVaultIdentityList, mock.Anything).Return()
+				executor.On("Execute", context.TODO()).Return(fmt.Errorf("error"))
+			},
+			labels: []string{"dev"},
+			err:    "Error executing command: error",
+		},
+		{
+			desc:     "Testing error starting the vault password client",
+			executor: execute.NewMockExecute(),
+			exec:     NewAnsibleWithVaultPasswordClientExecute(nil),
+			err:      "Error starting the vault password client: VaultPasswordClient requires at least one password reader",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			if test.prepareAssertFunc != nil {
+				test.prepareAssertFunc(test.executor)
+			}
+
+			err := test.exec.WithExecutor(test.executor).Execute(context.TODO())
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			test.executor.AssertExpectations(t)
+
+			for _, call := range test.executor.Calls {
+				if call.Method != "AddEnvVar" {
+					continue
+				}
+
+				vaultIDs := strings.Split(call.Arguments.String(1), ",")
+				assert.Len(t, vaultIDs, len(test.vaultIDs)+len(test.labels))
+				assert.Equal(t, append([]string{}, test.vaultIDs...), vaultIDs[:len(test.vaultIDs)])
+				for i, vaultID := range vaultIDs[len(test.vaultIDs):] {
+					label, script, _ := strings.Cut(vaultID, "@")
+					assert.Equal(t, test.labels[i], label)
+
+					// the vault client is removed once the execution finishes
+					_, err := os.Stat(script)
+					assert.True(t, os.IsNotExist(err))
+				}
+			}
+		})
+	}
+}
+
+// TestAnsibleWithVaultPasswordClientExecuteServePasswords tests that the vault ids set on the executor serve the passwords to the vault client script, as ansible calls it, while the executor runs
+func TestAnsibleWithVaultPasswordClientExecuteServePasswords(t *testing.T) {
+	_, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 is required to run the vault client script")
+	}
+
+	t.Log("Testing execute serving the vault passwords to the vault client script")
+
+	t.Setenv(configuration.AnsibleVaultIdentityList, "")
+
+	passwords := map[string]string{}
+	executor := execute.NewDefaultExecute()
+	executor.AddEnvVar(configuration.AnsibleVaultIdentityList, "test@prompt")
+
+	runner := &runFuncExecutor{
+		DefaultExecute: executor,
+		run: func() error {
+			vaultIDs := strings.Split(executor.EnvVars[configuration.AnsibleVaultIdentityList], ",")
+			assert.Len(t, vaultIDs, 3)
+			assert.Equal(t, "test@prompt", vaultIDs[0])
+
+			for _, vaultID := range vaultIDs[1:] {
+				label, script, _ := strings.Cut(vaultID, "@")
+				assert.Equal(t, VaultClientScriptName, filepath.Base(script))
+
+				// ansible calls the vault client scripts with the --vault-id argument
+				output, err := exec.Command(script, "--vault-id", label).Output()
+				if err != nil {
+					return err
+				}
+				passwords[label] = string(output)
+			}
+
+			return nil
+		},
+	}
+
+	err = NewAnsibleWithVaultPasswordClientExecute(runner,
+		WithPasswordReader("dev", text.NewReadPasswordFromText(text.WithText("dev-password"))),
+		WithPasswordReader("prod", text.NewReadPasswordFromText(text.WithText("prod-password"))),
+	).Execute(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"dev": "dev-password", "prod": "prod-password"}, passwords)
+}
+
+// runFuncExecutor is a DefaultExecute whose execution runs a function instead of the command
+type runFuncExecutor struct {
+	*execute.DefaultExecute
+	run func() error
+}
+
+// Execute runs the function
+func (e *runFuncExecutor) Execute(ctx context.Context) error {
+	return e.run()
+}
+
+func TestAnsibleWithVaultPasswordClientExecuteWithoutExecutor(t *testing.T) {
+	t.Log("Testing error executing without an executor")
+
+	err := NewAnsibleWithVaultPasswordClientExecute(nil).Execute(context.TODO())
+	assert.EqualError(t, err, "AnsibleWithVaultPasswordClientExecute executor requires an executor")
+}
+
+func TestVaultIdentityList(t *testing.T) {
+	t.Log("Testing the vault identity list format")
+
+	assert.Equal(t, "dev@/tmp/client,prod@/tmp/client", VaultIdentityList("dev@/tmp/client", "prod@/tmp/client"))
+}
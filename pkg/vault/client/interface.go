@@ -0,0 +1,6 @@
+package client
+
+// PasswordReader is the interface to read the vault passwords
+type PasswordReader interface {
+	Read() (string, error)
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"io"
+
+	"github.com/apenella/go-ansible/v2/pkg/internal/socketscript"
+)
+
+// DefaultInterpreter is the interpreter of the vault client script. Python is always available where ansible runs
+const DefaultInterpreter = socketscript.DefaultInterpreter
+
+// vaultClientScriptTemplate is the vault password client script called by ansible with the --vault-id argument. It asks the password of the vault id label to the VaultPasswordClient through its Unix socket and writes it to stdout
+var vaultClientScriptTemplate = socketscript.NewScriptTemplate("vault-client", "Vault password client", `def main():
+    label = {{ printf "%q" .DefaultVaultID }}
+    args = sys.argv[1:]
+    for i, arg in enumerate(args):
+        if arg == "--vault-id" and i + 1 < len(args):
+            label = args[i + 1]
+        elif arg.startswith("--vault-id="):
+            label = arg[len("--vault-id="):]
+
+    status, content = request(label)
+    if status == "{{ .StatusOK }}":
+        sys.stdout.write(content)
+        return 0
+
+    sys.stderr.write(content + "\n")
+    if status == "{{ .StatusNotFound }}":
+        return 2
+    return 1`)
+
+// vaultClientScript are the values of the vault client script template
+type vaultClientScript struct {
+	DefaultVaultID string
+	Interpreter    string
+	Socket         string
+	StatusNotFound string
+	StatusOK       string
+	Token          string
+}
+
+// Write writes the vault client script
+func (s *vaultClientScript) Write(w io.Writer) error {
+	return vaultClientScriptTemplate.Execute(w, s)
+}
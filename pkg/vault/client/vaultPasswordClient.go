@@ -0,0 +1,202 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/apenella/go-ansible/v2/pkg/internal/socketscript"
+	"github.com/apenella/go-ansible/v2/pkg/vault/encrypt"
+	"github.com/pkg/errors"
+)
+
+const (
+	// VaultClientScriptName is the name of the vault client script. Ansible calls the scripts whose name ends with -client using the --vault-id argument
+	VaultClientScriptName = "go-ansible-vault-client"
+
+	// socketName is the name of the Unix socket where the passwords are served
+	socketName = "vault.sock"
+	// statusNotFound is the response status when there is no password for the vault id label
+	statusNotFound = "NOTFOUND"
+)
+
+// OptionsFunc is a function used to configure VaultPasswordClient
+type OptionsFunc func(*VaultPasswordClient)
+
+// VaultPasswordClient serves the passwords read from PasswordReader to ansible without writing them to disk. It creates a private directory with a Unix socket and a vault client script, which ansible calls through the --vault-id label@script argument. The script asks the password to the VaultPasswordClient through the socket
+type VaultPasswordClient struct {
+	dir         string
+	interpreter string
+	labels      []string
+	mutex       sync.Mutex
+	readers     map[string]PasswordReader
+	server      *socketscript.Server
+}
+
+// NewVaultPasswordClient returns a VaultPasswordClient. It must be started before ansible runs and closed afterwards
+func NewVaultPasswordClient(options ...OptionsFunc) *VaultPasswordClient {
+	client := &VaultPasswordClient{
+		interpreter: DefaultInterpreter,
+		readers:     map[string]PasswordReader{},
+	}
+	client.Options(options...)
+
+	return client
+}
+
+// WithPasswordReader sets the reader of the password of the vault id label. The label defaults to encrypt.DefaultVaultID when it is empty
+func WithPasswordReader(label string, reader PasswordReader) OptionsFunc {
+	return func(c *VaultPasswordClient) {
+		if label == "" {
+			label = encrypt.DefaultVaultID
+		}
+
+		if _, exists := c.readers[label]; !exists {
+			c.labels = append(c.labels, label)
+		}
+		c.readers[label] = reader
+	}
+}
+
+// WithDir sets the directory where the private directory of the socket and the script is created. The default temporary directory is used by default
+func WithDir(dir string) OptionsFunc {
+	return func(c *VaultPasswordClient) {
+		c.dir = dir
+	}
+}
+
+// WithInterpreter sets the interpreter of the vault client script. It must run Python scripts
+func WithInterpreter(interpreter string) OptionsFunc {
+	return func(c *VaultPasswordClient) {
+		c.interpreter = interpreter
+	}
+}
+
+// Options configure the VaultPasswordClient
+func (c *VaultPasswordClient) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// Start creates the private directory, the vault client script and starts serving the passwords on the Unix socket
+func (c *VaultPasswordClient) Start() error {
+	if c == nil {
+		return errors.New("VaultPasswordClient must be initialized before starting it")
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.server != nil {
+		return errors.New("VaultPasswordClient is already started")
+	}
+
+	if len(c.readers) == 0 {
+		return errors.New("VaultPasswordClient requires at least one password reader")
+	}
+
+	for _, label := range c.labels {
+		err := validateLabel(label)
+		if err != nil {
+			return errors.Wrap(err, "Invalid vault id")
+		}
+	}
+
+	server, err := socketscript.Start(socketscript.Config{
+		Name:        "vault client",
+		Dir:         c.dir,
+		Pattern:     "go-ansible-vault-*",
+		ScriptName:  VaultClientScriptName,
+		SocketName:  socketName,
+		Handler:     c.handle,
+		WriteScript: c.writeScript,
+	})
+	if err != nil {
+		return errors.Wrap(err, "Error starting the vault password client")
+	}
+	c.server = server
+
+	return nil
+}
+
+// Close stops serving the passwords and removes the private directory. It is safe to call it more than once
+func (c *VaultPasswordClient) Close() error {
+	if c == nil {
+		return nil
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	err := c.server.Close()
+	c.server = nil
+	if err != nil {
+		return errors.Wrap(err, "Error closing the vault password client")
+	}
+
+	return nil
+}
+
+// ScriptPath returns the path of the vault client script. It is empty until the VaultPasswordClient is started
+func (c *VaultPasswordClient) ScriptPath() string {
+	if c == nil || c.server == nil {
+		return ""
+	}
+
+	return c.server.ScriptPath()
+}
+
+// VaultIDs returns a label@script vault id for each password reader, as expected by the --vault-id argument and the ANSIBLE_VAULT_IDENTITY_LIST setting
+func (c *VaultPasswordClient) VaultIDs() []string {
+	script := c.ScriptPath()
+	if script == "" {
+		return nil
+	}
+
+	vaultIDs := make([]string, 0, len(c.labels))
+	for _, label := range c.labels {
+		vaultIDs = append(vaultIDs, fmt.Sprintf("%s@%s", label, script))
+	}
+
+	return vaultIDs
+}
+
+// writeScript writes the vault client script
+func (c *VaultPasswordClient) writeScript(w io.Writer, socket, token string) error {
+	script := &vaultClientScript{
+		DefaultVaultID: encrypt.DefaultVaultID,
+		Interpreter:    c.interpreter,
+		Socket:         socket,
+		StatusNotFound: statusNotFound,
+		StatusOK:       socketscript.StatusOK,
+		Token:          token,
+	}
+
+	return script.Write(w)
+}
+
+// handle answers a request with the password of the requested vault id label, which is the request itself
+func (c *VaultPasswordClient) handle(label string) (string, string) {
+	reader, exists := c.readers[label]
+	if !exists {
+		return statusNotFound, fmt.Sprintf("no password defined for the vault id '%s'", label)
+	}
+
+	password, err := reader.Read()
+	if err != nil {
+		return socketscript.StatusError, fmt.Sprintf("error reading the password of the vault id '%s': %s", label, err.Error())
+	}
+
+	return socketscript.StatusOK, password
+}
+
+// validateLabel checks that the label can be used on a label@script vault id
+func validateLabel(label string) error {
+	if strings.ContainsAny(label, "@, \t\r\n") {
+		return fmt.Errorf("vault id label '%s' must not contain '@', ',' or whitespaces", label)
+	}
+
+	return nil
+}
@@ -0,0 +1,251 @@
+package client
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/mock"
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
+	"github.com/stretchr/testify/assert"
+)
+
+// request sends a request to the vault password client socket and returns the response
+func request(t *testing.T, c *VaultPasswordClient, token, label string) string {
+	conn, err := net.Dial("unix", c.server.SocketPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	_, err = fmt.Fprintf(conn, "%s %s\n", token, label)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := io.ReadAll(bufio.NewReader(conn))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(response)
+}
+
+func TestVaultPasswordClientStart(t *testing.T) {
+	tests := []struct {
+		desc    string
+		client  *VaultPasswordClient
+		vaultID []string
+		err     string
+	}{
+		{
+			desc: "Testing start a vault password client",
+			client: NewVaultPasswordClient(
+				WithPasswordReader("dev", text.NewReadPasswordFromText(text.WithText("dev-password"))),
+				WithPasswordReader("", text.NewReadPasswordFromText(text.WithText("default-password"))),
+			),
+			vaultID: []string{"dev", "default"},
+		},
+		{
+			desc:   "Testing error starting a vault password client without password readers",
+			client: NewVaultPasswordClient(),
+			err:    "VaultPasswordClient requires at least one password reader",
+		},
+		{
+			desc: "Testing error starting a vault password client with an invalid vault id",
+			client: NewVaultPasswordClient(
+				WithPasswordReader("dev@prod", text.NewReadPasswordFromText(text.WithText("dev-password"))),
+			),
+			err: "Invalid vault id: vault id label 'dev@prod' must not contain '@', ',' or whitespaces",
+		},
+		{
+			desc: "Testing error starting a vault password client on a directory that does not exist",
+			client: NewVaultPasswordClient(
+				WithDir(filepath.Join(t.TempDir(), "unknown")),
+				WithPasswordReader("dev", text.NewReadPasswordFromText(text.WithText("dev-password"))),
+			),
+			err: "Error creating the vault client directory",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.client.Start()
+			defer test.client.Close()
+			if err != nil {
+				assert.Contains(t, err.Error(), test.err)
+				assert.Empty(t, test.client.ScriptPath())
+				return
+			}
+
+			assert.Empty(t, test.err)
+
+			script := test.client.ScriptPath()
+			vaultIDs := []string{}
+			for _, label := range test.vaultID {
+				vaultIDs = append(vaultIDs, label+"@"+script)
+			}
+			assert.Equal(t, vaultIDs, test.client.VaultIDs())
+			assert.Equal(t, VaultClientScriptName, filepath.Base(script))
+
+			info, err := os.Stat(script)
+			assert.NoError(t, err)
+			assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+			info, err = os.Stat(filepath.Dir(script))
+			assert.NoError(t, err)
+			assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+			err = test.client.Start()
+			assert.EqualError(t, err, "VaultPasswordClient is already started")
+		})
+	}
+}
+
+func TestVaultPasswordClientClose(t *testing.T) {
+	t.Log("Testing close a vault password client removes its directory")
+
+	client := NewVaultPasswordClient(
+		WithDir(t.TempDir()),
+		WithPasswordReader("dev", text.NewReadPasswordFromText(text.WithText("dev-password"))),
+	)
+
+	err := client.Start()
+	assert.NoError(t, err)
+	dir := filepath.Dir(client.ScriptPath())
+
+	assert.NoError(t, client.Close())
+	assert.NoError(t, client.Close())
+
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err))
+	assert.Empty(t, client.ScriptPath())
+	assert.Nil(t, client.VaultIDs())
+}
+
+func TestVaultPasswordClientHandle(t *testing.T) {
+	failingReader := mock.NewMockReadPassword()
+	failingReader.On("Read").Return("", fmt.Errorf("reader error"))
+
+	client := NewVaultPasswordClient(
+		WithPasswordReader("dev", text.NewReadPasswordFromText(text.WithText("dev-password"))),
+		WithPasswordReader("prod", failingReader),
+	)
+	err := client.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	tests := []struct {
+		desc  string
+		token string
+		label string
+		res   string
+	}{
+		{
+			desc:  "Testing request the password of a vault id",
+			token: client.server.Token(),
+			label: "dev",
+			res:   "OK\ndev-password",
+		},
+		{
+			desc:  "Testing request the password of an unknown vault id",
+			token: client.server.Token(),
+			label: "test",
+			res:   "NOTFOUND\nno password defined for the vault id 'test'",
+		},
+		{
+			desc:  "Testing request a password that can not be read",
+			token: client.server.Token(),
+			label: "prod",
+			res:   "ERROR\nerror reading the password of the vault id 'prod': reader error",
+		},
+		{
+			desc:  "Testing request a password with an invalid token",
+			token: "invalid",
+			label: "dev",
+			res:   "ERROR\ninvalid vault client token",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res := request(t, client, test.token, test.label)
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestVaultPasswordClientScript(t *testing.T) {
+	_, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 is required to run the vault client script")
+	}
+
+	client := NewVaultPasswordClient(
+		WithPasswordReader("dev", text.NewReadPasswordFromText(text.WithText("dev-password"))),
+		WithPasswordReader("default", text.NewReadPasswordFromText(text.WithText("default-password"))),
+	)
+	err = client.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	tests := []struct {
+		desc     string
+		args     []string
+		res      string
+		exitCode int
+	}{
+		{
+			desc: "Testing the vault client script returns the password of the vault id",
+			args: []string{"--vault-id", "dev"},
+			res:  "dev-password",
+		},
+		{
+			desc: "Testing the vault client script returns the password of the default vault id",
+			args: []string{},
+			res:  "default-password",
+		},
+		{
+			desc:     "Testing the vault client script fails with an unknown vault id",
+			args:     []string{"--vault-id=test"},
+			exitCode: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			stdout := &strings.Builder{}
+			cmd := exec.Command(client.ScriptPath(), test.args...)
+			cmd.Stdout = stdout
+
+			err := cmd.Run()
+			if test.exitCode != 0 {
+				exitErr, isExitErr := err.(*exec.ExitError)
+				assert.True(t, isExitErr)
+				if isExitErr {
+					assert.Equal(t, test.exitCode, exitErr.ExitCode())
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.res, stdout.String())
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package decrypt
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault"
+	"github.com/apenella/go-ansible/v2/pkg/vault/encrypt"
+	"github.com/pkg/errors"
+	ansiblevault "github.com/sosedoff/ansible-vault-go"
+)
+
+// vaultHeader11 is the header understood by the vault library, which only supports the 1.1 format
+var vaultHeader11 = encrypt.NewVaultHeader("").String()
+
+// OptionsFunc is a function used to configure DecryptString
+type OptionsFunc func(*DecryptString)
+
+// DecryptString decrypts vault encrypted values, using the 1.1 and 1.2 vault formats. The password is resolved by the vault id label of the value
+type DecryptString struct {
+	reader        PasswordReader
+	vaultIDMatch  bool
+	vaultIDReader map[string]PasswordReader
+}
+
+// NewDecryptString returns a DecryptString
+func NewDecryptString(options ...OptionsFunc) *DecryptString {
+	decryptString := &DecryptString{
+		vaultIDReader: map[string]PasswordReader{},
+	}
+	decryptString.Options(options...)
+
+	return decryptString
+}
+
+// WithReader sets the reader of the password used to decrypt the values, regardless of their vault id label
+func WithReader(reader PasswordReader) OptionsFunc {
+	return func(d *DecryptString) {
+		d.reader = reader
+	}
+}
+
+// WithVaultIDReader sets the reader of the password of the vault id label
+func WithVaultIDReader(vaultID string, reader PasswordReader) OptionsFunc {
+	return func(d *DecryptString) {
+		d.vaultIDReader[vaultID] = reader
+	}
+}
+
+// WithVaultIDMatch only uses the password of the value vault id label, like the ansible vault_id_match setting. Otherwise, when that password can not decrypt the value, the rest of passwords are tried
+func WithVaultIDMatch() OptionsFunc {
+	return func(d *DecryptString) {
+		d.vaultIDMatch = true
+	}
+}
+
+// Options configure the DecryptString
+func (d *DecryptString) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(d)
+	}
+}
+
+// Decrypt decrypts the vault encrypted value. The password of the value vault id label is tried first. It returns an error that matches vault.ErrWrongPassword when none of the passwords decrypts the value
+func (d *DecryptString) Decrypt(cipherText string) (string, error) {
+	if d == nil {
+		return "", errors.New("DecryptString must be initialized before decrypting a value")
+	}
+
+	header, body, err := parseVaultText(cipherText)
+	if err != nil {
+		return "", errors.Wrap(err, "Error parsing the vault encrypted value")
+	}
+
+	readers := d.readers(header.Label())
+	if len(readers) == 0 {
+		return "", errors.Wrap(vault.ErrNoVaultSecrets, fmt.Sprintf("No password defined for the vault id '%s'", header.Label()))
+	}
+
+	readErrs := []string{}
+	for _, reader := range readers {
+		password, err := reader.Read()
+		if err != nil {
+			readErrs = append(readErrs, err.Error())
+			continue
+		}
+
+		plainText, err := ansiblevault.Decrypt(vaultHeader11+"\n"+body, password)
+		if err == nil {
+			return plainText, nil
+		}
+		if err == ansiblevault.ErrInvalidFormat {
+			return "", errors.Wrap(err, "Error decrypting the vault encrypted value")
+		}
+	}
+
+	message := fmt.Sprintf("Error decrypting the value encrypted with the vault id '%s'", header.Label())
+	if len(readErrs) > 0 {
+		message = fmt.Sprintf("%s. Some passwords could not be read: %s", message, strings.Join(readErrs, ", "))
+	}
+
+	return "", errors.Wrap(vault.ErrWrongPassword, message)
+}
+
+// readers returns the password readers to try for the vault id label. The label reader goes first, followed by the rest of labels readers sorted by label and the default reader
+func (d *DecryptString) readers(vaultID string) []PasswordReader {
+	readers := []PasswordReader{}
+
+	reader, exists := d.vaultIDReader[vaultID]
+	if exists {
+		readers = append(readers, reader)
+	}
+
+	if d.vaultIDMatch {
+		if !exists && d.reader != nil {
+			readers = append(readers, d.reader)
+		}
+		return readers
+	}
+
+	labels := make([]string, 0, len(d.vaultIDReader))
+	for label := range d.vaultIDReader {
+		if label != vaultID {
+			labels = append(labels, label)
+		}
+	}
+	sort.Strings(labels)
+
+	for _, label := range labels {
+		readers = append(readers, d.vaultIDReader[label])
+	}
+
+	if d.reader != nil {
+		readers = append(readers, d.reader)
+	}
+
+	return readers
+}
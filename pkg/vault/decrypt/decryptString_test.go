@@ -0,0 +1,126 @@
+package decrypt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault"
+	"github.com/apenella/go-ansible/v2/pkg/vault/encrypt"
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
+	"github.com/stretchr/testify/assert"
+)
+
+func encryptValue(t *testing.T, plainText, password, vaultID string) string {
+	cipherText, err := encrypt.NewEncryptString(
+		encrypt.WithReader(text.NewReadPasswordFromText(text.WithText(password))),
+		encrypt.WithVaultID(vaultID),
+	).Encrypt(plainText)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cipherText
+}
+
+func TestDecrypt(t *testing.T) {
+	unlabeled := encryptValue(t, "unlabeled secret", "default-password", "")
+	dev := encryptValue(t, "dev secret", "dev-password", "dev")
+	prod := encryptValue(t, "prod secret", "prod-password", "prod")
+
+	tests := []struct {
+		desc       string
+		decrypt    *DecryptString
+		cipherText string
+		res        string
+		errIs      error
+		err        string
+	}{
+		{
+			desc:       "Testing decrypt a 1.1 value",
+			decrypt:    NewDecryptString(WithReader(text.NewReadPasswordFromText(text.WithText("default-password")))),
+			cipherText: unlabeled,
+			res:        "unlabeled secret",
+		},
+		{
+			desc: "Testing decrypt a 1.2 value using the password of its vault id",
+			decrypt: NewDecryptString(
+				WithVaultIDReader("dev", text.NewReadPasswordFromText(text.WithText("dev-password"))),
+				WithVaultIDReader("prod", text.NewReadPasswordFromText(text.WithText("prod-password"))),
+			),
+			cipherText: prod,
+			res:        "prod secret",
+		},
+		{
+			desc: "Testing decrypt a 1.2 value using the password of another vault id",
+			decrypt: NewDecryptString(
+				WithVaultIDReader("other", text.NewReadPasswordFromText(text.WithText("dev-password"))),
+			),
+			cipherText: dev,
+			res:        "dev secret",
+		},
+		{
+			desc:       "Testing decrypt an indented value tagged as YAML vault",
+			decrypt:    NewDecryptString(WithReader(text.NewReadPasswordFromText(text.WithText("dev-password")))),
+			cipherText: "!vault |\n  " + strings.ReplaceAll(dev, "\n", "\n  "),
+			res:        "dev secret",
+		},
+		{
+			desc: "Testing decrypt skipping the passwords that can not be read",
+			decrypt: NewDecryptString(
+				WithVaultIDReader("dev", text.NewReadPasswordFromText()),
+				WithReader(text.NewReadPasswordFromText(text.WithText("dev-password"))),
+			),
+			cipherText: dev,
+			res:        "dev secret",
+		},
+		{
+			desc: "Testing error decrypting a value with a wrong password",
+			decrypt: NewDecryptString(
+				WithVaultIDReader("dev", text.NewReadPasswordFromText(text.WithText("wrong"))),
+			),
+			cipherText: prod,
+			errIs:      vault.ErrWrongPassword,
+			err:        "Error decrypting the value encrypted with the vault id 'prod': " + vault.ErrWrongPassword.Error(),
+		},
+		{
+			desc: "Testing error decrypting a value when the vault id must match",
+			decrypt: NewDecryptString(
+				WithVaultIDReader("other", text.NewReadPasswordFromText(text.WithText("dev-password"))),
+				WithVaultIDMatch(),
+			),
+			cipherText: dev,
+			errIs:      vault.ErrNoVaultSecrets,
+			err:        "No password defined for the vault id 'dev': " + vault.ErrNoVaultSecrets.Error(),
+		},
+		{
+			desc:       "Testing error decrypting a value that is not vault encrypted",
+			decrypt:    NewDecryptString(WithReader(text.NewReadPasswordFromText(text.WithText("dev-password")))),
+			cipherText: "plain text",
+			err:        "Error parsing the vault encrypted value: vault encrypted value must define a header and a body",
+		},
+		{
+			desc:       "Testing error decrypting when the DecryptString is not initialized",
+			decrypt:    nil,
+			cipherText: dev,
+			err:        "DecryptString must be initialized before decrypting a value",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := test.decrypt.Decrypt(test.cipherText)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				if test.errIs != nil {
+					assert.True(t, errors.Is(err, test.errIs))
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+package decrypt
+
+// PasswordReader defines the implementation of a password reader
+type PasswordReader interface {
+	Read() (string, error)
+}
@@ -0,0 +1,84 @@
+package decrypt
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault/encrypt"
+)
+
+const (
+	// yamlVaultTag is the YAML tag of the vault encrypted values
+	yamlVaultTag = "!vault"
+	// headerTerminators are the characters that end a vault header found on a payload
+	headerTerminators = " \t\r\"'\\,}"
+)
+
+// VaultID returns the vault id label of the vault encrypted value. It is the default label when the value does not define one
+func VaultID(cipherText string) (string, error) {
+	header, _, err := parseVaultText(cipherText)
+	if err != nil {
+		return "", err
+	}
+
+	return header.Label(), nil
+}
+
+// VaultIDs returns the sorted vault id labels required to decrypt the vault encrypted values found on the payload, such as a vault encrypted file or a YAML document with vault encrypted variables
+func VaultIDs(payload string) []string {
+	labels := map[string]struct{}{}
+
+	for _, line := range strings.Split(payload, "\n") {
+		_, value, found := strings.Cut(line, encrypt.VaultHeaderPrefix)
+		if !found {
+			continue
+		}
+
+		// the header ends where the line, the quoted string or the escaped line ends
+		end := strings.IndexAny(value, headerTerminators)
+		if end >= 0 {
+			value = value[:end]
+		}
+
+		header, err := encrypt.ParseVaultHeader(encrypt.VaultHeaderPrefix + value)
+		if err != nil {
+			continue
+		}
+		labels[header.Label()] = struct{}{}
+	}
+
+	vaultIDs := make([]string, 0, len(labels))
+	for label := range labels {
+		vaultIDs = append(vaultIDs, label)
+	}
+	sort.Strings(vaultIDs)
+
+	return vaultIDs
+}
+
+// parseVaultText returns the header and the body of a vault encrypted value. The lines indentation and the YAML vault tag are ignored
+func parseVaultText(cipherText string) (*encrypt.VaultHeader, string, error) {
+	lines := []string{}
+	for _, line := range strings.Split(cipherText, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) > 0 && strings.HasPrefix(lines[0], yamlVaultTag) {
+		lines = lines[1:]
+	}
+
+	if len(lines) < 2 {
+		return nil, "", fmt.Errorf("vault encrypted value must define a header and a body")
+	}
+
+	header, err := encrypt.ParseVaultHeader(lines[0])
+	if err != nil {
+		return nil, "", err
+	}
+
+	return header, strings.Join(lines[1:], "\n"), nil
+}
@@ -0,0 +1,62 @@
+package decrypt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVaultID(t *testing.T) {
+	tests := []struct {
+		desc       string
+		cipherText string
+		res        string
+		err        bool
+	}{
+		{
+			desc:       "Testing vault id of a 1.1 value",
+			cipherText: "$ANSIBLE_VAULT;1.1;AES256\n3836",
+			res:        "default",
+		},
+		{
+			desc:       "Testing vault id of a 1.2 value",
+			cipherText: "!vault |\n  $ANSIBLE_VAULT;1.2;AES256;prod\n  3836",
+			res:        "prod",
+		},
+		{
+			desc:       "Testing error getting the vault id of a plain value",
+			cipherText: "plain",
+			err:        true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := VaultID(test.cipherText)
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestVaultIDs(t *testing.T) {
+	payload := `---
+db_password: !vault |
+  $ANSIBLE_VAULT;1.2;AES256;prod
+  3836
+api_token: !vault |
+  $ANSIBLE_VAULT;1.1;AES256
+  3836
+json: {"__ansible_vault": "$ANSIBLE_VAULT;1.2;AES256;dev\n3836"}
+other: '$ANSIBLE_VAULT;1.2;AES256;prod'
+invalid: $ANSIBLE_VAULT;2.0;AES256
+`
+
+	assert.Equal(t, []string{"default", "dev", "prod"}, VaultIDs(payload))
+}
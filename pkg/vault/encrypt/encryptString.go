@@ -1,6 +1,8 @@
 package encrypt
 
 import (
+	"strings"
+
 	"github.com/pkg/errors"
 	vault "github.com/sosedoff/ansible-vault-go"
 )
@@ -9,7 +11,8 @@ import (
 type OptionsFunc func(*EncryptString)
 
 type EncryptString struct {
-	reader PasswordReader
+	reader  PasswordReader
+	vaultID string
 }
 
 func NewEncryptString(options ...OptionsFunc) *EncryptString {
@@ -25,6 +28,13 @@ func WithReader(reader PasswordReader) OptionsFunc {
 	}
 }
 
+// WithVaultID sets the vault id label of the encrypted data. The data is encrypted using the 1.2 vault format, which includes the label on its header
+func WithVaultID(vaultID string) OptionsFunc {
+	return func(c *EncryptString) {
+		c.vaultID = vaultID
+	}
+}
+
 // Options configure the ReadSecretFromEnvVar
 func (c *EncryptString) Options(opts ...OptionsFunc) {
 	for _, opt := range opts {
@@ -36,6 +46,11 @@ func (c *EncryptString) Encrypt(plainText string) (string, error) {
 	var pass, encryptedText string
 	var err error
 
+	err = validateVaultID(c.vaultID)
+	if err != nil {
+		return "", errors.Wrap(err, "Invalid vault id")
+	}
+
 	pass, err = c.reader.Read()
 	if err != nil {
 		return "", errors.Wrap(err, "Error reading the password")
@@ -46,5 +61,9 @@ func (c *EncryptString) Encrypt(plainText string) (string, error) {
 		return "", errors.Wrap(err, "Error encrypting the password")
 	}
 
+	// the encrypted data does not depend on the header, so the header generated for the 1.1 format is replaced by the one that includes the vault id label
+	_, body, _ := strings.Cut(encryptedText, "\n")
+	encryptedText = NewVaultHeader(c.vaultID).String() + "\n" + body
+
 	return encryptedText, nil
 }
@@ -1,6 +1,7 @@
 package encrypt
 
 import (
+	"fmt"
 	"regexp"
 	"testing"
 
@@ -30,6 +31,33 @@ func TestEncrypt(t *testing.T) {
 			expectedRegexp: "\\$ANSIBLE_VAULT;1.1;AES256",
 			expectedLen:    418,
 		},
+		{
+			desc: "Testing encrypting a message with a vault id",
+			text: "ThatIsASecretMessage",
+			encrypt: NewEncryptString(
+				WithReader(
+					text.NewReadPasswordFromText(
+						text.WithText("secret"),
+					),
+				),
+				WithVaultID("prod"),
+			),
+			expectedRegexp: "^\\$ANSIBLE_VAULT;1.2;AES256;prod\n",
+			expectedLen:    423,
+		},
+		{
+			desc: "Testing error encrypting a message with an invalid vault id",
+			text: "ThatIsASecretMessage",
+			encrypt: NewEncryptString(
+				WithReader(
+					text.NewReadPasswordFromText(
+						text.WithText("secret"),
+					),
+				),
+				WithVaultID("prod;1"),
+			),
+			err: fmt.Errorf("Invalid vault id: vault id 'prod;1' must not contain separators or white spaces"),
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,114 @@
+package encrypt
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// VaultHeaderPrefix is the prefix of the vault encrypted data header
+	VaultHeaderPrefix = "$ANSIBLE_VAULT"
+	// VaultFormatVersion11 is the vault format version of the data encrypted without a vault id label
+	VaultFormatVersion11 = "1.1"
+	// VaultFormatVersion12 is the vault format version of the data encrypted with a vault id label
+	VaultFormatVersion12 = "1.2"
+	// VaultCipherAES256 is the cipher used to encrypt the vault data
+	VaultCipherAES256 = "AES256"
+	// DefaultVaultID is the vault id label of the data that does not define one
+	DefaultVaultID = "default"
+
+	// vaultHeaderSeparator is the separator of the header fields
+	vaultHeaderSeparator = ";"
+)
+
+// VaultHeader is the header of a vault encrypted data, such as $ANSIBLE_VAULT;1.2;AES256;prod
+type VaultHeader struct {
+	// Version is the vault format version
+	Version string
+	// Cipher is the cipher used to encrypt the data
+	Cipher string
+	// VaultID is the vault id label. It is only defined by the 1.2 format
+	VaultID string
+}
+
+// NewVaultHeader returns the header of the data encrypted using the vault id label. The 1.1 format is used when the label is empty or it is the default one
+func NewVaultHeader(vaultID string) *VaultHeader {
+	if vaultID == "" || vaultID == DefaultVaultID {
+		return &VaultHeader{
+			Version: VaultFormatVersion11,
+			Cipher:  VaultCipherAES256,
+		}
+	}
+
+	return &VaultHeader{
+		Version: VaultFormatVersion12,
+		Cipher:  VaultCipherAES256,
+		VaultID: vaultID,
+	}
+}
+
+// ParseVaultHeader parses the header line of a vault encrypted data
+func ParseVaultHeader(line string) (*VaultHeader, error) {
+	fields := strings.Split(strings.TrimSpace(line), vaultHeaderSeparator)
+
+	if fields[0] != VaultHeaderPrefix {
+		return nil, fmt.Errorf("'%s' is not a vault header", line)
+	}
+
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("vault header '%s' must define the format version and the cipher", line)
+	}
+
+	header := &VaultHeader{
+		Version: fields[1],
+		Cipher:  fields[2],
+	}
+
+	switch header.Version {
+	case VaultFormatVersion11:
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("vault header '%s' with format version '%s' does not accept a vault id", line, header.Version)
+		}
+	case VaultFormatVersion12:
+		if len(fields) != 4 || fields[3] == "" {
+			return nil, fmt.Errorf("vault header '%s' with format version '%s' requires a vault id", line, header.Version)
+		}
+		header.VaultID = fields[3]
+	default:
+		return nil, fmt.Errorf("vault format version '%s' is not supported", header.Version)
+	}
+
+	if header.Cipher != VaultCipherAES256 {
+		return nil, fmt.Errorf("vault cipher '%s' is not supported", header.Cipher)
+	}
+
+	return header, nil
+}
+
+// Label returns the vault id label, which is the default one when the header does not define it
+func (h *VaultHeader) Label() string {
+	if h.VaultID == "" {
+		return DefaultVaultID
+	}
+
+	return h.VaultID
+}
+
+// String returns the header line
+func (h *VaultHeader) String() string {
+	fields := []string{VaultHeaderPrefix, h.Version, h.Cipher}
+	if h.VaultID != "" {
+		fields = append(fields, h.VaultID)
+	}
+
+	return strings.Join(fields, vaultHeaderSeparator)
+}
+
+// validateVaultID returns an error when the vault id label can not be part of a vault header
+func validateVaultID(vaultID string) error {
+	if strings.ContainsAny(vaultID, vaultHeaderSeparator+" \t\r\n") {
+		return fmt.Errorf("vault id '%s' must not contain separators or white spaces", vaultID)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package encrypt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVaultHeader(t *testing.T) {
+	tests := []struct {
+		desc    string
+		vaultID string
+		res     string
+	}{
+		{"Testing header without vault id", "", "$ANSIBLE_VAULT;1.1;AES256"},
+		{"Testing header with the default vault id", DefaultVaultID, "$ANSIBLE_VAULT;1.1;AES256"},
+		{"Testing header with a vault id", "prod", "$ANSIBLE_VAULT;1.2;AES256;prod"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, NewVaultHeader(test.vaultID).String())
+		})
+	}
+}
+
+func TestParseVaultHeader(t *testing.T) {
+	tests := []struct {
+		desc  string
+		line  string
+		res   *VaultHeader
+		label string
+		err   error
+	}{
+		{
+			desc:  "Testing parse a 1.1 header",
+			line:  "$ANSIBLE_VAULT;1.1;AES256",
+			res:   &VaultHeader{Version: "1.1", Cipher: "AES256"},
+			label: DefaultVaultID,
+		},
+		{
+			desc:  "Testing parse a 1.2 header",
+			line:  "  $ANSIBLE_VAULT;1.2;AES256;prod",
+			res:   &VaultHeader{Version: "1.2", Cipher: "AES256", VaultID: "prod"},
+			label: "prod",
+		},
+		{
+			desc: "Testing error parsing a line that is not a header",
+			line: "3836326462",
+			err:  fmt.Errorf("'3836326462' is not a vault header"),
+		},
+		{
+			desc: "Testing error parsing a header without cipher",
+			line: "$ANSIBLE_VAULT;1.1",
+			err:  fmt.Errorf("vault header '$ANSIBLE_VAULT;1.1' must define the format version and the cipher"),
+		},
+		{
+			desc: "Testing error parsing a 1.1 header with vault id",
+			line: "$ANSIBLE_VAULT;1.1;AES256;prod",
+			err:  fmt.Errorf("vault header '$ANSIBLE_VAULT;1.1;AES256;prod' with format version '1.1' does not accept a vault id"),
+		},
+		{
+			desc: "Testing error parsing a 1.2 header without vault id",
+			line: "$ANSIBLE_VAULT;1.2;AES256",
+			err:  fmt.Errorf("vault header '$ANSIBLE_VAULT;1.2;AES256' with format version '1.2' requires a vault id"),
+		},
+		{
+			desc: "Testing error parsing an unsupported version",
+			line: "$ANSIBLE_VAULT;1.0;AES",
+			err:  fmt.Errorf("vault format version '1.0' is not supported"),
+		},
+		{
+			desc: "Testing error parsing an unsupported cipher",
+			line: "$ANSIBLE_VAULT;1.1;AES",
+			err:  fmt.Errorf("vault cipher 'AES' is not supported"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := ParseVaultHeader(test.line)
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.res, res)
+				assert.Equal(t, test.label, res.Label())
+			}
+		})
+	}
+}
@@ -3,3 +3,18 @@ package vault
 type Encrypter interface {
 	Encrypt(plainText string) (string, error)
 }
+
+// PasswordReader defines the implementation of a password reader
+type PasswordReader interface {
+	Read() (string, error)
+}
+
+// ExitCodeErrorer is an error that provides the exit code of the command that failed
+type ExitCodeErrorer interface {
+	ExitCode() int
+}
+
+// Decrypter decrypts a vault encrypted value
+type Decrypter interface {
+	Decrypt(cipherText string) (string, error)
+}
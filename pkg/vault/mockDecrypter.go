@@ -0,0 +1,19 @@
+package vault
+
+import "github.com/stretchr/testify/mock"
+
+// MockDecrypter is a mock for Decrypter
+type MockDecrypter struct {
+	mock.Mock
+}
+
+// NewMockDecrypter returns a new MockDecrypter
+func NewMockDecrypter() *MockDecrypter {
+	return &MockDecrypter{}
+}
+
+// Decrypt is a mock
+func (d *MockDecrypter) Decrypt(cipherText string) (string, error) {
+	args := d.Called(cipherText)
+	return args.String(0), args.Error(1)
+}
@@ -0,0 +1,152 @@
+package executable
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTimeout is the maximum time the executable is allowed to run
+	DefaultTimeout = 60 * time.Second
+	// VaultIDFlag is the argument that sets the vault id label requested to a vault password client script
+	VaultIDFlag = "--vault-id"
+	// ClientScriptSuffix is the suffix of the vault password client scripts names
+	ClientScriptSuffix = "-client"
+	// ClientSecretNotFoundExitCode is the exit code of a vault password client script that does not know the requested vault id
+	ClientSecretNotFoundExitCode = 2
+)
+
+// OptionsFunc is a function used to configure ReadPasswordFromExecutable
+type OptionsFunc func(*ReadPasswordFromExecutable)
+
+// ReadPasswordFromExecutable returns the password written by an executable to its standard output, like the ansible executable vault password files
+type ReadPasswordFromExecutable struct {
+	args    []string
+	command string
+	env     []string
+	timeout time.Duration
+}
+
+// NewReadPasswordFromExecutable returns a ReadPasswordFromExecutable
+func NewReadPasswordFromExecutable(options ...OptionsFunc) *ReadPasswordFromExecutable {
+	secret := &ReadPasswordFromExecutable{
+		timeout: DefaultTimeout,
+	}
+	secret.Options(options...)
+
+	return secret
+}
+
+// WithCommand sets the executable that writes the password
+func WithCommand(command string) OptionsFunc {
+	return func(s *ReadPasswordFromExecutable) {
+		s.command = command
+	}
+}
+
+// WithArgs appends arguments to the executable
+func WithArgs(args ...string) OptionsFunc {
+	return func(s *ReadPasswordFromExecutable) {
+		s.args = append(s.args, args...)
+	}
+}
+
+// WithVaultID appends the --vault-id argument, which sets the vault id label requested to a vault password client script
+func WithVaultID(vaultID string) OptionsFunc {
+	return func(s *ReadPasswordFromExecutable) {
+		s.args = append(s.args, VaultIDFlag, vaultID)
+	}
+}
+
+// WithEnv appends environment variables, in the key=value form, to the executable environment
+func WithEnv(env ...string) OptionsFunc {
+	return func(s *ReadPasswordFromExecutable) {
+		s.env = append(s.env, env...)
+	}
+}
+
+// WithTimeout sets the maximum time the executable is allowed to run. A zero timeout disables it
+func WithTimeout(timeout time.Duration) OptionsFunc {
+	return func(s *ReadPasswordFromExecutable) {
+		s.timeout = timeout
+	}
+}
+
+// Options configure the ReadPasswordFromExecutable
+func (s *ReadPasswordFromExecutable) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(s)
+	}
+}
+
+// Read runs the executable and returns its standard output without the leading and trailing line breaks, as ansible does. It returns an error when the executable fails, exceeds the timeout or writes an empty password
+func (s *ReadPasswordFromExecutable) Read() (string, error) {
+	if s == nil {
+		return "", errors.New("password input from executable has not been initialized")
+	}
+
+	if len(s.command) <= 0 {
+		return "", errors.New("command must be specified to use the password input from executable")
+	}
+
+	ctx := context.Background()
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	cmd := exec.CommandContext(ctx, s.command, s.args...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if len(s.env) > 0 {
+		cmd.Env = append(cmd.Environ(), s.env...)
+	}
+
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("password executable '%s' did not finish within %s", s.command, s.timeout)
+	}
+	if err != nil {
+		return "", s.runError(err, stderr.String())
+	}
+
+	password := strings.Trim(stdout.String(), "\r\n")
+	if len(password) <= 0 {
+		return "", fmt.Errorf("password executable '%s' returned an empty password", s.command)
+	}
+
+	return password, nil
+}
+
+// runError describes the error of the executable, including its standard error output
+func (s *ReadPasswordFromExecutable) runError(err error, stderr string) error {
+	var exitErr *exec.ExitError
+
+	message := fmt.Sprintf("password executable '%s' failed", s.command)
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == ClientSecretNotFoundExitCode && s.isClient() {
+		message = fmt.Sprintf("password client '%s' did not find the secret", s.command)
+	}
+
+	stderr = strings.TrimSpace(stderr)
+	if len(stderr) > 0 {
+		return fmt.Errorf("%s: %w: %s", message, err, stderr)
+	}
+
+	return fmt.Errorf("%s: %w", message, err)
+}
+
+// isClient returns whether the executable is a vault password client script, which ansible recognizes by its name suffix
+func (s *ReadPasswordFromExecutable) isClient() bool {
+	name := strings.TrimSuffix(filepath.Base(s.command), filepath.Ext(s.command))
+	return strings.HasSuffix(name, ClientScriptSuffix)
+}
@@ -0,0 +1,131 @@
+package executable
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeScript(t *testing.T, dir, name, content string) string {
+	script := filepath.Join(dir, name)
+	err := os.WriteFile(script, []byte("#!/bin/sh\n"+content+"\n"), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return script
+}
+
+func TestRead(t *testing.T) {
+	dir := t.TempDir()
+
+	passwordScript := writeScript(t, dir, "password.sh", "echo ThatIsAPassword")
+	argsScript := writeScript(t, dir, "args.sh", `echo "$*"`)
+	envScript := writeScript(t, dir, "env.sh", `printf '%s\r\n' "$VAULT_PASSWORD"`)
+	spacesScript := writeScript(t, dir, "spaces.sh", `printf '\n  ThatIsAPassword \t\r\n'`)
+	failScript := writeScript(t, dir, "fail.sh", "echo 'missing secret' >&2\nexit 1")
+	clientScript := writeScript(t, dir, "secrets-client.sh", "exit 2")
+	emptyScript := writeScript(t, dir, "empty.sh", "echo")
+	slowScript := writeScript(t, dir, "slow.sh", "exec sleep 5")
+
+	tests := []struct {
+		desc     string
+		reader   *ReadPasswordFromExecutable
+		expected string
+		err      error
+	}{
+		{
+			desc: "Testing reading a password from an executable",
+			reader: NewReadPasswordFromExecutable(
+				WithCommand(passwordScript),
+			),
+			expected: "ThatIsAPassword",
+			err:      nil,
+		},
+		{
+			desc: "Testing reading a password from an executable with arguments and vault id",
+			reader: NewReadPasswordFromExecutable(
+				WithCommand(argsScript),
+				WithArgs("--format", "plain"),
+				WithVaultID("prod"),
+			),
+			expected: "--format plain --vault-id prod",
+			err:      nil,
+		},
+		{
+			desc: "Testing reading a password from an executable with environment variables",
+			reader: NewReadPasswordFromExecutable(
+				WithCommand(envScript),
+				WithEnv("VAULT_PASSWORD=ThatIsAPasswordFromEnv"),
+			),
+			expected: "ThatIsAPasswordFromEnv",
+			err:      nil,
+		},
+		{
+			desc: "Testing reading a password from an executable keeping its leading and trailing spaces",
+			reader: NewReadPasswordFromExecutable(
+				WithCommand(spacesScript),
+			),
+			expected: "  ThatIsAPassword \t",
+			err:      nil,
+		},
+		{
+			desc:   "Testing error reading a password from executable when ReadPasswordFromExecutable is not initialized",
+			reader: nil,
+			err:    errors.New("password input from executable has not been initialized"),
+		},
+		{
+			desc:   "Testing error reading a password from executable when the command is not specified",
+			reader: NewReadPasswordFromExecutable(),
+			err:    errors.New("command must be specified to use the password input from executable"),
+		},
+		{
+			desc: "Testing error reading a password from executable when the executable fails",
+			reader: NewReadPasswordFromExecutable(
+				WithCommand(failScript),
+			),
+			err: errors.New("password executable '" + failScript + "' failed: exit status 1: missing secret"),
+		},
+		{
+			desc: "Testing error reading a password from executable when the client does not find the secret",
+			reader: NewReadPasswordFromExecutable(
+				WithCommand(clientScript),
+				WithVaultID("prod"),
+			),
+			err: errors.New("password client '" + clientScript + "' did not find the secret: exit status 2"),
+		},
+		{
+			desc: "Testing error reading a password from executable when the password is empty",
+			reader: NewReadPasswordFromExecutable(
+				WithCommand(emptyScript),
+			),
+			err: errors.New("password executable '" + emptyScript + "' returned an empty password"),
+		},
+		{
+			desc: "Testing error reading a password from executable when the timeout is exceeded",
+			reader: NewReadPasswordFromExecutable(
+				WithCommand(slowScript),
+				WithTimeout(100*time.Millisecond),
+			),
+			err: errors.New("password executable '" + slowScript + "' did not finish within 100ms"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			password, err := test.reader.Read()
+			if test.err != nil {
+				assert.EqualError(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, password)
+			}
+		})
+	}
+}
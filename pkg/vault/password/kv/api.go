@@ -0,0 +1,28 @@
+package kv
+
+// secretResponse is the response of the KV version 2 secrets engine when reading a secret
+type secretResponse struct {
+	Data struct {
+		Data     map[string]interface{} `json:"data"`
+		Metadata map[string]interface{} `json:"metadata"`
+	} `json:"data"`
+}
+
+// appRoleLoginRequest is the request to log in through the AppRole auth method
+type appRoleLoginRequest struct {
+	RoleID   string `json:"role_id"`
+	SecretID string `json:"secret_id"`
+}
+
+// appRoleLoginResponse is the response of the AppRole auth method login
+type appRoleLoginResponse struct {
+	Auth struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int    `json:"lease_duration"`
+	} `json:"auth"`
+}
+
+// errorResponse is the response of the secret store when a request fails
+type errorResponse struct {
+	Errors []string `json:"errors"`
+}
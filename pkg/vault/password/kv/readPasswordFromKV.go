@@ -0,0 +1,379 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMount is the mount path of the KV version 2 secrets engine
+	DefaultMount = "secret"
+	// DefaultAppRoleMount is the mount path of the AppRole auth method
+	DefaultAppRoleMount = "approle"
+	// DefaultTimeout is the maximum time to get the secret, including the authentication
+	DefaultTimeout = 30 * time.Second
+
+	// TokenHeader is the header that sets the token of the requests
+	TokenHeader = "X-Vault-Token"
+	// NamespaceHeader is the header that sets the namespace of the requests
+	NamespaceHeader = "X-Vault-Namespace"
+)
+
+// OptionsFunc is a function used to configure ReadPasswordFromKV
+type OptionsFunc func(*ReadPasswordFromKV)
+
+// ReadPasswordFromKV reads a password from the key of a secret stored on a KV version 2 secrets engine, such as the HashiCorp Vault one, through its HTTP API. It authenticates with a token or an AppRole
+type ReadPasswordFromKV struct {
+	address      string
+	appRoleMount string
+	cacheTTL     time.Duration
+	client       *http.Client
+	key          string
+	mount        string
+	namespace    string
+	path         string
+	roleID       string
+	secretID     string
+	timeout      time.Duration
+	tlsConfig    *tls.Config
+	token        string
+	now          func() time.Time
+
+	mu             sync.Mutex
+	password       string
+	passwordExpiry time.Time
+	loginToken     string
+	loginExpiry    time.Time
+}
+
+// NewReadPasswordFromKV returns a ReadPasswordFromKV
+func NewReadPasswordFromKV(options ...OptionsFunc) *ReadPasswordFromKV {
+	secret := &ReadPasswordFromKV{
+		appRoleMount: DefaultAppRoleMount,
+		mount:        DefaultMount,
+		timeout:      DefaultTimeout,
+		now:          time.Now,
+	}
+	secret.Options(options...)
+
+	return secret
+}
+
+// WithAddress sets the address of the secret store, such as https://vault.example.com:8200
+func WithAddress(address string) OptionsFunc {
+	return func(s *ReadPasswordFromKV) {
+		s.address = strings.TrimRight(address, "/")
+	}
+}
+
+// WithMount sets the mount path of the KV version 2 secrets engine. The default mount is secret
+func WithMount(mount string) OptionsFunc {
+	return func(s *ReadPasswordFromKV) {
+		s.mount = strings.Trim(mount, "/")
+	}
+}
+
+// WithPath sets the path of the secret on the secrets engine
+func WithPath(path string) OptionsFunc {
+	return func(s *ReadPasswordFromKV) {
+		s.path = strings.Trim(path, "/")
+	}
+}
+
+// WithKey sets the key of the secret that contains the password
+func WithKey(key string) OptionsFunc {
+	return func(s *ReadPasswordFromKV) {
+		s.key = key
+	}
+}
+
+// WithNamespace sets the namespace of the requests
+func WithNamespace(namespace string) OptionsFunc {
+	return func(s *ReadPasswordFromKV) {
+		s.namespace = namespace
+	}
+}
+
+// WithToken sets the token used to authenticate the requests
+func WithToken(token string) OptionsFunc {
+	return func(s *ReadPasswordFromKV) {
+		s.token = token
+	}
+}
+
+// WithAppRole sets the role id and secret id used to log in through the AppRole auth method. The token got from the login is reused until its lease expires
+func WithAppRole(roleID, secretID string) OptionsFunc {
+	return func(s *ReadPasswordFromKV) {
+		s.roleID = roleID
+		s.secretID = secretID
+	}
+}
+
+// WithAppRoleMount sets the mount path of the AppRole auth method. The default mount is approle
+func WithAppRoleMount(mount string) OptionsFunc {
+	return func(s *ReadPasswordFromKV) {
+		s.appRoleMount = strings.Trim(mount, "/")
+	}
+}
+
+// WithTLSConfig sets the TLS configuration of the HTTP client. It is ignored when the HTTP client is set by WithHTTPClient
+func WithTLSConfig(config *tls.Config) OptionsFunc {
+	return func(s *ReadPasswordFromKV) {
+		s.tlsConfig = config
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send the requests
+func WithHTTPClient(client *http.Client) OptionsFunc {
+	return func(s *ReadPasswordFromKV) {
+		s.client = client
+	}
+}
+
+// WithCacheTTL sets how long the password is cached after it is read. The password is not cached by default
+func WithCacheTTL(ttl time.Duration) OptionsFunc {
+	return func(s *ReadPasswordFromKV) {
+		s.cacheTTL = ttl
+	}
+}
+
+// WithTimeout sets the maximum time to get the secret, including the authentication. A zero timeout disables it
+func WithTimeout(timeout time.Duration) OptionsFunc {
+	return func(s *ReadPasswordFromKV) {
+		s.timeout = timeout
+	}
+}
+
+// Options configure the ReadPasswordFromKV
+func (s *ReadPasswordFromKV) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(s)
+	}
+}
+
+// Read returns the password stored on the key of the secret
+func (s *ReadPasswordFromKV) Read() (string, error) {
+	return s.ReadContext(context.Background())
+}
+
+// ReadContext returns the password stored on the key of the secret. The requests are cancelled when the context is done or the timeout is exceeded
+func (s *ReadPasswordFromKV) ReadContext(ctx context.Context) (string, error) {
+	if s == nil {
+		return "", errors.New("password input from KV secret store has not been initialized")
+	}
+
+	err := s.validate()
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cacheTTL > 0 && s.now().Before(s.passwordExpiry) {
+		return s.password, nil
+	}
+
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	data, err := s.readSecret(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	value, exists := data[s.key]
+	if !exists {
+		return "", fmt.Errorf("key '%s' is not defined on the secret '%s'", s.key, s.secretPath())
+	}
+
+	password, isString := value.(string)
+	if !isString || len(password) <= 0 {
+		return "", fmt.Errorf("key '%s' of the secret '%s' must be a non empty string", s.key, s.secretPath())
+	}
+
+	if s.cacheTTL > 0 {
+		s.password = password
+		s.passwordExpiry = s.now().Add(s.cacheTTL)
+	}
+
+	return password, nil
+}
+
+// validate checks that the options required to read the secret are set
+func (s *ReadPasswordFromKV) validate() error {
+	if len(s.address) <= 0 {
+		return errors.New("address must be specified to use the password input from KV secret store")
+	}
+
+	if len(s.path) <= 0 {
+		return errors.New("secret path must be specified to use the password input from KV secret store")
+	}
+
+	if len(s.key) <= 0 {
+		return errors.New("secret key must be specified to use the password input from KV secret store")
+	}
+
+	if len(s.token) <= 0 && (len(s.roleID) <= 0 || len(s.secretID) <= 0) {
+		return errors.New("a token or an AppRole role id and secret id must be specified to use the password input from KV secret store")
+	}
+
+	return nil
+}
+
+// secretPath returns the path of the secret including the secrets engine mount
+func (s *ReadPasswordFromKV) secretPath() string {
+	return s.mount + "/" + s.path
+}
+
+// readSecret returns the data of the latest version of the secret. When the AppRole token is rejected, it logs in again and retries once
+func (s *ReadPasswordFromKV) readSecret(ctx context.Context) (map[string]interface{}, error) {
+	token, err := s.authToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &secretResponse{}
+	status, err := s.do(ctx, http.MethodGet, fmt.Sprintf("/v1/%s/data/%s", s.mount, s.path), token, nil, response)
+	if status == http.StatusForbidden && len(s.token) <= 0 {
+		s.loginToken = ""
+
+		token, err = s.authToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+		status, err = s.do(ctx, http.MethodGet, fmt.Sprintf("/v1/%s/data/%s", s.mount, s.path), token, nil, response)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading the secret '%s': %w", s.secretPath(), err)
+	}
+
+	if response.Data.Data == nil {
+		return nil, fmt.Errorf("secret '%s' has no data", s.secretPath())
+	}
+
+	return response.Data.Data, nil
+}
+
+// authToken returns the token that authenticates the requests. The AppRole token is reused until its lease expires
+func (s *ReadPasswordFromKV) authToken(ctx context.Context) (string, error) {
+	if len(s.token) > 0 {
+		return s.token, nil
+	}
+
+	if len(s.loginToken) > 0 && (s.loginExpiry.IsZero() || s.now().Before(s.loginExpiry)) {
+		return s.loginToken, nil
+	}
+
+	request := &appRoleLoginRequest{
+		RoleID:   s.roleID,
+		SecretID: s.secretID,
+	}
+	response := &appRoleLoginResponse{}
+
+	_, err := s.do(ctx, http.MethodPost, fmt.Sprintf("/v1/auth/%s/login", s.appRoleMount), "", request, response)
+	if err != nil {
+		return "", fmt.Errorf("error logging in with the AppRole auth method: %w", err)
+	}
+
+	if len(response.Auth.ClientToken) <= 0 {
+		return "", errors.New("error logging in with the AppRole auth method: no client token was returned")
+	}
+
+	s.loginToken = response.Auth.ClientToken
+	s.loginExpiry = time.Time{}
+	if response.Auth.LeaseDuration > 0 {
+		s.loginExpiry = s.now().Add(time.Duration(response.Auth.LeaseDuration) * time.Second)
+	}
+
+	return s.loginToken, nil
+}
+
+// do sends a request to the secret store and decodes its JSON response. It returns the response status code, and an error when the status code is not successful
+func (s *ReadPasswordFromKV) do(ctx context.Context, method, path, token string, body, result interface{}) (int, error) {
+	var reader io.Reader
+
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return 0, fmt.Errorf("error encoding the request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	endpoint, err := url.JoinPath(s.address, path)
+	if err != nil {
+		return 0, fmt.Errorf("error building the request URL: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return 0, fmt.Errorf("error creating the request: %w", err)
+	}
+
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if len(token) > 0 {
+		request.Header.Set(TokenHeader, token)
+	}
+	if len(s.namespace) > 0 {
+		request.Header.Set(NamespaceHeader, s.namespace)
+	}
+
+	response, err := s.httpClient().Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, responseError(response)
+	}
+
+	err = json.NewDecoder(response.Body).Decode(result)
+	if err != nil {
+		return response.StatusCode, fmt.Errorf("error decoding the response: %w", err)
+	}
+
+	return response.StatusCode, nil
+}
+
+// httpClient returns the HTTP client used to send the requests
+func (s *ReadPasswordFromKV) httpClient() *http.Client {
+	if s.client == nil {
+		s.client = &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: s.tlsConfig,
+			},
+		}
+	}
+
+	return s.client
+}
+
+// responseError returns the error of an unsuccessful response, including the errors reported by the secret store
+func responseError(response *http.Response) error {
+	errResponse := &errorResponse{}
+
+	err := json.NewDecoder(response.Body).Decode(errResponse)
+	if err != nil || len(errResponse.Errors) == 0 {
+		return fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+
+	return fmt.Errorf("unexpected status code %d: %s", response.StatusCode, strings.Join(errResponse.Errors, ", "))
+}
@@ -0,0 +1,421 @@
+package kv
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeKV is a stand-in of the KV version 2 secrets engine and the AppRole auth method HTTP API
+type fakeKV struct {
+	mu        sync.Mutex
+	secrets   map[string]map[string]interface{}
+	tokens    map[string]bool
+	roleID    string
+	secretID  string
+	lease     int
+	namespace string
+	delay     time.Duration
+	logins    int
+	reads     int
+}
+
+func newFakeKV() *fakeKV {
+	return &fakeKV{
+		secrets: map[string]map[string]interface{}{
+			"/v1/secret/data/ansible/prod": {
+				"vault_password":  "ThatIsAVaultPassword",
+				"become_password": "ThatIsABecomePassword",
+				"port":            22,
+			},
+			"/v1/kv/data/ansible/dev": {
+				"vault_password": "ThatIsADevVaultPassword",
+			},
+		},
+		tokens: map[string]bool{
+			"root-token": true,
+		},
+		roleID:   "ansible-role",
+		secretID: "ansible-secret",
+		lease:    3600,
+	}
+}
+
+func (f *fakeKV) revokeTokens() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.tokens = map[string]bool{}
+}
+
+func (f *fakeKV) writeError(w http.ResponseWriter, status int, message string) {
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"errors": []string{message}})
+}
+
+func (f *fakeKV) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.delay > 0 {
+		time.Sleep(f.delay)
+	}
+
+	if f.namespace != "" && r.Header.Get(NamespaceHeader) != f.namespace {
+		f.writeError(w, http.StatusForbidden, "permission denied")
+		return
+	}
+
+	if r.Method == http.MethodPost && r.URL.Path == "/v1/auth/approle/login" {
+		f.logins++
+
+		request := &appRoleLoginRequest{}
+		err := json.NewDecoder(r.Body).Decode(request)
+		if err != nil || request.RoleID != f.roleID || request.SecretID != f.secretID {
+			f.writeError(w, http.StatusBadRequest, "invalid role or secret ID")
+			return
+		}
+
+		token := "approle-token-" + strings.Repeat("x", f.logins)
+		f.tokens[token] = true
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"auth": map[string]interface{}{
+				"client_token":   token,
+				"lease_duration": f.lease,
+			},
+		})
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		f.writeError(w, http.StatusMethodNotAllowed, "unsupported operation")
+		return
+	}
+
+	f.reads++
+
+	if !f.tokens[r.Header.Get(TokenHeader)] {
+		f.writeError(w, http.StatusForbidden, "permission denied")
+		return
+	}
+
+	data, exists := f.secrets[r.URL.Path]
+	if !exists {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors":[]}`))
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": map[string]interface{}{
+			"data":     data,
+			"metadata": map[string]interface{}{"version": 1},
+		},
+	})
+}
+
+func TestRead(t *testing.T) {
+	kv := newFakeKV()
+	server := httptest.NewServer(kv)
+	defer server.Close()
+
+	namespaced := newFakeKV()
+	namespaced.namespace = "team-a"
+	namespacedServer := httptest.NewServer(namespaced)
+	defer namespacedServer.Close()
+
+	tests := []struct {
+		desc     string
+		reader   *ReadPasswordFromKV
+		expected string
+		err      error
+	}{
+		{
+			desc: "Testing reading a password from a KV secret store using a token",
+			reader: NewReadPasswordFromKV(
+				WithAddress(server.URL+"/"),
+				WithToken("root-token"),
+				WithPath("/ansible/prod"),
+				WithKey("vault_password"),
+			),
+			expected: "ThatIsAVaultPassword",
+			err:      nil,
+		},
+		{
+			desc: "Testing reading a password from a KV secret store on a custom mount",
+			reader: NewReadPasswordFromKV(
+				WithAddress(server.URL),
+				WithToken("root-token"),
+				WithMount("kv"),
+				WithPath("ansible/dev"),
+				WithKey("vault_password"),
+			),
+			expected: "ThatIsADevVaultPassword",
+			err:      nil,
+		},
+		{
+			desc: "Testing reading a password from a KV secret store using an AppRole",
+			reader: NewReadPasswordFromKV(
+				WithAddress(server.URL),
+				WithAppRole("ansible-role", "ansible-secret"),
+				WithPath("ansible/prod"),
+				WithKey("become_password"),
+			),
+			expected: "ThatIsABecomePassword",
+			err:      nil,
+		},
+		{
+			desc: "Testing reading a password from a KV secret store with a namespace",
+			reader: NewReadPasswordFromKV(
+				WithAddress(namespacedServer.URL),
+				WithNamespace("team-a"),
+				WithToken("root-token"),
+				WithPath("ansible/prod"),
+				WithKey("vault_password"),
+			),
+			expected: "ThatIsAVaultPassword",
+			err:      nil,
+		},
+		{
+			desc:   "Testing error reading a password from KV secret store when ReadPasswordFromKV is not initialized",
+			reader: nil,
+			err:    errors.New("password input from KV secret store has not been initialized"),
+		},
+		{
+			desc: "Testing error reading a password from KV secret store when the address is not specified",
+			reader: NewReadPasswordFromKV(
+				WithToken("root-token"),
+				WithPath("ansible/prod"),
+				WithKey("vault_password"),
+			),
+			err: errors.New("address must be specified to use the password input from KV secret store"),
+		},
+		{
+			desc: "Testing error reading a password from KV secret store when the path is not specified",
+			reader: NewReadPasswordFromKV(
+				WithAddress(server.URL),
+				WithToken("root-token"),
+				WithKey("vault_password"),
+			),
+			err: errors.New("secret path must be specified to use the password input from KV secret store"),
+		},
+		{
+			desc: "Testing error reading a password from KV secret store when the key is not specified",
+			reader: NewReadPasswordFromKV(
+				WithAddress(server.URL),
+				WithToken("root-token"),
+				WithPath("ansible/prod"),
+			),
+			err: errors.New("secret key must be specified to use the password input from KV secret store"),
+		},
+		{
+			desc: "Testing error reading a password from KV secret store when the authentication is not specified",
+			reader: NewReadPasswordFromKV(
+				WithAddress(server.URL),
+				WithAppRole("ansible-role", ""),
+				WithPath("ansible/prod"),
+				WithKey("vault_password"),
+			),
+			err: errors.New("a token or an AppRole role id and secret id must be specified to use the password input from KV secret store"),
+		},
+		{
+			desc: "Testing error reading a password from KV secret store when the token is not valid",
+			reader: NewReadPasswordFromKV(
+				WithAddress(server.URL),
+				WithToken("invalid-token"),
+				WithPath("ansible/prod"),
+				WithKey("vault_password"),
+			),
+			err: errors.New("error reading the secret 'secret/ansible/prod': unexpected status code 403: permission denied"),
+		},
+		{
+			desc: "Testing error reading a password from KV secret store when the AppRole is not valid",
+			reader: NewReadPasswordFromKV(
+				WithAddress(server.URL),
+				WithAppRole("ansible-role", "invalid-secret"),
+				WithPath("ansible/prod"),
+				WithKey("vault_password"),
+			),
+			err: errors.New("error logging in with the AppRole auth method: unexpected status code 400: invalid role or secret ID"),
+		},
+		{
+			desc: "Testing error reading a password from KV secret store when the secret does not exist",
+			reader: NewReadPasswordFromKV(
+				WithAddress(server.URL),
+				WithToken("root-token"),
+				WithPath("ansible/missing"),
+				WithKey("vault_password"),
+			),
+			err: errors.New("error reading the secret 'secret/ansible/missing': unexpected status code 404"),
+		},
+		{
+			desc: "Testing error reading a password from KV secret store when the key does not exist",
+			reader: NewReadPasswordFromKV(
+				WithAddress(server.URL),
+				WithToken("root-token"),
+				WithPath("ansible/prod"),
+				WithKey("missing"),
+			),
+			err: errors.New("key 'missing' is not defined on the secret 'secret/ansible/prod'"),
+		},
+		{
+			desc: "Testing error reading a password from KV secret store when the key is not a string",
+			reader: NewReadPasswordFromKV(
+				WithAddress(server.URL),
+				WithToken("root-token"),
+				WithPath("ansible/prod"),
+				WithKey("port"),
+			),
+			err: errors.New("key 'port' of the secret 'secret/ansible/prod' must be a non empty string"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			password, err := test.reader.Read()
+			if test.err != nil {
+				assert.EqualError(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, password)
+			}
+		})
+	}
+}
+
+func TestReadCache(t *testing.T) {
+	kv := newFakeKV()
+	server := httptest.NewServer(kv)
+	defer server.Close()
+
+	now := time.Now()
+	reader := NewReadPasswordFromKV(
+		WithAddress(server.URL),
+		WithToken("root-token"),
+		WithPath("ansible/prod"),
+		WithKey("vault_password"),
+		WithCacheTTL(time.Minute),
+	)
+	reader.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		password, err := reader.Read()
+		assert.NoError(t, err)
+		assert.Equal(t, "ThatIsAVaultPassword", password)
+	}
+	assert.Equal(t, 1, kv.reads)
+
+	now = now.Add(2 * time.Minute)
+	password, err := reader.Read()
+	assert.NoError(t, err)
+	assert.Equal(t, "ThatIsAVaultPassword", password)
+	assert.Equal(t, 2, kv.reads)
+}
+
+func TestReadAppRoleToken(t *testing.T) {
+	kv := newFakeKV()
+	kv.lease = 60
+	server := httptest.NewServer(kv)
+	defer server.Close()
+
+	now := time.Now()
+	reader := NewReadPasswordFromKV(
+		WithAddress(server.URL),
+		WithAppRole("ansible-role", "ansible-secret"),
+		WithPath("ansible/prod"),
+		WithKey("vault_password"),
+	)
+	reader.now = func() time.Time { return now }
+
+	t.Log("Testing the AppRole token is reused until its lease expires")
+	for i := 0; i < 2; i++ {
+		_, err := reader.Read()
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, kv.logins)
+
+	now = now.Add(2 * time.Minute)
+	_, err := reader.Read()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, kv.logins)
+
+	t.Log("Testing the AppRole login is retried when the token is rejected")
+	kv.revokeTokens()
+	password, err := reader.Read()
+	assert.NoError(t, err)
+	assert.Equal(t, "ThatIsAVaultPassword", password)
+	assert.Equal(t, 3, kv.logins)
+}
+
+func TestReadContext(t *testing.T) {
+	kv := newFakeKV()
+	kv.delay = 200 * time.Millisecond
+	server := httptest.NewServer(kv)
+	defer server.Close()
+
+	t.Log("Testing error reading a password from KV secret store when the timeout is exceeded")
+	reader := NewReadPasswordFromKV(
+		WithAddress(server.URL),
+		WithToken("root-token"),
+		WithPath("ansible/prod"),
+		WithKey("vault_password"),
+		WithTimeout(20*time.Millisecond),
+	)
+	_, err := reader.Read()
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	t.Log("Testing error reading a password from KV secret store when the context is cancelled")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	reader = NewReadPasswordFromKV(
+		WithAddress(server.URL),
+		WithToken("root-token"),
+		WithPath("ansible/prod"),
+		WithKey("vault_password"),
+	)
+	_, err = reader.ReadContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestReadTLS(t *testing.T) {
+	server := httptest.NewTLSServer(newFakeKV())
+	defer server.Close()
+
+	t.Log("Testing error reading a password from KV secret store when the certificate is not trusted")
+	reader := NewReadPasswordFromKV(
+		WithAddress(server.URL),
+		WithToken("root-token"),
+		WithPath("ansible/prod"),
+		WithKey("vault_password"),
+	)
+	_, err := reader.Read()
+	assert.Error(t, err)
+
+	t.Log("Testing reading a password from KV secret store trusting the server certificate")
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	reader = NewReadPasswordFromKV(
+		WithAddress(server.URL),
+		WithToken("root-token"),
+		WithPath("ansible/prod"),
+		WithKey("vault_password"),
+		WithTLSConfig(&tls.Config{RootCAs: pool}),
+	)
+	password, err := reader.Read()
+	assert.NoError(t, err)
+	assert.Equal(t, "ThatIsAVaultPassword", password)
+}
@@ -0,0 +1,79 @@
+package reader
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// OptionsFunc is a function used to configure ReadPasswordFromReader
+type OptionsFunc func(*ReadPasswordFromReader)
+
+// ReadPasswordFromReader reads a password from an io.Reader, such as the standard input. The reader is only read once, and the same password is returned on the following reads
+type ReadPasswordFromReader struct {
+	reader io.Reader
+
+	once     sync.Once
+	password string
+	err      error
+}
+
+// NewReadPasswordFromReader returns a ReadPasswordFromReader. The standard input is read by default
+func NewReadPasswordFromReader(options ...OptionsFunc) *ReadPasswordFromReader {
+	secret := &ReadPasswordFromReader{
+		reader: os.Stdin,
+	}
+	secret.Options(options...)
+
+	return secret
+}
+
+// WithReader sets the reader where to look for a password
+func WithReader(reader io.Reader) OptionsFunc {
+	return func(s *ReadPasswordFromReader) {
+		s.reader = reader
+	}
+}
+
+// Options configure the ReadPasswordFromReader
+func (s *ReadPasswordFromReader) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(s)
+	}
+}
+
+// Read returns the first line read from the reader. It returns an error when the reader can not be read or the password is empty
+func (s *ReadPasswordFromReader) Read() (string, error) {
+	if s == nil {
+		return "", errors.New("password input from reader has not been initialized")
+	}
+
+	if s.reader == nil {
+		return "", errors.New("reader must be specified to use the password input from reader")
+	}
+
+	s.once.Do(func() {
+		s.password, s.err = readLine(s.reader)
+	})
+
+	return s.password, s.err
+}
+
+// readLine returns the first line of the reader, without the line break
+func readLine(reader io.Reader) (string, error) {
+	line, err := bufio.NewReader(reader).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("error reading the password: %w", err)
+	}
+
+	password := strings.TrimRight(line, "\r\n")
+	if len(password) <= 0 {
+		return "", errors.New("an empty password was read from the reader")
+	}
+
+	return password, nil
+}
@@ -0,0 +1,94 @@
+package reader
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		desc     string
+		reader   *ReadPasswordFromReader
+		reads    int
+		expected string
+		err      error
+	}{
+		{
+			desc: "Testing reading a password from a reader",
+			reader: NewReadPasswordFromReader(
+				WithReader(strings.NewReader("ThatIsAPassword\r\nThatIsAnotherLine\n")),
+			),
+			reads:    1,
+			expected: "ThatIsAPassword",
+			err:      nil,
+		},
+		{
+			desc: "Testing reading a password from a reader without line break",
+			reader: NewReadPasswordFromReader(
+				WithReader(strings.NewReader("ThatIsAPassword")),
+			),
+			reads:    1,
+			expected: "ThatIsAPassword",
+			err:      nil,
+		},
+		{
+			desc: "Testing reading a password from a reader more than once",
+			reader: NewReadPasswordFromReader(
+				WithReader(strings.NewReader("ThatIsAPassword\nThatIsAnotherLine\n")),
+			),
+			reads:    3,
+			expected: "ThatIsAPassword",
+			err:      nil,
+		},
+		{
+			desc:   "Testing error reading a password from reader when ReadPasswordFromReader is not initialized",
+			reader: nil,
+			reads:  1,
+			err:    errors.New("password input from reader has not been initialized"),
+		},
+		{
+			desc: "Testing error reading a password from reader when the reader is not specified",
+			reader: NewReadPasswordFromReader(
+				WithReader(nil),
+			),
+			reads: 1,
+			err:   errors.New("reader must be specified to use the password input from reader"),
+		},
+		{
+			desc: "Testing error reading a password from reader when the reader fails",
+			reader: NewReadPasswordFromReader(
+				WithReader(iotest.ErrReader(errors.New("broken pipe"))),
+			),
+			reads: 1,
+			err:   errors.New("error reading the password: broken pipe"),
+		},
+		{
+			desc: "Testing error reading a password from reader when the password is empty",
+			reader: NewReadPasswordFromReader(
+				WithReader(strings.NewReader("\n")),
+			),
+			reads: 2,
+			err:   errors.New("an empty password was read from the reader"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			for i := 0; i < test.reads; i++ {
+				password, err := test.reader.Read()
+				if test.err != nil {
+					assert.EqualError(t, err, test.err.Error())
+				} else {
+					assert.NoError(t, err)
+					assert.Equal(t, test.expected, password)
+				}
+			}
+		})
+	}
+}
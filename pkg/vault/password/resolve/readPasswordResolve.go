@@ -2,8 +2,37 @@ package resolve
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 )
 
+// errNoPasswordFound is the message of the error returned when none of the readers returns a password
+const errNoPasswordFound = "the component to resolve read password does not found a password"
+
+// ReadersError is the error returned when none of the readers returns a password. It contains the error of each reader
+type ReadersError struct {
+	Errors []error
+}
+
+// Error returns the reason why each reader failed
+func (e *ReadersError) Error() string {
+	if len(e.Errors) == 0 {
+		return errNoPasswordFound
+	}
+
+	reasons := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		reasons = append(reasons, err.Error())
+	}
+
+	return fmt.Sprintf("%s: %s", errNoPasswordFound, strings.Join(reasons, "; "))
+}
+
+// Unwrap returns the errors of the readers
+func (e *ReadersError) Unwrap() []error {
+	return e.Errors
+}
+
 // OptionsFunc is a function used to configure ReadPasswordResolve
 type OptionsFunc func(*ReadPasswordResolve)
 
@@ -38,18 +67,20 @@ func (s *ReadPasswordResolve) Options(opts ...OptionsFunc) {
 	}
 }
 
-// Read looks for the first reader defined into the reader attribute which returns a password
+// Read looks for the first reader defined into the reader attribute which returns a password. When none of them returns a password, it returns a ReadersError that reports why each reader failed
 func (s *ReadPasswordResolve) Read() (string, error) {
 	if s == nil {
 		return "", errors.New("the component to resolve read password mechanism has not been initialized")
 	}
 
-	for _, reader := range s.reader {
+	readersErr := &ReadersError{}
+	for i, reader := range s.reader {
 		secret, err := reader.Read()
 		if err == nil {
 			return secret, nil
 		}
+		readersErr.Errors = append(readersErr.Errors, fmt.Errorf("reader %d (%T): %w", i, reader, err))
 	}
 
-	return "", errors.New("the component to resolve read password does not found a password")
+	return "", readersErr
 }
@@ -6,6 +6,8 @@ import (
 
 	"github.com/apenella/go-ansible/v2/pkg/vault/password/envvars"
 	"github.com/apenella/go-ansible/v2/pkg/vault/password/file"
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/mock"
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 )
@@ -67,6 +69,18 @@ func TestRead(t *testing.T) {
 			reader: NewReadPasswordResolve(),
 			err:    errors.New("the component to resolve read password does not found a password"),
 		},
+		{
+			desc: "Testing error resolve the password reader reporting why each reader failed",
+			reader: NewReadPasswordResolve(
+				WithReader(
+					file.NewReadPasswordFromFile(
+						file.WithFs(testFs),
+					),
+					text.NewReadPasswordFromText(),
+				),
+			),
+			err: errors.New("the component to resolve read password does not found a password: reader 0 (*file.ReadPasswordFromFile): File path must be specified to read the password from a file.; reader 1 (*text.ReadPasswordFromText): text must be specified to use the password input from text"),
+		},
 	}
 
 	for _, test := range tests {
@@ -81,3 +95,18 @@ func TestRead(t *testing.T) {
 		})
 	}
 }
+
+func TestReadReadersError(t *testing.T) {
+	errReader := errors.New("reader error")
+
+	reader := mock.NewMockReadPassword()
+	reader.On("Read").Return("", errReader)
+
+	_, err := NewReadPasswordResolve(WithReader(reader)).Read()
+
+	var readersErr *ReadersError
+	assert.ErrorAs(t, err, &readersErr)
+	assert.Len(t, readersErr.Errors, 1)
+	assert.ErrorIs(t, err, errReader)
+	reader.AssertExpectations(t)
+}
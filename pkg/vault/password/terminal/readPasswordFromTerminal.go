@@ -0,0 +1,108 @@
+package terminal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+const (
+	// DefaultTerminal is the controlling terminal of the process
+	DefaultTerminal = "/dev/tty"
+	// DefaultPrompt is the prompt written before reading the password
+	DefaultPrompt = "Vault password: "
+)
+
+// OptionsFunc is a function used to configure ReadPasswordFromTerminal
+type OptionsFunc func(*ReadPasswordFromTerminal)
+
+// ReadPasswordFromTerminal prompts for a password on the controlling terminal, without echoing the typed characters
+type ReadPasswordFromTerminal struct {
+	prompt       string
+	terminal     string
+	isTerminal   func(fd int) bool
+	readPassword func(fd int) ([]byte, error)
+}
+
+// NewReadPasswordFromTerminal returns a ReadPasswordFromTerminal
+func NewReadPasswordFromTerminal(options ...OptionsFunc) *ReadPasswordFromTerminal {
+	secret := &ReadPasswordFromTerminal{
+		prompt:       DefaultPrompt,
+		terminal:     DefaultTerminal,
+		isTerminal:   term.IsTerminal,
+		readPassword: term.ReadPassword,
+	}
+	secret.Options(options...)
+
+	return secret
+}
+
+// WithPrompt sets the prompt written before reading the password
+func WithPrompt(prompt string) OptionsFunc {
+	return func(s *ReadPasswordFromTerminal) {
+		s.prompt = prompt
+	}
+}
+
+// WithTerminal sets the terminal device where the password is prompted
+func WithTerminal(terminal string) OptionsFunc {
+	return func(s *ReadPasswordFromTerminal) {
+		s.terminal = terminal
+	}
+}
+
+// Options configure the ReadPasswordFromTerminal
+func (s *ReadPasswordFromTerminal) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(s)
+	}
+}
+
+// Read prompts for the password on the terminal and returns it. It returns an error when there is no terminal or the password is empty
+func (s *ReadPasswordFromTerminal) Read() (password string, err error) {
+	if s == nil {
+		return "", errors.New("password input from terminal has not been initialized")
+	}
+
+	if len(s.terminal) <= 0 {
+		return "", errors.New("terminal must be specified to use the password input from terminal")
+	}
+
+	tty, err := os.OpenFile(s.terminal, os.O_RDWR, 0)
+	if err != nil {
+		return "", fmt.Errorf("error opening the terminal '%s': %w", s.terminal, err)
+	}
+	defer func() {
+		cerr := tty.Close()
+		if cerr != nil && err == nil {
+			err = fmt.Errorf("error closing the terminal '%s': %w", s.terminal, cerr)
+		}
+	}()
+
+	fd := int(tty.Fd())
+	if !s.isTerminal(fd) {
+		return "", fmt.Errorf("'%s' is not a terminal", s.terminal)
+	}
+
+	_, err = fmt.Fprint(tty, s.prompt)
+	if err != nil {
+		return "", fmt.Errorf("error writing the prompt to the terminal '%s': %w", s.terminal, err)
+	}
+
+	secret, err := s.readPassword(fd)
+	// the line break typed by the user is not echoed
+	fmt.Fprintln(tty)
+	if err != nil {
+		return "", fmt.Errorf("error reading the password from the terminal '%s': %w", s.terminal, err)
+	}
+
+	password = strings.TrimRight(string(secret), "\r\n")
+	if len(password) <= 0 {
+		return "", errors.New("an empty password was provided on the terminal")
+	}
+
+	return password, nil
+}
@@ -0,0 +1,109 @@
+package terminal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRead(t *testing.T) {
+	dir := t.TempDir()
+	device := filepath.Join(dir, "tty")
+	err := os.WriteFile(device, []byte{}, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fakeTerminal := func(password string, err error) OptionsFunc {
+		return func(s *ReadPasswordFromTerminal) {
+			s.terminal = device
+			s.isTerminal = func(int) bool { return true }
+			s.readPassword = func(int) ([]byte, error) { return []byte(password), err }
+		}
+	}
+
+	tests := []struct {
+		desc     string
+		reader   *ReadPasswordFromTerminal
+		expected string
+		prompt   string
+		err      error
+	}{
+		{
+			desc: "Testing reading a password from the terminal",
+			reader: NewReadPasswordFromTerminal(
+				fakeTerminal("ThatIsAPassword", nil),
+				WithPrompt("Password: "),
+			),
+			expected: "ThatIsAPassword",
+			prompt:   "Password: \n",
+			err:      nil,
+		},
+		{
+			desc:   "Testing error reading a password from terminal when ReadPasswordFromTerminal is not initialized",
+			reader: nil,
+			err:    errors.New("password input from terminal has not been initialized"),
+		},
+		{
+			desc: "Testing error reading a password from terminal when the terminal is not specified",
+			reader: NewReadPasswordFromTerminal(
+				WithTerminal(""),
+			),
+			err: errors.New("terminal must be specified to use the password input from terminal"),
+		},
+		{
+			desc: "Testing error reading a password from terminal when the terminal does not exist",
+			reader: NewReadPasswordFromTerminal(
+				WithTerminal(filepath.Join(dir, "missing")),
+			),
+			err: errors.New("error opening the terminal '" + filepath.Join(dir, "missing") + "': open " + filepath.Join(dir, "missing") + ": no such file or directory"),
+		},
+		{
+			desc: "Testing error reading a password from terminal when the device is not a terminal",
+			reader: NewReadPasswordFromTerminal(
+				WithTerminal(device),
+			),
+			err: errors.New("'" + device + "' is not a terminal"),
+		},
+		{
+			desc: "Testing error reading a password from terminal when the reading fails",
+			reader: NewReadPasswordFromTerminal(
+				fakeTerminal("", errors.New("interrupted")),
+			),
+			err: errors.New("error reading the password from the terminal '" + device + "': interrupted"),
+		},
+		{
+			desc: "Testing error reading a password from terminal when the password is empty",
+			reader: NewReadPasswordFromTerminal(
+				fakeTerminal("", nil),
+			),
+			err: errors.New("an empty password was provided on the terminal"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := os.Truncate(device, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			password, err := test.reader.Read()
+			if test.err != nil {
+				assert.EqualError(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, password)
+
+				prompt, err := os.ReadFile(device)
+				assert.NoError(t, err)
+				assert.Equal(t, test.prompt, string(prompt))
+			}
+		})
+	}
+}
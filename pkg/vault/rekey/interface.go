@@ -0,0 +1,6 @@
+package rekey
+
+// PasswordReader is the interface to read the vault passwords
+type PasswordReader interface {
+	Read() (string, error)
+}
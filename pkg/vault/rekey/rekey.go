@@ -0,0 +1,394 @@
+package rekey
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault/decrypt"
+	"github.com/apenella/go-ansible/v2/pkg/vault/encrypt"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// tempFilePattern is the pattern of the temporary files where the rekeyed content is written before replacing the original files
+	tempFilePattern = ".%s.rekey-*"
+	// backupFileSuffix is the suffix of the backup of the original files, kept until all the files are replaced
+	backupFileSuffix = ".rekey-backup"
+)
+
+// OptionsFunc is a function used to configure Rekeyer
+type OptionsFunc func(*Rekeyer)
+
+// Rekeyer re-encrypts with a new password all the vault encrypted files and !vault tagged values found on a directory
+type Rekeyer struct {
+	dryRun         bool
+	fs             afero.Fs
+	newReader      PasswordReader
+	newVaultID     string
+	oldDecryptOpts []decrypt.OptionsFunc
+	replaceVaultID bool
+	skipDirs       []string
+}
+
+// NewRekeyer returns a Rekeyer. The OS file system is used when fs is nil
+func NewRekeyer(fs afero.Fs, options ...OptionsFunc) *Rekeyer {
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+
+	rekeyer := &Rekeyer{
+		fs: fs,
+	}
+	rekeyer.Options(options...)
+
+	return rekeyer
+}
+
+// WithOldPasswordReader sets the reader of the current password, used regardless of the artifacts vault id label
+func WithOldPasswordReader(reader PasswordReader) OptionsFunc {
+	return func(r *Rekeyer) {
+		r.oldDecryptOpts = append(r.oldDecryptOpts, decrypt.WithReader(reader))
+	}
+}
+
+// WithOldVaultIDReader sets the reader of the current password of the vault id label
+func WithOldVaultIDReader(vaultID string, reader PasswordReader) OptionsFunc {
+	return func(r *Rekeyer) {
+		r.oldDecryptOpts = append(r.oldDecryptOpts, decrypt.WithVaultIDReader(vaultID, reader))
+	}
+}
+
+// WithNewPasswordReader sets the reader of the password used to re-encrypt the artifacts
+func WithNewPasswordReader(reader PasswordReader) OptionsFunc {
+	return func(r *Rekeyer) {
+		r.newReader = reader
+	}
+}
+
+// WithNewVaultID sets the vault id label of the re-encrypted artifacts. When it is not set, the artifacts keep their vault id label
+func WithNewVaultID(vaultID string) OptionsFunc {
+	return func(r *Rekeyer) {
+		r.newVaultID = vaultID
+		r.replaceVaultID = true
+	}
+}
+
+// WithDryRun re-encrypts and verifies the artifacts without writing any file
+func WithDryRun() OptionsFunc {
+	return func(r *Rekeyer) {
+		r.dryRun = true
+	}
+}
+
+// WithSkipDirs sets the directories that are not scanned. It replaces DefaultSkipDirs
+func WithSkipDirs(dirs ...string) OptionsFunc {
+	return func(r *Rekeyer) {
+		r.skipDirs = append(r.skipDirs, dirs...)
+	}
+}
+
+// Options configure the Rekeyer
+func (r *Rekeyer) Options(opts ...OptionsFunc) {
+	for _, opt := range opts {
+		opt(r)
+	}
+}
+
+// Scan returns the vault encrypted artifacts found on the directory
+func (r *Rekeyer) Scan(dir string) ([]*Artifact, error) {
+	if r == nil {
+		return nil, errors.New("Rekeyer must be initialized before scanning a directory")
+	}
+
+	return Scan(r.fs, dir, r.skipDirs...)
+}
+
+// Rekey re-encrypts all the vault encrypted artifacts found on the directory and returns them as they are after the rekey. Every rekeyed file is verified by decrypting it with the new password before any file is written. The files are replaced once all of them are verified and, when a file can not be replaced, the already replaced files are restored
+func (r *Rekeyer) Rekey(dir string) ([]*Artifact, error) {
+	if r == nil {
+		return nil, errors.New("Rekeyer must be initialized before rekeying a directory")
+	}
+
+	if r.newReader == nil {
+		return nil, errors.New("New password reader must be defined to rekey a directory")
+	}
+
+	if len(r.oldDecryptOpts) == 0 {
+		return nil, errors.New("Old password reader must be defined to rekey a directory")
+	}
+
+	artifacts, err := r.Scan(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error looking for vault encrypted artifacts")
+	}
+
+	files := []string{}
+	fileArtifacts := map[string][]*Artifact{}
+	for _, artifact := range artifacts {
+		if _, exists := fileArtifacts[artifact.File]; !exists {
+			files = append(files, artifact.File)
+		}
+		fileArtifacts[artifact.File] = append(fileArtifacts[artifact.File], artifact)
+	}
+
+	rekeyed := map[string][]byte{}
+	rekeyedArtifacts := []*Artifact{}
+	for _, file := range files {
+		content, err := afero.ReadFile(r.fs, file)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("Error reading the file '%s'.", file))
+		}
+
+		newContent, plainTexts, err := r.rekeyContent(content, fileArtifacts[file])
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("Error rekeying the file '%s'.", file))
+		}
+
+		newArtifacts, err := r.verify(file, newContent, plainTexts)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("Error verifying the rekeyed file '%s'.", file))
+		}
+
+		rekeyed[file] = newContent
+		rekeyedArtifacts = append(rekeyedArtifacts, newArtifacts...)
+	}
+
+	if r.dryRun {
+		return rekeyedArtifacts, nil
+	}
+
+	err = r.replaceFiles(files, rekeyed)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error replacing the rekeyed files")
+	}
+
+	return rekeyedArtifacts, nil
+}
+
+// rekeyContent re-encrypts the artifacts of the content. It returns the new content and the plain text of each artifact. The artifacts must be sorted by line
+func (r *Rekeyer) rekeyContent(content []byte, artifacts []*Artifact) ([]byte, []string, error) {
+	decrypter := decrypt.NewDecryptString(r.oldDecryptOpts...)
+	plainTexts := make([]string, len(artifacts))
+	cipherTexts := make([]string, len(artifacts))
+
+	for i, artifact := range artifacts {
+		cipherText := string(content)
+		if !artifact.IsFile() {
+			cipherText = artifact.node.Value
+		}
+
+		plainText, err := decrypter.Decrypt(cipherText)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, fmt.Sprintf("Error decrypting '%s'", artifact))
+		}
+
+		vaultID := artifact.VaultID
+		if r.replaceVaultID {
+			vaultID = r.newVaultID
+		}
+
+		newCipherText, err := encrypt.NewEncryptString(
+			encrypt.WithReader(r.newReader),
+			encrypt.WithVaultID(vaultID),
+		).Encrypt(plainText)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, fmt.Sprintf("Error encrypting '%s'", artifact))
+		}
+
+		plainTexts[i] = plainText
+		cipherTexts[i] = strings.TrimSpace(newCipherText)
+	}
+
+	if len(artifacts) == 1 && artifacts[0].IsFile() {
+		return []byte(cipherTexts[0] + "\n"), plainTexts, nil
+	}
+
+	lines := strings.Split(string(content), "\n")
+	// values are replaced from the bottom to the top, so the lines of the pending values do not move
+	for i := len(artifacts) - 1; i >= 0; i-- {
+		var err error
+
+		lines, err = replaceValue(lines, artifacts[i], cipherTexts[i])
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return []byte(strings.Join(lines, "\n")), plainTexts, nil
+}
+
+// replaceValue replaces the lines of the !vault tagged literal block of the artifact by the cipher text, keeping the block indentation
+func replaceValue(lines []string, artifact *Artifact, cipherText string) ([]string, error) {
+	if artifact.node.Style&yaml.LiteralStyle == 0 {
+		return nil, fmt.Errorf("vault encrypted value '%s' must be written as a literal block to be rekeyed", artifact)
+	}
+
+	valueLines := strings.Split(strings.TrimRight(artifact.node.Value, "\n"), "\n")
+	// the literal block starts on the line after the one where the value is tagged
+	start := artifact.node.Line
+	end := start + len(valueLines)
+	if start < 1 || end > len(lines) {
+		return nil, fmt.Errorf("vault encrypted value '%s' is out of the file lines", artifact)
+	}
+
+	for i, valueLine := range valueLines {
+		if strings.TrimSpace(lines[start+i]) != strings.TrimSpace(valueLine) {
+			return nil, fmt.Errorf("vault encrypted value '%s' does not match the file content on line %d", artifact, start+i+1)
+		}
+	}
+
+	line := lines[start]
+	indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+
+	newLines := []string{}
+	for _, cipherLine := range strings.Split(cipherText, "\n") {
+		newLines = append(newLines, indent+cipherLine)
+	}
+
+	result := append([]string{}, lines[:start]...)
+	result = append(result, newLines...)
+	result = append(result, lines[end:]...)
+
+	return result, nil
+}
+
+// verify checks that the rekeyed content has the same artifacts than the original one and that they are decrypted by the new password to the same plain texts. It returns the artifacts of the rekeyed content
+func (r *Rekeyer) verify(file string, content []byte, plainTexts []string) ([]*Artifact, error) {
+	artifacts, err := scanContent(file, content)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error scanning the rekeyed content")
+	}
+
+	if len(artifacts) != len(plainTexts) {
+		return nil, fmt.Errorf("rekeyed content has %d vault encrypted artifacts but %d were expected", len(artifacts), len(plainTexts))
+	}
+
+	decrypter := decrypt.NewDecryptString(decrypt.WithReader(r.newReader))
+	for i, artifact := range artifacts {
+		cipherText := string(content)
+		if !artifact.IsFile() {
+			cipherText = artifact.node.Value
+		}
+
+		plainText, err := decrypter.Decrypt(cipherText)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("Error decrypting the rekeyed '%s'", artifact))
+		}
+
+		if plainText != plainTexts[i] {
+			return nil, fmt.Errorf("rekeyed '%s' does not decrypt to its original value", artifact)
+		}
+	}
+
+	return artifacts, nil
+}
+
+// replaceFiles replaces the files by their rekeyed content. The content is written to temporary files on the same directory, which are renamed to the files once all of them are written. The original files are restored when any of them can not be replaced
+func (r *Rekeyer) replaceFiles(files []string, content map[string][]byte) (err error) {
+	tempFiles := map[string]string{}
+	backupFiles := map[string]string{}
+	replaced := []string{}
+
+	defer func() {
+		for _, tempFile := range tempFiles {
+			_ = r.fs.Remove(tempFile)
+		}
+
+		if err != nil {
+			err = r.rollback(replaced, backupFiles, err)
+			return
+		}
+
+		for _, backupFile := range backupFiles {
+			_ = r.fs.Remove(backupFile)
+		}
+	}()
+
+	for _, file := range files {
+		tempFile, err := r.writeTempFile(file, content[file])
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("Error writing the rekeyed content of '%s'", file))
+		}
+		tempFiles[file] = tempFile
+	}
+
+	for _, file := range files {
+		backupFile := file + backupFileSuffix
+		exists, _ := afero.Exists(r.fs, backupFile)
+		if exists {
+			return fmt.Errorf("backup file '%s' already exists", backupFile)
+		}
+
+		err = r.fs.Rename(file, backupFile)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("Error backing up the file '%s'", file))
+		}
+		backupFiles[file] = backupFile
+
+		err = r.fs.Rename(tempFiles[file], file)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("Error replacing the file '%s'", file))
+		}
+		delete(tempFiles, file)
+		replaced = append(replaced, file)
+	}
+
+	return nil
+}
+
+// writeTempFile writes the content to a temporary file, on the same directory and with the same mode than the file, and returns its path
+func (r *Rekeyer) writeTempFile(file string, content []byte) (string, error) {
+	info, err := r.fs.Stat(file)
+	if err != nil {
+		return "", err
+	}
+
+	tempFile, err := afero.TempFile(r.fs, filepath.Dir(file), fmt.Sprintf(tempFilePattern, filepath.Base(file)))
+	if err != nil {
+		return "", err
+	}
+
+	_, err = tempFile.Write(content)
+	closeErr := tempFile.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = r.fs.Chmod(tempFile.Name(), info.Mode().Perm())
+	}
+	if err != nil {
+		_ = r.fs.Remove(tempFile.Name())
+		return "", err
+	}
+
+	return tempFile.Name(), nil
+}
+
+// rollback restores the backup of the files. The files replaced by their rekeyed content are removed before restoring them
+func (r *Rekeyer) rollback(replaced []string, backupFiles map[string]string, cause error) error {
+	errs := []string{}
+
+	for _, file := range replaced {
+		err := r.fs.Remove(file)
+		if err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	for file, backupFile := range backupFiles {
+		err := r.fs.Rename(backupFile, file)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Wrap(cause, fmt.Sprintf("Error restoring the original files: %s", strings.Join(errs, ", ")))
+	}
+
+	return cause
+}
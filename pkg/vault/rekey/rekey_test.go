@@ -0,0 +1,249 @@
+package rekey
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault/decrypt"
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// failingRenameFs is an afero.Fs that fails the first time a temporary file is renamed to the file
+type failingRenameFs struct {
+	afero.Fs
+	file   string
+	failed bool
+}
+
+func (fs *failingRenameFs) Rename(oldname, newname string) error {
+	if newname == fs.file && !fs.failed && !strings.HasSuffix(oldname, backupFileSuffix) {
+		fs.failed = true
+		return fmt.Errorf("rename to '%s' failed", newname)
+	}
+
+	return fs.Fs.Rename(oldname, newname)
+}
+
+func newProject(t *testing.T) (afero.Fs, map[string]string) {
+	fs := afero.NewMemMapFs()
+	files := map[string]string{
+		"/project/group_vars/all/vault.yml": encryptValue(t, "db_password: secret", "old-password", "") + "\n",
+		"/project/group_vars/all/vars.yml": "---\n" +
+			"# application user\n" +
+			"user: admin\n" +
+			"password: !vault |\n" + indent(encryptValue(t, "secret", "old-password", "dev"), "  ") + "\n" +
+			"databases:\n" +
+			"  - name: main\n" +
+			"    password: !vault |\n" + indent(encryptValue(t, "main secret", "old-password", "prod"), "      ") + "\n" +
+			"# end of file\n",
+		"/project/playbook.yml": "- hosts: all\n",
+	}
+
+	for file, content := range files {
+		writeFile(t, fs, file, content)
+	}
+
+	return fs, files
+}
+
+func readFiles(t *testing.T, fs afero.Fs, dir string) map[string]string {
+	files := map[string]string{}
+	err := afero.Walk(fs, dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		content, err := afero.ReadFile(fs, path)
+		files[path] = string(content)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return files
+}
+
+func TestRekey(t *testing.T) {
+	oldPassword := text.NewReadPasswordFromText(text.WithText("old-password"))
+	newPassword := text.NewReadPasswordFromText(text.WithText("new-password"))
+
+	tests := []struct {
+		desc      string
+		options   []OptionsFunc
+		failOn    string
+		res       []string
+		unchanged bool
+		err       string
+	}{
+		{
+			desc: "Testing rekey a directory",
+			options: []OptionsFunc{
+				WithOldPasswordReader(oldPassword),
+				WithNewPasswordReader(newPassword),
+			},
+			res: []string{
+				"/project/group_vars/all/vars.yml:4 password (dev)",
+				"/project/group_vars/all/vars.yml:13 databases.0.password (prod)",
+				"/project/group_vars/all/vault.yml (default)",
+			},
+		},
+		{
+			desc: "Testing rekey a directory replacing the vault id",
+			options: []OptionsFunc{
+				WithOldVaultIDReader("dev", oldPassword),
+				WithOldVaultIDReader("prod", oldPassword),
+				WithOldVaultIDReader("default", oldPassword),
+				WithNewPasswordReader(newPassword),
+				WithNewVaultID("ops"),
+			},
+			res: []string{
+				"/project/group_vars/all/vars.yml:4 password (ops)",
+				"/project/group_vars/all/vars.yml:13 databases.0.password (ops)",
+				"/project/group_vars/all/vault.yml (ops)",
+			},
+		},
+		{
+			desc: "Testing rekey a directory in dry run mode",
+			options: []OptionsFunc{
+				WithOldPasswordReader(oldPassword),
+				WithNewPasswordReader(newPassword),
+				WithDryRun(),
+			},
+			res: []string{
+				"/project/group_vars/all/vars.yml:4 password (dev)",
+				"/project/group_vars/all/vars.yml:13 databases.0.password (prod)",
+				"/project/group_vars/all/vault.yml (default)",
+			},
+			unchanged: true,
+		},
+		{
+			desc: "Testing error rekeying a directory with a wrong old password",
+			options: []OptionsFunc{
+				WithOldPasswordReader(newPassword),
+				WithNewPasswordReader(newPassword),
+			},
+			unchanged: true,
+			err:       "Error rekeying the file '/project/group_vars/all/vars.yml'.: Error decrypting '/project/group_vars/all/vars.yml:4 password (dev)'",
+		},
+		{
+			desc: "Testing error rekeying a directory with an invalid new vault id",
+			options: []OptionsFunc{
+				WithOldPasswordReader(oldPassword),
+				WithNewPasswordReader(newPassword),
+				WithNewVaultID("in valid"),
+			},
+			unchanged: true,
+			err:       "Invalid vault id",
+		},
+		{
+			desc: "Testing rollback when a file can not be replaced",
+			options: []OptionsFunc{
+				WithOldPasswordReader(oldPassword),
+				WithNewPasswordReader(newPassword),
+			},
+			failOn:    "/project/group_vars/all/vault.yml",
+			unchanged: true,
+			err:       "Error replacing the rekeyed files: Error replacing the file '/project/group_vars/all/vault.yml'",
+		},
+		{
+			desc: "Testing error rekeying without the new password reader",
+			options: []OptionsFunc{
+				WithOldPasswordReader(oldPassword),
+			},
+			unchanged: true,
+			err:       "New password reader must be defined to rekey a directory",
+		},
+		{
+			desc: "Testing error rekeying without the old password reader",
+			options: []OptionsFunc{
+				WithNewPasswordReader(newPassword),
+			},
+			unchanged: true,
+			err:       "Old password reader must be defined to rekey a directory",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			memFs, files := newProject(t)
+			var fs afero.Fs = memFs
+			if test.failOn != "" {
+				fs = &failingRenameFs{Fs: memFs, file: test.failOn}
+			}
+
+			artifacts, err := NewRekeyer(fs, test.options...).Rekey("/project")
+
+			after := readFiles(t, memFs, "/project")
+			if test.unchanged {
+				assert.Equal(t, files, after)
+			}
+
+			if err != nil {
+				assert.Contains(t, err.Error(), test.err)
+				return
+			}
+
+			assert.Empty(t, test.err)
+			res := []string{}
+			for _, artifact := range artifacts {
+				res = append(res, artifact.String())
+			}
+			assert.Equal(t, test.res, res)
+
+			if test.unchanged {
+				return
+			}
+
+			paths := []string{}
+			for path := range after {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+			assert.Equal(t, []string{"/project/group_vars/all/vars.yml", "/project/group_vars/all/vault.yml", "/project/playbook.yml"}, paths)
+			assert.Equal(t, files["/project/playbook.yml"], after["/project/playbook.yml"])
+
+			info, err := memFs.Stat("/project/group_vars/all/vars.yml")
+			assert.NoError(t, err)
+			assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+
+			vars := after["/project/group_vars/all/vars.yml"]
+			assert.True(t, strings.HasPrefix(vars, "---\n# application user\nuser: admin\npassword: !vault |\n  $ANSIBLE_VAULT;"))
+			assert.True(t, strings.HasSuffix(vars, "\n# end of file\n"))
+
+			decrypter := decrypt.NewDecryptString(decrypt.WithReader(newPassword))
+			plainText, err := decrypter.Decrypt(after["/project/group_vars/all/vault.yml"])
+			assert.NoError(t, err)
+			assert.Equal(t, "db_password: secret", plainText)
+
+			for _, artifact := range artifacts {
+				if artifact.IsFile() {
+					continue
+				}
+				_, err = decrypter.Decrypt(artifact.node.Value)
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRekeyNonLiteralValue(t *testing.T) {
+	t.Log("Testing error rekeying a vault encrypted value that is not a literal block")
+
+	fs := afero.NewMemMapFs()
+	cipherText := strings.ReplaceAll(strings.TrimSpace(encryptValue(t, "secret", "old-password", "")), "\n", "\\n")
+	writeFile(t, fs, "/project/vars.yml", fmt.Sprintf("password: !vault \"%s\"\n", cipherText))
+
+	_, err := NewRekeyer(fs,
+		WithOldPasswordReader(text.NewReadPasswordFromText(text.WithText("old-password"))),
+		WithNewPasswordReader(text.NewReadPasswordFromText(text.WithText("new-password"))),
+	).Rekey("/project")
+	assert.Contains(t, err.Error(), "must be written as a literal block to be rekeyed")
+}
@@ -0,0 +1,244 @@
+package rekey
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault"
+	"github.com/apenella/go-ansible/v2/pkg/vault/encrypt"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultSkipDirs are the directories that are not scanned by default
+var DefaultSkipDirs = []string{".git"}
+
+// yamlExtensions are the extensions of the files that must be valid YAML documents when they contain vault encrypted values
+var yamlExtensions = []string{".yml", ".yaml"}
+
+// Artifact is a vault encrypted content found on a file. It is either the whole file or a !vault tagged value of a YAML file
+type Artifact struct {
+	// File is the path of the file that contains the artifact
+	File string
+	// KeyPath is the path of the keys, and sequence indexes, to the vault encrypted value. It is empty when the whole file is encrypted
+	KeyPath []string
+	// Line is the line where the vault encrypted content starts
+	Line int
+	// VaultID is the vault id label of the artifact
+	VaultID string
+
+	// node is the YAML node of the vault encrypted value
+	node *yaml.Node
+}
+
+// IsFile returns whether the artifact is a whole encrypted file
+func (a *Artifact) IsFile() bool {
+	return len(a.KeyPath) == 0
+}
+
+// Key returns the key path joined by dots
+func (a *Artifact) Key() string {
+	return strings.Join(a.KeyPath, ".")
+}
+
+// String returns the artifact location
+func (a *Artifact) String() string {
+	if a.IsFile() {
+		return fmt.Sprintf("%s (%s)", a.File, a.VaultID)
+	}
+
+	return fmt.Sprintf("%s:%d %s (%s)", a.File, a.Line, a.Key(), a.VaultID)
+}
+
+// Scan walks the directory on the file system and returns the vault encrypted artifacts found, sorted by file and line. The skipDirs directories are not scanned and, when none is provided, DefaultSkipDirs are used
+func Scan(fs afero.Fs, dir string, skipDirs ...string) ([]*Artifact, error) {
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+
+	if len(skipDirs) == 0 {
+		skipDirs = DefaultSkipDirs
+	}
+
+	artifacts := []*Artifact{}
+
+	err := afero.Walk(fs, dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if path != dir && contains(skipDirs, info.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		content, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("Error reading the file '%s'.", path))
+		}
+
+		fileArtifacts, err := scanContent(path, content)
+		if err != nil {
+			return err
+		}
+		artifacts = append(artifacts, fileArtifacts...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("Error scanning the directory '%s'.", dir))
+	}
+
+	sort.SliceStable(artifacts, func(i, j int) bool {
+		if artifacts[i].File != artifacts[j].File {
+			return artifacts[i].File < artifacts[j].File
+		}
+		return artifacts[i].Line < artifacts[j].Line
+	})
+
+	return artifacts, nil
+}
+
+// scanContent returns the vault encrypted artifacts of the file content
+func scanContent(file string, content []byte) ([]*Artifact, error) {
+
+	// binary files are not scanned
+	if bytes.IndexByte(content, 0) >= 0 {
+		return nil, nil
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(content), []byte(encrypt.VaultHeaderPrefix+";")) {
+		firstLine, _, _ := strings.Cut(strings.TrimSpace(string(content)), "\n")
+		header, err := encrypt.ParseVaultHeader(firstLine)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("Error parsing the vault encrypted file '%s'.", file))
+		}
+
+		return []*Artifact{
+			{
+				File:    file,
+				Line:    1,
+				VaultID: header.Label(),
+			},
+		}, nil
+	}
+
+	if !bytes.Contains(content, []byte(vault.YAMLVaultTag)) {
+		return nil, nil
+	}
+
+	documents, err := parseYAML(content)
+	if err != nil {
+		// only the files with YAML extension must be valid YAML documents, the rest of files could be templates or any other content that mentions the vault tag
+		if contains(yamlExtensions, strings.ToLower(filepath.Ext(file))) {
+			return nil, errors.Wrap(err, fmt.Sprintf("Error parsing the YAML file '%s'.", file))
+		}
+		return nil, nil
+	}
+
+	artifacts := []*Artifact{}
+	for _, document := range documents {
+		err = collectArtifacts(file, document, []string{}, &artifacts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return artifacts, nil
+}
+
+// parseYAML returns the documents of a YAML content
+func parseYAML(content []byte) ([]*yaml.Node, error) {
+	documents := []*yaml.Node{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+
+	for {
+		document := &yaml.Node{}
+		err := decoder.Decode(document)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+
+	return documents, nil
+}
+
+// collectArtifacts appends to artifacts the !vault tagged values found under the node
+func collectArtifacts(file string, node *yaml.Node, keyPath []string, artifacts *[]*Artifact) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			err := collectArtifacts(file, child, keyPath, artifacts)
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			err := collectArtifacts(file, node.Content[i+1], appendKey(keyPath, node.Content[i].Value), artifacts)
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			err := collectArtifacts(file, child, appendKey(keyPath, strconv.Itoa(i)), artifacts)
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		if node.Tag != vault.YAMLVaultTag {
+			return nil
+		}
+
+		firstLine, _, _ := strings.Cut(strings.TrimSpace(node.Value), "\n")
+		header, err := encrypt.ParseVaultHeader(firstLine)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("Error parsing the vault encrypted value '%s' on '%s'.", strings.Join(keyPath, "."), file))
+		}
+
+		*artifacts = append(*artifacts, &Artifact{
+			File:    file,
+			KeyPath: keyPath,
+			Line:    node.Line,
+			VaultID: header.Label(),
+			node:    node,
+		})
+	}
+
+	return nil
+}
+
+// appendKey returns a copy of keyPath with the key appended
+func appendKey(keyPath []string, key string) []string {
+	return append(append([]string{}, keyPath...), key)
+}
+
+// contains returns whether the item is in the list
+func contains(list []string, item string) bool {
+	for _, element := range list {
+		if element == item {
+			return true
+		}
+	}
+
+	return false
+}
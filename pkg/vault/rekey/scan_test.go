@@ -0,0 +1,147 @@
+package rekey
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault/encrypt"
+	"github.com/apenella/go-ansible/v2/pkg/vault/password/text"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func encryptValue(t *testing.T, plainText, password, vaultID string) string {
+	cipherText, err := encrypt.NewEncryptString(
+		encrypt.WithReader(text.NewReadPasswordFromText(text.WithText(password))),
+		encrypt.WithVaultID(vaultID),
+	).Encrypt(plainText)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cipherText
+}
+
+func indent(cipherText, prefix string) string {
+	lines := strings.Split(strings.TrimSpace(cipherText), "\n")
+	for i := range lines {
+		lines[i] = prefix + lines[i]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func writeFile(t *testing.T, fs afero.Fs, file, content string) {
+	err := afero.WriteFile(fs, file, []byte(content), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScan(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeFile(t, fs, "/project/group_vars/all/vault.yml", encryptValue(t, "db_password: secret", "password", "")+"\n")
+	writeFile(t, fs, "/project/group_vars/all/vars.yml", "---\n"+
+		"user: admin\n"+
+		"password: !vault |\n"+indent(encryptValue(t, "secret", "password", "dev"), "  ")+"\n"+
+		"databases:\n"+
+		"  - name: main\n"+
+		"    password: !vault |\n"+indent(encryptValue(t, "main secret", "password", "prod"), "      ")+"\n")
+	writeFile(t, fs, "/project/templates/vars.j2", "password: !vault {{ password }}\n")
+	writeFile(t, fs, "/project/playbook.yml", "- hosts: all\n")
+	writeFile(t, fs, "/project/.git/vault.yml", encryptValue(t, "ignored", "password", "")+"\n")
+
+	tests := []struct {
+		desc     string
+		fs       afero.Fs
+		dir      string
+		skipDirs []string
+		res      []string
+		err      string
+	}{
+		{
+			desc: "Testing scan a directory",
+			fs:   fs,
+			dir:  "/project",
+			res: []string{
+				"/project/group_vars/all/vars.yml:3 password (dev)",
+				"/project/group_vars/all/vars.yml:12 databases.0.password (prod)",
+				"/project/group_vars/all/vault.yml (default)",
+			},
+		},
+		{
+			desc:     "Testing scan a directory skipping directories",
+			fs:       fs,
+			dir:      "/project",
+			skipDirs: []string{"group_vars"},
+			res: []string{
+				"/project/.git/vault.yml (default)",
+			},
+		},
+		{
+			desc: "Testing error scanning an invalid YAML file",
+			fs: func() afero.Fs {
+				fs := afero.NewMemMapFs()
+				writeFile(t, fs, "/project/vars.yml", "password: !vault [\n")
+				return fs
+			}(),
+			dir: "/project",
+			err: "Error scanning the directory '/project'.: Error parsing the YAML file '/project/vars.yml'.",
+		},
+		{
+			desc: "Testing error scanning a directory that does not exist",
+			fs:   afero.NewMemMapFs(),
+			dir:  "/unknown",
+			err:  "Error scanning the directory '/unknown'.",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			artifacts, err := Scan(test.fs, test.dir, test.skipDirs...)
+			if err != nil {
+				assert.Contains(t, err.Error(), test.err)
+				return
+			}
+
+			assert.Empty(t, test.err)
+			res := []string{}
+			for _, artifact := range artifacts {
+				res = append(res, artifact.String())
+			}
+			assert.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestArtifactKey(t *testing.T) {
+	tests := []struct {
+		desc     string
+		artifact *Artifact
+		res      string
+		isFile   bool
+	}{
+		{
+			desc:     "Testing the key of a vault encrypted file",
+			artifact: &Artifact{File: "vault.yml"},
+			res:      "",
+			isFile:   true,
+		},
+		{
+			desc:     "Testing the key of a vault encrypted value",
+			artifact: &Artifact{File: "vars.yml", KeyPath: []string{"databases", "0", "password"}},
+			res:      "databases.0.password",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			assert.Equal(t, test.res, test.artifact.Key())
+			assert.Equal(t, test.isFile, test.artifact.IsFile())
+		})
+	}
+}
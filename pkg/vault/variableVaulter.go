@@ -8,6 +8,7 @@ import (
 type OptionsFunc func(*VariableVaulter)
 
 type VariableVaulter struct {
+	decrypt Decrypter
 	encrypt Encrypter
 }
 
@@ -24,6 +25,13 @@ func WithEncrypt(e Encrypter) OptionsFunc {
 	}
 }
 
+// WithDecrypt sets the Decrypter used to unvault the variables
+func WithDecrypt(d Decrypter) OptionsFunc {
+	return func(v *VariableVaulter) {
+		v.decrypt = d
+	}
+}
+
 func (v *VariableVaulter) Options(opts ...OptionsFunc) {
 	for _, opt := range opts {
 		opt(v)
@@ -55,3 +63,30 @@ func (v *VariableVaulter) Vault(value string) (*VaultVariableValue, error) {
 
 	return VariableVaulterValue, nil
 }
+
+// Unvault returns the decrypted value of a vaulted variable
+func (v *VariableVaulter) Unvault(value *VaultVariableValue) (string, error) {
+	if v == nil {
+		return "", errors.New("VariableVaulter must be initialized before unvaulting a variable.")
+	}
+
+	if v.decrypt == nil {
+		return "", errors.New("Decrypter must be provided to decrypt a variable.")
+	}
+
+	if value == nil {
+		return "", errors.New("Vaulted variable must be provided to decrypt it.")
+	}
+
+	encryptedValue, isString := value.Value.(string)
+	if !isString {
+		return "", errors.New("Vaulted variable value must be a string.")
+	}
+
+	decryptedValue, err := v.decrypt.Decrypt(encryptedValue)
+	if err != nil {
+		return "", errors.Wrap(err, "Error decrypting variable value.")
+	}
+
+	return decryptedValue, nil
+}
@@ -91,3 +91,56 @@ func TestVaultIntegration(t *testing.T) {
 	// assert
 	assert.Regexp(t, regexp.MustCompile(expectedRegexp), VaultedVariableJSONString)
 }
+
+func TestUnvault(t *testing.T) {
+
+	decrypter := NewMockDecrypter()
+	decrypter.On("Decrypt", "encrypted_value").Return("Secret text", nil)
+
+	tests := []struct {
+		desc     string
+		vaulter  *VariableVaulter
+		value    *VaultVariableValue
+		expected string
+		err      error
+	}{
+		{
+			desc:     "Testing unvaulting a variable",
+			vaulter:  NewVariableVaulter(WithDecrypt(decrypter)),
+			value:    NewVaultVariableValue("encrypted_value"),
+			expected: "Secret text",
+		},
+		{
+			desc:    "Testing error unvaulting a variable when the VariableVaulter is not initialized",
+			vaulter: nil,
+			value:   NewVaultVariableValue("encrypted_value"),
+			err:     errors.New("VariableVaulter must be initialized before unvaulting a variable."),
+		},
+		{
+			desc:    "Testing error unvaulting a variable when the Decrypter is not initialized",
+			vaulter: NewVariableVaulter(),
+			value:   NewVaultVariableValue("encrypted_value"),
+			err:     errors.New("Decrypter must be provided to decrypt a variable."),
+		},
+		{
+			desc:    "Testing error unvaulting a variable that is not a string",
+			vaulter: NewVariableVaulter(WithDecrypt(decrypter)),
+			value:   NewVaultVariableValue(10),
+			err:     errors.New("Vaulted variable value must be a string."),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := test.vaulter.Unvault(test.value)
+			if test.err != nil {
+				assert.EqualError(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,439 @@
+package vault
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// YAMLVaultTag is the YAML tag of the vault encrypted values
+	YAMLVaultTag = "!vault"
+
+	// yamlDocumentStart is the YAML document start marker
+	yamlDocumentStart = "---"
+	// yamlIndent is the indentation used to write the variables files
+	yamlIndent = 2
+	// yamlStrTag is the YAML tag of the string values
+	yamlStrTag = "!!str"
+)
+
+// VarsFileOptionsFunc is a function used to configure VarsFile
+type VarsFileOptionsFunc func(*VarsFile)
+
+// VarsFile is a YAML variables file, such as the group_vars and host_vars files, that mixes plain values with values tagged as !vault. The vault encrypted values are decrypted when they are read. The file keeps the comments and the variables order when it is written back
+type VarsFile struct {
+	decrypt       Decrypter
+	encrypt       Encrypter
+	explicitStart bool
+	root          *yaml.Node
+}
+
+// NewVarsFile returns an empty VarsFile
+func NewVarsFile(options ...VarsFileOptionsFunc) *VarsFile {
+	varsFile := &VarsFile{
+		explicitStart: true,
+		root: &yaml.Node{
+			Kind: yaml.MappingNode,
+			Tag:  "!!map",
+		},
+	}
+
+	for _, option := range options {
+		option(varsFile)
+	}
+
+	return varsFile
+}
+
+// WithVarsFileDecrypter sets the Decrypter used to decrypt the vault encrypted values
+func WithVarsFileDecrypter(d Decrypter) VarsFileOptionsFunc {
+	return func(f *VarsFile) {
+		f.decrypt = d
+	}
+}
+
+// WithVarsFileEncrypter sets the Encrypter used to encrypt the values set as vaulted
+func WithVarsFileEncrypter(e Encrypter) VarsFileOptionsFunc {
+	return func(f *VarsFile) {
+		f.encrypt = e
+	}
+}
+
+// ParseVarsFile returns the VarsFile read from reader
+func ParseVarsFile(reader io.Reader, options ...VarsFileOptionsFunc) (*VarsFile, error) {
+	varsFile := NewVarsFile(options...)
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading the variables file.")
+	}
+
+	varsFile.explicitStart = strings.HasPrefix(strings.TrimSpace(string(content)), yamlDocumentStart)
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+
+	document := &yaml.Node{}
+	err = decoder.Decode(document)
+	// an empty file does not define any document
+	if err == io.EOF {
+		return varsFile, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing the variables file.")
+	}
+
+	// the variables of any other document would be lost when the file is written
+	err = decoder.Decode(&yaml.Node{})
+	if err != io.EOF {
+		return nil, errors.New("Variables file must contain a single YAML document.")
+	}
+
+	if len(document.Content) == 0 {
+		return varsFile, nil
+	}
+
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		if root.Kind == yaml.ScalarNode && root.Tag == "!!null" {
+			return varsFile, nil
+		}
+		return nil, errors.New("Variables file must be a YAML mapping.")
+	}
+
+	root.HeadComment = joinComments(document.HeadComment, root.HeadComment)
+	root.FootComment = joinComments(root.FootComment, document.FootComment)
+	varsFile.root = root
+
+	return varsFile, nil
+}
+
+// ReadVarsFile returns the VarsFile read from the file
+func ReadVarsFile(file string, options ...VarsFileOptionsFunc) (*VarsFile, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("Error opening the variables file '%s'.", file))
+	}
+	defer f.Close()
+
+	return ParseVarsFile(f, options...)
+}
+
+// Keys returns the variables names in the file order
+func (f *VarsFile) Keys() []string {
+	keys := []string{}
+	for i := 0; i+1 < len(f.root.Content); i += 2 {
+		keys = append(keys, f.root.Content[i].Value)
+	}
+
+	return keys
+}
+
+// Has returns whether the variable is defined
+func (f *VarsFile) Has(key string) bool {
+	return f.index(key) >= 0
+}
+
+// IsVaulted returns whether the variable value is vault encrypted
+func (f *VarsFile) IsVaulted(key string) bool {
+	index := f.index(key)
+	if index < 0 {
+		return false
+	}
+
+	return f.root.Content[index+1].Tag == YAMLVaultTag
+}
+
+// CipherText returns the vault encrypted value of the variable, without decrypting it
+func (f *VarsFile) CipherText(key string) (string, error) {
+	index := f.index(key)
+	if index < 0 {
+		return "", errors.New(fmt.Sprintf("Variable '%s' is not defined.", key))
+	}
+
+	value := f.root.Content[index+1]
+	if value.Tag != YAMLVaultTag {
+		return "", errors.New(fmt.Sprintf("Variable '%s' is not vault encrypted.", key))
+	}
+
+	return value.Value, nil
+}
+
+// Get returns the variable value. The vault encrypted values it contains are decrypted
+func (f *VarsFile) Get(key string) (interface{}, error) {
+	var value interface{}
+
+	index := f.index(key)
+	if index < 0 {
+		return nil, errors.New(fmt.Sprintf("Variable '%s' is not defined.", key))
+	}
+
+	err := f.decodeNode(f.root.Content[index+1], &value)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("Error reading variable '%s'.", key))
+	}
+
+	return value, nil
+}
+
+// Decode decodes all the variables into out, such as a map or a struct. The vault encrypted values are decrypted
+func (f *VarsFile) Decode(out interface{}) error {
+	err := f.decodeNode(f.root, out)
+	if err != nil {
+		return errors.Wrap(err, "Error decoding the variables.")
+	}
+
+	return nil
+}
+
+// Set sets the variable value. A variable that already exists keeps its position and comments, and a new one is added at the end
+func (f *VarsFile) Set(key string, value interface{}) error {
+	node := &yaml.Node{}
+
+	err := node.Encode(value)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Error encoding variable '%s'.", key))
+	}
+
+	f.setNode(key, node)
+
+	return nil
+}
+
+// SetVaulted encrypts the value and sets it as a !vault tagged variable
+func (f *VarsFile) SetVaulted(key string, value string) error {
+	if f.encrypt == nil {
+		return errors.New("Encrypter must be provided to encrypt a variable.")
+	}
+
+	cipherText, err := f.encrypt.Encrypt(value)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Error encrypting variable '%s'.", key))
+	}
+
+	if !strings.HasSuffix(cipherText, "\n") {
+		cipherText = cipherText + "\n"
+	}
+
+	f.setNode(key, &yaml.Node{
+		Kind:  yaml.ScalarNode,
+		Tag:   YAMLVaultTag,
+		Style: yaml.LiteralStyle,
+		Value: cipherText,
+	})
+
+	return nil
+}
+
+// SetVars sets the variables, encrypting the values of the vaultKeys. The new variables are added sorted by name
+func (f *VarsFile) SetVars(vars map[string]interface{}, vaultKeys ...string) error {
+	keys := make([]string, 0, len(vars))
+	for key := range vars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	vaulted := map[string]struct{}{}
+	for _, key := range vaultKeys {
+		_, exists := vars[key]
+		if !exists {
+			return errors.New(fmt.Sprintf("Variable '%s' can not be vaulted because it is not defined.", key))
+		}
+		vaulted[key] = struct{}{}
+	}
+
+	for _, key := range keys {
+		var err error
+
+		_, isVaulted := vaulted[key]
+		if isVaulted {
+			value, isString := vars[key].(string)
+			if !isString {
+				return errors.New(fmt.Sprintf("Variable '%s' can not be vaulted because it is not a string.", key))
+			}
+			err = f.SetVaulted(key, value)
+		} else {
+			err = f.Set(key, vars[key])
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete removes the variable and returns whether it was defined
+func (f *VarsFile) Delete(key string) bool {
+	index := f.index(key)
+	if index < 0 {
+		return false
+	}
+
+	f.root.Content = append(f.root.Content[:index], f.root.Content[index+2:]...)
+
+	return true
+}
+
+// Write writes the variables file as YAML
+func (f *VarsFile) Write(writer io.Writer) error {
+	content := &bytes.Buffer{}
+
+	if f.explicitStart {
+		content.WriteString(yamlDocumentStart + "\n")
+	}
+
+	if len(f.root.Content) > 0 || f.root.HeadComment != "" || f.root.FootComment != "" {
+		encoder := yaml.NewEncoder(content)
+		encoder.SetIndent(yamlIndent)
+
+		err := encoder.Encode(f.root)
+		if err != nil {
+			return errors.Wrap(err, "Error encoding the variables file.")
+		}
+
+		err = encoder.Close()
+		if err != nil {
+			return errors.Wrap(err, "Error encoding the variables file.")
+		}
+	}
+
+	_, err := writer.Write(content.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "Error writing the variables file.")
+	}
+
+	return nil
+}
+
+// WriteFile writes the variables file. The file is created when it does not exist and truncated otherwise
+func (f *VarsFile) WriteFile(file string) error {
+	out, err := os.Create(file)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Error creating the variables file '%s'.", file))
+	}
+	defer out.Close()
+
+	return f.Write(out)
+}
+
+// index returns the position of the variable key node on the root content, or -1 when it is not defined
+func (f *VarsFile) index(key string) int {
+	for i := 0; i+1 < len(f.root.Content); i += 2 {
+		if f.root.Content[i].Value == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// setNode sets the variable value node, keeping the comments of the value it replaces
+func (f *VarsFile) setNode(key string, node *yaml.Node) {
+	index := f.index(key)
+	if index < 0 {
+		// the comments at the end of the file are attached to the last variable, so they are moved to the end of the file to keep them after the new variable
+		if len(f.root.Content) >= 2 {
+			last := f.root.Content[len(f.root.Content)-2:]
+			f.root.FootComment = joinComments(last[0].FootComment, last[1].FootComment, f.root.FootComment)
+			last[0].FootComment = ""
+			last[1].FootComment = ""
+		}
+
+		f.root.Content = append(f.root.Content,
+			&yaml.Node{
+				Kind:  yaml.ScalarNode,
+				Tag:   yamlStrTag,
+				Value: key,
+			},
+			node,
+		)
+		return
+	}
+
+	previous := f.root.Content[index+1]
+	node.HeadComment = previous.HeadComment
+	node.LineComment = previous.LineComment
+	node.FootComment = previous.FootComment
+	f.root.Content[index+1] = node
+}
+
+// decodeNode decodes node into out, decrypting the vault encrypted values it contains
+func (f *VarsFile) decodeNode(node *yaml.Node, out interface{}) error {
+	decrypted, err := f.decryptNode(node, map[*yaml.Node]*yaml.Node{})
+	if err != nil {
+		return err
+	}
+
+	return decrypted.Decode(out)
+}
+
+// decryptNode returns a copy of node where the vault encrypted values are replaced by their decrypted value. The copies are tracked on clones to keep the aliases pointing to the copied anchors
+func (f *VarsFile) decryptNode(node *yaml.Node, clones map[*yaml.Node]*yaml.Node) (*yaml.Node, error) {
+	clone, isCloned := clones[node]
+	if isCloned {
+		return clone, nil
+	}
+
+	if node.Kind == yaml.ScalarNode && node.Tag == YAMLVaultTag {
+		if f.decrypt == nil {
+			return nil, errors.New("Decrypter must be provided to decrypt a variable.")
+		}
+
+		value, err := f.decrypt.Decrypt(node.Value)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error decrypting vault encrypted value.")
+		}
+
+		clone = &yaml.Node{
+			Kind:  yaml.ScalarNode,
+			Tag:   yamlStrTag,
+			Value: value,
+		}
+		clones[node] = clone
+
+		return clone, nil
+	}
+
+	copied := *node
+	clone = &copied
+	clones[node] = clone
+
+	if node.Alias != nil {
+		alias, err := f.decryptNode(node.Alias, clones)
+		if err != nil {
+			return nil, err
+		}
+		clone.Alias = alias
+	}
+
+	clone.Content = make([]*yaml.Node, 0, len(node.Content))
+	for _, child := range node.Content {
+		childClone, err := f.decryptNode(child, clones)
+		if err != nil {
+			return nil, err
+		}
+		clone.Content = append(clone.Content, childClone)
+	}
+
+	return clone, nil
+}
+
+// joinComments joins the non empty comments
+func joinComments(comments ...string) string {
+	nonEmpty := []string{}
+	for _, comment := range comments {
+		if comment != "" {
+			nonEmpty = append(nonEmpty, comment)
+		}
+	}
+
+	return strings.Join(nonEmpty, "\n\n")
+}
@@ -0,0 +1,305 @@
+package vault
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apenella/go-ansible/v2/pkg/vault/encrypt"
+	"github.com/stretchr/testify/assert"
+)
+
+const varsFileContent = `---
+# Database settings
+db_user: admin # the database user
+db_password: !vault |
+  $ANSIBLE_VAULT;1.2;AES256;prod
+  3836
+ports:
+  - 80
+  - 443
+nested:
+  token: !vault |
+    $ANSIBLE_VAULT;1.1;AES256
+    3937
+# end of file
+`
+
+func newTestDecrypter() *MockDecrypter {
+	decrypter := NewMockDecrypter()
+	decrypter.On("Decrypt", "$ANSIBLE_VAULT;1.2;AES256;prod\n3836\n").Return("s3cr3t", nil)
+	decrypter.On("Decrypt", "$ANSIBLE_VAULT;1.1;AES256\n3937\n").Return("t0k3n", nil)
+
+	return decrypter
+}
+
+func TestParseVarsFile(t *testing.T) {
+	tests := []struct {
+		desc    string
+		content string
+		keys    []string
+		err     string
+	}{
+		{
+			desc:    "Testing parse a variables file",
+			content: varsFileContent,
+			keys:    []string{"db_user", "db_password", "ports", "nested"},
+		},
+		{
+			desc:    "Testing parse an empty variables file",
+			content: "",
+			keys:    []string{},
+		},
+		{
+			desc:    "Testing parse a variables file with an empty document",
+			content: "---\n",
+			keys:    []string{},
+		},
+		{
+			desc:    "Testing error parsing a variables file that is not a mapping",
+			content: "- item\n",
+			err:     "Variables file must be a YAML mapping.",
+		},
+		{
+			desc:    "Testing error parsing a variables file with several documents",
+			content: "---\ndb_user: admin\n---\ndb_password: secret\n",
+			err:     "Variables file must contain a single YAML document.",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := ParseVarsFile(strings.NewReader(test.content))
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.keys, res.Keys())
+			}
+		})
+	}
+}
+
+func TestVarsFileGet(t *testing.T) {
+	varsFile, err := ParseVarsFile(strings.NewReader(varsFileContent), WithVarsFileDecrypter(newTestDecrypter()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc string
+		key  string
+		res  interface{}
+		err  string
+	}{
+		{
+			desc: "Testing get a plain variable",
+			key:  "db_user",
+			res:  "admin",
+		},
+		{
+			desc: "Testing get a vault encrypted variable",
+			key:  "db_password",
+			res:  "s3cr3t",
+		},
+		{
+			desc: "Testing get a variable with nested vault encrypted values",
+			key:  "nested",
+			res:  map[string]interface{}{"token": "t0k3n"},
+		},
+		{
+			desc: "Testing error getting an undefined variable",
+			key:  "undefined",
+			err:  "Variable 'undefined' is not defined.",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			res, err := varsFile.Get(test.key)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestVarsFileGetWithoutDecrypter(t *testing.T) {
+	varsFile, err := ParseVarsFile(strings.NewReader(varsFileContent))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := varsFile.Get("db_user")
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", value)
+
+	_, err = varsFile.Get("db_password")
+	assert.EqualError(t, err, "Error reading variable 'db_password'.: Decrypter must be provided to decrypt a variable.")
+
+	assert.True(t, varsFile.IsVaulted("db_password"))
+	assert.False(t, varsFile.IsVaulted("db_user"))
+
+	cipherText, err := varsFile.CipherText("db_password")
+	assert.NoError(t, err)
+	assert.Equal(t, "$ANSIBLE_VAULT;1.2;AES256;prod\n3836\n", cipherText)
+
+	_, err = varsFile.CipherText("db_user")
+	assert.EqualError(t, err, "Variable 'db_user' is not vault encrypted.")
+}
+
+func TestVarsFileDecode(t *testing.T) {
+	varsFile, err := ParseVarsFile(strings.NewReader(varsFileContent+"alias: &anchor\n  secret: !vault |\n    $ANSIBLE_VAULT;1.1;AES256\n    3937\nreference: *anchor\n"), WithVarsFileDecrypter(newTestDecrypter()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vars := struct {
+		DBUser     string            `yaml:"db_user"`
+		DBPassword string            `yaml:"db_password"`
+		Ports      []int             `yaml:"ports"`
+		Nested     map[string]string `yaml:"nested"`
+		Reference  map[string]string `yaml:"reference"`
+	}{}
+
+	err = varsFile.Decode(&vars)
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", vars.DBUser)
+	assert.Equal(t, "s3cr3t", vars.DBPassword)
+	assert.Equal(t, []int{80, 443}, vars.Ports)
+	assert.Equal(t, map[string]string{"token": "t0k3n"}, vars.Nested)
+	assert.Equal(t, map[string]string{"secret": "t0k3n"}, vars.Reference)
+}
+
+func TestVarsFileWrite(t *testing.T) {
+	encrypter := encrypt.NewMockEncryptString()
+	encrypter.On("Encrypt", "n3w").Return("$ANSIBLE_VAULT;1.1;AES256\n6162", nil)
+	encrypter.On("Encrypt", "k3y").Return("$ANSIBLE_VAULT;1.1;AES256\n6364", nil)
+
+	varsFile, err := ParseVarsFile(strings.NewReader(varsFileContent), WithVarsFileEncrypter(encrypter))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = varsFile.SetVaulted("db_password", "n3w")
+	assert.NoError(t, err)
+
+	err = varsFile.Set("db_user", "root")
+	assert.NoError(t, err)
+
+	assert.True(t, varsFile.Delete("ports"))
+	assert.False(t, varsFile.Delete("ports"))
+
+	err = varsFile.SetVars(map[string]interface{}{
+		"timeout": 30,
+		"api_key": "k3y",
+	}, "api_key")
+	assert.NoError(t, err)
+
+	expect := `---
+# Database settings
+db_user: root # the database user
+db_password: !vault |
+  $ANSIBLE_VAULT;1.1;AES256
+  6162
+nested:
+  token: !vault |
+    $ANSIBLE_VAULT;1.1;AES256
+    3937
+api_key: !vault |
+  $ANSIBLE_VAULT;1.1;AES256
+  6364
+timeout: 30
+
+# end of file
+`
+
+	buff := &bytes.Buffer{}
+	err = varsFile.Write(buff)
+	assert.NoError(t, err)
+	assert.Equal(t, expect, buff.String())
+}
+
+func TestVarsFileSetVarsErrors(t *testing.T) {
+	tests := []struct {
+		desc      string
+		varsFile  *VarsFile
+		vars      map[string]interface{}
+		vaultKeys []string
+		err       string
+	}{
+		{
+			desc:      "Testing error vaulting an undefined variable",
+			varsFile:  NewVarsFile(WithVarsFileEncrypter(encrypt.NewMockEncryptString())),
+			vars:      map[string]interface{}{"key": "value"},
+			vaultKeys: []string{"undefined"},
+			err:       "Variable 'undefined' can not be vaulted because it is not defined.",
+		},
+		{
+			desc:      "Testing error vaulting a variable that is not a string",
+			varsFile:  NewVarsFile(WithVarsFileEncrypter(encrypt.NewMockEncryptString())),
+			vars:      map[string]interface{}{"key": 10},
+			vaultKeys: []string{"key"},
+			err:       "Variable 'key' can not be vaulted because it is not a string.",
+		},
+		{
+			desc:      "Testing error vaulting a variable without encrypter",
+			varsFile:  NewVarsFile(),
+			vars:      map[string]interface{}{"key": "value"},
+			vaultKeys: []string{"key"},
+			err:       "Encrypter must be provided to encrypt a variable.",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Log(test.desc)
+
+			err := test.varsFile.SetVars(test.vars, test.vaultKeys...)
+			assert.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func TestVarsFileReadWriteFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "all.yml")
+
+	varsFile := NewVarsFile()
+	err := varsFile.Set("key", "value")
+	assert.NoError(t, err)
+
+	err = varsFile.WriteFile(file)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "---\nkey: value\n", string(content))
+
+	res, err := ReadVarsFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key"}, res.Keys())
+
+	_, err = ReadVarsFile(filepath.Join(t.TempDir(), "missing.yml"))
+	assert.Error(t, err)
+}
+
+func TestVarsFileWriteUnchanged(t *testing.T) {
+	varsFile, err := ParseVarsFile(strings.NewReader(varsFileContent))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buff := &bytes.Buffer{}
+	err = varsFile.Write(buff)
+	assert.NoError(t, err)
+	assert.Equal(t, varsFileContent, buff.String())
+}